@@ -0,0 +1,145 @@
+"""
+Workforce AI Transition Simulator - Python client
+
+Generated by `wfesim openapi -python` from the HTTP API of the server; do not edit.
+Requires only the Python standard library.
+
+Usage:
+    from wfesim_client import Client
+
+    client = Client("http://localhost:8080", api_key="...")
+    report = client.run_simulation(scenario="hiring-freeze", seed=42)
+    print(report["Summary"])
+"""
+
+import json
+import time
+import urllib.error
+import urllib.parse
+import urllib.request
+
+
+class WfesimError(Exception):
+    """An error response of the server."""
+
+    def __init__(self, status, message):
+        super().__init__(f"{status}: {message}")
+        self.status = status
+        self.message = message
+
+
+class JobFailed(Exception):
+    """A job that did not succeed."""
+
+    def __init__(self, job):
+        super().__init__(f"job {job['ID']} {job['Status']}: {job.get('Error', '')}")
+        self.job = job
+
+
+def _quote(value):
+    return urllib.parse.quote(str(value), safe="")
+
+
+class Client:
+    """Client of a wfesim server.
+
+    api_key authenticates requests to a server run with -keys; without keys, user names the
+    user jobs are submitted as.
+    """
+
+    def __init__(self, base_url="http://localhost:8080", api_key=None, user=None, timeout=60):
+        self.base_url = base_url.rstrip("/")
+        self.api_key = api_key
+        self.user = user
+        self.timeout = timeout
+
+    def _request(self, method, path, query=None, body=None):
+        url = self.base_url + path
+        query = {k: v for k, v in (query or {}).items() if v is not None}
+        if query:
+            url += "?" + urllib.parse.urlencode(query)
+        headers = {"Accept": "application/json"}
+        data = None
+        if body is not None:
+            data = json.dumps({k: v for k, v in body.items() if v is not None}).encode()
+            headers["Content-Type"] = "application/json"
+        if self.api_key:
+            headers["X-Api-Key"] = self.api_key
+        if self.user:
+            headers["X-Wfesim-User"] = self.user
+        request = urllib.request.Request(url, data=data, headers=headers, method=method)
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                return json.load(response)
+        except urllib.error.HTTPError as e:
+            raise WfesimError(e.code, e.read().decode(errors="replace").strip()) from None
+
+    def submit_job(self, kind=None, priority=None, scenario=None, config=None, seed=None, max_time_steps=None, parameter_ranges=None):
+        """Queue a simulation or sensitivity analysis."""
+        return self._request(
+            "POST",
+            "/v1/jobs",
+            body={
+                "Kind": kind,
+                "Priority": priority,
+                "Scenario": scenario,
+                "Config": config,
+                "Seed": seed,
+                "MaxTimeSteps": max_time_steps,
+                "ParameterRanges": parameter_ranges,
+            },
+        )
+
+    def list_jobs(self, user=None, status=None):
+        """List jobs."""
+        return self._request(
+            "GET",
+            "/v1/jobs",
+            query={"user": user, "status": status},
+        )
+
+    def get_job(self, id):
+        """Describe a job."""
+        return self._request(
+            "GET",
+            f"/v1/jobs/{_quote(id)}",
+        )
+
+    def get_job_result(self, id):
+        """Report of a succeeded job: a simulation report or a sensitivity report."""
+        return self._request(
+            "GET",
+            f"/v1/jobs/{_quote(id)}/result",
+        )
+
+    def cancel_job(self, id):
+        """Cancel a queued or running job."""
+        return self._request(
+            "DELETE",
+            f"/v1/jobs/{_quote(id)}",
+        )
+
+    def wait(self, job_id, poll_interval=1.0, timeout=None):
+        """Poll a job until it finishes, returning it; raise JobFailed unless it succeeded."""
+        deadline = None if timeout is None else time.monotonic() + timeout
+        while True:
+            job = self.get_job(job_id)
+            if job["Status"] not in ("queued", "running"):
+                if job["Status"] != "succeeded":
+                    raise JobFailed(job)
+                return job
+            if deadline is not None and time.monotonic() > deadline:
+                raise TimeoutError(f"job {job_id} did not finish within {timeout}s")
+            time.sleep(poll_interval)
+
+    def run_simulation(self, scenario=None, config=None, seed=0, max_time_steps=None, priority=None, poll_interval=1.0, timeout=None):
+        """Run a simulation of a scenario or configuration dict, returning its report."""
+        job = self.submit_job(kind="simulation", scenario=scenario, config=config, seed=seed, max_time_steps=max_time_steps, priority=priority)
+        self.wait(job["ID"], poll_interval, timeout)
+        return self.get_job_result(job["ID"])
+
+    def run_sensitivity(self, parameter_ranges, scenario=None, config=None, seed=0, max_time_steps=None, priority=None, poll_interval=1.0, timeout=None):
+        """Run a sensitivity analysis varying parameter_ranges, returning its report."""
+        job = self.submit_job(kind="sensitivity", scenario=scenario, config=config, seed=seed, max_time_steps=max_time_steps, priority=priority, parameter_ranges=parameter_ranges)
+        self.wait(job["ID"], poll_interval, timeout)
+        return self.get_job_result(job["ID"])
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// runConfig dispatches the config subcommands: validate and schema
+func runConfig(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: wfesim config validate|schema [options]")
+	}
+
+	switch args[0] {
+	case "validate":
+		return runConfigValidate(args[1:], stdout)
+	case "schema":
+		return runConfigSchema(args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown config subcommand %q (expected validate or schema)", args[0])
+	}
+}
+
+// fileDiagnostics holds the validation outcome of one configuration file
+type fileDiagnostics struct {
+	Path        string
+	Error       string `json:",omitempty"`
+	Diagnostics []types.ConfigError
+	Warnings    []string `json:",omitempty"`
+}
+
+// runConfigValidate checks configuration files and reports every constraint violation
+// Returns an error if any file is unreadable or invalid, so the exit status can gate CI
+func runConfigValidate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim config validate", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Print machine-readable diagnostics as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: wfesim config validate [-json] FILE...")
+	}
+
+	results := make([]fileDiagnostics, 0, fs.NArg())
+	invalid := 0
+	for _, path := range fs.Args() {
+		result := fileDiagnostics{Path: path, Diagnostics: []types.ConfigError{}}
+		diagnostics, warnings, err := config.Diagnose(path)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Diagnostics = diagnostics
+			result.Warnings = warnings
+		}
+		if result.Error != "" || len(result.Diagnostics) > 0 {
+			invalid++
+		}
+		results = append(results, result)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return fmt.Errorf("failed to encode diagnostics: %w", err)
+		}
+	} else {
+		for _, result := range results {
+			for _, warning := range result.Warnings {
+				fmt.Fprintf(stdout, "%s: warning: %s\n", result.Path, warning)
+			}
+			switch {
+			case result.Error != "":
+				fmt.Fprintf(stdout, "%s: %s\n", result.Path, result.Error)
+			case len(result.Diagnostics) == 0:
+				fmt.Fprintf(stdout, "%s: ok\n", result.Path)
+			default:
+				for _, diagnostic := range result.Diagnostics {
+					fmt.Fprintf(stdout, "%s: %s\n", result.Path, diagnostic.Error())
+				}
+			}
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d configuration files are invalid", invalid, len(results))
+	}
+	return nil
+}
+
+// runConfigSchema writes the JSON Schema of configuration files
+func runConfigSchema(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim config schema", flag.ContinueOnError)
+	outputPath := fs.String("output", "", "Write the schema to this file instead of standard output")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	schema, err := config.JSONSchema()
+	if err != nil {
+		return err
+	}
+
+	if *outputPath == "" {
+		_, err := stdout.Write(schema)
+		return err
+	}
+	return writeFile(*outputPath, func(f *os.File) error {
+		_, err := f.Write(schema)
+		return err
+	})
+}
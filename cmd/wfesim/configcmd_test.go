@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("InitialHumans: 0\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runConfig([]string{"validate", filepath.Join("..", "..", "example_config.yaml")}, &out); err != nil {
+		t.Fatalf("Expected the example configuration to be valid: %v\n%s", err, out.String())
+	}
+
+	out.Reset()
+	if err := runConfig([]string{"validate", "-json", invalid}, &out); err == nil {
+		t.Fatal("Expected validation of an invalid configuration to fail")
+	}
+	var results []fileDiagnostics
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("Expected JSON diagnostics: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 || len(results[0].Diagnostics) == 0 {
+		t.Fatalf("Expected diagnostics for the invalid file, got %+v", results)
+	}
+	if results[0].Diagnostics[0].Field != "InitialHumans" {
+		t.Errorf("Expected the first diagnostic to name InitialHumans, got %s", results[0].Diagnostics[0].Field)
+	}
+	if len(results[0].Warnings) != 1 {
+		t.Errorf("Expected a migration warning for the unversioned file, got %v", results[0].Warnings)
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := runConfig([]string{"schema"}, &out); err != nil {
+		t.Fatalf("config schema failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"InitialHumans"`) {
+		t.Error("Expected the schema to describe InitialHumans")
+	}
+}
@@ -0,0 +1,727 @@
+// Command wfesim runs workforce AI transition simulations and sensitivity analyses
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/charts"
+	"workforce-ai-transition-simulator/internal/compress"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/extension"
+	"workforce-ai-transition-simulator/internal/publish"
+	"workforce-ai-transition-simulator/internal/sink"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// options holds the parsed command line flags
+type options struct {
+	configPath   string
+	scenario     string
+	sensitivity  bool
+	outputDir    string
+	output       sink.Sink // where reports are written, opened from outputDir
+	seed         int64
+	maxTimeSteps int
+	runs         int
+	window       int
+	anomalyK     float64
+	forecast     int
+	forecastFit  string
+	phasePlot    bool
+	longCSV      bool
+	compression  compress.Format
+	arrow        bool
+	publishTo    string
+	pace         time.Duration
+	invariants   bool
+	cpuProfile   string
+	memProfile   string
+	
+	censoredPolicy  string
+	censoredPenalty float64
+	
+	checkpointPath string
+	warmStart      bool
+	sweepStore     string
+	coordinator    string
+	
+	pairwise      string
+	heatmapMetric string
+	
+	extensions        []string
+	controllerOptions []controller.Option // behaviors loaded from the extensions, and invariant checks
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line and executes the requested mode
+// A leading subcommand name selects an alternative mode; otherwise the arguments are flags
+// for a single simulation or sensitivity analysis
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "tui":
+			return runTUI(args[1:])
+		case "report":
+			return runReport(args[1:])
+		case "config":
+			return runConfig(args[1:], os.Stdout)
+		case "scenarios":
+			return runScenarios(args[1:], os.Stdout)
+		case "repl":
+			return runREPL(args[1:], os.Stdin, os.Stdout)
+		case "worker":
+			return runWorker(args[1:], os.Stdout)
+		case "serve":
+			return runServe(args[1:], os.Stdout)
+		case "openapi":
+			return runOpenAPI(args[1:], os.Stdout)
+		}
+	}
+
+	opts, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	stopProfiling, err := startProfiling(opts.cpuProfile, opts.memProfile)
+	if err != nil {
+		return err
+	}
+	defer stopProfiling()
+
+	simConfig, err := loadSimulationConfig(opts.configPath, opts.scenario)
+	if err != nil {
+		return err
+	}
+
+	opts.output, err = sink.Open(opts.outputDir)
+	if err != nil {
+		return err
+	}
+
+	opts.controllerOptions, err = extension.Options(opts.extensions)
+	if err != nil {
+		return err
+	}
+	if opts.invariants {
+		opts.controllerOptions = append(opts.controllerOptions, controller.WithInvariantChecks())
+	}
+
+	if opts.sensitivity {
+		return runSensitivity(simConfig, opts)
+	}
+	return runSimulation(simConfig, opts)
+}
+
+// parseFlags parses the command line flags into options
+func parseFlags(args []string) (options, error) {
+	var opts options
+
+	fs := flag.NewFlagSet("wfesim", flag.ContinueOnError)
+	fs.StringVar(&opts.configPath, "config", "example_config.yaml", "Path to configuration file (JSON or YAML)")
+	fs.StringVar(&opts.scenario, "scenario", "", "Use the named scenario template instead of a configuration file (see wfesim scenarios)")
+	fs.BoolVar(&opts.sensitivity, "sensitivity", false, "Run sensitivity analysis instead of single simulation")
+	fs.StringVar(&opts.outputDir, "output", ".", "Output directory for reports, or an s3://bucket/prefix or gs://bucket/prefix URI to upload them to")
+	fs.Int64Var(&opts.seed, "seed", 42, "Random seed for reproducible runs")
+	fs.IntVar(&opts.maxTimeSteps, "max-steps", 500, "Maximum number of time steps per simulation")
+	fs.IntVar(&opts.runs, "runs", 1, "Number of Monte Carlo runs with consecutive seeds from -seed; with more than 1, revenue percentiles across the runs are reported")
+	fs.IntVar(&opts.window, "window", analytics.DefaultRollingWindow, "Number of trailing time steps aggregated by the rolling KPI columns of the CSV report")
+	fs.Float64Var(&opts.anomalyK, "anomaly-threshold", analytics.DefaultAnomalyThreshold, "Standard deviations from the rolling mean beyond which a metric value is reported as an anomaly")
+	fs.IntVar(&opts.forecast, "forecast", analytics.DefaultForecastHorizon, "Number of steps projected beyond the end of the run in the JSON report; 0 leaves the forecast out")
+	fs.StringVar(&opts.forecastFit, "forecast-method", "linear", "Trend fitted to the last -window steps for the forecast: linear or exponential")
+	fs.BoolVar(&opts.phasePlot, "phase-plot", false, "Also draw the humans vs AI agents trajectory as an SVG phase plot")
+	fs.BoolVar(&opts.longCSV, "long", false, "Also write the time series in long format, one RunID, TimeStep, Metric, Value row per step and metric")
+	fs.StringVar(&opts.cpuProfile, "cpuprofile", "", "Write a pprof CPU profile to this file")
+	fs.StringVar(&opts.memProfile, "memprofile", "", "Write a pprof heap profile to this file on exit")
+	fs.StringVar(&opts.censoredPolicy, "censored", "penalize", "How sensitivity statistics treat runs that never reach equilibrium: include, exclude or penalize")
+	fs.Float64Var(&opts.censoredPenalty, "censored-penalty", analytics.DefaultCensoredPenaltyFactor, "Time multiplier for censored runs when -censored=penalize")
+	fs.StringVar(&opts.checkpointPath, "checkpoint", "", "Checkpoint file: written at the end of a single simulation, read by -warm-start")
+	fs.StringVar(&opts.sweepStore, "sweep-store", "", "Record sensitivity runs in this file as they complete and skip runs it already holds, to resume an interrupted sweep")
+	fs.StringVar(&opts.coordinator, "coordinator", "", "Listen on this address (e.g. :7070) for wfesim worker processes and run the sensitivity runs on them instead of locally")
+	fs.BoolVar(&opts.warmStart, "warm-start", false, "Start every sensitivity run from an equilibrium state (from -checkpoint, or the baseline run) instead of from scratch")
+	fs.StringVar(&opts.pairwise, "pairwise", "", "Run every combination of two comma-separated sensitivity parameters (e.g. NaturalAttritionRate,MidToSenior) and write a heatmap")
+	fs.StringVar(&opts.heatmapMetric, "heatmap-metric", "TimeToEquilibrium", "Outcome metric shown in the -pairwise heatmap: "+strings.Join(analytics.HeatmapMetrics(), ", "))
+	fs.BoolVar(&opts.arrow, "arrow", false, "Also write the time series and run tables as Apache Arrow IPC files (.arrow), which are never compressed so they can be memory-mapped")
+	fs.Func("compress", "Compress the JSON and CSV reports: none, gzip or zstd; compressed files get a .gz or .zst suffix", func(name string) error {
+		format, err := compress.ParseFormat(name)
+		opts.compression = format
+		return err
+	})
+	fs.StringVar(&opts.publishTo, "publish", "", "Publish every state, event and alert of the simulation as JSON to kafka://broker:9092/topic or nats://host:4222/subject as it runs")
+	fs.DurationVar(&opts.pace, "pace", 0, "Space the time steps of a single simulation at least this far apart (e.g. 500ms), so a run published with -publish advances at a watchable rate")
+	fs.BoolVar(&opts.invariants, "check-invariants", false, "Check the invariants of the model after every time step, failing on the first violation; slower, for debugging model changes")
+	fs.Func("extension", "Load custom attrition, revenue or optimization behaviors from this Go plugin; repeat to load several, later ones taking precedence", func(path string) error {
+		opts.extensions = append(opts.extensions, path)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+	if err := checkConfigSource(fs); err != nil {
+		return options{}, err
+	}
+
+	if opts.maxTimeSteps <= 0 {
+		return options{}, fmt.Errorf("max-steps must be greater than 0, got %d", opts.maxTimeSteps)
+	}
+	if opts.window <= 0 {
+		return options{}, fmt.Errorf("window must be greater than 0, got %d", opts.window)
+	}
+	if opts.anomalyK <= 0 {
+		return options{}, fmt.Errorf("anomaly-threshold must be greater than 0, got %g", opts.anomalyK)
+	}
+	if opts.forecast < 0 {
+		return options{}, fmt.Errorf("forecast must not be negative, got %d", opts.forecast)
+	}
+	if opts.runs <= 0 {
+		return options{}, fmt.Errorf("runs must be greater than 0, got %d", opts.runs)
+	}
+	if opts.runs > 1 && opts.sensitivity {
+		return options{}, fmt.Errorf("-runs cannot be combined with -sensitivity")
+	}
+	if opts.warmStart && !opts.sensitivity {
+		return options{}, fmt.Errorf("-warm-start requires -sensitivity")
+	}
+	if opts.pairwise != "" && !opts.sensitivity {
+		return options{}, fmt.Errorf("-pairwise requires -sensitivity")
+	}
+	if opts.coordinator != "" && !opts.sensitivity {
+		return options{}, fmt.Errorf("-coordinator requires -sensitivity")
+	}
+	if opts.pace < 0 {
+		return options{}, fmt.Errorf("pace must not be negative, got %s", opts.pace)
+	}
+	if opts.pace > 0 && opts.sensitivity {
+		return options{}, fmt.Errorf("-pace cannot be combined with -sensitivity")
+	}
+
+	return opts, nil
+}
+
+// runSimulation executes a single simulation and writes JSON and CSV reports
+func runSimulation(simConfig types.SimulationConfig, opts options) error {
+	engine := analytics.NewAnalyticsEngine()
+	engine.SetControllerOptions(opts.controllerOptions...)
+	if err := engine.SetRollingWindow(opts.window); err != nil {
+		return err
+	}
+	if err := engine.SetAnomalyThreshold(opts.anomalyK); err != nil {
+		return err
+	}
+	method, err := analytics.ParseForecastMethod(opts.forecastFit)
+	if err != nil {
+		return err
+	}
+	if err := engine.SetForecast(method, opts.forecast); err != nil {
+		return err
+	}
+
+	stamp := time.Now().Format("20060102_150405")
+	base := "simulation_report_" + stamp
+
+	// The CSV reports are written as the simulation runs rather than from its result
+	simController := controller.NewSimulationController(simConfig, opts.seed, append(opts.controllerOptions, controller.WithPacing(opts.pace))...)
+	if err := simController.Initialize(); err != nil {
+		return fmt.Errorf("simulation failed: initialization failed: %w", err)
+	}
+	streams, err := streamCSVReports(opts.output, simController, engine, base, opts.longCSV, opts.compression)
+	if err != nil {
+		return err
+	}
+	var published *publish.Stream
+	if opts.publishTo != "" {
+		publisher, err := publish.Open(opts.publishTo)
+		if err != nil {
+			closeCSVStreams(streams)
+			return err
+		}
+		published = publish.NewStream(publisher, simController.RunMetadata().RunID)
+		for _, state := range simController.GetTimeSeries() {
+			published.OnStep(state, nil)
+		}
+		simController.Observe(published)
+	}
+	result, err := simController.RunUntilEquilibrium(opts.maxTimeSteps)
+	closeErr := closeCSVStreams(streams)
+	if published != nil {
+		if publishErr := published.Close(); closeErr == nil {
+			closeErr = publishErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	engine.RecordSimulationResult(result)
+
+	if opts.arrow {
+		if err := writeReport(opts.output, base+".arrow", compress.None, func(f io.Writer) error { return engine.WriteReportArrow(result, f) }); err != nil {
+			return err
+		}
+	}
+	if err := writeReport(opts.output, base+".json", opts.compression, func(f io.Writer) error { return engine.WriteReportJSON(result, f) }); err != nil {
+		return err
+	}
+	correlations := engine.CorrelationMatrix()
+	if err := writeReport(opts.output, base+"_correlations.csv", opts.compression, func(f io.Writer) error { return analytics.WriteCorrelationCSV(correlations, f) }); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, base+"_correlations.json", opts.compression, func(f io.Writer) error { return analytics.WriteCorrelationJSON(correlations, f) }); err != nil {
+		return err
+	}
+	trajectory := analytics.PhaseSpace(result.TimeSeries)
+	if err := writeReport(opts.output, base+"_phase_space.csv", opts.compression, func(f io.Writer) error { return analytics.WritePhaseSpaceCSV(trajectory, f) }); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, base+"_phase_space.json", opts.compression, func(f io.Writer) error { return analytics.WritePhaseSpaceJSON(trajectory, f) }); err != nil {
+		return err
+	}
+	if opts.phasePlot {
+		if err := writeReport(opts.output, base+"_phase_space.svg", compress.None, func(f io.Writer) error { return charts.NewPhasePlot(result.TimeSeries).RenderSVG(f) }); err != nil {
+			return err
+		}
+	}
+	summary := engine.GenerateMarkdownSummary(result)
+	var sharedLearning *analytics.CounterfactualComparison
+	if simConfig.SharedLearning.Acceleration > 0 {
+		comparison, err := engine.CompareSharedLearning(simConfig, opts.seed, opts.maxTimeSteps)
+		if err != nil {
+			return fmt.Errorf("shared learning comparison failed: %w", err)
+		}
+		sharedLearning = &comparison
+		summary += "\n" + engine.GenerateSharedLearningSummary(comparison)
+	}
+	var monteCarlo *analytics.MonteCarloResult
+	if opts.runs > 1 {
+		spread, err := engine.RunMonteCarlo(simConfig, opts.runs, opts.seed, opts.maxTimeSteps)
+		if err != nil {
+			return err
+		}
+		monteCarlo = &spread
+		summary += "\n" + engine.GenerateMonteCarloSummary(spread)
+		if err := writeReport(opts.output, base+"_monte_carlo.csv", opts.compression, func(f io.Writer) error { return engine.WriteMonteCarloCSV(spread, f) }); err != nil {
+			return err
+		}
+		if err := writeReport(opts.output, base+"_runs.csv", opts.compression, func(f io.Writer) error { return analytics.WriteRunSummariesCSV(spread.RunSummaries, f) }); err != nil {
+			return err
+		}
+		if opts.arrow {
+			if err := writeReport(opts.output, base+"_runs.arrow", compress.None, func(f io.Writer) error { return analytics.WriteRunSummariesArrow(spread.RunSummaries, f) }); err != nil {
+				return err
+			}
+		}
+	}
+	var resilience *analytics.PerturbationResilience
+	if checkpoint, err := simController.Checkpoint(); err == nil && checkpoint.EquilibriumReached {
+		scored, err := engine.PerturbationResilience(checkpoint, opts.maxTimeSteps)
+		if err != nil {
+			return fmt.Errorf("perturbation resilience failed: %w", err)
+		}
+		resilience = &scored
+		summary += "\n" + engine.GeneratePerturbationSummary(scored)
+	}
+	if err := writeReport(opts.output, base+".md", compress.None, func(f io.Writer) error {
+		_, err := io.WriteString(f, summary)
+		return err
+	}); err != nil {
+		return err
+	}
+	if opts.checkpointPath != "" {
+		checkpoint, err := simController.Checkpoint()
+		if err != nil {
+			return err
+		}
+		if err := writeFile(opts.checkpointPath, func(f *os.File) error { return controller.WriteCheckpoint(checkpoint, f) }); err != nil {
+			return err
+		}
+		fmt.Printf("Checkpoint written to %s\n", opts.checkpointPath)
+	}
+
+	location := opts.output.Location(base)
+	fmt.Printf("Simulation completed in %d time steps (equilibrium: %t)\n", result.TimeToEquilibrium, result.EquilibriumState.IsEquilibrium)
+	if result.Insolvent {
+		fmt.Printf("Insolvent: %s\n", result.EquilibriumReason.Message)
+	} else if result.Status.Terminal() {
+		fmt.Printf("Terminal state: %s\n", result.EquilibriumReason.Message)
+	}
+	fmt.Printf("Run ID: %s\n", result.Metadata.RunID)
+	fmt.Printf("Final workforce: %d humans, %d AI agents\n",
+		result.EquilibriumState.Workforce.Humans.Total,
+		result.EquilibriumState.Workforce.AIAgents.Total)
+	if sharedLearning != nil {
+		fmt.Printf("Shared learning: %d time steps to equilibrium, %d without it\n",
+			sharedLearning.Modified.TimeToEquilibrium, sharedLearning.Baseline.TimeToEquilibrium)
+	}
+	if resilience != nil {
+		fmt.Printf("Perturbation resilience score: %.2f\n", resilience.Score)
+	}
+	if monteCarlo != nil {
+		fmt.Printf("Total revenue over %d runs: P10 %.0f, P50 %.0f, P90 %.0f\n", monteCarlo.Runs,
+			monteCarlo.TotalRevenue.P10, monteCarlo.TotalRevenue.P50, monteCarlo.TotalRevenue.P90)
+		fmt.Printf("Mean total revenue: %.0f (95%% CI %.0f to %.0f)\n", monteCarlo.Confidence.TotalRevenue.Mean,
+			monteCarlo.Confidence.TotalRevenue.Lower, monteCarlo.Confidence.TotalRevenue.Upper)
+		if len(monteCarlo.Attractors) > 1 {
+			fmt.Printf("Runs settled into %d distinct equilibria; see the summary\n", len(monteCarlo.Attractors))
+		}
+		fmt.Printf("Revenue percentiles written to %s_monte_carlo.csv%s\n", location, opts.compression.Extension())
+	}
+	ext := opts.compression.Extension()
+	fmt.Printf("Reports written to %s.{json%s,csv%s,md}\n", location, ext, ext)
+	fmt.Printf("Metric correlations written to %s_correlations.{csv,json}%s\n", location, ext)
+	if opts.phasePlot {
+		fmt.Printf("Phase space trajectory written to %s_phase_space.{csv%s,json%s,svg}\n", location, ext, ext)
+	} else {
+		fmt.Printf("Phase space trajectory written to %s_phase_space.{csv,json}%s\n", location, ext)
+	}
+	if opts.publishTo != "" {
+		fmt.Println("States, events and alerts published as the simulation ran")
+	}
+
+	return nil
+}
+
+// runSensitivity executes a sensitivity analysis around the base configuration and writes reports
+func runSensitivity(simConfig types.SimulationConfig, opts options) error {
+	policy, err := analytics.ParseCensoredRunPolicy(opts.censoredPolicy)
+	if err != nil {
+		return err
+	}
+	
+	engine := analytics.NewAnalyticsEngine()
+	engine.SetControllerOptions(opts.controllerOptions...)
+	if err := engine.SetCensoredRunPolicy(policy, opts.censoredPenalty); err != nil {
+		return err
+	}
+	
+	var sensitivityOpts analytics.SensitivityOptions
+	if opts.warmStart {
+		checkpoint, err := loadWarmStart(simConfig, opts)
+		if err != nil {
+			return err
+		}
+		sensitivityOpts.WarmStart = checkpoint
+	}
+	if opts.sweepStore != "" {
+		resultStore, err := store.OpenJSONLStore(opts.sweepStore, store.Header{
+			ConfigHash:   simConfig.Hash(),
+			MaxTimeSteps: opts.maxTimeSteps,
+			WarmStart:    opts.warmStart,
+		})
+		if err != nil {
+			return err
+		}
+		defer resultStore.Close()
+		if completed := resultStore.Len(); completed > 0 {
+			fmt.Printf("Resuming sweep: %d completed runs found in %s\n", completed, opts.sweepStore)
+		}
+		sensitivityOpts.Store = resultStore
+	}
+	if opts.coordinator != "" {
+		stop, err := serveCoordinator(opts.coordinator, &sensitivityOpts)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	
+	if opts.pairwise != "" {
+		return runPairwiseSensitivity(engine, simConfig, opts, sensitivityOpts)
+	}
+	
+	results, err := engine.RunSensitivityAnalysisWithOptions(simConfig, defaultParameterRanges(simConfig), opts.maxTimeSteps, opts.seed, sensitivityOpts)
+	if err != nil {
+		return fmt.Errorf("sensitivity analysis failed: %w", err)
+	}
+
+	stamp := time.Now().Format("20060102_150405")
+
+	if err := writeReport(opts.output, "sensitivity_report_"+stamp+".json", opts.compression, func(f io.Writer) error {
+		return engine.WriteSensitivityReportJSON(results, f)
+	}); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, "sensitivity_detailed_"+stamp+".csv", opts.compression, func(f io.Writer) error {
+		return engine.WriteDetailedSensitivityCSV(results, f)
+	}); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, "sensitivity_rankings_"+stamp+".csv", opts.compression, func(f io.Writer) error {
+		return engine.WriteSensitivityReportCSV(results, f)
+	}); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, "sensitivity_runs_"+stamp+".csv", opts.compression, func(f io.Writer) error {
+		return analytics.WriteRunSummariesCSV(analytics.SensitivityRunSummaries(results), f)
+	}); err != nil {
+		return err
+	}
+	if opts.arrow {
+		if err := writeReport(opts.output, "sensitivity_detailed_"+stamp+".arrow", compress.None, func(f io.Writer) error {
+			return engine.WriteSensitivityArrow(results, f)
+		}); err != nil {
+			return err
+		}
+		if err := writeReport(opts.output, "sensitivity_runs_"+stamp+".arrow", compress.None, func(f io.Writer) error {
+			return analytics.WriteRunSummariesArrow(analytics.SensitivityRunSummaries(results), f)
+		}); err != nil {
+			return err
+		}
+	}
+
+	summary := engine.CalculateSensitivitySummary(results)
+	fmt.Printf("Sensitivity analysis completed for %d parameters\n", len(results))
+	fmt.Printf("Most impactful parameter: %s\n", summary.MostImpactfulParameter)
+	fmt.Printf("Least impactful parameter: %s\n", summary.LeastImpactfulParameter)
+	if summary.CensoredRuns > 0 {
+		fmt.Printf("%d runs did not reach equilibrium within %d steps (treatment: %s)\n",
+			summary.CensoredRuns, opts.maxTimeSteps, summary.CensoredRunPolicy)
+	}
+
+	return nil
+}
+
+// runPairwiseSensitivity runs every combination of the two -pairwise parameters over their
+// default ranges and writes the heatmap of the -heatmap-metric outcome as CSV and JSON
+func runPairwiseSensitivity(engine *analytics.AnalyticsEngine, simConfig types.SimulationConfig, opts options, sensitivityOpts analytics.SensitivityOptions) error {
+	names := strings.Split(opts.pairwise, ",")
+	if len(names) != 2 {
+		return fmt.Errorf("-pairwise expects two comma-separated parameters, got %q", opts.pairwise)
+	}
+	
+	ranges := defaultParameterRanges(simConfig)
+	values := make([][]float64, 2)
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		v, exists := ranges.Values(names[i])
+		if !exists || len(v) == 0 {
+			return fmt.Errorf("unknown or fixed sensitivity parameter %q (expected one of %s)",
+				names[i], strings.Join(analytics.SensitivityParameterNames(), ", "))
+		}
+		values[i] = v
+	}
+	
+	// Check the metric before running the grid rather than after
+	if _, err := engine.GenerateHeatmap(analytics.PairwiseResults{}, opts.heatmapMetric); err != nil {
+		return err
+	}
+	
+	results, err := engine.RunPairwiseSensitivity(simConfig, names[0], values[0], names[1], values[1], opts.maxTimeSteps, opts.seed, sensitivityOpts)
+	if err != nil {
+		return err
+	}
+	heatmap, err := engine.GenerateHeatmap(results, opts.heatmapMetric)
+	if err != nil {
+		return err
+	}
+	
+	stamp := time.Now().Format("20060102_150405")
+	base := "sensitivity_heatmap_" + stamp
+	
+	if err := writeReport(opts.output, base+".csv", opts.compression, func(f io.Writer) error { return analytics.WriteHeatmapCSV(heatmap, f) }); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, base+".json", opts.compression, func(f io.Writer) error { return analytics.WriteHeatmapJSON(heatmap, f) }); err != nil {
+		return err
+	}
+	if err := writeReport(opts.output, base+"_runs.csv", opts.compression, func(f io.Writer) error {
+		return analytics.WriteRunSummariesCSV(analytics.PairwiseRunSummaries(results), f)
+	}); err != nil {
+		return err
+	}
+	if opts.arrow {
+		if err := writeReport(opts.output, base+"_runs.arrow", compress.None, func(f io.Writer) error {
+			return analytics.WriteRunSummariesArrow(analytics.PairwiseRunSummaries(results), f)
+		}); err != nil {
+			return err
+		}
+	}
+	
+	fmt.Printf("Pairwise sensitivity completed: %d x %d grid of %s by %s\n", len(values[0]), len(values[1]), names[0], names[1])
+	location, ext := opts.output.Location(base), opts.compression.Extension()
+	fmt.Printf("Heatmap of %s written to %s.csv%s and %s.json%s\n", opts.heatmapMetric, location, ext, location, ext)
+	return nil
+}
+
+// loadWarmStart reads the warm-start checkpoint, or runs the baseline to equilibrium if no
+// checkpoint file was given
+func loadWarmStart(simConfig types.SimulationConfig, opts options) (*controller.Checkpoint, error) {
+	if opts.checkpointPath == "" {
+		return analytics.BaselineCheckpoint(simConfig, opts.maxTimeSteps, opts.seed, opts.controllerOptions...)
+	}
+	
+	f, err := os.Open(opts.checkpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	defer f.Close()
+	
+	checkpoint, warnings, err := controller.ReadCheckpoint(f)
+	if err != nil {
+		return nil, err
+	}
+	printWarnings(opts.checkpointPath, warnings)
+	return &checkpoint, nil
+}
+
+// defaultParameterRanges varies each parameter around its base value
+// The budget is only scaled upwards because the initial workforce must fit within it
+func defaultParameterRanges(base types.SimulationConfig) analytics.ParameterRanges {
+	scales := []float64{0.5, 0.75, 1.0, 1.25, 1.5}
+
+	budgets := make([]float64, 0, len(scales))
+	for _, s := range scales {
+		budgets = append(budgets, base.FixedBudget*(0.5+s))
+	}
+
+	scaleFloat := func(value float64, max float64) []float64 {
+		values := make([]float64, 0, len(scales))
+		for _, s := range scales {
+			v := value * s
+			if max > 0 && v > max {
+				v = max
+			}
+			values = append(values, v)
+		}
+		return values
+	}
+	scaleInt := func(value int) []int {
+		values := make([]int, 0, len(scales))
+		for _, s := range scales {
+			v := int(float64(value) * s)
+			if v < 1 {
+				v = 1
+			}
+			values = append(values, v)
+		}
+		return values
+	}
+
+	return analytics.ParameterRanges{
+		FixedBudget:             budgets,
+		CatastrophicFailureRate: scaleFloat(base.CatastrophicFailureRate, 1),
+		TimeZoneInefficiency:    scaleFloat(base.TimeZoneInefficiency, 1),
+		NaturalAttritionRate:    scaleFloat(base.AttritionConfig.NaturalRate, 100),
+		UniversityToMid:         scaleInt(base.AILearningSpeeds.UniversityToMid),
+		MidToSenior:             scaleInt(base.AILearningSpeeds.MidToSenior),
+		SeniorToExecutive:       scaleInt(base.AILearningSpeeds.SeniorToExecutive),
+	}
+}
+
+// csvStream is a CSV report written while the simulation runs
+type csvStream struct {
+	location string
+	output   io.WriteCloser
+	writer   io.WriteCloser // compresses into output
+	stream   *analytics.ReportCSVStream
+}
+
+// streamCSVReports starts writing the CSV report, and the long format report if long is set,
+// to reports named after base from the states the controller has recorded so far and, as its
+// observers, from every state it records after
+func streamCSVReports(out sink.Sink, simController *controller.SimulationController, engine *analytics.AnalyticsEngine, base string, long bool, format compress.Format) ([]*csvStream, error) {
+	names := []string{base + ".csv"}
+	newStreams := []func(io.Writer, types.RunMetadata) *analytics.ReportCSVStream{engine.NewReportCSVStream}
+	if long {
+		names = append(names, base+"_long.csv")
+		newStreams = append(newStreams, engine.NewLongCSVStream)
+	}
+
+	var streams []*csvStream
+	for i, name := range names {
+		name += format.Extension()
+		output, err := out.Create(name)
+		if err != nil {
+			closeCSVStreams(streams)
+			return nil, err
+		}
+		w, err := compress.NewWriter(output, format)
+		if err != nil {
+			sink.Abort(output)
+			closeCSVStreams(streams)
+			return nil, err
+		}
+		stream := newStreams[i](w, simController.RunMetadata())
+		streams = append(streams, &csvStream{location: out.Location(name), output: output, writer: w, stream: stream})
+		for _, state := range simController.GetTimeSeries() {
+			stream.Write(state)
+		}
+		simController.Observe(stream)
+	}
+	return streams, nil
+}
+
+// closeCSVStreams flushes and closes CSV reports, returning the first error met while writing or
+// closing any of them
+func closeCSVStreams(streams []*csvStream) error {
+	var first error
+	for _, s := range streams {
+		err := s.stream.Flush()
+		if closeErr := s.writer.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to compress %s: %w", s.location, closeErr)
+		}
+		if err != nil {
+			sink.Abort(s.output)
+		} else {
+			err = s.output.Close()
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// writeReport writes the named report to out, compressed in the given format with the format's
+// extension added to the name
+func writeReport(out sink.Sink, name string, format compress.Format, write func(w io.Writer) error) error {
+	name += format.Extension()
+	output, err := out.Create(name)
+	if err != nil {
+		return err
+	}
+	w, err := compress.NewWriter(output, format)
+	if err == nil {
+		err = write(w)
+		if closeErr := w.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to compress %s: %w", out.Location(name), closeErr)
+		}
+	}
+	if err != nil {
+		sink.Abort(output)
+		return err
+	}
+	return output.Close()
+}
+
+// writeFile creates a file and passes it to the write function, closing it afterwards
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
+}
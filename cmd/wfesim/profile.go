@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiling starts CPU profiling if cpuPath is set and arranges for a heap profile
+// to be written to memPath when the returned stop function is called
+func startProfiling(cpuPath string, memPath string) (func(), error) {
+	var cpuFile *os.File
+
+	if cpuPath != "" {
+		f, err := os.Create(cpuPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		cpuFile = f
+	}
+
+	stop := func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+		}
+
+		if memPath != "" {
+			f, err := os.Create(memPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to create memory profile: %v\n", err)
+				return
+			}
+			defer f.Close()
+
+			// Run a GC so the heap profile reflects live objects only
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write memory profile: %v\n", err)
+			}
+		}
+	}
+
+	return stop, nil
+}
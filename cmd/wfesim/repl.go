@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/extension"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// replPrompt is printed before every command read by the REPL
+const replPrompt = "wfesim> "
+
+// replHelp describes the commands accepted by the REPL
+const replHelp = `Commands:
+  step [N]                advance the simulation by N time steps (default 1)
+  show workforce          humans and AI agents by experience level
+  show budget             cost, budget, revenue and cash of the current step
+  show events [N]         the last N events (default 10)
+  show config             the current configuration as JSON
+  show decisions          the optimizer decisions reviewed so far
+  set PARAMETER VALUE     change a parameter and continue the run with it
+  review on|off           ask for approval of every workforce change the optimizer proposes
+  decisions FILE          write the reviewed optimizer decisions as CSV
+  checkpoint FILE         write the current state as a checkpoint
+  help                    show this message
+  quit                    leave the REPL
+`
+
+// runREPL loads a configuration and reads commands that step and inspect the simulation
+// interactively, until quit or the end of the input
+func runREPL(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim repl", flag.ContinueOnError)
+	configPath := fs.String("config", "example_config.yaml", "Path to configuration file (JSON or YAML)")
+	scenario := fs.String("scenario", "", "Use the named scenario template instead of a configuration file (see wfesim scenarios)")
+	seed := fs.Int64("seed", 42, "Random seed for reproducible runs")
+	var extensions []string
+	fs.Func("extension", "Load custom behaviors from this Go plugin; repeat to load several", func(path string) error {
+		extensions = append(extensions, path)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkConfigSource(fs); err != nil {
+		return err
+	}
+
+	simConfig, err := loadSimulationConfig(*configPath, *scenario)
+	if err != nil {
+		return err
+	}
+
+	extensionOptions, err := extension.Options(extensions)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	session, err := newREPLSession(simConfig, *seed, extensionOptions, scanner, stdout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, "Workforce AI Transition Simulator. Type help for the list of commands.")
+	session.printStatus()
+
+	for {
+		fmt.Fprint(stdout, replPrompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(stdout)
+			return scanner.Err()
+		}
+		quit, err := session.execute(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(stdout, "Error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// replSession is the simulation explored by a REPL
+type replSession struct {
+	controller *controller.SimulationController
+	seed       int64
+	reviewing  bool                // optimizer decisions are held for the user's approval
+	extensions []controller.Option // behaviors loaded from extension plugins
+	in         *bufio.Scanner
+	out        io.Writer
+}
+
+// newREPLSession initializes a simulation of the given configuration, with the given extension
+// behaviors, for the REPL, which reads commands and decision reviews from in
+func newREPLSession(simConfig types.SimulationConfig, seed int64, extensions []controller.Option, in *bufio.Scanner, out io.Writer) (*replSession, error) {
+	s := &replSession{seed: seed, extensions: extensions, in: in, out: out}
+	if err := s.start(simConfig); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// start initializes a new simulation of the given configuration
+func (s *replSession) start(simConfig types.SimulationConfig) error {
+	simController := controller.NewSimulationController(simConfig, s.seed, s.options()...)
+	if err := simController.Initialize(); err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+	s.controller = simController
+	return nil
+}
+
+// restore continues the current simulation under the given configuration, keeping its history
+func (s *replSession) restore(simConfig types.SimulationConfig) error {
+	checkpoint, err := s.controller.Checkpoint()
+	if err != nil {
+		return err
+	}
+	restored, err := controller.RestoreSimulationController(checkpoint, simConfig, s.options()...)
+	if err != nil {
+		return err
+	}
+	s.controller = restored
+	return nil
+}
+
+// options returns the controller options of the session's extensions and review mode
+func (s *replSession) options() []controller.Option {
+	options := append([]controller.Option(nil), s.extensions...)
+	if s.reviewing {
+		options = append(options, controller.WithDecisionApprover(s.approve))
+	}
+	return options
+}
+
+// execute runs one command line, returning true when the REPL should exit
+// Blank lines do nothing; errors describe a command that could not be run and leave the
+// simulation unchanged
+func (s *replSession) execute(line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	switch fields[0] {
+	case "quit", "exit":
+		return true, nil
+	case "help":
+		fmt.Fprint(s.out, replHelp)
+		return false, nil
+	case "step":
+		return false, s.step(fields[1:])
+	case "show":
+		return false, s.show(fields[1:])
+	case "set":
+		return false, s.set(fields[1:])
+	case "review":
+		return false, s.review(fields[1:])
+	case "decisions":
+		return false, s.writeDecisions(fields[1:])
+	case "checkpoint":
+		return false, s.checkpoint(fields[1:])
+	default:
+		return false, fmt.Errorf("unknown command %q (type help for the list of commands)", fields[0])
+	}
+}
+
+// step advances the simulation, stopping early if it becomes insolvent or terminal
+func (s *replSession) step(args []string) error {
+	steps := 1
+	if len(args) > 1 {
+		return fmt.Errorf("usage: step [N]")
+	}
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("step count must be a positive integer, got %q", args[0])
+		}
+		steps = n
+	}
+
+	for i := 0; i < steps; i++ {
+		if s.controller.IsInsolvent() || s.controller.IsTerminated() {
+			break
+		}
+		s.controller.Step()
+	}
+	s.printStatus()
+	return nil
+}
+
+// show prints one view of the current simulation state
+func (s *replSession) show(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: show workforce|budget|events|decisions|config")
+	}
+
+	state := s.currentState()
+	switch args[0] {
+	case "workforce":
+		humans := state.Workforce.Humans
+		agents := state.Workforce.AIAgents
+		fmt.Fprintf(s.out, "Humans     %4d   %s\n", humans.Total, formatLevelCounts(humans.ByExperience))
+		fmt.Fprintf(s.out, "AI agents  %4d   %s\n", agents.Total, formatLevelCounts(agents.ByExperience))
+		fmt.Fprintf(s.out, "Orchestration utilization %.1f%%, capacity for %d more AI agents\n",
+			state.Workforce.OrchestrationUtilization, state.AvailableOrchestrationCapacity)
+	case "budget":
+		simConfig := s.controller.GetConfig()
+		budgetUsed := 0.0
+		if simConfig.FixedBudget > 0 {
+			budgetUsed = state.TotalCost / simConfig.FixedBudget * 100.0
+		}
+		fmt.Fprintf(s.out, "Cost       %s of %s (%.1f%%), %s available\n",
+			formatMoney(state.TotalCost), formatMoney(simConfig.FixedBudget), budgetUsed, formatMoney(state.AvailableBudget))
+		fmt.Fprintf(s.out, "Revenue    %s   productivity %.1f\n", formatMoney(state.RevenueOutput), state.TotalProductivity)
+		if simConfig.Cash.Enabled {
+			fmt.Fprintf(s.out, "Cash       %s\n", formatMoney(state.CashBalance))
+		}
+	case "events":
+		count := 10
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("event count must be a positive integer, got %q", args[1])
+			}
+			count = n
+		}
+		events := s.controller.GetEvents()
+		if len(events) > count {
+			events = events[len(events)-count:]
+		}
+		if len(events) == 0 {
+			fmt.Fprintln(s.out, "(no events yet)")
+		}
+		for _, event := range events {
+			fmt.Fprintf(s.out, "[%4d] %-20s %s\n", event.TimeStep, event.Type, event.Description)
+		}
+	case "decisions":
+		decisions := s.controller.GetDecisions()
+		if len(decisions) == 0 {
+			fmt.Fprintln(s.out, "(no decisions reviewed yet)")
+		}
+		for _, decision := range decisions {
+			fmt.Fprintf(s.out, "[%4d] %-8s proposed hire %d, release %d; hired %d, released %d", decision.TimeStep, decision.Verdict,
+				decision.ProposedHires, len(decision.ProposedReleases), decision.Hires, len(decision.Releases))
+			if decision.Reason != "" {
+				fmt.Fprintf(s.out, " (%s)", decision.Reason)
+			}
+			fmt.Fprintln(s.out)
+		}
+	case "config":
+		encoder := json.NewEncoder(s.out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(s.controller.GetConfig()); err != nil {
+			return fmt.Errorf("failed to encode configuration: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown view %q (expected workforce, budget, events, decisions or config)", args[0])
+	}
+	return nil
+}
+
+// set changes a sensitivity parameter and continues the run under the new configuration
+// The history so far is kept: the simulation is checkpointed and restored with the change
+// InitialHumans only shapes the initial workforce, so it can only be set before the first step,
+// which starts the run again
+func (s *replSession) set(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: set PARAMETER VALUE (parameters: %s)", strings.Join(analytics.SensitivityParameterNames(), ", "))
+	}
+	value, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s", args[1], args[0])
+	}
+
+	simConfig := s.controller.GetConfig()
+	if err := analytics.SetParameter(&simConfig, args[0], value); err != nil {
+		return err
+	}
+
+	if args[0] == "InitialHumans" {
+		if len(s.controller.GetTimeSeries()) > 1 {
+			return fmt.Errorf("InitialHumans can only be set before the first step")
+		}
+		err = s.start(simConfig)
+	} else {
+		err = s.restore(simConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.out, "%s set to %s\n", args[0], strconv.FormatFloat(value, 'f', -1, 64))
+	return nil
+}
+
+// review turns the review of optimizer decisions on or off
+func (s *replSession) review(args []string) error {
+	if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+		return fmt.Errorf("usage: review on|off")
+	}
+	s.reviewing = args[0] == "on"
+	if err := s.restore(s.controller.GetConfig()); err != nil {
+		return err
+	}
+	if s.reviewing {
+		fmt.Fprintln(s.out, "Workforce changes proposed by the optimizer will be held for your approval")
+	} else {
+		fmt.Fprintln(s.out, "Workforce changes proposed by the optimizer will be executed as proposed")
+	}
+	return nil
+}
+
+// approve asks the user to approve, modify or reject a workforce change proposed by the optimizer
+// The rest of the answer's line is recorded as the reason; at the end of the input the change is
+// approved
+func (s *replSession) approve(timeStep int, proposal events.WorkforceChange) controller.DecisionReview {
+	fmt.Fprintf(s.out, "Step %d: the optimizer proposes hiring %d AI agents and releasing %d\n",
+		timeStep, proposal.HireAIAgents, len(proposal.ReleaseAIAgents))
+	for {
+		fmt.Fprint(s.out, "approve, reject or hire N (optionally followed by a reason)? ")
+		if !s.in.Scan() {
+			fmt.Fprintln(s.out)
+			return controller.DecisionReview{Verdict: types.DecisionApproved, Reason: "end of input"}
+		}
+		fields := strings.Fields(s.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "approve", "a":
+			return controller.DecisionReview{Verdict: types.DecisionApproved, Reason: strings.Join(fields[1:], " ")}
+		case "reject", "r":
+			return controller.DecisionReview{Verdict: types.DecisionRejected, Reason: strings.Join(fields[1:], " ")}
+		case "hire", "h":
+			if len(fields) < 2 {
+				break
+			}
+			hires, err := strconv.Atoi(fields[1])
+			if err != nil || hires < 0 {
+				fmt.Fprintf(s.out, "Error: hire count must be a non-negative integer, got %q\n", fields[1])
+				continue
+			}
+			if hires > 0 && proposal.OrchestratorID == "" {
+				fmt.Fprintln(s.out, "Error: the optimizer proposed no orchestrator for new AI agents")
+				continue
+			}
+			change := proposal
+			change.HireAIAgents = hires
+			if hires < len(change.Assignments) {
+				change.Assignments = change.Assignments[:hires]
+			}
+			return controller.DecisionReview{Verdict: types.DecisionModified, Change: change, Reason: strings.Join(fields[2:], " ")}
+		}
+		fmt.Fprintf(s.out, "Error: unknown answer %q\n", s.in.Text())
+	}
+}
+
+// writeDecisions writes the reviewed optimizer decisions to a file as CSV
+func (s *replSession) writeDecisions(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: decisions FILE")
+	}
+	if err := writeFile(args[0], func(f *os.File) error { return analytics.WriteDecisionsCSV(s.controller.GetDecisions(), f) }); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "%d decisions written to %s\n", len(s.controller.GetDecisions()), args[0])
+	return nil
+}
+
+// checkpoint writes the current simulation state to a file, which -warm-start can read
+func (s *replSession) checkpoint(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: checkpoint FILE")
+	}
+	checkpoint, err := s.controller.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(args[0], func(f *os.File) error { return controller.WriteCheckpoint(checkpoint, f) }); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "Checkpoint written to %s\n", args[0])
+	return nil
+}
+
+// printStatus prints a one-line summary of the current time step
+func (s *replSession) printStatus() {
+	state := s.currentState()
+	fmt.Fprintf(s.out, "Step %d: %d humans, %d AI agents, cost %s, revenue %s",
+		state.TimeStep, state.Workforce.Humans.Total, state.Workforce.AIAgents.Total,
+		formatMoney(state.TotalCost), formatMoney(state.RevenueOutput))
+	switch {
+	case s.controller.IsInsolvent():
+		fmt.Fprintf(s.out, " [INSOLVENT: %s]", state.EquilibriumReason.Message)
+	case s.controller.IsTerminated():
+		fmt.Fprintf(s.out, " [TERMINAL: %s]", state.EquilibriumReason.Message)
+	case state.IsEquilibrium:
+		fmt.Fprintf(s.out, " [EQUILIBRIUM: %s]", state.EquilibriumReason.Message)
+	}
+	fmt.Fprintln(s.out)
+}
+
+// currentState returns the most recently recorded simulation state
+func (s *replSession) currentState() types.SimulationState {
+	timeSeries := s.controller.GetTimeSeries()
+	return timeSeries[len(timeSeries)-1]
+}
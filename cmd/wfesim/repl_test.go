@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+)
+
+func TestREPLStepsAndInspectsRun(t *testing.T) {
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	script := strings.Join([]string{
+		"step 3",
+		"show workforce",
+		"set FixedBudget 2500000",
+		"show budget",
+		"set InitialHumans 10",
+		"step zero",
+		"bogus",
+		"checkpoint " + checkpointPath,
+		"quit",
+		"step",
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := runREPL([]string{"-scenario", "hiring-freeze"}, strings.NewReader(script), &out); err != nil {
+		t.Fatalf("repl failed: %v", err)
+	}
+	for _, want := range []string{
+		"Step 0:",
+		"Step 3:",
+		"Humans ",
+		"FixedBudget set to 2500000",
+		"of $2,500,000",
+		"Error: InitialHumans can only be set before the first step",
+		`Error: step count must be a positive integer, got "zero"`,
+		`Error: unknown command "bogus"`,
+		"Checkpoint written to " + checkpointPath,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the REPL output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Step 4:") {
+		t.Error("Expected commands after quit to be ignored")
+	}
+
+	f, err := os.Open(checkpointPath)
+	if err != nil {
+		t.Fatalf("Expected a checkpoint file: %v", err)
+	}
+	defer f.Close()
+	checkpoint, _, err := controller.ReadCheckpoint(f)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint: %v", err)
+	}
+	if checkpoint.CurrentTimeStep != 3 || checkpoint.Config.FixedBudget != 2500000 {
+		t.Errorf("Expected the checkpoint at step 3 with the changed budget, got step %d and budget %.0f", checkpoint.CurrentTimeStep, checkpoint.Config.FixedBudget)
+	}
+}
+
+func TestREPLReviewsOptimizerDecisions(t *testing.T) {
+	decisionsPath := filepath.Join(t.TempDir(), "decisions.csv")
+	script := strings.Join([]string{
+		"review on",
+		"step",
+		"maybe",
+		"reject not yet",
+		"step",
+		"hire 1",
+		"review off",
+		"step",
+		"show decisions",
+		"decisions " + decisionsPath,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := runREPL([]string{"-scenario", "explosive-growth-startup"}, strings.NewReader(script), &out); err != nil {
+		t.Fatalf("repl failed: %v", err)
+	}
+	for _, want := range []string{
+		"Step 1: the optimizer proposes hiring",
+		`Error: unknown answer "maybe"`,
+		"Rejected proposed hire",
+		"(not yet)",
+		"Modified proposed hire",
+		"hired 1",
+		"2 decisions written to " + decisionsPath,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the REPL output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	data, err := os.ReadFile(decisionsPath)
+	if err != nil {
+		t.Fatalf("Expected a decisions file: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 || !strings.Contains(lines[1], "Rejected,not yet,0") {
+		t.Errorf("Expected a header and the two reviewed decisions, got:\n%s", data)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/charts"
+	"workforce-ai-transition-simulator/internal/compress"
+)
+
+// runReport renders charts from a saved JSON simulation report
+// One file per chart is written in the requested format, plus an HTML page embedding all charts
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("wfesim report", flag.ContinueOnError)
+	inputPath := fs.String("input", "", "Path to a JSON simulation report, which may be compressed with gzip or zstd")
+	outputDir := fs.String("output", ".", "Output directory for chart files")
+	format := fs.String("format", "svg", "Chart image format: svg or png")
+	width := fs.Int("width", charts.DefaultWidth, "Chart width in pixels")
+	height := fs.Int("height", charts.DefaultHeight, "Chart height in pixels")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *inputPath == "" {
+		return fmt.Errorf("-input is required")
+	}
+	if *format != "svg" && *format != "png" {
+		return fmt.Errorf("unsupported chart format %q (expected svg or png)", *format)
+	}
+
+	data, err := readReport(*inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var report analytics.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return fmt.Errorf("failed to parse report %s: %w", *inputPath, err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	chartList := charts.KeyMetricCharts(report.TimeSeriesData)
+	for i := range chartList {
+		chartList[i].Width = *width
+		chartList[i].Height = *height
+	}
+
+	for _, chart := range chartList {
+		path := filepath.Join(*outputDir, chart.Name+"."+*format)
+		if err := writeFile(path, func(f *os.File) error { return renderChart(chart, *format, f) }); err != nil {
+			return err
+		}
+	}
+
+	title := "Workforce Simulation Report"
+	if report.Metadata.RunID != "" {
+		title += " (run " + report.Metadata.RunID + ")"
+	}
+	htmlPath := filepath.Join(*outputDir, "report.html")
+	if err := writeFile(htmlPath, func(f *os.File) error {
+		return charts.WriteHTML(f, title, chartList)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d charts and %s\n", len(chartList), htmlPath)
+	return nil
+}
+
+// readReport reads a report file, decompressing it if it was written with -compress
+func readReport(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := compress.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// renderChart writes a chart in the given image format
+func renderChart(chart charts.Chart, format string, w io.Writer) error {
+	if format == "png" {
+		return chart.RenderPNG(w)
+	}
+	return chart.RenderSVG(w)
+}
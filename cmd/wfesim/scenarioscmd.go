@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/scenarios"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// runScenarios lists the scenario templates, prints the configuration of one template as JSON
+// so it can be saved and adapted, or ranks every template by objectives with -rank
+func runScenarios(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim scenarios", flag.ContinueOnError)
+	rank := fs.String("rank", "", "Run every template and rank them by comma-separated objectives ("+strings.Join(objective.Names(), ", ")+"; e.g. composition=0.6)")
+	seed := fs.Int64("seed", 42, "Random seed shared by the ranked runs")
+	maxSteps := fs.Int("max-steps", 500, "Maximum time steps of each ranked run")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 || (fs.NArg() == 1 && *rank != "") {
+		return fmt.Errorf("usage: wfesim scenarios [NAME] | wfesim scenarios -rank OBJECTIVES [-seed N] [-max-steps N]")
+	}
+
+	if *rank != "" {
+		return rankScenarios(*rank, *seed, *maxSteps, stdout)
+	}
+
+	if fs.NArg() == 1 {
+		scenario, err := scenarios.Get(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(scenario.Config); err != nil {
+			return fmt.Errorf("failed to encode scenario: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, scenario := range scenarios.All() {
+		fmt.Fprintf(w, "%s\t%s\n", scenario.Name, scenario.Description)
+	}
+	return w.Flush()
+}
+
+// rankScenarios runs every scenario template with the same seed and prints their ranking under
+// each of the comma-separated objectives in specs
+func rankScenarios(specs string, seed int64, maxSteps int, stdout io.Writer) error {
+	var objectives []objective.Objective
+	for _, spec := range strings.Split(specs, ",") {
+		obj, err := objective.Parse(spec)
+		if err != nil {
+			return err
+		}
+		objectives = append(objectives, obj)
+	}
+
+	configs := make(map[string]types.SimulationConfig)
+	for _, scenario := range scenarios.All() {
+		configs[scenario.Name] = scenario.Config
+	}
+	rankings, err := analytics.NewAnalyticsEngine().RankScenarios(configs, seed, maxSteps, objectives)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for i, ranking := range rankings {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Objective: %s\n", ranking.Objective)
+		for rank, score := range ranking.Scores {
+			fmt.Fprintf(w, "%d\t%s\t%.2f\n", rank+1, score.Scenario, score.Score)
+		}
+	}
+	return w.Flush()
+}
+
+// loadSimulationConfig returns the named scenario template when scenario is set, and the
+// configuration file at configPath otherwise
+// Warnings from upgrading an older configuration file are printed to standard error
+func loadSimulationConfig(configPath, scenario string) (types.SimulationConfig, error) {
+	if scenario == "" {
+		simConfig, warnings, err := config.Load(configPath)
+		if err != nil {
+			return types.SimulationConfig{}, err
+		}
+		printWarnings(configPath, warnings)
+		return simConfig, nil
+	}
+	template, err := scenarios.Get(scenario)
+	if err != nil {
+		return types.SimulationConfig{}, err
+	}
+	return template.Config, nil
+}
+
+// checkConfigSource rejects command lines that set both -config and -scenario
+func checkConfigSource(fs *flag.FlagSet) error {
+	configSet, scenarioSet := false, false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "config":
+			configSet = true
+		case "scenario":
+			scenarioSet = true
+		}
+	})
+	if configSet && scenarioSet {
+		return fmt.Errorf("-config and -scenario are mutually exclusive")
+	}
+	return nil
+}
+
+// printWarnings prints warnings about a file to standard error
+func printWarnings(path string, warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", path, warning)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/scenarios"
+)
+
+func TestScenarios(t *testing.T) {
+	var out bytes.Buffer
+	if err := runScenarios(nil, &out); err != nil {
+		t.Fatalf("scenarios failed: %v", err)
+	}
+	for _, name := range scenarios.Names() {
+		if !strings.Contains(out.String(), name) {
+			t.Errorf("Expected the listing to include %s", name)
+		}
+	}
+
+	// A printed scenario is a configuration file that loads back unchanged
+	out.Reset()
+	if err := runScenarios([]string{"hiring-freeze"}, &out); err != nil {
+		t.Fatalf("scenarios hiring-freeze failed: %v", err)
+	}
+	parsed, warnings, err := config.Parse(out.Bytes(), config.FormatJSON)
+	if err != nil || len(warnings) > 0 {
+		t.Fatalf("Expected the printed scenario to parse cleanly: %v, %v", warnings, err)
+	}
+	scenario, _ := scenarios.Get("hiring-freeze")
+	if !reflect.DeepEqual(parsed, scenario.Config) {
+		t.Errorf("Expected the printed scenario to round-trip, got %+v", parsed)
+	}
+
+	if err := runScenarios([]string{"no-such-scenario"}, &out); err == nil {
+		t.Error("Expected an unknown scenario to fail")
+	}
+}
+
+func TestScenariosRank(t *testing.T) {
+	var out bytes.Buffer
+	if err := runScenarios([]string{"-rank", "profit,composition=0.6", "-max-steps", "10"}, &out); err != nil {
+		t.Fatalf("scenarios -rank failed: %v", err)
+	}
+	for _, want := range append([]string{"Objective: profit", "Objective: composition"}, scenarios.Names()...) {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the ranking to include %q, got:\n%s", want, out.String())
+		}
+	}
+
+	if err := runScenarios([]string{"-rank", "revenue"}, &out); err == nil {
+		t.Error("Expected an unknown objective to fail")
+	}
+	if err := runScenarios([]string{"-rank", "profit", "hiring-freeze"}, &out); err == nil {
+		t.Error("Expected -rank with a scenario name to fail")
+	}
+}
+
+func TestParseFlagsRejectsConfigWithScenario(t *testing.T) {
+	if _, err := parseFlags([]string{"-scenario", "hiring-freeze"}); err != nil {
+		t.Errorf("Expected -scenario on its own to be accepted: %v", err)
+	}
+	if _, err := parseFlags([]string{"-scenario", "hiring-freeze", "-config", "custom.yaml"}); err == nil {
+		t.Error("Expected -config and -scenario together to be rejected")
+	}
+}
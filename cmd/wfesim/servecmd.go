@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/extension"
+	"workforce-ai-transition-simulator/internal/server"
+)
+
+// shutdownTimeout bounds how long the server waits for open requests when interrupted
+const shutdownTimeout = 10 * time.Second
+
+// runServe serves the HTTP API until interrupted, running submitted simulations and sensitivity
+// analyses as queued jobs
+func runServe(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim serve", flag.ContinueOnError)
+	listen := fs.String("listen", "localhost:8080", "Address to serve the API on")
+	workers := fs.Int("workers", runtime.NumCPU(), "Number of jobs run at once")
+	userLimit := fs.Int("user-limit", server.DefaultUserLimit, "Number of jobs of the same user run at once")
+	keysPath := fs.String("keys", "", "JSON file of API keys, their users and rate limits; every request must then carry a key")
+	maxWallTime := fs.Duration("max-wall-time", server.DefaultLimits.MaxWallTime, "Abort runs after this long; 0 for no limit")
+	maxSteps := fs.Int("max-steps", server.DefaultLimits.MaxSteps, "Abort runs still going after this many time steps; 0 for no limit")
+	maxEntitySteps := fs.Int("max-entity-steps", server.DefaultLimits.MaxEntitySteps, "Abort runs once their workers summed over the steps exceed this; 0 for no limit")
+	var extensions []string
+	fs.Func("extension", "Load custom behaviors from this Go plugin for every job; repeat to load several", func(path string) error {
+		extensions = append(extensions, path)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("usage: wfesim serve [-listen ADDRESS] [-workers N] [-user-limit N] [-keys FILE] [-max-wall-time DURATION] [-max-steps N] [-max-entity-steps N] [-extension PLUGIN]...")
+	}
+	if *workers <= 0 {
+		return fmt.Errorf("workers must be greater than 0, got %d", *workers)
+	}
+	if *userLimit <= 0 {
+		return fmt.Errorf("user-limit must be greater than 0, got %d", *userLimit)
+	}
+	if *maxWallTime < 0 || *maxSteps < 0 || *maxEntitySteps < 0 {
+		return fmt.Errorf("max-wall-time, max-steps and max-entity-steps must not be negative")
+	}
+	options, err := extension.Options(extensions)
+	if err != nil {
+		return err
+	}
+	options = append(options, controller.WithLimits(controller.Limits{MaxWallTime: *maxWallTime, MaxSteps: *maxSteps, MaxEntitySteps: *maxEntitySteps}))
+	var keys *server.Keys
+	if *keysPath != "" {
+		if keys, err = server.LoadKeys(*keysPath); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if keys == nil && !isLoopback(listener.Addr()) {
+		fmt.Fprintf(os.Stderr, "Warning: serving on %s without -keys; anyone who can reach it can run and cancel jobs\n", listener.Addr())
+	}
+	return serve(ctx, listener, *workers, *userLimit, keys, options, stdout)
+}
+
+// isLoopback reports whether an address only accepts connections from this machine
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// serve serves the API on listener until ctx is done, then cancels the jobs still queued or
+// running; keys, if not nil, authenticate the requests
+func serve(ctx context.Context, listener net.Listener, workers, userLimit int, keys *server.Keys, options []controller.Option, stdout io.Writer) error {
+	scheduler := server.NewScheduler(workers, userLimit)
+	defer scheduler.Close()
+	api := server.New(scheduler, options...)
+	if keys != nil {
+		api.SetKeys(keys)
+	}
+	httpServer := &http.Server{Handler: api}
+
+	served := make(chan error, 1)
+	go func() { served <- httpServer.Serve(listener) }()
+	fmt.Fprintf(stdout, "Serving on http://%s with %d workers, %d per user\n", listener.Addr(), workers, userLimit)
+	fmt.Fprintf(stdout, "Results browser at http://%s%s\n", listener.Addr(), server.UIPath)
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	fmt.Fprintln(stdout, "Server stopped")
+	return nil
+}
+
+// runOpenAPI writes the OpenAPI document of the HTTP API, for generating clients without a
+// running server, or the Python client generated from it
+func runOpenAPI(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim openapi", flag.ContinueOnError)
+	outputPath := fs.String("output", "", "Write the document to this file instead of standard output")
+	keys := fs.Bool("keys", false, "Document the API key schemes of a server run with -keys")
+	python := fs.Bool("python", false, "Write a Python client of the API instead of the document")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	generate := func() ([]byte, error) { return server.OpenAPI(*keys) }
+	if *python {
+		generate = server.PythonClient
+	}
+	document, err := generate()
+	if err != nil {
+		return err
+	}
+
+	if *outputPath == "" {
+		_, err := stdout.Write(document)
+		return err
+	}
+	return writeFile(*outputPath, func(f *os.File) error {
+		_, err := f.Write(document)
+		return err
+	})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServeUntilCancelled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, listener, 1, 1, nil, nil, &out) }()
+
+	body := strings.NewReader(`{"Kind": "simulation", "Scenario": "hiring-freeze", "MaxTimeSteps": 20}`)
+	response, err := http.Post("http://"+listener.Addr().String()+"/v1/jobs", "application/json", body)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected the job to be accepted, got %s", response.Status)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Server stopped") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+func TestOpenAPICommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := runOpenAPI([]string{"-keys"}, &out); err != nil {
+		t.Fatalf("openapi failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"/v1/jobs/{id}/result"`) || !strings.Contains(out.String(), `"securitySchemes"`) {
+		t.Error("Expected the document to describe the job endpoints and key schemes")
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ANSI escape sequences used to redraw the dashboard in place
+const (
+	ansiClearScreen = "\033[H\033[2J"
+	ansiBold        = "\033[1m"
+	ansiReset       = "\033[0m"
+)
+
+// sparkRunes are the block characters used to draw sparklines, lowest to highest
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// runTUI runs a simulation step by step, redrawing a terminal dashboard after every step
+func runTUI(args []string) error {
+	fs := flag.NewFlagSet("wfesim tui", flag.ContinueOnError)
+	configPath := fs.String("config", "example_config.yaml", "Path to configuration file (JSON or YAML)")
+	scenario := fs.String("scenario", "", "Use the named scenario template instead of a configuration file (see wfesim scenarios)")
+	seed := fs.Int64("seed", 42, "Random seed for reproducible runs")
+	maxTimeSteps := fs.Int("max-steps", 500, "Maximum number of time steps")
+	delay := fs.Duration("delay", 200*time.Millisecond, "Pause between steps so the run can be followed")
+	eventLines := fs.Int("events", 8, "Number of recent events shown in the event feed")
+	noClear := fs.Bool("no-clear", false, "Print successive frames instead of redrawing the screen")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkConfigSource(fs); err != nil {
+		return err
+	}
+
+	simConfig, err := loadSimulationConfig(*configPath, *scenario)
+	if err != nil {
+		return err
+	}
+
+	simController := controller.NewSimulationController(simConfig, *seed, controller.WithPacing(*delay))
+	if err := simController.Initialize(); err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+
+	dash := newDashboard(simConfig, *maxTimeSteps, *eventLines)
+	state := simController.GetTimeSeries()[0]
+	seenEvents := 0
+
+	for {
+		events := simController.GetEvents()
+		dash.update(state, events[seenEvents:])
+		seenEvents = len(events)
+
+		frame := dash.render()
+		if !*noClear {
+			frame = ansiClearScreen + frame
+		}
+		fmt.Fprint(os.Stdout, frame)
+
+		if simController.IsEquilibriumReached() || simController.IsInsolvent() || simController.IsTerminated() || simController.GetCurrentTimeStep() >= *maxTimeSteps {
+			break
+		}
+
+		state = simController.Step()
+	}
+
+	if simController.IsInsolvent() {
+		fmt.Printf("\nInsolvent after %d time steps (%s)\n", simController.GetCurrentTimeStep(), state.EquilibriumReason.Message)
+	} else if simController.IsTerminated() {
+		fmt.Printf("\nTerminal state after %d time steps (%s)\n", simController.GetCurrentTimeStep(), state.EquilibriumReason.Message)
+	} else if simController.IsEquilibriumReached() {
+		fmt.Printf("\nEquilibrium reached after %d time steps (%s)\n", simController.GetCurrentTimeStep(), state.EquilibriumReason.Message)
+	} else {
+		fmt.Printf("\nStopped after %d time steps without reaching equilibrium\n", simController.GetCurrentTimeStep())
+	}
+	return nil
+}
+
+// dashboard accumulates the history shown in the terminal dashboard
+type dashboard struct {
+	config       types.SimulationConfig
+	maxTimeSteps int
+	maxEvents    int
+	sparkWidth   int
+	barWidth     int
+
+	state    types.SimulationState
+	aiRatios []float64
+	events   []types.SimulationEvent
+}
+
+// newDashboard creates a dashboard for the given configuration
+func newDashboard(simConfig types.SimulationConfig, maxTimeSteps int, maxEvents int) *dashboard {
+	return &dashboard{
+		config:       simConfig,
+		maxTimeSteps: maxTimeSteps,
+		maxEvents:    maxEvents,
+		sparkWidth:   50,
+		barWidth:     40,
+		aiRatios:     make([]float64, 0),
+		events:       make([]types.SimulationEvent, 0),
+	}
+}
+
+// update records the latest state and any events emitted since the previous update
+func (d *dashboard) update(state types.SimulationState, newEvents []types.SimulationEvent) {
+	d.state = state
+
+	total := state.Workforce.Humans.Total + state.Workforce.AIAgents.Total
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(state.Workforce.AIAgents.Total) / float64(total) * 100.0
+	}
+	d.aiRatios = append(d.aiRatios, ratio)
+	if len(d.aiRatios) > d.sparkWidth {
+		d.aiRatios = d.aiRatios[len(d.aiRatios)-d.sparkWidth:]
+	}
+
+	d.events = append(d.events, newEvents...)
+	if len(d.events) > d.maxEvents {
+		d.events = d.events[len(d.events)-d.maxEvents:]
+	}
+}
+
+// render draws the dashboard for the most recent state
+func (d *dashboard) render() string {
+	var b strings.Builder
+	state := d.state
+	humans := state.Workforce.Humans
+	agents := state.Workforce.AIAgents
+
+	fmt.Fprintf(&b, "%sWorkforce AI Transition Simulator%s   step %d/%d", ansiBold, ansiReset, state.TimeStep, d.maxTimeSteps)
+	if state.IsEquilibrium {
+		b.WriteString("   [EQUILIBRIUM]")
+	}
+	if state.EquilibriumReason.Code == types.Insolvent {
+		b.WriteString("   [INSOLVENT]")
+	}
+	if state.EquilibriumReason.Code == types.OrganizationCollapsed {
+		b.WriteString("   [COLLAPSED]")
+	}
+	if state.EquilibriumReason.Code == types.OnlyOwnerRemains {
+		b.WriteString("   [ONLY OWNER]")
+	}
+	b.WriteString("\n\n")
+
+	fmt.Fprintf(&b, "Humans     %4d   %s\n", humans.Total, formatLevelCounts(humans.ByExperience))
+	fmt.Fprintf(&b, "AI agents  %4d   %s\n", agents.Total, formatLevelCounts(agents.ByExperience))
+	fmt.Fprintf(&b, "Orchestr.  %s %5.1f%%\n", bar(state.Workforce.OrchestrationUtilization/100.0, d.barWidth), state.Workforce.OrchestrationUtilization)
+	b.WriteString("\n")
+
+	budgetUsed := 0.0
+	if d.config.FixedBudget > 0 {
+		budgetUsed = state.TotalCost / d.config.FixedBudget
+	}
+	fmt.Fprintf(&b, "Budget     %s %5.1f%%  %s of %s\n", bar(budgetUsed, d.barWidth), budgetUsed*100.0,
+		formatMoney(state.TotalCost), formatMoney(d.config.FixedBudget))
+	fmt.Fprintf(&b, "Revenue    %s   productivity %.1f   failures %d\n", formatMoney(state.RevenueOutput), state.TotalProductivity, state.CatastrophicFailures)
+	b.WriteString("\n")
+
+	currentRatio := 0.0
+	if len(d.aiRatios) > 0 {
+		currentRatio = d.aiRatios[len(d.aiRatios)-1]
+	}
+	fmt.Fprintf(&b, "AI ratio   %s %5.1f%%\n", sparkline(d.aiRatios, 0, 100), currentRatio)
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%sRecent events%s\n", ansiBold, ansiReset)
+	if len(d.events) == 0 {
+		b.WriteString("  (none yet)\n")
+	}
+	for _, event := range d.events {
+		fmt.Fprintf(&b, "  [%4d] %-20s %s\n", event.TimeStep, event.Type, event.Description)
+	}
+
+	return b.String()
+}
+
+// bar draws a horizontal progress bar for a fraction between 0 and 1
+func bar(fraction float64, width int) string {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction*float64(width) + 0.5)
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// sparkline draws values scaled between min and max as a row of block characters
+func sparkline(values []float64, min float64, max float64) string {
+	if max <= min {
+		max = min + 1
+	}
+
+	runes := make([]rune, len(values))
+	for i, v := range values {
+		index := int((v - min) / (max - min) * float64(len(sparkRunes)-1))
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(sparkRunes) {
+			index = len(sparkRunes) - 1
+		}
+		runes[i] = sparkRunes[index]
+	}
+	return string(runes)
+}
+
+// formatLevelCounts formats per-experience-level counts in level order
+func formatLevelCounts(counts map[types.ExperienceLevel]int) string {
+	levels := []types.ExperienceLevel{types.UniversityHire, types.MidLevel, types.Senior, types.Executive}
+	parts := make([]string, len(levels))
+	for i, level := range levels {
+		parts[i] = fmt.Sprintf("%s %d", level, counts[level])
+	}
+	return strings.Join(parts, " | ")
+}
+
+// formatMoney formats a currency amount with thousands separators
+func formatMoney(amount float64) string {
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+
+	digits := fmt.Sprintf("%.0f", amount)
+	var b strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+
+	if negative {
+		return "-$" + b.String()
+	}
+	return "$" + b.String()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestBar(t *testing.T) {
+	if got := bar(0.5, 10); got != "[█████░░░░░]" {
+		t.Errorf("Expected half-filled bar, got %s", got)
+	}
+	if got := bar(1.7, 4); got != "[████]" {
+		t.Errorf("Expected fraction above 1 to be clamped, got %s", got)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	got := sparkline([]float64{0, 50, 100}, 0, 100)
+	if got != "▁▄█" {
+		t.Errorf("Expected ▁▄█, got %s", got)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[float64]string{
+		0:        "$0",
+		999:      "$999",
+		1500000:  "$1,500,000",
+		-25000.4: "-$25,000",
+	}
+	for amount, expected := range tests {
+		if got := formatMoney(amount); got != expected {
+			t.Errorf("formatMoney(%.1f) = %s, want %s", amount, got, expected)
+		}
+	}
+}
+
+func TestDashboardKeepsRecentEvents(t *testing.T) {
+	dash := newDashboard(types.SimulationConfig{FixedBudget: 1000000}, 10, 2)
+	events := []types.SimulationEvent{
+		{TimeStep: 1, Type: types.AgentHiredEvent, Description: "first"},
+		{TimeStep: 2, Type: types.AgentHiredEvent, Description: "second"},
+		{TimeStep: 3, Type: types.AttritionEvent, Description: "third"},
+	}
+	dash.update(types.SimulationState{TimeStep: 3, TotalCost: 500000}, events)
+
+	frame := dash.render()
+	if strings.Contains(frame, "first") {
+		t.Error("Expected oldest event to be dropped from the feed")
+	}
+	if !strings.Contains(frame, "third") || !strings.Contains(frame, "50.0%") {
+		t.Errorf("Expected latest event and budget usage in frame, got:\n%s", frame)
+	}
+}
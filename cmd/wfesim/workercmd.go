@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/distributed"
+	"workforce-ai-transition-simulator/internal/extension"
+)
+
+// clusterTokenEnv names the environment variable holding the token shared by a coordinator and
+// its workers
+const clusterTokenEnv = "WFESIM_CLUSTER_TOKEN"
+
+// coordinatorGrace is how long a coordinator keeps answering workers after its sweep, so those
+// between two claims hear that it is over rather than find it gone
+const coordinatorGrace = 2 * time.Second
+
+// runWorker runs the sensitivity runs of a coordinator started with -sensitivity -coordinator
+// until its sweep is over
+func runWorker(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wfesim worker", flag.ContinueOnError)
+	coordinator := fs.String("coordinator", "", "URL of the coordinator, e.g. http://coordinator:7070")
+	parallel := fs.Int("parallel", runtime.NumCPU(), "Number of runs simulated at once")
+	name := fs.String("name", "", "Name of this worker in the coordinator's messages (default: the host name)")
+	var extensions []string
+	fs.Func("extension", "Load custom behaviors from this Go plugin, as the coordinator's -extension does; repeat to load several", func(path string) error {
+		extensions = append(extensions, path)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *coordinator == "" || fs.NArg() > 0 {
+		return fmt.Errorf("usage: wfesim worker -coordinator URL [-parallel N] [-name NAME] [-extension PLUGIN]...")
+	}
+	if *parallel <= 0 {
+		return fmt.Errorf("parallel must be greater than 0, got %d", *parallel)
+	}
+
+	worker := distributed.NewWorker(*coordinator)
+	worker.Parallelism = *parallel
+	worker.Token = os.Getenv(clusterTokenEnv)
+	worker.Name = *name
+	if worker.Name == "" {
+		worker.Name, _ = os.Hostname()
+	}
+	options, err := extension.Options(extensions)
+	if err != nil {
+		return err
+	}
+	worker.Options = options
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(stdout, "Worker %s running up to %d runs at once for %s\n", worker.Name, worker.Parallelism, *coordinator)
+	completed, err := worker.Run(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Sweep over: %d runs completed\n", completed)
+	return nil
+}
+
+// serveCoordinator serves a coordinator on address and makes it run the analysis' simulations,
+// returning a function that stops it once the analysis is over
+func serveCoordinator(address string, sensitivityOpts *analytics.SensitivityOptions) (func(), error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for workers: %w", err)
+	}
+	coordinator := distributed.NewCoordinator()
+	coordinator.Token = os.Getenv(clusterTokenEnv)
+	server := &http.Server{Handler: coordinator}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Coordinator stopped: %v\n", err)
+		}
+	}()
+	sensitivityOpts.Executor = coordinator
+	fmt.Printf("Coordinating sensitivity runs on %s; start workers with wfesim worker -coordinator http://%s\n", listener.Addr(), listener.Addr())
+
+	return func() {
+		// Closing the coordinator tells polling workers the sweep is over before they disconnect
+		coordinator.Close()
+		time.Sleep(coordinatorGrace)
+		ctx, cancel := context.WithTimeout(context.Background(), distributed.DefaultPollWait)
+		defer cancel()
+		server.Shutdown(ctx)
+	}, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/distributed"
+)
+
+func TestWorkerRunsUntilSweepIsOver(t *testing.T) {
+	coordinator := distributed.NewCoordinator()
+	server := httptest.NewServer(coordinator)
+	defer server.Close()
+	coordinator.Close()
+
+	var out bytes.Buffer
+	if err := runWorker([]string{"-coordinator", server.URL, "-parallel", "2", "-name", "test"}, &out); err != nil {
+		t.Fatalf("worker failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Sweep over: 0 runs completed") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	if err := runWorker(nil, &out); err == nil {
+		t.Error("Expected a worker without -coordinator to fail")
+	}
+}
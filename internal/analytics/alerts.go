@@ -0,0 +1,27 @@
+package analytics
+
+import (
+	"fmt"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// maxSummaryAlerts is the number of individual alerts listed in the Markdown summary
+const maxSummaryAlerts = 10
+
+// writeAlerts appends a section listing the alerts raised during the run, if any were
+func writeAlerts(b *strings.Builder, alerts []types.Alert) {
+	if len(alerts) == 0 {
+		return
+	}
+
+	b.WriteString("## Alerts\n\n")
+	for i, alert := range alerts {
+		if i == maxSummaryAlerts {
+			fmt.Fprintf(b, "- ...and %d more\n", len(alerts)-i)
+			break
+		}
+		fmt.Fprintf(b, "- Step %d: %s (%s %.4g, threshold %.4g)\n", alert.TimeStep, alert.Message, alert.Metric, alert.Value, alert.Threshold)
+	}
+	b.WriteString("\n")
+}
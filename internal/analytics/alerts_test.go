@@ -0,0 +1,22 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestSummaryAlerts(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	result := types.SimulationResult{
+		TimeSeries: []types.SimulationState{{}},
+		Alerts:     []types.Alert{{TimeStep: 14, Metric: "human_count", Value: 14, Threshold: 15, Message: "humans below 30% of initial"}},
+	}
+	summary := engine.GenerateMarkdownSummary(result)
+	if !strings.Contains(summary, "## Alerts") || !strings.Contains(summary, "- Step 14: humans below 30% of initial (human_count 14, threshold 15)") {
+		t.Errorf("Expected the summary to list the alert, got:\n%s", summary)
+	}
+	if strings.Contains(engine.GenerateMarkdownSummary(types.SimulationResult{TimeSeries: []types.SimulationState{{}}}), "## Alerts") {
+		t.Error("Expected no alerts section without alerts")
+	}
+}
@@ -0,0 +1,125 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// DefaultAnomalyThreshold is the number of standard deviations from the rolling mean beyond which
+// a value is flagged as an anomaly
+const DefaultAnomalyThreshold = 3.0
+
+// minAnomalyHistory is the number of preceding steps needed before a step is checked for anomalies
+const minAnomalyHistory = 3
+
+// Anomaly is a time step where a metric deviates from its rolling mean by more than the threshold
+type Anomaly struct {
+	TimeStep      int
+	Metric        string
+	Value         float64
+	RollingMean   float64 // mean of the metric over the preceding window
+	RollingStdDev float64 // population standard deviation over the preceding window
+	Deviation     float64 // (Value - RollingMean) / RollingStdDev; negative for collapses, positive for spikes
+}
+
+// anomalyMetrics are the metrics checked for anomalies, named like the recorded metrics
+var anomalyMetrics = []struct {
+	name  string
+	value func(state types.SimulationState) float64
+}{
+	{"total_cost", func(state types.SimulationState) float64 { return state.TotalCost }},
+	{"total_productivity", func(state types.SimulationState) float64 { return state.TotalProductivity }},
+	{"revenue_output", func(state types.SimulationState) float64 { return state.RevenueOutput }},
+	{"human_count", func(state types.SimulationState) float64 { return float64(state.Workforce.Humans.Total) }},
+	{"ai_agent_count", func(state types.SimulationState) float64 { return float64(state.Workforce.AIAgents.Total) }},
+	{"orchestration_utilization", func(state types.SimulationState) float64 { return state.Workforce.OrchestrationUtilization }},
+}
+
+// SetAnomalyThreshold sets the number of standard deviations from the rolling mean beyond which
+// DetectAnomalies flags a value
+func (ae *AnalyticsEngine) SetAnomalyThreshold(threshold float64) error {
+	if threshold <= 0 {
+		return fmt.Errorf("anomaly threshold must be greater than 0, got %.2f", threshold)
+	}
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	ae.anomalyThreshold = threshold
+	return nil
+}
+
+// AnomalyThreshold returns the number of standard deviations beyond which a value is an anomaly
+func (ae *AnalyticsEngine) AnomalyThreshold() float64 {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	return ae.anomalyThreshold
+}
+
+// DetectAnomalies flags the steps of a time series where a metric deviates from its mean over the
+// preceding rolling window by more than the anomaly threshold times the window's standard deviation
+// Steps with fewer than three preceding steps, or after a window of constant values, are not checked
+// Anomalies are ordered by time step
+func (ae *AnalyticsEngine) DetectAnomalies(timeSeries []types.SimulationState) []Anomaly {
+	window := ae.RollingWindow()
+	threshold := ae.AnomalyThreshold()
+
+	values := make([][]float64, len(anomalyMetrics))
+	for m, metric := range anomalyMetrics {
+		values[m] = make([]float64, len(timeSeries))
+		for i, state := range timeSeries {
+			values[m][i] = metric.value(state)
+		}
+	}
+
+	anomalies := make([]Anomaly, 0)
+	for i := minAnomalyHistory; i < len(timeSeries); i++ {
+		for m, metric := range anomalyMetrics {
+			history := values[m][maxInt(0, i-window):i]
+			if len(history) < minAnomalyHistory {
+				continue
+			}
+			mean, stdDev := meanAndStdDev(history)
+			if stdDev == 0 {
+				continue
+			}
+			deviation := (values[m][i] - mean) / stdDev
+			if math.Abs(deviation) > threshold {
+				anomalies = append(anomalies, Anomaly{
+					TimeStep:      timeSeries[i].TimeStep,
+					Metric:        metric.name,
+					Value:         values[m][i],
+					RollingMean:   mean,
+					RollingStdDev: stdDev,
+					Deviation:     deviation,
+				})
+			}
+		}
+	}
+	return anomalies
+}
+
+// meanAndStdDev returns the mean and population standard deviation of values
+func meanAndStdDev(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// maxInt returns the larger of two integers
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,58 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestDetectAnomaliesFlagsSpikesAndCollapses(t *testing.T) {
+	engine := NewAnalyticsEngine()
+
+	timeSeries := make([]types.SimulationState, 12)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].TotalCost = 1000 + float64(i%2)*10
+		timeSeries[i].TotalProductivity = 50 + float64(i%2)
+	}
+	timeSeries[8].TotalCost = 5000
+	timeSeries[10].TotalProductivity = 5
+
+	anomalies := engine.DetectAnomalies(timeSeries)
+	if len(anomalies) != 2 {
+		t.Fatalf("Expected 2 anomalies, got %+v", anomalies)
+	}
+	if anomalies[0].TimeStep != 8 || anomalies[0].Metric != "total_cost" || anomalies[0].Deviation <= DefaultAnomalyThreshold {
+		t.Errorf("Expected a cost spike at step 8, got %+v", anomalies[0])
+	}
+	if anomalies[1].TimeStep != 10 || anomalies[1].Metric != "total_productivity" || anomalies[1].Deviation >= -DefaultAnomalyThreshold {
+		t.Errorf("Expected a productivity collapse at step 10, got %+v", anomalies[1])
+	}
+}
+
+func TestDetectAnomaliesSkipsConstantHistory(t *testing.T) {
+	engine := NewAnalyticsEngine()
+
+	timeSeries := make([]types.SimulationState, 6)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].TotalCost = 1000
+	}
+	timeSeries[5].TotalCost = 2000
+
+	if anomalies := engine.DetectAnomalies(timeSeries); len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies after a constant history, got %+v", anomalies)
+	}
+}
+
+func TestSetAnomalyThreshold(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetAnomalyThreshold(0); err == nil {
+		t.Error("Expected an error for a zero threshold")
+	}
+	if err := engine.SetAnomalyThreshold(1.5); err != nil {
+		t.Fatalf("SetAnomalyThreshold failed: %v", err)
+	}
+	if engine.AnomalyThreshold() != 1.5 {
+		t.Errorf("Expected threshold 1.5, got %.2f", engine.AnomalyThreshold())
+	}
+}
@@ -0,0 +1,301 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+	"workforce-ai-transition-simulator/internal/arrowipc"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// arrowField is a column of an Arrow table, read from every row at full precision
+// Exactly one of the readers is set, the one of the field's type
+type arrowField[R any] struct {
+	name        string
+	columnType  arrowipc.Type
+	intValue    func(R) int64
+	floatValue  func(R) float64
+	boolValue   func(R) bool
+	stringValue func(R) string
+}
+
+func intField[R any](name string, value func(R) int64) arrowField[R] {
+	return arrowField[R]{name: name, columnType: arrowipc.Int64, intValue: value}
+}
+
+func floatField[R any](name string, value func(R) float64) arrowField[R] {
+	return arrowField[R]{name: name, columnType: arrowipc.Float64, floatValue: value}
+}
+
+func boolField[R any](name string, value func(R) bool) arrowField[R] {
+	return arrowField[R]{name: name, columnType: arrowipc.Bool, boolValue: value}
+}
+
+func stringField[R any](name string, value func(R) string) arrowField[R] {
+	return arrowField[R]{name: name, columnType: arrowipc.Utf8, stringValue: value}
+}
+
+// arrowTable builds a table with a column per field and a row per row
+func arrowTable[R any](fields []arrowField[R], rows []R) arrowipc.Table {
+	table := arrowipc.Table{Columns: make([]arrowipc.Column, len(fields))}
+	for i, field := range fields {
+		column := arrowipc.Column{Name: field.name, Type: field.columnType}
+		switch field.columnType {
+		case arrowipc.Int64:
+			column.Int64s = make([]int64, len(rows))
+			for r, row := range rows {
+				column.Int64s[r] = field.intValue(row)
+			}
+		case arrowipc.Float64:
+			column.Float64s = make([]float64, len(rows))
+			for r, row := range rows {
+				column.Float64s[r] = field.floatValue(row)
+			}
+		case arrowipc.Bool:
+			column.Bools = make([]bool, len(rows))
+			for r, row := range rows {
+				column.Bools[r] = field.boolValue(row)
+			}
+		default:
+			column.Strings = make([]string, len(rows))
+			for r, row := range rows {
+				column.Strings[r] = field.stringValue(row)
+			}
+		}
+		table.Columns[i] = column
+	}
+	return table
+}
+
+// writeArrowTable writes a table as an Arrow IPC file
+func writeArrowTable(table arrowipc.Table, writer io.Writer) error {
+	if err := arrowipc.Write(writer, table); err != nil {
+		return fmt.Errorf("failed to write Arrow report: %w", err)
+	}
+	return nil
+}
+
+// reportArrowRow is a row of the Arrow time series table: a state, the rolling KPIs at it and the
+// metadata of its run
+type reportArrowRow struct {
+	state    types.SimulationState
+	kpi      RollingKPI
+	metadata types.RunMetadata
+}
+
+// reportArrowFields is the schema of the Arrow time series table, the columns of the CSV report
+var reportArrowFields = func() []arrowField[reportArrowRow] {
+	type row = reportArrowRow
+	fields := []arrowField[row]{
+		intField("TimeStep", func(r row) int64 { return int64(r.state.TimeStep) }),
+		intField("HumanCount", func(r row) int64 { return int64(r.state.Workforce.Humans.Total) }),
+		intField("AIAgentCount", func(r row) int64 { return int64(r.state.Workforce.AIAgents.Total) }),
+		intField("TotalWorkforce", func(r row) int64 {
+			return int64(r.state.Workforce.Humans.Total + r.state.Workforce.AIAgents.Total)
+		}),
+		floatField("TotalCost", func(r row) float64 { return r.state.TotalCost }),
+		floatField("AvailableBudget", func(r row) float64 { return r.state.AvailableBudget }),
+		floatField("TotalProductivity", func(r row) float64 { return r.state.TotalProductivity }),
+		floatField("RevenueOutput", func(r row) float64 { return r.state.RevenueOutput }),
+		floatField("OrchestrationUtilization", func(r row) float64 { return r.state.Workforce.OrchestrationUtilization }),
+		intField("CatastrophicFailures", func(r row) int64 { return int64(r.state.CatastrophicFailures) }),
+		boolField("IsEquilibrium", func(r row) bool { return r.state.IsEquilibrium }),
+		intField("AvailableOrchestrationCapacity", func(r row) int64 { return int64(r.state.AvailableOrchestrationCapacity) }),
+		intField("AgentsHired", func(r row) int64 { return int64(r.state.AgentsHiredThisStep) }),
+		intField("AgentsReleased", func(r row) int64 { return int64(r.state.AgentsReleasedThisStep) }),
+		intField("HumansLost", func(r row) int64 { return int64(r.state.HumansLostThisStep) }),
+	}
+	for _, contract := range contractTypes {
+		contract := contract
+		fields = append(fields, intField("Humans_"+contract.String(), func(r row) int64 {
+			return int64(r.state.Workforce.Humans.ByContractType[contract])
+		}))
+	}
+	fields = append(fields,
+		floatField("Overtime", func(r row) float64 { return r.state.Overtime }),
+		floatField("Burnout", func(r row) float64 { return r.state.Burnout }),
+		floatField("RecoveryLoss", func(r row) float64 { return r.state.RecoveryLoss }),
+		floatField("Utilization", func(r row) float64 { return r.state.Utilization.Overall }),
+	)
+	for _, level := range experienceLevels {
+		level := level
+		fields = append(fields, floatField("Utilization_"+level.String(), func(r row) float64 {
+			return r.state.Utilization.ByExperience[level]
+		}))
+	}
+	for _, level := range experienceLevels {
+		level := level
+		fields = append(fields, floatField("HumanPayroll_"+level.String(), func(r row) float64 {
+			return r.state.CostBreakdown.HumanPayroll[level]
+		}))
+	}
+	for _, level := range experienceLevels {
+		level := level
+		fields = append(fields, floatField("AICost_"+level.String(), func(r row) float64 {
+			return r.state.CostBreakdown.AICost[level]
+		}))
+	}
+	fields = append(fields,
+		floatField("AIPlatformFee", func(r row) float64 { return r.state.CostBreakdown.AIPlatformFee }),
+		floatField("Resilience", func(r row) float64 { return r.state.CostBreakdown.Resilience }),
+		floatField("Training", func(r row) float64 { return r.state.CostBreakdown.Training }),
+		floatField("Penalties", func(r row) float64 { return r.state.CostBreakdown.Penalties }),
+		floatField("Severance", func(r row) float64 { return r.state.CostBreakdown.Severance }),
+		floatField("Recruiting", func(r row) float64 { return r.state.CostBreakdown.Recruiting }),
+		floatField("Remediation", func(r row) float64 { return r.state.CostBreakdown.Remediation }),
+		floatField("Interest", func(r row) float64 { return r.state.CostBreakdown.Interest }),
+	)
+	for _, level := range experienceLevels {
+		level := level
+		fields = append(fields, floatField("HumanRevenue_"+level.String(), func(r row) float64 {
+			return r.state.RevenueAttribution.HumanByExperience[level]
+		}))
+	}
+	for _, level := range experienceLevels {
+		level := level
+		fields = append(fields, floatField("AIRevenue_"+level.String(), func(r row) float64 {
+			return r.state.RevenueAttribution.AIByExperience[level]
+		}))
+	}
+	fields = append(fields,
+		floatField("AIRevenueShare", func(r row) float64 { return r.state.RevenueAttribution.AIShare() }),
+		stringField("EquilibriumReason", func(r row) string { return r.state.EquilibriumReason.Code.String() }),
+		stringField("MarketShocks", func(r row) string { return formatMarketShocks(r.state.MarketShocks) }),
+		floatField("CashBalance", func(r row) float64 { return r.state.CashBalance }),
+		floatField("Borrowed", func(r row) float64 { return r.state.Financing.Borrowed }),
+		floatField("Repaid", func(r row) float64 { return r.state.Financing.Repaid }),
+		floatField("Debt", func(r row) float64 { return r.state.Financing.Debt }),
+		floatField("Leverage", func(r row) float64 { return r.state.Financing.Leverage }),
+		floatField("TrailingRevenue", func(r row) float64 { return r.kpi.TrailingRevenue }),
+		floatField("AverageHeadcount", func(r row) float64 { return r.kpi.AverageHeadcount }),
+		floatField("CostRunRate", func(r row) float64 { return r.kpi.CostRunRate }),
+		stringField("RunID", func(r row) string { return r.metadata.RunID }),
+		stringField("Timestamp", func(r row) string { return r.metadata.Timestamp.Format(time.RFC3339) }),
+		intField("Seed", func(r row) int64 { return r.metadata.Seed }),
+		stringField("Version", func(r row) string { return r.metadata.Version }),
+		stringField("ConfigHash", func(r row) string { return r.metadata.ConfigHash }),
+	)
+	return fields
+}()
+
+// reportArrowTable returns the time series of a run as an Arrow table
+func (ae *AnalyticsEngine) reportArrowTable(result types.SimulationResult) (arrowipc.Table, error) {
+	if len(result.TimeSeries) == 0 {
+		return arrowipc.Table{}, errors.New("no time series data available")
+	}
+	rolling := ae.RollingKPIs(result.TimeSeries)
+	rows := make([]reportArrowRow, len(result.TimeSeries))
+	for i, state := range result.TimeSeries {
+		rows[i] = reportArrowRow{state: state, kpi: rolling[i], metadata: result.Metadata}
+	}
+	return arrowTable(reportArrowFields, rows), nil
+}
+
+// WriteReportArrow writes the time series of a run as an Apache Arrow IPC file, which Python
+// and R can memory-map without parsing
+// The columns are those of the CSV report at full precision, with the fixed types of
+// reportArrowFields, so every run's file has the same schema
+func (ae *AnalyticsEngine) WriteReportArrow(result types.SimulationResult, writer io.Writer) error {
+	table, err := ae.reportArrowTable(result)
+	if err != nil {
+		return fmt.Errorf("failed to generate Arrow report: %w", err)
+	}
+	return writeArrowTable(table, writer)
+}
+
+// sensitivityArrowRow is a row of the Arrow sensitivity table: a run with the value of the
+// parameter it varied
+type sensitivityArrowRow struct {
+	parameter string
+	value     float64
+	result    types.SimulationResult
+}
+
+// sensitivityArrowFields is the schema of the Arrow sensitivity table, the columns of
+// WriteDetailedSensitivityCSV
+var sensitivityArrowFields = func() []arrowField[sensitivityArrowRow] {
+	type row = sensitivityArrowRow
+	return []arrowField[row]{
+		stringField("ParameterName", func(r row) string { return r.parameter }),
+		floatField("ParameterValue", func(r row) float64 { return r.value }),
+		intField("TimeToEquilibrium", func(r row) int64 { return int64(r.result.TimeToEquilibrium) }),
+		boolField("ReachedEquilibrium", func(r row) bool { return r.result.ReachedEquilibrium }),
+		intField("FinalHumanCount", func(r row) int64 { return int64(r.result.EquilibriumState.Workforce.Humans.Total) }),
+		intField("FinalAIAgentCount", func(r row) int64 { return int64(r.result.EquilibriumState.Workforce.AIAgents.Total) }),
+		floatField("FinalTotalCost", func(r row) float64 { return r.result.EquilibriumState.TotalCost }),
+		floatField("FinalProductivity", func(r row) float64 { return r.result.EquilibriumState.TotalProductivity }),
+		floatField("FinalRevenue", func(r row) float64 { return r.result.EquilibriumState.RevenueOutput }),
+		floatField("OrchestrationUtilization", func(r row) float64 {
+			return r.result.EquilibriumState.Workforce.OrchestrationUtilization
+		}),
+		intField("CatastrophicFailures", func(r row) int64 { return int64(r.result.TotalCatastrophicFailures) }),
+	}
+}()
+
+// sensitivityArrowTable returns the outcome of every sensitivity run as an Arrow table, grouped by
+// parameter in name order
+func sensitivityArrowTable(sensitivityResults map[string]SensitivityResults) (arrowipc.Table, error) {
+	if len(sensitivityResults) == 0 {
+		return arrowipc.Table{}, errors.New("no sensitivity results available")
+	}
+	names := make([]string, 0, len(sensitivityResults))
+	for name := range sensitivityResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]sensitivityArrowRow, 0)
+	for _, name := range names {
+		results := sensitivityResults[name]
+		for i, result := range results.Results {
+			rows = append(rows, sensitivityArrowRow{parameter: name, value: results.ParameterValues[i], result: result})
+		}
+	}
+	return arrowTable(sensitivityArrowFields, rows), nil
+}
+
+// WriteSensitivityArrow writes the outcome of every sensitivity run, the table of
+// WriteDetailedSensitivityCSV, as an Apache Arrow IPC file
+func (ae *AnalyticsEngine) WriteSensitivityArrow(sensitivityResults map[string]SensitivityResults, writer io.Writer) error {
+	table, err := sensitivityArrowTable(sensitivityResults)
+	if err != nil {
+		return fmt.Errorf("failed to generate Arrow sensitivity report: %w", err)
+	}
+	return writeArrowTable(table, writer)
+}
+
+// runSummaryArrowFields is the schema of the Arrow run summary table, the columns of
+// WriteRunSummariesCSV
+var runSummaryArrowFields = func() []arrowField[RunSummary] {
+	type row = RunSummary
+	fields := []arrowField[row]{
+		stringField("Batch", func(r row) string { return r.Batch }),
+		stringField("Varied", func(r row) string { return r.Varied }),
+		intField("Seed", func(r row) int64 { return r.Seed }),
+		stringField("ConfigHash", func(r row) string { return r.ConfigHash }),
+	}
+	for _, name := range SensitivityParameterNames() {
+		name := name
+		fields = append(fields, floatField(name, func(r row) float64 { return r.Parameters[name] }))
+	}
+	return append(fields,
+		stringField("RevenueScenario", func(r row) string { return r.RevenueScenario.String() }),
+		stringField("AttritionType", func(r row) string { return r.AttritionType.String() }),
+		intField("TimeToEquilibrium", func(r row) int64 { return int64(r.TimeToEquilibrium) }),
+		boolField("ReachedEquilibrium", func(r row) bool { return r.ReachedEquilibrium }),
+		stringField("Status", func(r row) string { return r.Status.String() }),
+		intField("FinalHumans", func(r row) int64 { return int64(r.FinalHumans) }),
+		intField("FinalAIAgents", func(r row) int64 { return int64(r.FinalAIAgents) }),
+		floatField("FinalAIShare", func(r row) float64 { return r.FinalAIShare }),
+		floatField("TotalRevenue", func(r row) float64 { return r.TotalRevenue }),
+		intField("CatastrophicFailures", func(r row) int64 { return int64(r.CatastrophicFailures) }),
+	)
+}()
+
+// WriteRunSummariesArrow writes run summaries, the table of WriteRunSummariesCSV, as an Apache
+// Arrow IPC file
+func WriteRunSummariesArrow(summaries []RunSummary, writer io.Writer) error {
+	return writeArrowTable(arrowTable(runSummaryArrowFields, summaries), writer)
+}
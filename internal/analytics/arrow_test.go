@@ -0,0 +1,67 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/arrowipc"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestArrowSchemasMatchCSV(t *testing.T) {
+	names := func(fields []string) string { return strings.Join(fields, ",") }
+	columns := func(table arrowipc.Table) []string {
+		header := make([]string, len(table.Columns))
+		for i, column := range table.Columns {
+			header[i] = column.Name
+		}
+		return header
+	}
+
+	engine := NewAnalyticsEngine()
+	result, err := controller.NewSimulationController(testSimulationConfig(), 5).RunUntilEquilibrium(30)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	table, err := engine.reportArrowTable(result)
+	if err != nil {
+		t.Fatalf("reportArrowTable failed: %v", err)
+	}
+	if got := names(columns(table)); got != names(reportCSVHeader()) {
+		t.Errorf("Expected the report columns of the CSV report, got %s", got)
+	}
+	if len(columns(arrowTable(runSummaryArrowFields, nil))) != len(RunSummariesCSV(nil)[0]) {
+		t.Errorf("Expected the run summary columns of the CSV table")
+	}
+
+	// Values keep their full precision, beyond the rounding of the CSV reports
+	sensitivity := map[string]SensitivityResults{"CatastrophicFailureRate": {ParameterValues: []float64{0.00001}, Results: []types.SimulationResult{result}}}
+	table, err = sensitivityArrowTable(sensitivity)
+	if err != nil {
+		t.Fatalf("sensitivityArrowTable failed: %v", err)
+	}
+	detailed, _ := engine.GenerateDetailedSensitivityCSV(sensitivity)
+	if got := names(columns(table)); got != names(detailed[0]) {
+		t.Errorf("Expected the sensitivity columns of the CSV report, got %s", got)
+	}
+	if value := table.Columns[1]; value.Type != arrowipc.Float64 || value.Float64s[0] != 0.00001 {
+		t.Errorf("Expected a ParameterValue of 0.00001, got %+v", value)
+	}
+}
+
+func TestWriteReportArrow(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	result, err := engine.RunMonteCarlo(testSimulationConfig(), 2, 5, 30)
+	if err != nil {
+		t.Fatalf("RunMonteCarlo failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummariesArrow(result.RunSummaries, &buf); err != nil {
+		t.Fatalf("WriteRunSummariesArrow failed: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("ARROW1")) || !bytes.HasSuffix(buf.Bytes(), []byte("ARROW1")) {
+		t.Errorf("Expected an Arrow IPC file")
+	}
+}
@@ -0,0 +1,201 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+const (
+	// maxAttractors bounds the number of distinct equilibria looked for across Monte Carlo runs
+	maxAttractors = 4
+	// minAttractorSilhouette is the mean silhouette a clustering needs for its clusters to count
+	// as distinct equilibria rather than spread around one
+	minAttractorSilhouette = 0.7
+	// minAttractorSeparation is the distance between the closest cluster centres, as a fraction of
+	// the mean final workforce, below which clusters are not told apart
+	minAttractorSeparation = 0.05
+	// maxClusteringIterations bounds the refinement of a k-means clustering
+	maxClusteringIterations = 100
+)
+
+// Attractor is a final workforce composition that a share of Monte Carlo runs settles into
+type Attractor struct {
+	Humans     float64 // mean final human count of the runs in the cluster
+	AIAgents   float64 // mean final AI agent count of the runs in the cluster
+	Runs       int
+	Prevalence float64 // fraction of all runs that settled here (0-1)
+	Seeds      []int64 // seeds of the runs that settled here, in run order
+}
+
+// findAttractors clusters the final compositions of a set of runs to tell whether they settle
+// into one equilibrium or several, depending on their random path, most prevalent first
+// It runs k-means on the human and AI agent counts for 1 to maxAttractors clusters and keeps the
+// largest number of clusters that are both well separated (by silhouette) and far enough apart
+// to be distinct compositions; otherwise all runs form a single attractor
+func findAttractors(results []types.SimulationResult, seeds []int64) []Attractor {
+	if len(results) == 0 {
+		return nil
+	}
+	points := make([][2]float64, len(results))
+	meanWorkforce := 0.0
+	for i, result := range results {
+		workforce := result.EquilibriumState.Workforce
+		points[i] = [2]float64{float64(workforce.Humans.Total), float64(workforce.AIAgents.Total)}
+		meanWorkforce += (points[i][0] + points[i][1]) / float64(len(results))
+	}
+
+	assignments := make([]int, len(points))
+	clusters := 1
+	for k := 2; k <= maxAttractors && k <= len(points); k++ {
+		candidate, centroids := kMeans(points, k)
+		if closestCentroids(centroids) < minAttractorSeparation*meanWorkforce {
+			continue
+		}
+		if silhouette(points, candidate, k) >= minAttractorSilhouette {
+			assignments, clusters = candidate, k
+		}
+	}
+
+	attractors := make([]Attractor, clusters)
+	for i, point := range points {
+		attractor := &attractors[assignments[i]]
+		attractor.Humans += point[0]
+		attractor.AIAgents += point[1]
+		attractor.Runs++
+		attractor.Seeds = append(attractor.Seeds, seeds[i])
+	}
+	for i := range attractors {
+		attractors[i].Humans /= float64(attractors[i].Runs)
+		attractors[i].AIAgents /= float64(attractors[i].Runs)
+		attractors[i].Prevalence = float64(attractors[i].Runs) / float64(len(points))
+	}
+	sort.SliceStable(attractors, func(i, j int) bool { return attractors[i].Runs > attractors[j].Runs })
+	return attractors
+}
+
+// kMeans partitions points into k clusters, returning each point's cluster and the cluster centres
+// Centres start at the point nearest the mean and then, in turn, at the point farthest from every
+// centre chosen so far, so the clustering is deterministic
+func kMeans(points [][2]float64, k int) ([]int, [][2]float64) {
+	var mean [2]float64
+	for _, point := range points {
+		mean[0] += point[0] / float64(len(points))
+		mean[1] += point[1] / float64(len(points))
+	}
+	centroids := make([][2]float64, 0, k)
+	centroids = append(centroids, points[bestPoint(points, func(point [2]float64) float64 { return -distance(point, mean) })])
+	for len(centroids) < k {
+		centroids = append(centroids, points[bestPoint(points, func(point [2]float64) float64 {
+			return distance(point, centroids[nearestCentroid(point, centroids)])
+		})])
+	}
+
+	assignments := make([]int, len(points))
+	for iteration := 0; iteration < maxClusteringIterations; iteration++ {
+		changed := iteration == 0
+		for i, point := range points {
+			if cluster := nearestCentroid(point, centroids); cluster != assignments[i] {
+				assignments[i] = cluster
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][2]float64, k)
+		counts := make([]int, k)
+		for i, point := range points {
+			sums[assignments[i]][0] += point[0]
+			sums[assignments[i]][1] += point[1]
+			counts[assignments[i]]++
+		}
+		for cluster := range centroids {
+			if counts[cluster] > 0 {
+				centroids[cluster] = [2]float64{sums[cluster][0] / float64(counts[cluster]), sums[cluster][1] / float64(counts[cluster])}
+			}
+		}
+	}
+	return assignments, centroids
+}
+
+// bestPoint returns the index of the point with the largest score, the first of any ties
+func bestPoint(points [][2]float64, score func(point [2]float64) float64) int {
+	best := 0
+	for i := range points {
+		if score(points[i]) > score(points[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// nearestCentroid returns the index of the centre closest to a point, the first of any ties
+func nearestCentroid(point [2]float64, centroids [][2]float64) int {
+	nearest := 0
+	for i := range centroids {
+		if distance(point, centroids[i]) < distance(point, centroids[nearest]) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+// closestCentroids returns the smallest distance between two cluster centres
+func closestCentroids(centroids [][2]float64) float64 {
+	closest := math.Inf(1)
+	for i := range centroids {
+		for j := i + 1; j < len(centroids); j++ {
+			closest = math.Min(closest, distance(centroids[i], centroids[j]))
+		}
+	}
+	return closest
+}
+
+// silhouette returns the mean silhouette of a clustering (-1 to 1), which is higher the closer
+// points are to their own cluster compared with the nearest other cluster
+// Points alone in their cluster score 0, as do all points when any cluster is empty
+func silhouette(points [][2]float64, assignments []int, k int) float64 {
+	counts := make([]int, k)
+	for _, cluster := range assignments {
+		counts[cluster]++
+	}
+	for _, count := range counts {
+		if count == 0 {
+			return 0
+		}
+	}
+
+	total := 0.0
+	distances := make([]float64, k)
+	for i, point := range points {
+		own := assignments[i]
+		if counts[own] == 1 {
+			continue
+		}
+		for cluster := range distances {
+			distances[cluster] = 0
+		}
+		for j, other := range points {
+			distances[assignments[j]] += distance(point, other)
+		}
+
+		cohesion := distances[own] / float64(counts[own]-1)
+		separation := math.Inf(1)
+		for cluster := range distances {
+			if cluster != own {
+				separation = math.Min(separation, distances[cluster]/float64(counts[cluster]))
+			}
+		}
+		if spread := math.Max(cohesion, separation); spread > 0 {
+			total += (separation - cohesion) / spread
+		}
+	}
+	return total / float64(len(points))
+}
+
+// distance returns the Euclidean distance between two compositions
+func distance(a [2]float64, b [2]float64) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
@@ -0,0 +1,58 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// finalCompositions returns results whose runs end with the given human and AI agent counts
+func finalCompositions(counts ...[2]int) ([]types.SimulationResult, []int64) {
+	results := make([]types.SimulationResult, len(counts))
+	seeds := make([]int64, len(counts))
+	for i, count := range counts {
+		results[i].EquilibriumState.Workforce.Humans.Total = count[0]
+		results[i].EquilibriumState.Workforce.AIAgents.Total = count[1]
+		seeds[i] = int64(i + 1)
+	}
+	return results, seeds
+}
+
+func TestFindAttractorsSeparatesDistinctEquilibria(t *testing.T) {
+	results, seeds := finalCompositions(
+		[2]int{50, 200}, [2]int{51, 198}, [2]int{49, 201}, [2]int{50, 199},
+		[2]int{20, 310}, [2]int{21, 305},
+	)
+
+	attractors := findAttractors(results, seeds)
+	if len(attractors) != 2 {
+		t.Fatalf("Expected two attractors, got %+v", attractors)
+	}
+	if attractors[0].Runs != 4 || attractors[1].Runs != 2 {
+		t.Errorf("Expected the most prevalent attractor first, got %d and %d runs", attractors[0].Runs, attractors[1].Runs)
+	}
+	if attractors[0].Humans != 50 || attractors[0].AIAgents != 199.5 {
+		t.Errorf("Expected the centre of the first cluster, got %.1f humans and %.1f AI agents", attractors[0].Humans, attractors[0].AIAgents)
+	}
+	if attractors[1].Prevalence != 2.0/6.0 || len(attractors[1].Seeds) != 2 || attractors[1].Seeds[0] != 5 {
+		t.Errorf("Expected the second attractor to hold seeds 5 and 6, got %+v", attractors[1])
+	}
+
+	engine := NewAnalyticsEngine()
+	summary := engine.GenerateMonteCarloSummary(MonteCarloResult{Runs: 6, Seeds: seeds, Attractors: attractors})
+	if !strings.Contains(summary, "**2 distinct equilibria**") {
+		t.Errorf("Expected the summary to report both equilibria, got %q", summary)
+	}
+}
+
+func TestFindAttractorsKeepsNoiseInOneEquilibrium(t *testing.T) {
+	results, seeds := finalCompositions([2]int{50, 200}, [2]int{50, 202}, [2]int{49, 204}, [2]int{50, 206}, [2]int{51, 208})
+	if attractors := findAttractors(results, seeds); len(attractors) != 1 || attractors[0].Prevalence != 1 {
+		t.Errorf("Expected small differences to form a single attractor, got %+v", attractors)
+	}
+
+	results, seeds = finalCompositions([2]int{10, 40}, [2]int{10, 40}, [2]int{10, 40})
+	if attractors := findAttractors(results, seeds); len(attractors) != 1 || attractors[0].Runs != 3 {
+		t.Errorf("Expected identical runs to form a single attractor, got %+v", attractors)
+	}
+}
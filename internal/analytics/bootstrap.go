@@ -0,0 +1,51 @@
+package analytics
+
+import (
+	"math/rand"
+	"sort"
+	"workforce-ai-transition-simulator/internal/random"
+)
+
+// bootstrapResamples is the number of resamples drawn for a bootstrap confidence interval
+const bootstrapResamples = 1000
+
+// ConfidenceInterval is a point estimate with a 95% bootstrap confidence interval
+type ConfidenceInterval struct {
+	Mean  float64
+	Lower float64
+	Upper float64
+}
+
+// MonteCarloConfidence holds bootstrap confidence intervals for the mean outcomes of Monte Carlo runs
+type MonteCarloConfidence struct {
+	TotalRevenue      ConfidenceInterval
+	TimeToEquilibrium ConfidenceInterval
+	FinalHumans       ConfidenceInterval
+	FinalAIAgents     ConfidenceInterval
+	FinalAIShare      ConfidenceInterval // fraction of the final workforce that is AI (0-1)
+}
+
+// bootstrapMean returns the mean of values with a 95% percentile bootstrap confidence interval,
+// resampling the values with replacement from a generator seeded with seed
+// With fewer than two values the interval collapses onto the mean
+func bootstrapMean(values []float64, seed int64) ConfidenceInterval {
+	if len(values) == 0 {
+		return ConfidenceInterval{}
+	}
+	mean, _ := meanAndStdDev(values)
+	if len(values) < 2 {
+		return ConfidenceInterval{Mean: mean, Lower: mean, Upper: mean}
+	}
+
+	rng := rand.New(random.NewSource(seed))
+	means := make([]float64, bootstrapResamples)
+	for r := range means {
+		sum := 0.0
+		for range values {
+			sum += values[rng.Intn(len(values))]
+		}
+		means[r] = sum / float64(len(values))
+	}
+	sort.Float64s(means)
+	return ConfidenceInterval{Mean: mean, Lower: percentile(means, 0.025), Upper: percentile(means, 0.975)}
+}
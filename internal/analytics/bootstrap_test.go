@@ -0,0 +1,24 @@
+package analytics
+
+import "testing"
+
+func TestBootstrapMean(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	interval := bootstrapMean(values, 1)
+	if interval.Mean != 5.5 {
+		t.Errorf("Expected mean 5.5, got %.2f", interval.Mean)
+	}
+	if !(interval.Lower > 1 && interval.Lower < 5.5 && interval.Upper > 5.5 && interval.Upper < 10) {
+		t.Errorf("Expected an interval around the mean within the data, got %+v", interval)
+	}
+	if again := bootstrapMean(values, 1); again != interval {
+		t.Errorf("Expected the same seed to reproduce the interval, got %+v and %+v", interval, again)
+	}
+
+	if single := bootstrapMean([]float64{3}, 1); single != (ConfidenceInterval{Mean: 3, Lower: 3, Upper: 3}) {
+		t.Errorf("Expected a single value to collapse the interval, got %+v", single)
+	}
+	if constant := bootstrapMean([]float64{2, 2, 2}, 1); constant.Lower != 2 || constant.Upper != 2 {
+		t.Errorf("Expected constant values to collapse the interval, got %+v", constant)
+	}
+}
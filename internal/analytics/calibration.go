@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// maxCalibrationRuns bounds the size of a calibration grid
+const maxCalibrationRuns = 10000
+
+// CalibrationParameter is a sensitivity parameter and the candidate values calibration tries for it
+type CalibrationParameter struct {
+	Name   string
+	Values []float64
+}
+
+// CalibrationResult is the configuration that best reproduces the observed history
+type CalibrationResult struct {
+	Config     types.SimulationConfig
+	Parameters map[string]float64 // best value of every calibrated parameter
+	Error      float64            // mean NRMSE over the observed metrics of the best configuration
+	Fits       []GoodnessOfFit    // fit of every observed metric under the best configuration
+	Runs       int                // configurations simulated
+	Failed     int                // configurations skipped because their simulation failed, e.g. over budget
+}
+
+// Calibrate searches a grid of parameter values for the configuration whose run best reproduces
+// observed history, minimizing the mean NRMSE over the observed metrics so that metrics in
+// different units weigh equally
+// Every candidate runs with the same seed, so parameter values are the only difference between them
+// Parameters are named like sensitivity parameters; see SensitivityParameterNames
+// Configurations whose simulation fails are skipped; returns an error if every one fails
+func (ae *AnalyticsEngine) Calibrate(baseConfig types.SimulationConfig, observed []ObservedSeries, parameters []CalibrationParameter, maxTimeSteps int, seed int64) (CalibrationResult, error) {
+	if len(observed) == 0 {
+		return CalibrationResult{}, errors.New("calibration needs at least one observed series")
+	}
+	if len(parameters) == 0 {
+		return CalibrationResult{}, errors.New("calibration needs at least one parameter")
+	}
+	for _, series := range observed {
+		if _, err := ae.observableMetric(series.Metric); err != nil {
+			return CalibrationResult{}, err
+		}
+	}
+	runs := 1
+	seen := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		if _, exists := parameterSetters[parameter.Name]; !exists {
+			return CalibrationResult{}, fmt.Errorf("unknown sensitivity parameter %q", parameter.Name)
+		}
+		if seen[parameter.Name] {
+			return CalibrationResult{}, fmt.Errorf("calibration parameter %s is listed twice", parameter.Name)
+		}
+		seen[parameter.Name] = true
+		if len(parameter.Values) == 0 {
+			return CalibrationResult{}, fmt.Errorf("calibration parameter %s has no values", parameter.Name)
+		}
+		runs *= len(parameter.Values)
+		if runs > maxCalibrationRuns {
+			return CalibrationResult{}, fmt.Errorf("calibration grid exceeds %d runs", maxCalibrationRuns)
+		}
+	}
+
+	best := CalibrationResult{Runs: runs}
+	var lastErr error
+	found := false
+	indices := make([]int, len(parameters))
+	for run := 0; run < runs; run++ {
+		// Decode the run number into one value index per parameter, the last parameter varying fastest
+		remainder := run
+		for p := len(parameters) - 1; p >= 0; p-- {
+			indices[p] = remainder % len(parameters[p].Values)
+			remainder /= len(parameters[p].Values)
+		}
+
+		config := baseConfig
+		values := make(map[string]float64, len(parameters))
+		for p, parameter := range parameters {
+			values[parameter.Name] = parameter.Values[indices[p]]
+			parameterSetters[parameter.Name](&config, values[parameter.Name])
+		}
+
+		meanError, fits, err := ae.calibrationError(config, observed, maxTimeSteps, seed)
+		if err != nil {
+			best.Failed++
+			lastErr = fmt.Errorf("calibration run with %v failed: %w", values, err)
+			continue
+		}
+		if !found || meanError < best.Error {
+			best.Config, best.Parameters, best.Error, best.Fits = config, values, meanError, fits
+			found = true
+		}
+	}
+	if !found {
+		return CalibrationResult{}, fmt.Errorf("every calibration run failed, the last: %w", lastErr)
+	}
+	return best, nil
+}
+
+// calibrationError runs a candidate configuration and returns its mean NRMSE against the observed history
+func (ae *AnalyticsEngine) calibrationError(config types.SimulationConfig, observed []ObservedSeries, maxTimeSteps int, seed int64) (float64, []GoodnessOfFit, error) {
+	result, err := controller.NewSimulationController(config, seed, ae.controllerOptions...).RunUntilEquilibrium(maxTimeSteps)
+	if err != nil {
+		return 0, nil, err
+	}
+	fits, err := ae.CompareObserved(result, observed)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	meanError := 0.0
+	for _, fit := range fits {
+		meanError += fit.NRMSE / float64(len(fits))
+	}
+	return meanError, fits, nil
+}
@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// observedFromRun turns a run's cost and headcount into observed history
+func observedFromRun(result types.SimulationResult) []ObservedSeries {
+	observed := []ObservedSeries{{Metric: "total_cost"}, {Metric: "human_count"}}
+	for _, state := range result.TimeSeries {
+		observed[0].Points = append(observed[0].Points, ObservedPoint{TimeStep: state.TimeStep, Value: state.TotalCost})
+		observed[1].Points = append(observed[1].Points, ObservedPoint{TimeStep: state.TimeStep, Value: float64(state.Workforce.Humans.Total)})
+	}
+	return observed
+}
+
+func TestCalibrateRecoversKnownParameters(t *testing.T) {
+	truth := testSimulationConfig()
+	truth.FixedBudget = 2000000.0
+	truth.AttritionConfig.NaturalRate = 30.0
+	actual, err := controller.NewSimulationController(truth, 7).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("Simulation failed: %v", err)
+	}
+
+	engine := NewAnalyticsEngine()
+	parameters := []CalibrationParameter{
+		{Name: "FixedBudget", Values: []float64{1000000, 2000000, 4000000}},
+		{Name: "NaturalAttritionRate", Values: []float64{10, 30}},
+	}
+	calibration, err := engine.Calibrate(testSimulationConfig(), observedFromRun(actual), parameters, 100, 7)
+	if err != nil {
+		t.Fatalf("Calibrate failed: %v", err)
+	}
+
+	// The smallest budget cannot pay for the initial workforce, so its runs fail and are skipped
+	if calibration.Runs != 6 || calibration.Failed != 2 {
+		t.Errorf("Expected 6 runs of which 2 failed, got %d and %d", calibration.Runs, calibration.Failed)
+	}
+	if calibration.Parameters["FixedBudget"] != 2000000 || calibration.Parameters["NaturalAttritionRate"] != 30 {
+		t.Errorf("Expected the true parameters, got %v", calibration.Parameters)
+	}
+	if calibration.Error != 0 || calibration.Config.FixedBudget != 2000000 || calibration.Config.AttritionConfig.NaturalRate != 30 {
+		t.Errorf("Expected an exact fit with the true configuration, got error %.4f", calibration.Error)
+	}
+	if len(calibration.Fits) != 2 {
+		t.Errorf("Expected a fit per observed metric, got %+v", calibration.Fits)
+	}
+}
+
+func TestCalibrateRejectsInvalidSearch(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	observed := []ObservedSeries{{Metric: "human_count", Points: []ObservedPoint{{0, 10}}}}
+	config := testSimulationConfig()
+
+	invalid := map[string][]CalibrationParameter{
+		"no parameters":     nil,
+		"unknown parameter": {{Name: "Morale", Values: []float64{1}}},
+		"no values":         {{Name: "FixedBudget"}},
+		"duplicate":         {{Name: "FixedBudget", Values: []float64{1}}, {Name: "FixedBudget", Values: []float64{2}}},
+		"grid too large": {
+			{Name: "FixedBudget", Values: make([]float64, 200)},
+			{Name: "NaturalAttritionRate", Values: make([]float64, 200)},
+		},
+	}
+	for name, parameters := range invalid {
+		if _, err := engine.Calibrate(config, observed, parameters, 10, 1); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+	if _, err := engine.Calibrate(config, observed, []CalibrationParameter{{Name: "FixedBudget", Values: []float64{1}}}, 10, 1); err == nil {
+		t.Error("Expected an error when every run fails")
+	}
+	if _, err := engine.Calibrate(config, nil, []CalibrationParameter{{Name: "FixedBudget", Values: []float64{1}}}, 10, 1); err == nil {
+		t.Error("Expected an error without observed data")
+	}
+}
@@ -0,0 +1,60 @@
+package analytics
+
+import (
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// cashFlowSeries holds per-step cash flows derived from a simulation time series
+type cashFlowSeries struct {
+	steps      []int
+	net        []float64 // revenue less workforce cost, penalties, severance and recruiting
+	transition []float64 // net cash flow in excess of keeping the initial workforce unchanged
+}
+
+// calculateCashFlows converts a time series into per-step cash flows
+// Revenue and workforce cost are annual run-rates, so each step contributes one
+// TimeStepsPerYear-th of them, as in SimulationState.NetCashFlow. The baseline for the transition flows keeps the initial workforce's productivity and
+// cost, earning revenue at the same per-productivity rate as the simulated run
+func calculateCashFlows(timeSeries []types.SimulationState) cashFlowSeries {
+	flows := cashFlowSeries{
+		steps:      make([]int, len(timeSeries)),
+		net:        make([]float64, len(timeSeries)),
+		transition: make([]float64, len(timeSeries)),
+	}
+	if len(timeSeries) == 0 {
+		return flows
+	}
+
+	initial := timeSeries[0]
+	revenuePerProductivity := 0.0
+
+	for i, state := range timeSeries {
+		net := state.NetCashFlow()
+
+		// Track the revenue multiplier so growth scenarios apply to the baseline too
+		if state.TotalProductivity > 0 {
+			revenuePerProductivity = state.RevenueOutput / state.TotalProductivity
+		}
+		baselineRevenue := initial.TotalProductivity * revenuePerProductivity
+		baseline := (baselineRevenue - initial.TotalCost) / types.TimeStepsPerYear
+
+		flows.steps[i] = state.TimeStep
+		flows.net[i] = net
+		flows.transition[i] = net - baseline
+	}
+
+	return flows
+}
+
+// breakEvenStep returns the first time step at which the cumulative transition cash flow
+// is positive, or -1 if the transition never pays back within the run
+func (c cashFlowSeries) breakEvenStep() int {
+	cumulative := 0.0
+	for i, cashFlow := range c.transition {
+		cumulative += cashFlow
+		if cumulative > 0 {
+			return c.steps[i]
+		}
+	}
+	return -1
+}
@@ -0,0 +1,63 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestCalculateCashFlows(t *testing.T) {
+	timeSeries := []types.SimulationState{
+		{TimeStep: 0, TotalCost: 1200000, TotalProductivity: 10, RevenueOutput: 1000000},
+		{TimeStep: 1, TotalCost: 1200000, TotalProductivity: 10, RevenueOutput: 1000000,
+			CostBreakdown: types.CostBreakdown{Severance: 60000}},
+		{TimeStep: 2, TotalCost: 1200000, TotalProductivity: 20, RevenueOutput: 2000000},
+		{TimeStep: 3, TotalCost: 1200000, TotalProductivity: 20, RevenueOutput: 2000000},
+	}
+
+	flows := calculateCashFlows(timeSeries)
+
+	// Monthly net: (1M - 1.2M) / 12 = -16666.67 at the baseline
+	if flows.transition[0] != 0 {
+		t.Errorf("Expected zero transition cash flow at step 0, got %f", flows.transition[0])
+	}
+	if flows.transition[1] != -60000 {
+		t.Errorf("Expected severance to be the only transition cash flow at step 1, got %f", flows.transition[1])
+	}
+
+	// Doubling productivity adds 1M/12 of monthly revenue over the baseline
+	expectedGain := 1000000.0 / types.TimeStepsPerYear
+	if diff := flows.transition[2] - expectedGain; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("Expected transition cash flow %f at step 2, got %f", expectedGain, flows.transition[2])
+	}
+
+	// Cumulative: -60000 after step 1, +23333 after step 2
+	if step := flows.breakEvenStep(); step != 2 {
+		t.Errorf("Expected break-even at step 2, got %d", step)
+	}
+}
+
+func TestReportSummaryDiscountedMetrics(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	result := types.SimulationResult{
+		Config: types.SimulationConfig{DiscountRate: 0.1},
+		TimeSeries: []types.SimulationState{
+			{TimeStep: 0, TotalCost: 1200000, TotalProductivity: 10, RevenueOutput: 1000000},
+			{TimeStep: 1, TotalCost: 1200000, TotalProductivity: 10, RevenueOutput: 1000000,
+				CostBreakdown: types.CostBreakdown{Severance: 60000}},
+			{TimeStep: 2, TotalCost: 1200000, TotalProductivity: 20, RevenueOutput: 2000000},
+		},
+	}
+	result.EquilibriumState = result.TimeSeries[2]
+
+	summary := engine.calculateReportSummary(result)
+
+	if summary.BreakEvenStep != 2 {
+		t.Errorf("Expected break-even at step 2, got %d", summary.BreakEvenStep)
+	}
+	if !summary.TransitionIRRDefined || summary.TransitionIRR <= 0 {
+		t.Errorf("Expected a positive IRR, got %f (defined: %t)", summary.TransitionIRR, summary.TransitionIRRDefined)
+	}
+	if summary.TransitionNPV >= 1000000.0/types.TimeStepsPerYear-60000 {
+		t.Errorf("Expected discounting to reduce transition NPV, got %f", summary.TransitionNPV)
+	}
+}
@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Aggregation is the rule for combining a metric's per-step values into one value for a run
+type Aggregation string
+
+const (
+	// AggregateSum adds the per-step values, for counts of events
+	AggregateSum Aggregation = "sum"
+	// AggregateMean averages the per-step values, for rates and ratios
+	AggregateMean Aggregation = "mean"
+	// AggregateLast takes the final value, for stocks such as headcount or budget
+	AggregateLast Aggregation = "last"
+)
+
+// MetricInfo describes a recorded metric so consumers of reports know what it measures
+type MetricInfo struct {
+	Name        string
+	Unit        string
+	Description string
+	Aggregation Aggregation
+	Derived     bool // registered with RegisterDerivedMetric rather than built in
+}
+
+// builtinMetricCatalog describes the metrics RecordTimeStep always records, in name order
+var builtinMetricCatalog = []MetricInfo{
+	{Name: "ai_agent_count", Unit: "agents", Description: "AI agents in the workforce", Aggregation: AggregateLast},
+	{Name: "ai_ratio", Unit: "%", Description: "AI agents as a percentage of the total workforce; not recorded while the workforce is empty", Aggregation: AggregateLast},
+	{Name: "ai_revenue_share", Unit: "%", Description: "Percentage of the step's revenue generated by AI agents", Aggregation: AggregateMean},
+	{Name: "available_budget", Unit: "USD", Description: "Budget remaining after the step's workforce cost", Aggregation: AggregateLast},
+	{Name: "catastrophic_failures", Unit: "failures", Description: "Catastrophic AI failures that occurred in the step", Aggregation: AggregateSum},
+	{Name: "cost_efficiency", Unit: "productivity per USD/year", Description: "Total productivity divided by annual total cost; not recorded while cost is zero", Aggregation: AggregateMean},
+	{Name: "human_count", Unit: "humans", Description: "Human workers, including the business owner", Aggregation: AggregateLast},
+	{Name: "orchestration_utilization", Unit: "%", Description: "Share of the humans' orchestration capacity used by AI agents", Aggregation: AggregateMean},
+	{Name: "revenue_output", Unit: "USD/year", Description: "Annualized revenue produced by the workforce at this step", Aggregation: AggregateMean},
+	{Name: "total_cost", Unit: "USD/year", Description: "Annualized cost of humans and AI agents at this step", Aggregation: AggregateMean},
+	{Name: "total_productivity", Unit: "productivity", Description: "Combined productivity of humans and AI agents", Aggregation: AggregateMean},
+	{Name: "total_workforce", Unit: "workers", Description: "Humans plus AI agents", Aggregation: AggregateLast},
+}
+
+// isBuiltinMetric reports whether name is one of the metrics RecordTimeStep always records
+func isBuiltinMetric(name string) bool {
+	for _, info := range builtinMetricCatalog {
+		if info.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeMetric sets the unit, description and aggregation of a registered derived metric
+// Returns an error if no derived metric with info.Name is registered
+func (ae *AnalyticsEngine) DescribeMetric(info MetricInfo) error {
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	for i, metric := range ae.derivedMetrics {
+		if metric.name == info.Name {
+			info.Derived = true
+			ae.derivedMetrics[i].info = info
+			return nil
+		}
+	}
+	if isBuiltinMetric(info.Name) {
+		return fmt.Errorf("metric %q is built in and already described", info.Name)
+	}
+	return fmt.Errorf("no derived metric %q is registered", info.Name)
+}
+
+// MetricCatalog describes every built-in and registered derived metric, in name order
+func (ae *AnalyticsEngine) MetricCatalog() []MetricInfo {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	catalog := append([]MetricInfo(nil), builtinMetricCatalog...)
+	for _, metric := range ae.derivedMetrics {
+		catalog = append(catalog, metric.info)
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+	return catalog
+}
@@ -0,0 +1,62 @@
+package analytics
+
+import (
+	"sort"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestBuiltinMetricCatalogMatchesRecordTimeStep(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	state := types.SimulationState{TotalCost: 100}
+	state.Workforce.Humans.Total = 1
+	engine.RecordTimeStep(state)
+
+	for name := range engine.GetMetrics() {
+		if !isBuiltinMetric(name) {
+			t.Errorf("Recorded metric %s is missing from the catalog", name)
+		}
+	}
+	if len(engine.GetMetrics()) != len(builtinMetricCatalog) {
+		t.Errorf("Expected %d built-in metrics, recorded %d", len(builtinMetricCatalog), len(engine.GetMetrics()))
+	}
+	for _, info := range builtinMetricCatalog {
+		if info.Unit == "" || info.Description == "" || info.Aggregation == "" || info.Derived {
+			t.Errorf("Expected a complete built-in description, got %+v", info)
+		}
+	}
+}
+
+func TestMetricCatalogIncludesDerivedMetrics(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	compute := func(state types.SimulationState) float64 { return state.TotalCost / 12 }
+	if err := engine.RegisterDerivedMetric("monthly_cost", compute); err != nil {
+		t.Fatalf("RegisterDerivedMetric failed: %v", err)
+	}
+	if err := engine.DescribeMetric(MetricInfo{Name: "monthly_cost", Unit: "USD/month", Description: "Cost per month", Aggregation: AggregateSum}); err != nil {
+		t.Fatalf("DescribeMetric failed: %v", err)
+	}
+
+	catalog := engine.MetricCatalog()
+	if len(catalog) != len(builtinMetricCatalog)+1 {
+		t.Fatalf("Expected the built-in metrics plus one derived metric, got %d", len(catalog))
+	}
+	if !sort.SliceIsSorted(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name }) {
+		t.Error("Expected the catalog in name order")
+	}
+	for _, info := range catalog {
+		if info.Name == "monthly_cost" {
+			want := MetricInfo{Name: "monthly_cost", Unit: "USD/month", Description: "Cost per month", Aggregation: AggregateSum, Derived: true}
+			if info != want {
+				t.Errorf("Catalog entry = %+v, want %+v", info, want)
+			}
+		}
+	}
+
+	if err := engine.DescribeMetric(MetricInfo{Name: "total_cost"}); err == nil {
+		t.Error("Expected an error describing a built-in metric")
+	}
+	if err := engine.DescribeMetric(MetricInfo{Name: "unregistered"}); err == nil {
+		t.Error("Expected an error describing an unregistered metric")
+	}
+}
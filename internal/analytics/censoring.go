@@ -0,0 +1,121 @@
+package analytics
+
+import (
+	"fmt"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// CensoredRunPolicy controls how sensitivity analytics treat runs that hit the step limit
+// without reaching equilibrium. Their TimeToEquilibrium is only a lower bound, so treating
+// it like a real equilibrium time understates the impact of parameters that prevent equilibrium
+type CensoredRunPolicy int
+
+const (
+	// IncludeCensored uses the step limit as the time to equilibrium, as if equilibrium was reached
+	IncludeCensored CensoredRunPolicy = iota
+	// ExcludeCensored leaves censored runs out of time and composition statistics
+	ExcludeCensored
+	// PenalizeCensored multiplies the time of censored runs by the configured penalty factor
+	PenalizeCensored
+)
+
+// DefaultCensoredPenaltyFactor is the multiplier applied to censored run times under PenalizeCensored
+const DefaultCensoredPenaltyFactor = 2.0
+
+// String returns the string representation of CensoredRunPolicy
+func (p CensoredRunPolicy) String() string {
+	switch p {
+	case IncludeCensored:
+		return "include"
+	case ExcludeCensored:
+		return "exclude"
+	case PenalizeCensored:
+		return "penalize"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCensoredRunPolicy converts a policy name ("include", "exclude" or "penalize") to a CensoredRunPolicy
+func ParseCensoredRunPolicy(name string) (CensoredRunPolicy, error) {
+	switch name {
+	case "include":
+		return IncludeCensored, nil
+	case "exclude":
+		return ExcludeCensored, nil
+	case "penalize":
+		return PenalizeCensored, nil
+	default:
+		return IncludeCensored, fmt.Errorf("unknown censored run policy %q (expected include, exclude or penalize)", name)
+	}
+}
+
+// SetCensoredRunPolicy sets how sensitivity rankings and summaries treat runs that did not reach
+// equilibrium. penaltyFactor is only used by PenalizeCensored and must be at least 1
+func (ae *AnalyticsEngine) SetCensoredRunPolicy(policy CensoredRunPolicy, penaltyFactor float64) error {
+	if policy < IncludeCensored || policy > PenalizeCensored {
+		return fmt.Errorf("unknown censored run policy %d", policy)
+	}
+	if policy == PenalizeCensored && penaltyFactor < 1.0 {
+		return fmt.Errorf("censored penalty factor must be at least 1, got %.2f", penaltyFactor)
+	}
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	ae.censoredPolicy = policy
+	ae.censoredPenaltyFactor = penaltyFactor
+	return nil
+}
+
+// CensoredRunPolicy returns the current policy for runs that did not reach equilibrium
+func (ae *AnalyticsEngine) CensoredRunPolicy() CensoredRunPolicy {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	return ae.censoredPolicy
+}
+
+// effectiveTimeToEquilibrium returns the time to equilibrium to use in statistics for a run,
+// and whether the run should be included at all under the current censored run policy
+func (ae *AnalyticsEngine) effectiveTimeToEquilibrium(result types.SimulationResult) (float64, bool) {
+	ae.mu.RLock()
+	policy, penaltyFactor := ae.censoredPolicy, ae.censoredPenaltyFactor
+	ae.mu.RUnlock()
+
+	timeToEquilibrium := float64(result.TimeToEquilibrium)
+	if result.ReachedEquilibrium {
+		return timeToEquilibrium, true
+	}
+
+	switch policy {
+	case ExcludeCensored:
+		return 0, false
+	case PenalizeCensored:
+		return timeToEquilibrium * penaltyFactor, true
+	default:
+		return timeToEquilibrium, true
+	}
+}
+
+// includeInComposition reports whether a run's final composition counts as an equilibrium
+// composition under the current censored run policy
+func (ae *AnalyticsEngine) includeInComposition(result types.SimulationResult) bool {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	return result.ReachedEquilibrium || ae.censoredPolicy != ExcludeCensored
+}
+
+// countCensoredRuns counts the runs that did not reach equilibrium
+func countCensoredRuns(sensitivityResults map[string]SensitivityResults) int {
+	count := 0
+	for _, results := range sensitivityResults {
+		for _, result := range results.Results {
+			if !result.ReachedEquilibrium {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// censoredTestResults returns one parameter sweep where the last value never reaches equilibrium
+func censoredTestResults() map[string]SensitivityResults {
+	return map[string]SensitivityResults{
+		"FixedBudget": {
+			ParameterName:   "FixedBudget",
+			ParameterValues: []float64{1.0, 2.0, 3.0},
+			Results: []types.SimulationResult{
+				{TimeToEquilibrium: 10, ReachedEquilibrium: true},
+				{TimeToEquilibrium: 12, ReachedEquilibrium: true},
+				{TimeToEquilibrium: 100, ReachedEquilibrium: false},
+			},
+		},
+	}
+}
+
+func TestCensoredRunPolicyInclude(t *testing.T) {
+	engine := NewAnalyticsEngine()
+
+	summary := engine.CalculateSensitivitySummary(censoredTestResults())
+	if summary.AverageTimeToEquilibrium != (10.0+12.0+100.0)/3.0 {
+		t.Errorf("Expected censored run to count at the step limit, got average %f", summary.AverageTimeToEquilibrium)
+	}
+	if summary.CensoredRuns != 1 {
+		t.Errorf("Expected 1 censored run, got %d", summary.CensoredRuns)
+	}
+	if summary.CensoredRunPolicy != "include" {
+		t.Errorf("Expected include policy by default, got %s", summary.CensoredRunPolicy)
+	}
+}
+
+func TestCensoredRunPolicyExclude(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetCensoredRunPolicy(ExcludeCensored, 0); err != nil {
+		t.Fatalf("SetCensoredRunPolicy failed: %v", err)
+	}
+
+	summary := engine.CalculateSensitivitySummary(censoredTestResults())
+	if summary.AverageTimeToEquilibrium != 11.0 {
+		t.Errorf("Expected censored run to be excluded, got average %f", summary.AverageTimeToEquilibrium)
+	}
+	if summary.OptimalParameterValues["FixedBudget"] != 1.0 {
+		t.Errorf("Expected optimal value 1.0, got %f", summary.OptimalParameterValues["FixedBudget"])
+	}
+}
+
+func TestCensoredRunPolicyPenalize(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetCensoredRunPolicy(PenalizeCensored, 3.0); err != nil {
+		t.Fatalf("SetCensoredRunPolicy failed: %v", err)
+	}
+
+	values := engine.extractTimeToEquilibrium(censoredTestResults()["FixedBudget"])
+	if len(values) != 3 || values[2] != 300.0 {
+		t.Errorf("Expected censored run time to be tripled, got %v", values)
+	}
+
+	if err := engine.SetCensoredRunPolicy(PenalizeCensored, 0.5); err == nil {
+		t.Error("Expected error for penalty factor below 1")
+	}
+}
+
+func TestParseCensoredRunPolicy(t *testing.T) {
+	for _, policy := range []CensoredRunPolicy{IncludeCensored, ExcludeCensored, PenalizeCensored} {
+		parsed, err := ParseCensoredRunPolicy(policy.String())
+		if err != nil || parsed != policy {
+			t.Errorf("Expected %s to round trip, got %v (%v)", policy, parsed, err)
+		}
+	}
+
+	if _, err := ParseCensoredRunPolicy("ignore"); err == nil {
+		t.Error("Expected error for unknown policy name")
+	}
+}
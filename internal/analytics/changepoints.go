@@ -0,0 +1,224 @@
+package analytics
+
+import (
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// TransitionPhase classifies a stretch of a run by how much of the workforce is AI
+type TransitionPhase int
+
+const (
+	// HumanDominant phases have less than a third of the workforce as AI agents
+	HumanDominant TransitionPhase = iota
+	// MixedWorkforce phases have between a third and two thirds of the workforce as AI agents
+	MixedWorkforce
+	// AIDominant phases have more than two thirds of the workforce as AI agents
+	AIDominant
+)
+
+// String returns the string representation of TransitionPhase
+func (p TransitionPhase) String() string {
+	switch p {
+	case HumanDominant:
+		return "Human_Dominant"
+	case MixedWorkforce:
+		return "Mixed"
+	case AIDominant:
+		return "AI_Dominant"
+	default:
+		return "Unknown"
+	}
+}
+
+// classifyPhase returns the phase of a workforce with the given fraction of AI agents (0-1)
+func classifyPhase(aiShare float64) TransitionPhase {
+	switch {
+	case aiShare > 2.0/3.0:
+		return AIDominant
+	case aiShare >= 1.0/3.0:
+		return MixedWorkforce
+	default:
+		return HumanDominant
+	}
+}
+
+// Phase is a stretch of a run between changepoints, with the averages that characterize it
+type Phase struct {
+	Label        TransitionPhase
+	StartStep    int // time step of the first state in the phase
+	EndStep      int // time step of the last state in the phase
+	MeanCost     float64
+	MeanHumans   float64
+	MeanAIAgents float64
+	MeanAIShare  float64 // mean fraction of the workforce that is AI (0-1)
+}
+
+const (
+	// minPhaseLength is the fewest time steps a segment between changepoints may span
+	minPhaseLength = 3
+	// maxChangepoints bounds the number of changepoints found in a run
+	maxChangepoints = 8
+)
+
+// DetectChangepoints finds the time steps where the cost and headcount series of a run shift to a
+// new level, by binary segmentation: the segment split that most reduces the squared error around
+// segment means is taken repeatedly while the reduction exceeds a BIC-style penalty
+// Each series is standardized first so cost and headcount weigh equally
+// Returns the time steps that start a new segment, in order
+func DetectChangepoints(timeSeries []types.SimulationState) []int {
+	series := changepointSeries(timeSeries)
+	if len(series) == 0 {
+		return []int{}
+	}
+	n := len(timeSeries)
+	penalty := float64(len(series)) * math.Log(float64(n))
+
+	// Prefix sums of each series and its squares give the squared error of any segment in constant time
+	sums := make([][]float64, len(series))
+	squares := make([][]float64, len(series))
+	for d, values := range series {
+		sums[d] = make([]float64, n+1)
+		squares[d] = make([]float64, n+1)
+		for i, value := range values {
+			sums[d][i+1] = sums[d][i] + value
+			squares[d][i+1] = squares[d][i] + value*value
+		}
+	}
+	segmentError := func(start, end int) float64 {
+		total := 0.0
+		length := float64(end - start)
+		for d := range series {
+			sum := sums[d][end] - sums[d][start]
+			total += squares[d][end] - squares[d][start] - sum*sum/length
+		}
+		return total
+	}
+
+	// Segments are [start, end) index ranges; boundaries holds their starts after the first
+	boundaries := []int{0, n}
+	for len(boundaries)-2 < maxChangepoints {
+		bestGain, bestSplit := penalty, -1
+		for b := 0; b+1 < len(boundaries); b++ {
+			start, end := boundaries[b], boundaries[b+1]
+			whole := segmentError(start, end)
+			for split := start + minPhaseLength; split <= end-minPhaseLength; split++ {
+				gain := whole - segmentError(start, split) - segmentError(split, end)
+				if gain > bestGain {
+					bestGain, bestSplit = gain, split
+				}
+			}
+		}
+		if bestSplit < 0 {
+			break
+		}
+		boundaries = insertSorted(boundaries, bestSplit)
+	}
+
+	changepoints := make([]int, 0, len(boundaries)-2)
+	for _, boundary := range boundaries[1 : len(boundaries)-1] {
+		changepoints = append(changepoints, timeSeries[boundary].TimeStep)
+	}
+	return changepoints
+}
+
+// DetectPhases splits a run at its changepoints and labels each segment by its mean AI share of
+// the workforce, merging adjacent segments with the same label into one phase
+func DetectPhases(timeSeries []types.SimulationState) []Phase {
+	if len(timeSeries) == 0 {
+		return []Phase{}
+	}
+
+	starts := []int{0}
+	changepoints := DetectChangepoints(timeSeries)
+	for i, state := range timeSeries {
+		if len(changepoints) > 0 && state.TimeStep == changepoints[0] {
+			starts = append(starts, i)
+			changepoints = changepoints[1:]
+		}
+	}
+	starts = append(starts, len(timeSeries))
+
+	phases := make([]Phase, 0, len(starts)-1)
+	phaseStart := 0
+	for s := 1; s < len(starts); s++ {
+		segment := summarizePhase(timeSeries[phaseStart:starts[s]])
+		last := s+1 == len(starts)
+		if !last && summarizePhase(timeSeries[starts[s]:starts[s+1]]).Label == segment.Label {
+			continue
+		}
+		phases = append(phases, segment)
+		phaseStart = starts[s]
+	}
+	return phases
+}
+
+// summarizePhase computes the averages and label of a non-empty stretch of states
+func summarizePhase(states []types.SimulationState) Phase {
+	phase := Phase{StartStep: states[0].TimeStep, EndStep: states[len(states)-1].TimeStep}
+	for _, state := range states {
+		phase.MeanCost += state.TotalCost
+		phase.MeanHumans += float64(state.Workforce.Humans.Total)
+		phase.MeanAIAgents += float64(state.Workforce.AIAgents.Total)
+		phase.MeanAIShare += aiWorkforceShare(state)
+	}
+	count := float64(len(states))
+	phase.MeanCost /= count
+	phase.MeanHumans /= count
+	phase.MeanAIAgents /= count
+	phase.MeanAIShare /= count
+	phase.Label = classifyPhase(phase.MeanAIShare)
+	return phase
+}
+
+// aiWorkforceShare returns the fraction of a state's workforce that is AI agents (0-1)
+func aiWorkforceShare(state types.SimulationState) float64 {
+	total := state.Workforce.Humans.Total + state.Workforce.AIAgents.Total
+	if total == 0 {
+		return 0
+	}
+	return float64(state.Workforce.AIAgents.Total) / float64(total)
+}
+
+// changepointSeries returns the standardized cost, human and AI agent series of a run, leaving
+// out series that never change
+func changepointSeries(timeSeries []types.SimulationState) [][]float64 {
+	raw := [][]float64{
+		make([]float64, len(timeSeries)),
+		make([]float64, len(timeSeries)),
+		make([]float64, len(timeSeries)),
+	}
+	for i, state := range timeSeries {
+		raw[0][i] = state.TotalCost
+		raw[1][i] = float64(state.Workforce.Humans.Total)
+		raw[2][i] = float64(state.Workforce.AIAgents.Total)
+	}
+
+	series := make([][]float64, 0, len(raw))
+	for _, values := range raw {
+		if len(values) == 0 {
+			continue
+		}
+		mean, stdDev := meanAndStdDev(values)
+		if stdDev == 0 {
+			continue
+		}
+		for i := range values {
+			values[i] = (values[i] - mean) / stdDev
+		}
+		series = append(series, values)
+	}
+	return series
+}
+
+// insertSorted inserts value into an ascending slice, keeping it sorted
+func insertSorted(values []int, value int) []int {
+	i := 0
+	for i < len(values) && values[i] < value {
+		i++
+	}
+	values = append(values, 0)
+	copy(values[i+1:], values[i:])
+	values[i] = value
+	return values
+}
@@ -0,0 +1,94 @@
+package analytics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// phasedTimeSeries builds a run of 10 human-only steps, 10 mixed steps and 10 AI-dominant steps,
+// with a little noise in cost so the segments are not perfectly flat
+func phasedTimeSeries() []types.SimulationState {
+	timeSeries := make([]types.SimulationState, 30)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i + 1
+		timeSeries[i].Workforce.Humans.Total = 20
+		timeSeries[i].TotalCost = 2000 + float64(i%3)*5
+		switch {
+		case i >= 20:
+			timeSeries[i].Workforce.Humans.Total = 10
+			timeSeries[i].Workforce.AIAgents.Total = 40
+			timeSeries[i].TotalCost = 1500 + float64(i%3)*5
+		case i >= 10:
+			timeSeries[i].Workforce.AIAgents.Total = 20
+			timeSeries[i].TotalCost = 2400 + float64(i%3)*5
+		}
+	}
+	return timeSeries
+}
+
+func TestDetectChangepoints(t *testing.T) {
+	changepoints := DetectChangepoints(phasedTimeSeries())
+	if want := []int{11, 21}; !reflect.DeepEqual(changepoints, want) {
+		t.Errorf("DetectChangepoints() = %v, want %v", changepoints, want)
+	}
+}
+
+func TestDetectChangepointsIgnoresConstantRun(t *testing.T) {
+	timeSeries := make([]types.SimulationState, 20)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].TotalCost = 1000
+		timeSeries[i].Workforce.Humans.Total = 10
+	}
+	if changepoints := DetectChangepoints(timeSeries); len(changepoints) != 0 {
+		t.Errorf("Expected no changepoints in a constant run, got %v", changepoints)
+	}
+	if phases := DetectPhases(timeSeries); len(phases) != 1 || phases[0].Label != HumanDominant || phases[0].StartStep != 0 || phases[0].EndStep != 19 {
+		t.Errorf("Expected a single human-dominant phase, got %+v", phases)
+	}
+}
+
+func TestDetectPhases(t *testing.T) {
+	phases := DetectPhases(phasedTimeSeries())
+	if len(phases) != 3 {
+		t.Fatalf("Expected 3 phases, got %+v", phases)
+	}
+	want := []struct {
+		label      TransitionPhase
+		start, end int
+	}{
+		{HumanDominant, 1, 10},
+		{MixedWorkforce, 11, 20},
+		{AIDominant, 21, 30},
+	}
+	for i, phase := range phases {
+		if phase.Label != want[i].label || phase.StartStep != want[i].start || phase.EndStep != want[i].end {
+			t.Errorf("Phase %d = %s from %d to %d, want %s from %d to %d", i, phase.Label, phase.StartStep, phase.EndStep,
+				want[i].label, want[i].start, want[i].end)
+		}
+	}
+	if math.Abs(phases[2].MeanAIShare-0.8) > 1e-9 || phases[2].MeanHumans != 10 {
+		t.Errorf("Expected the AI-dominant phase to average 10 humans and an AI share of 0.8, got %+v", phases[2])
+	}
+}
+
+func TestDetectPhasesMergesSameLabel(t *testing.T) {
+	// A cost shift without a change in composition is a changepoint but not a new phase
+	timeSeries := make([]types.SimulationState, 20)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].Workforce.Humans.Total = 10
+		timeSeries[i].TotalCost = 1000
+		if i >= 10 {
+			timeSeries[i].TotalCost = 3000
+		}
+	}
+	if changepoints := DetectChangepoints(timeSeries); !reflect.DeepEqual(changepoints, []int{10}) {
+		t.Errorf("DetectChangepoints() = %v, want [10]", changepoints)
+	}
+	if phases := DetectPhases(timeSeries); len(phases) != 1 || phases[0].EndStep != 19 {
+		t.Errorf("Expected the two segments to merge into one phase, got %+v", phases)
+	}
+}
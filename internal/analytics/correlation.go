@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// CorrelationMatrix holds the pairwise correlations between recorded metrics across a run
+// Cells are nil where a correlation is undefined because one of the metrics never changes
+type CorrelationMatrix struct {
+	Metrics  []string
+	Steps    int // number of recorded steps the correlations are computed over
+	Pearson  [][]*float64
+	Spearman [][]*float64
+}
+
+// CorrelationMatrix computes the Pearson and Spearman correlations between every pair of
+// recorded metrics, in name order
+// Only metrics recorded at every step are included, so their values line up step by step;
+// metrics that are skipped on some steps, such as cost_efficiency while cost is zero, are left out
+func (ae *AnalyticsEngine) CorrelationMatrix() CorrelationMatrix {
+	names := make([]string, 0)
+	recorded := make([][]float64, 0)
+	steps := 0
+	ae.ForEachMetric(func(name string, values []float64) bool {
+		names = append(names, name)
+		recorded = append(recorded, append([]float64(nil), values...))
+		steps = maxInt(steps, len(values))
+		return true
+	})
+
+	matrix := CorrelationMatrix{Metrics: make([]string, 0), Steps: steps}
+	series := make([][]float64, 0)
+	ranks := make([][]float64, 0)
+	for i, values := range recorded {
+		if len(values) == steps && steps > 0 {
+			matrix.Metrics = append(matrix.Metrics, names[i])
+			series = append(series, values)
+			ranks = append(ranks, rankValues(values))
+		}
+	}
+
+	matrix.Pearson = make([][]*float64, len(series))
+	matrix.Spearman = make([][]*float64, len(series))
+	for i := range series {
+		matrix.Pearson[i] = make([]*float64, len(series))
+		matrix.Spearman[i] = make([]*float64, len(series))
+		for j := range series {
+			matrix.Pearson[i][j] = pearson(series[i], series[j])
+			matrix.Spearman[i][j] = pearson(ranks[i], ranks[j])
+		}
+	}
+	return matrix
+}
+
+// pearson returns the Pearson correlation of two equally long series, or nil if either is constant
+func pearson(a []float64, b []float64) *float64 {
+	meanA, stdDevA := meanAndStdDev(a)
+	meanB, stdDevB := meanAndStdDev(b)
+	if stdDevA == 0 || stdDevB == 0 {
+		return nil
+	}
+
+	covariance := 0.0
+	for i := range a {
+		covariance += (a[i] - meanA) * (b[i] - meanB)
+	}
+	correlation := covariance / float64(len(a)) / (stdDevA * stdDevB)
+	correlation = math.Max(-1, math.Min(1, correlation))
+	return &correlation
+}
+
+// rankValues returns the rank of each value (1 for the smallest), averaging the ranks of ties
+func rankValues(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+
+	ranks := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		// Positions start..end-1 hold equal values and share the mean of ranks start+1..end
+		rank := float64(start+end+1) / 2.0
+		for _, index := range order[start:end] {
+			ranks[index] = rank
+		}
+		start = end
+	}
+	return ranks
+}
+
+// CorrelationCSV formats a correlation matrix as CSV rows, one per pair of metrics
+// Undefined correlations are left empty
+func CorrelationCSV(matrix CorrelationMatrix) [][]string {
+	data := make([][]string, 0, len(matrix.Metrics)*len(matrix.Metrics)+1)
+	data = append(data, []string{"MetricA", "MetricB", "Pearson", "Spearman"})
+	for i, a := range matrix.Metrics {
+		for j, b := range matrix.Metrics {
+			data = append(data, []string{a, b, formatCorrelation(matrix.Pearson[i][j]), formatCorrelation(matrix.Spearman[i][j])})
+		}
+	}
+	return data
+}
+
+// formatCorrelation formats a correlation cell, leaving undefined correlations empty
+func formatCorrelation(correlation *float64) string {
+	if correlation == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.4f", *correlation)
+}
+
+// WriteCorrelationCSV writes a correlation matrix as CSV
+func WriteCorrelationCSV(matrix CorrelationMatrix, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(CorrelationCSV(matrix)); err != nil {
+		return fmt.Errorf("failed to write correlation CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteCorrelationJSON writes a correlation matrix as JSON; undefined correlations are null
+func WriteCorrelationJSON(matrix CorrelationMatrix, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(matrix); err != nil {
+		return fmt.Errorf("failed to encode correlation matrix: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestCorrelationMatrix(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	for i := 0; i < 5; i++ {
+		// Cost grows linearly, revenue grows with its square (monotonic but not linear),
+		// humans fall and AI agents stay constant
+		engine.RecordTimeStep(types.SimulationState{
+			TimeStep:      i,
+			TotalCost:     float64(100 + 10*i),
+			RevenueOutput: float64((i + 1) * (i + 1)),
+			Workforce: types.WorkforceComposition{
+				Humans: types.HumanComposition{Total: 10 - i},
+			},
+		})
+	}
+
+	matrix := engine.CorrelationMatrix()
+	if matrix.Steps != 5 {
+		t.Errorf("Expected 5 steps, got %d", matrix.Steps)
+	}
+	index := make(map[string]int)
+	for i, name := range matrix.Metrics {
+		index[name] = i
+	}
+	if _, exists := index["ai_ratio"]; !exists {
+		t.Fatalf("Expected metrics recorded at every step, got %v", matrix.Metrics)
+	}
+
+	cost, revenue, humans, agents := index["total_cost"], index["revenue_output"], index["human_count"], index["ai_agent_count"]
+	if got := *matrix.Spearman[cost][revenue]; math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected a Spearman correlation of 1 between monotonic series, got %.4f", got)
+	}
+	if got := *matrix.Pearson[cost][revenue]; got >= 1-1e-9 || got < 0.9 {
+		t.Errorf("Expected a Pearson correlation just below 1 for a curved relationship, got %.4f", got)
+	}
+	if got := *matrix.Pearson[cost][humans]; math.Abs(got+1) > 1e-9 {
+		t.Errorf("Expected a Pearson correlation of -1 against falling headcount, got %.4f", got)
+	}
+	if matrix.Pearson[cost][agents] != nil || matrix.Spearman[agents][agents] != nil {
+		t.Error("Expected undefined correlations for a constant metric")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCorrelationJSON(matrix, &buf); err != nil {
+		t.Fatalf("WriteCorrelationJSON failed: %v", err)
+	}
+	var decoded CorrelationMatrix
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode correlation JSON: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Metrics, matrix.Metrics) || decoded.Pearson[cost][agents] != nil {
+		t.Errorf("Expected the matrix to round-trip with null cells, got %+v", decoded)
+	}
+
+	rows := CorrelationCSV(matrix)
+	if len(rows) != len(matrix.Metrics)*len(matrix.Metrics)+1 {
+		t.Errorf("Expected a CSV row per pair of metrics, got %d rows", len(rows))
+	}
+}
+
+func TestRankValuesAveragesTies(t *testing.T) {
+	ranks := rankValues([]float64{30, 10, 20, 10})
+	if want := []float64{4, 1.5, 3, 1.5}; !reflect.DeepEqual(ranks, want) {
+		t.Errorf("rankValues() = %v, want %v", ranks, want)
+	}
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"fmt"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// CounterfactualStep is the step-aligned difference between a modified run and its baseline
+// Every delta is modified minus baseline
+type CounterfactualStep struct {
+	TimeStep                      int
+	HumanCountDelta               int
+	AIAgentCountDelta             int
+	TotalCostDelta                float64
+	AvailableBudgetDelta          float64
+	TotalProductivityDelta        float64
+	RevenueOutputDelta            float64
+	OrchestrationUtilizationDelta float64
+	CatastrophicFailuresDelta     int
+}
+
+// CounterfactualComparison holds both runs of a counterfactual experiment and their differences
+type CounterfactualComparison struct {
+	Seed                   int64
+	Baseline               types.SimulationResult
+	Modified               types.SimulationResult
+	Steps                  []CounterfactualStep
+	TimeToEquilibriumDelta int
+	TotalRevenueDelta      float64
+	FinalHumanCountDelta   int
+	FinalAIAgentCountDelta int
+}
+
+// CompareCounterfactual runs a baseline and a modified configuration with the same seed and
+// returns a step-aligned diff of their states
+// Both runs derive identical random streams from the seed, so differences between them are
+// caused by the parameter change rather than by random noise. When one run stops earlier
+// (at equilibrium) its final state is held constant for the remaining steps of the other run
+func (ae *AnalyticsEngine) CompareCounterfactual(config types.SimulationConfig, modifiedConfig types.SimulationConfig, seed int64, maxTimeSteps int) (CounterfactualComparison, error) {
+	baseline, err := controller.NewSimulationController(config, seed, ae.controllerOptions...).RunUntilEquilibrium(maxTimeSteps)
+	if err != nil {
+		return CounterfactualComparison{}, fmt.Errorf("baseline simulation failed: %w", err)
+	}
+
+	modified, err := controller.NewSimulationController(modifiedConfig, seed, ae.controllerOptions...).RunUntilEquilibrium(maxTimeSteps)
+	if err != nil {
+		return CounterfactualComparison{}, fmt.Errorf("modified simulation failed: %w", err)
+	}
+
+	return CounterfactualComparison{
+		Seed:                   seed,
+		Baseline:               baseline,
+		Modified:               modified,
+		Steps:                  alignCounterfactualSteps(baseline.TimeSeries, modified.TimeSeries),
+		TimeToEquilibriumDelta: modified.TimeToEquilibrium - baseline.TimeToEquilibrium,
+		TotalRevenueDelta:      sumRevenue(modified.TimeSeries) - sumRevenue(baseline.TimeSeries),
+		FinalHumanCountDelta:   modified.EquilibriumState.Workforce.Humans.Total - baseline.EquilibriumState.Workforce.Humans.Total,
+		FinalAIAgentCountDelta: modified.EquilibriumState.Workforce.AIAgents.Total - baseline.EquilibriumState.Workforce.AIAgents.Total,
+	}, nil
+}
+
+// CompareSharedLearning measures the contribution of shared learning to a run by comparing it
+// with the same run without shared learning, which is the baseline of the comparison
+// A negative TimeToEquilibriumDelta means shared learning brought equilibrium forward
+func (ae *AnalyticsEngine) CompareSharedLearning(config types.SimulationConfig, seed int64, maxTimeSteps int) (CounterfactualComparison, error) {
+	baseline := config
+	baseline.SharedLearning = types.SharedLearningConfig{}
+	return ae.CompareCounterfactual(baseline, config, seed, maxTimeSteps)
+}
+
+// alignCounterfactualSteps diffs two time series step by step, holding the last state of the shorter series
+func alignCounterfactualSteps(baseline []types.SimulationState, modified []types.SimulationState) []CounterfactualStep {
+	if len(baseline) == 0 || len(modified) == 0 {
+		return []CounterfactualStep{}
+	}
+
+	length := len(baseline)
+	if len(modified) > length {
+		length = len(modified)
+	}
+
+	steps := make([]CounterfactualStep, length)
+	for i := 0; i < length; i++ {
+		b := baseline[minInt(i, len(baseline)-1)]
+		m := modified[minInt(i, len(modified)-1)]
+
+		steps[i] = CounterfactualStep{
+			TimeStep:                      i,
+			HumanCountDelta:               m.Workforce.Humans.Total - b.Workforce.Humans.Total,
+			AIAgentCountDelta:             m.Workforce.AIAgents.Total - b.Workforce.AIAgents.Total,
+			TotalCostDelta:                m.TotalCost - b.TotalCost,
+			AvailableBudgetDelta:          m.AvailableBudget - b.AvailableBudget,
+			TotalProductivityDelta:        m.TotalProductivity - b.TotalProductivity,
+			RevenueOutputDelta:            m.RevenueOutput - b.RevenueOutput,
+			OrchestrationUtilizationDelta: m.Workforce.OrchestrationUtilization - b.Workforce.OrchestrationUtilization,
+			CatastrophicFailuresDelta:     m.CatastrophicFailures - b.CatastrophicFailures,
+		}
+	}
+
+	return steps
+}
+
+// sumRevenue totals the revenue output over a time series
+func sumRevenue(timeSeries []types.SimulationState) float64 {
+	total := 0.0
+	for _, state := range timeSeries {
+		total += state.RevenueOutput
+	}
+	return total
+}
+
+// minInt returns the smaller of two ints
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
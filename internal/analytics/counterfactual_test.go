@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// testSimulationConfig returns a small configuration with attrition and failures enabled
+func testSimulationConfig() types.SimulationConfig {
+	return types.SimulationConfig{
+		InitialHumans: 10,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     3000000.0,
+		RevenueScenario: types.FlatRevenue,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        20.0,
+			ForcedAcceleration: 1.0,
+		},
+		CatastrophicFailureRate: 0.05,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+func TestCompareCounterfactualIdenticalConfigs(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+
+	comparison, err := engine.CompareCounterfactual(config, config, 7, 100)
+	if err != nil {
+		t.Fatalf("CompareCounterfactual failed: %v", err)
+	}
+
+	if len(comparison.Steps) == 0 {
+		t.Fatal("Expected aligned steps")
+	}
+
+	// Same config and seed means identical random streams, so there must be no difference at all
+	for _, step := range comparison.Steps {
+		if step.HumanCountDelta != 0 || step.AIAgentCountDelta != 0 || step.TotalCostDelta != 0 || step.RevenueOutputDelta != 0 {
+			t.Fatalf("Expected zero deltas at step %d, got %+v", step.TimeStep, step)
+		}
+	}
+	if comparison.TimeToEquilibriumDelta != 0 || comparison.TotalRevenueDelta != 0 {
+		t.Errorf("Expected zero summary deltas, got %d and %.2f", comparison.TimeToEquilibriumDelta, comparison.TotalRevenueDelta)
+	}
+}
+
+func TestCompareCounterfactualBudgetChange(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	config.FixedBudget = 1500000.0 // Budget-bound rather than orchestration-bound
+	modified := config
+	modified.FixedBudget = config.FixedBudget * 1.5
+
+	comparison, err := engine.CompareCounterfactual(config, modified, 7, 100)
+	if err != nil {
+		t.Fatalf("CompareCounterfactual failed: %v", err)
+	}
+
+	expectedLength := len(comparison.Baseline.TimeSeries)
+	if len(comparison.Modified.TimeSeries) > expectedLength {
+		expectedLength = len(comparison.Modified.TimeSeries)
+	}
+	if len(comparison.Steps) != expectedLength {
+		t.Errorf("Expected %d aligned steps, got %d", expectedLength, len(comparison.Steps))
+	}
+
+	// The initial state does not depend on the budget
+	if comparison.Steps[0].HumanCountDelta != 0 || comparison.Steps[0].AIAgentCountDelta != 0 {
+		t.Errorf("Expected no difference in the initial state, got %+v", comparison.Steps[0])
+	}
+
+	// A larger budget funds more agents
+	if comparison.FinalAIAgentCountDelta <= 0 {
+		t.Errorf("Expected a larger budget to yield more agents, got delta %d", comparison.FinalAIAgentCountDelta)
+	}
+}
+
+func TestAlignCounterfactualStepsHoldsFinalState(t *testing.T) {
+	baseline := []types.SimulationState{{TimeStep: 0, TotalCost: 100}, {TimeStep: 1, TotalCost: 200}}
+	modified := []types.SimulationState{{TimeStep: 0, TotalCost: 100}, {TimeStep: 1, TotalCost: 250}, {TimeStep: 2, TotalCost: 300}}
+
+	steps := alignCounterfactualSteps(baseline, modified)
+	if len(steps) != 3 {
+		t.Fatalf("Expected 3 steps, got %d", len(steps))
+	}
+	if steps[2].TotalCostDelta != 100 {
+		t.Errorf("Expected baseline final state to be held (delta 100), got %.2f", steps[2].TotalCostDelta)
+	}
+}
+
+func TestCompareSharedLearning(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	config.SharedLearning = types.SharedLearningConfig{Acceleration: 0.5}
+
+	comparison, err := engine.CompareSharedLearning(config, 42, 60)
+	if err != nil {
+		t.Fatalf("CompareSharedLearning failed: %v", err)
+	}
+	if comparison.Baseline.Config.SharedLearning != (types.SharedLearningConfig{}) {
+		t.Errorf("Expected a baseline without shared learning, got %+v", comparison.Baseline.Config.SharedLearning)
+	}
+	if comparison.Modified.Config.SharedLearning != config.SharedLearning {
+		t.Errorf("Expected the modified run to keep shared learning, got %+v", comparison.Modified.Config.SharedLearning)
+	}
+	if comparison.TimeToEquilibriumDelta != comparison.Modified.TimeToEquilibrium-comparison.Baseline.TimeToEquilibrium {
+		t.Errorf("Inconsistent time to equilibrium delta %d", comparison.TimeToEquilibriumDelta)
+	}
+
+	summary := engine.GenerateSharedLearningSummary(comparison)
+	if !strings.HasPrefix(summary, "## Shared Learning") {
+		t.Errorf("Expected a Shared Learning section, got %q", summary)
+	}
+}
@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// DecisionsCSV formats a decision trail as CSV rows, one per reviewed decision, with agent IDs
+// joined by semicolons
+func DecisionsCSV(decisions []types.OptimizerDecision) [][]string {
+	data := make([][]string, 0, len(decisions)+1)
+	data = append(data, []string{"TimeStep", "ProposedHires", "ProposedReleases", "Verdict", "Reason", "Hires", "Releases"})
+	for _, decision := range decisions {
+		data = append(data, []string{
+			fmt.Sprintf("%d", decision.TimeStep),
+			fmt.Sprintf("%d", decision.ProposedHires),
+			strings.Join(decision.ProposedReleases, ";"),
+			decision.Verdict.String(),
+			decision.Reason,
+			fmt.Sprintf("%d", decision.Hires),
+			strings.Join(decision.Releases, ";"),
+		})
+	}
+	return data
+}
+
+// WriteDecisionsCSV writes a decision trail as CSV
+func WriteDecisionsCSV(decisions []types.OptimizerDecision, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(DecisionsCSV(decisions)); err != nil {
+		return fmt.Errorf("failed to write decisions CSV: %w", err)
+	}
+	return nil
+}
+
+// writeDecisions appends a section summarizing the review of optimizer decisions, if any were
+// reviewed
+func writeDecisions(b *strings.Builder, decisions []types.OptimizerDecision) {
+	if len(decisions) == 0 {
+		return
+	}
+
+	verdicts := make(map[types.DecisionVerdict]int)
+	proposedHires, hires, proposedReleases, releases := 0, 0, 0, 0
+	for _, decision := range decisions {
+		verdicts[decision.Verdict]++
+		proposedHires += decision.ProposedHires
+		hires += decision.Hires
+		proposedReleases += len(decision.ProposedReleases)
+		releases += len(decision.Releases)
+	}
+
+	b.WriteString("## Optimizer Decisions\n\n")
+	fmt.Fprintf(b, "%d workforce changes proposed by the optimizer were reviewed: %d approved, %d modified and %d rejected.",
+		len(decisions), verdicts[types.DecisionApproved], verdicts[types.DecisionModified], verdicts[types.DecisionRejected])
+	fmt.Fprintf(b, " The optimizer proposed hiring %d AI agents and releasing %d; %d were hired and %d released.\n\n",
+		proposedHires, proposedReleases, hires, releases)
+}
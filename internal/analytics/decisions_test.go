@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestDecisionTrailExportAndSummary(t *testing.T) {
+	decisions := []types.OptimizerDecision{
+		{TimeStep: 1, ProposedHires: 6, Verdict: types.DecisionRejected, Reason: "wait, see"},
+		{TimeStep: 2, ProposedHires: 6, Verdict: types.DecisionModified, Hires: 2},
+		{TimeStep: 3, ProposedReleases: []string{"agent-1", "agent-2"}, Verdict: types.DecisionApproved, Releases: []string{"agent-1", "agent-2"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDecisionsCSV(decisions, &buf); err != nil {
+		t.Fatalf("WriteDecisionsCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "TimeStep,ProposedHires,ProposedReleases,Verdict,Reason,Hires,Releases" ||
+		lines[1] != `1,6,,Rejected,"wait, see",0,` || lines[3] != "3,0,agent-1;agent-2,Approved,,0,agent-1;agent-2" {
+		t.Errorf("Unexpected decisions CSV:\n%s", buf.String())
+	}
+
+	engine := NewAnalyticsEngine()
+	result := types.SimulationResult{TimeSeries: []types.SimulationState{{}}, Decisions: decisions}
+	summary := engine.GenerateMarkdownSummary(result)
+	if !strings.Contains(summary, "## Optimizer Decisions") || !strings.Contains(summary, "3 workforce changes proposed by the optimizer were reviewed: 1 approved, 1 modified and 1 rejected") ||
+		!strings.Contains(summary, "hiring 12 AI agents and releasing 2; 2 were hired and 2 released") {
+		t.Errorf("Expected the summary to describe the review, got:\n%s", summary)
+	}
+	if strings.Contains(engine.GenerateMarkdownSummary(types.SimulationResult{TimeSeries: []types.SimulationState{{}}}), "Optimizer Decisions") {
+		t.Error("Expected no decisions section without a decision trail")
+	}
+}
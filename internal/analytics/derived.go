@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// derivedMetric is a custom metric computed from every recorded state
+type derivedMetric struct {
+	name    string
+	compute func(state types.SimulationState) float64
+	info    MetricInfo
+}
+
+// RegisterDerivedMetric adds a custom metric that RecordTimeStep and RecordSimulationResult compute
+// from every state, alongside the built-in metrics; DescribeMetric documents it in the catalog
+// A NaN result leaves the step out of the metric, as cost_efficiency does while cost is zero
+// Steps recorded before registration are not back-filled
+// Returns an error if the name is empty or already used by a built-in or registered metric, or compute is nil
+func (ae *AnalyticsEngine) RegisterDerivedMetric(name string, compute func(state types.SimulationState) float64) error {
+	if name == "" {
+		return fmt.Errorf("derived metric name must not be empty")
+	}
+	if compute == nil {
+		return fmt.Errorf("derived metric %q has no function", name)
+	}
+	if isBuiltinMetric(name) {
+		return fmt.Errorf("derived metric %q would replace a built-in metric", name)
+	}
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	for _, metric := range ae.derivedMetrics {
+		if metric.name == name {
+			return fmt.Errorf("derived metric %q is already registered", name)
+		}
+	}
+	ae.derivedMetrics = append(ae.derivedMetrics, derivedMetric{
+		name:    name,
+		compute: compute,
+		info:    MetricInfo{Name: name, Description: "Custom derived metric", Aggregation: AggregateMean, Derived: true},
+	})
+	return nil
+}
+
+// DerivedMetricNames returns the names of the registered derived metrics, in registration order
+func (ae *AnalyticsEngine) DerivedMetricNames() []string {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	names := make([]string, len(ae.derivedMetrics))
+	for i, metric := range ae.derivedMetrics {
+		names[i] = metric.name
+	}
+	return names
+}
+
+// recordDerivedMetrics records every registered derived metric of a state
+// The caller must hold the write lock
+func (ae *AnalyticsEngine) recordDerivedMetrics(state types.SimulationState) {
+	for _, metric := range ae.derivedMetrics {
+		if value := metric.compute(state); !math.IsNaN(value) {
+			ae.recordMetric(metric.name, value)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRegisterDerivedMetric(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	err := engine.RegisterDerivedMetric("budget_headroom", func(state types.SimulationState) float64 {
+		return state.AvailableBudget - state.TotalCost
+	})
+	if err != nil {
+		t.Fatalf("RegisterDerivedMetric failed: %v", err)
+	}
+	err = engine.RegisterDerivedMetric("revenue_per_cost", func(state types.SimulationState) float64 {
+		if state.TotalCost == 0 {
+			return math.NaN()
+		}
+		return state.RevenueOutput / state.TotalCost
+	})
+	if err != nil {
+		t.Fatalf("RegisterDerivedMetric failed: %v", err)
+	}
+
+	engine.RecordTimeStep(types.SimulationState{AvailableBudget: 500, TotalCost: 200, RevenueOutput: 400})
+	engine.RecordTimeStep(types.SimulationState{AvailableBudget: 300})
+
+	metrics := engine.GetMetrics()
+	if want := []float64{300, 300}; !reflect.DeepEqual(metrics["budget_headroom"], want) {
+		t.Errorf("budget_headroom = %v, want %v", metrics["budget_headroom"], want)
+	}
+	if want := []float64{2}; !reflect.DeepEqual(metrics["revenue_per_cost"], want) {
+		t.Errorf("Expected the NaN step to be skipped, got %v", metrics["revenue_per_cost"])
+	}
+	if want := []string{"budget_headroom", "revenue_per_cost"}; !reflect.DeepEqual(engine.DerivedMetricNames(), want) {
+		t.Errorf("DerivedMetricNames() = %v, want %v", engine.DerivedMetricNames(), want)
+	}
+
+	// Recording a whole result rebuilds derived metrics too, and the report summarizes them
+	result := types.SimulationResult{TimeSeries: []types.SimulationState{{AvailableBudget: 100, TotalCost: 40}}}
+	engine.RecordSimulationResult(result)
+	if got := engine.GetMetrics()["budget_headroom"]; !reflect.DeepEqual(got, []float64{60}) {
+		t.Errorf("Expected derived metrics rebuilt from the result, got %v", got)
+	}
+	found := false
+	for _, summary := range engine.GenerateReport(result).Metrics {
+		found = found || summary.Name == "budget_headroom"
+	}
+	if !found {
+		t.Error("Expected the report to summarize derived metrics")
+	}
+}
+
+func TestRegisterDerivedMetricRejectsConflicts(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	compute := func(state types.SimulationState) float64 { return 0 }
+
+	if err := engine.RegisterDerivedMetric("", compute); err == nil {
+		t.Error("Expected an error for an empty name")
+	}
+	if err := engine.RegisterDerivedMetric("custom", nil); err == nil {
+		t.Error("Expected an error for a nil function")
+	}
+	if err := engine.RegisterDerivedMetric("total_cost", compute); err == nil {
+		t.Error("Expected an error for a built-in metric name")
+	}
+	if err := engine.RegisterDerivedMetric("custom", compute); err != nil {
+		t.Fatalf("RegisterDerivedMetric failed: %v", err)
+	}
+	if err := engine.RegisterDerivedMetric("custom", compute); err == nil {
+		t.Error("Expected an error for a duplicate name")
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"io"
 	"math"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/economic"
+	"workforce-ai-transition-simulator/internal/store"
 	"workforce-ai-transition-simulator/internal/types"
 )
 
@@ -21,6 +25,26 @@ type AnalyticsEngine struct {
 	// Metrics storage
 	metrics map[string][]float64
 	
+	// How sensitivity statistics treat runs that did not reach equilibrium
+	censoredPolicy        CensoredRunPolicy
+	censoredPenaltyFactor float64
+	
+	// Number of trailing time steps aggregated by rolling KPIs
+	rollingWindow int
+	
+	// Standard deviations from the rolling mean beyond which a value is an anomaly
+	anomalyThreshold float64
+	
+	// Trend method and number of steps projected beyond the end of a run
+	forecastMethod  ForecastMethod
+	forecastHorizon int
+	
+	// Custom metrics recorded from every state, in registration order
+	derivedMetrics []derivedMetric
+	
+	// Options applied to the controller of every run the engine simulates
+	controllerOptions []controller.Option
+	
 	// Mutex for thread-safe operations during parallel sensitivity analysis
 	mu sync.RWMutex
 }
@@ -28,8 +52,14 @@ type AnalyticsEngine struct {
 // NewAnalyticsEngine creates a new AnalyticsEngine instance
 func NewAnalyticsEngine() *AnalyticsEngine {
 	return &AnalyticsEngine{
-		timeSeries: make([]types.SimulationState, 0),
-		metrics:    make(map[string][]float64),
+		timeSeries:            make([]types.SimulationState, 0),
+		metrics:               make(map[string][]float64),
+		censoredPolicy:        IncludeCensored,
+		censoredPenaltyFactor: DefaultCensoredPenaltyFactor,
+		rollingWindow:         DefaultRollingWindow,
+		anomalyThreshold:      DefaultAnomalyThreshold,
+		forecastMethod:        LinearForecast,
+		forecastHorizon:       DefaultForecastHorizon,
 	}
 }
 
@@ -67,6 +97,54 @@ func (ae *AnalyticsEngine) GetMetrics() map[string][]float64 {
 	return result
 }
 
+// ForEachState visits every recorded simulation state in time order without copying the time series
+// The read lock is held for the whole visit, so fn must not call methods that modify the engine
+// Returning false from fn stops the iteration early
+func (ae *AnalyticsEngine) ForEachState(fn func(index int, state types.SimulationState) bool) {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	for i, state := range ae.timeSeries {
+		if !fn(i, state) {
+			return
+		}
+	}
+}
+
+// MetricSeries gives fn read-only access to the recorded values of a single metric
+// The slice is the engine's own storage and is only valid while fn runs; it must not be
+// modified or retained. Returns false if no metric with the given name has been recorded
+func (ae *AnalyticsEngine) MetricSeries(name string, fn func(values []float64)) bool {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	values, exists := ae.metrics[name]
+	if !exists {
+		return false
+	}
+	fn(values)
+	return true
+}
+
+// ForEachMetric visits every recorded metric in name order with read-only access to its values
+// The same lock and aliasing rules as MetricSeries apply. Returning false from fn stops the iteration early
+func (ae *AnalyticsEngine) ForEachMetric(fn func(name string, values []float64) bool) {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	names := make([]string, 0, len(ae.metrics))
+	for name := range ae.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !fn(name, ae.metrics[name]) {
+			return
+		}
+	}
+}
+
 // SensitivityResults represents the results of a sensitivity analysis
 type SensitivityResults struct {
 	ParameterName                    string
@@ -104,6 +182,14 @@ type Report struct {
 	EquilibriumDetails     types.SimulationState
 	TotalSimulationDuration int
 	Summary                ReportSummary
+	MetricCatalog          []MetricInfo    // unit, description and aggregation of every metric, see MetricCatalog
+	Metrics                []MetricSummary // statistics of every metric over the run, see SummarizeAll
+	RollingKPIs            []RollingKPI    // trailing-window aggregates of every time step, see RollingKPIs
+	Anomalies              []Anomaly       // steps where a metric deviates sharply from its rolling mean, see DetectAnomalies
+	Phases                 []Phase         // stretches of the run between cost and headcount changepoints, see DetectPhases
+	Forecast               *Forecast       // projection beyond the end of the run, nil when disabled or the run is too short
+	Failures               []Incident      // postmortem of every catastrophic failure, see Postmortem
+	Metadata               types.RunMetadata
 }
 
 // ReportSummary provides key metrics and insights from the simulation
@@ -117,6 +203,15 @@ type ReportSummary struct {
 	TotalRevenueGenerated   float64
 	AverageProductivity     float64
 	CostEfficiencyRatio     float64 // final productivity / final cost
+	TotalAIRevenue          float64 // revenue attributed to AI agents over the whole run
+	FinalAIRevenueShare     float64 // fraction of final-step revenue generated by AI agents (0-1)
+	
+	// Discounted cash flow metrics at the configured discount rate
+	NetPresentValue      float64 // NPV of per-step net cash flows (revenue less costs)
+	TransitionNPV        float64 // NPV of net cash flows relative to keeping the initial workforce
+	BreakEvenStep        int     // first step at which cumulative transition cash flow is positive, -1 if never
+	TransitionIRR        float64 // annualized internal rate of return of the transition cash flows
+	TransitionIRRDefined bool    // false when the transition cash flows never change sign
 }
 
 // SensitivityReport represents a sensitivity analysis report
@@ -133,6 +228,8 @@ type SensitivitySummary struct {
 	AverageTimeToEquilibrium   float64
 	TimeToEquilibriumVariance  float64
 	OptimalParameterValues     map[string]float64
+	CensoredRuns               int    // runs that hit the step limit without reaching equilibrium
+	CensoredRunPolicy          string // how censored runs were treated in the statistics above
 }
 
 // RecordTimeStep captures and stores simulation state at each time step
@@ -149,6 +246,7 @@ func (ae *AnalyticsEngine) RecordTimeStep(state types.SimulationState) {
 	ae.recordMetric("available_budget", state.AvailableBudget)
 	ae.recordMetric("total_productivity", state.TotalProductivity)
 	ae.recordMetric("revenue_output", state.RevenueOutput)
+	ae.recordMetric("ai_revenue_share", state.RevenueAttribution.AIShare()*100.0)
 	ae.recordMetric("human_count", float64(state.Workforce.Humans.Total))
 	ae.recordMetric("ai_agent_count", float64(state.Workforce.AIAgents.Total))
 	ae.recordMetric("orchestration_utilization", state.Workforce.OrchestrationUtilization)
@@ -169,6 +267,8 @@ func (ae *AnalyticsEngine) RecordTimeStep(state types.SimulationState) {
 		aiRatio := float64(state.Workforce.AIAgents.Total) / totalWorkforce * 100.0
 		ae.recordMetric("ai_ratio", aiRatio)
 	}
+	
+	ae.recordDerivedMetrics(state)
 }
 
 // recordMetric is a helper method to store individual metrics
@@ -196,6 +296,7 @@ func (ae *AnalyticsEngine) RecordSimulationResult(result types.SimulationResult)
 		ae.recordMetric("available_budget", state.AvailableBudget)
 		ae.recordMetric("total_productivity", state.TotalProductivity)
 		ae.recordMetric("revenue_output", state.RevenueOutput)
+		ae.recordMetric("ai_revenue_share", state.RevenueAttribution.AIShare()*100.0)
 		ae.recordMetric("human_count", float64(state.Workforce.Humans.Total))
 		ae.recordMetric("ai_agent_count", float64(state.Workforce.AIAgents.Total))
 		ae.recordMetric("orchestration_utilization", state.Workforce.OrchestrationUtilization)
@@ -214,12 +315,24 @@ func (ae *AnalyticsEngine) RecordSimulationResult(result types.SimulationResult)
 			aiRatio := float64(state.Workforce.AIAgents.Total) / totalWorkforce * 100.0
 			ae.recordMetric("ai_ratio", aiRatio)
 		}
+		
+		ae.recordDerivedMetrics(state)
 	}
 }
 // RunSensitivityAnalysis executes multiple simulations with parameter variations
 // Requirements 11.1, 11.2: Execute multiple simulations varying one parameter at a time
 // Uses Go goroutines for parallel execution
 func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationConfig, paramRanges ParameterRanges, maxTimeSteps int, seed int64) (map[string]SensitivityResults, error) {
+	return ae.RunSensitivityAnalysisWithOptions(baseConfig, paramRanges, maxTimeSteps, seed, SensitivityOptions{})
+}
+
+// RunSensitivityAnalysisWithOptions executes a sensitivity analysis with non-default run options
+func (ae *AnalyticsEngine) RunSensitivityAnalysisWithOptions(baseConfig types.SimulationConfig, paramRanges ParameterRanges, maxTimeSteps int, seed int64, opts SensitivityOptions) (map[string]SensitivityResults, error) {
+	if err := opts.validate(paramRanges); err != nil {
+		return nil, err
+	}
+	opts.controllerOptions = ae.controllerOptions
+	
 	results := make(map[string]SensitivityResults)
 	
 	// Channel for collecting results from goroutines
@@ -238,7 +351,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			result, err := ae.runParameterSensitivity("FixedBudget", baseConfig, paramRanges.FixedBudget, maxTimeSteps, seed, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("FixedBudget", baseConfig, paramRanges.FixedBudget, maxTimeSteps, seed, opts, func(config *types.SimulationConfig, value float64) {
 				config.FixedBudget = value
 			})
 			resultChan <- paramResult{"FixedBudget", result, err}
@@ -254,7 +367,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 			for i, v := range paramRanges.InitialHumans {
 				intValues[i] = float64(v)
 			}
-			result, err := ae.runParameterSensitivity("InitialHumans", baseConfig, intValues, maxTimeSteps, seed+1, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("InitialHumans", baseConfig, intValues, maxTimeSteps, seed+1, opts, func(config *types.SimulationConfig, value float64) {
 				config.InitialHumans = int(value)
 			})
 			resultChan <- paramResult{"InitialHumans", result, err}
@@ -266,7 +379,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			result, err := ae.runParameterSensitivity("CatastrophicFailureRate", baseConfig, paramRanges.CatastrophicFailureRate, maxTimeSteps, seed+2, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("CatastrophicFailureRate", baseConfig, paramRanges.CatastrophicFailureRate, maxTimeSteps, seed+2, opts, func(config *types.SimulationConfig, value float64) {
 				config.CatastrophicFailureRate = value
 			})
 			resultChan <- paramResult{"CatastrophicFailureRate", result, err}
@@ -278,7 +391,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			result, err := ae.runParameterSensitivity("TimeZoneInefficiency", baseConfig, paramRanges.TimeZoneInefficiency, maxTimeSteps, seed+3, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("TimeZoneInefficiency", baseConfig, paramRanges.TimeZoneInefficiency, maxTimeSteps, seed+3, opts, func(config *types.SimulationConfig, value float64) {
 				config.TimeZoneInefficiency = value
 			})
 			resultChan <- paramResult{"TimeZoneInefficiency", result, err}
@@ -290,7 +403,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			result, err := ae.runParameterSensitivity("NaturalAttritionRate", baseConfig, paramRanges.NaturalAttritionRate, maxTimeSteps, seed+4, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("NaturalAttritionRate", baseConfig, paramRanges.NaturalAttritionRate, maxTimeSteps, seed+4, opts, func(config *types.SimulationConfig, value float64) {
 				config.AttritionConfig.NaturalRate = value
 			})
 			resultChan <- paramResult{"NaturalAttritionRate", result, err}
@@ -302,7 +415,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			result, err := ae.runParameterSensitivity("ForcedAcceleration", baseConfig, paramRanges.ForcedAcceleration, maxTimeSteps, seed+5, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("ForcedAcceleration", baseConfig, paramRanges.ForcedAcceleration, maxTimeSteps, seed+5, opts, func(config *types.SimulationConfig, value float64) {
 				config.AttritionConfig.ForcedAcceleration = value
 			})
 			resultChan <- paramResult{"ForcedAcceleration", result, err}
@@ -318,7 +431,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 			for i, v := range paramRanges.UniversityToMid {
 				intValues[i] = float64(v)
 			}
-			result, err := ae.runParameterSensitivity("UniversityToMid", baseConfig, intValues, maxTimeSteps, seed+6, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("UniversityToMid", baseConfig, intValues, maxTimeSteps, seed+6, opts, func(config *types.SimulationConfig, value float64) {
 				config.AILearningSpeeds.UniversityToMid = int(value)
 			})
 			resultChan <- paramResult{"UniversityToMid", result, err}
@@ -333,7 +446,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 			for i, v := range paramRanges.MidToSenior {
 				intValues[i] = float64(v)
 			}
-			result, err := ae.runParameterSensitivity("MidToSenior", baseConfig, intValues, maxTimeSteps, seed+7, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("MidToSenior", baseConfig, intValues, maxTimeSteps, seed+7, opts, func(config *types.SimulationConfig, value float64) {
 				config.AILearningSpeeds.MidToSenior = int(value)
 			})
 			resultChan <- paramResult{"MidToSenior", result, err}
@@ -348,7 +461,7 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 			for i, v := range paramRanges.SeniorToExecutive {
 				intValues[i] = float64(v)
 			}
-			result, err := ae.runParameterSensitivity("SeniorToExecutive", baseConfig, intValues, maxTimeSteps, seed+8, func(config *types.SimulationConfig, value float64) {
+			result, err := ae.runParameterSensitivity("SeniorToExecutive", baseConfig, intValues, maxTimeSteps, seed+8, opts, func(config *types.SimulationConfig, value float64) {
 				config.AILearningSpeeds.SeniorToExecutive = int(value)
 			})
 			resultChan <- paramResult{"SeniorToExecutive", result, err}
@@ -373,32 +486,39 @@ func (ae *AnalyticsEngine) RunSensitivityAnalysis(baseConfig types.SimulationCon
 }
 
 // runParameterSensitivity runs sensitivity analysis for a single parameter
-func (ae *AnalyticsEngine) runParameterSensitivity(paramName string, baseConfig types.SimulationConfig, values []float64, maxTimeSteps int, seed int64, setter func(*types.SimulationConfig, float64)) (SensitivityResults, error) {
+func (ae *AnalyticsEngine) runParameterSensitivity(paramName string, baseConfig types.SimulationConfig, values []float64, maxTimeSteps int, seed int64, opts SensitivityOptions, setter func(*types.SimulationConfig, float64)) (SensitivityResults, error) {
 	results := make([]types.SimulationResult, len(values))
 	timeToEquilibrium := make(map[float64]int)
 	equilibriumComposition := make(map[float64]types.WorkforceComposition)
 	
 	// Run simulation for each parameter value
-	for i, value := range values {
+	err := opts.forEachRun(len(values), func(i int) error {
+		value := values[i]
+		
 		// Create a copy of the base configuration
 		config := baseConfig
 		
 		// Apply the parameter value using the setter function
 		setter(&config, value)
 		
-		// Create a new simulation controller with unique seed
-		simController := controller.NewSimulationController(config, seed+int64(i))
-		
-		// Run the simulation
-		result, err := simController.RunUntilEquilibrium(maxTimeSteps)
+		// Run the simulation with a unique seed, starting from the baseline equilibrium when
+		// warm-starting and reusing the stored result when resuming
+		key := store.RunKey{Parameter: paramName, Value: value, Seed: seed + int64(i)}
+		result, err := opts.runSimulation(key, config, maxTimeSteps)
 		if err != nil {
-			return SensitivityResults{}, fmt.Errorf("simulation failed for %s=%f: %w", paramName, value, err)
+			return fmt.Errorf("simulation failed for %s=%f: %w", paramName, value, err)
 		}
-		
-		// Store the results
 		results[i] = result
-		timeToEquilibrium[value] = result.TimeToEquilibrium
-		equilibriumComposition[value] = result.EquilibriumState.Workforce
+		return nil
+	})
+	if err != nil {
+		return SensitivityResults{}, err
+	}
+	
+	// Store the results
+	for i, value := range values {
+		timeToEquilibrium[value] = results[i].TimeToEquilibrium
+		equilibriumComposition[value] = results[i].EquilibriumState.Workforce
 	}
 	
 	return SensitivityResults{
@@ -439,10 +559,13 @@ func (ae *AnalyticsEngine) RankParameterImpacts(sensitivityResults map[string]Se
 }
 
 // extractTimeToEquilibrium extracts time to equilibrium values from sensitivity results
+// Runs that did not reach equilibrium are handled according to the censored run policy
 func (ae *AnalyticsEngine) extractTimeToEquilibrium(results SensitivityResults) []float64 {
-	values := make([]float64, len(results.Results))
-	for i, result := range results.Results {
-		values[i] = float64(result.TimeToEquilibrium)
+	values := make([]float64, 0, len(results.Results))
+	for _, result := range results.Results {
+		if value, include := ae.effectiveTimeToEquilibrium(result); include {
+			values = append(values, value)
+		}
 	}
 	return values
 }
@@ -477,15 +600,18 @@ func (ae *AnalyticsEngine) calculateCompositionVariance(results SensitivityResul
 	}
 	
 	// Extract composition metrics for variance calculation
-	humanCounts := make([]float64, len(results.Results))
-	aiCounts := make([]float64, len(results.Results))
-	orchestrationUtils := make([]float64, len(results.Results))
+	humanCounts := make([]float64, 0, len(results.Results))
+	aiCounts := make([]float64, 0, len(results.Results))
+	orchestrationUtils := make([]float64, 0, len(results.Results))
 	
-	for i, result := range results.Results {
+	for _, result := range results.Results {
+		if !ae.includeInComposition(result) {
+			continue
+		}
 		composition := result.EquilibriumState.Workforce
-		humanCounts[i] = float64(composition.Humans.Total)
-		aiCounts[i] = float64(composition.AIAgents.Total)
-		orchestrationUtils[i] = composition.OrchestrationUtilization
+		humanCounts = append(humanCounts, float64(composition.Humans.Total))
+		aiCounts = append(aiCounts, float64(composition.AIAgents.Total))
+		orchestrationUtils = append(orchestrationUtils, composition.OrchestrationUtilization)
 	}
 	
 	// Calculate variance for each composition metric
@@ -562,10 +688,10 @@ func (ae *AnalyticsEngine) CalculateSensitivitySummary(sensitivityResults map[st
 	timeValues := make([]float64, 0)
 	
 	for _, results := range sensitivityResults {
-		for _, result := range results.Results {
-			totalTime += float64(result.TimeToEquilibrium)
+		for _, value := range ae.extractTimeToEquilibrium(results) {
+			totalTime += value
 			totalCount++
-			timeValues = append(timeValues, float64(result.TimeToEquilibrium))
+			timeValues = append(timeValues, value)
 		}
 	}
 	
@@ -586,6 +712,8 @@ func (ae *AnalyticsEngine) CalculateSensitivitySummary(sensitivityResults map[st
 		AverageTimeToEquilibrium:   averageTime,
 		TimeToEquilibriumVariance:  timeVariance,
 		OptimalParameterValues:     optimalValues,
+		CensoredRuns:               countCensoredRuns(sensitivityResults),
+		CensoredRunPolicy:          ae.CensoredRunPolicy().String(),
 	}
 }
 
@@ -598,8 +726,9 @@ func (ae *AnalyticsEngine) findOptimalParameterValues(sensitivityResults map[str
 		optimalValue := 0.0
 		
 		for i, result := range results.Results {
-			if float64(result.TimeToEquilibrium) < minTime {
-				minTime = float64(result.TimeToEquilibrium)
+			value, include := ae.effectiveTimeToEquilibrium(result)
+			if include && value < minTime {
+				minTime = value
 				optimalValue = results.ParameterValues[i]
 			}
 		}
@@ -622,6 +751,19 @@ func (ae *AnalyticsEngine) GenerateReport(result types.SimulationResult) Report
 	// Calculate summary statistics
 	summary := ae.calculateReportSummary(result)
 	
+	var forecast *Forecast
+	if projection, err := ae.Forecast(result.TimeSeries); err == nil {
+		forecast = &projection
+	}
+	
+	// Metric statistics come from the result alone, not from whatever this engine has recorded,
+	// but include this engine's derived metrics
+	metrics := NewAnalyticsEngine()
+	ae.mu.RLock()
+	metrics.derivedMetrics = append(metrics.derivedMetrics, ae.derivedMetrics...)
+	ae.mu.RUnlock()
+	metrics.RecordSimulationResult(result)
+	
 	return Report{
 		InitialParameters:       result.Config,
 		TimeSeriesData:         result.TimeSeries,
@@ -629,6 +771,14 @@ func (ae *AnalyticsEngine) GenerateReport(result types.SimulationResult) Report
 		EquilibriumDetails:     result.EquilibriumState,
 		TotalSimulationDuration: result.TimeToEquilibrium,
 		Summary:                summary,
+		MetricCatalog:          ae.MetricCatalog(),
+		Metrics:                metrics.SummarizeAll(),
+		RollingKPIs:            ae.RollingKPIs(result.TimeSeries),
+		Anomalies:              ae.DetectAnomalies(result.TimeSeries),
+		Phases:                 DetectPhases(result.TimeSeries),
+		Forecast:               forecast,
+		Failures:               Postmortem(result),
+		Metadata:               result.Metadata,
 	}
 }
 
@@ -641,10 +791,12 @@ func (ae *AnalyticsEngine) calculateReportSummary(result types.SimulationResult)
 	initialState := result.TimeSeries[0]
 	finalState := result.EquilibriumState
 	
-	// Calculate total revenue generated throughout the simulation
+	// Calculate total revenue generated throughout the simulation, and the AI-generated part of it
 	totalRevenue := 0.0
+	totalAIRevenue := 0.0
 	for _, state := range result.TimeSeries {
 		totalRevenue += state.RevenueOutput
+		totalAIRevenue += state.RevenueAttribution.TotalAI()
 	}
 	
 	// Calculate average productivity across the simulation
@@ -660,6 +812,13 @@ func (ae *AnalyticsEngine) calculateReportSummary(result types.SimulationResult)
 		costEfficiencyRatio = finalState.TotalProductivity / finalState.TotalCost
 	}
 	
+	cashFlows := calculateCashFlows(result.TimeSeries)
+	perStepRate := economic.PerStepRate(result.Config.DiscountRate)
+	transitionIRR, irrDefined := economic.InternalRateOfReturn(cashFlows.transition)
+	if irrDefined {
+		transitionIRR = economic.AnnualRate(transitionIRR)
+	}
+	
 	return ReportSummary{
 		InitialWorkforceSize:    initialState.Workforce.Humans.Total + initialState.Workforce.AIAgents.Total,
 		FinalWorkforceSize:      finalState.Workforce.Humans.Total + finalState.Workforce.AIAgents.Total,
@@ -670,6 +829,13 @@ func (ae *AnalyticsEngine) calculateReportSummary(result types.SimulationResult)
 		TotalRevenueGenerated:   totalRevenue,
 		AverageProductivity:     averageProductivity,
 		CostEfficiencyRatio:     costEfficiencyRatio,
+		TotalAIRevenue:          totalAIRevenue,
+		FinalAIRevenueShare:     finalState.RevenueAttribution.AIShare(),
+		NetPresentValue:         economic.NetPresentValue(perStepRate, cashFlows.net),
+		TransitionNPV:           economic.NetPresentValue(perStepRate, cashFlows.transition),
+		BreakEvenStep:           cashFlows.breakEvenStep(),
+		TransitionIRR:           transitionIRR,
+		TransitionIRRDefined:    irrDefined,
 	}
 }
 
@@ -700,7 +866,22 @@ func (ae *AnalyticsEngine) GenerateReportCSV(result types.SimulationResult) ([][
 		return nil, fmt.Errorf("no time series data available")
 	}
 	
-	// Create CSV header
+	rolling := ae.RollingKPIs(result.TimeSeries)
+	metadata := reportCSVMetadata(result.Metadata)
+	
+	// Create CSV data
+	data := make([][]string, len(result.TimeSeries)+1)
+	data[0] = reportCSVHeader()
+	
+	for i, state := range result.TimeSeries {
+		data[i+1] = append(reportCSVRow(state, rolling[i]), metadata...)
+	}
+	
+	return data, nil
+}
+
+// reportCSVHeader returns the header of the CSV report, ending with the run metadata columns
+func reportCSVHeader() []string {
 	header := []string{
 		"TimeStep",
 		"HumanCount",
@@ -714,49 +895,140 @@ func (ae *AnalyticsEngine) GenerateReportCSV(result types.SimulationResult) ([][
 		"CatastrophicFailures",
 		"IsEquilibrium",
 	}
-	
-	// Create CSV data
-	data := make([][]string, len(result.TimeSeries)+1)
-	data[0] = header
-	
-	for i, state := range result.TimeSeries {
-		row := []string{
-			fmt.Sprintf("%d", state.TimeStep),
-			fmt.Sprintf("%d", state.Workforce.Humans.Total),
-			fmt.Sprintf("%d", state.Workforce.AIAgents.Total),
-			fmt.Sprintf("%d", state.Workforce.Humans.Total+state.Workforce.AIAgents.Total),
-			fmt.Sprintf("%.2f", state.TotalCost),
-			fmt.Sprintf("%.2f", state.AvailableBudget),
-			fmt.Sprintf("%.2f", state.TotalProductivity),
-			fmt.Sprintf("%.2f", state.RevenueOutput),
-			fmt.Sprintf("%.2f", state.Workforce.OrchestrationUtilization),
-			fmt.Sprintf("%d", state.CatastrophicFailures),
-			fmt.Sprintf("%t", state.IsEquilibrium),
-		}
-		data[i+1] = row
+	header = append(header, "AvailableOrchestrationCapacity", "AgentsHired", "AgentsReleased", "HumansLost")
+	for _, contract := range contractTypes {
+		header = append(header, "Humans_"+contract.String())
+	}
+	header = append(header, "Overtime", "Burnout", "RecoveryLoss")
+	header = append(header, "Utilization")
+	for _, level := range experienceLevels {
+		header = append(header, "Utilization_"+level.String())
 	}
+	for _, level := range experienceLevels {
+		header = append(header, "HumanPayroll_"+level.String())
+	}
+	for _, level := range experienceLevels {
+		header = append(header, "AICost_"+level.String())
+	}
+	header = append(header, "AIPlatformFee", "Resilience", "Training", "Penalties", "Severance", "Recruiting", "Remediation", "Interest")
+	for _, level := range experienceLevels {
+		header = append(header, "HumanRevenue_"+level.String())
+	}
+	for _, level := range experienceLevels {
+		header = append(header, "AIRevenue_"+level.String())
+	}
+	header = append(header, "AIRevenueShare", "EquilibriumReason", "MarketShocks", "CashBalance",
+		"Borrowed", "Repaid", "Debt", "Leverage")
+	header = append(header, "TrailingRevenue", "AverageHeadcount", "CostRunRate")
 	
-	return data, nil
+	// Run metadata is repeated on every row so rows from different runs can be combined
+	header = append(header, "RunID", "Timestamp", "Seed", "Version", "ConfigHash")
+	return header
+}
+
+// reportCSVMetadata returns the run metadata columns of every row of the CSV report
+func reportCSVMetadata(metadata types.RunMetadata) []string {
+	return []string{
+		metadata.RunID,
+		metadata.Timestamp.Format(time.RFC3339),
+		fmt.Sprintf("%d", metadata.Seed),
+		metadata.Version,
+		metadata.ConfigHash,
+	}
+}
+
+// reportCSVRow returns the columns of the CSV report for a state, up to the run metadata
+func reportCSVRow(state types.SimulationState, kpi RollingKPI) []string {
+	row := []string{
+		fmt.Sprintf("%d", state.TimeStep),
+		fmt.Sprintf("%d", state.Workforce.Humans.Total),
+		fmt.Sprintf("%d", state.Workforce.AIAgents.Total),
+		fmt.Sprintf("%d", state.Workforce.Humans.Total+state.Workforce.AIAgents.Total),
+		fmt.Sprintf("%.2f", state.TotalCost),
+		fmt.Sprintf("%.2f", state.AvailableBudget),
+		fmt.Sprintf("%.2f", state.TotalProductivity),
+		fmt.Sprintf("%.2f", state.RevenueOutput),
+		fmt.Sprintf("%.2f", state.Workforce.OrchestrationUtilization),
+		fmt.Sprintf("%d", state.CatastrophicFailures),
+		fmt.Sprintf("%t", state.IsEquilibrium),
+	}
+	row = append(row,
+		fmt.Sprintf("%d", state.AvailableOrchestrationCapacity),
+		fmt.Sprintf("%d", state.AgentsHiredThisStep),
+		fmt.Sprintf("%d", state.AgentsReleasedThisStep),
+		fmt.Sprintf("%d", state.HumansLostThisStep),
+	)
+	for _, contract := range contractTypes {
+		row = append(row, fmt.Sprintf("%d", state.Workforce.Humans.ByContractType[contract]))
+	}
+	row = append(row, fmt.Sprintf("%.4f", state.Overtime), fmt.Sprintf("%.4f", state.Burnout), fmt.Sprintf("%.4f", state.RecoveryLoss))
+	row = append(row, fmt.Sprintf("%.2f", state.Utilization.Overall))
+	for _, level := range experienceLevels {
+		row = append(row, fmt.Sprintf("%.2f", state.Utilization.ByExperience[level]))
+	}
+	for _, level := range experienceLevels {
+		row = append(row, fmt.Sprintf("%.2f", state.CostBreakdown.HumanPayroll[level]))
+	}
+	for _, level := range experienceLevels {
+		row = append(row, fmt.Sprintf("%.2f", state.CostBreakdown.AICost[level]))
+	}
+	row = append(row,
+		fmt.Sprintf("%.2f", state.CostBreakdown.AIPlatformFee),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Resilience),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Training),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Penalties),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Severance),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Recruiting),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Remediation),
+		fmt.Sprintf("%.2f", state.CostBreakdown.Interest),
+	)
+	for _, level := range experienceLevels {
+		row = append(row, fmt.Sprintf("%.2f", state.RevenueAttribution.HumanByExperience[level]))
+	}
+	for _, level := range experienceLevels {
+		row = append(row, fmt.Sprintf("%.2f", state.RevenueAttribution.AIByExperience[level]))
+	}
+	row = append(row, fmt.Sprintf("%.4f", state.RevenueAttribution.AIShare()), state.EquilibriumReason.Code.String(), formatMarketShocks(state.MarketShocks),
+		fmt.Sprintf("%.2f", state.CashBalance))
+	row = append(row,
+		fmt.Sprintf("%.2f", state.Financing.Borrowed),
+		fmt.Sprintf("%.2f", state.Financing.Repaid),
+		fmt.Sprintf("%.2f", state.Financing.Debt),
+		fmt.Sprintf("%.4f", state.Financing.Leverage),
+	)
+	row = append(row,
+		fmt.Sprintf("%.2f", kpi.TrailingRevenue),
+		fmt.Sprintf("%.2f", kpi.AverageHeadcount),
+		fmt.Sprintf("%.2f", kpi.CostRunRate),
+	)
+	return row
+}
+
+// formatMarketShocks lists the market shocks of a time step in one CSV cell, separated by semicolons
+func formatMarketShocks(shocks []types.MarketShock) string {
+	descriptions := make([]string, len(shocks))
+	for i, shock := range shocks {
+		descriptions[i] = shock.String()
+	}
+	return strings.Join(descriptions, "; ")
 }
 
 // WriteReportCSV writes the simulation report to a CSV file
+// Rows are written as they are produced, see ReportCSVStream
 func (ae *AnalyticsEngine) WriteReportCSV(result types.SimulationResult, writer io.Writer) error {
-	csvData, err := ae.GenerateReportCSV(result)
-	if err != nil {
-		return fmt.Errorf("failed to generate CSV report: %w", err)
+	if len(result.TimeSeries) == 0 {
+		return fmt.Errorf("failed to generate CSV report: no time series data available")
 	}
 	
-	csvWriter := csv.NewWriter(writer)
-	defer csvWriter.Flush()
-	
-	for _, row := range csvData {
-		if err := csvWriter.Write(row); err != nil {
-			return fmt.Errorf("failed to write CSV row: %w", err)
+	stream := ae.NewReportCSVStream(writer, result.Metadata)
+	for _, state := range result.TimeSeries {
+		if err := stream.Write(state); err != nil {
+			return err
 		}
 	}
-	
-	return nil
+	return stream.Flush()
 }
+
 // GenerateSensitivityReport creates a sensitivity analysis report with parameter rankings
 // Requirements 12.6, 12.7: Create sensitivity analysis report with parameter rankings in CSV/JSON format
 func (ae *AnalyticsEngine) GenerateSensitivityReport(sensitivityResults map[string]SensitivityResults) SensitivityReport {
@@ -861,6 +1133,7 @@ func (ae *AnalyticsEngine) GenerateDetailedSensitivityCSV(sensitivityResults map
 		"ParameterName",
 		"ParameterValue",
 		"TimeToEquilibrium",
+		"ReachedEquilibrium",
 		"FinalHumanCount",
 		"FinalAIAgentCount",
 		"FinalTotalCost",
@@ -890,6 +1163,7 @@ func (ae *AnalyticsEngine) GenerateDetailedSensitivityCSV(sensitivityResults map
 				paramName,
 				fmt.Sprintf("%.4f", paramValue),
 				fmt.Sprintf("%d", result.TimeToEquilibrium),
+				fmt.Sprintf("%t", result.ReachedEquilibrium),
 				fmt.Sprintf("%d", equilibrium.Workforce.Humans.Total),
 				fmt.Sprintf("%d", equilibrium.Workforce.AIAgents.Total),
 				fmt.Sprintf("%.2f", equilibrium.TotalCost),
@@ -34,17 +34,10 @@ func TestRecordTimeStep(t *testing.T) {
 		TotalProductivity: 10.5,
 		RevenueOutput:     25000,
 		Workforce: types.WorkforceComposition{
-			Humans: struct {
-				Total          int
-				ByExperience   map[types.ExperienceLevel]int
-				ByCostCategory map[types.CostCategory]int
-			}{
+			Humans: types.HumanComposition{
 				Total: 5,
 			},
-			AIAgents: struct {
-				Total        int
-				ByExperience map[types.ExperienceLevel]int
-			}{
+			AIAgents: types.AgentComposition{
 				Total: 3,
 			},
 			OrchestrationUtilization: 75.0,
@@ -93,17 +86,10 @@ func TestGenerateReport(t *testing.T) {
 			TotalProductivity: 15.0,
 			RevenueOutput:     30000,
 			Workforce: types.WorkforceComposition{
-				Humans: struct {
-					Total          int
-					ByExperience   map[types.ExperienceLevel]int
-					ByCostCategory map[types.CostCategory]int
-				}{
+				Humans: types.HumanComposition{
 					Total: 10,
 				},
-				AIAgents: struct {
-					Total        int
-					ByExperience map[types.ExperienceLevel]int
-				}{
+				AIAgents: types.AgentComposition{
 					Total: 0,
 				},
 			},
@@ -114,17 +100,10 @@ func TestGenerateReport(t *testing.T) {
 			TotalProductivity: 20.0,
 			RevenueOutput:     40000,
 			Workforce: types.WorkforceComposition{
-				Humans: struct {
-					Total          int
-					ByExperience   map[types.ExperienceLevel]int
-					ByCostCategory map[types.CostCategory]int
-				}{
+				Humans: types.HumanComposition{
 					Total: 8,
 				},
-				AIAgents: struct {
-					Total        int
-					ByExperience map[types.ExperienceLevel]int
-				}{
+				AIAgents: types.AgentComposition{
 					Total: 5,
 				},
 			},
@@ -185,17 +164,10 @@ func TestGenerateReportCSV(t *testing.T) {
 				TotalProductivity: 10.0,
 				RevenueOutput:     20000,
 				Workforce: types.WorkforceComposition{
-					Humans: struct {
-						Total          int
-						ByExperience   map[types.ExperienceLevel]int
-						ByCostCategory map[types.CostCategory]int
-					}{
+					Humans: types.HumanComposition{
 						Total: 5,
 					},
-					AIAgents: struct {
-						Total        int
-						ByExperience map[types.ExperienceLevel]int
-					}{
+					AIAgents: types.AgentComposition{
 						Total: 2,
 					},
 					OrchestrationUtilization: 50.0,
@@ -222,11 +194,28 @@ func TestGenerateReportCSV(t *testing.T) {
 		"TimeStep", "HumanCount", "AIAgentCount", "TotalWorkforce",
 		"TotalCost", "AvailableBudget", "TotalProductivity", "RevenueOutput",
 		"OrchestrationUtilization", "CatastrophicFailures", "IsEquilibrium",
+		"AvailableOrchestrationCapacity", "AgentsHired", "AgentsReleased", "HumansLost",
+		"Humans_FTE", "Humans_Contractor", "Humans_Part_Time", "Overtime", "Burnout", "RecoveryLoss",
+		"Utilization", "Utilization_University_Hire", "Utilization_Mid_Level", "Utilization_Senior", "Utilization_Executive",
+		"HumanPayroll_University_Hire", "HumanPayroll_Mid_Level", "HumanPayroll_Senior", "HumanPayroll_Executive",
+		"AICost_University_Hire", "AICost_Mid_Level", "AICost_Senior", "AICost_Executive",
+		"AIPlatformFee", "Resilience", "Training", "Penalties", "Severance", "Recruiting", "Remediation", "Interest",
+		"HumanRevenue_University_Hire", "HumanRevenue_Mid_Level", "HumanRevenue_Senior", "HumanRevenue_Executive",
+		"AIRevenue_University_Hire", "AIRevenue_Mid_Level", "AIRevenue_Senior", "AIRevenue_Executive",
+		"AIRevenueShare", "EquilibriumReason", "MarketShocks", "CashBalance",
+		"Borrowed", "Repaid", "Debt", "Leverage",
+		"TrailingRevenue", "AverageHeadcount", "CostRunRate",
+		"RunID", "Timestamp", "Seed", "Version", "ConfigHash",
 	}
 	
 	if len(csvData[0]) != len(expectedHeaders) {
 		t.Errorf("Expected %d headers, got %d", len(expectedHeaders), len(csvData[0]))
 	}
+	for i, header := range expectedHeaders {
+		if i < len(csvData[0]) && csvData[0][i] != header {
+			t.Errorf("Expected header %d to be %s, got %s", i, header, csvData[0][i])
+		}
+	}
 	
 	// Verify data row
 	dataRow := csvData[1]
@@ -252,17 +241,10 @@ func TestWriteReportCSV(t *testing.T) {
 				TotalCost:         100000,
 				TotalProductivity: 10.0,
 				Workforce: types.WorkforceComposition{
-					Humans: struct {
-						Total          int
-						ByExperience   map[types.ExperienceLevel]int
-						ByCostCategory map[types.CostCategory]int
-					}{
+					Humans: types.HumanComposition{
 						Total: 5,
 					},
-					AIAgents: struct {
-						Total        int
-						ByExperience map[types.ExperienceLevel]int
-					}{
+					AIAgents: types.AgentComposition{
 						Total: 2,
 					},
 				},
@@ -358,4 +340,110 @@ func TestCalculateVariance(t *testing.T) {
 	if variance != 0 {
 		t.Errorf("Expected variance 0 for empty slice, got %.2f", variance)
 	}
-}
\ No newline at end of file
+}
+func TestForEachState(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	for i := 0; i < 5; i++ {
+		engine.RecordTimeStep(types.SimulationState{TimeStep: i, TotalCost: float64(i) * 1000})
+	}
+
+	visited := make([]int, 0)
+	engine.ForEachState(func(index int, state types.SimulationState) bool {
+		if index != state.TimeStep {
+			t.Errorf("Expected index %d to match time step, got %d", index, state.TimeStep)
+		}
+		visited = append(visited, state.TimeStep)
+		return true
+	})
+	if len(visited) != 5 {
+		t.Errorf("Expected 5 states visited, got %d", len(visited))
+	}
+
+	// Returning false should stop the iteration
+	count := 0
+	engine.ForEachState(func(index int, state types.SimulationState) bool {
+		count++
+		return index < 1
+	})
+	if count != 2 {
+		t.Errorf("Expected iteration to stop after 2 states, got %d", count)
+	}
+}
+
+func TestMetricSeries(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	engine.RecordTimeStep(types.SimulationState{TimeStep: 0, TotalCost: 100})
+	engine.RecordTimeStep(types.SimulationState{TimeStep: 1, TotalCost: 300})
+
+	sum := 0.0
+	found := engine.MetricSeries("total_cost", func(values []float64) {
+		for _, v := range values {
+			sum += v
+		}
+	})
+	if !found {
+		t.Fatal("Expected total_cost metric to exist")
+	}
+	if sum != 400 {
+		t.Errorf("Expected total_cost sum 400, got %.2f", sum)
+	}
+
+	if engine.MetricSeries("no_such_metric", func(values []float64) {}) {
+		t.Error("Expected unknown metric to report not found")
+	}
+
+	// Metrics should be visited in name order
+	names := make([]string, 0)
+	engine.ForEachMetric(func(name string, values []float64) bool {
+		names = append(names, name)
+		return true
+	})
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("Expected metrics in name order, got %s before %s", names[i-1], names[i])
+		}
+	}
+}
+
+func BenchmarkRunSensitivityAnalysis(b *testing.B) {
+	engine := NewAnalyticsEngine()
+	baseConfig := types.SimulationConfig{
+		InitialHumans: 10,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     3000000.0,
+		RevenueScenario: types.FlatRevenue,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        10.0,
+			ForcedAcceleration: 1.0,
+		},
+		CatastrophicFailureRate: 0.01,
+		TimeZoneInefficiency:    0.1,
+	}
+	ranges := ParameterRanges{
+		FixedBudget:          []float64{2000000, 3000000, 4000000},
+		NaturalAttritionRate: []float64{5, 10, 20},
+		UniversityToMid:      []int{5, 10, 20},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.RunSensitivityAnalysis(baseConfig, ranges, 100, int64(i)); err != nil {
+			b.Fatalf("RunSensitivityAnalysis failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package analytics
+
+import "workforce-ai-transition-simulator/internal/enumtext"
+
+// The enum types of reports marshal as their names and unmarshal from names or numbers, like
+// the enum types of the simulation
+
+// Number of named values of each enum type
+const (
+	numCensoredRunPolicies = int(PenalizeCensored) + 1
+	numForecastMethods     = int(ExponentialForecast) + 1
+	numTransitionPhases    = int(AIDominant) + 1
+)
+
+// MarshalText encodes CensoredRunPolicy as its name
+func (p CensoredRunPolicy) MarshalText() ([]byte, error) {
+	return enumtext.Text(p, numCensoredRunPolicies), nil
+}
+
+// UnmarshalText decodes CensoredRunPolicy from its name or number
+func (p *CensoredRunPolicy) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[CensoredRunPolicy](string(text), numCensoredRunPolicies)
+	if err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
+
+// UnmarshalJSON decodes CensoredRunPolicy from its name or number, as a JSON string or number
+func (p *CensoredRunPolicy) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[CensoredRunPolicy](data, numCensoredRunPolicies)
+	if err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
+
+// MarshalText encodes ForecastMethod as its name
+func (m ForecastMethod) MarshalText() ([]byte, error) {
+	return enumtext.Text(m, numForecastMethods), nil
+}
+
+// UnmarshalText decodes ForecastMethod from its name or number
+func (m *ForecastMethod) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[ForecastMethod](string(text), numForecastMethods)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// UnmarshalJSON decodes ForecastMethod from its name or number, as a JSON string or number
+func (m *ForecastMethod) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[ForecastMethod](data, numForecastMethods)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// MarshalText encodes TransitionPhase as its name
+func (p TransitionPhase) MarshalText() ([]byte, error) {
+	return enumtext.Text(p, numTransitionPhases), nil
+}
+
+// UnmarshalText decodes TransitionPhase from its name or number
+func (p *TransitionPhase) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[TransitionPhase](string(text), numTransitionPhases)
+	if err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
+
+// UnmarshalJSON decodes TransitionPhase from its name or number, as a JSON string or number
+func (p *TransitionPhase) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[TransitionPhase](data, numTransitionPhases)
+	if err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
@@ -0,0 +1,210 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ForecastMethod selects the trend fitted to the recent window of a run when forecasting
+type ForecastMethod int
+
+const (
+	// LinearForecast fits a straight line to the recent values
+	LinearForecast ForecastMethod = iota
+	// ExponentialForecast fits a straight line to the logarithm of the recent values, i.e. constant
+	// growth per step; series with values of zero or less fall back to LinearForecast
+	ExponentialForecast
+)
+
+// DefaultForecastHorizon is the number of steps forecast beyond the end of a run, one year
+const DefaultForecastHorizon = types.TimeStepsPerYear
+
+// minForecastHistory is the fewest recent steps a trend is fitted to
+const minForecastHistory = 3
+
+// forecastZ is the standard normal quantile of the two-sided 95% confidence bands
+const forecastZ = 1.96
+
+// String returns the string representation of ForecastMethod
+func (m ForecastMethod) String() string {
+	switch m {
+	case LinearForecast:
+		return "linear"
+	case ExponentialForecast:
+		return "exponential"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseForecastMethod converts a method name ("linear" or "exponential") to a ForecastMethod
+func ParseForecastMethod(name string) (ForecastMethod, error) {
+	switch name {
+	case "linear":
+		return LinearForecast, nil
+	case "exponential":
+		return ExponentialForecast, nil
+	default:
+		return LinearForecast, fmt.Errorf("unknown forecast method %q (expected linear or exponential)", name)
+	}
+}
+
+// ForecastPoint is the projected value of a metric at a time step after the run, with its 95% band
+type ForecastPoint struct {
+	TimeStep int
+	Value    float64
+	Lower    float64
+	Upper    float64
+}
+
+// SeriesForecast projects one metric beyond the end of a run
+type SeriesForecast struct {
+	Metric string
+	Method ForecastMethod // method actually used, LinearForecast after a fallback
+	Points []ForecastPoint
+}
+
+// Forecast projects revenue, cost and workforce composition beyond the end of a run
+type Forecast struct {
+	Horizon int // steps projected beyond the last state
+	Window  int // recent steps the trends were fitted to
+	Series  []SeriesForecast
+}
+
+// forecastMetrics are the metrics projected by Forecast, named like the recorded metrics
+var forecastMetrics = []struct {
+	name        string
+	value       func(state types.SimulationState) float64
+	nonNegative bool
+}{
+	{"revenue_output", func(state types.SimulationState) float64 { return state.RevenueOutput }, false},
+	{"total_cost", func(state types.SimulationState) float64 { return state.TotalCost }, true},
+	{"human_count", func(state types.SimulationState) float64 { return float64(state.Workforce.Humans.Total) }, true},
+	{"ai_agent_count", func(state types.SimulationState) float64 { return float64(state.Workforce.AIAgents.Total) }, true},
+	{"ai_ratio", func(state types.SimulationState) float64 { return aiWorkforceShare(state) * 100.0 }, true},
+}
+
+// SetForecast sets the trend method and the number of steps projected by Forecast; a horizon
+// of 0 leaves forecasts out of reports
+func (ae *AnalyticsEngine) SetForecast(method ForecastMethod, horizon int) error {
+	if method < LinearForecast || method > ExponentialForecast {
+		return fmt.Errorf("unknown forecast method %d", method)
+	}
+	if horizon < 0 {
+		return fmt.Errorf("forecast horizon must not be negative, got %d", horizon)
+	}
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	ae.forecastMethod = method
+	ae.forecastHorizon = horizon
+	return nil
+}
+
+// Forecast fits a trend to the last rolling window of a run and projects revenue, cost and
+// workforce composition beyond its end, with 95% prediction bands from the fit's residuals
+// Returns an error if the horizon is 0 or the run has fewer than three steps
+func (ae *AnalyticsEngine) Forecast(timeSeries []types.SimulationState) (Forecast, error) {
+	ae.mu.RLock()
+	method, horizon, window := ae.forecastMethod, ae.forecastHorizon, ae.rollingWindow
+	ae.mu.RUnlock()
+
+	if horizon == 0 {
+		return Forecast{}, fmt.Errorf("forecasting is disabled")
+	}
+	if len(timeSeries) < minForecastHistory {
+		return Forecast{}, fmt.Errorf("forecasting needs at least %d time steps, got %d", minForecastHistory, len(timeSeries))
+	}
+
+	recent := timeSeries[len(timeSeries)-maxInt(minInt(window, len(timeSeries)), minForecastHistory):]
+	lastStep := recent[len(recent)-1].TimeStep
+	forecast := Forecast{Horizon: horizon, Window: len(recent), Series: make([]SeriesForecast, 0, len(forecastMetrics))}
+	for _, metric := range forecastMetrics {
+		values := make([]float64, len(recent))
+		for i, state := range recent {
+			values[i] = metric.value(state)
+		}
+
+		series := SeriesForecast{Metric: metric.name, Method: method, Points: make([]ForecastPoint, horizon)}
+		if method == ExponentialForecast && !allPositive(values) {
+			series.Method = LinearForecast
+		}
+		if series.Method == ExponentialForecast {
+			for i := range values {
+				values[i] = math.Log(values[i])
+			}
+		}
+
+		fit := fitLine(values)
+		for k := 1; k <= horizon; k++ {
+			x := float64(len(values) - 1 + k)
+			value, band := fit.predict(x)
+			point := ForecastPoint{TimeStep: lastStep + k, Value: value, Lower: value - band, Upper: value + band}
+			if series.Method == ExponentialForecast {
+				point.Value, point.Lower, point.Upper = math.Exp(point.Value), math.Exp(point.Lower), math.Exp(point.Upper)
+			}
+			if metric.nonNegative {
+				point.Value, point.Lower = math.Max(point.Value, 0), math.Max(point.Lower, 0)
+			}
+			series.Points[k-1] = point
+		}
+		forecast.Series = append(forecast.Series, series)
+	}
+	return forecast, nil
+}
+
+// lineFit is a least-squares line through values indexed 0..n-1
+type lineFit struct {
+	intercept     float64
+	slope         float64
+	n             float64
+	meanX         float64
+	sxx           float64 // sum of squared deviations of the indices from their mean
+	residualError float64 // residual standard error, with n-2 degrees of freedom
+}
+
+// fitLine fits a least-squares line to values against their index
+func fitLine(values []float64) lineFit {
+	fit := lineFit{n: float64(len(values)), meanX: float64(len(values)-1) / 2.0}
+	mean, _ := meanAndStdDev(values)
+
+	sxy := 0.0
+	for i, value := range values {
+		dx := float64(i) - fit.meanX
+		fit.sxx += dx * dx
+		sxy += dx * (value - mean)
+	}
+	if fit.sxx > 0 {
+		fit.slope = sxy / fit.sxx
+	}
+	fit.intercept = mean - fit.slope*fit.meanX
+
+	if len(values) > 2 {
+		residuals := 0.0
+		for i, value := range values {
+			residual := value - fit.intercept - fit.slope*float64(i)
+			residuals += residual * residual
+		}
+		fit.residualError = math.Sqrt(residuals / (fit.n - 2))
+	}
+	return fit
+}
+
+// predict returns the fitted value at index x and the half-width of its 95% prediction band
+func (f lineFit) predict(x float64) (float64, float64) {
+	value := f.intercept + f.slope*x
+	band := forecastZ * f.residualError * math.Sqrt(1.0+1.0/f.n+(x-f.meanX)*(x-f.meanX)/f.sxx)
+	return value, band
+}
+
+// allPositive reports whether every value is greater than zero
+func allPositive(values []float64) bool {
+	for _, value := range values {
+		if value <= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// seriesByMetric returns the forecast of the named metric
+func seriesByMetric(t *testing.T, forecast Forecast, metric string) SeriesForecast {
+	t.Helper()
+	for _, series := range forecast.Series {
+		if series.Metric == metric {
+			return series
+		}
+	}
+	t.Fatalf("Expected a forecast of %s", metric)
+	return SeriesForecast{}
+}
+
+func TestForecastExtendsLinearTrend(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetForecast(LinearForecast, 3); err != nil {
+		t.Fatalf("SetForecast failed: %v", err)
+	}
+
+	timeSeries := make([]types.SimulationState, 20)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].TotalCost = 1000 + 50*float64(i)
+		timeSeries[i].Workforce.Humans.Total = 10
+	}
+
+	forecast, err := engine.Forecast(timeSeries)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if forecast.Horizon != 3 || forecast.Window != DefaultRollingWindow {
+		t.Errorf("Expected a horizon of 3 fitted to %d steps, got %+v", DefaultRollingWindow, forecast)
+	}
+
+	cost := seriesByMetric(t, forecast, "total_cost")
+	for k, point := range cost.Points {
+		want := 1000 + 50*float64(19+k+1)
+		if point.TimeStep != 20+k || math.Abs(point.Value-want) > 1e-6 {
+			t.Errorf("Point %d = %+v, want step %d with value %.2f", k, point, 20+k, want)
+		}
+		// A perfect fit leaves no residuals, so the band collapses onto the projection
+		if math.Abs(point.Upper-point.Lower) > 1e-6 {
+			t.Errorf("Expected no band around a perfect fit, got %+v", point)
+		}
+	}
+}
+
+func TestForecastBandsWidenWithHorizon(t *testing.T) {
+	engine := NewAnalyticsEngine()
+
+	timeSeries := make([]types.SimulationState, 12)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].RevenueOutput = 1000 + float64(i%2)*100
+	}
+
+	forecast, err := engine.Forecast(timeSeries)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	revenue := seriesByMetric(t, forecast, "revenue_output")
+	if len(revenue.Points) != DefaultForecastHorizon {
+		t.Fatalf("Expected %d points, got %d", DefaultForecastHorizon, len(revenue.Points))
+	}
+	for k := 1; k < len(revenue.Points); k++ {
+		previous, point := revenue.Points[k-1], revenue.Points[k]
+		if point.Lower > point.Value || point.Upper < point.Value {
+			t.Errorf("Expected the value inside its band, got %+v", point)
+		}
+		if point.Upper-point.Lower <= previous.Upper-previous.Lower {
+			t.Errorf("Expected the band to widen further from the data, got %+v after %+v", point, previous)
+		}
+	}
+}
+
+func TestForecastExponentialFallsBackForZeroSeries(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetForecast(ExponentialForecast, 2); err != nil {
+		t.Fatalf("SetForecast failed: %v", err)
+	}
+
+	timeSeries := make([]types.SimulationState, 6)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].RevenueOutput = 1000 * math.Pow(1.1, float64(i))
+	}
+
+	forecast, err := engine.Forecast(timeSeries)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	revenue := seriesByMetric(t, forecast, "revenue_output")
+	if revenue.Method != ExponentialForecast || math.Abs(revenue.Points[1].Value-1000*math.Pow(1.1, 7)) > 1e-6 {
+		t.Errorf("Expected exponential growth to continue, got %+v", revenue)
+	}
+	if agents := seriesByMetric(t, forecast, "ai_agent_count"); agents.Method != LinearForecast {
+		t.Errorf("Expected a series of zeros to fall back to a linear fit, got %s", agents.Method)
+	}
+}
+
+func TestForecastErrors(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if _, err := engine.Forecast(make([]types.SimulationState, 2)); err == nil {
+		t.Error("Expected an error for a run shorter than three steps")
+	}
+	if err := engine.SetForecast(LinearForecast, -1); err == nil {
+		t.Error("Expected an error for a negative horizon")
+	}
+	if err := engine.SetForecast(LinearForecast, 0); err != nil {
+		t.Fatalf("SetForecast failed: %v", err)
+	}
+	if _, err := engine.Forecast(make([]types.SimulationState, 10)); err == nil {
+		t.Error("Expected an error when forecasting is disabled")
+	}
+	if _, err := ParseForecastMethod("quadratic"); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+}
@@ -0,0 +1,224 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Percentiles summarizes the distribution of a metric across Monte Carlo runs
+type Percentiles struct {
+	P10 float64
+	P50 float64
+	P90 float64
+}
+
+// MonteCarloStep holds the distribution of revenue across runs at one time step
+type MonteCarloStep struct {
+	TimeStep int
+	Revenue  Percentiles
+}
+
+// MonteCarloResult summarizes repeated runs of one configuration with different seeds
+type MonteCarloResult struct {
+	Runs              int
+	Seeds             []int64
+	Steps             []MonteCarloStep
+	TotalRevenue      Percentiles
+	TimeToEquilibrium Percentiles
+	CensoredRuns      int // runs that stopped without reaching equilibrium
+	Confidence        MonteCarloConfidence
+	Attractors        []Attractor  // distinct final compositions the runs settle into, most prevalent first
+	RunSummaries      []RunSummary // one per run, in seed order
+}
+
+// RunMonteCarlo runs a configuration once per seed, starting from seed and counting up, and
+// reports the percentiles of revenue across the runs rather than a single path
+// Revenue only varies between seeds through randomness in the model, most directly the
+// configured RevenueVolatility. When a run stops earlier than others (at equilibrium) its
+// final revenue is held constant for the remaining steps
+func (ae *AnalyticsEngine) RunMonteCarlo(config types.SimulationConfig, runs int, seed int64, maxTimeSteps int) (MonteCarloResult, error) {
+	if runs <= 0 {
+		return MonteCarloResult{}, fmt.Errorf("monte carlo needs at least 1 run, got %d", runs)
+	}
+
+	results := make([]types.SimulationResult, runs)
+	seeds := make([]int64, runs)
+	for i := range results {
+		seeds[i] = seed + int64(i)
+		result, err := controller.NewSimulationController(config, seeds[i], ae.controllerOptions...).RunUntilEquilibrium(maxTimeSteps)
+		if err != nil {
+			return MonteCarloResult{}, fmt.Errorf("monte carlo run with seed %d failed: %w", seeds[i], err)
+		}
+		results[i] = result
+	}
+
+	return summarizeMonteCarlo(results, seeds), nil
+}
+
+// summarizeMonteCarlo computes the step-aligned revenue percentiles of a set of runs
+func summarizeMonteCarlo(results []types.SimulationResult, seeds []int64) MonteCarloResult {
+	length := 0
+	for _, result := range results {
+		if len(result.TimeSeries) > length {
+			length = len(result.TimeSeries)
+		}
+	}
+
+	steps := make([]MonteCarloStep, length)
+	revenues := make([]float64, 0, len(results))
+	for i := range steps {
+		revenues = revenues[:0]
+		for _, result := range results {
+			if len(result.TimeSeries) == 0 {
+				continue
+			}
+			revenues = append(revenues, result.TimeSeries[minInt(i, len(result.TimeSeries)-1)].RevenueOutput)
+		}
+		steps[i] = MonteCarloStep{TimeStep: i, Revenue: percentilesOf(revenues)}
+	}
+
+	totals := make([]float64, len(results))
+	times := make([]float64, len(results))
+	humans := make([]float64, len(results))
+	agents := make([]float64, len(results))
+	shares := make([]float64, len(results))
+	summaries := make([]RunSummary, len(results))
+	censored := 0
+	for i, result := range results {
+		summaries[i] = SummarizeRun(BatchMonteCarlo, "", result)
+		totals[i] = sumRevenue(result.TimeSeries)
+		times[i] = float64(result.TimeToEquilibrium)
+		humans[i] = float64(result.EquilibriumState.Workforce.Humans.Total)
+		agents[i] = float64(result.EquilibriumState.Workforce.AIAgents.Total)
+		shares[i] = aiWorkforceShare(result.EquilibriumState)
+		if !result.ReachedEquilibrium {
+			censored++
+		}
+	}
+
+	// Each interval resamples from its own stream, derived from the first seed so reruns reproduce it
+	var seed int64
+	if len(seeds) > 0 {
+		seed = seeds[0]
+	}
+	confidence := MonteCarloConfidence{
+		TotalRevenue:      bootstrapMean(totals, random.DeriveSeed(seed, "bootstrap_revenue")),
+		TimeToEquilibrium: bootstrapMean(times, random.DeriveSeed(seed, "bootstrap_time")),
+		FinalHumans:       bootstrapMean(humans, random.DeriveSeed(seed, "bootstrap_humans")),
+		FinalAIAgents:     bootstrapMean(agents, random.DeriveSeed(seed, "bootstrap_agents")),
+		FinalAIShare:      bootstrapMean(shares, random.DeriveSeed(seed, "bootstrap_share")),
+	}
+
+	return MonteCarloResult{
+		Runs:              len(results),
+		Seeds:             seeds,
+		Steps:             steps,
+		TotalRevenue:      percentilesOf(totals),
+		TimeToEquilibrium: percentilesOf(times),
+		CensoredRuns:      censored,
+		Confidence:        confidence,
+		Attractors:        findAttractors(results, seeds),
+		RunSummaries:      summaries,
+	}
+}
+
+// percentilesOf returns the 10th, 50th and 90th percentiles of values
+func percentilesOf(values []float64) Percentiles {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return Percentiles{
+		P10: percentile(sorted, 0.1),
+		P50: percentile(sorted, 0.5),
+		P90: percentile(sorted, 0.9),
+	}
+}
+
+// percentile returns the p-th quantile (0-1) of sorted values, interpolating linearly
+// between the closest ranks; it is 0 for no values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// GenerateMonteCarloCSV produces the per-step revenue percentiles of a Monte Carlo analysis
+func (ae *AnalyticsEngine) GenerateMonteCarloCSV(result MonteCarloResult) [][]string {
+	data := make([][]string, 0, len(result.Steps)+1)
+	data = append(data, []string{"TimeStep", "RevenueP10", "RevenueP50", "RevenueP90"})
+	for _, step := range result.Steps {
+		data = append(data, []string{
+			fmt.Sprintf("%d", step.TimeStep),
+			fmt.Sprintf("%.2f", step.Revenue.P10),
+			fmt.Sprintf("%.2f", step.Revenue.P50),
+			fmt.Sprintf("%.2f", step.Revenue.P90),
+		})
+	}
+	return data
+}
+
+// WriteMonteCarloCSV writes the per-step revenue percentiles of a Monte Carlo analysis as CSV
+func (ae *AnalyticsEngine) WriteMonteCarloCSV(result MonteCarloResult, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(ae.GenerateMonteCarloCSV(result)); err != nil {
+		return fmt.Errorf("failed to write CSV data: %w", err)
+	}
+	return nil
+}
+
+// GenerateMonteCarloSummary describes the spread of outcomes across Monte Carlo runs as a
+// Markdown section
+func (ae *AnalyticsEngine) GenerateMonteCarloSummary(result MonteCarloResult) string {
+	var b strings.Builder
+
+	b.WriteString("## Revenue Uncertainty\n\n")
+	fmt.Fprintf(&b, "Across %d runs with seeds %d to %d, total revenue ranged from %s (P10) through %s (P50) to %s (P90).",
+		result.Runs, result.Seeds[0], result.Seeds[len(result.Seeds)-1],
+		formatCurrency(result.TotalRevenue.P10), formatCurrency(result.TotalRevenue.P50), formatCurrency(result.TotalRevenue.P90))
+	fmt.Fprintf(&b, " Time to equilibrium was %.0f, %.0f and %.0f steps at the same percentiles",
+		result.TimeToEquilibrium.P10, result.TimeToEquilibrium.P50, result.TimeToEquilibrium.P90)
+	if result.CensoredRuns > 0 {
+		fmt.Fprintf(&b, "; %d runs stopped without reaching equilibrium, so these are lower bounds", result.CensoredRuns)
+	}
+	b.WriteString(".\n\n")
+
+	confidence := result.Confidence
+	b.WriteString("Means across the runs with 95% bootstrap confidence intervals:\n\n")
+	b.WriteString("| | Mean | 95% CI |\n")
+	b.WriteString("|---|---:|---:|\n")
+	fmt.Fprintf(&b, "| Total revenue | %s | %s to %s |\n", formatCurrency(confidence.TotalRevenue.Mean),
+		formatCurrency(confidence.TotalRevenue.Lower), formatCurrency(confidence.TotalRevenue.Upper))
+	fmt.Fprintf(&b, "| Time to equilibrium | %.1f | %.1f to %.1f |\n", confidence.TimeToEquilibrium.Mean,
+		confidence.TimeToEquilibrium.Lower, confidence.TimeToEquilibrium.Upper)
+	fmt.Fprintf(&b, "| Final humans | %.1f | %.1f to %.1f |\n", confidence.FinalHumans.Mean,
+		confidence.FinalHumans.Lower, confidence.FinalHumans.Upper)
+	fmt.Fprintf(&b, "| Final AI agents | %.1f | %.1f to %.1f |\n", confidence.FinalAIAgents.Mean,
+		confidence.FinalAIAgents.Lower, confidence.FinalAIAgents.Upper)
+	fmt.Fprintf(&b, "| Final AI share | %.0f%% | %.0f%% to %.0f%% |\n", confidence.FinalAIShare.Mean*100.0,
+		confidence.FinalAIShare.Lower*100.0, confidence.FinalAIShare.Upper*100.0)
+
+	if len(result.Attractors) == 1 {
+		attractor := result.Attractors[0]
+		fmt.Fprintf(&b, "\nAll runs settled into a single equilibrium of about %.0f humans and %.0f AI agents.\n",
+			attractor.Humans, attractor.AIAgents)
+	} else if len(result.Attractors) > 1 {
+		fmt.Fprintf(&b, "\nThe runs settled into **%d distinct equilibria** depending on their random path:\n\n", len(result.Attractors))
+		b.WriteString("| Humans | AI agents | Runs | Prevalence |\n")
+		b.WriteString("|---:|---:|---:|---:|\n")
+		for _, attractor := range result.Attractors {
+			fmt.Fprintf(&b, "| %.1f | %.1f | %d | %.0f%% |\n", attractor.Humans, attractor.AIAgents, attractor.Runs, attractor.Prevalence*100.0)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0, 1}, {0.5, 3}, {0.9, 4.6}, {1, 5},
+	}
+	for _, test := range tests {
+		if got := percentile(sorted, test.p); got < test.expected-1e-9 || got > test.expected+1e-9 {
+			t.Errorf("percentile(%v) = %f, want %f", test.p, got, test.expected)
+		}
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("Expected percentile of no values to be 0, got %f", got)
+	}
+}
+
+func TestRunMonteCarloSpreadsRevenue(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	config.RevenueVolatility = 0.3
+
+	result, err := engine.RunMonteCarlo(config, 10, 100, 50)
+	if err != nil {
+		t.Fatalf("RunMonteCarlo failed: %v", err)
+	}
+	if result.Runs != 10 || result.Seeds[0] != 100 || result.Seeds[9] != 109 {
+		t.Errorf("Expected 10 runs with seeds 100 to 109, got %d runs with seeds %v", result.Runs, result.Seeds)
+	}
+	if len(result.Steps) == 0 {
+		t.Fatal("Expected per-step revenue percentiles")
+	}
+	for _, step := range result.Steps {
+		if step.Revenue.P10 > step.Revenue.P50 || step.Revenue.P50 > step.Revenue.P90 {
+			t.Fatalf("Step %d: percentiles out of order: %+v", step.TimeStep, step.Revenue)
+		}
+	}
+	if result.TotalRevenue.P10 >= result.TotalRevenue.P90 {
+		t.Errorf("Expected volatility to spread total revenue, got %+v", result.TotalRevenue)
+	}
+	revenue := result.Confidence.TotalRevenue
+	if !(revenue.Lower < revenue.Mean && revenue.Mean < revenue.Upper) {
+		t.Errorf("Expected a confidence interval around the mean total revenue, got %+v", revenue)
+	}
+	if revenue.Lower < result.TotalRevenue.P10 || revenue.Upper > result.TotalRevenue.P90 {
+		t.Errorf("Expected the interval of the mean to be narrower than the spread of runs, got %+v and %+v", revenue, result.TotalRevenue)
+	}
+
+	prevalence := 0.0
+	for _, attractor := range result.Attractors {
+		prevalence += attractor.Prevalence
+	}
+	if len(result.Attractors) == 0 || math.Abs(prevalence-1) > 1e-9 {
+		t.Errorf("Expected attractors covering every run, got %+v", result.Attractors)
+	}
+
+	var buf bytes.Buffer
+	if err := engine.WriteMonteCarloCSV(result, &buf); err != nil {
+		t.Fatalf("WriteMonteCarloCSV failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(result.Steps)+1 {
+		t.Errorf("Expected a header and %d rows, got %d lines", len(result.Steps), lines)
+	}
+	if summary := engine.GenerateMonteCarloSummary(result); !strings.Contains(summary, "Across 10 runs with seeds 100 to 109") {
+		t.Errorf("Expected the summary to describe the runs, got %q", summary)
+	}
+	if summary := engine.GenerateMonteCarloSummary(result); !strings.Contains(summary, "| Total revenue |") {
+		t.Errorf("Expected the summary to list confidence intervals, got %q", summary)
+	}
+}
+
+func TestRunMonteCarloRejectsNoRuns(t *testing.T) {
+	if _, err := NewAnalyticsEngine().RunMonteCarlo(testSimulationConfig(), 0, 1, 10); err == nil {
+		t.Error("Expected an error for 0 runs")
+	}
+}
@@ -0,0 +1,64 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ScenarioScore is the score of a scenario's run under an objective
+type ScenarioScore struct {
+	Scenario string
+	Score    float64
+}
+
+// ScenarioRanking orders scenarios by their score under an objective, best first
+type ScenarioRanking struct {
+	Objective string
+	Scores    []ScenarioScore
+}
+
+// RankScenarios runs every named configuration with the same seed and ranks the runs by each
+// objective, so scenarios are judged on the outcome that matters rather than on a single metric
+// Returns an error if a run fails
+func (ae *AnalyticsEngine) RankScenarios(configs map[string]types.SimulationConfig, seed int64, maxTimeSteps int, objectives []objective.Objective) ([]ScenarioRanking, error) {
+	results := make(map[string]types.SimulationResult, len(configs))
+	for name, config := range configs {
+		result, err := controller.NewSimulationController(config, seed, ae.controllerOptions...).RunUntilEquilibrium(maxTimeSteps)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s failed: %w", name, err)
+		}
+		results[name] = result
+	}
+
+	rankings := make([]ScenarioRanking, len(objectives))
+	for i, obj := range objectives {
+		rankings[i] = RankResults(results, obj)
+	}
+	return rankings, nil
+}
+
+// RankResults ranks named simulation results by their score under an objective, best first,
+// breaking ties by name
+func RankResults(results map[string]types.SimulationResult, obj objective.Objective) ScenarioRanking {
+	ranking := ScenarioRanking{Objective: obj.Name(), Scores: make([]ScenarioScore, 0, len(results))}
+	for name, result := range results {
+		ranking.Scores = append(ranking.Scores, ScenarioScore{Scenario: name, Score: obj.Score(result)})
+	}
+	sort.Slice(ranking.Scores, func(i, j int) bool {
+		a, b := ranking.Scores[i], ranking.Scores[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Scenario < b.Scenario
+	})
+	return ranking
+}
+
+// ObjectiveDelta returns the modified run's score under an objective less the baseline's; a
+// positive delta means the change served the objective
+func (c CounterfactualComparison) ObjectiveDelta(obj objective.Objective) float64 {
+	return obj.Score(c.Modified) - obj.Score(c.Baseline)
+}
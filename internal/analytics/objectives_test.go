@@ -0,0 +1,64 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRankResults(t *testing.T) {
+	result := func(agents int) types.SimulationResult {
+		var state types.SimulationState
+		state.Workforce.Humans.Total = 10 - agents
+		state.Workforce.AIAgents.Total = agents
+		return types.SimulationResult{TimeSeries: []types.SimulationState{state, state}}
+	}
+	results := map[string]types.SimulationResult{
+		"half":   result(5),
+		"none":   result(0),
+		"most":   result(8),
+		"evenly": result(5),
+	}
+
+	ranking := RankResults(results, objective.CompositionTarget{TargetAIShare: 0.5})
+	if ranking.Objective != "composition" {
+		t.Errorf("Expected the ranking to name its objective, got %q", ranking.Objective)
+	}
+	want := []string{"evenly", "half", "most", "none"}
+	for i, score := range ranking.Scores {
+		if score.Scenario != want[i] {
+			t.Fatalf("Expected the ranking %v, got %+v", want, ranking.Scores)
+		}
+	}
+}
+
+func TestRankScenarios(t *testing.T) {
+	config := testSimulationConfig()
+	wealthy := config
+	wealthy.FixedBudget = config.FixedBudget * 2
+	configs := map[string]types.SimulationConfig{"baseline": config, "wealthy": wealthy}
+	objectives := []objective.Objective{objective.Profit{}, objective.Resilience{}}
+
+	ae := NewAnalyticsEngine()
+	rankings, err := ae.RankScenarios(configs, 42, 40, objectives)
+	if err != nil {
+		t.Fatalf("RankScenarios failed: %v", err)
+	}
+	if len(rankings) != 2 {
+		t.Fatalf("Expected a ranking per objective, got %d", len(rankings))
+	}
+	for _, ranking := range rankings {
+		if len(ranking.Scores) != 2 || ranking.Scores[0].Score < ranking.Scores[1].Score {
+			t.Errorf("Expected both scenarios ranked best first, got %+v", ranking.Scores)
+		}
+	}
+
+	comparison, err := ae.CompareCounterfactual(config, wealthy, 42, 40)
+	if err != nil {
+		t.Fatalf("CompareCounterfactual failed: %v", err)
+	}
+	profit := objective.Profit{}
+	if delta := comparison.ObjectiveDelta(profit); delta != profit.Score(comparison.Modified)-profit.Score(comparison.Baseline) {
+		t.Errorf("Expected the objective delta to be the difference of the scores, got %f", delta)
+	}
+}
@@ -0,0 +1,180 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ObservedPoint is an observed value of a metric at a time step
+type ObservedPoint struct {
+	TimeStep int
+	Value    float64
+}
+
+// ObservedSeries is the observed history of one metric, such as a company's real headcount
+type ObservedSeries struct {
+	Metric string
+	Points []ObservedPoint
+}
+
+// GoodnessOfFit measures how closely a simulated metric follows its observed history
+type GoodnessOfFit struct {
+	Metric     string
+	Points     int     // observed steps that fall within the simulated run
+	RMSE       float64 // root mean squared error, in the metric's unit
+	NRMSE      float64 // RMSE divided by the mean absolute observation, comparable across units; 0 when all observations are 0
+	MAPE       float64 // mean absolute percentage error (0-100) over the points with a non-zero observation
+	MAPEPoints int     // points MAPE was computed over; MAPE is 0 when there are none
+}
+
+// observableMetrics are the built-in metrics that can be compared against observed data
+var observableMetrics = map[string]func(state types.SimulationState) float64{
+	"human_count":    func(state types.SimulationState) float64 { return float64(state.Workforce.Humans.Total) },
+	"ai_agent_count": func(state types.SimulationState) float64 { return float64(state.Workforce.AIAgents.Total) },
+	"total_workforce": func(state types.SimulationState) float64 {
+		return float64(state.Workforce.Humans.Total + state.Workforce.AIAgents.Total)
+	},
+	"total_cost":         func(state types.SimulationState) float64 { return state.TotalCost },
+	"revenue_output":     func(state types.SimulationState) float64 { return state.RevenueOutput },
+	"total_productivity": func(state types.SimulationState) float64 { return state.TotalProductivity },
+}
+
+// ReadObservedCSV reads observed history from CSV: a TimeStep column followed by one column per
+// metric, named like the recorded metrics (e.g. human_count, total_cost, revenue_output)
+// Empty cells are missing observations and are skipped
+func ReadObservedCSV(reader io.Reader) ([]ObservedSeries, error) {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read observed data: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("observed data has no header")
+	}
+
+	header := rows[0]
+	if len(header) < 2 || strings.TrimSpace(header[0]) != "TimeStep" {
+		return nil, fmt.Errorf("observed data must start with a TimeStep column followed by at least one metric")
+	}
+	series := make([]ObservedSeries, len(header)-1)
+	for i, name := range header[1:] {
+		series[i].Metric = strings.TrimSpace(name)
+		series[i].Points = make([]ObservedPoint, 0, len(rows)-1)
+	}
+
+	for line, row := range rows[1:] {
+		timeStep, err := strconv.Atoi(strings.TrimSpace(row[0]))
+		if err != nil {
+			return nil, fmt.Errorf("observed data line %d: invalid time step %q", line+2, row[0])
+		}
+		for i, cell := range row[1:] {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return nil, fmt.Errorf("observed data line %d: invalid %s value %q", line+2, series[i].Metric, cell)
+			}
+			series[i].Points = append(series[i].Points, ObservedPoint{TimeStep: timeStep, Value: value})
+		}
+	}
+	return series, nil
+}
+
+// LoadObservedCSV reads observed history from a CSV file, see ReadObservedCSV
+func LoadObservedCSV(path string) ([]ObservedSeries, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open observed data: %w", err)
+	}
+	defer file.Close()
+
+	return ReadObservedCSV(file)
+}
+
+// CompareObserved measures how closely a simulated run follows observed history, matching points
+// by time step
+// Metrics can be headcounts, cost, revenue or productivity, or any registered derived metric
+// Returns an error wrapping types.ErrNotFound for a metric that cannot be compared, or an error if
+// none of a metric's observed steps fall within the run
+func (ae *AnalyticsEngine) CompareObserved(result types.SimulationResult, observed []ObservedSeries) ([]GoodnessOfFit, error) {
+	statesByStep := make(map[int]types.SimulationState, len(result.TimeSeries))
+	for _, state := range result.TimeSeries {
+		statesByStep[state.TimeStep] = state
+	}
+
+	fits := make([]GoodnessOfFit, 0, len(observed))
+	for _, series := range observed {
+		value, err := ae.observableMetric(series.Metric)
+		if err != nil {
+			return nil, err
+		}
+
+		fit := GoodnessOfFit{Metric: series.Metric}
+		squaredError, percentageError, observedTotal := 0.0, 0.0, 0.0
+		for _, point := range series.Points {
+			state, exists := statesByStep[point.TimeStep]
+			if !exists {
+				continue
+			}
+			simulated := value(state)
+			if math.IsNaN(simulated) {
+				continue
+			}
+			fit.Points++
+			observedTotal += math.Abs(point.Value)
+			squaredError += (simulated - point.Value) * (simulated - point.Value)
+			if point.Value != 0 {
+				fit.MAPEPoints++
+				percentageError += math.Abs((simulated - point.Value) / point.Value)
+			}
+		}
+		if fit.Points == 0 {
+			return nil, fmt.Errorf("no observed %s steps fall within the simulated run", series.Metric)
+		}
+		fit.RMSE = math.Sqrt(squaredError / float64(fit.Points))
+		if observedTotal > 0 {
+			fit.NRMSE = fit.RMSE / (observedTotal / float64(fit.Points))
+		}
+		if fit.MAPEPoints > 0 {
+			fit.MAPE = percentageError / float64(fit.MAPEPoints) * 100.0
+		}
+		fits = append(fits, fit)
+	}
+	return fits, nil
+}
+
+// observableMetric returns the function computing a comparable metric from a state
+func (ae *AnalyticsEngine) observableMetric(name string) (func(state types.SimulationState) float64, error) {
+	if value, exists := observableMetrics[name]; exists {
+		return value, nil
+	}
+
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	for _, metric := range ae.derivedMetrics {
+		if metric.name == name {
+			return metric.compute, nil
+		}
+	}
+	return nil, fmt.Errorf("metric %q cannot be compared with observed data (expected one of %s or a derived metric): %w",
+		name, strings.Join(ObservableMetrics(), ", "), types.ErrNotFound)
+}
+
+// ObservableMetrics returns the built-in metrics that can be compared against observed data
+func ObservableMetrics() []string {
+	names := make([]string, 0, len(observableMetrics))
+	for name := range observableMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestReadObservedCSV(t *testing.T) {
+	data := "TimeStep,human_count,total_cost\n0,10,1000\n1,,1100\n2,8,1200\n"
+	series, err := ReadObservedCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadObservedCSV failed: %v", err)
+	}
+	if len(series) != 2 || series[0].Metric != "human_count" || series[1].Metric != "total_cost" {
+		t.Fatalf("Expected human_count and total_cost series, got %+v", series)
+	}
+	if len(series[0].Points) != 2 || series[0].Points[1] != (ObservedPoint{TimeStep: 2, Value: 8}) {
+		t.Errorf("Expected the empty cell to be skipped, got %+v", series[0].Points)
+	}
+	if len(series[1].Points) != 3 {
+		t.Errorf("Expected 3 cost observations, got %+v", series[1].Points)
+	}
+
+	for _, invalid := range []string{"", "Step,human_count\n0,1\n", "TimeStep,human_count\nzero,1\n", "TimeStep,human_count\n0,many\n"} {
+		if _, err := ReadObservedCSV(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestCompareObserved(t *testing.T) {
+	result := types.SimulationResult{TimeSeries: make([]types.SimulationState, 3)}
+	for i := range result.TimeSeries {
+		result.TimeSeries[i].TimeStep = i
+		result.TimeSeries[i].TotalCost = 1000
+		result.TimeSeries[i].Workforce.Humans.Total = 10
+	}
+
+	observed := []ObservedSeries{
+		// Errors of 100 and -100 give an RMSE of 100 and a MAPE of (10% + 1/11) / 2
+		{Metric: "total_cost", Points: []ObservedPoint{{0, 900}, {1, 1100}, {7, 5000}}},
+		{Metric: "human_count", Points: []ObservedPoint{{0, 0}, {2, 10}}},
+	}
+	engine := NewAnalyticsEngine()
+	fits, err := engine.CompareObserved(result, observed)
+	if err != nil {
+		t.Fatalf("CompareObserved failed: %v", err)
+	}
+
+	cost := fits[0]
+	wantMAPE := (100.0/900.0 + 100.0/1100.0) / 2 * 100
+	if cost.Points != 2 || math.Abs(cost.RMSE-100) > 1e-9 || math.Abs(cost.MAPE-wantMAPE) > 1e-9 {
+		t.Errorf("Expected RMSE 100 and MAPE %.4f over 2 points, got %+v", wantMAPE, cost)
+	}
+	humans := fits[1]
+	if humans.Points != 2 || humans.MAPEPoints != 1 || humans.MAPE != 0 || math.Abs(humans.RMSE-math.Sqrt(50)) > 1e-9 {
+		t.Errorf("Expected the zero observation to be left out of MAPE only, got %+v", humans)
+	}
+}
+
+func TestCompareObservedErrors(t *testing.T) {
+	result := types.SimulationResult{TimeSeries: []types.SimulationState{{TimeStep: 0}}}
+	engine := NewAnalyticsEngine()
+
+	_, err := engine.CompareObserved(result, []ObservedSeries{{Metric: "morale", Points: []ObservedPoint{{0, 1}}}})
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown metric, got %v", err)
+	}
+	if _, err := engine.CompareObserved(result, []ObservedSeries{{Metric: "total_cost", Points: []ObservedPoint{{5, 1}}}}); err == nil {
+		t.Error("Expected an error when no observations fall within the run")
+	}
+
+	if err := engine.RegisterDerivedMetric("morale", func(state types.SimulationState) float64 { return 1 }); err != nil {
+		t.Fatalf("RegisterDerivedMetric failed: %v", err)
+	}
+	fits, err := engine.CompareObserved(result, []ObservedSeries{{Metric: "morale", Points: []ObservedPoint{{0, 1}}}})
+	if err != nil || fits[0].RMSE != 0 {
+		t.Errorf("Expected derived metrics to be comparable, got %+v, %v", fits, err)
+	}
+}
@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// SensitivityOptions controls how each run of a sensitivity analysis is started
+type SensitivityOptions struct {
+	// WarmStart, when set, starts every run from this checkpoint instead of building a new
+	// workforce, so the analysis measures how a stable organization responds to each
+	// parameter change rather than cold-start dynamics. All runs continue the checkpoint's
+	// random streams, so the parameter value is the only difference between them
+	WarmStart *controller.Checkpoint
+
+	// Store, when set, records each run's result as soon as it completes, and runs already in
+	// the store are reused instead of simulated again, so an interrupted sweep can be resumed
+	Store store.ResultStore
+
+	// Executor, when set, runs every simulation of the analysis instead of this process, e.g. on
+	// remote workers; the runs of a parameter are then handed to it all at once rather than one
+	// after the other, so it can execute them in parallel
+	Executor SweepExecutor
+
+	// controllerOptions are the engine's options for every run's controller
+	controllerOptions []controller.Option
+}
+
+// validate checks that the requested parameter ranges can be studied with these options
+func (opts SensitivityOptions) validate(paramRanges ParameterRanges) error {
+	if opts.WarmStart != nil && len(paramRanges.InitialHumans) > 0 {
+		return errors.New("InitialHumans cannot be varied in a warm-started sensitivity analysis")
+	}
+	return nil
+}
+
+// SweepTask is a single run of a sensitivity analysis, with everything needed to simulate it
+// in another process
+type SweepTask struct {
+	Key          store.RunKey
+	Config       types.SimulationConfig
+	MaxTimeSteps int
+	WarmStart    *controller.Checkpoint `json:",omitempty"` // see SensitivityOptions.WarmStart
+}
+
+// Run simulates the task, applying options to its controller
+// The result is the same wherever the task runs, given the same controller options
+func (t SweepTask) Run(options ...controller.Option) (types.SimulationResult, error) {
+	var simController *controller.SimulationController
+	if t.WarmStart == nil {
+		simController = controller.NewSimulationController(t.Config, t.Key.Seed, options...)
+	} else {
+		var err error
+		simController, err = controller.WarmStartSimulationController(*t.WarmStart, t.Config, options...)
+		if err != nil {
+			return types.SimulationResult{}, fmt.Errorf("failed to start simulation: %w", err)
+		}
+	}
+	return simController.RunUntilEquilibrium(t.MaxTimeSteps)
+}
+
+// SweepExecutor runs the simulations of a sensitivity analysis in place of the local process
+// Implementations must be safe for concurrent use, since analyses submit runs in parallel
+type SweepExecutor interface {
+	// Execute runs a task to completion and returns its result
+	Execute(task SweepTask) (types.SimulationResult, error)
+}
+
+// runSimulation returns the result of a single sensitivity run, reusing a stored result if
+// the run has completed before
+func (opts SensitivityOptions) runSimulation(key store.RunKey, config types.SimulationConfig, maxTimeSteps int) (types.SimulationResult, error) {
+	if opts.Store != nil {
+		if result, exists := opts.Store.Lookup(key); exists {
+			return result, nil
+		}
+	}
+
+	task := SweepTask{Key: key, Config: config, MaxTimeSteps: maxTimeSteps, WarmStart: opts.WarmStart}
+	var result types.SimulationResult
+	var err error
+	if opts.Executor != nil {
+		result, err = opts.Executor.Execute(task)
+	} else {
+		result, err = task.Run(opts.controllerOptions...)
+	}
+	if err != nil {
+		return types.SimulationResult{}, err
+	}
+
+	if opts.Store != nil {
+		if err := opts.Store.Save(key, result); err != nil {
+			return types.SimulationResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// forEachRun calls run for each of n runs, one after the other, or all at once when an
+// Executor runs them; it returns the error of the first failed run
+func (opts SensitivityOptions) forEachRun(n int, run func(i int) error) error {
+	if opts.Executor == nil {
+		for i := 0; i < n; i++ {
+			if err := run(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = run(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BaselineCheckpoint runs the base configuration, with any controller options, until equilibrium
+// and checkpoints the result for use as SensitivityOptions.WarmStart
+// Returns an error if the baseline does not reach equilibrium within maxTimeSteps
+func BaselineCheckpoint(baseConfig types.SimulationConfig, maxTimeSteps int, seed int64, options ...controller.Option) (*controller.Checkpoint, error) {
+	simController := controller.NewSimulationController(baseConfig, seed, options...)
+	result, err := simController.RunUntilEquilibrium(maxTimeSteps)
+	if err != nil {
+		return nil, fmt.Errorf("baseline simulation failed: %w", err)
+	}
+	if !result.ReachedEquilibrium {
+		return nil, fmt.Errorf("baseline did not reach equilibrium within %d steps", maxTimeSteps)
+	}
+
+	checkpoint, err := simController.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// SetControllerOptions sets options applied to the controller of every run the engine simulates,
+// such as custom behaviors, replacing any set before
+// Stored sensitivity runs are reused regardless of the options, so a sweep store should not be
+// shared between engines with different options
+func (ae *AnalyticsEngine) SetControllerOptions(options ...controller.Option) {
+	ae.controllerOptions = options
+}
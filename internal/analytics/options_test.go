@@ -0,0 +1,200 @@
+package analytics
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/testfixtures"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestWarmStartSensitivityStartsFromBaselineEquilibrium(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+
+	checkpoint, err := BaselineCheckpoint(config, 200, 7)
+	if err != nil {
+		t.Fatalf("BaselineCheckpoint failed: %v", err)
+	}
+	baseline := checkpoint.TimeSeries[len(checkpoint.TimeSeries)-1].Workforce
+
+	ranges := ParameterRanges{
+		FixedBudget:             []float64{config.FixedBudget, config.FixedBudget * 1.5},
+		CatastrophicFailureRate: []float64{0.01, 0.1},
+	}
+	results, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{WarmStart: checkpoint})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions failed: %v", err)
+	}
+
+	for name, paramResults := range results {
+		for i, result := range paramResults.Results {
+			initial := result.TimeSeries[0]
+			if initial.TimeStep != checkpoint.CurrentTimeStep {
+				t.Errorf("%s run %d: expected to start at step %d, got %d", name, i, checkpoint.CurrentTimeStep, initial.TimeStep)
+			}
+			if initial.Workforce.Humans.Total != baseline.Humans.Total ||
+				initial.Workforce.AIAgents.Total != baseline.AIAgents.Total {
+				t.Errorf("%s run %d: expected to start from the baseline equilibrium workforce", name, i)
+			}
+			if result.TimeToEquilibrium > 100 {
+				t.Errorf("%s run %d: time to equilibrium %d exceeds the step limit", name, i, result.TimeToEquilibrium)
+			}
+		}
+	}
+}
+
+// midRunCheckpoint returns a checkpoint of a run of config at time step 20, with a workforce
+// orchestrating a few agents
+func midRunCheckpoint(t *testing.T, config types.SimulationConfig) *controller.Checkpoint {
+	t.Helper()
+	checkpoint, err := testfixtures.NewWorkforceBuilder().
+		WithConfig(config).
+		WithSeed(7).
+		AtTimeStep(20).
+		WithHumans(1, types.Executive, types.HighCostUS).
+		WithHumans(3, types.Senior, types.HighCostUS).
+		WithHumans(4, types.MidLevel, types.LowCostNonUS).
+		WithAgents(6, types.MidLevel).
+		Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	return &checkpoint
+}
+
+func TestWarmStartSensitivityRejectsInitialHumans(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+
+	checkpoint := midRunCheckpoint(t, config)
+
+	ranges := ParameterRanges{InitialHumans: []int{5, 10}}
+	if _, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{WarmStart: checkpoint}); err == nil {
+		t.Error("Expected an error when varying InitialHumans in a warm-started analysis")
+	}
+}
+
+// countingStore is an in-memory ResultStore that counts saved runs
+type countingStore struct {
+	mu      sync.Mutex
+	results map[store.RunKey]types.SimulationResult
+	saves   int
+}
+
+func (s *countingStore) Lookup(key store.RunKey) (types.SimulationResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, exists := s.results[key]
+	return result, exists
+}
+
+func (s *countingStore) Save(key store.RunKey, result types.SimulationResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[key] = result
+	s.saves++
+	return nil
+}
+
+func TestSensitivityResumeSkipsCompletedRuns(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	ranges := ParameterRanges{
+		FixedBudget:             []float64{config.FixedBudget, config.FixedBudget * 1.5},
+		CatastrophicFailureRate: []float64{0.01, 0.1},
+	}
+
+	resultStore := &countingStore{results: make(map[store.RunKey]types.SimulationResult)}
+	first, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{Store: resultStore})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions failed: %v", err)
+	}
+	if resultStore.saves != 4 {
+		t.Fatalf("Expected 4 saved runs, got %d", resultStore.saves)
+	}
+
+	// Drop one run as if the sweep had been interrupted before it completed
+	delete(resultStore.results, store.RunKey{Parameter: "FixedBudget", Value: config.FixedBudget * 1.5, Seed: 8})
+	resumed, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{Store: resultStore})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions failed: %v", err)
+	}
+	if resultStore.saves != 5 {
+		t.Errorf("Expected only the missing run to be simulated again, got %d saves in total", resultStore.saves)
+	}
+	// The run simulated again is a new run with its own ID and start time
+	clearRunIdentity(first)
+	clearRunIdentity(resumed)
+	if !reflect.DeepEqual(first, resumed) {
+		t.Error("Expected the resumed sweep to produce the same results")
+	}
+}
+
+// remoteExecutor runs sweep tasks after a JSON round trip, as a remote worker would receive them
+type remoteExecutor struct {
+	mu    sync.Mutex
+	tasks int
+}
+
+func (e *remoteExecutor) Execute(task SweepTask) (types.SimulationResult, error) {
+	e.mu.Lock()
+	e.tasks++
+	e.mu.Unlock()
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		return types.SimulationResult{}, err
+	}
+	var received SweepTask
+	if err := json.Unmarshal(data, &received); err != nil {
+		return types.SimulationResult{}, err
+	}
+	return received.Run()
+}
+
+func TestSensitivityExecutorMatchesLocalRuns(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	checkpoint := midRunCheckpoint(t, config)
+	ranges := ParameterRanges{
+		FixedBudget:             []float64{config.FixedBudget, config.FixedBudget * 1.5, config.FixedBudget * 2},
+		CatastrophicFailureRate: []float64{0.01, 0.1},
+	}
+
+	local, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{WarmStart: checkpoint})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions failed: %v", err)
+	}
+	executor := &remoteExecutor{}
+	remote, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, SensitivityOptions{WarmStart: checkpoint, Executor: executor})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions with an executor failed: %v", err)
+	}
+	if executor.tasks != 5 {
+		t.Errorf("Expected the executor to run all 5 runs, got %d", executor.tasks)
+	}
+	for name, results := range local {
+		for i := range results.Results {
+			if local, remote := results.Results[i], remote[name].Results[i]; local.TimeToEquilibrium != remote.TimeToEquilibrium ||
+				!reflect.DeepEqual(local.EquilibriumState.Workforce, remote.EquilibriumState.Workforce) || local.EquilibriumState.TotalCost != remote.EquilibriumState.TotalCost {
+				t.Errorf("%s run %d: expected the executed run to match the local one", name, i)
+			}
+		}
+	}
+}
+
+// clearRunIdentity blanks the run IDs and timestamps of sensitivity results so that separate
+// runs with the same outcome compare equal
+func clearRunIdentity(results map[string]SensitivityResults) {
+	for _, parameterResults := range results {
+		for i := range parameterResults.Results {
+			parameterResults.Results[i].Metadata.RunID = ""
+			parameterResults.Results[i].Metadata.Timestamp = time.Time{}
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// parameterSetters applies a sensitivity parameter value to a configuration, keyed by the
+// parameter names used in sensitivity results
+var parameterSetters = map[string]func(config *types.SimulationConfig, value float64){
+	"FixedBudget":             func(config *types.SimulationConfig, value float64) { config.FixedBudget = value },
+	"InitialHumans":           func(config *types.SimulationConfig, value float64) { config.InitialHumans = int(value) },
+	"CatastrophicFailureRate": func(config *types.SimulationConfig, value float64) { config.CatastrophicFailureRate = value },
+	"TimeZoneInefficiency":    func(config *types.SimulationConfig, value float64) { config.TimeZoneInefficiency = value },
+	"NaturalAttritionRate":    func(config *types.SimulationConfig, value float64) { config.AttritionConfig.NaturalRate = value },
+	"ForcedAcceleration":      func(config *types.SimulationConfig, value float64) { config.AttritionConfig.ForcedAcceleration = value },
+	"UniversityToMid": func(config *types.SimulationConfig, value float64) {
+		config.AILearningSpeeds.UniversityToMid = int(value)
+	},
+	"MidToSenior": func(config *types.SimulationConfig, value float64) { config.AILearningSpeeds.MidToSenior = int(value) },
+	"SeniorToExecutive": func(config *types.SimulationConfig, value float64) {
+		config.AILearningSpeeds.SeniorToExecutive = int(value)
+	},
+}
+
+// SensitivityParameterNames returns the names of the parameters a sensitivity analysis can vary
+func SensitivityParameterNames() []string {
+	names := make([]string, 0, len(parameterSetters))
+	for name := range parameterSetters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetParameter applies a value of the named sensitivity parameter to a configuration
+// Returns an error if the name is not a sensitivity parameter
+func SetParameter(config *types.SimulationConfig, name string, value float64) error {
+	setter, exists := parameterSetters[name]
+	if !exists {
+		return fmt.Errorf("unknown sensitivity parameter %q", name)
+	}
+	setter(config, value)
+	return nil
+}
+
+// Values returns the values of the named parameter's range
+// Returns false if the name is not a sensitivity parameter
+func (pr ParameterRanges) Values(name string) ([]float64, bool) {
+	toFloats := func(values []int) []float64 {
+		floats := make([]float64, len(values))
+		for i, v := range values {
+			floats[i] = float64(v)
+		}
+		return floats
+	}
+
+	switch name {
+	case "FixedBudget":
+		return pr.FixedBudget, true
+	case "InitialHumans":
+		return toFloats(pr.InitialHumans), true
+	case "CatastrophicFailureRate":
+		return pr.CatastrophicFailureRate, true
+	case "TimeZoneInefficiency":
+		return pr.TimeZoneInefficiency, true
+	case "NaturalAttritionRate":
+		return pr.NaturalAttritionRate, true
+	case "ForcedAcceleration":
+		return pr.ForcedAcceleration, true
+	case "UniversityToMid":
+		return toFloats(pr.UniversityToMid), true
+	case "MidToSenior":
+		return toFloats(pr.MidToSenior), true
+	case "SeniorToExecutive":
+		return toFloats(pr.SeniorToExecutive), true
+	default:
+		return nil, false
+	}
+}
+
+// PairwiseResults holds the results of a pairwise sensitivity analysis, which runs every
+// combination of two parameters' values to expose interactions between them
+type PairwiseResults struct {
+	ParameterA string
+	ParameterB string
+	ValuesA    []float64
+	ValuesB    []float64
+	Results    [][]types.SimulationResult // Results[i][j] ran with ValuesA[i] and ValuesB[j]
+}
+
+// RunPairwiseSensitivity runs a simulation for every combination of paramA and paramB values
+// Rows run in parallel, as does every run with an Executor; every run has its own seed and is
+// recorded in opts.Store under a key naming both parameters, so pairwise grids can share a store
+// with one-at-a-time sweeps
+func (ae *AnalyticsEngine) RunPairwiseSensitivity(baseConfig types.SimulationConfig, paramA string, valuesA []float64, paramB string, valuesB []float64, maxTimeSteps int, seed int64, opts SensitivityOptions) (PairwiseResults, error) {
+	setterA, exists := parameterSetters[paramA]
+	if !exists {
+		return PairwiseResults{}, fmt.Errorf("unknown sensitivity parameter %q", paramA)
+	}
+	setterB, exists := parameterSetters[paramB]
+	if !exists {
+		return PairwiseResults{}, fmt.Errorf("unknown sensitivity parameter %q", paramB)
+	}
+	if paramA == paramB {
+		return PairwiseResults{}, fmt.Errorf("pairwise sensitivity needs two different parameters, got %s twice", paramA)
+	}
+	if len(valuesA) == 0 || len(valuesB) == 0 {
+		return PairwiseResults{}, errors.New("pairwise sensitivity needs at least one value for each parameter")
+	}
+	if opts.WarmStart != nil && (paramA == "InitialHumans" || paramB == "InitialHumans") {
+		return PairwiseResults{}, errors.New("InitialHumans cannot be varied in a warm-started sensitivity analysis")
+	}
+	opts.controllerOptions = ae.controllerOptions
+
+	results := make([][]types.SimulationResult, len(valuesA))
+	errs := make([]error, len(valuesA))
+
+	var wg sync.WaitGroup
+	for i := range valuesA {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			row := make([]types.SimulationResult, len(valuesB))
+			errs[i] = opts.forEachRun(len(valuesB), func(j int) error {
+				valueB := valuesB[j]
+				config := baseConfig
+				setterA(&config, valuesA[i])
+				setterB(&config, valueB)
+
+				key := store.RunKey{
+					Parameter: fmt.Sprintf("%s=%g,%s", paramA, valuesA[i], paramB),
+					Value:     valueB,
+					Seed:      seed + int64(i*len(valuesB)+j),
+				}
+				result, err := opts.runSimulation(key, config, maxTimeSteps)
+				if err != nil {
+					return fmt.Errorf("simulation failed for %s=%f, %s=%f: %w", paramA, valuesA[i], paramB, valueB, err)
+				}
+				row[j] = result
+				return nil
+			})
+			results[i] = row
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return PairwiseResults{}, fmt.Errorf("pairwise sensitivity analysis failed: %w", err)
+		}
+	}
+
+	return PairwiseResults{
+		ParameterA: paramA,
+		ParameterB: paramB,
+		ValuesA:    append([]float64(nil), valuesA...),
+		ValuesB:    append([]float64(nil), valuesB...),
+		Results:    results,
+	}, nil
+}
+
+// heatmapMetrics extracts each heatmap outcome metric from a run, named like the columns
+// of the detailed sensitivity CSV
+var heatmapMetrics = map[string]func(result types.SimulationResult) float64{
+	"FinalHumanCount":          func(r types.SimulationResult) float64 { return float64(r.EquilibriumState.Workforce.Humans.Total) },
+	"FinalAIAgentCount":        func(r types.SimulationResult) float64 { return float64(r.EquilibriumState.Workforce.AIAgents.Total) },
+	"FinalTotalCost":           func(r types.SimulationResult) float64 { return r.EquilibriumState.TotalCost },
+	"FinalProductivity":        func(r types.SimulationResult) float64 { return r.EquilibriumState.TotalProductivity },
+	"FinalRevenue":             func(r types.SimulationResult) float64 { return r.EquilibriumState.RevenueOutput },
+	"OrchestrationUtilization": func(r types.SimulationResult) float64 { return r.EquilibriumState.Workforce.OrchestrationUtilization },
+	"CatastrophicFailures":     func(r types.SimulationResult) float64 { return float64(r.TotalCatastrophicFailures) },
+}
+
+// HeatmapMetrics returns the outcome metrics a heatmap can show
+func HeatmapMetrics() []string {
+	names := []string{"TimeToEquilibrium"}
+	for name := range heatmapMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Heatmap is a pairwise sensitivity grid pivoted for plotting: one row per ParameterA
+// value, one column per ParameterB value, and the outcome metric in each cell
+type Heatmap struct {
+	ParameterA string
+	ParameterB string
+	Metric     string
+	Rows       []float64
+	Columns    []float64
+	Cells      [][]*float64 // nil where the run is excluded by the censored run policy
+}
+
+// GenerateHeatmap pivots pairwise results into a heatmap of the named metric
+// TimeToEquilibrium cells follow the engine's censored run policy; other metrics use the
+// final state of every run
+func (ae *AnalyticsEngine) GenerateHeatmap(results PairwiseResults, metric string) (Heatmap, error) {
+	extract, exists := heatmapMetrics[metric]
+	if !exists && metric != "TimeToEquilibrium" {
+		return Heatmap{}, fmt.Errorf("unknown heatmap metric %q", metric)
+	}
+
+	cells := make([][]*float64, len(results.ValuesA))
+	for i, row := range results.Results {
+		cells[i] = make([]*float64, len(row))
+		for j, result := range row {
+			var value float64
+			if exists {
+				value = extract(result)
+			} else {
+				var included bool
+				if value, included = ae.effectiveTimeToEquilibrium(result); !included {
+					continue
+				}
+			}
+			cells[i][j] = &value
+		}
+	}
+
+	return Heatmap{
+		ParameterA: results.ParameterA,
+		ParameterB: results.ParameterB,
+		Metric:     metric,
+		Rows:       results.ValuesA,
+		Columns:    results.ValuesB,
+		Cells:      cells,
+	}, nil
+}
+
+// HeatmapCSV formats a heatmap as CSV rows
+// The top-left cell names both parameters as "ParameterA\ParameterB", the rest of the header
+// holds the ParameterB values and each row starts with its ParameterA value; excluded cells
+// are left empty
+func HeatmapCSV(heatmap Heatmap) [][]string {
+	data := make([][]string, 0, len(heatmap.Rows)+1)
+
+	header := []string{heatmap.ParameterA + `\` + heatmap.ParameterB}
+	for _, value := range heatmap.Columns {
+		header = append(header, fmt.Sprintf("%.4f", value))
+	}
+	data = append(data, header)
+
+	for i, value := range heatmap.Rows {
+		row := []string{fmt.Sprintf("%.4f", value)}
+		for _, cell := range heatmap.Cells[i] {
+			if cell == nil {
+				row = append(row, "")
+			} else {
+				row = append(row, fmt.Sprintf("%.2f", *cell))
+			}
+		}
+		data = append(data, row)
+	}
+
+	return data
+}
+
+// WriteHeatmapCSV writes a heatmap as CSV
+func WriteHeatmapCSV(heatmap Heatmap, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(HeatmapCSV(heatmap)); err != nil {
+		return fmt.Errorf("failed to write heatmap CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteHeatmapJSON writes a heatmap as JSON; excluded cells are null
+func WriteHeatmapJSON(heatmap Heatmap, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(heatmap); err != nil {
+		return fmt.Errorf("failed to encode heatmap: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRunPairwiseSensitivityCoversGrid(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+	rates := []float64{0.01, 0.1}
+	inefficiencies := []float64{0.0, 0.2, 0.4}
+
+	results, err := engine.RunPairwiseSensitivity(config, "CatastrophicFailureRate", rates, "TimeZoneInefficiency", inefficiencies, 100, 7, SensitivityOptions{})
+	if err != nil {
+		t.Fatalf("RunPairwiseSensitivity failed: %v", err)
+	}
+
+	if len(results.Results) != len(rates) {
+		t.Fatalf("Expected %d rows, got %d", len(rates), len(results.Results))
+	}
+	for i, row := range results.Results {
+		if len(row) != len(inefficiencies) {
+			t.Fatalf("Expected %d columns in row %d, got %d", len(inefficiencies), i, len(row))
+		}
+		for j, result := range row {
+			if result.Config.CatastrophicFailureRate != rates[i] || result.Config.TimeZoneInefficiency != inefficiencies[j] {
+				t.Errorf("Cell (%d, %d) ran with %.2f/%.2f", i, j,
+					result.Config.CatastrophicFailureRate, result.Config.TimeZoneInefficiency)
+			}
+		}
+	}
+}
+
+func TestRunPairwiseSensitivityRejectsInvalidParameters(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+
+	if _, err := engine.RunPairwiseSensitivity(config, "Unknown", []float64{1}, "FixedBudget", []float64{1}, 10, 1, SensitivityOptions{}); err == nil {
+		t.Error("Expected an error for an unknown parameter")
+	}
+	if _, err := engine.RunPairwiseSensitivity(config, "FixedBudget", []float64{1}, "FixedBudget", []float64{1}, 10, 1, SensitivityOptions{}); err == nil {
+		t.Error("Expected an error for the same parameter twice")
+	}
+}
+
+func pairwiseFixture() PairwiseResults {
+	result := func(timeToEquilibrium int, reached bool, humans int) types.SimulationResult {
+		r := types.SimulationResult{TimeToEquilibrium: timeToEquilibrium, ReachedEquilibrium: reached}
+		r.EquilibriumState.Workforce.Humans.Total = humans
+		return r
+	}
+
+	return PairwiseResults{
+		ParameterA: "NaturalAttritionRate",
+		ParameterB: "MidToSenior",
+		ValuesA:    []float64{5, 10},
+		ValuesB:    []float64{12, 24, 36},
+		Results: [][]types.SimulationResult{
+			{result(10, true, 4), result(20, true, 5), result(100, false, 6)},
+			{result(15, true, 3), result(25, true, 2), result(30, true, 1)},
+		},
+	}
+}
+
+func TestGenerateHeatmapPivotsMetric(t *testing.T) {
+	engine := NewAnalyticsEngine()
+
+	heatmap, err := engine.GenerateHeatmap(pairwiseFixture(), "FinalHumanCount")
+	if err != nil {
+		t.Fatalf("GenerateHeatmap failed: %v", err)
+	}
+
+	want := [][]float64{{4, 5, 6}, {3, 2, 1}}
+	for i, row := range want {
+		for j, value := range row {
+			if cell := heatmap.Cells[i][j]; cell == nil || *cell != value {
+				t.Errorf("Expected cell (%d, %d) to be %.0f, got %v", i, j, value, cell)
+			}
+		}
+	}
+
+	if _, err := engine.GenerateHeatmap(pairwiseFixture(), "Unknown"); err == nil {
+		t.Error("Expected an error for an unknown metric")
+	}
+}
+
+func TestGenerateHeatmapAppliesCensoredPolicy(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetCensoredRunPolicy(ExcludeCensored, 1); err != nil {
+		t.Fatalf("SetCensoredRunPolicy failed: %v", err)
+	}
+
+	heatmap, err := engine.GenerateHeatmap(pairwiseFixture(), "TimeToEquilibrium")
+	if err != nil {
+		t.Fatalf("GenerateHeatmap failed: %v", err)
+	}
+	if heatmap.Cells[0][2] != nil {
+		t.Errorf("Expected the censored run to be excluded, got %v", *heatmap.Cells[0][2])
+	}
+
+	var csvOutput bytes.Buffer
+	if err := WriteHeatmapCSV(heatmap, &csvOutput); err != nil {
+		t.Fatalf("WriteHeatmapCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOutput.String()), "\n")
+	expected := []string{
+		`NaturalAttritionRate\MidToSenior,12.0000,24.0000,36.0000`,
+		"5.0000,10.00,20.00,",
+		"10.0000,15.00,25.00,30.00",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected heatmap CSV:\n%s", csvOutput.String())
+	}
+
+	var jsonOutput bytes.Buffer
+	if err := WriteHeatmapJSON(heatmap, &jsonOutput); err != nil {
+		t.Fatalf("WriteHeatmapJSON failed: %v", err)
+	}
+	var decoded struct{ Cells [][]*float64 }
+	if err := json.Unmarshal(jsonOutput.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode heatmap JSON: %v", err)
+	}
+	if decoded.Cells[0][2] != nil || decoded.Cells[1][2] == nil || *decoded.Cells[1][2] != 30 {
+		t.Errorf("Unexpected heatmap JSON cells: %s", jsonOutput.String())
+	}
+}
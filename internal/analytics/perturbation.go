@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// PerturbationResult is how an equilibrium absorbed one standardized shock
+type PerturbationResult struct {
+	Perturbation  string
+	Recovered     bool    // the shocked run settled into equilibrium again within the step limit
+	RecoverySteps int     // time steps the shocked run took to settle; when not Recovered, the steps it ran for
+	Score         float64 // BaselineSteps divided by RecoverySteps, capped at 1; 0 when not Recovered
+	FinalHumans   int
+	FinalAIAgents int
+}
+
+// PerturbationResilience scores how quickly an equilibrium returns to equilibrium after
+// standardized shocks
+type PerturbationResilience struct {
+	BaselineSteps int // time steps an unshocked run from the same equilibrium takes to be detected as settled
+	Perturbations []PerturbationResult
+	Score         float64 // mean score over the perturbations (0-1); 1 when every shock is absorbed as fast as no shock at all
+}
+
+// perturbation is a standardized shock applied to an equilibrium: a change to the configuration
+// and, once the organization is restored, a change to the running simulation
+type perturbation struct {
+	name   string
+	config func(config *types.SimulationConfig)
+	apply  func(sc *controller.SimulationController, config types.SimulationConfig)
+}
+
+// standardPerturbations are the shocks every equilibrium is tested against
+var standardPerturbations = []perturbation{
+	{
+		name:  "Lose 10% of humans",
+		apply: func(sc *controller.SimulationController, config types.SimulationConfig) { sc.RemoveHumans(0.1) },
+	},
+	{
+		name:   "Budget cut by 10%",
+		config: func(config *types.SimulationConfig) { config.FixedBudget *= 0.9 },
+	},
+	{
+		name: "Catastrophic failure of severity 1.0",
+		apply: func(sc *controller.SimulationController, config types.SimulationConfig) {
+			sc.ForceCatastrophicFailure(mostLikelyFailureClass(config), 1.0)
+		},
+	},
+}
+
+// PerturbationResilience branches runs from an equilibrium checkpoint, applies a standardized
+// shock to each (losing 10% of humans, a 10% budget cut and a catastrophic failure of severity
+// 1.0) and scores how quickly each returns to equilibrium
+// A branch is back at equilibrium once equilibrium is detected and its composition has also
+// stayed within the configured tolerance over the stability window, so a run that is declared
+// settled on an exhausted budget or full orchestration capacity while it still sheds agents
+// does not count. Every branch starts from the same organization and random state, and the
+// scores are relative to an unshocked branch, which still needs a full window to settle
+// Returns an error if the checkpoint is not at equilibrium or a branch cannot be started
+func (ae *AnalyticsEngine) PerturbationResilience(checkpoint controller.Checkpoint, maxTimeSteps int) (PerturbationResilience, error) {
+	if !checkpoint.EquilibriumReached {
+		return PerturbationResilience{}, errors.New("perturbation resilience needs a checkpoint at equilibrium")
+	}
+
+	baseline, err := runPerturbation(checkpoint, perturbation{name: "None"}, maxTimeSteps, ae.controllerOptions)
+	if err != nil {
+		return PerturbationResilience{}, err
+	}
+	resilience := PerturbationResilience{
+		BaselineSteps: baseline.RecoverySteps,
+		Perturbations: make([]PerturbationResult, len(standardPerturbations)),
+	}
+	for i, shock := range standardPerturbations {
+		outcome, err := runPerturbation(checkpoint, shock, maxTimeSteps, ae.controllerOptions)
+		if err != nil {
+			return PerturbationResilience{}, err
+		}
+		if outcome.Recovered && outcome.RecoverySteps > 0 {
+			outcome.Score = float64(resilience.BaselineSteps) / float64(outcome.RecoverySteps)
+			if outcome.Score > 1 {
+				outcome.Score = 1
+			}
+		}
+		resilience.Perturbations[i] = outcome
+		resilience.Score += outcome.Score / float64(len(standardPerturbations))
+	}
+	return resilience, nil
+}
+
+// runPerturbation warm-starts a run from the checkpoint with a shock applied and steps it until
+// it is back at equilibrium, becomes insolvent or terminal, or reaches the step limit
+func runPerturbation(checkpoint controller.Checkpoint, shock perturbation, maxTimeSteps int, options []controller.Option) (PerturbationResult, error) {
+	config := checkpoint.Config
+	if shock.config != nil {
+		shock.config(&config)
+	}
+	sc, err := controller.WarmStartSimulationController(checkpoint, config, options...)
+	if err != nil {
+		return PerturbationResult{}, fmt.Errorf("perturbation %q failed to start: %w", shock.name, err)
+	}
+	if shock.apply != nil {
+		shock.apply(sc, config)
+	}
+
+	outcome := PerturbationResult{Perturbation: shock.name}
+	window := config.Equilibrium.StabilityWindow()
+	for outcome.RecoverySteps < maxTimeSteps && !sc.IsInsolvent() && !sc.IsTerminated() {
+		sc.Step()
+		outcome.RecoverySteps++
+		states := sc.GetTimeSeries()
+		if sc.IsEquilibriumReached() && len(states) >= window && config.Equilibrium.Settled(states[len(states)-window:]) {
+			outcome.Recovered = true
+			break
+		}
+	}
+
+	final := sc.GetTimeSeries()[len(sc.GetTimeSeries())-1]
+	outcome.FinalHumans = final.Workforce.Humans.Total
+	outcome.FinalAIAgents = final.Workforce.AIAgents.Total
+	return outcome, nil
+}
+
+// mostLikelyFailureClass returns the failure class with the largest weight, the first of any ties
+func mostLikelyFailureClass(config types.SimulationConfig) types.FailureClass {
+	classes := config.FailureClassTable()
+	likeliest := types.FailureClass(0)
+	for class := range classes {
+		if classes[class].Weight > classes[likeliest].Weight {
+			likeliest = types.FailureClass(class)
+		}
+	}
+	return likeliest
+}
+
+// GeneratePerturbationSummary describes how an equilibrium absorbed standardized shocks as a
+// Markdown section
+func (ae *AnalyticsEngine) GeneratePerturbationSummary(resilience PerturbationResilience) string {
+	var b strings.Builder
+
+	b.WriteString("## Perturbation Resilience\n\n")
+	fmt.Fprintf(&b, "Shocks were applied to the equilibrium in separate branched runs. The equilibrium scores **%.2f** out of 1,", resilience.Score)
+	fmt.Fprintf(&b, " where 1 means every shock was absorbed as quickly as an unshocked run settles again (%d time steps).\n\n", resilience.BaselineSteps)
+	b.WriteString("| Shock | Back to equilibrium | Score | Humans | AI agents |\n")
+	b.WriteString("|---|---:|---:|---:|---:|\n")
+	for _, outcome := range resilience.Perturbations {
+		recovery := fmt.Sprintf("%d steps", outcome.RecoverySteps)
+		if !outcome.Recovered {
+			recovery = fmt.Sprintf("never (stopped after %d steps)", outcome.RecoverySteps)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %.2f | %d | %d |\n", outcome.Perturbation, recovery, outcome.Score, outcome.FinalHumans, outcome.FinalAIAgents)
+	}
+
+	return b.String()
+}
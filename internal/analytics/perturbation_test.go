@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+)
+
+func TestPerturbationResilienceScoresEveryShock(t *testing.T) {
+	config := testSimulationConfig()
+	config.InitialHumans = 50
+	config.FixedBudget = 20000000
+	sc := controller.NewSimulationController(config, 7)
+	if _, err := sc.RunUntilEquilibrium(200); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	checkpoint, err := sc.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	engine := NewAnalyticsEngine()
+	resilience, err := engine.PerturbationResilience(checkpoint, 200)
+	if err != nil {
+		t.Fatalf("PerturbationResilience failed: %v", err)
+	}
+	if len(resilience.Perturbations) != len(standardPerturbations) || resilience.BaselineSteps < config.Equilibrium.StabilityWindow() {
+		t.Fatalf("Expected every shock scored against a baseline of at least a stability window, got %+v", resilience)
+	}
+	mean := 0.0
+	for _, outcome := range resilience.Perturbations {
+		if outcome.Score < 0 || outcome.Score > 1 || (outcome.Recovered != (outcome.Score > 0)) {
+			t.Errorf("Expected a score between 0 and 1, positive only when recovered, got %+v", outcome)
+		}
+		mean += outcome.Score / float64(len(resilience.Perturbations))
+	}
+	if math.Abs(resilience.Score-mean) > 1e-9 {
+		t.Errorf("Expected the overall score to be the mean %.4f, got %.4f", mean, resilience.Score)
+	}
+	if lost := resilience.Perturbations[0]; lost.FinalHumans >= checkpoint.TimeSeries[len(checkpoint.TimeSeries)-1].Workforce.Humans.Total {
+		t.Errorf("Expected the branch that lost humans to end with fewer, got %+v", lost)
+	}
+	if summary := engine.GeneratePerturbationSummary(resilience); !strings.Contains(summary, "## Perturbation Resilience") ||
+		!strings.Contains(summary, "| Budget cut by 10% |") {
+		t.Errorf("Expected the summary to list the shocks, got %q", summary)
+	}
+
+	checkpoint.EquilibriumReached = false
+	if _, err := engine.PerturbationResilience(checkpoint, 200); err == nil {
+		t.Error("Expected an error for a checkpoint that is not at equilibrium")
+	}
+}
@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// PhaseSpacePoint is one step of a run's trajectory through (human count, AI agent count) space
+type PhaseSpacePoint struct {
+	TimeStep      int
+	Humans        int
+	AIAgents      int
+	IsEquilibrium bool
+	Annotations   []string // what happened at this step: market shocks, catastrophic failures and equilibrium changes
+}
+
+// PhaseSpace returns the trajectory of a run through (human count, AI agent count) space, one
+// point per recorded step, annotated with the events that moved it
+func PhaseSpace(timeSeries []types.SimulationState) []PhaseSpacePoint {
+	points := make([]PhaseSpacePoint, len(timeSeries))
+	for i, state := range timeSeries {
+		point := PhaseSpacePoint{
+			TimeStep:      state.TimeStep,
+			Humans:        state.Workforce.Humans.Total,
+			AIAgents:      state.Workforce.AIAgents.Total,
+			IsEquilibrium: state.IsEquilibrium,
+			Annotations:   make([]string, 0),
+		}
+		for _, shock := range state.MarketShocks {
+			point.Annotations = append(point.Annotations, shock.String())
+		}
+		if i > 0 {
+			previous := timeSeries[i-1]
+			if failures := state.CatastrophicFailures - previous.CatastrophicFailures; failures > 0 {
+				point.Annotations = append(point.Annotations, fmt.Sprintf("%d catastrophic failure(s)", failures))
+			}
+			if state.IsEquilibrium && !previous.IsEquilibrium {
+				point.Annotations = append(point.Annotations, "equilibrium reached")
+			} else if !state.IsEquilibrium && previous.IsEquilibrium {
+				point.Annotations = append(point.Annotations, "equilibrium lost")
+			}
+		}
+		points[i] = point
+	}
+	return points
+}
+
+// PhaseSpaceCSV formats a phase space trajectory as CSV rows, one per step, with the step's
+// annotations joined by semicolons
+func PhaseSpaceCSV(points []PhaseSpacePoint) [][]string {
+	data := make([][]string, 0, len(points)+1)
+	data = append(data, []string{"TimeStep", "Humans", "AIAgents", "IsEquilibrium", "Annotations"})
+	for _, point := range points {
+		data = append(data, []string{
+			fmt.Sprintf("%d", point.TimeStep),
+			fmt.Sprintf("%d", point.Humans),
+			fmt.Sprintf("%d", point.AIAgents),
+			fmt.Sprintf("%t", point.IsEquilibrium),
+			strings.Join(point.Annotations, "; "),
+		})
+	}
+	return data
+}
+
+// WritePhaseSpaceCSV writes a phase space trajectory as CSV
+func WritePhaseSpaceCSV(points []PhaseSpacePoint, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(PhaseSpaceCSV(points)); err != nil {
+		return fmt.Errorf("failed to write phase space CSV: %w", err)
+	}
+	return nil
+}
+
+// WritePhaseSpaceJSON writes a phase space trajectory as JSON
+func WritePhaseSpaceJSON(points []PhaseSpacePoint, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(points); err != nil {
+		return fmt.Errorf("failed to encode phase space: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestPhaseSpaceAnnotatesTrajectory(t *testing.T) {
+	timeSeries := make([]types.SimulationState, 4)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].Workforce.Humans.Total = 10 - i
+		timeSeries[i].Workforce.AIAgents.Total = 5 * i
+	}
+	timeSeries[1].MarketShocks = []types.MarketShock{{TimeStep: 1, Type: types.AICostShock, Change: -50}}
+	timeSeries[2].CatastrophicFailures = 2
+	timeSeries[3].CatastrophicFailures = 2
+	timeSeries[3].IsEquilibrium = true
+
+	points := PhaseSpace(timeSeries)
+	if len(points) != 4 || points[3].Humans != 7 || points[3].AIAgents != 15 || !points[3].IsEquilibrium {
+		t.Fatalf("Expected one point per step ending at 7 humans and 15 AI agents in equilibrium, got %+v", points)
+	}
+	expected := [][]string{{}, {"AI_Cost_Shock of -50%"}, {"2 catastrophic failure(s)"}, {"equilibrium reached"}}
+	for i, annotations := range expected {
+		if strings.Join(points[i].Annotations, "; ") != strings.Join(annotations, "; ") {
+			t.Errorf("Expected step %d annotated with %v, got %v", i, annotations, points[i].Annotations)
+		}
+	}
+
+	rows := PhaseSpaceCSV(points)
+	if len(rows) != 5 || strings.Join(rows[4], ",") != "3,7,15,true,equilibrium reached" {
+		t.Errorf("Expected a header and one row per step, got %v", rows)
+	}
+	var buf bytes.Buffer
+	if err := WritePhaseSpaceJSON(points, &buf); err != nil {
+		t.Fatalf("WritePhaseSpaceJSON failed: %v", err)
+	}
+	var decoded []PhaseSpacePoint
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 4 || decoded[1].Annotations[0] != "AI_Cost_Shock of -50%" {
+		t.Errorf("Expected the JSON to round-trip, got %+v (%v)", decoded, err)
+	}
+}
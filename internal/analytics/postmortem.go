@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Incident is the postmortem of one catastrophic failure: what happened, who was available to
+// respond and what the failures up to it have cost
+type Incident struct {
+	TimeStep            int
+	Class               types.FailureClass
+	Severity            float64 // 0-1, where 1 is most severe
+	Handled             bool
+	SeniorHumans        int     // senior and executive humans available to respond
+	SeniorAgents        int     // senior and executive AI agents available to respond
+	HumanCapability     float64 // capability contributed by the senior humans
+	AgentCapability     float64 // capability contributed by the senior AI agents
+	RequiredCapability  float64 // capability needed at the failure's severity
+	HumanShare          float64 // fraction of the responding capability from humans (0-1); 0 with no capability
+	ProductivityPenalty float64 // productivity lost to the failure when not handled (0-1)
+	Penalty             float64 // spend lost to this failure
+	Remediation         float64 // one-off cost of remediating this failure
+	CumulativePenalty   float64 // spend lost to this and every earlier failure of the run
+}
+
+// Postmortem lists every catastrophic failure of a run in order, with the capability mix of the
+// response and the penalty accumulated up to and including each failure
+func Postmortem(result types.SimulationResult) []Incident {
+	incidents := make([]Incident, len(result.Failures))
+	cumulative := 0.0
+	for i, failure := range result.Failures {
+		cumulative += failure.Penalty
+		humanShare := 0.0
+		if total := failure.HumanCapability + failure.AgentCapability; total > 0 {
+			humanShare = failure.HumanCapability / total
+		}
+		incidents[i] = Incident{
+			TimeStep:            failure.TimeStep,
+			Class:               failure.Class,
+			Severity:            failure.Severity,
+			Handled:             failure.Handled,
+			SeniorHumans:        failure.SeniorHumans,
+			SeniorAgents:        failure.SeniorAgents,
+			HumanCapability:     failure.HumanCapability,
+			AgentCapability:     failure.AgentCapability,
+			RequiredCapability:  failure.RequiredCapability,
+			HumanShare:          humanShare,
+			ProductivityPenalty: failure.ProductivityPenalty,
+			Penalty:             failure.Penalty,
+			Remediation:         failure.Remediation,
+			CumulativePenalty:   cumulative,
+		}
+	}
+	return incidents
+}
@@ -0,0 +1,31 @@
+package analytics
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestPostmortemAccumulatesPenaltiesAndCapabilityMix(t *testing.T) {
+	result := types.SimulationResult{Failures: []types.FailureRecord{
+		{TimeStep: 3, Class: types.ModelOutage, Severity: 0.4, Handled: true, SeniorHumans: 2, SeniorAgents: 4, HumanCapability: 2, AgentCapability: 2},
+		{TimeStep: 7, Class: types.SecurityIncident, Severity: 0.9, SeniorHumans: 1, HumanCapability: 1, Penalty: 1500, Remediation: 800},
+		{TimeStep: 9, Class: types.ComplianceBreach, Severity: 0.5, Penalty: 500},
+	}}
+
+	incidents := Postmortem(result)
+	if len(incidents) != 3 {
+		t.Fatalf("Expected one incident per failure, got %d", len(incidents))
+	}
+	if incidents[0].HumanShare != 0.5 || incidents[1].HumanShare != 1 || incidents[2].HumanShare != 0 {
+		t.Errorf("Expected human capability shares 0.5, 1 and 0, got %.2f, %.2f and %.2f",
+			incidents[0].HumanShare, incidents[1].HumanShare, incidents[2].HumanShare)
+	}
+	for i, expected := range []float64{0, 1500, 2000} {
+		if incidents[i].CumulativePenalty != expected {
+			t.Errorf("Expected cumulative penalty %.2f at incident %d, got %.2f", expected, i, incidents[i].CumulativePenalty)
+		}
+	}
+	if incidents[1].TimeStep != 7 || incidents[1].Class != types.SecurityIncident || incidents[1].Remediation != 800 {
+		t.Errorf("Expected the failure's details to carry over, got %+v", incidents[1])
+	}
+}
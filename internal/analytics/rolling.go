@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"fmt"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// DefaultRollingWindow is the number of trailing time steps aggregated by rolling KPIs, one year
+const DefaultRollingWindow = types.TimeStepsPerYear
+
+// RollingKPI holds trailing-window aggregates of a time step, smoothing noisy step-level values
+// Early steps aggregate over the steps recorded so far when fewer than the window are available
+type RollingKPI struct {
+	TimeStep         int
+	TrailingRevenue  float64 // revenue earned over the window; each step earns a TimeStepsPerYear-th of its annual RevenueOutput
+	AverageHeadcount float64 // moving average of humans plus AI agents
+	CostRunRate      float64 // moving average of the annual TotalCost
+}
+
+// SetRollingWindow sets the number of trailing time steps aggregated by RollingKPIs
+func (ae *AnalyticsEngine) SetRollingWindow(window int) error {
+	if window < 1 {
+		return fmt.Errorf("rolling window must be at least 1 time step, got %d", window)
+	}
+
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	ae.rollingWindow = window
+	return nil
+}
+
+// RollingWindow returns the number of trailing time steps aggregated by RollingKPIs
+func (ae *AnalyticsEngine) RollingWindow() int {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	return ae.rollingWindow
+}
+
+// RollingKPIs computes the trailing-window aggregates of every state in a time series
+func (ae *AnalyticsEngine) RollingKPIs(timeSeries []types.SimulationState) []RollingKPI {
+	tracker := newRollingTracker(ae.RollingWindow())
+	kpis := make([]RollingKPI, len(timeSeries))
+	for i, state := range timeSeries {
+		kpis[i] = tracker.add(state)
+	}
+	return kpis
+}
+
+// rollingValues are the values of a state that rolling KPIs aggregate
+type rollingValues struct {
+	revenue   float64
+	headcount float64
+	cost      float64
+}
+
+// rollingTracker computes rolling KPIs one state at a time, keeping only the values of the
+// states in the window
+type rollingTracker struct {
+	window int
+	recent []rollingValues // ring of the window's values; next is the oldest once it is full
+	next   int
+
+	// Running sums over the window, updated as each state enters and the oldest one leaves
+	revenue, headcount, cost float64
+}
+
+// newRollingTracker returns a tracker of rolling KPIs over the given number of time steps
+func newRollingTracker(window int) *rollingTracker {
+	return &rollingTracker{window: window}
+}
+
+// add records the next state of a time series and returns its rolling KPIs
+func (t *rollingTracker) add(state types.SimulationState) RollingKPI {
+	values := rollingValues{
+		revenue:   state.RevenueOutput,
+		headcount: float64(state.Workforce.Humans.Total + state.Workforce.AIAgents.Total),
+		cost:      state.TotalCost,
+	}
+	t.revenue += values.revenue
+	t.headcount += values.headcount
+	t.cost += values.cost
+	if len(t.recent) < t.window {
+		t.recent = append(t.recent, values)
+	} else {
+		oldest := t.recent[t.next]
+		t.revenue -= oldest.revenue
+		t.headcount -= oldest.headcount
+		t.cost -= oldest.cost
+		t.recent[t.next] = values
+		t.next = (t.next + 1) % t.window
+	}
+
+	steps := float64(len(t.recent))
+	return RollingKPI{
+		TimeStep:         state.TimeStep,
+		TrailingRevenue:  t.revenue / types.TimeStepsPerYear,
+		AverageHeadcount: t.headcount / steps,
+		CostRunRate:      t.cost / steps,
+	}
+}
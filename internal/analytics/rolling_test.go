@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRollingKPIs(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if engine.RollingWindow() != DefaultRollingWindow {
+		t.Errorf("Expected default window %d, got %d", DefaultRollingWindow, engine.RollingWindow())
+	}
+	if err := engine.SetRollingWindow(3); err != nil {
+		t.Fatalf("SetRollingWindow failed: %v", err)
+	}
+
+	timeSeries := make([]types.SimulationState, 5)
+	for i := range timeSeries {
+		timeSeries[i].TimeStep = i
+		timeSeries[i].RevenueOutput = 1200 * float64(i+1)
+		timeSeries[i].TotalCost = 100 * float64(i+1)
+		timeSeries[i].Workforce.Humans.Total = 10
+		timeSeries[i].Workforce.AIAgents.Total = i
+	}
+
+	kpis := engine.RollingKPIs(timeSeries)
+	if len(kpis) != len(timeSeries) {
+		t.Fatalf("Expected a KPI per time step, got %d", len(kpis))
+	}
+	want := []RollingKPI{
+		{TimeStep: 0, TrailingRevenue: 100, AverageHeadcount: 10, CostRunRate: 100},
+		{TimeStep: 1, TrailingRevenue: 300, AverageHeadcount: 10.5, CostRunRate: 150},
+		{TimeStep: 2, TrailingRevenue: 600, AverageHeadcount: 11, CostRunRate: 200},
+		{TimeStep: 3, TrailingRevenue: 900, AverageHeadcount: 12, CostRunRate: 300},
+		{TimeStep: 4, TrailingRevenue: 1200, AverageHeadcount: 13, CostRunRate: 400},
+	}
+	for i, kpi := range kpis {
+		if kpi.TimeStep != want[i].TimeStep ||
+			math.Abs(kpi.TrailingRevenue-want[i].TrailingRevenue) > 1e-9 ||
+			math.Abs(kpi.AverageHeadcount-want[i].AverageHeadcount) > 1e-9 ||
+			math.Abs(kpi.CostRunRate-want[i].CostRunRate) > 1e-9 {
+			t.Errorf("RollingKPIs()[%d] = %+v, want %+v", i, kpi, want[i])
+		}
+	}
+}
+
+func TestSetRollingWindowRejectsEmptyWindow(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetRollingWindow(0); err == nil {
+		t.Error("Expected an error for a zero rolling window")
+	}
+	if engine.RollingWindow() != DefaultRollingWindow {
+		t.Errorf("Expected the window to be unchanged, got %d", engine.RollingWindow())
+	}
+}
@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Batches a RunSummary can belong to
+const (
+	BatchMonteCarlo  = "monte_carlo"
+	BatchSensitivity = "sensitivity"
+	BatchPairwise    = "pairwise"
+)
+
+// RunSummary is one run of a Monte Carlo or sensitivity batch reduced to its key parameters and
+// outcomes, so batches can be analyzed with one row per run
+type RunSummary struct {
+	Batch                string // BatchMonteCarlo, BatchSensitivity or BatchPairwise
+	Varied               string // parameters the batch varied for the run, joined by "+"; empty for Monte Carlo runs
+	Seed                 int64
+	ConfigHash           string
+	Parameters           map[string]float64 // value of every sensitivity parameter in the run's configuration
+	RevenueScenario      types.RevenueScenario
+	AttritionType        types.AttritionType
+	TimeToEquilibrium    int
+	ReachedEquilibrium   bool
+	Status               types.RunStatus
+	FinalHumans          int
+	FinalAIAgents        int
+	FinalAIShare         float64 // AI agents as a percentage of the final workforce
+	TotalRevenue         float64 // revenue summed over the time series
+	CatastrophicFailures int
+}
+
+// parameterGetters read a sensitivity parameter from a configuration, keyed like parameterSetters
+var parameterGetters = map[string]func(config types.SimulationConfig) float64{
+	"FixedBudget":             func(config types.SimulationConfig) float64 { return config.FixedBudget },
+	"InitialHumans":           func(config types.SimulationConfig) float64 { return float64(config.InitialHumans) },
+	"CatastrophicFailureRate": func(config types.SimulationConfig) float64 { return config.CatastrophicFailureRate },
+	"TimeZoneInefficiency":    func(config types.SimulationConfig) float64 { return config.TimeZoneInefficiency },
+	"NaturalAttritionRate":    func(config types.SimulationConfig) float64 { return config.AttritionConfig.NaturalRate },
+	"ForcedAcceleration":      func(config types.SimulationConfig) float64 { return config.AttritionConfig.ForcedAcceleration },
+	"UniversityToMid":         func(config types.SimulationConfig) float64 { return float64(config.AILearningSpeeds.UniversityToMid) },
+	"MidToSenior":             func(config types.SimulationConfig) float64 { return float64(config.AILearningSpeeds.MidToSenior) },
+	"SeniorToExecutive":       func(config types.SimulationConfig) float64 { return float64(config.AILearningSpeeds.SeniorToExecutive) },
+}
+
+// SummarizeRun reduces a run of a batch to its RunSummary
+func SummarizeRun(batch string, varied string, result types.SimulationResult) RunSummary {
+	parameters := make(map[string]float64, len(parameterGetters))
+	for name, get := range parameterGetters {
+		parameters[name] = get(result.Config)
+	}
+	final := result.EquilibriumState
+	return RunSummary{
+		Batch:                batch,
+		Varied:               varied,
+		Seed:                 result.Metadata.Seed,
+		ConfigHash:           result.Metadata.ConfigHash,
+		Parameters:           parameters,
+		RevenueScenario:      result.Config.RevenueScenario,
+		AttritionType:        result.Config.AttritionConfig.Type,
+		TimeToEquilibrium:    result.TimeToEquilibrium,
+		ReachedEquilibrium:   result.ReachedEquilibrium,
+		Status:               result.Status,
+		FinalHumans:          final.Workforce.Humans.Total,
+		FinalAIAgents:        final.Workforce.AIAgents.Total,
+		FinalAIShare:         aiWorkforceShare(final) * 100.0,
+		TotalRevenue:         sumRevenue(result.TimeSeries),
+		CatastrophicFailures: result.TotalCatastrophicFailures,
+	}
+}
+
+// SensitivityRunSummaries returns a summary of every run of a one-at-a-time sensitivity
+// analysis, grouped by parameter in name order
+func SensitivityRunSummaries(results map[string]SensitivityResults) []RunSummary {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	summaries := make([]RunSummary, 0)
+	for _, name := range names {
+		for _, result := range results[name].Results {
+			summaries = append(summaries, SummarizeRun(BatchSensitivity, name, result))
+		}
+	}
+	return summaries
+}
+
+// PairwiseRunSummaries returns a summary of every run of a pairwise sensitivity grid, row by row
+func PairwiseRunSummaries(results PairwiseResults) []RunSummary {
+	varied := results.ParameterA + "+" + results.ParameterB
+	summaries := make([]RunSummary, 0, len(results.ValuesA)*len(results.ValuesB))
+	for _, row := range results.Results {
+		for _, result := range row {
+			summaries = append(summaries, SummarizeRun(BatchPairwise, varied, result))
+		}
+	}
+	return summaries
+}
+
+// RunSummariesCSV formats run summaries as a flat table with one row per run and one column per
+// sensitivity parameter, ready for regression analysis
+func RunSummariesCSV(summaries []RunSummary) [][]string {
+	parameters := SensitivityParameterNames()
+	header := []string{"Batch", "Varied", "Seed", "ConfigHash"}
+	header = append(header, parameters...)
+	header = append(header, "RevenueScenario", "AttritionType", "TimeToEquilibrium", "ReachedEquilibrium", "Status",
+		"FinalHumans", "FinalAIAgents", "FinalAIShare", "TotalRevenue", "CatastrophicFailures")
+
+	data := make([][]string, 0, len(summaries)+1)
+	data = append(data, header)
+	for _, summary := range summaries {
+		row := []string{summary.Batch, summary.Varied, fmt.Sprintf("%d", summary.Seed), summary.ConfigHash}
+		for _, name := range parameters {
+			row = append(row, fmt.Sprintf("%g", summary.Parameters[name]))
+		}
+		row = append(row,
+			summary.RevenueScenario.String(),
+			summary.AttritionType.String(),
+			fmt.Sprintf("%d", summary.TimeToEquilibrium),
+			fmt.Sprintf("%t", summary.ReachedEquilibrium),
+			summary.Status.String(),
+			fmt.Sprintf("%d", summary.FinalHumans),
+			fmt.Sprintf("%d", summary.FinalAIAgents),
+			fmt.Sprintf("%.2f", summary.FinalAIShare),
+			fmt.Sprintf("%.2f", summary.TotalRevenue),
+			fmt.Sprintf("%d", summary.CatastrophicFailures),
+		)
+		data = append(data, row)
+	}
+	return data
+}
+
+// WriteRunSummariesCSV writes run summaries as CSV with one row per run
+func WriteRunSummariesCSV(summaries []RunSummary, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(RunSummariesCSV(summaries)); err != nil {
+		return fmt.Errorf("failed to write runs CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestRunSummariesCSV(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	config := testSimulationConfig()
+
+	monteCarlo, err := engine.RunMonteCarlo(config, 3, 100, 50)
+	if err != nil {
+		t.Fatalf("RunMonteCarlo failed: %v", err)
+	}
+	if len(monteCarlo.RunSummaries) != 3 || monteCarlo.RunSummaries[2].Seed != 102 || monteCarlo.RunSummaries[0].Batch != BatchMonteCarlo {
+		t.Fatalf("Expected a summary per Monte Carlo run in seed order, got %+v", monteCarlo.RunSummaries)
+	}
+
+	pairwise, err := engine.RunPairwiseSensitivity(config, "CatastrophicFailureRate", []float64{0.01, 0.1}, "FixedBudget", []float64{config.FixedBudget, 2 * config.FixedBudget}, 50, 7, SensitivityOptions{})
+	if err != nil {
+		t.Fatalf("RunPairwiseSensitivity failed: %v", err)
+	}
+	summaries := append(monteCarlo.RunSummaries, PairwiseRunSummaries(pairwise)...)
+	last := summaries[len(summaries)-1]
+	if len(summaries) != 7 || last.Varied != "CatastrophicFailureRate+FixedBudget" ||
+		last.Parameters["CatastrophicFailureRate"] != 0.1 || last.Parameters["FixedBudget"] != 2*config.FixedBudget {
+		t.Fatalf("Expected the pairwise runs to record the varied parameters, got %+v", last)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummariesCSV(summaries, &buf); err != nil {
+		t.Fatalf("WriteRunSummariesCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Runs CSV does not parse: %v", err)
+	}
+	if len(rows) != 8 {
+		t.Fatalf("Expected a header and 7 rows, got %d rows", len(rows))
+	}
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[name] = i
+	}
+	for _, name := range append(SensitivityParameterNames(), "Batch", "Seed", "ConfigHash", "TimeToEquilibrium", "FinalHumans", "FinalAIAgents", "TotalRevenue", "CatastrophicFailures") {
+		if _, exists := columns[name]; !exists {
+			t.Errorf("Expected a %s column, got %v", name, rows[0])
+		}
+	}
+	if rows[1][columns["Batch"]] != "monte_carlo" || rows[1][columns["Seed"]] != "100" || rows[1][columns["ConfigHash"]] != config.Hash() {
+		t.Errorf("Unexpected first run %v", rows[1])
+	}
+	if rows[7][columns["Batch"]] != "pairwise" || rows[7][columns["CatastrophicFailureRate"]] != "0.1" {
+		t.Errorf("Unexpected last run %v", rows[7])
+	}
+}
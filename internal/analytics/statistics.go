@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// MetricSummary holds descriptive statistics of a recorded metric over the recorded time steps
+type MetricSummary struct {
+	Name       string
+	Count      int
+	Min        float64
+	Max        float64
+	Mean       float64
+	Median     float64
+	StdDev     float64 // population standard deviation
+	P10        float64
+	P90        float64
+	TrendSlope float64 // least-squares slope of the values per recorded step
+}
+
+// SummarizeMetric computes descriptive statistics and the linear trend of a recorded metric
+// Returns an error wrapping types.ErrNotFound if no metric with the given name has been recorded
+func (ae *AnalyticsEngine) SummarizeMetric(name string) (MetricSummary, error) {
+	var summary MetricSummary
+	if !ae.MetricSeries(name, func(values []float64) { summary = summarizeValues(name, values) }) {
+		return MetricSummary{}, fmt.Errorf("metric %q: %w", name, types.ErrNotFound)
+	}
+	return summary, nil
+}
+
+// SummarizeAll computes the statistics of every recorded metric, in name order
+func (ae *AnalyticsEngine) SummarizeAll() []MetricSummary {
+	summaries := make([]MetricSummary, 0)
+	ae.ForEachMetric(func(name string, values []float64) bool {
+		summaries = append(summaries, summarizeValues(name, values))
+		return true
+	})
+	return summaries
+}
+
+// summarizeValues computes the statistics of a metric's values in recording order
+func summarizeValues(name string, values []float64) MetricSummary {
+	summary := MetricSummary{Name: name, Count: len(values)}
+	if len(values) == 0 {
+		return summary
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	summary.Min = sorted[0]
+	summary.Max = sorted[len(sorted)-1]
+	summary.Median = percentile(sorted, 0.5)
+	summary.P10 = percentile(sorted, 0.1)
+	summary.P90 = percentile(sorted, 0.9)
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	summary.Mean = sum / float64(len(values))
+
+	// Population variance, and the least-squares slope against the step index, whose mean is (n-1)/2
+	meanIndex := float64(len(values)-1) / 2.0
+	variance, covariance, indexVariance := 0.0, 0.0, 0.0
+	for i, value := range values {
+		deviation := value - summary.Mean
+		variance += deviation * deviation
+		covariance += (float64(i) - meanIndex) * deviation
+		indexVariance += (float64(i) - meanIndex) * (float64(i) - meanIndex)
+	}
+	summary.StdDev = math.Sqrt(variance / float64(len(values)))
+	if indexVariance > 0 {
+		summary.TrendSlope = covariance / indexVariance
+	}
+
+	return summary
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestSummarizeMetric(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	for i, cost := range []float64{100, 200, 300, 400, 500} {
+		engine.RecordTimeStep(types.SimulationState{TimeStep: i, TotalCost: cost})
+	}
+
+	summary, err := engine.SummarizeMetric("total_cost")
+	if err != nil {
+		t.Fatalf("SummarizeMetric failed: %v", err)
+	}
+	want := MetricSummary{
+		Name: "total_cost", Count: 5, Min: 100, Max: 500, Mean: 300, Median: 300,
+		StdDev: math.Sqrt(20000), P10: 140, P90: 460, TrendSlope: 100,
+	}
+	if math.Abs(summary.StdDev-want.StdDev) > 1e-9 || math.Abs(summary.P10-want.P10) > 1e-9 || math.Abs(summary.P90-want.P90) > 1e-9 {
+		t.Errorf("SummarizeMetric() = %+v, want %+v", summary, want)
+	}
+	summary.StdDev, summary.P10, summary.P90 = want.StdDev, want.P10, want.P90
+	if summary != want {
+		t.Errorf("SummarizeMetric() = %+v, want %+v", summary, want)
+	}
+
+	if _, err := engine.SummarizeMetric("no_such_metric"); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown metric, got %v", err)
+	}
+}
+
+func TestSummarizeAllCoversEveryMetric(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	engine.RecordTimeStep(types.SimulationState{TotalCost: 100, TotalProductivity: 5})
+
+	summaries := engine.SummarizeAll()
+	if len(summaries) != len(engine.GetMetrics()) {
+		t.Fatalf("Expected a summary per metric, got %d for %d metrics", len(summaries), len(engine.GetMetrics()))
+	}
+	for i, summary := range summaries {
+		if i > 0 && summaries[i-1].Name > summary.Name {
+			t.Errorf("Expected summaries in name order, got %s before %s", summaries[i-1].Name, summary.Name)
+		}
+		if summary.Count != 1 || summary.TrendSlope != 0 || summary.StdDev != 0 {
+			t.Errorf("Expected a single value without spread or trend, got %+v", summary)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ReportCSVStream writes the CSV report of a run one state at a time, so exports of long runs
+// never hold the whole report in memory
+// States are written with Write, or as the run progresses by adding the stream as an observer
+// of its controller (see controller.SimulationController.Observe); Flush must be called at the
+// end and returns the first error met
+type ReportCSVStream struct {
+	writer   *csv.Writer
+	rolling  *rollingTracker
+	header   []string
+	metadata []string
+	runID    string
+	long     bool
+	columns  []int // columns of the wide report written as metrics in long format
+	started  bool
+	err      error
+}
+
+// NewReportCSVStream returns a stream writing the wide CSV report of WriteReportCSV for a run
+// with the given metadata
+func (ae *AnalyticsEngine) NewReportCSVStream(writer io.Writer, metadata types.RunMetadata) *ReportCSVStream {
+	return &ReportCSVStream{
+		writer:   csv.NewWriter(writer),
+		rolling:  newRollingTracker(ae.RollingWindow()),
+		header:   reportCSVHeader(),
+		metadata: reportCSVMetadata(metadata),
+		runID:    metadata.RunID,
+	}
+}
+
+// NewLongCSVStream returns a stream writing the long format report of WriteLongCSV for a run
+// with the given metadata
+func (ae *AnalyticsEngine) NewLongCSVStream(writer io.Writer, metadata types.RunMetadata) *ReportCSVStream {
+	stream := ae.NewReportCSVStream(writer, metadata)
+	stream.long = true
+	stream.columns = longColumns(stream.header)
+	return stream
+}
+
+// Write writes the rows of the next state of the run, after the header for the first one
+func (s *ReportCSVStream) Write(state types.SimulationState) error {
+	if s.err != nil {
+		return s.err
+	}
+	if !s.started {
+		s.started = true
+		header := s.header
+		if s.long {
+			header = longHeader
+		}
+		if err := s.writer.Write(header); err != nil {
+			s.err = fmt.Errorf("failed to write CSV header: %w", err)
+			return s.err
+		}
+	}
+
+	row := append(reportCSVRow(state, s.rolling.add(state)), s.metadata...)
+	rows := [][]string{row}
+	if s.long {
+		rows = longRows(s.runID, s.header, s.columns, row)
+	}
+	for _, row := range rows {
+		if err := s.writer.Write(row); err != nil {
+			s.err = fmt.Errorf("failed to write CSV row: %w", err)
+			return s.err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows and returns the first error met by the stream
+func (s *ReportCSVStream) Flush() error {
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil && s.err == nil {
+		s.err = fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return s.err
+}
+
+// OnStep writes the state of a time step as the run progresses; errors are returned by Flush
+func (s *ReportCSVStream) OnStep(state types.SimulationState, events []types.SimulationEvent) {
+	s.Write(state)
+}
+
+// OnAlert does nothing; alerts are not part of the CSV report
+func (s *ReportCSVStream) OnAlert(alert types.Alert) {}
@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+)
+
+func TestReportCSVStreamObservesRun(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	if err := engine.SetRollingWindow(3); err != nil {
+		t.Fatalf("SetRollingWindow failed: %v", err)
+	}
+
+	sc := controller.NewSimulationController(testSimulationConfig(), 11)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	var wide, long bytes.Buffer
+	streams := []*ReportCSVStream{
+		engine.NewReportCSVStream(&wide, sc.RunMetadata()),
+		engine.NewLongCSVStream(&long, sc.RunMetadata()),
+	}
+	for _, stream := range streams {
+		for _, state := range sc.GetTimeSeries() {
+			if err := stream.Write(state); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+		}
+		sc.Observe(stream)
+	}
+	result, err := sc.RunUntilEquilibrium(40)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	for _, stream := range streams {
+		if err := stream.Flush(); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+	}
+
+	var wantWide, wantLong bytes.Buffer
+	if err := engine.WriteReportCSV(result, &wantWide); err != nil {
+		t.Fatalf("WriteReportCSV failed: %v", err)
+	}
+	if err := engine.WriteLongCSV(result, &wantLong); err != nil {
+		t.Fatalf("WriteLongCSV failed: %v", err)
+	}
+	if wide.String() != wantWide.String() {
+		t.Errorf("Expected the streamed CSV report to match the report of the result")
+	}
+	if long.String() != wantLong.String() {
+		t.Errorf("Expected the streamed long CSV report to match the report of the result")
+	}
+
+	// The streaming writers produce the same rows as the in-memory reports
+	generated, err := engine.GenerateReportCSV(result)
+	if err != nil {
+		t.Fatalf("GenerateReportCSV failed: %v", err)
+	}
+	var fromRows bytes.Buffer
+	if err := csv.NewWriter(&fromRows).WriteAll(generated); err != nil {
+		t.Fatalf("Writing the generated rows failed: %v", err)
+	}
+	if fromRows.String() != wantWide.String() {
+		t.Errorf("Expected WriteReportCSV to write the rows of GenerateReportCSV")
+	}
+}
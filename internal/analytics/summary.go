@@ -0,0 +1,316 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// maxSummaryCostDrivers is the number of cost drivers listed in the Markdown summary
+const maxSummaryCostDrivers = 3
+
+// maxSummaryEvents is the number of individual notable events listed in the Markdown summary
+const maxSummaryEvents = 5
+
+// costDriver is a workforce group and its share of the total annual cost
+type costDriver struct {
+	label string
+	cost  float64
+}
+
+// GenerateMarkdownSummary produces a short narrative summary of a simulation run in Markdown
+// The summary covers initial vs final composition, what triggered equilibrium, the largest
+// cost drivers in the final workforce, and notable events, and is meant for pasting into
+// documents or chat
+func (ae *AnalyticsEngine) GenerateMarkdownSummary(result types.SimulationResult) string {
+	var b strings.Builder
+
+	b.WriteString("# Simulation Summary\n\n")
+
+	if len(result.TimeSeries) == 0 {
+		b.WriteString("No simulation data was recorded.\n")
+		return b.String()
+	}
+
+	initial := result.TimeSeries[0]
+	final := result.EquilibriumState
+	summary := ae.calculateReportSummary(result)
+
+	// Outcome
+	if result.Insolvent {
+		fmt.Fprintf(&b, "The organization **became insolvent after %d time steps** (%s).", result.TimeToEquilibrium, result.EquilibriumReason.Message)
+	} else if result.Status.Terminal() {
+		fmt.Fprintf(&b, "The simulation **ended in a terminal state after %d time steps** (%s).", result.TimeToEquilibrium, result.EquilibriumReason.Message)
+	} else if result.ReachedEquilibrium {
+		fmt.Fprintf(&b, "Equilibrium was reached after **%d time steps**", result.TimeToEquilibrium)
+		if result.EquilibriumReason.Message != "" {
+			fmt.Fprintf(&b, " (%s)", result.EquilibriumReason.Message)
+		}
+		b.WriteString(".")
+		if confirmation := result.Confirmation; confirmation != nil {
+			switch {
+			case !confirmation.Persisted:
+				fmt.Fprintf(&b, " It **did not hold**: equilibrium was lost again at time step %d.", confirmation.LostAt)
+			case confirmation.Disrupted:
+				fmt.Fprintf(&b, " It held for %d further time steps, until a disruption ended the check.", confirmation.Steps)
+			default:
+				fmt.Fprintf(&b, " It held for all %d further time steps simulated to confirm it.", confirmation.Steps)
+			}
+		}
+	} else {
+		fmt.Fprintf(&b, "The simulation stopped after **%d time steps** without reaching equilibrium.", result.TimeToEquilibrium)
+	}
+	fmt.Fprintf(&b, " The workforce moved from %d humans and %d AI agents to %d humans and %d AI agents,",
+		summary.InitialHumanCount, summary.InitialAIAgentCount, summary.FinalHumanCount, summary.FinalAIAgentCount)
+	fmt.Fprintf(&b, " generating %s of revenue in total.", formatCurrency(summary.TotalRevenueGenerated))
+	fmt.Fprintf(&b, " AI agents generated %.0f%% of revenue in the final step.\n\n", summary.FinalAIRevenueShare*100.0)
+
+	// Discounted cash flow
+	fmt.Fprintf(&b, "Relative to keeping the initial workforce, the transition has an NPV of %s at a %.1f%% discount rate",
+		formatCurrency(summary.TransitionNPV), result.Config.DiscountRate*100.0)
+	if summary.BreakEvenStep >= 0 {
+		fmt.Fprintf(&b, " and breaks even at step %d", summary.BreakEvenStep)
+	} else {
+		b.WriteString(" and does not break even within the run")
+	}
+	if summary.TransitionIRRDefined {
+		fmt.Fprintf(&b, " (IRR %.1f%% per year)", summary.TransitionIRR*100.0)
+	}
+	b.WriteString(".\n\n")
+	writeFinancing(&b, result)
+
+	// Composition
+	b.WriteString("## Workforce Composition\n\n")
+	b.WriteString("| | Initial | Final |\n")
+	b.WriteString("|---|---:|---:|\n")
+	fmt.Fprintf(&b, "| Humans | %d | %d |\n", initial.Workforce.Humans.Total, final.Workforce.Humans.Total)
+	fmt.Fprintf(&b, "| AI agents | %d | %d |\n", initial.Workforce.AIAgents.Total, final.Workforce.AIAgents.Total)
+	for _, level := range experienceLevels {
+		fmt.Fprintf(&b, "| AI agents (%s) | %d | %d |\n", level,
+			initial.Workforce.AIAgents.ByExperience[level], final.Workforce.AIAgents.ByExperience[level])
+	}
+	fmt.Fprintf(&b, "| Annual cost | %s | %s |\n", formatCurrency(initial.TotalCost), formatCurrency(final.TotalCost))
+	fmt.Fprintf(&b, "| Productivity | %.1f | %.1f |\n\n", initial.TotalProductivity, final.TotalProductivity)
+	writeValueContribution(&b, final)
+
+	// Cost drivers
+	drivers := finalCostDrivers(final)
+	if len(drivers) > 0 && final.TotalCost > 0 {
+		b.WriteString("## Top Cost Drivers\n\n")
+		for i, driver := range drivers {
+			if i >= maxSummaryCostDrivers {
+				break
+			}
+			fmt.Fprintf(&b, "%d. %s: %s (%.0f%% of annual cost)\n", i+1, driver.label,
+				formatCurrency(driver.cost), driver.cost/final.TotalCost*100.0)
+		}
+		b.WriteString("\n")
+	}
+
+	writeDecisions(&b, result.Decisions)
+	writeAlerts(&b, result.Alerts)
+
+	// Notable events
+	b.WriteString("## Notable Events\n\n")
+	if len(result.Events) == 0 {
+		b.WriteString("No events were recorded.\n")
+		writeRunMetadata(&b, result.Metadata)
+		return b.String()
+	}
+
+	counts := make(map[types.EventType]int)
+	for _, event := range result.Events {
+		counts[event.Type]++
+	}
+	countParts := make([]string, 0, len(counts))
+	for _, eventType := range []types.EventType{
+		types.HumanHiredEvent, types.AgentHiredEvent, types.AgentReleasedEvent, types.AgentLevelUpEvent,
+		types.AttritionEvent, types.OwnerSuccessionEvent, types.OversightEnforcedEvent, types.CatastrophicFailureEvent,
+		types.MarketShockEvent, types.InsolvencyEvent,
+	} {
+		if counts[eventType] > 0 {
+			countParts = append(countParts, fmt.Sprintf("%d %s", counts[eventType], eventType))
+		}
+	}
+	if len(countParts) > 0 {
+		fmt.Fprintf(&b, "Event counts: %s.\n\n", strings.Join(countParts, ", "))
+	}
+
+	listed := 0
+	for _, event := range result.Events {
+		if !isNotableEvent(event.Type) {
+			continue
+		}
+		if listed == maxSummaryEvents {
+			fmt.Fprintf(&b, "- ...and %d more\n", countNotableEvents(result.Events)-listed)
+			break
+		}
+		fmt.Fprintf(&b, "- Step %d: %s\n", event.TimeStep, event.Description)
+		listed++
+	}
+	if listed == 0 {
+		b.WriteString("No catastrophic failures, market shocks or budget-driven releases occurred.\n")
+	}
+
+	writeRunMetadata(&b, result.Metadata)
+	return b.String()
+}
+
+// writeFinancing appends a paragraph on the organization's borrowing, if it borrowed at all
+func writeFinancing(b *strings.Builder, result types.SimulationResult) {
+	borrowed, interest, peakDebt, peakLeverage := 0.0, 0.0, 0.0, 0.0
+	for _, state := range result.TimeSeries {
+		borrowed += state.Financing.Borrowed
+		interest += state.CostBreakdown.Interest
+		peakDebt = math.Max(peakDebt, state.Financing.Debt)
+		peakLeverage = math.Max(peakLeverage, state.Financing.Leverage)
+	}
+	if borrowed == 0 {
+		return
+	}
+	fmt.Fprintf(b, "The organization borrowed %s to fund its workforce and paid %s of interest. Debt peaked at %s (%.2fx annual revenue); %s was outstanding at the end.\n\n",
+		formatCurrency(borrowed), formatCurrency(interest), formatCurrency(peakDebt), peakLeverage,
+		formatCurrency(result.EquilibriumState.Financing.Debt))
+}
+
+// writeValueContribution appends the productivity and cost of each workforce group in the final
+// state, showing the value each group contributes alongside its headcount
+func writeValueContribution(b *strings.Builder, final types.SimulationState) {
+	type group struct {
+		label        string
+		count        int
+		productivity float64
+		cost         float64
+	}
+	groups := make([]group, 0, 2*len(experienceLevels))
+	for _, level := range experienceLevels {
+		if count := final.Workforce.Humans.ByExperience[level]; count > 0 {
+			groups = append(groups, group{fmt.Sprintf("%s humans", level), count,
+				final.Workforce.HumanSubtotals.ProductivityByExperience[level], final.Workforce.HumanSubtotals.CostByExperience[level]})
+		}
+		if count := final.Workforce.AIAgents.ByExperience[level]; count > 0 {
+			groups = append(groups, group{fmt.Sprintf("%s AI agents", level), count,
+				final.Workforce.AISubtotals.ProductivityByExperience[level], final.Workforce.AISubtotals.CostByExperience[level]})
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+
+	b.WriteString("## Value Contribution\n\n")
+	b.WriteString("| Group | Count | Productivity | Annual cost | Cost per unit of productivity |\n")
+	b.WriteString("|---|---:|---:|---:|---:|\n")
+	for _, g := range groups {
+		perUnit := "-"
+		if g.productivity > 0 {
+			perUnit = formatCurrency(g.cost / g.productivity)
+		}
+		fmt.Fprintf(b, "| %s | %d | %.1f | %s | %s |\n", g.label, g.count, g.productivity, formatCurrency(g.cost), perUnit)
+	}
+	b.WriteString("\n")
+}
+
+// writeRunMetadata appends a provenance line identifying the run, if the result records one
+func writeRunMetadata(b *strings.Builder, metadata types.RunMetadata) {
+	if metadata.RunID == "" {
+		return
+	}
+	fmt.Fprintf(b, "\n---\n\nRun `%s` started %s with seed %d, simulator version %s, configuration hash `%s`.\n",
+		metadata.RunID, metadata.Timestamp.Format(time.RFC3339), metadata.Seed, metadata.Version, metadata.ConfigHash)
+	if metadata.Lineage != nil {
+		fmt.Fprintf(b, "It is a what-if branch of run `%s` from time step %d.\n", metadata.Lineage.ParentRunID, metadata.Lineage.BranchStep)
+	}
+}
+
+// experienceLevels lists all experience levels in ascending order
+var experienceLevels = []types.ExperienceLevel{types.UniversityHire, types.MidLevel, types.Senior, types.Executive}
+
+// contractTypes lists the contract types in report order
+var contractTypes = []types.ContractType{types.FullTime, types.Contractor, types.PartTime}
+
+// GenerateSharedLearningSummary describes the contribution of shared learning measured by
+// CompareSharedLearning as a Markdown section
+// When a run stopped without reaching equilibrium its time is only a lower bound, so the
+// difference is flagged as approximate
+func (ae *AnalyticsEngine) GenerateSharedLearningSummary(comparison CounterfactualComparison) string {
+	var b strings.Builder
+
+	b.WriteString("## Shared Learning\n\n")
+	delta := comparison.TimeToEquilibriumDelta
+	switch {
+	case delta < 0:
+		fmt.Fprintf(&b, "Shared learning between AI agents brought equilibrium forward by **%d time steps**", -delta)
+	case delta > 0:
+		fmt.Fprintf(&b, "Shared learning between AI agents delayed equilibrium by **%d time steps**", delta)
+	default:
+		b.WriteString("Shared learning between AI agents did not change the time to equilibrium")
+	}
+	fmt.Fprintf(&b, " (%d with shared learning, %d without)", comparison.Modified.TimeToEquilibrium, comparison.Baseline.TimeToEquilibrium)
+	if !comparison.Baseline.ReachedEquilibrium || !comparison.Modified.ReachedEquilibrium {
+		b.WriteString("; at least one run stopped without reaching equilibrium, so the difference is approximate")
+	}
+	fmt.Fprintf(&b, ". It changed total revenue by %s.\n", formatCurrency(comparison.TotalRevenueDelta))
+
+	return b.String()
+}
+
+// finalCostDrivers lists the annual cost of each workforce group in a state, largest first
+func finalCostDrivers(state types.SimulationState) []costDriver {
+	drivers := make([]costDriver, 0, 2*len(experienceLevels))
+
+	for _, level := range experienceLevels {
+		if count := state.Workforce.Humans.ByExperience[level]; count > 0 {
+			drivers = append(drivers, costDriver{
+				label: fmt.Sprintf("%d %s humans", count, level),
+				cost:  state.CostBreakdown.HumanPayroll[level],
+			})
+		}
+		if count := state.Workforce.AIAgents.ByExperience[level]; count > 0 {
+			drivers = append(drivers, costDriver{
+				label: fmt.Sprintf("%d %s AI agents", count, level),
+				cost:  state.CostBreakdown.AICost[level],
+			})
+		}
+	}
+
+	sort.SliceStable(drivers, func(i, j int) bool {
+		return drivers[i].cost > drivers[j].cost
+	})
+	return drivers
+}
+
+// isNotableEvent reports whether an event type is listed individually in the summary
+// Routine hires, level-ups and attrition only appear in the event counts
+func isNotableEvent(eventType types.EventType) bool {
+	return eventType == types.CatastrophicFailureEvent || eventType == types.AgentReleasedEvent ||
+		eventType == types.OwnerSuccessionEvent || eventType == types.OversightEnforcedEvent ||
+		eventType == types.MarketShockEvent || eventType == types.InsolvencyEvent
+}
+
+// countNotableEvents counts the events that are listed individually in the summary
+func countNotableEvents(events []types.SimulationEvent) int {
+	count := 0
+	for _, event := range events {
+		if isNotableEvent(event.Type) {
+			count++
+		}
+	}
+	return count
+}
+
+// formatCurrency formats an amount in dollars with a k/M suffix
+func formatCurrency(amount float64) string {
+	switch {
+	case amount >= 1e9 || amount <= -1e9:
+		return fmt.Sprintf("$%.2fB", amount/1e9)
+	case amount >= 1e6 || amount <= -1e6:
+		return fmt.Sprintf("$%.2fM", amount/1e6)
+	case amount >= 1e3 || amount <= -1e3:
+		return fmt.Sprintf("$%.1fk", amount/1e3)
+	default:
+		return fmt.Sprintf("$%.0f", amount)
+	}
+}
@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestGenerateMarkdownSummary(t *testing.T) {
+	simController := controller.NewSimulationController(testSimulationConfig(), 7)
+	result, err := simController.RunUntilEquilibrium(200)
+	if err != nil {
+		t.Fatalf("Simulation failed: %v", err)
+	}
+
+	engine := NewAnalyticsEngine()
+	summary := engine.GenerateMarkdownSummary(result)
+
+	for _, section := range []string{"# Simulation Summary", "## Workforce Composition", "## Value Contribution", "## Top Cost Drivers", "## Notable Events"} {
+		if !strings.Contains(summary, section) {
+			t.Errorf("Expected summary to contain %q", section)
+		}
+	}
+
+	if result.EquilibriumState.IsEquilibrium {
+		reason := result.EquilibriumReason.Message
+		if reason == "" {
+			t.Fatal("Expected an equilibrium reason in the result")
+		}
+		if !strings.Contains(summary, reason) {
+			t.Errorf("Expected summary to mention equilibrium reason %q", reason)
+		}
+	}
+
+	if result.Metadata.Seed != 7 || !strings.Contains(summary, result.Metadata.RunID) || !strings.Contains(summary, result.Metadata.ConfigHash) {
+		t.Errorf("Expected summary to identify run %+v", result.Metadata)
+	}
+}
+
+func TestGenerateMarkdownSummaryEmptyResult(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	summary := engine.GenerateMarkdownSummary(types.SimulationResult{})
+
+	if !strings.Contains(summary, "No simulation data") {
+		t.Errorf("Expected empty-result message, got %q", summary)
+	}
+}
+
+func TestFinalCostDriversOrdering(t *testing.T) {
+	var state types.SimulationState
+	state.Workforce.Humans.Total = 2
+	state.Workforce.Humans.ByExperience = map[types.ExperienceLevel]int{types.Executive: 2}
+	state.Workforce.AIAgents.Total = 3
+	state.Workforce.AIAgents.ByExperience = map[types.ExperienceLevel]int{types.UniversityHire: 3}
+	state.CostBreakdown.HumanPayroll = map[types.ExperienceLevel]float64{types.Executive: 600000}
+	state.CostBreakdown.AICost = map[types.ExperienceLevel]float64{types.UniversityHire: 60000}
+
+	drivers := finalCostDrivers(state)
+	if len(drivers) != 2 {
+		t.Fatalf("Expected 2 cost drivers, got %d", len(drivers))
+	}
+	if drivers[0].cost != 600000 {
+		t.Errorf("Expected executive humans to cost 600000, got %f", drivers[0].cost)
+	}
+	if drivers[1].cost != 60000 {
+		t.Errorf("Expected university AI agents to cost 60000, got %f", drivers[1].cost)
+	}
+}
+
+func TestGenerateSharedLearningSummary(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	comparison := CounterfactualComparison{
+		Baseline:               types.SimulationResult{TimeToEquilibrium: 30, ReachedEquilibrium: true},
+		Modified:               types.SimulationResult{TimeToEquilibrium: 24, ReachedEquilibrium: true},
+		TimeToEquilibriumDelta: -6,
+		TotalRevenueDelta:      250000,
+	}
+
+	summary := engine.GenerateSharedLearningSummary(comparison)
+	for _, want := range []string{"brought equilibrium forward by **6 time steps**", "(24 with shared learning, 30 without)", "$250.0k"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to contain %q, got %q", want, summary)
+		}
+	}
+
+	comparison.Baseline.ReachedEquilibrium = false
+	if summary := engine.GenerateSharedLearningSummary(comparison); !strings.Contains(summary, "approximate") {
+		t.Errorf("Expected a censored comparison to be flagged as approximate, got %q", summary)
+	}
+}
@@ -0,0 +1,90 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// longHeader is the header of the long format report
+var longHeader = []string{"RunID", "TimeStep", "Metric", "Value"}
+
+// longExcludedColumns are the columns of the wide CSV report that the long format leaves out:
+// the time step and run ID it keys rows by, text columns and the rest of the run metadata
+var longExcludedColumns = map[string]bool{
+	"TimeStep":          true,
+	"EquilibriumReason": true,
+	"MarketShocks":      true,
+	"RunID":             true,
+	"Timestamp":         true,
+	"Seed":              true,
+	"Version":           true,
+	"ConfigHash":        true,
+}
+
+// GenerateLongCSV produces the time series of a run in long (tidy) format, with one
+// RunID, TimeStep, Metric, Value row per time step and metric, as plotting libraries and OLAP
+// tools expect. The metrics are the numeric columns of the wide CSV report under the same names;
+// IsEquilibrium is written as 1 or 0, and cells the wide report leaves empty have no row
+func (ae *AnalyticsEngine) GenerateLongCSV(result types.SimulationResult) ([][]string, error) {
+	wide, err := ae.GenerateReportCSV(result)
+	if err != nil {
+		return nil, err
+	}
+
+	header := wide[0]
+	columns := longColumns(header)
+	data := make([][]string, 0, (len(wide)-1)*len(columns)+1)
+	data = append(data, longHeader)
+	for _, row := range wide[1:] {
+		data = append(data, longRows(result.Metadata.RunID, header, columns, row)...)
+	}
+	return data, nil
+}
+
+// WriteLongCSV writes the time series of a run in long (tidy) format, see GenerateLongCSV
+// Rows are written as they are produced, see ReportCSVStream
+func (ae *AnalyticsEngine) WriteLongCSV(result types.SimulationResult, writer io.Writer) error {
+	if len(result.TimeSeries) == 0 {
+		return fmt.Errorf("failed to generate long CSV report: no time series data available")
+	}
+
+	stream := ae.NewLongCSVStream(writer, result.Metadata)
+	for _, state := range result.TimeSeries {
+		if err := stream.Write(state); err != nil {
+			return err
+		}
+	}
+	return stream.Flush()
+}
+
+// longColumns returns the indices of the columns of the wide report written as metrics
+func longColumns(header []string) []int {
+	columns := make([]int, 0, len(header))
+	for i, name := range header {
+		if !longExcludedColumns[name] {
+			columns = append(columns, i)
+		}
+	}
+	return columns
+}
+
+// longRows pivots a row of the wide report into a row per metric
+func longRows(runID string, header []string, columns []int, row []string) [][]string {
+	timeStep := row[0]
+	rows := make([][]string, 0, len(columns))
+	for _, i := range columns {
+		value := row[i]
+		if value == "" {
+			continue
+		}
+		switch value {
+		case "true":
+			value = "1"
+		case "false":
+			value = "0"
+		}
+		rows = append(rows, []string{runID, timeStep, header[i], value})
+	}
+	return rows
+}
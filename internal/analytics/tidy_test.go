@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestGenerateLongCSV(t *testing.T) {
+	engine := NewAnalyticsEngine()
+	result := types.SimulationResult{
+		Metadata: types.RunMetadata{RunID: "run-1"},
+		TimeSeries: []types.SimulationState{
+			{TimeStep: 0, RevenueOutput: 20000, Workforce: types.WorkforceComposition{Humans: types.HumanComposition{Total: 5}}},
+			{TimeStep: 1, RevenueOutput: 21000, Workforce: types.WorkforceComposition{Humans: types.HumanComposition{Total: 4}}, IsEquilibrium: true},
+		},
+	}
+
+	wide, err := engine.GenerateReportCSV(result)
+	if err != nil {
+		t.Fatalf("GenerateReportCSV failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := engine.WriteLongCSV(result, &buf); err != nil {
+		t.Fatalf("WriteLongCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Long CSV does not parse: %v", err)
+	}
+	if strings.Join(rows[0], ",") != "RunID,TimeStep,Metric,Value" {
+		t.Fatalf("Unexpected header %v", rows[0])
+	}
+
+	values := make(map[string]string)
+	for _, row := range rows[1:] {
+		if row[0] != "run-1" {
+			t.Fatalf("Expected every row to carry the run ID, got %v", row)
+		}
+		if longExcludedColumns[row[2]] {
+			t.Errorf("Expected %s to be left out of the long format", row[2])
+		}
+		values[row[1]+"/"+row[2]] = row[3]
+	}
+	if values["1/HumanCount"] != "4" || values["0/RevenueOutput"] != wide[1][7] {
+		t.Errorf("Expected the long format to carry the wide report's values, got %v", values)
+	}
+	if values["0/IsEquilibrium"] != "0" || values["1/IsEquilibrium"] != "1" {
+		t.Errorf("Expected IsEquilibrium as 0 or 1, got %q and %q", values["0/IsEquilibrium"], values["1/IsEquilibrium"])
+	}
+}
@@ -0,0 +1,372 @@
+// Package arrowipc writes tables in the Apache Arrow IPC file format, which Python (pyarrow,
+// pandas, polars) and R (arrow) can memory-map without parsing
+// Only what the simulator's reports need is supported: a single record batch of 64-bit integer,
+// double, boolean and UTF-8 string columns, any of which may hold nulls
+package arrowipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// Type is the type of the values of a column
+type Type int
+
+const (
+	Int64 Type = iota
+	Float64
+	Bool
+	Utf8
+)
+
+// Column is a named column of a table
+// Values are read from the slice of the column's Type; Valid, if not nil, marks which rows hold
+// a value, the others being null
+type Column struct {
+	Name     string
+	Type     Type
+	Int64s   []int64
+	Float64s []float64
+	Bools    []bool
+	Strings  []string
+	Valid    []bool
+}
+
+// Len returns the number of rows of the column
+func (c Column) Len() int {
+	switch c.Type {
+	case Int64:
+		return len(c.Int64s)
+	case Float64:
+		return len(c.Float64s)
+	case Bool:
+		return len(c.Bools)
+	default:
+		return len(c.Strings)
+	}
+}
+
+// nullCount returns the number of null rows of the column
+func (c Column) nullCount() int {
+	nulls := 0
+	for _, valid := range c.Valid {
+		if !valid {
+			nulls++
+		}
+	}
+	return nulls
+}
+
+// Table is a set of columns of the same length
+type Table struct {
+	Columns []Column
+}
+
+// Rows returns the number of rows of the table
+func (t Table) Rows() int {
+	if len(t.Columns) == 0 {
+		return 0
+	}
+	return t.Columns[0].Len()
+}
+
+// magic starts and ends every Arrow IPC file
+const magic = "ARROW1"
+
+// Values of the Arrow flatbuffer schema (Schema.fbs, Message.fbs and File.fbs) that are written
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+
+	precisionDouble = 2
+)
+
+// block locates a record batch in the file for the footer
+type block struct {
+	offset         int64
+	metadataLength int32
+	bodyLength     int64
+}
+
+// Write writes a table to w as an Arrow IPC file holding one record batch
+// Returns an error if the columns have different lengths or a column's Valid does not match
+// its length
+func Write(w io.Writer, table Table) error {
+	rows := table.Rows()
+	for _, column := range table.Columns {
+		if column.Len() != rows {
+			return fmt.Errorf("column %s has %d rows, expected %d", column.Name, column.Len(), rows)
+		}
+		if column.Valid != nil && len(column.Valid) != rows {
+			return fmt.Errorf("column %s marks %d rows valid or null, expected %d", column.Name, len(column.Valid), rows)
+		}
+	}
+
+	out := &countingWriter{w: w}
+	out.write([]byte(magic + "\x00\x00"))
+
+	schema := flatbuffers.NewBuilder(1024)
+	schema.Finish(message(schema, headerSchema, buildSchema(schema, table), 0))
+	out.writeMessage(schema.FinishedBytes(), nil)
+
+	nodes, buffers, body := buildBody(table)
+	batch := flatbuffers.NewBuilder(1024)
+	batch.Finish(message(batch, headerRecordBatch, buildRecordBatch(batch, rows, nodes, buffers), int64(len(body))))
+	offset := out.n
+	metadataLength := out.writeMessage(batch.FinishedBytes(), body)
+	blocks := []block{{offset: offset, metadataLength: metadataLength, bodyLength: int64(len(body))}}
+
+	// End of stream marker, then the footer locating the schema and record batches
+	out.write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0})
+	footer := flatbuffers.NewBuilder(1024)
+	footer.Finish(buildFooter(footer, table, blocks))
+	out.write(footer.FinishedBytes())
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer.FinishedBytes())))
+	out.write(length[:])
+	out.write([]byte(magic))
+	return out.err
+}
+
+// countingWriter tracks the position in the file and the first write error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// write writes p unless an earlier write failed
+func (c *countingWriter) write(p []byte) {
+	if c.err != nil {
+		return
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+}
+
+// writeMessage writes an encapsulated message: the continuation marker, the length of the
+// metadata, the metadata padded to 8 bytes and the body, which is already padded
+// Returns the length of the message up to the body, as the footer records it
+func (c *countingWriter) writeMessage(metadata []byte, body []byte) int32 {
+	padded := padLength(8+len(metadata)) - 8
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[0:4], 0xffffffff)
+	binary.LittleEndian.PutUint32(prefix[4:8], uint32(padded))
+	c.write(prefix[:])
+	c.write(metadata)
+	c.write(make([]byte, padded-len(metadata)))
+	c.write(body)
+	return int32(8 + padded)
+}
+
+// padLength rounds a length up to a multiple of 8 bytes
+func padLength(n int) int {
+	return (n + 7) &^ 7
+}
+
+// message builds a Message table around a header
+func message(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, bodyLength int64) flatbuffers.UOffsetT {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	return b.EndObject()
+}
+
+// buildSchema builds the Schema table of a table's columns
+func buildSchema(b *flatbuffers.Builder, table Table) flatbuffers.UOffsetT {
+	fields := make([]flatbuffers.UOffsetT, len(table.Columns))
+	for i, column := range table.Columns {
+		fields[i] = buildField(b, column)
+	}
+	b.StartVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fields[i])
+	}
+	fieldVector := b.EndVector(len(fields))
+
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldVector, 0) // endianness is left at its default, little-endian
+	return b.EndObject()
+}
+
+// buildField builds the Field table of a column
+func buildField(b *flatbuffers.Builder, column Column) flatbuffers.UOffsetT {
+	name := b.CreateString(column.Name)
+	var typeType byte
+	switch column.Type {
+	case Int64:
+		typeType = typeInt
+		b.StartObject(2)
+		b.PrependInt32Slot(0, 64, 0)
+		b.PrependBoolSlot(1, true, false)
+	case Float64:
+		typeType = typeFloatingPoint
+		b.StartObject(1)
+		b.PrependInt16Slot(0, precisionDouble, 0)
+	case Bool:
+		typeType = typeBool
+		b.StartObject(0)
+	default:
+		typeType = typeUtf8
+		b.StartObject(0)
+	}
+	fieldType := b.EndObject()
+	b.StartVector(4, 0, 4)
+	children := b.EndVector(0)
+
+	b.StartObject(7)
+	b.PrependUOffsetTSlot(0, name, 0)
+	b.PrependBoolSlot(1, true, false)
+	b.PrependByteSlot(2, typeType, 0)
+	b.PrependUOffsetTSlot(3, fieldType, 0)
+	b.PrependUOffsetTSlot(5, children, 0)
+	return b.EndObject()
+}
+
+// fieldNode is the length and null count of a column in a record batch
+type fieldNode struct {
+	length    int64
+	nullCount int64
+}
+
+// buffer locates a buffer in the body of a record batch
+type buffer struct {
+	offset int64
+	length int64
+}
+
+// buildBody lays out the buffers of every column, each padded to 8 bytes: the validity bitmap,
+// empty when the column has no nulls, then the values, or the offsets and data of strings
+func buildBody(table Table) ([]fieldNode, []buffer, []byte) {
+	var nodes []fieldNode
+	var buffers []buffer
+	var body []byte
+	add := func(data []byte) {
+		buffers = append(buffers, buffer{offset: int64(len(body)), length: int64(len(data))})
+		body = append(body, data...)
+		body = append(body, make([]byte, padLength(len(body))-len(body))...)
+	}
+
+	for _, column := range table.Columns {
+		rows := column.Len()
+		nulls := column.nullCount()
+		nodes = append(nodes, fieldNode{length: int64(rows), nullCount: int64(nulls)})
+		if nulls > 0 {
+			add(bitmap(column.Valid))
+		} else {
+			add(nil)
+		}
+
+		switch column.Type {
+		case Int64:
+			data := make([]byte, 8*rows)
+			for i, v := range column.Int64s {
+				if column.isValid(i) {
+					binary.LittleEndian.PutUint64(data[8*i:], uint64(v))
+				}
+			}
+			add(data)
+		case Float64:
+			data := make([]byte, 8*rows)
+			for i, v := range column.Float64s {
+				if column.isValid(i) {
+					binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(v))
+				}
+			}
+			add(data)
+		case Bool:
+			values := make([]bool, rows)
+			for i, v := range column.Bools {
+				values[i] = v && column.isValid(i)
+			}
+			add(bitmap(values))
+		default:
+			offsets := make([]byte, 4*(rows+1))
+			var data []byte
+			for i, s := range column.Strings {
+				if column.isValid(i) {
+					data = append(data, s...)
+				}
+				binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+			}
+			add(offsets)
+			add(data)
+		}
+	}
+	return nodes, buffers, body
+}
+
+// isValid reports whether a row of the column holds a value
+func (c Column) isValid(row int) bool {
+	return c.Valid == nil || c.Valid[row]
+}
+
+// bitmap packs flags into bits, least significant bit first
+func bitmap(flags []bool) []byte {
+	bits := make([]byte, (len(flags)+7)/8)
+	for i, set := range flags {
+		if set {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bits
+}
+
+// buildRecordBatch builds the RecordBatch table of a body
+func buildRecordBatch(b *flatbuffers.Builder, rows int, nodes []fieldNode, buffers []buffer) flatbuffers.UOffsetT {
+	b.StartVector(16, len(nodes), 8)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(nodes[i].nullCount)
+		b.PrependInt64(nodes[i].length)
+	}
+	nodeVector := b.EndVector(len(nodes))
+
+	b.StartVector(16, len(buffers), 8)
+	for i := len(buffers) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(buffers[i].length)
+		b.PrependInt64(buffers[i].offset)
+	}
+	bufferVector := b.EndVector(len(buffers))
+
+	b.StartObject(5)
+	b.PrependInt64Slot(0, int64(rows), 0)
+	b.PrependUOffsetTSlot(1, nodeVector, 0)
+	b.PrependUOffsetTSlot(2, bufferVector, 0)
+	return b.EndObject()
+}
+
+// buildFooter builds the Footer table of a file
+func buildFooter(b *flatbuffers.Builder, table Table, blocks []block) flatbuffers.UOffsetT {
+	schema := buildSchema(b, table)
+	b.StartVector(24, len(blocks), 8)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b.Prep(8, 24)
+		b.PrependInt64(blocks[i].bodyLength)
+		b.Pad(4)
+		b.PrependInt32(blocks[i].metadataLength)
+		b.PrependInt64(blocks[i].offset)
+	}
+	blockVector := b.EndVector(len(blocks))
+
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataV5, 0)
+	b.PrependUOffsetTSlot(1, schema, 0)
+	b.PrependUOffsetTSlot(3, blockVector, 0)
+	return b.EndObject()
+}
@@ -0,0 +1,130 @@
+package arrowipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// field returns the position of a table's field in slot, or 0 if it is not set
+func field(table flatbuffers.Table, slot int) flatbuffers.UOffsetT {
+	return flatbuffers.UOffsetT(table.Offset(flatbuffers.VOffsetT(4 + 2*slot)))
+}
+
+// subtable returns the table referenced by a table's field
+func subtable(table flatbuffers.Table, slot int) flatbuffers.Table {
+	return flatbuffers.Table{Bytes: table.Bytes, Pos: table.Indirect(field(table, slot) + table.Pos)}
+}
+
+// root returns the root table of a flatbuffer
+func root(buf []byte) flatbuffers.Table {
+	return flatbuffers.Table{Bytes: buf, Pos: flatbuffers.GetUOffsetT(buf)}
+}
+
+func TestWriteFileLayout(t *testing.T) {
+	table := Table{Columns: []Column{
+		{Name: "TimeStep", Type: Int64, Int64s: []int64{0, 1, 2}},
+		{Name: "Revenue", Type: Float64, Float64s: []float64{1.5, 0, -2.25}, Valid: []bool{true, false, true}},
+		{Name: "IsEquilibrium", Type: Bool, Bools: []bool{false, true, true}},
+		{Name: "Reason", Type: Utf8, Strings: []string{"", "stable", "shock"}, Valid: []bool{false, true, true}},
+	}}
+	var buf bytes.Buffer
+	if err := Write(&buf, table); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	file := buf.Bytes()
+	if string(file[:6]) != magic || string(file[len(file)-6:]) != magic {
+		t.Fatalf("Expected the file to start and end with %q", magic)
+	}
+
+	// Footer: schema field names and the block of the record batch
+	footerLength := int(binary.LittleEndian.Uint32(file[len(file)-10:]))
+	footer := root(file[len(file)-10-footerLength : len(file)-10])
+	if version := footer.GetInt16(field(footer, 0) + footer.Pos); version != metadataV5 {
+		t.Errorf("Expected metadata version V5, got %d", version)
+	}
+	schema := subtable(footer, 1)
+	fields := schema.Vector(field(schema, 1))
+	if n := schema.VectorLen(field(schema, 1)); n != len(table.Columns) {
+		t.Fatalf("Expected %d fields, got %d", len(table.Columns), n)
+	}
+	wantTypes := []byte{typeInt, typeFloatingPoint, typeBool, typeUtf8}
+	for i, column := range table.Columns {
+		f := flatbuffers.Table{Bytes: schema.Bytes, Pos: schema.Indirect(fields + flatbuffers.UOffsetT(4*i))}
+		if name := string(f.ByteVector(field(f, 0) + f.Pos)); name != column.Name {
+			t.Errorf("Expected field %d to be %s, got %s", i, column.Name, name)
+		}
+		if typeType := f.GetByte(field(f, 2) + f.Pos); typeType != wantTypes[i] {
+			t.Errorf("Expected field %s to have type %d, got %d", column.Name, wantTypes[i], typeType)
+		}
+		if field(f, 5) == 0 {
+			t.Errorf("Expected field %s to have a children vector", column.Name)
+		}
+	}
+	blocks := footer.Vector(field(footer, 3))
+	offset := int(footer.GetInt64(blocks))
+	metadataLength := int(footer.GetInt32(blocks + 8))
+	bodyLength := int(footer.GetInt64(blocks + 16))
+	if offset%8 != 0 || metadataLength%8 != 0 {
+		t.Fatalf("Expected the record batch to be 8-byte aligned, got offset %d and metadata length %d", offset, metadataLength)
+	}
+
+	// Record batch message and its body
+	if binary.LittleEndian.Uint32(file[offset:]) != 0xffffffff {
+		t.Fatalf("Expected a continuation marker at the record batch")
+	}
+	message := root(file[offset+8 : offset+metadataLength])
+	if header := message.GetByte(field(message, 1) + message.Pos); header != headerRecordBatch {
+		t.Fatalf("Expected a record batch message, got header %d", header)
+	}
+	if length := int(message.GetInt64(field(message, 3) + message.Pos)); length != bodyLength {
+		t.Errorf("Expected the message body length %d to match the footer's %d", length, bodyLength)
+	}
+	batch := subtable(message, 2)
+	if rows := batch.GetInt64(field(batch, 0) + batch.Pos); rows != 3 {
+		t.Errorf("Expected 3 rows, got %d", rows)
+	}
+	nodes := batch.Vector(field(batch, 1))
+	if nulls := batch.GetInt64(nodes + 16 + 8); nulls != 1 {
+		t.Errorf("Expected Revenue to have 1 null, got %d", nulls)
+	}
+
+	body := file[offset+metadataLength : offset+metadataLength+bodyLength]
+	buffers := batch.Vector(field(batch, 2))
+	bufferAt := func(i int) []byte {
+		start := batch.GetInt64(buffers + flatbuffers.UOffsetT(16*i))
+		length := batch.GetInt64(buffers + flatbuffers.UOffsetT(16*i+8))
+		if start%8 != 0 {
+			t.Errorf("Expected buffer %d to be 8-byte aligned, got offset %d", i, start)
+		}
+		return body[start : start+length]
+	}
+	if steps := bufferAt(1); len(bufferAt(0)) != 0 || binary.LittleEndian.Uint64(steps[16:]) != 2 {
+		t.Errorf("Unexpected TimeStep buffers")
+	}
+	if validity := bufferAt(2); validity[0] != 0b101 {
+		t.Errorf("Expected Revenue validity 0b101, got %b", validity[0])
+	}
+	if revenue := bufferAt(3); math.Float64frombits(binary.LittleEndian.Uint64(revenue[16:])) != -2.25 {
+		t.Errorf("Unexpected Revenue values")
+	}
+	if equilibrium := bufferAt(5); equilibrium[0] != 0b110 {
+		t.Errorf("Expected IsEquilibrium bits 0b110, got %b", equilibrium[0])
+	}
+	if offsets, data := bufferAt(7), bufferAt(8); binary.LittleEndian.Uint32(offsets[12:]) != 11 || string(data) != "stableshock" {
+		t.Errorf("Unexpected Reason buffers %v %q", offsets, data)
+	}
+}
+
+func TestWriteRejectsRaggedColumns(t *testing.T) {
+	table := Table{Columns: []Column{
+		{Name: "A", Type: Int64, Int64s: []int64{1, 2}},
+		{Name: "B", Type: Float64, Float64s: []float64{1}},
+	}}
+	if err := Write(&bytes.Buffer{}, table); err == nil {
+		t.Error("Expected an error for columns of different lengths")
+	}
+}
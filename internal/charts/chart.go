@@ -0,0 +1,297 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Default chart dimensions in pixels
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 400
+)
+
+// Margins around the plot area in pixels
+const (
+	marginLeft   = 80
+	marginRight  = 20
+	marginTop    = 40
+	marginBottom = 60
+)
+
+// Series is a named line on a chart, one value per time step
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// Marker annotates a time step with a labelled vertical line, e.g. a market shock
+type Marker struct {
+	Step  int
+	Label string
+}
+
+// Chart is a line chart of one or more series over simulation time steps
+type Chart struct {
+	Name    string // short identifier used for file names
+	Title   string
+	XLabel  string
+	YLabel  string
+	Steps   []int // time step of each point; all series share the same x values
+	Series  []Series
+	Markers []Marker
+	Width   int
+	Height  int
+}
+
+// colorMarker is the color of marker lines and labels as an RGB hex string
+const colorMarker = "#7f7f7f"
+
+// markerTextOffset is the distance in pixels between a marker line and its label
+const markerTextOffset = 4
+
+// palette holds the series colors as RGB hex strings, used in order
+var palette = []string{"#1f77b4", "#d62728", "#2ca02c", "#ff7f0e", "#9467bd", "#8c564b"}
+
+// KeyMetricCharts builds the standard charts for a simulation time series:
+// headcount, revenue, cost-efficiency, and AI ratio
+// Every chart marks the time steps at which market shocks took effect
+func KeyMetricCharts(timeSeries []types.SimulationState) []Chart {
+	markers := ShockMarkers(timeSeries)
+	steps := make([]int, len(timeSeries))
+	humans := make([]float64, len(timeSeries))
+	agents := make([]float64, len(timeSeries))
+	revenue := make([]float64, len(timeSeries))
+	costEfficiency := make([]float64, len(timeSeries))
+	aiRatio := make([]float64, len(timeSeries))
+
+	for i, state := range timeSeries {
+		steps[i] = state.TimeStep
+		humans[i] = float64(state.Workforce.Humans.Total)
+		agents[i] = float64(state.Workforce.AIAgents.Total)
+		revenue[i] = state.RevenueOutput
+
+		// Productivity per million of cost keeps the axis readable
+		if state.TotalCost > 0 {
+			costEfficiency[i] = state.TotalProductivity / state.TotalCost * 1e6
+		}
+
+		total := humans[i] + agents[i]
+		if total > 0 {
+			aiRatio[i] = agents[i] / total * 100.0
+		}
+	}
+
+	return []Chart{
+		{
+			Name:    "headcount",
+			Title:   "Workforce Headcount",
+			XLabel:  "Time step",
+			YLabel:  "Workers",
+			Steps:   steps,
+			Series:  []Series{{Name: "Humans", Values: humans}, {Name: "AI agents", Values: agents}},
+			Markers: markers,
+		},
+		{
+			Name:    "revenue",
+			Title:   "Revenue Output",
+			XLabel:  "Time step",
+			YLabel:  "Revenue",
+			Steps:   steps,
+			Series:  []Series{{Name: "Revenue", Values: revenue}},
+			Markers: markers,
+		},
+		{
+			Name:    "cost_efficiency",
+			Title:   "Cost Efficiency",
+			XLabel:  "Time step",
+			YLabel:  "Productivity per $1M cost",
+			Steps:   steps,
+			Series:  []Series{{Name: "Cost efficiency", Values: costEfficiency}},
+			Markers: markers,
+		},
+		{
+			Name:    "ai_ratio",
+			Title:   "AI Share of Workforce",
+			XLabel:  "Time step",
+			YLabel:  "AI agents (%)",
+			Steps:   steps,
+			Series:  []Series{{Name: "AI ratio", Values: aiRatio}},
+			Markers: markers,
+		},
+	}
+}
+
+// ShockMarkers returns a marker for every time step of a time series at which market shocks took
+// effect, labelled with the shocks
+func ShockMarkers(timeSeries []types.SimulationState) []Marker {
+	var markers []Marker
+	for _, state := range timeSeries {
+		if len(state.MarketShocks) == 0 {
+			continue
+		}
+		labels := make([]string, len(state.MarketShocks))
+		for i, shock := range state.MarketShocks {
+			labels[i] = shock.String()
+		}
+		markers = append(markers, Marker{Step: state.TimeStep, Label: strings.Join(labels, ", ")})
+	}
+	return markers
+}
+
+// size returns the chart dimensions, falling back to the defaults
+func (c Chart) size() (int, int) {
+	width, height := c.Width, c.Height
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	if height <= 0 {
+		height = DefaultHeight
+	}
+	return width, height
+}
+
+// validate checks that the chart has data that can be plotted
+func (c Chart) validate() error {
+	if len(c.Steps) == 0 {
+		return fmt.Errorf("chart %q has no data points", c.Name)
+	}
+	for _, series := range c.Series {
+		if len(series.Values) != len(c.Steps) {
+			return fmt.Errorf("chart %q series %q has %d values for %d steps", c.Name, series.Name, len(series.Values), len(c.Steps))
+		}
+	}
+	return nil
+}
+
+// axes holds the data ranges and tick positions of a chart
+type axes struct {
+	xMin, xMax float64
+	yMin, yMax float64
+	xTicks     []float64
+	yTicks     []float64
+}
+
+// computeAxes determines nice axis ranges and ticks covering all series
+func (c Chart) computeAxes() axes {
+	xMin, xMax := float64(c.Steps[0]), float64(c.Steps[len(c.Steps)-1])
+	if xMax <= xMin {
+		xMax = xMin + 1
+	}
+
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, series := range c.Series {
+		for _, v := range series.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			yMin = math.Min(yMin, v)
+			yMax = math.Max(yMax, v)
+		}
+	}
+	if math.IsInf(yMin, 1) {
+		yMin, yMax = 0, 1
+	}
+	// Anchor non-negative data at zero so magnitudes are comparable
+	if yMin > 0 {
+		yMin = 0
+	}
+	if yMax <= yMin {
+		yMax = yMin + 1
+	}
+
+	yStep := niceStep((yMax - yMin) / 5)
+	yMin = math.Floor(yMin/yStep) * yStep
+	yMax = math.Ceil(yMax/yStep) * yStep
+
+	xStep := niceStep((xMax - xMin) / 8)
+	if xStep < 1 {
+		xStep = 1
+	}
+
+	return axes{
+		xMin:   xMin,
+		xMax:   xMax,
+		yMin:   yMin,
+		yMax:   yMax,
+		xTicks: ticks(math.Ceil(xMin/xStep)*xStep, xMax, xStep),
+		yTicks: ticks(yMin, yMax, yStep),
+	}
+}
+
+// niceStep rounds a raw tick interval up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if factor*magnitude >= raw {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// ticks lists tick positions from start to end (inclusive) at the given interval
+func ticks(start float64, end float64, step float64) []float64 {
+	values := make([]float64, 0)
+	for v := start; v <= end+step*1e-9; v += step {
+		values = append(values, v)
+	}
+	return values
+}
+
+// formatTick formats an axis value with a k/M/B suffix for large magnitudes
+func formatTick(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1e9:
+		return trimZeros(fmt.Sprintf("%.1f", v/1e9)) + "B"
+	case abs >= 1e6:
+		return trimZeros(fmt.Sprintf("%.1f", v/1e6)) + "M"
+	case abs >= 1e3:
+		return trimZeros(fmt.Sprintf("%.1f", v/1e3)) + "k"
+	case abs == math.Trunc(abs):
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return trimZeros(fmt.Sprintf("%.2f", v))
+	}
+}
+
+// trimZeros removes trailing zeros (and a trailing decimal point) from a formatted number
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// plotArea maps data coordinates to pixel coordinates inside the chart margins
+type plotArea struct {
+	left, top, width, height float64
+	axes                     axes
+}
+
+// newPlotArea creates the plot area for a chart of the given size
+func newPlotArea(width int, height int, a axes) plotArea {
+	return plotArea{
+		left:   marginLeft,
+		top:    marginTop,
+		width:  float64(width - marginLeft - marginRight),
+		height: float64(height - marginTop - marginBottom),
+		axes:   a,
+	}
+}
+
+// x converts a time step to a horizontal pixel position
+func (p plotArea) x(step float64) float64 {
+	return p.left + (step-p.axes.xMin)/(p.axes.xMax-p.axes.xMin)*p.width
+}
+
+// y converts a value to a vertical pixel position
+func (p plotArea) y(value float64) float64 {
+	return p.top + p.height - (value-p.axes.yMin)/(p.axes.yMax-p.axes.yMin)*p.height
+}
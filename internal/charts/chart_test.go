@@ -0,0 +1,191 @@
+package charts
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func testTimeSeries() []types.SimulationState {
+	series := make([]types.SimulationState, 0, 10)
+	for t := 0; t < 10; t++ {
+		var state types.SimulationState
+		state.TimeStep = t
+		state.Workforce.Humans.Total = 10 - t/2
+		state.Workforce.AIAgents.Total = t * 3
+		state.TotalCost = 1e6 + float64(t)*1e5
+		state.TotalProductivity = 10 + float64(t)
+		state.RevenueOutput = state.TotalProductivity * 100000
+		series = append(series, state)
+	}
+	return series
+}
+
+func TestKeyMetricCharts(t *testing.T) {
+	chartList := KeyMetricCharts(testTimeSeries())
+
+	names := []string{"headcount", "revenue", "cost_efficiency", "ai_ratio"}
+	if len(chartList) != len(names) {
+		t.Fatalf("Expected %d charts, got %d", len(names), len(chartList))
+	}
+	for i, name := range names {
+		if chartList[i].Name != name {
+			t.Errorf("Expected chart %d to be %s, got %s", i, name, chartList[i].Name)
+		}
+	}
+
+	aiRatio := chartList[3].Series[0].Values
+	if aiRatio[0] != 0 {
+		t.Errorf("Expected AI ratio 0 at step 0, got %f", aiRatio[0])
+	}
+	// Step 9: 6 humans, 27 agents
+	expected := 27.0 / 33.0 * 100.0
+	if math.Abs(aiRatio[9]-expected) > 1e-9 {
+		t.Errorf("Expected AI ratio %f at step 9, got %f", expected, aiRatio[9])
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	chart := KeyMetricCharts(testTimeSeries())[0]
+
+	var buf bytes.Buffer
+	if err := chart.RenderSVG(&buf); err != nil {
+		t.Fatalf("RenderSVG failed: %v", err)
+	}
+
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Errorf("Expected output to start with <svg, got %q", svg[:20])
+	}
+	if strings.Count(svg, "<polyline") != 2 {
+		t.Errorf("Expected one polyline per series, got %d", strings.Count(svg, "<polyline"))
+	}
+	if !strings.Contains(svg, "Workforce Headcount") {
+		t.Error("Expected chart title in SVG output")
+	}
+}
+
+func TestShockMarkersAnnotateCharts(t *testing.T) {
+	series := testTimeSeries()
+	series[4].MarketShocks = []types.MarketShock{
+		{TimeStep: 4, Type: types.DemandShock, Change: -30},
+		{TimeStep: 4, Type: types.SalaryInflation, Change: 8},
+	}
+
+	chartList := KeyMetricCharts(series)
+	expected := Marker{Step: 4, Label: "Demand_Shock of -30%, Salary_Inflation of +8%"}
+	for _, chart := range chartList {
+		if len(chart.Markers) != 1 || chart.Markers[0] != expected {
+			t.Errorf("Chart %s: expected marker %+v, got %+v", chart.Name, expected, chart.Markers)
+		}
+	}
+
+	svg, err := chartList[0].SVG()
+	if err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	if !strings.Contains(svg, "stroke-dasharray") || !strings.Contains(svg, expected.Label) {
+		t.Error("Expected a labelled dashed marker line in SVG output")
+	}
+
+	var buf bytes.Buffer
+	if err := chartList[0].RenderPNG(&buf); err != nil {
+		t.Fatalf("RenderPNG with markers failed: %v", err)
+	}
+}
+
+func TestRenderPhasePlot(t *testing.T) {
+	timeSeries := testTimeSeries()
+	timeSeries[4].MarketShocks = []types.MarketShock{{TimeStep: 4, Type: types.DemandShock, Change: -20}}
+	timeSeries[7].IsEquilibrium = true
+	timeSeries[8].IsEquilibrium = true
+
+	plot := NewPhasePlot(timeSeries)
+	if plot.Equilibrium != 7 || plot.Humans[9] != 6 || plot.AIAgents[9] != 27 {
+		t.Fatalf("Expected the trajectory to end at 6 humans and 27 AI agents with equilibrium at step 7, got %+v", plot)
+	}
+
+	var buf bytes.Buffer
+	if err := plot.RenderSVG(&buf); err != nil {
+		t.Fatalf("RenderSVG failed: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{"<polyline", "start (step 0)", "equilibrium (step 7)", "Demand_Shock of -20%", ">Humans<", ">AI agents<"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected the phase plot to contain %q", want)
+		}
+	}
+
+	plot.AIAgents = plot.AIAgents[:5]
+	if err := plot.RenderSVG(&buf); err == nil {
+		t.Error("Expected an error for mismatched counts")
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	chart := KeyMetricCharts(testTimeSeries())[1]
+	chart.Width = 400
+	chart.Height = 200
+
+	var buf bytes.Buffer
+	if err := chart.RenderPNG(&buf); err != nil {
+		t.Fatalf("RenderPNG failed: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Output is not a valid PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 400 || img.Bounds().Dy() != 200 {
+		t.Errorf("Expected 400x200 image, got %dx%d", img.Bounds().Dx(), img.Bounds().Dy())
+	}
+}
+
+func TestRenderRejectsMismatchedSeries(t *testing.T) {
+	chart := Chart{
+		Name:   "bad",
+		Steps:  []int{0, 1, 2},
+		Series: []Series{{Name: "short", Values: []float64{1, 2}}},
+	}
+
+	if err := chart.RenderSVG(&bytes.Buffer{}); err == nil {
+		t.Error("Expected error for series with mismatched length")
+	}
+	if err := chart.RenderPNG(&bytes.Buffer{}); err == nil {
+		t.Error("Expected error for series with mismatched length")
+	}
+}
+
+func TestWriteHTMLEmbedsCharts(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, "Report <test>", KeyMetricCharts(testTimeSeries())); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+
+	page := buf.String()
+	if strings.Count(page, "<svg") != 4 {
+		t.Errorf("Expected 4 inline SVG charts, got %d", strings.Count(page, "<svg"))
+	}
+	if !strings.Contains(page, "Report &lt;test&gt;") {
+		t.Error("Expected escaped title in HTML output")
+	}
+}
+
+func TestFormatTick(t *testing.T) {
+	tests := map[float64]string{
+		0:       "0",
+		5:       "5",
+		0.25:    "0.25",
+		1500:    "1.5k",
+		2000000: "2M",
+		3.5e9:   "3.5B",
+	}
+	for value, expected := range tests {
+		if got := formatTick(value); got != expected {
+			t.Errorf("formatTick(%v) = %q, expected %q", value, got, expected)
+		}
+	}
+}
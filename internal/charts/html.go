@@ -0,0 +1,32 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WriteHTML writes a self-contained HTML page with each chart inlined as SVG
+func WriteHTML(w io.Writer, title string, charts []Chart) error {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>body{font-family:sans-serif;margin:2em;} figure{margin:0 0 2em 0;}</style>\n")
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+
+	for _, chart := range charts {
+		svg, err := chart.SVG()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "<figure id=\"%s\">\n%s</figure>\n", html.EscapeString(chart.Name), svg)
+	}
+
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
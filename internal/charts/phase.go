@@ -0,0 +1,152 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Colors of the phase plot's start and equilibrium points as RGB hex strings
+const (
+	colorStart       = "#2ca02c"
+	colorEquilibrium = "#d62728"
+)
+
+// PhasePlot is the trajectory of a run through (human count, AI agent count) space, which shows
+// the transition as a single path from the initial workforce to its equilibrium
+type PhasePlot struct {
+	Title       string
+	Steps       []int // time step of each point
+	Humans      []float64
+	AIAgents    []float64
+	Equilibrium int      // index of the point at which equilibrium was first reached; -1 if it never was
+	Markers     []Marker // steps to label on the path, e.g. market shocks
+	Width       int
+	Height      int
+}
+
+// NewPhasePlot builds the phase plot of a simulation time series, labelling the steps at which
+// market shocks took effect and marking where equilibrium was first reached
+func NewPhasePlot(timeSeries []types.SimulationState) PhasePlot {
+	plot := PhasePlot{
+		Title:       "Workforce Phase Space",
+		Steps:       make([]int, len(timeSeries)),
+		Humans:      make([]float64, len(timeSeries)),
+		AIAgents:    make([]float64, len(timeSeries)),
+		Equilibrium: -1,
+		Markers:     ShockMarkers(timeSeries),
+	}
+	for i, state := range timeSeries {
+		plot.Steps[i] = state.TimeStep
+		plot.Humans[i] = float64(state.Workforce.Humans.Total)
+		plot.AIAgents[i] = float64(state.Workforce.AIAgents.Total)
+		if state.IsEquilibrium && plot.Equilibrium < 0 {
+			plot.Equilibrium = i
+		}
+	}
+	return plot
+}
+
+// RenderSVG writes the phase plot as a standalone SVG document, with humans on the horizontal
+// axis and AI agents on the vertical axis
+func (p PhasePlot) RenderSVG(w io.Writer) error {
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("phase plot has no data points")
+	}
+	if len(p.Humans) != len(p.Steps) || len(p.AIAgents) != len(p.Steps) {
+		return fmt.Errorf("phase plot has %d human and %d AI agent counts for %d steps", len(p.Humans), len(p.AIAgents), len(p.Steps))
+	}
+
+	chart := Chart{Width: p.Width, Height: p.Height}
+	width, height := chart.size()
+	xMin, xMax, xTicks := valueAxis(p.Humans)
+	yMin, yMax, yTicks := valueAxis(p.AIAgents)
+	area := newPlotArea(width, height, axes{xMin: xMin, xMax: xMax, yMin: yMin, yMax: yMax, xTicks: xTicks, yTicks: yTicks})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	// Title and axis labels
+	fmt.Fprintf(&b, `<text x="%d" y="24" text-anchor="middle" font-size="16" font-weight="bold">%s</text>`+"\n", width/2, html.EscapeString(p.Title))
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">Humans</text>`+"\n", area.left+area.width/2, height-12)
+	fmt.Fprintf(&b, `<text x="16" y="%.1f" text-anchor="middle" transform="rotate(-90 16 %.1f)">AI agents</text>`+"\n",
+		area.top+area.height/2, area.top+area.height/2)
+
+	// Gridlines and ticks
+	for _, tick := range yTicks {
+		y := area.y(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", area.left, y, area.left+area.width, y)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", area.left-6, y, formatTick(tick))
+	}
+	for _, tick := range xTicks {
+		x := area.x(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", x, area.top, x, area.top+area.height)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x, area.top+area.height+18, formatTick(tick))
+	}
+
+	// Axes
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`+"\n", area.left, area.top, area.left, area.top+area.height)
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`+"\n", area.left, area.top+area.height, area.left+area.width, area.top+area.height)
+
+	// Trajectory, with a dot per step so the pace of the transition is visible
+	points := make([]string, len(p.Steps))
+	for i := range p.Steps {
+		points[i] = fmt.Sprintf("%.1f,%.1f", area.x(p.Humans[i]), area.y(p.AIAgents[i]))
+	}
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="1.5" points="%s"/>`+"\n", palette[0], strings.Join(points, " "))
+	for i := range p.Steps {
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="2" fill="%s"><title>step %d</title></circle>`+"\n",
+			area.x(p.Humans[i]), area.y(p.AIAgents[i]), palette[0], p.Steps[i])
+	}
+
+	// Shock markers, labelled at the point of their step
+	for _, marker := range p.Markers {
+		for i, step := range p.Steps {
+			if step != marker.Step {
+				continue
+			}
+			x, y := area.x(p.Humans[i]), area.y(p.AIAgents[i])
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="4" fill="none" stroke="%s"/>`+"\n", x, y, colorMarker)
+			fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" fill="%s" font-size="10">%s</text>`+"\n", x+markerTextOffset+4, y, colorMarker, html.EscapeString(marker.Label))
+		}
+	}
+
+	// Start and equilibrium points
+	p.writePoint(&b, area, 0, colorStart, fmt.Sprintf("start (step %d)", p.Steps[0]))
+	if p.Equilibrium >= 0 && p.Equilibrium < len(p.Steps) {
+		p.writePoint(&b, area, p.Equilibrium, colorEquilibrium, fmt.Sprintf("equilibrium (step %d)", p.Steps[p.Equilibrium]))
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePoint draws a highlighted, labelled point of the trajectory
+func (p PhasePlot) writePoint(b *strings.Builder, area plotArea, index int, color string, label string) {
+	x, y := area.x(p.Humans[index]), area.y(p.AIAgents[index])
+	fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="5" fill="%s"/>`+"\n", x, y, color)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" fill="%s" font-weight="bold">%s</text>`+"\n", x+8, y-8, color, html.EscapeString(label))
+}
+
+// valueAxis returns a nice axis range anchored at zero and its ticks for the given values
+func valueAxis(values []float64) (float64, float64, []float64) {
+	maximum := 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			maximum = math.Max(maximum, v)
+		}
+	}
+	if maximum <= 0 {
+		maximum = 1
+	}
+	step := niceStep(maximum / 5)
+	maximum = math.Ceil(maximum/step) * step
+	return 0, maximum, ticks(0, maximum, step)
+}
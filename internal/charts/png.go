@@ -0,0 +1,192 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorGrid       = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	colorAxis       = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	colorText       = color.RGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// RenderPNG writes the chart as a PNG image
+// Text is drawn with a fixed 7x13 bitmap font, so the y-axis label is written horizontally
+// above the axis rather than rotated
+func (c Chart) RenderPNG(w io.Writer) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	width, height := c.size()
+	a := c.computeAxes()
+	area := newPlotArea(width, height, a)
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colorBackground}, image.Point{}, draw.Src)
+
+	// Title and axis labels
+	drawText(img, c.Title, width/2, 20, alignCenter)
+	drawText(img, c.XLabel, int(area.left+area.width/2), height-12, alignCenter)
+	drawText(img, c.YLabel, 8, int(area.top)-8, alignLeft)
+
+	// Gridlines and ticks
+	for _, tick := range a.yTicks {
+		y := int(math.Round(area.y(tick)))
+		drawLine(img, int(area.left), y, int(area.left+area.width), y, colorGrid, 1)
+		drawText(img, formatTick(tick), int(area.left)-6, y+4, alignRight)
+	}
+	for _, tick := range a.xTicks {
+		x := int(math.Round(area.x(tick)))
+		drawLine(img, x, int(area.top), x, int(area.top+area.height), colorGrid, 1)
+		drawText(img, formatTick(tick), x, int(area.top+area.height)+18, alignCenter)
+	}
+
+	// Axes
+	drawLine(img, int(area.left), int(area.top), int(area.left), int(area.top+area.height), colorAxis, 1)
+	drawLine(img, int(area.left), int(area.top+area.height), int(area.left+area.width), int(area.top+area.height), colorAxis, 1)
+
+	// Markers, dashed so they are not mistaken for series
+	markerColor := parseHexColor(colorMarker)
+	for _, marker := range c.Markers {
+		x := int(math.Round(area.x(float64(marker.Step))))
+		for y := int(area.top); y < int(area.top+area.height); y += 7 {
+			drawLine(img, x, y, x, minInt(y+4, int(area.top+area.height)), markerColor, 1)
+		}
+		drawText(img, marker.Label, x+markerTextOffset, int(area.top+area.height)-6, alignLeft)
+	}
+
+	// Series lines, skipping gaps where values are not finite
+	for i, series := range c.Series {
+		lineColor := parseHexColor(palette[i%len(palette)])
+		prevX, prevY, havePrev := 0, 0, false
+		for j, v := range series.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				havePrev = false
+				continue
+			}
+			x := int(math.Round(area.x(float64(c.Steps[j]))))
+			y := int(math.Round(area.y(v)))
+			if havePrev {
+				drawLine(img, prevX, prevY, x, y, lineColor, 2)
+			}
+			prevX, prevY, havePrev = x, y, true
+		}
+	}
+
+	// Legend
+	for i, series := range c.Series {
+		x := int(area.left) + 10 + i*140
+		y := int(area.top) + 12
+		drawLine(img, x, y, x+20, y, parseHexColor(palette[i%len(palette)]), 3)
+		drawText(img, series.Name, x+26, y+4, alignLeft)
+	}
+
+	return png.Encode(w, img)
+}
+
+// textAlign controls horizontal placement of text relative to its anchor point
+type textAlign int
+
+const (
+	alignLeft textAlign = iota
+	alignCenter
+	alignRight
+)
+
+// drawText draws a string with its baseline at y, aligned horizontally around x
+func drawText(img *image.RGBA, text string, x int, y int, align textAlign) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(colorText),
+		Face: basicfont.Face7x13,
+	}
+
+	textWidth := drawer.MeasureString(text).Round()
+	switch align {
+	case alignCenter:
+		x -= textWidth / 2
+	case alignRight:
+		x -= textWidth
+	}
+
+	drawer.Dot = fixed.P(x, y)
+	drawer.DrawString(text)
+}
+
+// drawLine draws a straight line using Bresenham's algorithm
+// Thickness greater than one is approximated by filling a square around each point
+func drawLine(img *image.RGBA, x0 int, y0 int, x1 int, y1 int, c color.RGBA, thickness int) {
+	dx := absInt(x1 - x0)
+	dy := -absInt(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	for {
+		plot(img, x0, y0, c, thickness)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// plot sets the pixels of a square of the given thickness centered on (x, y)
+func plot(img *image.RGBA, x int, y int, c color.RGBA, thickness int) {
+	offset := (thickness - 1) / 2
+	for px := x - offset; px < x-offset+thickness; px++ {
+		for py := y - offset; py < y-offset+thickness; py++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+// parseHexColor converts a "#rrggbb" string to an opaque color
+func parseHexColor(hex string) color.RGBA {
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return colorAxis
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xff}
+}
+
+// minInt returns the smaller of two integers
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// absInt returns the absolute value of an integer
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
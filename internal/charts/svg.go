@@ -0,0 +1,90 @@
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+)
+
+// RenderSVG writes the chart as a standalone SVG document
+// The output has no XML prolog, so it can also be inlined directly into an HTML page
+func (c Chart) RenderSVG(w io.Writer) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	width, height := c.size()
+	a := c.computeAxes()
+	area := newPlotArea(width, height, a)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	// Title and axis labels
+	fmt.Fprintf(&b, `<text x="%d" y="24" text-anchor="middle" font-size="16" font-weight="bold">%s</text>`+"\n", width/2, html.EscapeString(c.Title))
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", area.left+area.width/2, height-12, html.EscapeString(c.XLabel))
+	fmt.Fprintf(&b, `<text x="16" y="%.1f" text-anchor="middle" transform="rotate(-90 16 %.1f)">%s</text>`+"\n",
+		area.top+area.height/2, area.top+area.height/2, html.EscapeString(c.YLabel))
+
+	// Gridlines and ticks
+	for _, tick := range a.yTicks {
+		y := area.y(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", area.left, y, area.left+area.width, y)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", area.left-6, y, formatTick(tick))
+	}
+	for _, tick := range a.xTicks {
+		x := area.x(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", x, area.top, x, area.top+area.height)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x, area.top+area.height+18, formatTick(tick))
+	}
+
+	// Axes
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`+"\n", area.left, area.top, area.left, area.top+area.height)
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`+"\n", area.left, area.top+area.height, area.left+area.width, area.top+area.height)
+
+	// Markers, dashed so they are not mistaken for series
+	for _, marker := range c.Markers {
+		x := area.x(float64(marker.Step))
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-dasharray="4 3"/>`+"\n", x, area.top, x, area.top+area.height, colorMarker)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" fill="%s" font-size="10">%s</text>`+"\n", x+markerTextOffset, area.top+area.height-6, colorMarker, html.EscapeString(marker.Label))
+	}
+
+	// Series polylines
+	for i, series := range c.Series {
+		points := make([]string, 0, len(series.Values))
+		for j, v := range series.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			points = append(points, fmt.Sprintf("%.1f,%.1f", area.x(float64(c.Steps[j])), area.y(v)))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n", palette[i%len(palette)], strings.Join(points, " "))
+	}
+
+	// Legend
+	for i, series := range c.Series {
+		x := area.left + 10 + float64(i)*140
+		y := area.top + 12
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="3"/>`+"\n", x, y, x+20, y, palette[i%len(palette)])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" dominant-baseline="middle">%s</text>`+"\n", x+26, y, html.EscapeString(series.Name))
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// SVG returns the chart as an SVG string, suitable for inlining into an HTML report
+func (c Chart) SVG() (string, error) {
+	var buf bytes.Buffer
+	if err := c.RenderSVG(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
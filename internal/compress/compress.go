@@ -0,0 +1,111 @@
+// Package compress writes report output compressed with gzip or zstd, since the outputs of large
+// batches such as Monte Carlo runs routinely reach hundreds of megabytes, and reads it back
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Format is a compression format of report output
+type Format int
+
+const (
+	None Format = iota // output is written as is
+	Gzip
+	Zstd
+)
+
+// formatNames are the names of the formats, indexed by Format
+var formatNames = []string{"none", "gzip", "zstd"}
+
+// String returns the name of the format
+func (f Format) String() string {
+	if f >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Extension returns the suffix of files compressed in the format: .gz, .zst, or nothing
+func (f Format) Extension() string {
+	switch f {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// ParseFormat converts a format name, matched case-insensitively, to a Format; an empty name is
+// None
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return None, nil
+	}
+	for i, formatName := range formatNames {
+		if strings.EqualFold(name, formatName) {
+			return Format(i), nil
+		}
+	}
+	return None, fmt.Errorf("unknown compression %q: must be one of %s", name, strings.Join(formatNames, ", "))
+}
+
+// NewWriter returns a writer compressing what is written to it in the given format into w
+// Close must be called to write the end of the compressed stream; it does not close w
+func NewWriter(w io.Writer, format Format) (io.WriteCloser, error) {
+	switch format {
+	case None:
+		return nopCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown compression %s", format)
+	}
+}
+
+// Magic numbers at the start of compressed streams
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// NewReader returns a reader decompressing r, detecting gzip and zstd from the start of the
+// stream and passing anything else through unchanged
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	start, err := buffered.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(start, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(start, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return io.NopCloser(buffered), nil
+	}
+}
+
+// nopCloser is a writer whose Close does nothing
+type nopCloser struct {
+	io.Writer
+}
+
+// Close does nothing
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,54 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	report := strings.Repeat("TimeStep,HumanCount,AIAgentCount\n0,10,0\n", 1000)
+	for _, format := range []Format{None, Gzip, Zstd} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format)
+		if err != nil {
+			t.Fatalf("NewWriter(%s) failed: %v", format, err)
+		}
+		if _, err := io.WriteString(w, report); err != nil {
+			t.Fatalf("Writing %s failed: %v", format, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Closing %s failed: %v", format, err)
+		}
+		if format != None && buf.Len() >= len(report)/10 {
+			t.Errorf("Expected %s to compress a repetitive report, got %d of %d bytes", format, buf.Len(), len(report))
+		}
+
+		r, err := NewReader(&buf)
+		if err != nil {
+			t.Fatalf("NewReader(%s) failed: %v", format, err)
+		}
+		read, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("Reading %s failed: %v", format, err)
+		}
+		if string(read) != report {
+			t.Errorf("Expected %s to round-trip the report", format)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": None, "none": None, "GZIP": Gzip, "zstd": Zstd} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %s, %v; expected %s", name, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("brotli"); err == nil {
+		t.Error("Expected an error for an unknown compression")
+	}
+	if Zstd.Extension() != ".zst" || Gzip.Extension() != ".gz" || None.Extension() != "" {
+		t.Error("Unexpected file extensions")
+	}
+}
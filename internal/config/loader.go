@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the serialization format of a configuration file
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+)
+
+// String returns the string representation of Format
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "JSON"
+	case FormatYAML:
+		return "YAML"
+	default:
+		return "Unknown"
+	}
+}
+
+// FormatFromPath determines the configuration format from a file extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return FormatJSON, fmt.Errorf("unsupported configuration file extension %q (expected .json, .yaml or .yml)", filepath.Ext(path))
+	}
+}
+
+// Load reads a simulation configuration from a JSON or YAML file
+// The format is chosen from the file extension. Configurations written for an older schema
+// version are upgraded, and the returned warnings describe what the upgrade changed
+func Load(path string) (types.SimulationConfig, []string, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return types.SimulationConfig{}, nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.SimulationConfig{}, nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	config, warnings, err := Parse(data, format)
+	if err != nil {
+		return types.SimulationConfig{}, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return config, warnings, nil
+}
+
+// Diagnose loads a configuration file and checks it against every configuration constraint
+// Returns an error only if the file cannot be read or parsed; constraint violations are
+// returned as diagnostics, which are empty for a valid configuration, alongside any
+// migration warnings. Rules scripts are also compiled, so script errors are diagnosed before a run
+func Diagnose(path string) ([]types.ConfigError, []string, error) {
+	config, warnings, err := Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	diagnostics := config.Diagnose()
+	if _, err := events.CompileRules(config.Rules.Script); err != nil {
+		diagnostics = append(diagnostics, types.ConfigError{
+			Field:      "Rules.Script",
+			Constraint: "must be a Starlark script defining " + events.RulesFunction + "(state)",
+			Value:      err.Error(),
+		})
+	}
+	return diagnostics, warnings, nil
+}
+
+// Parse decodes a simulation configuration from raw JSON or YAML data
+// Field names match the SimulationConfig struct fields (e.g. InitialHumans, FixedBudget)
+// The data is migrated to the current schema version first; see Migrate
+func Parse(data []byte, format Format) (types.SimulationConfig, []string, error) {
+	// Decode into a generic document first so it can be migrated, then re-encode it as JSON
+	// so that YAML keys follow the same field-name matching rules as JSON configuration files
+	var document interface{}
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			return types.SimulationConfig{}, nil, fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return types.SimulationConfig{}, nil, fmt.Errorf("invalid YAML configuration: %w", err)
+		}
+
+	default:
+		return types.SimulationConfig{}, nil, fmt.Errorf("unsupported configuration format %s", format)
+	}
+
+	var warnings []string
+	if object, ok := document.(map[string]interface{}); ok {
+		var err error
+		if warnings, err = Migrate(object); err != nil {
+			return types.SimulationConfig{}, nil, err
+		}
+	}
+
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return types.SimulationConfig{}, nil, fmt.Errorf("failed to convert %s configuration: %w", format, err)
+	}
+
+	var config types.SimulationConfig
+	if err := json.Unmarshal(jsonData, &config); err != nil {
+		return types.SimulationConfig{}, nil, fmt.Errorf("invalid %s configuration: %w", format, err)
+	}
+
+	return config, warnings, nil
+}
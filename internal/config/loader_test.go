@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestParseYAML(t *testing.T) {
+	data := []byte(`
+InitialHumans: 8
+ExperienceDistribution:
+  UniversityHire: 60.0
+  MidLevel: 20.0
+  Senior: 15.0
+  Executive: 5.0
+FixedBudget: 1500000.0
+RevenueScenario: 1  # ExplosiveGrowth
+AttritionConfig:
+  Type: 1  # HiringFreeze
+  NaturalRate: 8.0
+`)
+
+	config, _, err := Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if config.InitialHumans != 8 {
+		t.Errorf("Expected 8 initial humans, got %d", config.InitialHumans)
+	}
+	if config.ExperienceDistribution.UniversityHire != 60.0 {
+		t.Errorf("Expected UniversityHire 60, got %.2f", config.ExperienceDistribution.UniversityHire)
+	}
+	if config.RevenueScenario != types.ExplosiveGrowth {
+		t.Errorf("Expected Explosive_Growth, got %s", config.RevenueScenario)
+	}
+	if config.AttritionConfig.Type != types.HiringFreeze {
+		t.Errorf("Expected Hiring_Freeze, got %s", config.AttritionConfig.Type)
+	}
+}
+
+func TestParseEnumNames(t *testing.T) {
+	data := []byte(`
+RevenueScenario: Explosive_Growth
+AttritionConfig:
+  Type: reduction_in_force
+Shocks:
+  - TimeStep: 12
+    Type: AI_Cost_Shock
+    Change: -50
+`)
+
+	config, _, err := Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if config.RevenueScenario != types.ExplosiveGrowth || config.AttritionConfig.Type != types.ReductionInForce {
+		t.Errorf("Expected enums decoded from their names, got %s and %s", config.RevenueScenario, config.AttritionConfig.Type)
+	}
+	if len(config.Shocks) != 1 || config.Shocks[0].Type != types.AICostShock {
+		t.Errorf("Expected an AI_Cost_Shock, got %v", config.Shocks)
+	}
+
+	if _, _, err := Parse([]byte(`{"RevenueScenario": "Sideways"}`), FormatJSON); err == nil {
+		t.Error("Expected error for an unknown revenue scenario")
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	data := []byte(`{"InitialHumans": 12, "FixedBudget": 2000000, "TimeZoneInefficiency": 0.2}`)
+
+	config, _, err := Parse(data, FormatJSON)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if config.InitialHumans != 12 {
+		t.Errorf("Expected 12 initial humans, got %d", config.InitialHumans)
+	}
+	if config.FixedBudget != 2000000 {
+		t.Errorf("Expected budget 2000000, got %.2f", config.FixedBudget)
+	}
+
+	if _, _, err := Parse([]byte(`{"InitialHumans": "many"}`), FormatJSON); err == nil {
+		t.Error("Expected error for mistyped field")
+	}
+}
+
+func TestLoadExampleConfigs(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "examples", "*.yaml"))
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+	paths = append(paths, filepath.Join("..", "..", "example_config.yaml"))
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			config, warnings, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if len(warnings) > 0 {
+				t.Errorf("Expected examples to be at the current version, got warnings %v", warnings)
+			}
+			if config.InitialHumans <= 0 {
+				t.Errorf("Expected positive initial humans, got %d", config.InitialHumans)
+			}
+		})
+	}
+}
+
+func TestDiagnoseRulesScript(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "example_config.yaml"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	for script, valid := range map[string]bool{
+		"def rules(state):\n    freeze_ai_hiring()\n": true,
+		"def rule(state):\n    pass\n":                false,
+	} {
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		document := string(data) + "\nRules:\n  Script: |\n    " + strings.ReplaceAll(strings.TrimSpace(script), "\n", "\n    ") + "\n"
+		if err := os.WriteFile(path, []byte(document), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		diagnostics, _, err := Diagnose(path)
+		if err != nil {
+			t.Fatalf("Diagnose failed: %v", err)
+		}
+		if valid && len(diagnostics) != 0 {
+			t.Errorf("Expected a valid rules script to pass, got %v", diagnostics)
+		}
+		if !valid && (len(diagnostics) != 1 || diagnostics[0].Field != "Rules.Script") {
+			t.Errorf("Expected a Rules.Script diagnostic, got %v", diagnostics)
+		}
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	if format, err := FormatFromPath("scenario.YML"); err != nil || format != FormatYAML {
+		t.Errorf("Expected YAML format, got %s (err %v)", format, err)
+	}
+	if _, err := FormatFromPath("scenario.toml"); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+}
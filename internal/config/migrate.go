@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// migration upgrades a decoded configuration document from one schema version to the next
+// It edits the document in place and returns a warning for every change the user should review
+type migration func(document map[string]interface{}) []string
+
+// migrations holds the migration from each schema version to the next, indexed by the version
+// they upgrade from; it has exactly types.ConfigVersion entries
+var migrations = []migration{
+	migrateUnversioned,
+}
+
+// migrateUnversioned upgrades a configuration written before schema versioning was introduced
+// Every field added since then defaults to off, so the configuration behaves as before
+func migrateUnversioned(document map[string]interface{}) []string {
+	return []string{"configuration has no Version and predates versioning; upgraded to version 1 with no changes"}
+}
+
+// Migrate upgrades a decoded configuration document in place to types.ConfigVersion
+// Documents are migrated before they are decoded into a SimulationConfig, so migrations can
+// still see fields that were renamed or removed. Returns the migration warnings, or a
+// *types.ConfigError if the document was written for a newer version than this simulator supports
+func Migrate(document map[string]interface{}) ([]string, error) {
+	key, version, err := documentVersion(document)
+	if err != nil {
+		return nil, err
+	}
+	if version < 0 {
+		return nil, &types.ConfigError{Field: "Version", Constraint: "must be non-negative", Value: version}
+	}
+	if version > types.ConfigVersion {
+		constraint := fmt.Sprintf("must not be newer than %d, the latest version this simulator supports", types.ConfigVersion)
+		return nil, &types.ConfigError{Field: "Version", Constraint: constraint, Value: version}
+	}
+
+	var warnings []string
+	for ; version < types.ConfigVersion; version++ {
+		warnings = append(warnings, migrations[version](document)...)
+	}
+	document[key] = types.ConfigVersion
+	return warnings, nil
+}
+
+// MigrateJSON upgrades a JSON-encoded configuration to types.ConfigVersion
+// Returns the upgraded JSON and the migration warnings
+func MigrateJSON(data []byte) ([]byte, []string, error) {
+	var document map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON configuration: %w", err)
+	}
+
+	warnings, err := Migrate(document)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrated, err := json.Marshal(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode migrated configuration: %w", err)
+	}
+	return migrated, warnings, nil
+}
+
+// documentVersion returns the key and value of a document's Version field
+// Keys match case-insensitively, as when decoding into a SimulationConfig; a missing
+// Version is version 0
+func documentVersion(document map[string]interface{}) (string, int, error) {
+	for key, value := range document {
+		if !strings.EqualFold(key, "Version") {
+			continue
+		}
+		switch v := value.(type) {
+		case int:
+			return key, v, nil
+		case float64:
+			if v == float64(int(v)) {
+				return key, int(v), nil
+			}
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				return key, int(n), nil
+			}
+		}
+		return "", 0, &types.ConfigError{Field: "Version", Constraint: "must be an integer", Value: value}
+	}
+	return "Version", 0, nil
+}
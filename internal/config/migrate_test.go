@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestMigrationsCoverEveryVersion(t *testing.T) {
+	if len(migrations) != types.ConfigVersion {
+		t.Errorf("Expected one migration per version below %d, got %d", types.ConfigVersion, len(migrations))
+	}
+}
+
+func TestParseMigratesUnversionedConfig(t *testing.T) {
+	config, warnings, err := Parse([]byte("InitialHumans: 8\nFixedBudget: 1500000\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if config.Version != types.ConfigVersion {
+		t.Errorf("Expected the configuration to be upgraded to version %d, got %d", types.ConfigVersion, config.Version)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a warning about the missing version, got %v", warnings)
+	}
+
+	// A configuration at the current version loads without warnings
+	_, warnings, err = Parse([]byte(`{"version": 1, "InitialHumans": 8}`), FormatJSON)
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("Expected a current configuration to load cleanly, got %v, %v", warnings, err)
+	}
+}
+
+func TestParseRejectsUnsupportedVersions(t *testing.T) {
+	for _, data := range []string{
+		`{"Version": 99, "InitialHumans": 8}`,
+		`{"Version": -1, "InitialHumans": 8}`,
+		`{"Version": 1.5, "InitialHumans": 8}`,
+	} {
+		_, _, err := Parse([]byte(data), FormatJSON)
+		var configErr *types.ConfigError
+		if !errors.As(err, &configErr) || configErr.Field != "Version" {
+			t.Errorf("Expected a Version error for %s, got %v", data, err)
+		}
+	}
+}
+
+func TestMigrateJSON(t *testing.T) {
+	migrated, warnings, err := MigrateJSON([]byte(`{"InitialHumans": 8, "FixedBudget": 1500000.5}`))
+	if err != nil {
+		t.Fatalf("MigrateJSON failed: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a warning about the missing version, got %v", warnings)
+	}
+
+	config, warnings, err := Parse(migrated, FormatJSON)
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("Expected the migrated configuration to load cleanly, got %v, %v", warnings, err)
+	}
+	if config.Version != types.ConfigVersion || config.FixedBudget != 1500000.5 {
+		t.Errorf("Unexpected migrated configuration %+v", config)
+	}
+}
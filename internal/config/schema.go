@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing SimulationConfig files
+// The schema is derived from the SimulationConfig struct and the numeric bounds enforced by
+// SimulationConfig.Diagnose, so editors flag the same range errors the simulator rejects.
+// Constraints spanning several fields, such as distributions summing to 100, are not expressed
+func JSONSchema() ([]byte, error) {
+	schema := Schema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "SimulationConfig"
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Schema returns the schema of JSONSchema as a value, without the $schema and title keywords,
+// for embedding in other documents
+func Schema() map[string]interface{} {
+	bounds := make(map[string]types.FieldBounds)
+	for _, b := range types.ConfigFieldBounds() {
+		bounds[b.Field] = b
+	}
+	return schemaFor(reflect.TypeOf(types.SimulationConfig{}), "", bounds)
+}
+
+// stringer is implemented by the integer enum types of the configuration
+var stringer = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// textUnmarshaler is implemented by pointers to the enum types that also decode from their names
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// schemaFor builds the schema of a configuration type; path is the dotted field path used
+// to look up the field's bounds
+func schemaFor(t reflect.Type, path string, bounds map[string]types.FieldBounds) map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			properties[field.Name] = schemaFor(field.Type, fieldPath, bounds)
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+
+	case reflect.Slice:
+		schema["type"] = "array"
+		schema["items"] = schemaFor(t.Elem(), path+"[]", bounds)
+
+	case reflect.Bool:
+		schema["type"] = "boolean"
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema["type"] = "integer"
+		if reflect.PointerTo(t).Implements(textUnmarshaler) {
+			schema["type"] = []string{"string", "integer"}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		schema["type"] = "number"
+
+	case reflect.String:
+		schema["type"] = "string"
+	}
+
+	if b, exists := bounds[path]; exists {
+		if b.ExclusiveMin {
+			schema["exclusiveMinimum"] = b.Min
+		} else if !math.IsInf(b.Min, -1) {
+			schema["minimum"] = b.Min
+		}
+		if !math.IsInf(b.Max, 1) {
+			schema["maximum"] = b.Max
+		}
+
+		// Describe the values of integer enums by name, and list the names and numbers they
+		// can be given as
+		if t.Kind() == reflect.Int && t.Implements(stringer) && !math.IsInf(b.Max, 1) {
+			names := make([]string, 0, int(b.Max)+1)
+			values := make([]interface{}, 0, 2*(int(b.Max)+1))
+			for v := int(b.Min); v <= int(b.Max); v++ {
+				value := reflect.New(t).Elem()
+				value.SetInt(int64(v))
+				name := value.Interface().(fmt.Stringer).String()
+				names = append(names, fmt.Sprintf("%d=%s", v, name))
+				values = append(values, name)
+			}
+			for v := int(b.Min); v <= int(b.Max); v++ {
+				values = append(values, v)
+			}
+			schema["description"] = strings.Join(names, ", ")
+			if reflect.PointerTo(t).Implements(textUnmarshaler) {
+				schema["enum"] = values
+			}
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	if schema["type"] != "object" {
+		t.Errorf("Expected an object schema, got %v", schema["type"])
+	}
+
+	properties := schema["properties"].(map[string]interface{})
+	initialHumans := properties["InitialHumans"].(map[string]interface{})
+	if initialHumans["type"] != "integer" || initialHumans["exclusiveMinimum"] != 0.0 {
+		t.Errorf("Expected InitialHumans to be a positive integer, got %v", initialHumans)
+	}
+
+	attrition := properties["AttritionConfig"].(map[string]interface{})["properties"].(map[string]interface{})
+	naturalRate := attrition["NaturalRate"].(map[string]interface{})
+	if naturalRate["minimum"] != 0.0 || naturalRate["maximum"] != 100.0 {
+		t.Errorf("Expected NaturalRate bounds 0-100, got %v", naturalRate)
+	}
+	attritionType := attrition["Type"].(map[string]interface{})
+	if attritionType["description"] != "0=Natural_Attrition, 1=Hiring_Freeze, 2=Reduction_In_Force" {
+		t.Errorf("Expected attrition type names in the description, got %v", attritionType["description"])
+	}
+	if values, ok := attritionType["enum"].([]interface{}); !ok || len(values) != 6 || values[1] != "Hiring_Freeze" || values[4] != 1.0 {
+		t.Errorf("Expected attrition types by name or number, got %v", attritionType["enum"])
+	}
+
+	regions := properties["Regions"].(map[string]interface{})
+	region := regions["items"].(map[string]interface{})["properties"].(map[string]interface{})
+	if regions["type"] != "array" || region["Share"].(map[string]interface{})["maximum"] != 100.0 {
+		t.Errorf("Expected Regions to be an array of bounded regions, got %v", regions)
+	}
+}
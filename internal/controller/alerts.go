@@ -0,0 +1,56 @@
+package controller
+
+import "workforce-ai-transition-simulator/internal/types"
+
+// GetAlerts returns the alerts raised by the configured alert rules so far, in order
+func (sc *SimulationController) GetAlerts() []types.Alert {
+	return sc.Snapshot().Alerts
+}
+
+// checkAlerts raises the alerts whose rules the state of the current step newly satisfies,
+// recording them in the event log, and returns them for the observers to be notified of
+// A rule fires when its condition holds at this step but did not at the previous one; at the
+// first step of the run there is no previous step, so a rule that holds fires
+func (sc *SimulationController) checkAlerts(state types.SimulationState) []types.Alert {
+	if len(sc.config.Alerts) == 0 || len(sc.timeSeries) < 2 {
+		return nil
+	}
+	initial := sc.timeSeries[0]
+	previous := sc.timeSeries[len(sc.timeSeries)-2]
+	var raised []types.Alert
+	for i, rule := range sc.config.Alerts {
+		threshold := rule.AbsoluteThreshold(initial)
+		if !rule.Holds(state, threshold) {
+			continue
+		}
+		if previous.TimeStep > initial.TimeStep && rule.Holds(previous, threshold) {
+			continue
+		}
+
+		alert := types.Alert{
+			TimeStep:  state.TimeStep,
+			Rule:      i,
+			Metric:    rule.Metric,
+			Value:     rule.Value(state),
+			Threshold: threshold,
+			Message:   rule.Describe(),
+		}
+		sc.alerts = append(sc.alerts, alert)
+		sc.recordEvent(types.AlertEvent, "alert: %s (%s %.4g %s %.4g)", alert.Message, alert.Metric, alert.Value, rule.Comparator, alert.Threshold)
+		raised = append(raised, alert)
+	}
+	return raised
+}
+
+// notifyStep passes the alerts raised at a step, then its recorded state and events, to the
+// observers
+func (sc *SimulationController) notifyStep(state types.SimulationState, alerts []types.Alert, events []types.SimulationEvent) {
+	for _, alert := range alerts {
+		for _, observer := range sc.observers {
+			observer.OnAlert(alert)
+		}
+	}
+	for _, observer := range sc.observers {
+		observer.OnStep(state, events)
+	}
+}
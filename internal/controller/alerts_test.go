@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// recordingObserver records what it is notified of
+type recordingObserver struct {
+	steps  []int
+	events int
+	alerts []types.Alert
+}
+
+func (o *recordingObserver) OnStep(state types.SimulationState, events []types.SimulationEvent) {
+	o.steps = append(o.steps, state.TimeStep)
+	o.events += len(events)
+}
+
+func (o *recordingObserver) OnAlert(alert types.Alert) {
+	o.alerts = append(o.alerts, alert)
+}
+
+func TestAlerts(t *testing.T) {
+	config := benchmarkConfig()
+	config.Alerts = []types.AlertRule{
+		{Metric: "ai_agent_count", Comparator: types.Above, Threshold: 0, Message: "first AI agents hired"},
+		{Metric: "human_count", Comparator: types.Below, Threshold: 30, RelativeToInitial: true},
+		{Metric: "human_count", Comparator: types.AtOrBelow, Threshold: 100, RelativeToInitial: true},
+	}
+	observer := &recordingObserver{}
+	sc := NewSimulationController(config, 42, WithObserver(observer))
+	result, err := sc.RunUntilEquilibrium(10)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	if len(result.Alerts) != 2 {
+		t.Fatalf("Expected each holding rule to fire once, got %+v", result.Alerts)
+	}
+	first, second := result.Alerts[0], result.Alerts[1]
+	if first.TimeStep != 1 || first.Rule != 0 || first.Message != "first AI agents hired" || first.Value <= 0 {
+		t.Errorf("Unexpected first alert %+v", first)
+	}
+	if second.Rule != 2 || second.Threshold != float64(config.InitialHumans) || second.Message != "human_count <= 100% of initial" {
+		t.Errorf("Expected the relative threshold to be the initial human count, got %+v", second)
+	}
+
+	if len(observer.alerts) != 2 || observer.alerts[0] != first {
+		t.Errorf("Expected the observer to be notified of the alerts, got %+v", observer.alerts)
+	}
+	if len(observer.steps) != len(result.TimeSeries)-1 || observer.steps[0] != 1 {
+		t.Errorf("Expected the observer to be notified of every step, got %v", observer.steps)
+	}
+	if observer.events != len(result.Events) {
+		t.Errorf("Expected the observer to see all %d events, got %d", len(result.Events), observer.events)
+	}
+	alertEvents := 0
+	for _, event := range result.Events {
+		if event.Type == types.AlertEvent {
+			alertEvents++
+		}
+	}
+	if alertEvents != 2 {
+		t.Errorf("Expected the alerts to be recorded as events, got %d", alertEvents)
+	}
+
+	checkpoint, err := sc.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	restored, err := RestoreSimulationController(checkpoint, config)
+	if err != nil {
+		t.Fatalf("RestoreSimulationController failed: %v", err)
+	}
+	restored.Step()
+	if len(restored.GetAlerts()) != 2 {
+		t.Errorf("Expected restored alerts to be kept and holding rules not to fire again, got %+v", restored.GetAlerts())
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Behaviors replaces parts of the simulation logic with custom functions, such as those an
+// extension plugin provides; nil functions keep the built-in logic
+// Analyses running simulations in parallel share the functions between runs, so they must be
+// safe for concurrent use
+type Behaviors struct {
+	// Attrition returns the IDs of the humans who leave at a time step, in place of the
+	// configured attrition model. IDs of humans not in the workforce are ignored
+	Attrition func(humans []*types.HumanWorker, timeStep int) []string
+
+	// Revenue returns the revenue of a time step from the workforce's productivity and the
+	// revenue the configured revenue scenario produces for it
+	Revenue func(productivity float64, timeStep int, revenue float64) float64
+
+	// Optimize proposes the AI agents to hire and release at a time step in place of the
+	// optimizer. A workforce policy, when set, still takes precedence
+	Optimize func(humans []*types.HumanWorker, agents []*types.AIAgent, availableBudget float64, availableCapacity int) events.WorkforceChange
+}
+
+// WithBehaviors replaces the simulation logic behaviors sets, keeping behaviors set by earlier
+// options where it leaves a function nil
+func WithBehaviors(behaviors Behaviors) Option {
+	return func(sc *SimulationController) {
+		if behaviors.Attrition != nil {
+			sc.behaviors.Attrition = behaviors.Attrition
+		}
+		if behaviors.Revenue != nil {
+			sc.behaviors.Revenue = behaviors.Revenue
+		}
+		if behaviors.Optimize != nil {
+			sc.behaviors.Optimize = behaviors.Optimize
+		}
+	}
+}
+
+// attrition returns the IDs of the humans who leave at the current time step
+func (sc *SimulationController) attrition(humans []*types.HumanWorker) []string {
+	if sc.behaviors.Attrition == nil {
+		return sc.eventProcessor.ProcessAttrition(humans, sc.currentTimeStep)
+	}
+	present := make(map[string]bool, len(humans))
+	for _, human := range humans {
+		present[human.ID] = true
+	}
+	leaving := make([]string, 0)
+	for _, id := range sc.behaviors.Attrition(humans, sc.currentTimeStep) {
+		if present[id] {
+			leaving = append(leaving, id)
+			delete(present, id)
+		}
+	}
+	return leaving
+}
+
+// revenue returns the revenue of the current time step for a productivity
+func (sc *SimulationController) revenue(productivity float64) float64 {
+	revenue := sc.economicModel.CalculateRevenue(productivity, sc.currentTimeStep)
+	if sc.behaviors.Revenue != nil {
+		revenue = sc.behaviors.Revenue(productivity, sc.currentTimeStep, revenue)
+	}
+	return revenue
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestWithBehaviors(t *testing.T) {
+	config := benchmarkConfig()
+	baseline := NewSimulationController(config, 42)
+	if err := baseline.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	expected := baseline.Step()
+
+	leaving := make([]int, 0)
+	sc := NewSimulationController(config, 42,
+		WithBehaviors(Behaviors{Revenue: func(productivity float64, timeStep int, revenue float64) float64 { return revenue / 2 }}),
+		WithBehaviors(Behaviors{
+			Attrition: func(humans []*types.HumanWorker, timeStep int) []string {
+				leaving = append(leaving, timeStep)
+				return []string{"no-such-human", humans[len(humans)-1].ID, humans[len(humans)-1].ID}
+			},
+			Optimize: func(humans []*types.HumanWorker, agents []*types.AIAgent, availableBudget float64, availableCapacity int) events.WorkforceChange {
+				return events.WorkforceChange{}
+			},
+		}),
+	)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	state := sc.Step()
+
+	if len(leaving) != 1 || leaving[0] != 1 {
+		t.Errorf("Expected the custom attrition to run once at step 1, ran at %v", leaving)
+	}
+	if state.HumansLostThisStep != 1 {
+		t.Errorf("Expected one human to leave, ignoring unknown and repeated IDs, got %d", state.HumansLostThisStep)
+	}
+	if state.AgentsHiredThisStep != 0 {
+		t.Errorf("Expected the custom optimizer to hire nobody, got %d hires", state.AgentsHiredThisStep)
+	}
+	if expected.AgentsHiredThisStep == 0 {
+		t.Fatal("Expected the built-in optimizer to hire AI agents")
+	}
+	if state.RevenueOutput >= expected.RevenueOutput {
+		t.Errorf("Expected the earlier option's revenue behavior to be kept and halve revenue, got %f against %f", state.RevenueOutput, expected.RevenueOutput)
+	}
+}
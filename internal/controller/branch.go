@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// RunID returns the identifier of the current run, which its result's metadata carries
+// It is generated on first use and stays the same until the simulation is initialized or reset
+func (sc *SimulationController) RunID() string {
+	if sc.runID == "" {
+		sc.runID = types.NewRunID()
+	}
+	return sc.runID
+}
+
+// RunMetadata returns the metadata the result of the current run will carry, e.g. to label
+// output streamed while the run is under way
+// The start time of the run is taken on first use, unless RunUntilEquilibrium started it first,
+// and stays the same until the simulation is initialized or reset
+func (sc *SimulationController) RunMetadata() types.RunMetadata {
+	if sc.startedAt.IsZero() {
+		sc.startedAt = time.Now()
+	}
+	metadata := types.NewRunMetadata(sc.config, sc.streams.MasterSeed(), sc.startedAt)
+	metadata.RunID = sc.RunID()
+	metadata.Lineage = sc.lineage
+	return metadata
+}
+
+// Lineage returns the run this one was branched from, or nil if it is not a branch
+func (sc *SimulationController) Lineage() *types.RunLineage {
+	return sc.lineage
+}
+
+// BranchAt starts a what-if run from a recorded time step of this run
+// The full state at the step is reconstructed by replaying the run with the same configuration
+// and seed from its start, or from the checkpoint it was restored or warm-started from. modify,
+// if not nil, then changes the configuration, and the branch continues from the step as a new
+// run: it keeps the history up to the step, detects equilibrium afresh and records this run as
+// its parent in the metadata of its result. The parent run is left unchanged
+// Returns an error if the step was not recorded by this run, or if the replay does not reproduce
+// the recorded history, as happens after Reset or with injected components
+func (sc *SimulationController) BranchAt(step int, modify func(config *types.SimulationConfig)) (*SimulationController, error) {
+	if len(sc.timeSeries) == 0 {
+		return nil, errors.New("cannot branch a simulation that has not been initialized")
+	}
+	first := sc.timeSeries[0].TimeStep
+	if step < first || step > sc.currentTimeStep {
+		return nil, fmt.Errorf("cannot branch at time step %d: the run recorded steps %d to %d", step, first, sc.currentTimeStep)
+	}
+	if sc.injectedWorkforceManager || sc.injectedEconomicModel {
+		return nil, errors.New("cannot branch a run with injected components, which cannot be replayed")
+	}
+
+	replay, err := sc.replay()
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay the run: %w", err)
+	}
+	for replay.currentTimeStep < step {
+		replay.Step()
+	}
+	if !sameOutcome(replay.timeSeries[len(replay.timeSeries)-1], sc.timeSeries[step-first]) {
+		return nil, fmt.Errorf("cannot branch at time step %d: replaying the run does not reproduce its recorded history", step)
+	}
+
+	checkpoint, err := replay.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	config := checkpoint.Config
+	if modify != nil {
+		modify(&config)
+	}
+	branch, err := RestoreSimulationController(checkpoint, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start branch: %w", err)
+	}
+	branch.equilibriumReached = false
+	branch.lineage = &types.RunLineage{
+		ParentRunID:      sc.RunID(),
+		ParentConfigHash: sc.config.Hash(),
+		BranchStep:       step,
+	}
+	branch.publish()
+	return branch, nil
+}
+
+// replay returns a controller at the start of this run, from which stepping repeats it
+func (sc *SimulationController) replay() (*SimulationController, error) {
+	if sc.origin == nil {
+		replay := NewSimulationController(sc.config, sc.streams.MasterSeed())
+		if err := replay.Initialize(); err != nil {
+			return nil, err
+		}
+		return replay, nil
+	}
+	if sc.originWarmStart {
+		return WarmStartSimulationController(*sc.origin, sc.config)
+	}
+	return RestoreSimulationController(*sc.origin, sc.config)
+}
+
+// sameOutcome reports whether two recorded states of a time step agree on the step's workforce,
+// cost and revenue
+func sameOutcome(a types.SimulationState, b types.SimulationState) bool {
+	return a.TimeStep == b.TimeStep &&
+		a.Workforce.Humans.Total == b.Workforce.Humans.Total &&
+		a.Workforce.AIAgents.Total == b.Workforce.AIAgents.Total &&
+		a.TotalCost == b.TotalCost &&
+		a.RevenueOutput == b.RevenueOutput
+}
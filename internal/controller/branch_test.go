@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestBranchAtReproducesUnmodifiedRun(t *testing.T) {
+	parent := NewSimulationController(benchmarkConfig(), 12345)
+	parentResult, err := parent.RunUntilEquilibrium(200)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	branch, err := parent.BranchAt(10, nil)
+	if err != nil {
+		t.Fatalf("BranchAt failed: %v", err)
+	}
+	if branch.GetCurrentTimeStep() != 10 || len(branch.GetTimeSeries()) != 11 {
+		t.Fatalf("Expected the branch to continue from step 10 with its history, got step %d with %d states", branch.GetCurrentTimeStep(), len(branch.GetTimeSeries()))
+	}
+	branchResult, err := branch.RunUntilEquilibrium(200)
+	if err != nil {
+		t.Fatalf("Branch RunUntilEquilibrium failed: %v", err)
+	}
+
+	if branchResult.TimeToEquilibrium != parentResult.TimeToEquilibrium || !reflect.DeepEqual(branchResult.EquilibriumState, parentResult.EquilibriumState) {
+		t.Errorf("Expected an unmodified branch to end like its parent at step %d, got step %d", parentResult.TimeToEquilibrium, branchResult.TimeToEquilibrium)
+	}
+	lineage := branchResult.Metadata.Lineage
+	if lineage == nil || lineage.ParentRunID != parentResult.Metadata.RunID || lineage.BranchStep != 10 || lineage.ParentConfigHash != parentResult.Metadata.ConfigHash {
+		t.Errorf("Expected the branch to record its parent run %s at step 10, got %+v", parentResult.Metadata.RunID, lineage)
+	}
+	if branchResult.Metadata.RunID == parentResult.Metadata.RunID || parentResult.Metadata.Lineage != nil {
+		t.Error("Expected the branch to be a new run and the parent to have no lineage")
+	}
+}
+
+func TestBranchAtAppliesModifications(t *testing.T) {
+	parent := NewSimulationController(benchmarkConfig(), 12345)
+	if err := parent.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		parent.Step()
+	}
+	recorded := append([]types.SimulationState(nil), parent.GetTimeSeries()...)
+
+	branch, err := parent.BranchAt(5, func(config *types.SimulationConfig) {
+		config.AttritionConfig.NaturalRate = 0
+		config.AttritionConfig.ForcedAcceleration = 0
+	})
+	if err != nil {
+		t.Fatalf("BranchAt failed: %v", err)
+	}
+	if branch.GetConfig().AttritionConfig.NaturalRate != 0 || parent.GetConfig().AttritionConfig.NaturalRate == 0 {
+		t.Error("Expected only the branch to run without attrition")
+	}
+	for i := 0; i < 15; i++ {
+		branch.Step()
+	}
+	if kept, lost := branch.GetTimeSeries()[20].Workforce.Humans.Total, recorded[20].Workforce.Humans.Total; kept != recorded[5].Workforce.Humans.Total || kept <= lost {
+		t.Errorf("Expected the branch to keep the %d humans of step 5, got %d against %d in the parent", recorded[5].Workforce.Humans.Total, kept, lost)
+	}
+	if !reflect.DeepEqual(parent.GetTimeSeries(), recorded) {
+		t.Error("Expected branching to leave the parent run unchanged")
+	}
+
+	for _, step := range []int{-1, 21} {
+		if _, err := parent.BranchAt(step, nil); err == nil {
+			t.Errorf("Expected branching at unrecorded step %d to fail", step)
+		}
+	}
+}
+
+func TestBranchAtFromWarmStart(t *testing.T) {
+	base := NewSimulationController(benchmarkConfig(), 12345)
+	if _, err := base.RunUntilEquilibrium(200); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	checkpoint, err := base.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate *= 2
+	warm, err := WarmStartSimulationController(checkpoint, config)
+	if err != nil {
+		t.Fatalf("WarmStartSimulationController failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		warm.Step()
+	}
+
+	branch, err := warm.BranchAt(checkpoint.CurrentTimeStep+4, nil)
+	if err != nil {
+		t.Fatalf("BranchAt failed: %v", err)
+	}
+	branch.Step()
+	if got, want := branch.GetTimeSeries()[len(branch.GetTimeSeries())-1], warm.GetTimeSeries()[5]; !sameOutcome(got, want) {
+		t.Errorf("Expected the branch of a warm-started run to continue it, got %+v, want %+v", got.Workforce, want.Workforce)
+	}
+	if _, err := warm.BranchAt(checkpoint.CurrentTimeStep-1, nil); err == nil {
+		t.Error("Expected branching before the warm start to fail")
+	}
+}
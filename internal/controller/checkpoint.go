@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+	"workforce-ai-transition-simulator/internal/workforce"
+)
+
+// Checkpoint is a complete, serializable copy of a simulation's state
+// Restoring a checkpoint with the same configuration continues the run exactly as if
+// it had never been interrupted, including the random sequences of every stream
+type Checkpoint struct {
+	Config                    types.SimulationConfig
+	Seed                      int64
+	CurrentTimeStep           int
+	StartTimeStep             int
+	TimeSeries                []types.SimulationState
+	TotalCatastrophicFailures int
+	EquilibriumReached        bool
+	Events                    []types.SimulationEvent
+	Workforce                 workforce.Snapshot
+	RevenueHistory            []float64
+	RandomStates              map[string]uint64
+	PendingBackfills          []types.BackfillRequest
+	Recoveries                []types.FailureRecovery
+	Failures                  []types.FailureRecord
+	Decisions                 []types.OptimizerDecision
+	RuleActions               events.RuleActions // actions the rules took at the current time step
+	Alerts                    []types.Alert
+	Burnout                   float64
+	CashBalance               float64
+	Insolvent                 bool
+	TerminalReason            types.EquilibriumReason
+	Debt                      float64
+}
+
+// Checkpoint captures the controller's current state
+// Returns an error if the simulation has not been initialized yet
+func (sc *SimulationController) Checkpoint() (Checkpoint, error) {
+	if len(sc.timeSeries) == 0 {
+		return Checkpoint{}, errors.New("cannot checkpoint a simulation that has not been initialized")
+	}
+
+	return Checkpoint{
+		Config:                    sc.config,
+		Seed:                      sc.streams.MasterSeed(),
+		CurrentTimeStep:           sc.currentTimeStep,
+		StartTimeStep:             sc.startTimeStep,
+		TimeSeries:                append([]types.SimulationState(nil), sc.timeSeries...),
+		TotalCatastrophicFailures: sc.totalCatastrophicFailures,
+		EquilibriumReached:        sc.equilibriumReached,
+		Events:                    append([]types.SimulationEvent(nil), sc.eventLog...),
+		Workforce:                 sc.workforceManager.Snapshot(),
+		RevenueHistory:            append([]float64(nil), sc.economicModel.GetRevenueHistory()...),
+		RandomStates:              sc.streams.States(),
+		PendingBackfills:          append([]types.BackfillRequest(nil), sc.pendingBackfills...),
+		Recoveries:                append([]types.FailureRecovery(nil), sc.recoveries...),
+		Failures:                  append([]types.FailureRecord(nil), sc.failures...),
+		Decisions:                 append([]types.OptimizerDecision(nil), sc.decisions...),
+		RuleActions:               sc.ruleActions,
+		Alerts:                    append([]types.Alert(nil), sc.alerts...),
+		Burnout:                   sc.burnout,
+		CashBalance:               sc.cashBalance,
+		Insolvent:                 sc.insolvent,
+		TerminalReason:            sc.terminalReason,
+		Debt:                      sc.economicModel.GetDebt(),
+	}, nil
+}
+
+// RestoreSimulationController recreates a controller from a checkpoint
+// config replaces the checkpointed configuration, so parameters can be changed before the
+// run continues; pass checkpoint.Config to resume unchanged. Parameters that only shape the
+// initial workforce (InitialHumans and the distributions) have no effect on a restored run
+// Options are not checkpointed, so any the run was created with have to be passed again
+func RestoreSimulationController(checkpoint Checkpoint, config types.SimulationConfig, opts ...Option) (*SimulationController, error) {
+	if len(checkpoint.TimeSeries) == 0 {
+		return nil, errors.New("checkpoint has no recorded time series")
+	}
+
+	workforceManager, err := workforce.RestoreWorkforceManager(checkpoint.Workforce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore workforce: %w", err)
+	}
+
+	sc := NewSimulationController(config, checkpoint.Seed, opts...)
+	if err := sc.validateConfiguration(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Components hold no random state of their own, so restoring the streams after
+	// the event processor is created is enough to resume its random sequences
+	sc.streams.Restore(checkpoint.RandomStates)
+	sc.workforceManager = workforceManager
+	sc.economicModel.RestoreRevenueHistory(checkpoint.RevenueHistory)
+	sc.economicModel.RestoreDebt(checkpoint.Debt)
+
+	sc.currentTimeStep = checkpoint.CurrentTimeStep
+	sc.startTimeStep = checkpoint.StartTimeStep
+	sc.timeSeries = append(make([]types.SimulationState, 0, len(checkpoint.TimeSeries)), checkpoint.TimeSeries...)
+	sc.totalCatastrophicFailures = checkpoint.TotalCatastrophicFailures
+	sc.equilibriumReached = checkpoint.EquilibriumReached
+	sc.eventLog = append(make([]types.SimulationEvent, 0, len(checkpoint.Events)), checkpoint.Events...)
+	sc.pendingBackfills = append([]types.BackfillRequest(nil), checkpoint.PendingBackfills...)
+	sc.recoveries = append([]types.FailureRecovery(nil), checkpoint.Recoveries...)
+	sc.failures = append([]types.FailureRecord(nil), checkpoint.Failures...)
+	sc.decisions = append([]types.OptimizerDecision(nil), checkpoint.Decisions...)
+	sc.ruleActions = checkpoint.RuleActions
+	sc.alerts = append([]types.Alert(nil), checkpoint.Alerts...)
+	sc.setBurnout(checkpoint.Burnout)
+	sc.cashBalance = checkpoint.CashBalance
+	sc.insolvent = checkpoint.Insolvent
+	sc.terminalReason = checkpoint.TerminalReason
+	sc.setMarketConditions(config.MarketConditions(sc.currentTimeStep))
+	sc.origin = &checkpoint
+	sc.publish()
+
+	return sc, nil
+}
+
+// WarmStartSimulationController starts a new run from a checkpointed organization
+// Unlike RestoreSimulationController the history is discarded: the run starts at the
+// checkpoint's time step with the restored workforce as its initial state, so
+// TimeToEquilibrium measures how long the organization takes to settle after the
+// configuration change rather than from a cold start. The cash balance starts again from
+// the configured initial balance
+func WarmStartSimulationController(checkpoint Checkpoint, config types.SimulationConfig, opts ...Option) (*SimulationController, error) {
+	sc, err := RestoreSimulationController(checkpoint, config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.startTimeStep = sc.currentTimeStep
+	sc.totalCatastrophicFailures = 0
+	sc.failures = nil
+	sc.decisions = nil
+	sc.ruleActions = events.RuleActions{}
+	sc.alerts = nil
+	sc.equilibriumReached = false
+	sc.eventLog = make([]types.SimulationEvent, 0)
+	sc.cashBalance = sc.initialCashBalance()
+	sc.insolvent = false
+	sc.terminalReason = types.EquilibriumReason{}
+	sc.stepPenalties = 0
+	sc.stepSeverance = 0
+	sc.stepRecruiting = 0
+	sc.stepRemediation = 0
+	sc.originWarmStart = true
+
+	// Record the restored organization, evaluated under the new configuration, as the initial state
+	sc.timeSeries = []types.SimulationState{sc.captureCurrentState()}
+	sc.publish()
+
+	return sc, nil
+}
+
+// WriteCheckpoint writes a checkpoint as JSON
+// The configuration is stamped with the current schema version so it can be migrated if the
+// checkpoint is read by a later version of the simulator
+func WriteCheckpoint(checkpoint Checkpoint, writer io.Writer) error {
+	checkpoint.Config.Version = types.ConfigVersion
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(checkpoint); err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ReadCheckpoint reads a checkpoint previously written by WriteCheckpoint
+// A configuration written for an older schema version is upgraded, and the returned warnings
+// describe what the upgrade changed
+func ReadCheckpoint(reader io.Reader) (Checkpoint, []string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return Checkpoint{}, nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var envelope struct {
+		Config json.RawMessage
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return Checkpoint{}, nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	var warnings []string
+	if len(envelope.Config) > 0 {
+		migrated, configWarnings, err := config.MigrateJSON(envelope.Config)
+		if err != nil {
+			return Checkpoint{}, nil, fmt.Errorf("failed to migrate checkpoint configuration: %w", err)
+		}
+		envelope.Config = migrated
+		warnings = configWarnings
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return Checkpoint{}, nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	if len(envelope.Config) > 0 {
+		checkpoint.Config = types.SimulationConfig{}
+		if err := json.Unmarshal(envelope.Config, &checkpoint.Config); err != nil {
+			return Checkpoint{}, nil, fmt.Errorf("failed to decode checkpoint configuration: %w", err)
+		}
+	}
+	return checkpoint, warnings, nil
+}
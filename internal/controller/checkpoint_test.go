@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestCheckpointResumeMatchesUninterruptedRun(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 0.1
+	config.AttritionConfig.NaturalRate = 40.0
+	config.Backfill = types.BackfillConfig{Fraction: 0.5, LagSteps: 3, RecruitingCost: 10000}
+
+	uninterrupted := NewSimulationController(config, 7)
+	if err := uninterrupted.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 40; i++ {
+		uninterrupted.Step()
+	}
+
+	interrupted := NewSimulationController(config, 7)
+	if err := interrupted.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		interrupted.Step()
+	}
+
+	checkpoint, err := interrupted.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	// Round-trip through JSON so the test also covers persistence
+	var buf bytes.Buffer
+	if err := WriteCheckpoint(checkpoint, &buf); err != nil {
+		t.Fatalf("WriteCheckpoint failed: %v", err)
+	}
+	loaded, warnings, err := ReadCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("ReadCheckpoint failed: %v", err)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("Expected a current checkpoint to read without warnings, got %v", warnings)
+	}
+
+	resumed, err := RestoreSimulationController(loaded, loaded.Config)
+	if err != nil {
+		t.Fatalf("RestoreSimulationController failed: %v", err)
+	}
+	if resumed.GetCurrentTimeStep() != 20 {
+		t.Fatalf("Expected resumed time step 20, got %d", resumed.GetCurrentTimeStep())
+	}
+	for i := 0; i < 20; i++ {
+		resumed.Step()
+	}
+
+	want := uninterrupted.GetTimeSeries()[21:]
+	got := resumed.GetTimeSeries()[21:]
+	if !reflect.DeepEqual(want, got) {
+		t.Fatal("Expected the resumed run to match the uninterrupted run")
+	}
+	if !reflect.DeepEqual(uninterrupted.GetEvents(), resumed.GetEvents()) {
+		t.Error("Expected the resumed event log to match the uninterrupted run")
+	}
+}
+
+func TestReadCheckpointMigratesUnversionedConfig(t *testing.T) {
+	sc := NewSimulationController(benchmarkConfig(), 3)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	checkpoint, err := sc.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	// Strip the version, as in checkpoints written before configurations were versioned
+	var buf bytes.Buffer
+	if err := WriteCheckpoint(checkpoint, &buf); err != nil {
+		t.Fatalf("WriteCheckpoint failed: %v", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("failed to decode checkpoint: %v", err)
+	}
+	delete(document["Config"].(map[string]interface{}), "Version")
+	legacy, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("failed to encode checkpoint: %v", err)
+	}
+
+	loaded, warnings, err := ReadCheckpoint(bytes.NewReader(legacy))
+	if err != nil {
+		t.Fatalf("ReadCheckpoint failed: %v", err)
+	}
+	if len(warnings) != 1 || loaded.Config.Version != types.ConfigVersion {
+		t.Errorf("Expected the configuration to be upgraded with a warning, got version %d and %v", loaded.Config.Version, warnings)
+	}
+	if loaded.Config.InitialHumans != checkpoint.Config.InitialHumans || len(loaded.TimeSeries) != len(checkpoint.TimeSeries) {
+		t.Error("Expected the rest of the checkpoint to be read unchanged")
+	}
+}
+
+func TestCheckpointRequiresInitialization(t *testing.T) {
+	if _, err := NewSimulationController(benchmarkConfig(), 1).Checkpoint(); err == nil {
+		t.Error("Expected an error when checkpointing an uninitialized simulation")
+	}
+}
+
+func TestWarmStartFromEquilibrium(t *testing.T) {
+	config := benchmarkConfig()
+
+	baseline := NewSimulationController(config, 12345)
+	baselineResult, err := baseline.RunUntilEquilibrium(500)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if !baselineResult.ReachedEquilibrium {
+		t.Fatal("Expected the baseline to reach equilibrium")
+	}
+
+	checkpoint, err := baseline.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	perturbed := config
+	perturbed.FixedBudget = config.FixedBudget * 1.5
+	warm, err := WarmStartSimulationController(checkpoint, perturbed)
+	if err != nil {
+		t.Fatalf("WarmStartSimulationController failed: %v", err)
+	}
+
+	initial := warm.GetTimeSeries()
+	if len(initial) != 1 {
+		t.Fatalf("Expected the warm-started run to begin with a single state, got %d", len(initial))
+	}
+	if initial[0].Workforce.Humans.Total != baselineResult.EquilibriumState.Workforce.Humans.Total ||
+		initial[0].Workforce.AIAgents.Total != baselineResult.EquilibriumState.Workforce.AIAgents.Total {
+		t.Error("Expected the warm-started run to begin with the baseline equilibrium workforce")
+	}
+	if warm.IsEquilibriumReached() {
+		t.Error("Expected the warm-started run not to start in equilibrium")
+	}
+
+	result, err := warm.RunUntilEquilibrium(50)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.TimeToEquilibrium <= 0 || result.TimeToEquilibrium > 50 {
+		t.Errorf("Expected time to equilibrium relative to the warm start within 1-50, got %d", result.TimeToEquilibrium)
+	}
+	if got := len(result.TimeSeries); got != result.TimeToEquilibrium+1 {
+		t.Errorf("Expected %d recorded states, got %d", result.TimeToEquilibrium+1, got)
+	}
+
+	// The checkpoint itself must be unaffected so it can seed further runs
+	if checkpoint.CurrentTimeStep != baselineResult.TimeToEquilibrium || len(checkpoint.TimeSeries) != len(baselineResult.TimeSeries) {
+		t.Error("Expected the checkpoint to be left unchanged by a warm-started run")
+	}
+}
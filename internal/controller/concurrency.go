@@ -0,0 +1,66 @@
+package controller
+
+import "workforce-ai-transition-simulator/internal/types"
+
+// Snapshot is the state of a run as of the latest operation its controller completed, such as a
+// time step, for readers on other goroutines
+// Its slices are views of the run's history that later steps never change, so a snapshot may be
+// kept and read while the run goes on; they must not be modified
+type Snapshot struct {
+	TimeStep                  int
+	TimeSeries                []types.SimulationState
+	Events                    []types.SimulationEvent
+	Alerts                    []types.Alert
+	Decisions                 []types.OptimizerDecision
+	TotalCatastrophicFailures int
+	EquilibriumReached        bool
+	Insolvent                 bool
+	TerminalReason            types.EquilibriumReason // code EquilibriumNotReached unless the run ended in a terminal state
+}
+
+// Terminated returns whether the run had ended in a terminal state
+func (s Snapshot) Terminated() bool {
+	return s.TerminalReason.Code != types.EquilibriumNotReached
+}
+
+// Latest returns the latest recorded state, and false if the run had not been initialized
+func (s Snapshot) Latest() (types.SimulationState, bool) {
+	if len(s.TimeSeries) == 0 {
+		return types.SimulationState{}, false
+	}
+	return s.TimeSeries[len(s.TimeSeries)-1], true
+}
+
+// Snapshot returns the state of the run as of the latest operation the controller completed
+// It is safe to call from any goroutine, including observers, while the run is stepped
+func (sc *SimulationController) Snapshot() Snapshot {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.published
+}
+
+// publish makes the state of the run after an operation visible to readers
+// The writer calls it at the end of every exported operation that changes the run, never in the
+// middle of one, so readers only ever see the run between operations. The history is only ever
+// appended to, and a capped view of it does not see later appends, so the views need not be copied
+func (sc *SimulationController) publish() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.published = Snapshot{
+		TimeStep:                  sc.currentTimeStep,
+		TimeSeries:                capped(sc.timeSeries),
+		Events:                    capped(sc.eventLog),
+		Alerts:                    capped(sc.alerts),
+		Decisions:                 capped(sc.decisions),
+		TotalCatastrophicFailures: sc.totalCatastrophicFailures,
+		EquilibriumReached:        sc.equilibriumReached,
+		Insolvent:                 sc.insolvent,
+		TerminalReason:            sc.terminalReason,
+	}
+}
+
+// capped returns a view of s whose capacity is its length, so appending to s never writes
+// within it
+func capped[T any](s []T) []T {
+	return s[:len(s):len(s)]
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// stepObserver checks that observers, notified on the writer's goroutine, see the step they are
+// notified of through the controller's accessors
+type stepObserver struct {
+	t  *testing.T
+	sc *SimulationController
+}
+
+func (o stepObserver) OnStep(state types.SimulationState, _ []types.SimulationEvent) {
+	if step := o.sc.GetCurrentTimeStep(); step != state.TimeStep {
+		o.t.Errorf("Observer of time step %d read time step %d", state.TimeStep, step)
+	}
+}
+
+func (o stepObserver) OnAlert(types.Alert) {}
+
+// TestConcurrentReaders steps a run while other goroutines read it; run with -race
+func TestConcurrentReaders(t *testing.T) {
+	config := benchmarkConfig()
+	config.Alerts = []types.AlertRule{{Metric: "ai_agent_count", Comparator: types.Above}, {Metric: "catastrophic_failures", Comparator: types.Above}}
+	sc := NewSimulationController(config, 12345)
+	sc.Observe(stepObserver{t: t, sc: sc})
+
+	var done atomic.Bool
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			seen := 0
+			for !done.Load() {
+				snapshot := sc.Snapshot()
+				latest, ok := snapshot.Latest()
+				if !ok {
+					continue
+				}
+				if latest.TimeStep != snapshot.TimeStep || len(snapshot.TimeSeries) != snapshot.TimeStep+1 {
+					t.Errorf("Inconsistent snapshot: time step %d with %d states ending at %d", snapshot.TimeStep, len(snapshot.TimeSeries), latest.TimeStep)
+					return
+				}
+				if snapshot.TimeStep < seen {
+					t.Errorf("Time step went back from %d to %d", seen, snapshot.TimeStep)
+					return
+				}
+				seen = snapshot.TimeStep
+				for _, state := range snapshot.TimeSeries {
+					_ = state.Workforce.Humans.Total
+				}
+				for _, event := range snapshot.Events {
+					_ = event.Description
+				}
+				_ = sc.GetCurrentTimeStep() + len(sc.GetTimeSeries()) + len(sc.GetEvents()) + sc.GetTotalCatastrophicFailures()
+				_ = len(sc.GetAlerts()) + len(sc.GetDecisions())
+				_ = sc.IsEquilibriumReached() || sc.IsInsolvent() || sc.IsTerminated() || sc.IsAborted()
+				sc.SetPacing(0)
+			}
+		}()
+	}
+
+	result, err := sc.RunUntilEquilibrium(200)
+	done.Store(true)
+	readers.Wait()
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if got := len(sc.GetTimeSeries()); got != len(result.TimeSeries) {
+		t.Errorf("Expected the final snapshot to hold the %d states of the result, got %d", len(result.TimeSeries), got)
+	}
+}
+
+func TestSnapshotUnchangedByLaterSteps(t *testing.T) {
+	sc := NewSimulationController(benchmarkConfig(), 12345)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		sc.Step()
+	}
+	snapshot := sc.Snapshot()
+	latest, _ := snapshot.Latest()
+	events := len(snapshot.Events)
+
+	for i := 0; i < 10; i++ {
+		sc.Step()
+	}
+	if len(snapshot.TimeSeries) != 4 || len(snapshot.Events) != events {
+		t.Fatalf("Expected the snapshot to keep 4 states and %d events, got %d and %d", events, len(snapshot.TimeSeries), len(snapshot.Events))
+	}
+	if after, _ := snapshot.Latest(); after.TimeStep != latest.TimeStep || after.TotalCost != latest.TotalCost {
+		t.Errorf("Expected the snapshot's latest state to stay at time step %d, got %d", latest.TimeStep, after.TimeStep)
+	}
+	if sc.GetCurrentTimeStep() != 13 || len(sc.GetTimeSeries()) != 14 {
+		t.Errorf("Expected the controller to be at time step 13, got %d", sc.GetCurrentTimeStep())
+	}
+}
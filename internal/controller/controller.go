@@ -1,60 +1,179 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"math"
+	"sync"
+	"time"
 	"workforce-ai-transition-simulator/internal/economic"
 	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/random"
 	"workforce-ai-transition-simulator/internal/types"
 	"workforce-ai-transition-simulator/internal/workforce"
 )
 
 // SimulationController coordinates WorkforceManager, EconomicModel, and EventProcessor
 // and tracks simulation state throughout the execution
+//
+// A controller has a single writer, the goroutine that initializes, steps, resets or otherwise
+// changes the run, and any number of readers on other goroutines, such as a server streaming the
+// run's progress. Readers see the run as of the latest operation the writer completed, through
+// Snapshot and the accessors reading it: GetCurrentTimeStep, GetTimeSeries, GetEvents, GetAlerts,
+// GetDecisions, GetTotalCatastrophicFailures, IsEquilibrium, IsEquilibriumReached, IsInsolvent,
+// IsTerminated and IsAborted. GetConfig and SetPacing are also safe to call from any goroutine;
+// every other method belongs to the writer
 type SimulationController struct {
 	config           types.SimulationConfig
-	workforceManager *workforce.WorkforceManager
-	economicModel    *economic.EconomicModel
-	eventProcessor   *events.EventProcessor
+	workforceManager WorkforceManager
+	economicModel    EconomicModel
+	eventProcessor   EventProcessor
+	
+	// Components supplied through options, which Reset keeps rather than rebuilding
+	injectedWorkforceManager bool
+	injectedEconomicModel    bool
+	
+	// Regions human workers are hired in, indexed by cost category
+	regions types.RegionTable
 	
 	// Simulation state tracking
 	currentTimeStep           int
+	startTimeStep             int // time step the current run started from, non-zero when warm-started
 	timeSeries               []types.SimulationState
 	totalCatastrophicFailures int
 	equilibriumReached        bool
+	eventLog                  []types.SimulationEvent
+	
+	// One-off costs incurred during the current time step
+	stepPenalties   float64
+	stepSeverance   float64
+	stepRecruiting  float64
+	stepRemediation float64
+	
+	// Workforce changes made during the current time step
+	stepAgentsHired    int
+	stepAgentsReleased int
+	stepHumansLost     int
+	
+	// Burnout accumulated from overtime worked to meet the required workload
+	burnout float64
+	
+	// Cash on hand, tracked when Cash is enabled; the run ends once it becomes insolvent
+	cashBalance float64
+	insolvent   bool
+	
+	// Why the run ended in a terminal state such as a collapsed organization; the code is
+	// EquilibriumNotReached while the organization can still operate and change
+	terminalReason types.EquilibriumReason
+	
+	// Replacement hires scheduled for humans lost to attrition, in scheduling order
+	pendingBackfills []types.BackfillRequest
+	
+	// Recoveries from catastrophic failures still under way
+	recoveries []types.FailureRecovery
+	
+	// Every catastrophic failure of the run and the workforce's response to it
+	failures []types.FailureRecord
+	
+	// Failure forced to strike on the next step, see ForceCatastrophicFailure
+	forcedFailure *events.CatastrophicFailure
+	
+	// Policy deciding hires and releases in place of the optimizer, see WithWorkforcePolicy
+	policy WorkforcePolicy
+	
+	// Objective the optimizer's hires are trimmed to pursue, see WithObjective
+	objective objective.Objective
+	
+	// Custom attrition, revenue and optimization logic, see WithBehaviors
+	behaviors Behaviors
+	
+	// Actions the scripted rules took for the current step, see types.RulesConfig
+	ruleActions events.RuleActions
+	
+	// Reviewer of optimizer decisions and the decisions reviewed so far, see WithDecisionApprover
+	approver  DecisionApprover
+	decisions []types.OptimizerDecision
+	
+	// Observers of the run and the alerts raised so far, see WithObserver and types.AlertRule
+	observers []Observer
+	alerts    []types.Alert
+	
+	// Identity of the run and where it came from, see BranchAt
+	runID           string            // assigned on first use, so a result and the branches of its run agree on it
+	startedAt       time.Time         // start of the run, set on first use like runID
+	origin          *Checkpoint       // checkpoint the run was restored or warm-started from; nil for a run from scratch
+	originWarmStart bool              // the run was warm-started from origin rather than resumed
+	lineage         *types.RunLineage // parent run of a branch; nil otherwise
+	
+	// Guards aborting the run, see WithLimits, and the workers summed over its steps so far
+	limits      Limits
+	entitySteps int
 	
-	// Random number generator for reproducible results
-	rng *rand.Rand
+	// Minimum interval between time steps and when the latest one started, see WithPacing
+	pace       time.Duration
+	lastStepAt time.Time
+	
+	// Checkers run after every time step and the first error they returned, see WithInvariantChecker
+	invariantCheckers []InvariantChecker
+	invariantErr      error
+	
+	// Per-subsystem random streams derived from the seed for reproducible results
+	streams *random.Streams
+	
+	// The run as of the writer's latest completed operation, see Snapshot, and the lock guarding
+	// it and the pacing readers may change
+	mu        sync.RWMutex
+	published Snapshot
 }
 
 // NewSimulationController creates a new SimulationController instance
-func NewSimulationController(config types.SimulationConfig, seed int64) *SimulationController {
-	// Create random number generator with seed for reproducibility
-	rng := rand.New(rand.NewSource(seed))
+// Options substitute custom components for the defaults built from the configuration
+func NewSimulationController(config types.SimulationConfig, seed int64, opts ...Option) *SimulationController {
+	// Derive independent random streams from the seed for reproducibility
+	streams := random.NewStreams(seed)
 	
 	// Create component instances
+	regions := config.RegionTable()
 	workforceManager := workforce.NewWorkforceManager()
-	economicModel := economic.NewEconomicModel(config.FixedBudget, config.RevenueScenario)
+	economicModel := economic.NewEconomicModel(config.WorkforceBudget(), config.RevenueScenario, config.Overhead, config.Financing, config.RevenueVolatility, config.MarketSize, streams)
+	economicModel.SetAIPricing(config.AIPricing)
 	eventProcessor := events.NewEventProcessor(
 		config.AttritionConfig,
-		config.CatastrophicFailureRate,
+		config.CatastrophicFailureRate*config.Resilience.RateMultiplier(),
+		config.FailureClassTable(),
 		config.AILearningSpeeds,
-		config.TimeZoneInefficiency,
-		rng,
+		config.SharedLearning,
+		regions,
+		config.Overhead,
+		config.Assignment.Strategy,
+		streams,
 	)
+	eventProcessor.SetSeverityMultiplier(config.Resilience.SeverityMultiplier())
+	eventProcessor.SetTraining(config.Training, config.Training.Capacity(config.FixedBudget))
+	eventProcessor.SetDataExposure(config.DataExposure)
+	eventProcessor.SetDomains(config.Domains)
+	eventProcessor.SetVendors(config.Vendors)
+	eventProcessor.SetAIPricing(config.AIPricing)
 	
-	return &SimulationController{
+	sc := &SimulationController{
 		config:                    config,
 		workforceManager:         workforceManager,
 		economicModel:            economicModel,
 		eventProcessor:           eventProcessor,
+		regions:                  regions,
 		currentTimeStep:          0,
 		timeSeries:               make([]types.SimulationState, 0),
 		totalCatastrophicFailures: 0,
 		equilibriumReached:       false,
-		rng:                      rng,
+		eventLog:                 make([]types.SimulationEvent, 0),
+		streams:                  streams,
 	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
 }
 
 // GetConfig returns the simulation configuration
@@ -64,27 +183,43 @@ func (sc *SimulationController) GetConfig() types.SimulationConfig {
 
 // GetCurrentTimeStep returns the current simulation time step
 func (sc *SimulationController) GetCurrentTimeStep() int {
-	return sc.currentTimeStep
+	return sc.Snapshot().TimeStep
 }
 
 // GetTimeSeries returns the complete time series data
 func (sc *SimulationController) GetTimeSeries() []types.SimulationState {
-	return sc.timeSeries
+	return sc.Snapshot().TimeSeries
 }
 
 // GetTotalCatastrophicFailures returns the total number of catastrophic failures encountered
 func (sc *SimulationController) GetTotalCatastrophicFailures() int {
-	return sc.totalCatastrophicFailures
+	return sc.Snapshot().TotalCatastrophicFailures
+}
+
+// GetEvents returns the log of notable events recorded so far, in time order
+func (sc *SimulationController) GetEvents() []types.SimulationEvent {
+	return sc.Snapshot().Events
+}
+
+// recordEvent appends an entry to the event log for the current time step
+func (sc *SimulationController) recordEvent(eventType types.EventType, format string, args ...interface{}) {
+	sc.eventLog = append(sc.eventLog, types.SimulationEvent{
+		TimeStep:    sc.currentTimeStep,
+		Type:        eventType,
+		Description: fmt.Sprintf(format, args...),
+	})
 }
 
 // IsEquilibriumReached returns whether equilibrium has been reached
 func (sc *SimulationController) IsEquilibriumReached() bool {
-	return sc.equilibriumReached
+	return sc.Snapshot().EquilibriumReached
 }
 
 // Initialize sets up the initial workforce based on configuration and validates parameters
 // Returns an error if the configuration is invalid or initialization fails
 func (sc *SimulationController) Initialize() error {
+	defer sc.publish()
+	
 	// Validate configuration parameters
 	if err := sc.validateConfiguration(); err != nil {
 		return fmt.Errorf("configuration validation failed: %w", err)
@@ -92,9 +227,34 @@ func (sc *SimulationController) Initialize() error {
 	
 	// Reset simulation state
 	sc.currentTimeStep = 0
+	sc.startTimeStep = 0
 	sc.timeSeries = make([]types.SimulationState, 0)
 	sc.totalCatastrophicFailures = 0
 	sc.equilibriumReached = false
+	sc.eventLog = make([]types.SimulationEvent, 0)
+	sc.stepPenalties = 0
+	sc.stepSeverance = 0
+	sc.stepRecruiting = 0
+	sc.stepRemediation = 0
+	sc.resetStepChanges()
+	sc.pendingBackfills = nil
+	sc.recoveries = nil
+	sc.failures = nil
+	sc.forcedFailure = nil
+	sc.decisions = nil
+	sc.ruleActions = events.RuleActions{}
+	sc.alerts = nil
+	sc.runID = ""
+	sc.startedAt = time.Time{}
+	sc.origin = nil
+	sc.lineage = nil
+	sc.setBurnout(0)
+	sc.cashBalance = sc.initialCashBalance()
+	sc.insolvent = false
+	sc.terminalReason = types.EquilibriumReason{}
+	sc.entitySteps = 0
+	sc.invariantErr = nil
+	sc.applyMarketShocks()
 	
 	// Create initial workforce based on configuration
 	if err := sc.createInitialWorkforce(); err != nil {
@@ -104,68 +264,18 @@ func (sc *SimulationController) Initialize() error {
 	// Record initial state
 	initialState := sc.captureCurrentState()
 	sc.timeSeries = append(sc.timeSeries, initialState)
+	sc.lastStepAt = time.Now()
 	
 	return nil
 }
 
 // validateConfiguration checks if all configuration parameters are valid
+// Returns a *types.ConfigError naming the first invalid field
 func (sc *SimulationController) validateConfiguration() error {
-	config := sc.config
-	
-	// Check initial humans count
-	if config.InitialHumans <= 0 {
-		return errors.New("initial humans count must be greater than 0")
-	}
-	
-	// Check experience distribution sums to 100%
-	expSum := config.ExperienceDistribution.UniversityHire +
-		config.ExperienceDistribution.MidLevel +
-		config.ExperienceDistribution.Senior +
-		config.ExperienceDistribution.Executive
-	if expSum < 99.9 || expSum > 100.1 { // Allow small floating point errors
-		return fmt.Errorf("experience distribution must sum to 100%%, got %.2f%%", expSum)
-	}
-	
-	// Check cost category distribution sums to 100%
-	costSum := config.CostCategoryDistribution.HighCostUS +
-		config.CostCategoryDistribution.LowCostNonUS
-	if costSum < 99.9 || costSum > 100.1 { // Allow small floating point errors
-		return fmt.Errorf("cost category distribution must sum to 100%%, got %.2f%%", costSum)
-	}
-	
-	// Check fixed budget is positive
-	if config.FixedBudget <= 0 {
-		return errors.New("fixed budget must be greater than 0")
-	}
-	
-	// Check AI learning speeds are positive
-	if config.AILearningSpeeds.UniversityToMid <= 0 ||
-		config.AILearningSpeeds.MidToSenior <= 0 ||
-		config.AILearningSpeeds.SeniorToExecutive <= 0 {
-		return errors.New("AI learning speeds must be greater than 0")
+	if err := sc.config.Validate(); err != nil {
+		return err
 	}
-	
-	// Check attrition rate is valid (0-100%)
-	if config.AttritionConfig.NaturalRate < 0 || config.AttritionConfig.NaturalRate > 100 {
-		return fmt.Errorf("natural attrition rate must be between 0-100%%, got %.2f%%", config.AttritionConfig.NaturalRate)
-	}
-	
-	// Check forced acceleration is positive
-	if config.AttritionConfig.ForcedAcceleration < 0 {
-		return errors.New("forced acceleration must be non-negative")
-	}
-	
-	// Check catastrophic failure rate is valid (0-1)
-	if config.CatastrophicFailureRate < 0 || config.CatastrophicFailureRate > 1 {
-		return fmt.Errorf("catastrophic failure rate must be between 0-1, got %.4f", config.CatastrophicFailureRate)
-	}
-	
-	// Check time zone inefficiency is valid (0-1)
-	if config.TimeZoneInefficiency < 0 || config.TimeZoneInefficiency > 1 {
-		return fmt.Errorf("time zone inefficiency must be between 0-1, got %.4f", config.TimeZoneInefficiency)
-	}
-	
-	return nil
+	return sc.eventProcessor.LoadRules(sc.config.Rules.Script)
 }
 
 // createInitialWorkforce creates the initial human workforce based on configuration
@@ -195,9 +305,15 @@ func (sc *SimulationController) createInitialWorkforce() error {
 		}
 	}
 	
-	// Calculate cost category distribution
-	costDist := config.CostCategoryDistribution
-	highCostCount := int(float64(config.InitialHumans) * costDist.HighCostUS / 100.0)
+	// Calculate the regional distribution; rounding leftovers go to the last region
+	regionCounts := make([]int, len(sc.regions))
+	regionTotal := 0
+	for i := 0; i < len(sc.regions)-1; i++ {
+		regionCounts[i] = int(float64(config.InitialHumans) * sc.regions[i].Share / 100.0)
+		regionTotal += regionCounts[i]
+	}
+	regionCounts[len(sc.regions)-1] = config.InitialHumans - regionTotal
+	region := 0
 	
 	// Create workers for each experience level
 	experienceLevels := []struct {
@@ -211,17 +327,17 @@ func (sc *SimulationController) createInitialWorkforce() error {
 	}
 	
 	businessOwnerAssigned := false
+	contracts := initialContracts(config.InitialHumans, config.Contracts)
+	worker := 0
 	
 	for _, expLevel := range experienceLevels {
 		for i := 0; i < expLevel.count; i++ {
-			// Determine cost category for this worker
-			var costCategory types.CostCategory
-			if highCostCount > 0 {
-				costCategory = types.HighCostUS
-				highCostCount--
-			} else {
-				costCategory = types.LowCostNonUS
+			// Determine the region for this worker, filling regions in table order
+			for regionCounts[region] <= 0 && region < len(regionCounts)-1 {
+				region++
 			}
+			regionCounts[region]--
+			costCategory := types.CostCategory(region)
 			
 			// Assign business owner to the first worker if not yet assigned
 			isBusinessOwner := !businessOwnerAssigned
@@ -230,10 +346,12 @@ func (sc *SimulationController) createInitialWorkforce() error {
 			}
 			
 			// Create the human worker
-			_, err := sc.workforceManager.AddHuman(expLevel.level, costCategory, isBusinessOwner)
+			human, err := sc.workforceManager.AddHuman(expLevel.level, costCategory, isBusinessOwner)
 			if err != nil {
 				return fmt.Errorf("failed to add human worker: %w", err)
 			}
+			human.ApplyEmployment(sc.regions[region], contracts[worker], config.Contracts)
+			worker++
 		}
 	}
 	
@@ -247,13 +365,49 @@ func (sc *SimulationController) createInitialWorkforce() error {
 	agents := sc.workforceManager.GetAllAIAgents()
 	totalCost := sc.economicModel.CalculateWorkforceCost(humans, agents)
 	
-	if totalCost > config.FixedBudget {
-		return fmt.Errorf("initial workforce cost (%.2f) exceeds fixed budget (%.2f)", totalCost, config.FixedBudget)
+	if totalCost > config.WorkforceBudget() {
+		return fmt.Errorf("%w: initial workforce cost (%.2f) exceeds workforce budget (%.2f)", types.ErrBudgetExceeded, totalCost, config.WorkforceBudget())
 	}
 	
 	return nil
 }
 
+// initialContracts returns the contract type of each initial worker in creation order
+// The business owner, created first, is always a full-time employee; the other contract types
+// are interleaved evenly (smooth weighted round-robin) so they spread across experience levels
+func initialContracts(count int, terms types.ContractConfig) []types.ContractType {
+	contracts := make([]types.ContractType, count)
+	if count <= 1 {
+		return contracts
+	}
+	
+	var weights [types.NumContractTypes]int
+	weights[types.Contractor] = int(float64(count) * terms.ContractorShare / 100.0)
+	weights[types.PartTime] = int(float64(count) * terms.PartTimeShare / 100.0)
+	others := count - 1
+	if weights[types.Contractor] > others {
+		weights[types.Contractor] = others
+	}
+	if weights[types.PartTime] > others-weights[types.Contractor] {
+		weights[types.PartTime] = others - weights[types.Contractor]
+	}
+	weights[types.FullTime] = others - weights[types.Contractor] - weights[types.PartTime]
+	
+	var current [types.NumContractTypes]int
+	for i := 1; i < count; i++ {
+		chosen := 0
+		for contract := range current {
+			current[contract] += weights[contract]
+			if current[contract] > current[chosen] {
+				chosen = contract
+			}
+		}
+		current[chosen] -= others
+		contracts[i] = types.ContractType(chosen)
+	}
+	return contracts
+}
+
 // captureCurrentState captures the current simulation state for recording
 func (sc *SimulationController) captureCurrentState() types.SimulationState {
 	humans := sc.workforceManager.GetAllHumans()
@@ -262,33 +416,290 @@ func (sc *SimulationController) captureCurrentState() types.SimulationState {
 	// Calculate metrics
 	totalCost := sc.economicModel.CalculateWorkforceCost(humans, agents)
 	availableBudget := sc.economicModel.GetAvailableBudget(humans, agents)
-	totalProductivity := sc.workforceManager.CalculateTotalProductivity(sc.config.TimeZoneInefficiency)
-	revenueOutput := sc.economicModel.CalculateRevenue(totalProductivity, sc.currentTimeStep)
+	costBreakdown := sc.economicModel.CalculateCostBreakdown(humans, agents)
+	costBreakdown.Resilience = sc.config.Resilience.Spend(sc.config.FixedBudget)
+	costBreakdown.Training = sc.config.Training.Spend(sc.config.FixedBudget)
+	costBreakdown.Penalties = sc.stepPenalties
+	costBreakdown.Severance = sc.stepSeverance
+	costBreakdown.Recruiting = sc.stepRecruiting
+	costBreakdown.Remediation = sc.stepRemediation
+	baseProductivity := sc.workforceManager.CalculateTotalProductivity(sc.regions, sc.config.OrchestrationEffectiveness)
+	humanProductivity := sc.workforceManager.CalculateHumanProductivity(sc.regions)
+	
+	// Failure recovery depresses output and takes up part of the senior humans' capacity
+	recoveryLoss, recovering := sc.recoveryLoss()
+	seniorFactor, recoveryFactor := 1.0, 1.0
+	if recovering {
+		humanCapacity, _ := sc.workforceManager.CalculateProductivityByExperience(sc.regions, sc.config.OrchestrationEffectiveness)
+		occupied := (humanCapacity[types.Senior] + humanCapacity[types.Executive]) * sc.config.Recovery.SeniorOccupancy
+		seniorFactor = 1.0 - sc.config.Recovery.SeniorOccupancy
+		recoveryFactor = 1.0 - recoveryLoss
+		baseProductivity = (baseProductivity - occupied) * recoveryFactor
+		humanProductivity = (humanProductivity - occupied) * recoveryFactor
+	}
+	
+	// Humans work overtime when the workforce falls short of the required workload
+	overtime := sc.config.Workload.Overtime(humanProductivity, baseProductivity)
+	totalProductivity := baseProductivity + humanProductivity*overtime
+	
+	// With task demand, only the work matched to tasks earns revenue
+	var utilization types.TaskUtilization
+	if sc.config.Tasks.Enabled() {
+		humanCapacity, aiCapacity := sc.workforceManager.CalculateProductivityByExperience(sc.regions, sc.config.OrchestrationEffectiveness)
+		var capacity [types.NumExperienceLevels]float64
+		for level := range capacity {
+			if types.ExperienceLevel(level) >= types.Senior {
+				humanCapacity[level] *= seniorFactor
+			}
+			capacity[level] = (humanCapacity[level]*(1.0+overtime) + aiCapacity[level]) * recoveryFactor
+		}
+		utilization = sc.config.Tasks.Match(capacity)
+		totalProductivity = utilization.Delivered
+	}
 	
-	// Get workforce composition
+	revenueOutput := sc.revenue(totalProductivity)
+	revenueAttribution := sc.economicModel.AttributeRevenue(revenueOutput, humans, agents, sc.regions, sc.config.OrchestrationEffectiveness)
+	if overtime > 0 {
+		creditOvertime(revenueAttribution, 1.0+overtime, baseProductivity/(baseProductivity+humanProductivity*overtime))
+	}
+	if sc.config.Tasks.Enabled() {
+		creditUtilization(revenueAttribution, utilization, revenueOutput)
+	}
+	
+	// Get workforce composition with the productivity and cost of each group
 	workforce := sc.workforceManager.GetWorkforceComposition()
+	humanByLevel, aiByLevel := sc.workforceManager.CalculateProductivityByExperience(sc.regions, sc.config.OrchestrationEffectiveness)
+	workforce.HumanSubtotals = groupSubtotals(humanByLevel, costBreakdown.HumanPayroll)
+	workforce.AISubtotals = groupSubtotals(aiByLevel, costBreakdown.AICost)
+	
+	// Departures after oversight was enforced can leave more agents than the ratio allows
+	capacity := sc.availableCapacity(humans, agents)
+	if capacity < 0 {
+		capacity = 0
+	}
 	
 	return types.SimulationState{
 		TimeStep:             sc.currentTimeStep,
 		Workforce:            workforce,
 		TotalCost:            totalCost,
+		CostBreakdown:        costBreakdown,
 		AvailableBudget:      availableBudget,
 		TotalProductivity:    totalProductivity,
 		RevenueOutput:        revenueOutput,
+		RevenueAttribution:   revenueAttribution,
+		Overtime:             overtime,
+		Burnout:              sc.burnout,
+		RecoveryLoss:         recoveryLoss,
+		Utilization:          utilization,
+		MarketShocks:         sc.config.Shocks.At(sc.currentTimeStep),
+		CashBalance:          sc.cashBalance,
+		Financing:            types.FinancingState{Debt: sc.economicModel.GetDebt()},
 		IsEquilibrium:        sc.equilibriumReached,
 		CatastrophicFailures: sc.totalCatastrophicFailures,
+		AvailableOrchestrationCapacity: capacity,
+		AgentsHiredThisStep:            sc.stepAgentsHired,
+		AgentsReleasedThisStep:         sc.stepAgentsReleased,
+		HumansLostThisStep:             sc.stepHumansLost,
 	}
 }
 
+// resetStepChanges clears the counts of workforce changes made during the previous time step
+func (sc *SimulationController) resetStepChanges() {
+	sc.stepAgentsHired = 0
+	sc.stepAgentsReleased = 0
+	sc.stepHumansLost = 0
+}
+
+// groupSubtotals returns the subtotals of a group of workers from its productivity and annual
+// cost by experience level
+func groupSubtotals(productivity [types.NumExperienceLevels]float64, costs map[types.ExperienceLevel]float64) types.GroupSubtotals {
+	subtotals := types.GroupSubtotals{
+		ProductivityByExperience: make(map[types.ExperienceLevel]float64),
+		CostByExperience:         make(map[types.ExperienceLevel]float64),
+	}
+	for level := types.ExperienceLevel(0); level < types.NumExperienceLevels; level++ {
+		subtotals.ProductivityByExperience[level] = productivity[level]
+		subtotals.CostByExperience[level] = costs[level]
+		subtotals.Productivity += productivity[level]
+		subtotals.Cost += costs[level]
+	}
+	return subtotals
+}
+
+// creditUtilization scales each experience level's share of revenue by the utilization of
+// workers at that level, so idle capacity earns nothing, keeping the shares summing to revenue
+func creditUtilization(attribution types.RevenueAttribution, utilization types.TaskUtilization, revenue float64) {
+	total := 0.0
+	for _, shares := range []map[types.ExperienceLevel]float64{attribution.HumanByExperience, attribution.AIByExperience} {
+		for level, share := range shares {
+			shares[level] = share * utilization.ByExperience[level] / 100.0
+			total += shares[level]
+		}
+	}
+	if total == 0 {
+		return
+	}
+	for _, shares := range []map[types.ExperienceLevel]float64{attribution.HumanByExperience, attribution.AIByExperience} {
+		for level, share := range shares {
+			shares[level] = share * revenue / total
+		}
+	}
+}
+
+// creditOvertime credits humans with the revenue earned by their overtime
+// AttributeRevenue splits revenue by productivity without overtime; humans' shares are scaled
+// by their overtime boost and every share by the fraction of output produced without overtime
+func creditOvertime(attribution types.RevenueAttribution, boost, baseFraction float64) {
+	for level, revenue := range attribution.HumanByExperience {
+		attribution.HumanByExperience[level] = revenue * boost * baseFraction
+	}
+	for level, revenue := range attribution.AIByExperience {
+		attribution.AIByExperience[level] = revenue * baseFraction
+	}
+}
+
+// setBurnout records the workforce's burnout and the attrition pressure it creates
+func (sc *SimulationController) setBurnout(burnout float64) {
+	sc.burnout = burnout
+	sc.eventProcessor.SetBurnoutMultiplier(sc.config.Workload.AttritionMultiplier(burnout))
+}
+
+// initialCashBalance returns the cash on hand at the start of a run, 0 unless Cash is enabled
+func (sc *SimulationController) initialCashBalance() float64 {
+	if !sc.config.Cash.Enabled {
+		return 0
+	}
+	return sc.config.Cash.InitialBalance
+}
+
+// updateCashBalance adds a recorded step's net cash flow to the cash balance and ends the run
+// as insolvent once the balance falls beyond the credit limit
+func (sc *SimulationController) updateCashBalance(state *types.SimulationState) {
+	if !sc.config.Cash.Enabled {
+		return
+	}
+	sc.cashBalance += state.NetCashFlow() + state.Financing.Borrowed - state.Financing.Repaid
+	state.CashBalance = sc.cashBalance
+	
+	if sc.config.Cash.Insolvent(sc.cashBalance) && !sc.insolvent {
+		sc.insolvent = true
+		sc.equilibriumReached = false
+		state.IsEquilibrium = false
+		sc.recordEvent(types.InsolvencyEvent, "insolvent: cash balance %.0f exceeds the credit limit of %.0f", sc.cashBalance, sc.config.Cash.CreditLimit)
+	}
+}
+
+// checkTerminalState ends the run once the organization can no longer operate or change: when
+// no humans remain to orchestrate AI agents, or when attrition has left only the business owner,
+// with no backfill pending and no room for more agents
+// Such a run would otherwise never settle into equilibrium, as failures keep its agents changing
+func (sc *SimulationController) checkTerminalState(state *types.SimulationState) {
+	if sc.insolvent || sc.terminated() {
+		return
+	}
+	
+	humans := sc.workforceManager.GetAllHumans()
+	agents := sc.workforceManager.GetAllAIAgents()
+	startedWithOthers := len(sc.timeSeries) > 0 && sc.timeSeries[0].Workforce.Humans.Total > 1
+	switch {
+	case len(humans) == 0:
+		sc.terminalReason = types.EquilibriumReason{
+			Code:    types.OrganizationCollapsed,
+			Message: fmt.Sprintf("organization collapsed: no humans remain to orchestrate %d AI agents", len(agents)),
+		}
+	case len(humans) == 1 && humans[0].IsBusinessOwner && startedWithOthers && len(sc.pendingBackfills) == 0 &&
+		(sc.availableCapacity(humans, agents) <= 0 || sc.aiHiringFrozen()):
+		sc.terminalReason = types.EquilibriumReason{
+			Code:    types.OnlyOwnerRemains,
+			Message: fmt.Sprintf("only the business owner remains, orchestrating %d AI agents", len(agents)),
+		}
+	default:
+		return
+	}
+	
+	sc.equilibriumReached = false
+	state.IsEquilibrium = false
+	sc.recordEvent(types.TerminalStateEvent, "%s", sc.terminalReason.Message)
+}
+
+// IsTerminated returns whether the run has ended in a terminal state, see checkTerminalState
+func (sc *SimulationController) IsTerminated() bool {
+	return sc.Snapshot().Terminated()
+}
+
+// terminated is IsTerminated for the writer, which sees the run in the middle of an operation
+func (sc *SimulationController) terminated() bool {
+	return sc.terminalReason.Code != types.EquilibriumNotReached
+}
+
+// settleFinancing services the outstanding debt for a recorded step and borrows to cover the
+// spending the fixed budget cannot
+func (sc *SimulationController) settleFinancing(state *types.SimulationState) {
+	state.Financing, state.CostBreakdown.Interest = sc.economicModel.SettleFinancing(state.TotalCost, state.RevenueOutput)
+}
+
+// IsInsolvent returns whether the run has ended because it ran out of cash
+func (sc *SimulationController) IsInsolvent() bool {
+	return sc.Snapshot().Insolvent
+}
+
+// insolvencyReason describes the insolvency that ended the run
+func (sc *SimulationController) insolvencyReason() types.EquilibriumReason {
+	return types.EquilibriumReason{
+		Code:    types.Insolvent,
+		Message: fmt.Sprintf("cash balance fell to %.0f, beyond the credit limit of %.0f", sc.cashBalance, sc.config.Cash.CreditLimit),
+	}
+}
+
+// setMarketConditions applies market shock factors and the AI cost curve to revenue and
+// workforce costs
+func (sc *SimulationController) setMarketConditions(market types.MarketConditions) {
+	sc.economicModel.SetMarketConditions(market)
+	sc.eventProcessor.SetMarketConditions(market)
+}
+
+// applyMarketShocks logs the market shocks scheduled for the current time step and brings
+// market conditions, including the AI cost curve, up to date
+func (sc *SimulationController) applyMarketShocks() {
+	for _, shock := range sc.config.Shocks.At(sc.currentTimeStep) {
+		sc.recordEvent(types.MarketShockEvent, "market shock: %s", shock)
+	}
+	sc.setMarketConditions(sc.config.MarketConditions(sc.currentTimeStep))
+}
+
 // Step executes one simulation time step
 // Processes attrition, learning, optimization, and metrics according to requirements 10.2-10.7
 func (sc *SimulationController) Step() types.SimulationState {
-	// Increment time step
+	sc.awaitPace()
+	
+	// Increment time step and clear the previous step's one-off costs
 	sc.currentTimeStep++
+	sc.stepPenalties = 0
+	sc.stepSeverance = 0
+	sc.stepRecruiting = 0
+	sc.stepRemediation = 0
+	sc.resetStepChanges()
+	sc.endRecoveries()
+	firstEvent := len(sc.eventLog)
+	
+	// Market shocks take effect at the start of their time step
+	sc.applyMarketShocks()
+	
+	// Scripted rules act on the previous step's state before anything else happens
+	sc.applyRules()
 	
 	// Step 1: Process human attrition events (Requirement 10.2)
 	sc.processAttrition()
 	
+	// Step 1b: Hire backfills whose recruiting lag has elapsed
+	sc.processBackfills()
+	
+	// Step 1c: Release AI agents that departures left without the required human oversight
+	sc.enforceOversight()
+	
+	// Step 1d: Even out orchestration load after departures
+	sc.rebalanceAgents()
+	
 	// Step 2: Update AI agent experience and learning progression (Requirement 10.3)
 	sc.processLearning()
 	
@@ -302,42 +713,192 @@ func (sc *SimulationController) Step() types.SimulationState {
 	// This is done in captureCurrentState()
 	
 	// Step 6: Check for equilibrium conditions (Requirement 10.6)
+	wasEquilibrium := sc.equilibriumReached
 	sc.checkEquilibrium()
 	
 	// Step 7: Record workforce state and metrics at each time step (Requirement 10.7)
 	currentState := sc.captureCurrentState()
+	sc.settleFinancing(&currentState)
+	sc.updateCashBalance(&currentState)
+	sc.checkTerminalState(&currentState)
+	sc.checkLimits(&currentState)
 	sc.timeSeries = append(sc.timeSeries, currentState)
 	
+	// The reason is classified from the recorded state, so it is filled in afterwards, as is
+	// the burnout from this step's overtime, which raises attrition from the next step on
+	currentState.EquilibriumReason = sc.currentEquilibriumReason()
+	sc.setBurnout(sc.config.Workload.Burnout(sc.burnout, currentState.Overtime))
+	currentState.Burnout = sc.burnout
+	sc.timeSeries[len(sc.timeSeries)-1] = currentState
+	
+	if sc.equilibriumReached && !wasEquilibrium {
+		sc.recordEvent(types.EquilibriumEvent, "equilibrium reached: %s", currentState.EquilibriumReason.Message)
+	}
+	
+	sc.checkInvariants(currentState)
+	alerts := sc.checkAlerts(currentState)
+	
+	// Observers are notified once the step is published, so they may read the controller
+	sc.publish()
+	sc.notifyStep(currentState, alerts, capped(sc.eventLog[firstEvent:]))
+	
 	return currentState
 }
 
 // processAttrition handles human worker attrition based on configured attrition type
 func (sc *SimulationController) processAttrition() {
 	humans := sc.workforceManager.GetAllHumans()
-	workersToRemove := sc.eventProcessor.ProcessAttrition(humans, sc.currentTimeStep)
+	workersToRemove := sc.attrition(humans)
 	
 	// Remove the selected workers
 	for _, workerID := range workersToRemove {
-		err := sc.workforceManager.RemoveHuman(workerID)
-		if err != nil {
-			// Log error but continue simulation
-			// In a production system, this would use proper logging
-			fmt.Printf("Warning: Failed to remove human worker %s: %v\n", workerID, err)
+		human, _ := sc.workforceManager.GetHuman(workerID)
+		if human.IsBusinessOwner {
+			if !sc.succeedBusinessOwner() {
+				continue
+			}
+		} else {
+			err := sc.workforceManager.RemoveHuman(workerID)
+			if err != nil {
+				// Log error but continue simulation
+				// In a production system, this would use proper logging
+				fmt.Printf("Warning: Failed to remove human worker %s: %v\n", workerID, err)
+				continue
+			}
+			sc.recordEvent(types.AttritionEvent, "%s (%s) left, releasing %d AI agents",
+				workerID, human.ExperienceLevel, len(human.AssignedAgents))
+			sc.stepAgentsReleased += len(human.AssignedAgents)
 		}
+		sc.stepHumansLost++
+		
+		// Workers let go in a reduction in force receive severance; other departures may be backfilled
+		// Contractors are not entitled to severance
+		if sc.config.AttritionConfig.Type == types.ReductionInForce {
+			if human.ContractType != types.Contractor {
+				sc.stepSeverance += human.BaseCost * sc.config.MarketConditions(sc.currentTimeStep).Salary / types.TimeStepsPerYear * sc.config.AttritionConfig.SeveranceMonths
+			}
+		} else {
+			sc.scheduleBackfill(human)
+		}
+	}
+}
+
+// succeedBusinessOwner removes the departing business owner and hands ownership to a successor
+// Returns false if the owner stays because no other human can succeed them
+func (sc *SimulationController) succeedBusinessOwner() bool {
+	succession, err := sc.workforceManager.RemoveBusinessOwner()
+	if errors.Is(err, types.ErrBusinessOwnerRemoval) {
+		return false
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to remove business owner: %v\n", err)
+		return false
+	}
+	
+	previous, successor := succession.PreviousOwner, succession.Successor
+	sc.stepAgentsReleased += succession.ReleasedAgents
+	sc.recordEvent(types.AttritionEvent, "%s (%s) left, releasing %d AI agents",
+		previous.ID, previous.ExperienceLevel, succession.ReleasedAgents)
+	sc.recordEvent(types.OwnerSuccessionEvent, "business owner %s (%s) succeeded by %s (%s), %d AI agents reassigned",
+		previous.ID, previous.ExperienceLevel, successor.ID, successor.ExperienceLevel, succession.ReassignedAgents)
+	return true
+}
+
+// scheduleBackfill decides whether a departed human is replaced and, if so, queues a
+// like-for-like hire after the recruiting lag
+func (sc *SimulationController) scheduleBackfill(departed *types.HumanWorker) {
+	backfill := sc.config.Backfill
+	if backfill.Fraction <= 0 || sc.config.AttritionConfig.HumanHiringFrozen() {
+		return
+	}
+	if backfill.Fraction < 1 && sc.streams.Get(random.StreamBackfill).Float64() >= backfill.Fraction {
+		return
+	}
+	
+	sc.pendingBackfills = append(sc.pendingBackfills, types.BackfillRequest{
+		DueTimeStep:     sc.currentTimeStep + backfill.LagSteps,
+		ExperienceLevel: departed.ExperienceLevel,
+		CostCategory:    departed.CostCategory,
+		ContractType:    departed.ContractType,
+	})
+}
+
+// processBackfills hires the pending backfills that are due and charges their recruiting cost
+// Backfills that no longer fit within the budget are cancelled
+func (sc *SimulationController) processBackfills() {
+	remaining := sc.pendingBackfills[:0]
+	for _, request := range sc.pendingBackfills {
+		if request.DueTimeStep > sc.currentTimeStep || sc.ruleActions.FreezeHumanHiring {
+			remaining = append(remaining, request)
+			continue
+		}
+		
+		humans := sc.workforceManager.GetAllHumans()
+		agents := sc.workforceManager.GetAllAIAgents()
+		fullTimeCost := sc.regions.Region(request.CostCategory).AnnualCost(request.ExperienceLevel)
+		cost := sc.config.Overhead.HumanCost(sc.config.Contracts.AnnualCost(fullTimeCost, request.ContractType), request.ContractType) * sc.config.MarketConditions(sc.currentTimeStep).Salary
+		if !sc.economicModel.CanAfford(cost, humans, agents) {
+			continue
+		}
+		
+		if _, err := sc.hireContractHuman(request.ExperienceLevel, request.CostCategory, request.ContractType); err != nil {
+			fmt.Printf("Warning: Failed to backfill %s worker: %v\n", request.ExperienceLevel, err)
+			continue
+		}
+		sc.stepRecruiting += sc.config.Backfill.RecruitingCost
 	}
+	sc.pendingBackfills = remaining
+}
+
+// HireHuman adds a full-time human worker to the running simulation
+// Returns an error if human hiring is frozen by the attrition configuration
+func (sc *SimulationController) HireHuman(experienceLevel types.ExperienceLevel, costCategory types.CostCategory) (*types.HumanWorker, error) {
+	return sc.HireContractHuman(experienceLevel, costCategory, types.FullTime)
+}
+
+// HireContractHuman adds a human worker on the given contract type to the running simulation
+// costCategory selects the worker's region in the simulation's region table
+// Returns an error if human hiring is frozen by the attrition configuration or the rules
+func (sc *SimulationController) HireContractHuman(experienceLevel types.ExperienceLevel, costCategory types.CostCategory, contract types.ContractType) (*types.HumanWorker, error) {
+	defer sc.publish()
+	return sc.hireContractHuman(experienceLevel, costCategory, contract)
+}
+
+// hireContractHuman is HireContractHuman within a time step, which publishes the hire with the step
+func (sc *SimulationController) hireContractHuman(experienceLevel types.ExperienceLevel, costCategory types.CostCategory, contract types.ContractType) (*types.HumanWorker, error) {
+	if sc.config.AttritionConfig.HumanHiringFrozen() || sc.ruleActions.FreezeHumanHiring {
+		return nil, fmt.Errorf("human %w", types.ErrHiringFrozen)
+	}
+	
+	human, err := sc.workforceManager.AddHuman(experienceLevel, costCategory, false)
+	if err != nil {
+		return nil, err
+	}
+	human.ApplyEmployment(sc.regions.Region(costCategory), contract, sc.config.Contracts)
+	sc.recordEvent(types.HumanHiredEvent, "hired %s (%s, %s, %s)", human.ID, experienceLevel, sc.regions.Name(costCategory), contract)
+	return human, nil
 }
 
 // processLearning updates AI agent experience and triggers level-ups
 func (sc *SimulationController) processLearning() {
 	agents := sc.workforceManager.GetAllAIAgents()
+	sc.eventProcessor.SetOrchestrators(sc.workforceManager.GetAllHumans())
 	// Process learning with time delta of 1 (one time step)
-	sc.eventProcessor.ProcessLearning(agents, 1)
+	leveledUp := sc.eventProcessor.ProcessLearning(agents, 1)
+	for _, agent := range leveledUp {
+		sc.recordEvent(types.AgentLevelUpEvent, "%s advanced to %s", agent.ID, agent.ExperienceLevel)
+	}
 }
 
 // processCatastrophicFailures generates and handles catastrophic failure events
 func (sc *SimulationController) processCatastrophicFailures() {
 	// Generate potential catastrophic failure
 	failure := sc.eventProcessor.GenerateCatastrophicFailure(sc.currentTimeStep)
+	if sc.forcedFailure != nil {
+		failure = sc.forcedFailure
+		failure.TimeStep = sc.currentTimeStep
+		sc.forcedFailure = nil
+	}
 	if failure != nil {
 		sc.totalCatastrophicFailures++
 		
@@ -345,15 +906,71 @@ func (sc *SimulationController) processCatastrophicFailures() {
 		humans := sc.workforceManager.GetAllHumans()
 		agents := sc.workforceManager.GetAllAIAgents()
 		outcome := sc.eventProcessor.EvaluateFailureResponse(failure, humans, agents)
+		if outcome.CanHandle {
+			sc.recordEvent(types.CatastrophicFailureEvent, "catastrophic failure (%s, severity %.2f) handled by the workforce", failure.Class, failure.Severity)
+		} else {
+			sc.recordEvent(types.CatastrophicFailureEvent, "catastrophic failure (%s, severity %.2f) not handled, productivity penalty %.1f%%",
+				failure.Class, failure.Severity, outcome.ProductivityPenalty*100.0)
+		}
 		
-		// Apply productivity penalties if workforce cannot handle the failure
+		// Charge the share of this step's spend lost to the failure
+		penalty := 0.0
 		if !outcome.CanHandle && outcome.ProductivityPenalty > 0 {
-			// In a more sophisticated implementation, we would apply temporary
-			// productivity penalties. For now, we track the failure count.
-			// The penalty could be applied by modifying productivity calculations
-			// in subsequent steps, but this would require additional state tracking.
+			monthlyCost := sc.economicModel.CalculateWorkforceCost(humans, agents) / types.TimeStepsPerYear
+			penalty = monthlyCost * outcome.ProductivityPenalty
+			sc.stepPenalties += penalty
+		}
+		
+		// Remediate the failure and, when recovery takes time, depress productivity until it is over
+		class := sc.config.FailureClassTable()[failure.Class]
+		remediation := class.Remediation(failure.Severity)
+		sc.stepRemediation += remediation
+		sc.failures = append(sc.failures, types.FailureRecord{
+			TimeStep:            sc.currentTimeStep,
+			Class:               failure.Class,
+			Severity:            failure.Severity,
+			Handled:             outcome.CanHandle,
+			SeniorHumans:        outcome.SeniorHumans,
+			SeniorAgents:        outcome.SeniorAgents,
+			HumanCapability:     outcome.HumanCapability,
+			AgentCapability:     outcome.AgentCapability,
+			RequiredCapability:  outcome.RequiredCapability,
+			ProductivityPenalty: outcome.ProductivityPenalty,
+			Penalty:             penalty,
+			Remediation:         remediation,
+		})
+		if steps := class.RecoverySteps; steps > 0 {
+			loss := 0.0
+			if !outcome.CanHandle {
+				loss = outcome.ProductivityPenalty
+			}
+			sc.recoveries = append(sc.recoveries, types.FailureRecovery{
+				EndTimeStep:      sc.currentTimeStep + steps - 1,
+				ProductivityLoss: loss,
+			})
+		}
+	}
+}
+
+// endRecoveries drops the failure recoveries that ended before the current time step
+func (sc *SimulationController) endRecoveries() {
+	remaining := sc.recoveries[:0]
+	for _, recovery := range sc.recoveries {
+		if recovery.EndTimeStep >= sc.currentTimeStep {
+			remaining = append(remaining, recovery)
 		}
 	}
+	sc.recoveries = remaining
+}
+
+// recoveryLoss returns the fraction of productivity lost to the failure recoveries under way,
+// the largest of their losses, and whether any recovery is under way
+func (sc *SimulationController) recoveryLoss() (float64, bool) {
+	loss := 0.0
+	for _, recovery := range sc.recoveries {
+		loss = math.Max(loss, recovery.ProductivityLoss)
+	}
+	return loss, len(sc.recoveries) > 0
 }
 
 // processWorkforceOptimization evaluates and executes workforce composition changes
@@ -363,30 +980,165 @@ func (sc *SimulationController) processWorkforceOptimization() {
 	
 	// Calculate available budget and orchestration capacity
 	availableBudget := sc.economicModel.GetAvailableBudget(humans, agents)
-	availableCapacity := sc.workforceManager.GetAvailableOrchestrationCapacity()
+	availableCapacity := sc.availableCapacity(humans, agents)
 	
-	// Get optimization recommendations
-	changes := sc.eventProcessor.OptimizeWorkforce(humans, agents, availableBudget, availableCapacity)
+	// Get optimization recommendations, or the policy's action when one is set, held for review
+	// when an approver is set
+	var changes events.WorkforceChange
+	if sc.policy != nil {
+		changes = sc.policyChange(humans, agents, availableBudget, availableCapacity)
+	} else {
+		if sc.behaviors.Optimize != nil {
+			changes = sc.behaviors.Optimize(humans, agents, availableBudget, availableCapacity)
+		} else {
+			changes = sc.eventProcessor.OptimizeWorkforce(humans, agents, availableBudget, availableCapacity)
+		}
+		if sc.objective != nil {
+			sc.pursueObjective(&changes, agents)
+		}
+	}
+	decision := sc.reviewChange(&changes)
 	
 	// Execute agent releases first (to free up budget)
+	releasedIDs := make([]string, 0, len(changes.ReleaseAIAgents))
 	for _, agentID := range changes.ReleaseAIAgents {
 		err := sc.workforceManager.ReleaseAIAgent(agentID)
 		if err != nil {
 			fmt.Printf("Warning: Failed to release AI agent %s: %v\n", agentID, err)
+			continue
 		}
+		releasedIDs = append(releasedIDs, agentID)
+	}
+	released := len(releasedIDs)
+	sc.stepAgentsReleased += released
+	if released > 0 {
+		sc.recordEvent(types.AgentReleasedEvent, "released %d AI agents to stay within budget", released)
 	}
 	
-	// Execute agent hires
-	if changes.HireAIAgents > 0 && changes.OrchestratorID != "" {
+	// Execute agent hires unless AI hiring is frozen; releases above still go ahead
+	hired := 0
+	if changes.HireAIAgents > 0 && changes.OrchestratorID != "" && !sc.aiHiringFrozen() {
+		domainCounts := sc.countAgents(len(sc.config.Domains), func(agent *types.AIAgent) int { return agent.Domain })
+		vendorCounts := sc.countAgents(len(sc.config.Vendors.Tiers), func(agent *types.AIAgent) int { return agent.Vendor })
 		for i := 0; i < changes.HireAIAgents; i++ {
-			_, err := sc.workforceManager.AddAIAgent(changes.OrchestratorID, sc.currentTimeStep)
+			orchestratorID := changes.OrchestratorID
+			if i < len(changes.Assignments) {
+				orchestratorID = changes.Assignments[i]
+			}
+			agent, err := sc.workforceManager.AddAIAgent(orchestratorID, sc.currentTimeStep)
 			if err != nil {
-				// If we can't hire more agents, stop trying
-				fmt.Printf("Warning: Failed to hire AI agent: %v\n", err)
+				// If we can't hire more agents, stop trying; running out of capacity is expected
+				if !errors.Is(err, types.ErrNoOrchestrationCapacity) {
+					fmt.Printf("Warning: Failed to hire AI agent: %v\n", err)
+				}
 				break
 			}
+			if len(domainCounts) > 0 {
+				agent.Domain = sc.config.Domains.Assign(domainCounts)
+				domainCounts[agent.Domain]++
+			}
+			// The optimizer picks the vendor tier of its hires; other hires, such as a policy's,
+			// follow the vendor policy here
+			if len(vendorCounts) > 0 {
+				vendor := sc.config.Vendors.Select(vendorCounts)
+				if i < len(changes.Vendors) {
+					vendor = changes.Vendors[i]
+				}
+				agent.ApplyVendor(vendor, sc.config.Vendors.Tiers.Tier(vendor))
+				vendorCounts[agent.Vendor]++
+			}
+			hired++
+		}
+		sc.stepAgentsHired += hired
+		if hired > 0 && len(changes.Assignments) > 0 {
+			sc.recordEvent(types.AgentHiredEvent, "hired %d AI agents across %d orchestrators", hired, countDistinct(changes.Assignments[:hired]))
+		} else if hired > 0 {
+			sc.recordEvent(types.AgentHiredEvent, "hired %d AI agents orchestrated by %s", hired, changes.OrchestratorID)
+		}
+	}
+	
+	if decision != nil {
+		decision.Hires = hired
+		decision.Releases = releasedIDs
+		sc.decisions = append(sc.decisions, *decision)
+	}
+}
+
+// availableCapacity returns the number of AI agents the optimizer may still hire, limited by
+// orchestration capacity and the minimum human oversight ratio
+func (sc *SimulationController) availableCapacity(humans []*types.HumanWorker, agents []*types.AIAgent) int {
+	availableCapacity := sc.workforceManager.GetAvailableOrchestrationCapacity()
+	if oversight := sc.config.Oversight; oversight.Enabled() {
+		if headroom := oversight.MaxAgents(len(humans)) - len(agents); headroom < availableCapacity {
+			availableCapacity = headroom
+		}
+	}
+	return availableCapacity
+}
+
+// rebalanceAgents moves AI agents between orchestrators until their loads differ by at most
+// one, when rebalancing is configured
+func (sc *SimulationController) rebalanceAgents() {
+	if !sc.config.Assignment.Rebalance {
+		return
+	}
+	if moved := sc.workforceManager.RebalanceAgents(); moved > 0 {
+		sc.recordEvent(types.AgentsRebalancedEvent, "rebalanced %d AI agents across orchestrators", moved)
+	}
+}
+
+// countAgents returns the number of AI agents at each of n indexes, such as the domains, or nil
+// when n is 0
+func (sc *SimulationController) countAgents(n int, index func(agent *types.AIAgent) int) []int {
+	if n == 0 {
+		return nil
+	}
+	counts := make([]int, n)
+	for _, agent := range sc.workforceManager.GetAllAIAgents() {
+		if i := index(agent); i >= 0 && i < n {
+			counts[i]++
 		}
 	}
+	return counts
+}
+
+// countDistinct returns the number of distinct IDs in ids
+func countDistinct(ids []string) int {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
+
+// enforceOversight releases AI agents in excess of the minimum human oversight ratio,
+// least cost-effective first
+func (sc *SimulationController) enforceOversight() {
+	oversight := sc.config.Oversight
+	if !oversight.Enabled() {
+		return
+	}
+	
+	humanCount := len(sc.workforceManager.GetAllHumans())
+	agents := sc.workforceManager.GetAllAIAgents()
+	excess := len(agents) - oversight.MaxAgents(humanCount)
+	if excess <= 0 {
+		return
+	}
+	
+	released := 0
+	for _, agentID := range sc.eventProcessor.SelectExcessAgents(agents, excess) {
+		if err := sc.workforceManager.ReleaseAIAgent(agentID); err != nil {
+			fmt.Printf("Warning: Failed to release AI agent %s: %v\n", agentID, err)
+			continue
+		}
+		released++
+	}
+	sc.stepAgentsReleased += released
+	if released > 0 {
+		sc.recordEvent(types.OversightEnforcedEvent, "released %d AI agents to restore human oversight of %d humans per %d agents (%d humans remain)",
+			released, oversight.MinHumans, oversight.PerAgents, humanCount)
+	}
 }
 
 // checkEquilibrium determines if equilibrium conditions have been met
@@ -394,46 +1146,28 @@ func (sc *SimulationController) checkEquilibrium() {
 	// Simple equilibrium detection: check if workforce composition has been stable
 	// for the last few time steps
 	
-	const stabilityWindow = 5 // Number of time steps to check for stability
+	equilibrium := sc.config.Equilibrium
+	stabilityWindow := equilibrium.StabilityWindow()
+	
+	// The workforce cannot settle while market shocks are still to come
+	if sc.config.Shocks.Pending(sc.currentTimeStep) {
+		sc.equilibriumReached = false
+		return
+	}
 	
 	if len(sc.timeSeries) < stabilityWindow {
 		// Not enough history to determine stability
 		return
 	}
 	
-	// Get the last few states
+	// Check if workforce composition has remained stable over the last few states
 	recentStates := sc.timeSeries[len(sc.timeSeries)-stabilityWindow:]
-	
-	// Check if workforce composition has remained stable
-	firstState := recentStates[0]
-	isStable := true
-	
-	for i := 1; i < len(recentStates); i++ {
-		state := recentStates[i]
-		
-		// Compare workforce composition
-		if state.Workforce.Humans.Total != firstState.Workforce.Humans.Total ||
-			state.Workforce.AIAgents.Total != firstState.Workforce.AIAgents.Total {
-			isStable = false
-			break
-		}
-		
-		// Check if available budget is too low to hire more agents
-		// (indicating cost-effectiveness equilibrium)
-		if state.AvailableBudget > 0 {
-			// Still have budget, check if we have orchestration capacity
-			if state.Workforce.OrchestrationUtilization < 100.0 {
-				// Have both budget and capacity, but no hiring occurred
-				// This suggests equilibrium has been reached
-				continue
-			}
-		}
-	}
+	isStable := equilibrium.Settled(recentStates)
 	
 	// Additional check: if we have no available orchestration capacity
 	// and no budget for more humans, we've reached equilibrium
 	currentState := sc.captureCurrentState()
-	if currentState.Workforce.OrchestrationUtilization >= 100.0 || currentState.AvailableBudget <= 0 {
+	if currentState.Workforce.OrchestrationUtilization >= 100.0 || equilibrium.BudgetExhausted(currentState.AvailableBudget) {
 		isStable = true
 	}
 	
@@ -443,52 +1177,47 @@ func (sc *SimulationController) checkEquilibrium() {
 // Checks workforce composition stability according to requirements 8.1, 8.2, 8.3
 func (sc *SimulationController) IsEquilibrium() bool {
 	// Return the cached equilibrium state (updated in checkEquilibrium)
-	return sc.equilibriumReached
+	return sc.Snapshot().EquilibriumReached
 }
 
 // IsEquilibriumDetailed provides detailed equilibrium analysis
 // This method provides more granular equilibrium detection logic
 func (sc *SimulationController) IsEquilibriumDetailed() (bool, string) {
+	reason := sc.EquilibriumReasonDetailed()
+	return reason.Code != types.EquilibriumNotReached, reason.Message
+}
+
+// EquilibriumReasonDetailed classifies which equilibrium condition the latest state meets
+// Returns EquilibriumNotReached with an explanatory message if no condition is met
+func (sc *SimulationController) EquilibriumReasonDetailed() types.EquilibriumReason {
 	if len(sc.timeSeries) == 0 {
-		return false, "no simulation data available"
+		return types.EquilibriumReason{Code: types.EquilibriumNotReached, Message: "no simulation data available"}
 	}
 	
 	currentState := sc.timeSeries[len(sc.timeSeries)-1]
 	
 	// Check if we have reached maximum orchestration capacity
 	if currentState.Workforce.OrchestrationUtilization >= 100.0 {
-		return true, "maximum orchestration capacity reached"
+		return types.EquilibriumReason{Code: types.OrchestrationCapacityReached, Message: "maximum orchestration capacity reached"}
 	}
 	
 	// Check if we have no available budget for more agents
-	if currentState.AvailableBudget <= 0 {
-		return true, "no available budget for workforce expansion"
+	if sc.config.Equilibrium.BudgetExhausted(currentState.AvailableBudget) {
+		return types.EquilibriumReason{Code: types.BudgetExhausted, Message: "no available budget for workforce expansion"}
 	}
 	
 	// Check if the cost of adding additional AI agents exceeds productivity benefit
 	// This requires checking if we have budget and capacity but no hiring occurred
-	const stabilityWindow = 5
+	stabilityWindow := sc.config.Equilibrium.StabilityWindow()
 	if len(sc.timeSeries) >= stabilityWindow {
 		recentStates := sc.timeSeries[len(sc.timeSeries)-stabilityWindow:]
 		
 		// Check if workforce composition has been stable
-		firstState := recentStates[0]
-		isStable := true
-		
-		for i := 1; i < len(recentStates); i++ {
-			state := recentStates[i]
-			if state.Workforce.Humans.Total != firstState.Workforce.Humans.Total ||
-				state.Workforce.AIAgents.Total != firstState.Workforce.AIAgents.Total {
-				isStable = false
-				break
-			}
-		}
-		
-		if isStable {
+		if sc.config.Equilibrium.Settled(recentStates) {
 			// Check if we had opportunities to hire but didn't
 			hasOpportunity := false
 			for _, state := range recentStates {
-				if state.AvailableBudget > types.AIAgentCosts[types.UniversityHire] &&
+				if state.AvailableBudget > sc.config.Overhead.AICost(types.AIAgentCosts[types.UniversityHire])*sc.config.MarketConditions(state.TimeStep).AICost &&
 					state.Workforce.OrchestrationUtilization < 100.0 {
 					hasOpportunity = true
 					break
@@ -496,63 +1225,209 @@ func (sc *SimulationController) IsEquilibriumDetailed() (bool, string) {
 			}
 			
 			if hasOpportunity {
-				return true, "workforce composition stable despite hiring opportunities (cost-effectiveness equilibrium)"
+				return types.EquilibriumReason{
+					Code:    types.CostEffectivenessEquilibrium,
+					Message: "workforce composition stable despite hiring opportunities (cost-effectiveness equilibrium)",
+				}
 			} else {
-				return true, "workforce composition stable with no hiring opportunities"
+				return types.EquilibriumReason{
+					Code:    types.CompositionStable,
+					Message: "workforce composition stable with no hiring opportunities",
+				}
 			}
 		}
 	}
 	
-	return false, "equilibrium conditions not yet met"
+	return types.EquilibriumReason{Code: types.EquilibriumNotReached, Message: "equilibrium conditions not yet met"}
+}
+
+// currentEquilibriumReason returns the reason to record for the latest state
+// checkEquilibrium uses a slightly different window than EquilibriumReasonDetailed, so a
+// reached equilibrium without a matching detailed condition is reported as a stable composition
+func (sc *SimulationController) currentEquilibriumReason() types.EquilibriumReason {
+	if sc.insolvent {
+		return sc.insolvencyReason()
+	}
+	if sc.terminated() {
+		return sc.terminalReason
+	}
+	if !sc.equilibriumReached {
+		return types.EquilibriumReason{Code: types.EquilibriumNotReached}
+	}
+	
+	reason := sc.EquilibriumReasonDetailed()
+	if reason.Code == types.EquilibriumNotReached {
+		reason = types.EquilibriumReason{
+			Code:    types.CompositionStable,
+			Message: "workforce composition stable over the stability window",
+		}
+	}
+	return reason
 }
 // RunUntilEquilibrium executes the simulation loop until equilibrium is reached
 // Returns complete simulation result according to requirements 8.3, 8.4
 func (sc *SimulationController) RunUntilEquilibrium(maxTimeSteps int) (types.SimulationResult, error) {
+	return sc.RunUntilEquilibriumContext(context.Background(), maxTimeSteps)
+}
+
+// RunUntilEquilibriumContext is RunUntilEquilibrium stopping with ctx's error once ctx is done
+// The context is checked before every step, including the steps confirming the equilibrium
+func (sc *SimulationController) RunUntilEquilibriumContext(ctx context.Context, maxTimeSteps int) (types.SimulationResult, error) {
+	startedAt := time.Now()
+	
 	// Initialize the simulation if not already done
 	if len(sc.timeSeries) == 0 {
 		if err := sc.Initialize(); err != nil {
 			return types.SimulationResult{}, fmt.Errorf("initialization failed: %w", err)
 		}
 	}
+	if sc.startedAt.IsZero() {
+		sc.startedAt = startedAt
+	}
 	
 	// Execute simulation steps until equilibrium or max steps reached
-	for sc.currentTimeStep < maxTimeSteps && !sc.equilibriumReached {
+	// Steps are counted from the start of the run so warm-started runs get the full budget
+	for sc.currentTimeStep-sc.startTimeStep < maxTimeSteps && !sc.equilibriumReached && !sc.insolvent && !sc.terminated() {
+		if err := ctx.Err(); err != nil {
+			return types.SimulationResult{}, err
+		}
 		sc.Step()
+		if sc.invariantErr != nil {
+			return types.SimulationResult{}, sc.invariantErr
+		}
 		
 		// Safety check to prevent infinite loops
-		if sc.currentTimeStep >= maxTimeSteps {
+		if sc.currentTimeStep-sc.startTimeStep >= maxTimeSteps {
 			break
 		}
 	}
 	
-	// Determine final equilibrium state
+	// Determine final equilibrium state and why the run stopped
+	reason := sc.currentEquilibriumReason()
+	if !sc.equilibriumReached && !sc.insolvent && !sc.terminated() {
+		reason = types.EquilibriumReason{
+			Code:    types.MaxStepsReached,
+			Message: fmt.Sprintf("max steps (%d) reached without equilibrium", maxTimeSteps),
+		}
+	}
+	
 	var equilibriumState types.SimulationState
 	if len(sc.timeSeries) > 0 {
 		equilibriumState = sc.timeSeries[len(sc.timeSeries)-1]
 		equilibriumState.IsEquilibrium = sc.equilibriumReached
+		equilibriumState.EquilibriumReason = reason
+	}
+	timeToEquilibrium := sc.currentTimeStep - sc.startTimeStep
+	reachedEquilibrium, insolvent := sc.equilibriumReached, sc.insolvent
+	
+	// Optionally keep simulating to check that the equilibrium holds; the result still describes
+	// the equilibrium as first detected, and the extra steps are added to the time series
+	var confirmation *types.EquilibriumConfirmation
+	if reachedEquilibrium && sc.config.Equilibrium.ConfirmSteps > 0 {
+		var err error
+		if confirmation, err = sc.confirmEquilibrium(ctx, sc.config.Equilibrium.ConfirmSteps); err != nil {
+			return types.SimulationResult{}, err
+		}
 	}
 	
 	// Create and return simulation result
 	result := types.SimulationResult{
 		Config:                    sc.config,
-		TimeSeries:               sc.timeSeries,
+		TimeSeries:               capped(sc.timeSeries),
 		EquilibriumState:         equilibriumState,
-		TimeToEquilibrium:        sc.currentTimeStep,
+		TimeToEquilibrium:        timeToEquilibrium,
+		ReachedEquilibrium:       reachedEquilibrium,
+		Insolvent:                insolvent,
+		EquilibriumReason:        reason,
+		Status:                   types.RunStatusOf(reason.Code),
 		TotalCatastrophicFailures: sc.totalCatastrophicFailures,
+		Failures:                 sc.failures,
+		Confirmation:             confirmation,
+		Decisions:                capped(sc.decisions),
+		Alerts:                   capped(sc.alerts),
+		Events:                   capped(sc.eventLog),
+		Metadata:                 sc.RunMetadata(),
 	}
 	
 	return result, nil
 }
 
+// confirmEquilibrium keeps simulating for up to the given number of steps after equilibrium was
+// first detected and reports whether it held
+// It stops early at a catastrophic failure or market shock, and once the run becomes insolvent or
+// reaches a terminal state, which also counts as losing the equilibrium. It fails with ctx's
+// error once ctx is done and with the error of a violated invariant
+func (sc *SimulationController) confirmEquilibrium(ctx context.Context, steps int) (*types.EquilibriumConfirmation, error) {
+	confirmation := &types.EquilibriumConfirmation{Persisted: true}
+	for confirmation.Steps < steps && !sc.insolvent && !sc.terminated() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		events := len(sc.eventLog)
+		sc.Step()
+		confirmation.Steps++
+		if sc.invariantErr != nil {
+			return nil, sc.invariantErr
+		}
+		
+		if confirmation.Persisted && (!sc.equilibriumReached || sc.insolvent || sc.terminated()) {
+			confirmation.Persisted = false
+			confirmation.LostAt = sc.currentTimeStep
+		}
+		if sc.disruptedSince(events) {
+			confirmation.Disrupted = true
+			break
+		}
+	}
+	return confirmation, nil
+}
+
+// disruptedSince reports whether a catastrophic failure or market shock has been logged since
+// the given number of events
+func (sc *SimulationController) disruptedSince(events int) bool {
+	for _, event := range sc.eventLog[events:] {
+		if event.Type == types.CatastrophicFailureEvent || event.Type == types.MarketShockEvent {
+			return true
+		}
+	}
+	return false
+}
+
 // Reset resets the simulation controller to initial state
 // Useful for running multiple simulations with the same configuration
 func (sc *SimulationController) Reset() {
+	defer sc.publish()
+	
 	sc.currentTimeStep = 0
+	sc.startTimeStep = 0
 	sc.timeSeries = make([]types.SimulationState, 0)
 	sc.totalCatastrophicFailures = 0
 	sc.equilibriumReached = false
+	sc.eventLog = make([]types.SimulationEvent, 0)
+	sc.pendingBackfills = nil
+	sc.recoveries = nil
+	sc.failures = nil
+	sc.forcedFailure = nil
+	sc.decisions = nil
+	sc.ruleActions = events.RuleActions{}
+	sc.alerts = nil
+	sc.runID = ""
+	sc.startedAt = time.Time{}
+	sc.origin = nil
+	sc.lineage = nil
+	sc.cashBalance = sc.initialCashBalance()
+	sc.insolvent = false
+	sc.terminalReason = types.EquilibriumReason{}
+	sc.entitySteps = 0
+	sc.invariantErr = nil
 	
-	// Reset component states
-	sc.workforceManager = workforce.NewWorkforceManager()
-	sc.economicModel = economic.NewEconomicModel(sc.config.FixedBudget, sc.config.RevenueScenario)
+	// Reset component states, keeping any injected components as they are
+	if !sc.injectedWorkforceManager {
+		sc.workforceManager = workforce.NewWorkforceManager()
+	}
+	if !sc.injectedEconomicModel {
+		economicModel := economic.NewEconomicModel(sc.config.WorkforceBudget(), sc.config.RevenueScenario, sc.config.Overhead, sc.config.Financing, sc.config.RevenueVolatility, sc.config.MarketSize, sc.streams)
+		economicModel.SetAIPricing(sc.config.AIPricing)
+		sc.economicModel = economicModel
+	}
 }
\ No newline at end of file
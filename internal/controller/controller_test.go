@@ -1,6 +1,11 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 	"workforce-ai-transition-simulator/internal/types"
 )
@@ -342,4 +347,1306 @@ func TestIsEquilibriumDetailed(t *testing.T) {
 	// Check equilibrium status
 	isEq, reason = controller.IsEquilibriumDetailed()
 	t.Logf("Equilibrium status after 3 steps: %v, reason: %s", isEq, reason)
-}
\ No newline at end of file
+}
+func TestSameSeedIsReproducible(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 0.1
+
+	first, err := NewSimulationController(config, 99).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		again, err := NewSimulationController(config, 99).RunUntilEquilibrium(100)
+		if err != nil {
+			t.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+		if !reflect.DeepEqual(first.TimeSeries, again.TimeSeries) {
+			t.Fatal("Expected identical time series for runs with the same seed")
+		}
+	}
+}
+
+// benchmarkConfig returns a medium-sized configuration that keeps the workforce busy for many steps
+func benchmarkConfig() types.SimulationConfig {
+	return types.SimulationConfig{
+		InitialHumans: 50,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     20000000.0,
+		RevenueScenario: types.ExplosiveGrowth,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        10.0,
+			ForcedAcceleration: 1.0,
+		},
+		CatastrophicFailureRate: 0.02,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+func BenchmarkStep(b *testing.B) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	if err := controller.Initialize(); err != nil {
+		b.Fatalf("Initialize failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		controller.Step()
+	}
+}
+
+func BenchmarkRunUntilEquilibrium(b *testing.B) {
+	config := benchmarkConfig()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		controller := NewSimulationController(config, int64(i))
+		if _, err := controller.RunUntilEquilibrium(200); err != nil {
+			b.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+	}
+}
+
+func TestEventLogRecordsHires(t *testing.T) {
+	config := benchmarkConfig()
+	controller := NewSimulationController(config, 12345)
+
+	result, err := controller.RunUntilEquilibrium(20)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	if len(result.Events) == 0 {
+		t.Fatal("Expected events to be recorded")
+	}
+
+	hires := 0
+	lastStep := 0
+	for _, event := range result.Events {
+		if event.TimeStep < lastStep {
+			t.Errorf("Expected events in time order, got step %d after %d", event.TimeStep, lastStep)
+		}
+		lastStep = event.TimeStep
+		if event.Type == types.AgentHiredEvent {
+			hires++
+		}
+	}
+	if hires == 0 {
+		t.Error("Expected at least one hiring event")
+	}
+}
+
+func TestCostBreakdownMatchesTotalCost(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig = types.AttritionConfig{
+		Type:               types.ReductionInForce,
+		NaturalRate:        30.0,
+		ForcedAcceleration: 2.0,
+		SeveranceMonths:    3.0,
+	}
+	controller := NewSimulationController(config, 12345)
+
+	result, err := controller.RunUntilEquilibrium(30)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	totalSeverance := 0.0
+	for _, state := range result.TimeSeries {
+		breakdown := state.CostBreakdown
+		runRate := breakdown.TotalHumanPayroll() + breakdown.TotalAICost()
+		if diff := runRate - state.TotalCost; diff > 1e-6 || diff < -1e-6 {
+			t.Errorf("Step %d: payroll and AI cost sum to %.2f, expected total cost %.2f", state.TimeStep, runRate, state.TotalCost)
+		}
+		totalSeverance += breakdown.Severance
+	}
+
+	if totalSeverance <= 0 {
+		t.Error("Expected severance to be paid under reduction in force")
+	}
+}
+
+func TestRevenueAttributionSumsToRevenue(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+
+	result, err := controller.RunUntilEquilibrium(30)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	for _, state := range result.TimeSeries {
+		attributed := state.RevenueAttribution.TotalHuman() + state.RevenueAttribution.TotalAI()
+		if diff := attributed - state.RevenueOutput; diff > 1e-3 || diff < -1e-3 {
+			t.Errorf("Step %d: attributed revenue %.2f does not match revenue %.2f", state.TimeStep, attributed, state.RevenueOutput)
+		}
+	}
+
+	final := result.EquilibriumState
+	if final.Workforce.AIAgents.Total > 0 && final.RevenueAttribution.AIShare() <= 0 {
+		t.Error("Expected AI agents to be credited with a share of final revenue")
+	}
+}
+
+func TestEquilibriumReasonRecorded(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+
+	result, err := controller.RunUntilEquilibrium(500)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if !result.EquilibriumState.IsEquilibrium {
+		t.Fatal("Expected the benchmark configuration to reach equilibrium")
+	}
+
+	reason := result.EquilibriumReason
+	if reason.Code == types.EquilibriumNotReached || reason.Code == types.MaxStepsReached {
+		t.Errorf("Expected an equilibrium trigger, got %s", reason.Code)
+	}
+	if reason.Message == "" {
+		t.Error("Expected a human-readable equilibrium message")
+	}
+	if result.EquilibriumState.EquilibriumReason != reason {
+		t.Errorf("Expected equilibrium state reason %+v to match result reason %+v", result.EquilibriumState.EquilibriumReason, reason)
+	}
+	if last := result.TimeSeries[len(result.TimeSeries)-1]; last.EquilibriumReason.Code != reason.Code {
+		t.Errorf("Expected final time series state to record %s, got %s", reason.Code, last.EquilibriumReason.Code)
+	}
+}
+
+func TestEquilibriumReasonMaxStepsReached(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+
+	result, err := controller.RunUntilEquilibrium(1)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	if result.EquilibriumReason.Code != types.MaxStepsReached {
+		t.Errorf("Expected %s, got %s", types.MaxStepsReached, result.EquilibriumReason.Code)
+	}
+	if result.EquilibriumState.EquilibriumReason.Code != types.MaxStepsReached {
+		t.Errorf("Expected equilibrium state to record %s", types.MaxStepsReached)
+	}
+}
+
+func TestReachedEquilibriumFlag(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	result, err := controller.RunUntilEquilibrium(1)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.ReachedEquilibrium {
+		t.Error("Expected a one-step run not to reach equilibrium")
+	}
+	if result.TimeToEquilibrium != 1 {
+		t.Errorf("Expected the run to stop at the step limit, got %d", result.TimeToEquilibrium)
+	}
+
+	controller = NewSimulationController(benchmarkConfig(), 12345)
+	result, err = controller.RunUntilEquilibrium(500)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if !result.ReachedEquilibrium {
+		t.Error("Expected the benchmark configuration to reach equilibrium")
+	}
+}
+
+func TestHiringFreezeStopsAIHiring(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.Type = types.HiringFreeze
+	config.AttritionConfig.FreezeAIHiring = true
+	config.CatastrophicFailureRate = 0
+
+	controller := NewSimulationController(config, 12345)
+	result, err := controller.RunUntilEquilibrium(50)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	for _, state := range result.TimeSeries {
+		if state.Workforce.AIAgents.Total != 0 {
+			t.Fatalf("Expected no AI agents under a full hiring freeze, got %d at step %d", state.Workforce.AIAgents.Total, state.TimeStep)
+		}
+	}
+	if _, err := controller.HireHuman(types.MidLevel, types.HighCostUS); err == nil {
+		t.Error("Expected human hiring to be rejected under a full hiring freeze")
+	}
+}
+
+// TestHiringFreezeHumansOnly pins the meaning of Hiring_Freeze in configurations written before
+// the freeze flags existed: human hiring stops and AI agents are still hired
+func TestHiringFreezeHumansOnly(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.Type = types.HiringFreeze
+
+	controller := NewSimulationController(config, 12345)
+	result, err := controller.RunUntilEquilibrium(50)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.EquilibriumState.Workforce.AIAgents.Total == 0 {
+		t.Error("Expected AI agents to be hired when only human hiring is frozen")
+	}
+	if _, err := controller.HireHuman(types.MidLevel, types.HighCostUS); err == nil {
+		t.Error("Expected human hiring to be rejected")
+	}
+}
+
+func TestHireHuman(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	before := len(controller.workforceManager.GetAllHumans())
+
+	human, err := controller.HireHuman(types.Senior, types.LowCostNonUS)
+	if err != nil {
+		t.Fatalf("HireHuman failed: %v", err)
+	}
+	if human.IsBusinessOwner {
+		t.Error("Expected a hired human not to be the business owner")
+	}
+	if got := len(controller.workforceManager.GetAllHumans()); got != before+1 {
+		t.Errorf("Expected %d humans after hiring, got %d", before+1, got)
+	}
+	events := controller.GetEvents()
+	if len(events) == 0 || events[len(events)-1].Type != types.HumanHiredEvent {
+		t.Error("Expected the hire to be recorded in the event log")
+	}
+}
+
+func TestBackfillReplacesAttritionAfterLag(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 60.0
+	config.Backfill = types.BackfillConfig{Fraction: 1.0, LagSteps: 2, RecruitingCost: 15000}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		controller.Step()
+	}
+
+	departures := make(map[int]int)
+	hires := 0
+	for _, event := range controller.GetEvents() {
+		switch event.Type {
+		case types.AttritionEvent:
+			departures[event.TimeStep]++
+		case types.HumanHiredEvent:
+			hires++
+			if departures[event.TimeStep-2] == 0 {
+				t.Errorf("Expected hire at step %d to follow a departure two steps earlier", event.TimeStep)
+			}
+		}
+	}
+	if hires == 0 {
+		t.Fatal("Expected departures to be backfilled")
+	}
+
+	recruiting := 0.0
+	for _, state := range controller.GetTimeSeries() {
+		recruiting += state.CostBreakdown.Recruiting
+	}
+	if recruiting != float64(hires)*15000 {
+		t.Errorf("Expected recruiting cost %.0f for %d hires, got %.0f", float64(hires)*15000, hires, recruiting)
+	}
+}
+
+func TestBackfillOffByDefault(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 60.0
+
+	controller := NewSimulationController(config, 12345)
+	if _, err := controller.RunUntilEquilibrium(30); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	for _, event := range controller.GetEvents() {
+		if event.Type == types.HumanHiredEvent {
+			t.Fatal("Expected no backfill hires without a backfill policy")
+		}
+	}
+}
+
+func TestOwnerAttritionRecordsSuccession(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 100.0
+	config.AttritionConfig.OwnerAttrition = true
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	original, _ := controller.workforceManager.GetBusinessOwner()
+	for i := 0; i < 60; i++ {
+		controller.Step()
+	}
+
+	successions := 0
+	for _, event := range controller.GetEvents() {
+		if event.Type == types.OwnerSuccessionEvent {
+			successions++
+		}
+	}
+	if successions == 0 {
+		t.Fatal("Expected the business owner to leave and be succeeded")
+	}
+
+	owner, err := controller.workforceManager.GetBusinessOwner()
+	if err != nil {
+		t.Fatalf("Expected a business owner after succession: %v", err)
+	}
+	if owner.ID == original.ID {
+		t.Error("Expected the original business owner to have been replaced")
+	}
+}
+
+func TestOwnerStaysWithoutOwnerAttrition(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 100.0
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	original, _ := controller.workforceManager.GetBusinessOwner()
+	for i := 0; i < 60; i++ {
+		controller.Step()
+	}
+
+	owner, err := controller.workforceManager.GetBusinessOwner()
+	if err != nil || owner.ID != original.ID {
+		t.Errorf("Expected %s to remain the business owner", original.ID)
+	}
+}
+
+func TestOnlyOwnerRemainingEndsRun(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 100.0
+	// A late market shock keeps the run from settling into equilibrium before attrition runs its course
+	config.Shocks = types.MarketShocks{{TimeStep: 400, Type: types.DemandShock, Change: -10}}
+
+	controller := NewSimulationController(config, 12345)
+	result, err := controller.RunUntilEquilibrium(500)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	if result.Status != types.StatusOnlyOwnerRemains || result.ReachedEquilibrium {
+		t.Fatalf("Expected the run to end with only the owner remaining, got status %s (%s)", result.Status, result.EquilibriumReason.Message)
+	}
+	if result.EquilibriumReason.Code != types.OnlyOwnerRemains || !result.Status.Terminal() {
+		t.Errorf("Expected terminal reason %s, got %s", types.OnlyOwnerRemains, result.EquilibriumReason.Code)
+	}
+	if humans := result.EquilibriumState.Workforce.Humans.Total; humans != 1 {
+		t.Errorf("Expected only the owner to remain, got %d humans", humans)
+	}
+	if result.TimeToEquilibrium >= 400 {
+		t.Error("Expected the run to stop before the market shock")
+	}
+	if events := controller.GetEvents(); events[len(events)-1].Type != types.TerminalStateEvent {
+		t.Errorf("Expected the run to end with a terminal state event, got %s", events[len(events)-1].Type)
+	}
+}
+
+func TestSoloOwnerIsNotTerminal(t *testing.T) {
+	config := benchmarkConfig()
+	config.InitialHumans = 1
+
+	result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(200)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.Status.Terminal() {
+		t.Errorf("Expected an organization that started with only its owner to run normally, got %s", result.Status)
+	}
+}
+
+func TestFailureRecoveryChargesRemediationAndDepressesProductivity(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 1.0
+
+	baseline := NewSimulationController(config, 12345)
+	config.Recovery = types.FailureRecoveryConfig{RemediationCost: 100000, RecoverySteps: 3, SeniorOccupancy: 0.5}
+	recovering := NewSimulationController(config, 12345)
+	for _, controller := range []*SimulationController{baseline, recovering} {
+		if err := controller.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+	}
+
+	// Both runs draw the same failure, so they differ only by its recovery
+	without, with := baseline.Step(), recovering.Step()
+	if without.CostBreakdown.Remediation != 0 || without.RecoveryLoss != 0 {
+		t.Errorf("Expected no remediation or recovery by default, got %.2f and %.4f", without.CostBreakdown.Remediation, without.RecoveryLoss)
+	}
+	if remediation := with.CostBreakdown.Remediation; remediation <= 0 || remediation > 100000 {
+		t.Errorf("Expected a remediation cost scaled by severity, got %.2f", remediation)
+	}
+	if with.TotalProductivity >= without.TotalProductivity {
+		t.Errorf("Expected recovery to occupy senior humans and depress productivity, got %.2f vs %.2f", with.TotalProductivity, without.TotalProductivity)
+	}
+}
+
+func TestFailureRecoveriesEndAfterTheirDuration(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	controller.recoveries = []types.FailureRecovery{
+		{EndTimeStep: 3, ProductivityLoss: 0.2},
+		{EndTimeStep: 5, ProductivityLoss: 0.1},
+	}
+
+	controller.currentTimeStep = 3
+	controller.endRecoveries()
+	if loss, recovering := controller.recoveryLoss(); !recovering || loss != 0.2 {
+		t.Errorf("Expected the largest loss of the recoveries under way, got %.2f (recovering %t)", loss, recovering)
+	}
+
+	controller.currentTimeStep = 4
+	controller.endRecoveries()
+	if loss, _ := controller.recoveryLoss(); loss != 0.1 {
+		t.Errorf("Expected the first recovery to have ended, got loss %.2f", loss)
+	}
+
+	controller.currentTimeStep = 6
+	controller.endRecoveries()
+	if _, recovering := controller.recoveryLoss(); recovering {
+		t.Error("Expected every recovery to have ended")
+	}
+}
+
+func TestInitialContractMix(t *testing.T) {
+	config := benchmarkConfig()
+	config.Contracts = types.ContractConfig{ContractorShare: 20, PartTimeShare: 10}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	composition := controller.GetTimeSeries()[0].Workforce
+	byContract := composition.Humans.ByContractType
+	if byContract[types.Contractor] != 10 || byContract[types.PartTime] != 5 || byContract[types.FullTime] != 35 {
+		t.Errorf("Expected 35 FTE, 10 contractors and 5 part-time, got %v", byContract)
+	}
+
+	owner, _ := controller.workforceManager.GetBusinessOwner()
+	if owner.ContractType != types.FullTime {
+		t.Errorf("Expected the business owner to be a full-time employee, got %s", owner.ContractType)
+	}
+
+	// Contracts are spread across experience levels rather than filling the first level
+	contractorLevels := make(map[types.ExperienceLevel]bool)
+	for _, human := range controller.workforceManager.GetAllHumans() {
+		if human.ContractType == types.Contractor {
+			contractorLevels[human.ExperienceLevel] = true
+		}
+	}
+	if len(contractorLevels) < 3 {
+		t.Errorf("Expected contractors across experience levels, got %v", contractorLevels)
+	}
+}
+
+func TestRegionsDistributeInitialWorkforce(t *testing.T) {
+	config := benchmarkConfig()
+	config.Regions = types.RegionTable{
+		{Name: "US", Share: 50, CostMultiplier: 1.0},
+		{Name: "EU", Share: 30, CostMultiplier: 0.8, TimeZoneInefficiency: 0.1},
+		{Name: "India", Share: 20, CostMultiplier: 0.35, TimeZoneInefficiency: 0.3},
+	}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	byRegion := controller.GetTimeSeries()[0].Workforce.Humans.ByCostCategory
+	if byRegion[0] != 25 || byRegion[1] != 15 || byRegion[2] != 10 {
+		t.Errorf("Expected 25 US, 15 EU and 10 India workers, got %v", byRegion)
+	}
+
+	expectedProductivity := 0.0
+	for _, human := range controller.workforceManager.GetAllHumans() {
+		region := config.Regions[human.CostCategory]
+		if expected := types.BaseCosts[human.ExperienceLevel][types.HighCostUS] * region.CostMultiplier; human.BaseCost != expected {
+			t.Errorf("%s in %s costs %.2f, want %.2f", human.ID, region.Name, human.BaseCost, expected)
+		}
+		expectedProductivity += human.BaseProductivity * (1 - region.TimeZoneInefficiency)
+	}
+	if got := controller.GetTimeSeries()[0].TotalProductivity; math.Abs(got-expectedProductivity) > 1e-9 {
+		t.Errorf("Expected productivity %.4f after regional penalties, got %.4f", expectedProductivity, got)
+	}
+
+	human, err := controller.HireHuman(types.MidLevel, 2)
+	if err != nil {
+		t.Fatalf("HireHuman failed: %v", err)
+	}
+	if human.BaseCost != 150000*0.35 {
+		t.Errorf("Expected an India mid-level hire to cost %.2f, got %.2f", 150000*0.35, human.BaseCost)
+	}
+	events := controller.GetEvents()
+	if description := events[len(events)-1].Description; !strings.Contains(description, "India") {
+		t.Errorf("Expected the hire event to name the region, got %q", description)
+	}
+}
+
+func TestWorkloadShortfallCausesOvertimeAndBurnout(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig = types.AttritionConfig{Type: types.HiringFreeze, NaturalRate: 30.0, ForcedAcceleration: 1.0, FreezeAIHiring: true}
+	config.CatastrophicFailureRate = 0
+	config.Workload = types.WorkloadConfig{Required: 1000, MaxOvertime: 0.1, BurnoutAttrition: 2.0}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	previousBurnout := 0.0
+	for step := 1; step <= 6; step++ {
+		state := controller.Step()
+
+		// 50 humans produce far less than 1000 units, so overtime is capped every step
+		if state.Overtime != 0.1 {
+			t.Fatalf("Step %d: expected capped overtime of 0.1, got %v", step, state.Overtime)
+		}
+		if math.Abs(state.Burnout-(previousBurnout+0.1)) > 1e-12 {
+			t.Errorf("Step %d: expected burnout %.2f, got %v", step, previousBurnout+0.1, state.Burnout)
+		}
+		previousBurnout = state.Burnout
+
+		humanProductivity := controller.workforceManager.CalculateHumanProductivity(controller.regions)
+		if math.Abs(state.TotalProductivity-humanProductivity*1.1) > 1e-9 {
+			t.Errorf("Step %d: expected productivity %.4f with overtime, got %.4f", step, humanProductivity*1.1, state.TotalProductivity)
+		}
+		attributed := state.RevenueAttribution.TotalHuman() + state.RevenueAttribution.TotalAI()
+		if math.Abs(attributed-state.RevenueOutput) > 1e-6 {
+			t.Errorf("Step %d: attributed revenue %.2f does not add up to %.2f", step, attributed, state.RevenueOutput)
+		}
+	}
+
+	// Burnout of 0.6 with a factor of 2 more than doubles the attrition rate
+	if multiplier := config.Workload.AttritionMultiplier(previousBurnout); math.Abs(multiplier-2.2) > 1e-12 {
+		t.Errorf("Expected an attrition multiplier of 2.2, got %v", multiplier)
+	}
+}
+
+func TestWorkloadMetIncursNoOvertime(t *testing.T) {
+	config := benchmarkConfig()
+	config.Workload = types.WorkloadConfig{Required: 1}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	state := controller.Step()
+	if state.Overtime != 0 || state.Burnout != 0 {
+		t.Errorf("Expected no overtime or burnout when the workload is met, got %v and %v", state.Overtime, state.Burnout)
+	}
+}
+
+func TestOversightRatioLimitsAIAgents(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 40.0
+	config.Oversight = types.OversightConfig{MinHumans: 1, PerAgents: 2}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	for step := 1; step <= 24; step++ {
+		state := controller.Step()
+		if state.Workforce.AIAgents.Total > 2*state.Workforce.Humans.Total {
+			t.Fatalf("Step %d: %d AI agents exceed the oversight limit for %d humans",
+				step, state.Workforce.AIAgents.Total, state.Workforce.Humans.Total)
+		}
+	}
+
+	// Attrition shrinks the human workforce below the oversight the hired agents need
+	enforced := 0
+	for _, event := range controller.GetEvents() {
+		if event.Type == types.OversightEnforcedEvent {
+			enforced++
+		}
+	}
+	if enforced == 0 {
+		t.Error("Expected attrition to force AI agent releases to restore oversight")
+	}
+}
+
+func TestReductionInForceReleasesContractorsFirstWithoutSeverance(t *testing.T) {
+	config := benchmarkConfig()
+	config.Contracts = types.ContractConfig{ContractorShare: 20}
+	config.AttritionConfig = types.AttritionConfig{
+		Type:               types.ReductionInForce,
+		ForcedAcceleration: 10.0, // 5 of 50 humans, all of them contractors
+		SeveranceMonths:    3.0,
+	}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	state := controller.Step()
+
+	if got := state.Workforce.Humans.ByContractType[types.Contractor]; got != 5 {
+		t.Errorf("Expected 5 contractors to remain, got %d", got)
+	}
+	if got := state.Workforce.Humans.ByContractType[types.FullTime]; got != 40 {
+		t.Errorf("Expected all 40 employees to remain, got %d", got)
+	}
+	if state.CostBreakdown.Severance != 0 {
+		t.Errorf("Expected no severance for contractors, got %.2f", state.CostBreakdown.Severance)
+	}
+}
+
+func TestValidateConfigurationReportsField(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.NaturalRate = 120.0
+
+	err := NewSimulationController(config, 1).Initialize()
+	if !errors.Is(err, types.ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+
+	var configErr *types.ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("Expected a *types.ConfigError, got %T", err)
+	}
+	if configErr.Field != "AttritionConfig.NaturalRate" {
+		t.Errorf("Expected field AttritionConfig.NaturalRate, got %s", configErr.Field)
+	}
+	if configErr.Value != 120.0 {
+		t.Errorf("Expected value 120, got %v", configErr.Value)
+	}
+}
+
+func TestInitialWorkforceOverBudget(t *testing.T) {
+	config := benchmarkConfig()
+	config.FixedBudget = 1000.0
+
+	err := NewSimulationController(config, 1).Initialize()
+	if !errors.Is(err, types.ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded, got %v", err)
+	}
+	if errors.Is(err, types.ErrInvalidConfig) {
+		t.Error("Expected a budget error not to be reported as an invalid configuration")
+	}
+}
+
+func TestDemandShockScalesRevenueFromItsTimeStep(t *testing.T) {
+	baseline := NewSimulationController(benchmarkConfig(), 12345)
+	config := benchmarkConfig()
+	config.Shocks = types.MarketShocks{{TimeStep: 3, Type: types.DemandShock, Change: -50}}
+	shocked := NewSimulationController(config, 12345)
+	if err := baseline.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := shocked.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	// Demand does not change costs, so both workforces evolve identically
+	for step := 1; step <= 6; step++ {
+		want := baseline.Step().RevenueOutput
+		state := shocked.Step()
+		if step >= 3 {
+			want *= 0.5
+		}
+		if math.Abs(state.RevenueOutput-want) > 1e-6 {
+			t.Errorf("Step %d: expected revenue %.2f, got %.2f", step, want, state.RevenueOutput)
+		}
+		if got := len(state.MarketShocks); (step == 3) != (got == 1) {
+			t.Errorf("Step %d: expected the shock to be recorded only at step 3, got %d shocks", step, got)
+		}
+	}
+
+	events := 0
+	for _, event := range shocked.GetEvents() {
+		if event.Type == types.MarketShockEvent {
+			events++
+			if event.TimeStep != 3 {
+				t.Errorf("Expected the market shock event at step 3, got step %d", event.TimeStep)
+			}
+		}
+	}
+	if events != 1 {
+		t.Errorf("Expected 1 market shock event, got %d", events)
+	}
+}
+
+func TestPendingMarketShockDelaysEquilibrium(t *testing.T) {
+	config := benchmarkConfig()
+	config.Shocks = types.MarketShocks{{TimeStep: 60, Type: types.AICostShock, Change: -50}}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	result, err := controller.RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.TimeToEquilibrium < 60 {
+		t.Errorf("Expected equilibrium no earlier than the shock at step 60, got step %d", result.TimeToEquilibrium)
+	}
+}
+
+func TestEquilibriumConfigTunesStability(t *testing.T) {
+	run := func(equilibrium types.EquilibriumConfig) types.SimulationResult {
+		config := benchmarkConfig()
+		config.AttritionConfig.ForcedAcceleration = 0
+		config.Equilibrium = equilibrium
+		result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+		if err != nil {
+			t.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+		if !result.ReachedEquilibrium {
+			t.Fatalf("Expected equilibrium with %+v, got %s", equilibrium, result.EquilibriumReason.Code)
+		}
+		return result
+	}
+
+	standard := run(types.EquilibriumConfig{})
+	if standard.EquilibriumReason.Code != types.BudgetExhausted {
+		t.Fatalf("Expected the default run to settle on an exhausted budget, got %s", standard.EquilibriumReason.Code)
+	}
+
+	slack := run(types.EquilibriumConfig{BudgetSlack: 5000000})
+	if slack.TimeToEquilibrium >= standard.TimeToEquilibrium || slack.EquilibriumState.AvailableBudget > 5000000 {
+		t.Errorf("Expected budget slack to settle earlier with budget left, got step %d vs %d with %.0f left",
+			slack.TimeToEquilibrium, standard.TimeToEquilibrium, slack.EquilibriumState.AvailableBudget)
+	}
+
+	longer := run(types.EquilibriumConfig{Window: 60})
+	if longer.TimeToEquilibrium < 59 {
+		t.Errorf("Expected a 60-step window to hold off equilibrium until step 59, got step %d", longer.TimeToEquilibrium)
+	}
+}
+
+func TestEquilibriumConfirmationContinuesRun(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.ForcedAcceleration = 0
+	config.CatastrophicFailureRate = 0
+	first, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if !first.ReachedEquilibrium || first.Confirmation != nil {
+		t.Fatalf("Expected equilibrium without confirmation, got %t and %+v", first.ReachedEquilibrium, first.Confirmation)
+	}
+
+	config.Equilibrium.ConfirmSteps = 10
+	confirmed, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if confirmed.Confirmation == nil || confirmed.Confirmation.Steps != 10 || confirmed.Confirmation.Disrupted {
+		t.Fatalf("Expected 10 undisrupted confirmation steps, got %+v", confirmed.Confirmation)
+	}
+	// The optimizer keeps releasing agents after the budget runs out, so the equilibrium does not hold
+	if confirmed.Confirmation.Persisted || confirmed.Confirmation.LostAt <= first.TimeToEquilibrium ||
+		confirmed.Confirmation.LostAt > first.TimeToEquilibrium+10 {
+		t.Errorf("Expected the equilibrium to be lost within the confirmation steps, got %+v", confirmed.Confirmation)
+	}
+	if confirmed.TimeToEquilibrium != first.TimeToEquilibrium || len(confirmed.TimeSeries) != len(first.TimeSeries)+10 {
+		t.Errorf("Expected the equilibrium as first detected plus 10 more states, got step %d with %d states (vs %d with %d)",
+			confirmed.TimeToEquilibrium, len(confirmed.TimeSeries), first.TimeToEquilibrium, len(first.TimeSeries))
+	}
+
+	config.CatastrophicFailureRate = 1
+	disrupted, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if disrupted.ReachedEquilibrium && (disrupted.Confirmation == nil || !disrupted.Confirmation.Disrupted || disrupted.Confirmation.Steps != 1) {
+		t.Errorf("Expected a catastrophic failure to end the confirmation after one step, got %+v", disrupted.Confirmation)
+	}
+}
+
+func TestRunUntilEquilibriumContextStopsDuringConfirmation(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig.ForcedAcceleration = 0
+	config.CatastrophicFailureRate = 0
+	first, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+	if err != nil || !first.ReachedEquilibrium {
+		t.Fatalf("Expected the run to reach equilibrium, got %v", err)
+	}
+	config.Equilibrium.ConfirmSteps = 10
+
+	// Cancelled at the step equilibrium is detected, the run stops before confirming it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	canceller := func(_ *SimulationController, state types.SimulationState) error {
+		if state.TimeStep == first.TimeToEquilibrium {
+			cancel()
+		}
+		return nil
+	}
+	sc := NewSimulationController(config, 12345, WithInvariantChecker(canceller))
+	if _, err := sc.RunUntilEquilibriumContext(ctx, 100); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the confirmation to stop with the context's error, got %v", err)
+	}
+	if sc.GetCurrentTimeStep() != first.TimeToEquilibrium {
+		t.Errorf("Expected no confirmation step after cancellation, stopped at step %d", sc.GetCurrentTimeStep())
+	}
+
+	// An invariant violated while confirming fails the run
+	broken := errors.New("broken")
+	checker := func(_ *SimulationController, state types.SimulationState) error {
+		if state.TimeStep == first.TimeToEquilibrium+2 {
+			return broken
+		}
+		return nil
+	}
+	if _, err := NewSimulationController(config, 12345, WithInvariantChecker(checker)).RunUntilEquilibrium(100); !errors.Is(err, broken) {
+		t.Errorf("Expected the invariant error of a confirmation step, got %v", err)
+	}
+}
+
+func TestInsolvencyEndsRun(t *testing.T) {
+	config := benchmarkConfig()
+	config.Cash = types.CashConfig{Enabled: true, InitialBalance: 2000000, CreditLimit: 500000}
+	config.Shocks = types.MarketShocks{{TimeStep: 1, Type: types.DemandShock, Change: -90}}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	result, err := controller.RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	if !result.Insolvent || result.ReachedEquilibrium {
+		t.Fatalf("Expected the run to end insolvent without equilibrium, got insolvent=%t equilibrium=%t", result.Insolvent, result.ReachedEquilibrium)
+	}
+	if result.EquilibriumReason.Code != types.Insolvent {
+		t.Errorf("Expected reason %s, got %s", types.Insolvent, result.EquilibriumReason.Code)
+	}
+
+	// The balance starts at the initial cash and accumulates each step's net cash flow
+	balance := 2000000.0
+	for _, state := range result.TimeSeries[1:] {
+		balance += state.NetCashFlow()
+		if math.Abs(state.CashBalance-balance) > 1e-6 {
+			t.Fatalf("Step %d: expected cash balance %.2f, got %.2f", state.TimeStep, balance, state.CashBalance)
+		}
+	}
+	if final := result.EquilibriumState.CashBalance; final >= -500000 {
+		t.Errorf("Expected the final balance beyond the credit limit, got %.2f", final)
+	}
+	if previous := result.TimeSeries[len(result.TimeSeries)-2].CashBalance; previous < -500000 {
+		t.Errorf("Expected the run to stop at the first step beyond the credit limit, but the previous balance was %.2f", previous)
+	}
+	if events := controller.GetEvents(); events[len(events)-1].Type != types.InsolvencyEvent {
+		t.Errorf("Expected the run to end with an insolvency event, got %s", events[len(events)-1].Type)
+	}
+}
+
+func TestCashDisabledNeverInsolvent(t *testing.T) {
+	config := benchmarkConfig()
+	config.Shocks = types.MarketShocks{{TimeStep: 1, Type: types.DemandShock, Change: -90}}
+
+	result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.Insolvent || result.EquilibriumState.CashBalance != 0 {
+		t.Errorf("Expected no cash tracking by default, got insolvent=%t balance=%.2f", result.Insolvent, result.EquilibriumState.CashBalance)
+	}
+}
+
+func TestFinancingFundsHiringBeyondBudget(t *testing.T) {
+	run := func(financing types.FinancingConfig) types.SimulationResult {
+		config := benchmarkConfig()
+		config.FixedBudget = 5500000.0 // 300000 above the initial workforce's cost
+		config.Financing = financing
+		result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(24)
+		if err != nil {
+			t.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+		return result
+	}
+	baseline := run(types.FinancingConfig{})
+	financed := run(types.FinancingConfig{CreditLine: 2000000, InterestRate: 0.08})
+
+	peakAgents := func(result types.SimulationResult) int {
+		peak := 0
+		for _, state := range result.TimeSeries {
+			if state.Workforce.AIAgents.Total > peak {
+				peak = state.Workforce.AIAgents.Total
+			}
+		}
+		return peak
+	}
+	if peakAgents(financed) <= peakAgents(baseline) {
+		t.Errorf("Expected borrowing to fund more AI agents than %d, got %d", peakAgents(baseline), peakAgents(financed))
+	}
+
+	borrowed, interest := 0.0, 0.0
+	for _, state := range financed.TimeSeries {
+		borrowed += state.Financing.Borrowed
+		interest += state.CostBreakdown.Interest
+		if state.Financing.Debt > 2000000+1e-6 {
+			t.Fatalf("Step %d: debt %.2f exceeds the credit line", state.TimeStep, state.Financing.Debt)
+		}
+	}
+	if borrowed == 0 || interest == 0 {
+		t.Errorf("Expected borrowing and interest, got %.2f borrowed and %.2f interest", borrowed, interest)
+	}
+	for _, state := range baseline.TimeSeries {
+		if state.Financing != (types.FinancingState{}) || state.CostBreakdown.Interest != 0 {
+			t.Fatalf("Step %d: expected no financing by default, got %+v", state.TimeStep, state.Financing)
+		}
+	}
+}
+
+func TestLeastLoadedAssignmentSpreadsHires(t *testing.T) {
+	orchestrators := func(strategy types.AssignmentStrategy) (int, int) {
+		config := benchmarkConfig()
+		config.Assignment.Strategy = strategy
+		controller := NewSimulationController(config, 12345)
+		if err := controller.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		controller.Step()
+
+		used, maxLoad := 0, 0
+		for _, human := range controller.workforceManager.GetAllHumans() {
+			if len(human.AssignedAgents) > 0 {
+				used++
+			}
+			if len(human.AssignedAgents) > maxLoad {
+				maxLoad = len(human.AssignedAgents)
+			}
+		}
+		return used, maxLoad
+	}
+
+	filledUsed, filledMax := orchestrators(types.FillFirst)
+	spreadUsed, spreadMax := orchestrators(types.LeastLoaded)
+	if filledUsed == 0 {
+		t.Fatal("Expected AI agents to be hired in the first step")
+	}
+	if spreadUsed <= filledUsed || spreadMax >= filledMax {
+		t.Errorf("Expected Least_Loaded to spread hires: %d orchestrators with at most %d agents, versus %d with at most %d under Fill_First",
+			spreadUsed, spreadMax, filledUsed, filledMax)
+	}
+}
+
+func TestTaskDemandLimitsDeliveredProductivity(t *testing.T) {
+	run := func(tasks types.TaskDemand) types.SimulationState {
+		config := benchmarkConfig()
+		config.Tasks = tasks
+		controller := NewSimulationController(config, 12345)
+		if err := controller.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		return controller.Step()
+	}
+
+	unconstrained := run(types.TaskDemand{})
+	constrained := run(types.TaskDemand{UniversityHire: 5, Senior: 5})
+	if constrained.TotalProductivity > 10+1e-9 || constrained.TotalProductivity != constrained.Utilization.Delivered {
+		t.Errorf("Expected at most the 10 units of demand to be delivered, got %.2f", constrained.TotalProductivity)
+	}
+	if constrained.RevenueOutput >= unconstrained.RevenueOutput {
+		t.Errorf("Expected idle capacity to lower revenue below %.2f, got %.2f", unconstrained.RevenueOutput, constrained.RevenueOutput)
+	}
+	if constrained.Utilization.Overall <= 0 || constrained.Utilization.Overall >= 100 {
+		t.Errorf("Expected partial utilization, got %.2f%%", constrained.Utilization.Overall)
+	}
+	attributed := constrained.RevenueAttribution.TotalHuman() + constrained.RevenueAttribution.TotalAI()
+	if math.Abs(attributed-constrained.RevenueOutput) > 1e-6 {
+		t.Errorf("Expected attributed revenue %.2f to match revenue %.2f", attributed, constrained.RevenueOutput)
+	}
+	if unconstrained.Utilization.ByExperience != nil {
+		t.Error("Expected no utilization without task demand")
+	}
+}
+
+func TestResilienceSpendTradesBudgetForFewerFailures(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 0.5
+
+	baseline := NewSimulationController(config, 12345)
+	config.Resilience = types.ResilienceConfig{BudgetShare: 0.1, MaxRateReduction: 0.9, MaxSeverityReduction: 0.5, HalfEffectShare: 0.02}
+	resilient := NewSimulationController(config, 12345)
+	results := make([]types.SimulationResult, 2)
+	for i, controller := range []*SimulationController{baseline, resilient} {
+		result, err := controller.RunUntilEquilibrium(120)
+		if err != nil {
+			t.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+		results[i] = result
+	}
+
+	if results[1].TotalCatastrophicFailures >= results[0].TotalCatastrophicFailures {
+		t.Errorf("Expected resilience spend to lower the number of failures, got %d vs %d",
+			results[1].TotalCatastrophicFailures, results[0].TotalCatastrophicFailures)
+	}
+	first := results[1].TimeSeries[0]
+	if first.CostBreakdown.Resilience != config.FixedBudget*0.1 {
+		t.Errorf("Expected the annual resilience spend in the cost breakdown, got %.2f", first.CostBreakdown.Resilience)
+	}
+	if first.AvailableBudget >= results[0].TimeSeries[0].AvailableBudget {
+		t.Errorf("Expected resilience spend to leave less budget for the workforce, got %.2f vs %.2f",
+			first.AvailableBudget, results[0].TimeSeries[0].AvailableBudget)
+	}
+}
+
+func TestTrainingSpendSpeedsUpLearning(t *testing.T) {
+	config := benchmarkConfig()
+	firstLevelUp := func(config types.SimulationConfig) (int, types.SimulationResult) {
+		result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(60)
+		if err != nil {
+			t.Fatalf("RunUntilEquilibrium failed: %v", err)
+		}
+		for _, event := range result.Events {
+			if event.Type == types.AgentLevelUpEvent {
+				return event.TimeStep, result
+			}
+		}
+		t.Fatal("Expected an agent to level up")
+		return 0, result
+	}
+
+	organic, _ := firstLevelUp(config)
+	config.Training = types.TrainingConfig{BudgetShare: 0.02, CostPerAgent: 5000, ThresholdReduction: 0.5}
+	trained, result := firstLevelUp(config)
+	if trained >= organic {
+		t.Errorf("Expected training to bring the first level-up forward from step %d, got step %d", organic, trained)
+	}
+	if spend := result.TimeSeries[0].CostBreakdown.Training; spend != config.FixedBudget*0.02 {
+		t.Errorf("Expected the annual training spend in the cost breakdown, got %.2f", spend)
+	}
+}
+
+func TestDomainsCapAgentLevels(t *testing.T) {
+	config := benchmarkConfig()
+	config.AILearningSpeeds = types.AILearningSpeed{UniversityToMid: 2, MidToSenior: 3, SeniorToExecutive: 4}
+	config.Domains = types.DomainTable{
+		{Name: "Support", Share: 50, MaxAgentLevel: types.MidLevel},
+		{Name: "Engineering", Share: 50, MaxAgentLevel: types.Executive},
+	}
+	sc := NewSimulationController(config, 12345)
+	if _, err := sc.RunUntilEquilibrium(60); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	var counts [2]int
+	executives := 0
+	for _, agent := range sc.workforceManager.GetAllAIAgents() {
+		counts[agent.Domain]++
+		if agent.ExperienceLevel > config.Domains[agent.Domain].MaxAgentLevel {
+			t.Errorf("Expected %s in %s to stop at %s, got %s", agent.ID, config.Domains[agent.Domain].Name, config.Domains[agent.Domain].MaxAgentLevel, agent.ExperienceLevel)
+		}
+		if agent.ExperienceLevel == types.Executive {
+			executives++
+		}
+	}
+	if counts[0] == 0 || counts[1] == 0 {
+		t.Fatalf("Expected agents hired into both domains, got %v", counts)
+	}
+	if executives == 0 {
+		t.Error("Expected Engineering agents to reach Executive")
+	}
+}
+
+func TestVendorTiersPriceHiredAgents(t *testing.T) {
+	config := benchmarkConfig()
+	config.Vendors = types.VendorConfig{Tiers: types.VendorTable{
+		{Name: "Frontier", Share: 50, CostMultiplier: 2, ProductivityMultiplier: 1.5},
+		{Name: "Open_Source", Share: 50, CostMultiplier: 0.5},
+	}}
+	sc := NewSimulationController(config, 12345)
+	if _, err := sc.RunUntilEquilibrium(60); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	var counts [2]int
+	for _, agent := range sc.workforceManager.GetAllAIAgents() {
+		counts[agent.Vendor]++
+		tier := config.Vendors.Tiers[agent.Vendor]
+		if agent.GetCost() != types.AIAgentCosts[agent.ExperienceLevel]*tier.CostMultiplier {
+			t.Errorf("Expected %s of %s to cost %.0f at %s, got %.0f", agent.ID, tier.Name, types.AIAgentCosts[agent.ExperienceLevel]*tier.CostMultiplier, agent.ExperienceLevel, agent.GetCost())
+		}
+	}
+	if counts[0] == 0 || counts[0]-counts[1] > 1 || counts[1]-counts[0] > 1 {
+		t.Errorf("Expected the agents split evenly across the tiers, got %v", counts)
+	}
+}
+
+func TestAICostCurveLowersAgentCosts(t *testing.T) {
+	config := benchmarkConfig()
+	agentCost := func(config types.SimulationConfig) float64 {
+		sc := NewSimulationController(config, 12345)
+		if err := sc.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		for i := 0; i < 24; i++ {
+			sc.Step()
+		}
+		agents := sc.workforceManager.GetAllAIAgents()
+		if len(agents) == 0 {
+			t.Fatal("Expected AI agents to be hired")
+		}
+		return sc.economicModel.CalculateWorkforceCost(nil, agents[:1])
+	}
+
+	static := agentCost(config)
+	config.AICostCurve = types.AICostCurve{AnnualChange: -50}
+	falling := agentCost(config)
+	if math.Abs(falling-static*0.25) > 1e-6 {
+		t.Errorf("Expected two years of a -50%% curve to quarter the cost of an agent from %.2f, got %.2f", static, falling)
+	}
+	config.AICostCurve.Floor = 0.5
+	if floored := agentCost(config); math.Abs(floored-static*0.5) > 1e-6 {
+		t.Errorf("Expected the curve to stop at half the cost of %.2f, got %.2f", static, floored)
+	}
+}
+
+func TestResetKeepsAIPricing(t *testing.T) {
+	config := benchmarkConfig()
+	config.AIPricing = types.AIPricingConfig{PlatformFee: 250000, Discounts: []types.VolumeDiscount{{MinAgents: 10, Discount: 0.2}}}
+	sc := NewSimulationController(config, 12345)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for len(sc.workforceManager.GetAllAIAgents()) == 0 {
+		sc.Step()
+	}
+	humans, agents := sc.workforceManager.GetAllHumans(), sc.workforceManager.GetAllAIAgents()
+	priced := sc.economicModel.CalculateWorkforceCost(humans, agents)
+	if unpriced := NewSimulationController(benchmarkConfig(), 12345).economicModel.CalculateWorkforceCost(humans, agents); priced == unpriced {
+		t.Fatalf("Expected AI pricing to change the cost of the workforce from %.2f", unpriced)
+	}
+
+	// Reset rebuilds the economic model, which must price the same workforce the same way
+	sc.Reset()
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if cost := sc.economicModel.CalculateWorkforceCost(humans, agents); cost != priced {
+		t.Errorf("Expected the workforce to cost %.2f after Reset, got %.2f", priced, cost)
+	}
+}
+
+func TestFailuresAreRecordedWithTheResponse(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 0.3
+	config.Recovery = types.FailureRecoveryConfig{RemediationCost: 10000}
+
+	result, err := NewSimulationController(config, 12345).RunUntilEquilibrium(60)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if len(result.Failures) != result.TotalCatastrophicFailures || len(result.Failures) == 0 {
+		t.Fatalf("Expected a record of each of the %d failures, got %d", result.TotalCatastrophicFailures, len(result.Failures))
+	}
+
+	penalties, remediation := 0.0, 0.0
+	for _, state := range result.TimeSeries {
+		penalties += state.CostBreakdown.Penalties
+		remediation += state.CostBreakdown.Remediation
+	}
+	recordedPenalties, recordedRemediation := 0.0, 0.0
+	for _, failure := range result.Failures {
+		recordedPenalties += failure.Penalty
+		recordedRemediation += failure.Remediation
+		if failure.Handled && (failure.Penalty != 0 || failure.ProductivityPenalty != 0) {
+			t.Errorf("Expected a handled failure to cost no penalty, got %+v", failure)
+		}
+		if failure.HumanCapability != float64(failure.SeniorHumans) {
+			t.Errorf("Expected each senior human to contribute 1 capability, got %+v", failure)
+		}
+	}
+	if math.Abs(recordedPenalties-penalties) > 1e-6 || math.Abs(recordedRemediation-remediation) > 1e-6 {
+		t.Errorf("Expected the records to add up to the charged costs, got %.2f and %.2f vs %.2f and %.2f",
+			recordedPenalties, recordedRemediation, penalties, remediation)
+	}
+}
+
+func TestStepChangesAccountForWorkforceDeltas(t *testing.T) {
+	config := benchmarkConfig()
+	config.AttritionConfig = types.AttritionConfig{Type: types.NaturalAttrition, NaturalRate: 30, ForcedAcceleration: 1}
+
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for step := 0; step < 36; step++ {
+		controller.Step()
+	}
+
+	hired, lost := 0, 0
+	timeSeries := controller.GetTimeSeries()
+	for i := 1; i < len(timeSeries); i++ {
+		previous, state := timeSeries[i-1], timeSeries[i]
+		agentDelta := state.Workforce.AIAgents.Total - previous.Workforce.AIAgents.Total
+		if agentDelta != state.AgentsHiredThisStep-state.AgentsReleasedThisStep {
+			t.Errorf("Step %d: expected an agent change of %d hired less %d released, got %d",
+				state.TimeStep, state.AgentsHiredThisStep, state.AgentsReleasedThisStep, agentDelta)
+		}
+		if humanDelta := state.Workforce.Humans.Total - previous.Workforce.Humans.Total; humanDelta != -state.HumansLostThisStep {
+			t.Errorf("Step %d: expected %d humans lost without backfills, got a change of %d", state.TimeStep, state.HumansLostThisStep, humanDelta)
+		}
+		if state.AvailableOrchestrationCapacity < 0 {
+			t.Errorf("Step %d: expected non-negative remaining capacity, got %d", state.TimeStep, state.AvailableOrchestrationCapacity)
+		}
+		hired += state.AgentsHiredThisStep
+		lost += state.HumansLostThisStep
+	}
+	if hired == 0 || lost == 0 {
+		t.Errorf("Expected the run to hire agents and lose humans, got %d hired and %d lost", hired, lost)
+	}
+}
+
+func TestCompositionSubtotalsAddUpToTotals(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for step := 0; step < 12; step++ {
+		controller.Step()
+	}
+
+	state := controller.GetTimeSeries()[len(controller.GetTimeSeries())-1]
+	humans, ai := state.Workforce.HumanSubtotals, state.Workforce.AISubtotals
+	if ai.Productivity <= 0 || humans.Productivity <= 0 {
+		t.Fatalf("Expected both humans and AI agents to contribute productivity, got %.2f and %.2f", humans.Productivity, ai.Productivity)
+	}
+	if math.Abs(humans.Productivity+ai.Productivity-state.TotalProductivity) > 1e-6 {
+		t.Errorf("Expected the productivity subtotals to add up to %.2f without overtime or recovery, got %.2f",
+			state.TotalProductivity, humans.Productivity+ai.Productivity)
+	}
+	if math.Abs(humans.Cost+ai.Cost-state.TotalCost) > 1e-6 {
+		t.Errorf("Expected the cost subtotals to add up to %.2f, got %.2f", state.TotalCost, humans.Cost+ai.Cost)
+	}
+	levels := 0.0
+	for _, productivity := range ai.ProductivityByExperience {
+		levels += productivity
+	}
+	if math.Abs(levels-ai.Productivity) > 1e-6 {
+		t.Errorf("Expected the AI productivity by level to add up to %.2f, got %.2f", ai.Productivity, levels)
+	}
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// GetDecisions returns the optimizer decisions reviewed so far, in order
+// It is empty unless the controller was built WithDecisionApprover
+func (sc *SimulationController) GetDecisions() []types.OptimizerDecision {
+	return sc.Snapshot().Decisions
+}
+
+// reviewChange holds a proposed workforce change for review by the approver and replaces it with
+// the change to execute, returning the decision to record once it has been executed
+// Returns nil, leaving the change as proposed, when no approver is set or the proposal is empty
+func (sc *SimulationController) reviewChange(change *events.WorkforceChange) *types.OptimizerDecision {
+	if sc.approver == nil || (change.HireAIAgents == 0 && len(change.ReleaseAIAgents) == 0) {
+		return nil
+	}
+
+	review := sc.approver(sc.currentTimeStep, *change)
+	decision := &types.OptimizerDecision{
+		TimeStep:         sc.currentTimeStep,
+		ProposedHires:    change.HireAIAgents,
+		ProposedReleases: append([]string(nil), change.ReleaseAIAgents...),
+		Verdict:          review.Verdict,
+		Reason:           review.Reason,
+	}
+	switch review.Verdict {
+	case types.DecisionModified:
+		*change = review.Change
+	case types.DecisionRejected:
+		*change = events.WorkforceChange{}
+	}
+	return decision
+}
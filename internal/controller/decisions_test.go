@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestDecisionApproverGatesOptimizer(t *testing.T) {
+	reviewed := 0
+	approver := func(timeStep int, proposal events.WorkforceChange) DecisionReview {
+		reviewed++
+		switch timeStep {
+		case 1:
+			return DecisionReview{Verdict: types.DecisionRejected, Reason: "wait and see"}
+		case 2:
+			proposal.HireAIAgents = 1
+			proposal.Assignments = nil
+			return DecisionReview{Verdict: types.DecisionModified, Change: proposal}
+		default:
+			return DecisionReview{Verdict: types.DecisionApproved}
+		}
+	}
+	controller := NewSimulationController(benchmarkConfig(), 12345, WithDecisionApprover(approver))
+	result, err := controller.RunUntilEquilibrium(5)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+
+	decisions := result.Decisions
+	if len(decisions) != reviewed || len(decisions) < 3 {
+		t.Fatalf("Expected every reviewed proposal in the decision trail, got %d of %d", len(decisions), reviewed)
+	}
+	if rejected := decisions[0]; rejected.TimeStep != 1 || rejected.Verdict != types.DecisionRejected || rejected.ProposedHires == 0 || rejected.Hires != 0 || rejected.Reason != "wait and see" {
+		t.Errorf("Expected the step 1 proposal to be rejected and nothing hired, got %+v", rejected)
+	}
+	if modified := decisions[1]; modified.Verdict != types.DecisionModified || modified.Hires != 1 {
+		t.Errorf("Expected the modified step 2 proposal to hire a single agent, got %+v", modified)
+	}
+	if approved := decisions[2]; approved.Verdict != types.DecisionApproved || approved.Hires != approved.ProposedHires {
+		t.Errorf("Expected the approved step 3 proposal to be executed as proposed, got %+v", approved)
+	}
+	if result.TimeSeries[1].AgentsHiredThisStep != 0 || result.TimeSeries[2].AgentsHiredThisStep != 1 {
+		t.Errorf("Expected hires to follow the review, got %d and %d", result.TimeSeries[1].AgentsHiredThisStep, result.TimeSeries[2].AgentsHiredThisStep)
+	}
+
+	// Without an approver the optimizer is not gated and no trail is kept
+	unreviewed, err := NewSimulationController(benchmarkConfig(), 12345).RunUntilEquilibrium(5)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if unreviewed.Decisions != nil || unreviewed.TimeSeries[1].AgentsHiredThisStep != decisions[0].ProposedHires {
+		t.Errorf("Expected an ungated run to hire the %d proposed agents without a trail, got %+v", decisions[0].ProposedHires, unreviewed.Decisions)
+	}
+}
@@ -0,0 +1,6 @@
+package controller
+
+// Workforce exposes the controller's workforce manager to the external tests
+func (sc *SimulationController) Workforce() WorkforceManager {
+	return sc.workforceManager
+}
@@ -0,0 +1,41 @@
+package controller_test
+
+import (
+	"flag"
+	"testing"
+	"workforce-ai-transition-simulator/internal/testutil"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// update rewrites the golden files with the current results instead of comparing against them
+var update = flag.Bool("update", false, "rewrite golden files instead of comparing against them")
+
+// TestGoldenSimulations pins the full results of reference runs so that any change to the
+// model's behavior is deliberate; regenerate with `go test ./internal/controller -run Golden -update`
+func TestGoldenSimulations(t *testing.T) {
+	reductionInForce := testutil.CanonicalConfig()
+	reductionInForce.AttritionConfig = types.AttritionConfig{
+		Type:               types.ReductionInForce,
+		NaturalRate:        15.0,
+		ForcedAcceleration: 2.0,
+		SeveranceMonths:    3.0,
+	}
+
+	hiringFreeze := testutil.CanonicalConfig()
+	hiringFreeze.AttritionConfig.Type = types.HiringFreeze
+
+	cases := []struct {
+		name   string
+		config types.SimulationConfig
+	}{
+		{"canonical", testutil.CanonicalConfig()},
+		{"reduction_in_force", reductionInForce},
+		{"hiring_freeze", hiringFreeze},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testutil.RunGolden(t, tc.name, tc.config, testutil.CanonicalSeed, *update)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Invariant names a property every recorded state of a run must have
+type Invariant string
+
+const (
+	InvariantBudget              Invariant = "budget"                // no AI agents are hired beyond the spending limit
+	InvariantOrchestrationLimit  Invariant = "orchestration-limit"   // no human orchestrates more than types.OrchestrationLimit agents
+	InvariantOrchestratorExists  Invariant = "orchestrator-exists"   // every agent's orchestrator is a human of the workforce listing it
+	InvariantSingleBusinessOwner Invariant = "single-business-owner" // exactly one human owns the business while any remain
+)
+
+// ErrInvariantViolated is wrapped by every InvariantViolation, so errors.Is finds them
+var ErrInvariantViolated = errors.New("invariant violated")
+
+// InvariantViolation is an invariant found violated after a time step
+type InvariantViolation struct {
+	TimeStep  int
+	Invariant Invariant
+	Detail    string
+}
+
+func (v *InvariantViolation) Error() string {
+	return fmt.Sprintf("time step %d: %s invariant violated: %s", v.TimeStep, v.Invariant, v.Detail)
+}
+
+func (v *InvariantViolation) Unwrap() error {
+	return ErrInvariantViolated
+}
+
+// InvariantChecker checks the state a run recorded after a time step, returning an error, such
+// as an *InvariantViolation, if the run has gone wrong
+type InvariantChecker func(sc *SimulationController, state types.SimulationState) error
+
+// budgetTolerance is the relative amount costs may exceed the spending limit by, absorbing
+// floating point error in the sums of costs
+const budgetTolerance = 1e-9
+
+// WithInvariantChecker runs checker after every time step
+// The first error it returns stops the run: RunUntilEquilibrium returns it, and InvariantError
+// reports it to callers stepping the run themselves. Checking costs time, so it is meant for
+// tests and debugging rather than batch runs
+func WithInvariantChecker(checker InvariantChecker) Option {
+	return func(sc *SimulationController) {
+		sc.invariantCheckers = append(sc.invariantCheckers, checker)
+	}
+}
+
+// WithInvariantChecks runs CheckInvariants after every time step, see WithInvariantChecker
+func WithInvariantChecks() Option {
+	return WithInvariantChecker(CheckInvariants)
+}
+
+// InvariantError returns the first error an invariant checker returned, or nil if none has
+func (sc *SimulationController) InvariantError() error {
+	return sc.invariantErr
+}
+
+// checkInvariants runs the invariant checkers on the state recorded for a time step, keeping the
+// first error
+func (sc *SimulationController) checkInvariants(state types.SimulationState) {
+	for _, checker := range sc.invariantCheckers {
+		if sc.invariantErr != nil {
+			return
+		}
+		sc.invariantErr = checker(sc, state)
+	}
+}
+
+// CheckInvariants checks the invariants of the model: no hiring beyond the spending limit, humans
+// within the orchestration limit, agents orchestrated by humans of the workforce, and a single
+// business owner, joining the violations it finds
+// Costs alone may exceed the limit, as market shocks raise the prices of a workforce that is not
+// laid off to match, so the budget is only violated by hiring AI agents while over it
+func CheckInvariants(sc *SimulationController, state types.SimulationState) error {
+	var violations []error
+	violate := func(invariant Invariant, format string, args ...interface{}) {
+		violations = append(violations, &InvariantViolation{TimeStep: state.TimeStep, Invariant: invariant, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	limit := state.TotalCost + state.AvailableBudget
+	if state.AvailableBudget < -budgetTolerance*math.Max(1, math.Abs(limit)) && state.AgentsHiredThisStep > 0 {
+		violate(InvariantBudget, "%d AI agents hired with workforce cost %.2f over the spending limit %.2f",
+			state.AgentsHiredThisStep, state.TotalCost, limit)
+	}
+
+	humans := sc.workforceManager.GetAllHumans()
+	orchestrators := make(map[string]map[string]bool, len(humans))
+	owners := 0
+	for _, human := range humans {
+		if len(human.AssignedAgents) > types.OrchestrationLimit {
+			violate(InvariantOrchestrationLimit, "%s orchestrates %d agents, more than %d", human.ID, len(human.AssignedAgents), types.OrchestrationLimit)
+		}
+		assigned := make(map[string]bool, len(human.AssignedAgents))
+		for _, id := range human.AssignedAgents {
+			assigned[id] = true
+		}
+		orchestrators[human.ID] = assigned
+		if human.IsBusinessOwner {
+			owners++
+		}
+	}
+	if len(humans) > 0 && owners != 1 {
+		violate(InvariantSingleBusinessOwner, "%d of %d humans own the business", owners, len(humans))
+	}
+
+	agents := make(map[string]bool)
+	for _, agent := range sc.workforceManager.GetAllAIAgents() {
+		agents[agent.ID] = true
+		assigned, ok := orchestrators[agent.OrchestratorID]
+		switch {
+		case !ok:
+			violate(InvariantOrchestratorExists, "%s is orchestrated by %q, who is not in the workforce", agent.ID, agent.OrchestratorID)
+		case !assigned[agent.ID]:
+			violate(InvariantOrchestratorExists, "%s is orchestrated by %s, who does not list it", agent.ID, agent.OrchestratorID)
+		}
+	}
+	for _, human := range humans {
+		for _, id := range human.AssignedAgents {
+			if !agents[id] {
+				violate(InvariantOrchestratorExists, "%s lists %s, which is not in the workforce", human.ID, id)
+			}
+		}
+	}
+
+	return errors.Join(violations...)
+}
@@ -0,0 +1,66 @@
+package controller_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/testfixtures"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestCheckInvariantsReportsViolations(t *testing.T) {
+	tests := []struct {
+		name      string
+		corrupt   func(wm controller.WorkforceManager, state *types.SimulationState)
+		invariant controller.Invariant
+	}{
+		{"second business owner", func(wm controller.WorkforceManager, _ *types.SimulationState) {
+			wm.GetAllHumans()[1].IsBusinessOwner = true
+		}, controller.InvariantSingleBusinessOwner},
+		{"no business owner", func(wm controller.WorkforceManager, _ *types.SimulationState) {
+			for _, human := range wm.GetAllHumans() {
+				human.IsBusinessOwner = false
+			}
+		}, controller.InvariantSingleBusinessOwner},
+		{"orchestration limit", func(wm controller.WorkforceManager, _ *types.SimulationState) {
+			human := wm.GetAllHumans()[0]
+			for len(human.AssignedAgents) <= types.OrchestrationLimit {
+				human.AssignedAgents = append(human.AssignedAgents, fmt.Sprintf("ghost-%d", len(human.AssignedAgents)))
+			}
+		}, controller.InvariantOrchestrationLimit},
+		{"missing orchestrator", func(wm controller.WorkforceManager, _ *types.SimulationState) {
+			wm.GetAllAIAgents()[0].OrchestratorID = "nobody"
+		}, controller.InvariantOrchestratorExists},
+		{"hiring over budget", func(_ controller.WorkforceManager, state *types.SimulationState) {
+			state.AgentsHiredThisStep = 1
+			state.AvailableBudget = -1000
+		}, controller.InvariantBudget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := testfixtures.NewWorkforceBuilder().
+				AtTimeStep(10).
+				WithHumans(1, types.Executive, types.HighCostUS).
+				WithHumans(4, types.MidLevel, types.LowCostNonUS).
+				WithAgents(3, types.UniversityHire).
+				MustController(t)
+			series := sc.GetTimeSeries()
+			state := series[len(series)-1]
+			if err := controller.CheckInvariants(sc, state); err != nil {
+				t.Fatalf("Unexpected violation before corrupting the workforce: %v", err)
+			}
+
+			tt.corrupt(sc.Workforce(), &state)
+			err := controller.CheckInvariants(sc, state)
+			var violation *controller.InvariantViolation
+			if !errors.As(err, &violation) || !errors.Is(err, controller.ErrInvariantViolated) {
+				t.Fatalf("Expected an invariant violation, got %v", err)
+			}
+			if violation.Invariant != tt.invariant || violation.TimeStep != state.TimeStep {
+				t.Errorf("Expected the %s invariant violated at time step %d, got %v", tt.invariant, state.TimeStep, violation)
+			}
+		})
+	}
+}
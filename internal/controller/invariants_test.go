@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestInvariantsHoldThroughRun(t *testing.T) {
+	sc := NewSimulationController(benchmarkConfig(), 12345, WithInvariantChecks())
+	if _, err := sc.RunUntilEquilibrium(100); err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if err := sc.InvariantError(); err != nil {
+		t.Errorf("Unexpected invariant error: %v", err)
+	}
+}
+
+func TestInvariantViolationStopsRun(t *testing.T) {
+	broken := errors.New("broken")
+	checker := func(sc *SimulationController, state types.SimulationState) error {
+		if state.TimeStep == 3 {
+			return broken
+		}
+		return nil
+	}
+
+	sc := NewSimulationController(benchmarkConfig(), 12345, WithInvariantChecker(checker))
+	if _, err := sc.RunUntilEquilibrium(100); !errors.Is(err, broken) {
+		t.Fatalf("Expected the checker's error, got %v", err)
+	}
+	if sc.GetCurrentTimeStep() != 3 || !errors.Is(sc.InvariantError(), broken) {
+		t.Errorf("Expected the run to stop at time step 3 with the error kept, got step %d and %v", sc.GetCurrentTimeStep(), sc.InvariantError())
+	}
+}
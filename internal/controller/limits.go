@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Limits guard a run against configurations that would take too long or hold too much state
+// A run exceeding one is aborted: it ends like a terminal state, with status types.StatusAborted
+// and reason code types.LimitExceeded. Zero fields are unlimited
+type Limits struct {
+	MaxWallTime    time.Duration // time since the run started
+	MaxSteps       int           // time steps a run may take; one that has not ended after them is aborted
+	MaxEntitySteps int           // humans and AI agents summed over the recorded steps, an estimate of the memory the time series holds
+}
+
+// WithLimits aborts the run once it exceeds limits, which are checked after every time step,
+// whether the steps are taken by RunUntilEquilibrium or by calling Step
+// An equilibrium confirmation cut short by a limit counts as not persisting
+func WithLimits(limits Limits) Option {
+	return func(sc *SimulationController) {
+		sc.limits = limits
+	}
+}
+
+// checkLimits aborts the run if the step just recorded exceeds a limit
+func (sc *SimulationController) checkLimits(state *types.SimulationState) {
+	if sc.limits == (Limits{}) || sc.insolvent || sc.terminated() {
+		return
+	}
+	sc.entitySteps += state.Workforce.Humans.Total + state.Workforce.AIAgents.Total
+
+	steps := sc.currentTimeStep - sc.startTimeStep
+	var exceeded string
+	switch {
+	case sc.limits.MaxWallTime > 0 && sc.wallTime() > sc.limits.MaxWallTime:
+		exceeded = fmt.Sprintf("wall time limit of %s exceeded after %d steps", sc.limits.MaxWallTime, steps)
+	case sc.limits.MaxSteps > 0 && steps >= sc.limits.MaxSteps && !sc.equilibriumReached:
+		exceeded = fmt.Sprintf("step limit of %d reached without equilibrium", sc.limits.MaxSteps)
+	case sc.limits.MaxEntitySteps > 0 && sc.entitySteps > sc.limits.MaxEntitySteps:
+		exceeded = fmt.Sprintf("entity-step limit of %d exceeded after %d steps", sc.limits.MaxEntitySteps, steps)
+	default:
+		return
+	}
+
+	sc.terminalReason = types.EquilibriumReason{Code: types.LimitExceeded, Message: "aborted: " + exceeded}
+	sc.equilibriumReached = false
+	state.IsEquilibrium = false
+	sc.recordEvent(types.TerminalStateEvent, "%s", sc.terminalReason.Message)
+}
+
+// wallTime returns how long the run has been going, starting its clock if it has not started
+func (sc *SimulationController) wallTime() time.Duration {
+	if sc.startedAt.IsZero() {
+		sc.startedAt = time.Now()
+	}
+	return time.Since(sc.startedAt)
+}
+
+// IsAborted returns whether the run was aborted for exceeding its limits, see WithLimits
+func (sc *SimulationController) IsAborted() bool {
+	return sc.Snapshot().TerminalReason.Code == types.LimitExceeded
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestLimitsAbortRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		message string
+	}{
+		{"steps", Limits{MaxSteps: 5}, "step limit of 5"},
+		{"entity steps", Limits{MaxEntitySteps: 200}, "entity-step limit of 200"},
+		{"wall time", Limits{MaxWallTime: time.Nanosecond}, "wall time limit of 1ns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSimulationController(benchmarkConfig(), 12345, WithLimits(tt.limits)).RunUntilEquilibrium(100)
+			if err != nil {
+				t.Fatalf("RunUntilEquilibrium failed: %v", err)
+			}
+			if result.Status != types.StatusAborted || result.EquilibriumReason.Code != types.LimitExceeded || result.ReachedEquilibrium {
+				t.Fatalf("Expected the run to be aborted, got %s: %s", result.Status, result.EquilibriumReason.Message)
+			}
+			if !strings.HasPrefix(result.EquilibriumReason.Message, "aborted: "+tt.message) {
+				t.Errorf("Unexpected reason %q", result.EquilibriumReason.Message)
+			}
+			if result.TimeToEquilibrium >= 100 || len(result.TimeSeries) != result.TimeToEquilibrium+1 {
+				t.Errorf("Expected the run to stop at the limit, got %d steps", result.TimeToEquilibrium)
+			}
+		})
+	}
+}
+
+func TestLimitsStopManualStepping(t *testing.T) {
+	sc := NewSimulationController(benchmarkConfig(), 12345, WithLimits(Limits{MaxSteps: 3}))
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		sc.Step()
+	}
+	if !sc.IsAborted() || !sc.IsTerminated() {
+		t.Fatal("Expected the run to be aborted after 3 steps")
+	}
+	if result, _ := sc.RunUntilEquilibrium(100); result.TimeToEquilibrium != 3 || result.Status != types.StatusAborted {
+		t.Errorf("Expected no further steps, got %d (%s)", result.TimeToEquilibrium, result.Status)
+	}
+}
+
+func TestUnreachedLimitsLeaveRunUnchanged(t *testing.T) {
+	want, _ := NewSimulationController(benchmarkConfig(), 12345).RunUntilEquilibrium(30)
+	got, _ := NewSimulationController(benchmarkConfig(), 12345, WithLimits(Limits{MaxWallTime: time.Hour, MaxSteps: 1000, MaxEntitySteps: 1 << 30})).RunUntilEquilibrium(30)
+	if got.Status != want.Status || got.TimeToEquilibrium != want.TimeToEquilibrium || got.EquilibriumState.Workforce.AIAgents.Total != want.EquilibriumState.Workforce.AIAgents.Total {
+		t.Errorf("Expected limits that are not reached to leave the run unchanged, got %s after %d steps, want %s after %d", got.Status, got.TimeToEquilibrium, want.Status, want.TimeToEquilibrium)
+	}
+}
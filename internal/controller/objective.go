@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// WithObjective has the optimizer pursue obj: of the hires it proposes at a time step, all, half
+// or none go ahead, whichever obj rewards most in a projection of the step from the previous
+// state, preferring more hires on a tie. Releases are kept as proposed. A workforce policy, when
+// set, decides instead
+func WithObjective(obj objective.Objective) Option {
+	return func(sc *SimulationController) {
+		sc.objective = obj
+	}
+}
+
+// pursueObjective trims the hires of an optimizer's proposal to the number the objective rewards most
+func (sc *SimulationController) pursueObjective(change *events.WorkforceChange, agents []*types.AIAgent) {
+	if change.HireAIAgents <= 0 || len(sc.timeSeries) == 0 {
+		return
+	}
+	previous := sc.timeSeries[len(sc.timeSeries)-1]
+	best, bestReward := 0, 0.0
+	for i, hires := range []int{change.HireAIAgents, change.HireAIAgents / 2, 0} {
+		reward := sc.objective.Reward(previous, sc.projectChange(previous, hires, change.ReleaseAIAgents, agents))
+		if i == 0 || reward > bestReward {
+			best, bestReward = hires, reward
+		}
+	}
+	change.HireAIAgents = best
+	if len(change.Assignments) > best {
+		change.Assignments = change.Assignments[:best]
+	}
+	if best == 0 {
+		change.OrchestratorID = ""
+	}
+}
+
+// projectChange estimates the state after hiring and releasing AI agents from the previous
+// state: cost and productivity change by the agents' own, and revenue in proportion to productivity
+func (sc *SimulationController) projectChange(previous types.SimulationState, hires int, releases []string, agents []*types.AIAgent) types.SimulationState {
+	aiCost := sc.config.MarketConditions(sc.currentTimeStep).AICost
+	projected := previous
+	projected.TimeStep = sc.currentTimeStep
+	projected.HumansLostThisStep = sc.stepHumansLost
+	projected.AgentsHiredThisStep = hires
+	projected.AgentsReleasedThisStep = len(releases)
+	projected.Workforce.AIAgents.Total += hires - len(releases)
+
+	cost := float64(hires) * sc.config.Overhead.AICost(types.AIAgentCosts[types.UniversityHire]) * aiCost
+	productivity := float64(hires) * types.AIAgentProductivity[types.UniversityHire]
+	released := make(map[string]bool, len(releases))
+	for _, id := range releases {
+		released[id] = true
+	}
+	for _, agent := range agents {
+		if released[agent.ID] {
+			cost -= sc.config.Overhead.AICost(agent.GetCost()) * aiCost
+			productivity -= agent.GetProductivity()
+		}
+	}
+	projected.TotalCost += cost
+	if previous.TotalProductivity > 0 {
+		projected.RevenueOutput *= (previous.TotalProductivity + productivity) / previous.TotalProductivity
+	}
+	projected.TotalProductivity += productivity
+	return projected
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// hiresOver runs config with the given options and returns the AI agents hired over the run
+func hiresOver(t *testing.T, config types.SimulationConfig, options ...Option) int {
+	t.Helper()
+	sc := NewSimulationController(config, 42, options...)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	hired := 0
+	for i := 0; i < 24; i++ {
+		hired += sc.Step().AgentsHiredThisStep
+	}
+	return hired
+}
+
+func TestWithObjectiveTrimsHires(t *testing.T) {
+	config := benchmarkConfig()
+	baseline := hiresOver(t, config)
+	if baseline == 0 {
+		t.Fatal("Expected the optimizer to hire AI agents without an objective")
+	}
+
+	// An all-AI target rewards every proposed hire, so the optimizer is unchanged
+	if hired := hiresOver(t, config, WithObjective(objective.CompositionTarget{TargetAIShare: 1})); hired != baseline {
+		t.Errorf("Expected an all-AI target to keep all %d hires, got %d", baseline, hired)
+	}
+	// An all-human target rewards no hires at all
+	if hired := hiresOver(t, config, WithObjective(objective.CompositionTarget{TargetAIShare: 0})); hired != 0 {
+		t.Errorf("Expected an all-human target to stop hiring, got %d hires", hired)
+	}
+}
+
+func TestProjectChange(t *testing.T) {
+	config := benchmarkConfig()
+	sc := NewSimulationController(config, 42)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	previous := sc.timeSeries[0]
+	projected := sc.projectChange(previous, 2, nil, nil)
+
+	agentCost := config.Overhead.AICost(types.AIAgentCosts[types.UniversityHire]) * config.Shocks.Conditions(sc.currentTimeStep).AICost
+	if projected.Workforce.AIAgents.Total != previous.Workforce.AIAgents.Total+2 {
+		t.Errorf("Expected 2 more AI agents, got %d", projected.Workforce.AIAgents.Total)
+	}
+	if diff := projected.TotalCost - previous.TotalCost - 2*agentCost; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("Expected the cost to rise by 2 agents' cost, got %f", projected.TotalCost-previous.TotalCost)
+	}
+	if projected.TotalProductivity <= previous.TotalProductivity || projected.RevenueOutput <= previous.RevenueOutput {
+		t.Error("Expected hires to raise projected productivity and revenue")
+	}
+	if previous.Workforce.AIAgents.Total != sc.timeSeries[0].Workforce.AIAgents.Total {
+		t.Error("Expected the projection to leave the recorded state unchanged")
+	}
+}
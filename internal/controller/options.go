@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+	"workforce-ai-transition-simulator/internal/workforce"
+)
+
+// EventProcessor is the attrition, learning, failure and optimization logic the controller
+// drives each time step; *events.EventProcessor is the default implementation
+type EventProcessor interface {
+	SetBurnoutMultiplier(multiplier float64)
+	SetMarketConditions(market types.MarketConditions)
+	SetOrchestrators(humans []*types.HumanWorker)
+	ProcessAttrition(humans []*types.HumanWorker, timeStep int) []string
+	ProcessLearning(agents []*types.AIAgent, timeDelta int) []*types.AIAgent
+	GenerateCatastrophicFailure(timeStep int) *events.CatastrophicFailure
+	EvaluateFailureResponse(failure *events.CatastrophicFailure, humans []*types.HumanWorker, agents []*types.AIAgent) events.FailureOutcome
+	OptimizeWorkforce(humans []*types.HumanWorker, agents []*types.AIAgent, availableBudget float64, availableOrchestrationCapacity int) events.WorkforceChange
+	SelectExcessAgents(agents []*types.AIAgent, count int) []string
+	LoadRules(script string) error
+	EvaluateRules(state types.SimulationState) (events.RuleActions, error)
+}
+
+// EconomicModel prices the workforce, computes revenue and manages the budget and debt;
+// *economic.EconomicModel is the default implementation
+type EconomicModel interface {
+	SetMarketConditions(market types.MarketConditions)
+	CalculateWorkforceCost(humans []*types.HumanWorker, agents []*types.AIAgent) float64
+	CalculateCostBreakdown(humans []*types.HumanWorker, agents []*types.AIAgent) types.CostBreakdown
+	GetAvailableBudget(humans []*types.HumanWorker, agents []*types.AIAgent) float64
+	CanAfford(cost float64, humans []*types.HumanWorker, agents []*types.AIAgent) bool
+	CalculateRevenue(productivity float64, timeStep int) float64
+	AttributeRevenue(revenue float64, humans []*types.HumanWorker, agents []*types.AIAgent, regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) types.RevenueAttribution
+	SettleFinancing(workforceCost float64, revenue float64) (types.FinancingState, float64)
+	GetDebt() float64
+	RestoreDebt(debt float64)
+	GetRevenueHistory() []float64
+	RestoreRevenueHistory(history []float64)
+}
+
+// WorkforceManager holds the humans and AI agents of the simulated organization;
+// *workforce.WorkforceManager is the default implementation
+type WorkforceManager interface {
+	GetHuman(id string) (*types.HumanWorker, bool)
+	GetAllHumans() []*types.HumanWorker
+	GetAllAIAgents() []*types.AIAgent
+	GetBusinessOwner() (*types.HumanWorker, error)
+	AddHuman(experienceLevel types.ExperienceLevel, costCategory types.CostCategory, isBusinessOwner bool) (*types.HumanWorker, error)
+	RemoveHuman(workerID string) error
+	RemoveBusinessOwner() (workforce.Succession, error)
+	AddAIAgent(orchestratorID string, creationTime int) (*types.AIAgent, error)
+	ReleaseAIAgent(agentID string) error
+	RebalanceAgents() int
+	GetAvailableOrchestrationCapacity() int
+	CalculateHumanProductivity(regions types.RegionTable) float64
+	CalculateTotalProductivity(regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) float64
+	CalculateProductivityByExperience(regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) (human, ai [types.NumExperienceLevels]float64)
+	GetWorkforceComposition() types.WorkforceComposition
+	Snapshot() workforce.Snapshot
+}
+
+// Option customizes a SimulationController built by NewSimulationController
+type Option func(sc *SimulationController)
+
+// WithEventProcessor substitutes a custom event processor for the default one
+func WithEventProcessor(processor EventProcessor) Option {
+	return func(sc *SimulationController) {
+		sc.eventProcessor = processor
+	}
+}
+
+// WithEconomicModel substitutes a custom economic model for the default one
+// Reset keeps an injected model, so resetting it is up to the caller
+func WithEconomicModel(model EconomicModel) Option {
+	return func(sc *SimulationController) {
+		sc.economicModel = model
+		sc.injectedEconomicModel = true
+	}
+}
+
+// WithWorkforceManager substitutes a custom workforce manager for the default one
+// The manager should start empty, as Initialize adds the initial workforce to it. Reset keeps
+// an injected manager, so resetting it is up to the caller
+func WithWorkforceManager(manager WorkforceManager) Option {
+	return func(sc *SimulationController) {
+		sc.workforceManager = manager
+		sc.injectedWorkforceManager = true
+	}
+}
+
+// DecisionReview is a reviewer's resolution of a workforce change proposed by the optimizer
+type DecisionReview struct {
+	Verdict types.DecisionVerdict
+	Change  events.WorkforceChange // executed instead of the proposal when Verdict is DecisionModified
+	Reason  string
+}
+
+// DecisionApprover reviews a workforce change proposed by the optimizer at a time step before it
+// is executed, approving, modifying or rejecting it
+type DecisionApprover func(timeStep int, proposal events.WorkforceChange) DecisionReview
+
+// WithDecisionApprover holds every non-empty workforce change the optimizer proposes for review
+// by approver, and records the proposals, verdicts and executed changes as the run's decision trail
+// A modified change is executed as given, within orchestration capacity and any AI hiring freeze
+func WithDecisionApprover(approver DecisionApprover) Option {
+	return func(sc *SimulationController) {
+		sc.approver = approver
+	}
+}
+
+// Observer is notified as a run progresses, e.g. to stream its states and alerts elsewhere
+type Observer interface {
+	// OnStep is called after every time step with the recorded state and the events of the step
+	OnStep(state types.SimulationState, events []types.SimulationEvent)
+	// OnAlert is called when an alert rule fires, before OnStep of its time step
+	OnAlert(alert types.Alert)
+}
+
+// WithObserver notifies observer of every time step and alert of the run
+// Observers are notified in the order they were added, on the goroutine stepping the simulation
+func WithObserver(observer Observer) Option {
+	return func(sc *SimulationController) {
+		sc.observers = append(sc.observers, observer)
+	}
+}
+
+// Observe adds an observer to a controller that has already been created, e.g. one that labels
+// its output with the RunMetadata of the run; it is notified like those added by WithObserver
+func (sc *SimulationController) Observe(observer Observer) {
+	sc.observers = append(sc.observers, observer)
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/types"
+	"workforce-ai-transition-simulator/internal/workforce"
+)
+
+// failureFreeProcessor wraps the default event processor, suppressing catastrophic failures
+// and counting the attrition passes it sees
+type failureFreeProcessor struct {
+	*events.EventProcessor
+	attritionCalls int
+}
+
+func (p *failureFreeProcessor) GenerateCatastrophicFailure(timeStep int) *events.CatastrophicFailure {
+	return nil
+}
+
+func (p *failureFreeProcessor) ProcessAttrition(humans []*types.HumanWorker, timeStep int) []string {
+	p.attritionCalls++
+	return p.EventProcessor.ProcessAttrition(humans, timeStep)
+}
+
+func TestWithEventProcessorReplacesDefault(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 1
+	processor := &failureFreeProcessor{
+		EventProcessor: events.NewEventProcessor(config.AttritionConfig, config.CatastrophicFailureRate, config.FailureClassTable(), config.AILearningSpeeds,
+			config.SharedLearning, config.RegionTable(), config.Overhead, config.Assignment.Strategy, random.NewStreams(1)),
+	}
+
+	result, err := NewSimulationController(config, 12345, WithEventProcessor(processor)).RunUntilEquilibrium(10)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if result.TotalCatastrophicFailures != 0 {
+		t.Errorf("Expected the injected processor to suppress failures, got %d", result.TotalCatastrophicFailures)
+	}
+	if processor.attritionCalls == 0 {
+		t.Error("Expected the controller to drive attrition through the injected processor")
+	}
+}
+
+func TestResetKeepsInjectedWorkforceManager(t *testing.T) {
+	manager := workforce.NewWorkforceManager()
+	controller := NewSimulationController(benchmarkConfig(), 12345, WithWorkforceManager(manager))
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if len(manager.GetAllHumans()) != 50 {
+		t.Fatalf("Expected the initial workforce in the injected manager, got %d humans", len(manager.GetAllHumans()))
+	}
+
+	controller.Reset()
+	if controller.workforceManager != WorkforceManager(manager) {
+		t.Error("Expected Reset to keep the injected workforce manager")
+	}
+}
@@ -0,0 +1,37 @@
+package controller
+
+import "time"
+
+// WithPacing spaces the time steps of the run at least interval apart, so a live-streamed demo
+// advances at a watchable rate; runs without it step at full speed
+// Step waits out what remains of the interval since the previous step started, or since the run
+// was initialized, so the time spent simulating, observing and rendering a step counts towards
+// the interval rather than adding to it
+func WithPacing(interval time.Duration) Option {
+	return func(sc *SimulationController) {
+		sc.pace = interval
+	}
+}
+
+// SetPacing changes the interval between time steps while the run is under way, e.g. to speed
+// up a demo; zero runs the remaining steps at full speed
+// It is safe to call from any goroutine, e.g. one handling the demo's controls
+func (sc *SimulationController) SetPacing(interval time.Duration) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.pace = interval
+}
+
+// awaitPace blocks until the next time step is due under the run's pacing
+func (sc *SimulationController) awaitPace() {
+	sc.mu.RLock()
+	pace := sc.pace
+	sc.mu.RUnlock()
+	if pace <= 0 {
+		return
+	}
+	if wait := time.Until(sc.lastStepAt.Add(pace)); wait > 0 && !sc.lastStepAt.IsZero() {
+		time.Sleep(wait)
+	}
+	sc.lastStepAt = time.Now()
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPacingSpacesSteps(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	sc := NewSimulationController(benchmarkConfig(), 12345, WithPacing(interval))
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		sc.Step()
+	}
+	if elapsed := time.Since(start); elapsed < 3*interval-5*time.Millisecond {
+		t.Errorf("Expected 3 paced steps to take at least %s, took %s", 3*interval, elapsed)
+	}
+
+	sc.SetPacing(0)
+	start = time.Now()
+	sc.Step()
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Errorf("Expected an unpaced step to run at full speed, took %s", elapsed)
+	}
+}
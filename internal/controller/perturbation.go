@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"fmt"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// RemoveHumans makes a fraction of the humans other than the business owner leave at once, as a
+// sudden shock to the workforce, and returns how many left
+// The leavers are spread evenly through the workforce in hiring order, so every experience level
+// loses about the same share. They release their AI agents and may be backfilled like any other
+// departure; oversight and orchestration load are restored on the next step
+func (sc *SimulationController) RemoveHumans(fraction float64) int {
+	defer sc.publish()
+	candidates := make([]*types.HumanWorker, 0)
+	for _, human := range sc.workforceManager.GetAllHumans() {
+		if !human.IsBusinessOwner {
+			candidates = append(candidates, human)
+		}
+	}
+	count := int(float64(len(candidates))*fraction + 0.5)
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+
+	removed := 0
+	for i := 0; i < count; i++ {
+		human := candidates[i*len(candidates)/count]
+		if err := sc.workforceManager.RemoveHuman(human.ID); err != nil {
+			fmt.Printf("Warning: Failed to remove human worker %s: %v\n", human.ID, err)
+			continue
+		}
+		sc.recordEvent(types.AttritionEvent, "%s (%s) left in a workforce shock, releasing %d AI agents",
+			human.ID, human.ExperienceLevel, len(human.AssignedAgents))
+		sc.scheduleBackfill(human)
+		removed++
+	}
+	return removed
+}
+
+// ForceCatastrophicFailure makes a catastrophic failure of the given class and severity strike on
+// the next step, in place of any failure drawn at random
+// The random draw still takes place, so the run's random sequences are the same as without it
+func (sc *SimulationController) ForceCatastrophicFailure(class types.FailureClass, severity float64) {
+	sc.forcedFailure = &events.CatastrophicFailure{Class: class, Severity: severity}
+}
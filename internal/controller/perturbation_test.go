@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRemoveHumansSparesTheOwner(t *testing.T) {
+	controller := NewSimulationController(benchmarkConfig(), 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	before := len(controller.workforceManager.GetAllHumans())
+
+	if removed := controller.RemoveHumans(0.1); removed != 5 {
+		t.Errorf("Expected 10%% of the 49 humans besides the owner to leave, got %d", removed)
+	}
+	if after := len(controller.workforceManager.GetAllHumans()); after != before-5 {
+		t.Errorf("Expected %d humans to remain, got %d", before-5, after)
+	}
+	controller.RemoveHumans(1)
+	humans := controller.workforceManager.GetAllHumans()
+	if len(humans) != 1 || !humans[0].IsBusinessOwner {
+		t.Errorf("Expected only the business owner to remain, got %d humans", len(humans))
+	}
+}
+
+func TestForceCatastrophicFailureStrikesNextStep(t *testing.T) {
+	config := benchmarkConfig()
+	config.CatastrophicFailureRate = 0
+	controller := NewSimulationController(config, 12345)
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	controller.ForceCatastrophicFailure(types.DataCorruption, 1.0)
+	controller.Step()
+	controller.Step()
+	if controller.totalCatastrophicFailures != 1 || len(controller.failures) != 1 {
+		t.Fatalf("Expected exactly one failure, got %d", controller.totalCatastrophicFailures)
+	}
+	if failure := controller.failures[0]; failure.TimeStep != 1 || failure.Class != types.DataCorruption || failure.Severity != 1.0 {
+		t.Errorf("Expected a severity 1.0 Data_Corruption failure at step 1, got %+v", failure)
+	}
+}
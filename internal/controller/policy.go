@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// WorkforceAction is a number of AI agents to hire and to release at a time step
+type WorkforceAction struct {
+	Hire    int
+	Release int
+}
+
+// WorkforcePolicy chooses the workforce action at a time step in place of the optimizer
+type WorkforcePolicy func(timeStep int) WorkforceAction
+
+// WithWorkforcePolicy lets policy decide the AI agents hired and released at every time step
+// instead of the optimizer. Released agents are the least cost-effective ones, and hires are
+// limited by orchestration capacity, human oversight and the budget available before the step's
+// releases, each new agent going to the human with the most spare capacity. Budget-driven
+// releases are left to the policy as well
+func WithWorkforcePolicy(policy WorkforcePolicy) Option {
+	return func(sc *SimulationController) {
+		sc.policy = policy
+	}
+}
+
+// policyChange turns the policy's action at the current time step into a workforce change
+func (sc *SimulationController) policyChange(humans []*types.HumanWorker, agents []*types.AIAgent, availableBudget float64, availableCapacity int) events.WorkforceChange {
+	action := sc.policy(sc.currentTimeStep)
+	change := events.WorkforceChange{ReleaseAIAgents: make([]string, 0)}
+	if action.Release > 0 {
+		change.ReleaseAIAgents = sc.eventProcessor.SelectExcessAgents(agents, action.Release)
+	}
+
+	hires := action.Hire
+	if hires > availableCapacity {
+		hires = availableCapacity
+	}
+	agentCost := sc.config.Overhead.AICost(types.AIAgentCosts[types.UniversityHire]) * sc.config.MarketConditions(sc.currentTimeStep).AICost
+	affordable := 0
+	if availableBudget > 0 {
+		affordable = int(availableBudget / agentCost)
+	}
+	if hires > affordable {
+		hires = affordable
+	}
+
+	spare := make([]int, len(humans))
+	for i, human := range humans {
+		spare[i] = human.GetOrchestrationCapacity()
+	}
+	for len(change.Assignments) < hires {
+		chosen := 0
+		for i := range spare {
+			if spare[i] > spare[chosen] {
+				chosen = i
+			}
+		}
+		if len(spare) == 0 || spare[chosen] <= 0 {
+			break
+		}
+		spare[chosen]--
+		change.Assignments = append(change.Assignments, humans[chosen].ID)
+	}
+	change.HireAIAgents = len(change.Assignments)
+	if change.HireAIAgents > 0 {
+		change.OrchestratorID = change.Assignments[0]
+	}
+	return change
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// applyRules evaluates the configured rules on the previous step's state and carries out the
+// actions they take for the current step, recording them in the event log
+// Rules that fail are recorded and take no action
+func (sc *SimulationController) applyRules() {
+	previous := sc.ruleActions
+	if previous.AttritionMultiplier == 0 {
+		// Before the first evaluation attrition is unscaled
+		previous.AttritionMultiplier = 1.0
+	}
+	sc.ruleActions = events.RuleActions{AttritionMultiplier: 1.0}
+	if len(sc.timeSeries) == 0 {
+		return
+	}
+	actions, err := sc.eventProcessor.EvaluateRules(sc.timeSeries[len(sc.timeSeries)-1])
+	if err != nil {
+		sc.recordEvent(types.RuleEvent, "%v", err)
+		return
+	}
+	sc.ruleActions = actions
+
+	for _, message := range actions.Messages {
+		sc.recordEvent(types.RuleEvent, "rules: %s", message)
+	}
+	if actions.FreezeAIHiring != previous.FreezeAIHiring {
+		sc.recordEvent(types.RuleEvent, "rules %s AI hiring", frozenOrResumed(actions.FreezeAIHiring))
+	}
+	if actions.FreezeHumanHiring != previous.FreezeHumanHiring {
+		sc.recordEvent(types.RuleEvent, "rules %s human hiring", frozenOrResumed(actions.FreezeHumanHiring))
+	}
+	if actions.AttritionMultiplier != previous.AttritionMultiplier {
+		sc.recordEvent(types.RuleEvent, "rules scaled attrition by %.2f", actions.AttritionMultiplier)
+	}
+
+	if actions.ReleaseAgents > 0 {
+		released := 0
+		for _, agentID := range sc.eventProcessor.SelectExcessAgents(sc.workforceManager.GetAllAIAgents(), actions.ReleaseAgents) {
+			if err := sc.workforceManager.ReleaseAIAgent(agentID); err != nil {
+				fmt.Printf("Warning: Failed to release AI agent %s: %v\n", agentID, err)
+				continue
+			}
+			released++
+		}
+		sc.stepAgentsReleased += released
+		if released > 0 {
+			sc.recordEvent(types.RuleEvent, "rules released %d AI agents", released)
+		}
+	}
+}
+
+// aiHiringFrozen reports whether AI agents may not be hired at the current time step, by the
+// attrition configuration or the rules
+func (sc *SimulationController) aiHiringFrozen() bool {
+	return sc.config.AttritionConfig.AIHiringFrozen() || sc.ruleActions.FreezeAIHiring
+}
+
+// frozenOrResumed describes a change of a hiring freeze
+func frozenOrResumed(frozen bool) string {
+	if frozen {
+		return "froze"
+	}
+	return "resumed"
+}
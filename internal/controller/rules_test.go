@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestRulesFreezeAIHiring(t *testing.T) {
+	config := benchmarkConfig()
+	config.Rules.Script = `
+def rules(state):
+    if state.time_step >= 2:
+        freeze_ai_hiring()
+        print("holding at", state.ai_agents, "agents")
+`
+	sc := NewSimulationController(config, 42)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	first := sc.Step()
+	second := sc.Step()
+	third := sc.Step()
+	if first.AgentsHiredThisStep == 0 || second.AgentsHiredThisStep == 0 {
+		t.Fatal("Expected AI agents to be hired before the rules froze hiring")
+	}
+	if third.AgentsHiredThisStep != 0 {
+		t.Errorf("Expected no AI agents to be hired while frozen, got %d", third.AgentsHiredThisStep)
+	}
+
+	var descriptions []string
+	for _, event := range sc.GetEvents() {
+		if event.Type == types.RuleEvent {
+			descriptions = append(descriptions, event.Description)
+		}
+	}
+	if len(descriptions) != 2 || descriptions[1] != "rules froze AI hiring" || !strings.HasPrefix(descriptions[0], "rules: holding at") {
+		t.Errorf("Expected the rule's message and freeze to be recorded, got %q", descriptions)
+	}
+}
+
+func TestRulesReleaseAgents(t *testing.T) {
+	config := benchmarkConfig()
+	config.Rules.Script = `
+def rules(state):
+    if state.time_step == 1:
+        release_agents(3)
+`
+	sc := NewSimulationController(config, 42)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	first := sc.Step()
+	second := sc.Step()
+	if first.Workforce.AIAgents.Total < 3 {
+		t.Fatalf("Expected at least 3 AI agents after the first step, got %d", first.Workforce.AIAgents.Total)
+	}
+	if second.AgentsReleasedThisStep < 3 {
+		t.Errorf("Expected the rules to release 3 AI agents, got %d releases", second.AgentsReleasedThisStep)
+	}
+}
+
+func TestRulesInvalidScript(t *testing.T) {
+	config := benchmarkConfig()
+	config.Rules.Script = "def rules(state):\n  if\n"
+	if err := NewSimulationController(config, 42).Initialize(); err == nil {
+		t.Error("Expected Initialize to fail with an invalid rules script")
+	}
+}
+
+func TestRulesFailureTakesNoAction(t *testing.T) {
+	config := benchmarkConfig()
+	config.Rules.Script = "def rules(state):\n  freeze_ai_hiring()\n  fail(\"broken\")\n"
+	sc := NewSimulationController(config, 42)
+	if err := sc.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if state := sc.Step(); state.AgentsHiredThisStep == 0 {
+		t.Error("Expected failed rules not to freeze AI hiring")
+	}
+	events := sc.GetEvents()
+	if len(events) == 0 || events[0].Type != types.RuleEvent || !strings.Contains(events[0].Description, "broken") {
+		t.Errorf("Expected the failure to be recorded, got %+v", events)
+	}
+}
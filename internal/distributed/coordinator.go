@@ -0,0 +1,283 @@
+// Package distributed runs sensitivity analyses across machines: a Coordinator queues the
+// runs of a sweep and hands them to Workers that poll it over HTTP, so sweeps too large for one
+// machine finish in the time of the slowest share
+package distributed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Defaults of Coordinators
+const (
+	DefaultLease       = 10 * time.Minute
+	DefaultMaxAttempts = 3
+	DefaultPollWait    = 30 * time.Second
+)
+
+// Endpoints of the coordinator
+const (
+	claimPath  = "/v1/claim"
+	resultPath = "/v1/results/"
+)
+
+// ErrClosed is returned for runs still queued when the coordinator is closed
+var ErrClosed = errors.New("coordinator closed")
+
+// Claim is what a worker sends to ask for a task
+type Claim struct {
+	Worker  string // name of the worker, for messages
+	Version string // simulator version of the worker, which must match the coordinator's
+}
+
+// Assignment is a task handed to a worker, to be reported under its ID
+type Assignment struct {
+	ID   string
+	Task analytics.SweepTask
+}
+
+// Report is the outcome of a task a worker sends back
+type Report struct {
+	Result *types.SimulationResult `json:",omitempty"`
+	Error  string                  `json:",omitempty"` // why the simulation failed; simulations are deterministic, so it is not retried
+}
+
+// Coordinator is an analytics.SweepExecutor queueing runs for remote workers, and the HTTP
+// handler the workers poll
+// A task not reported within Lease of being claimed, e.g. because its worker died, is offered
+// to another worker, up to MaxAttempts claims; Execute waits as long as it takes workers to
+// connect, so a sweep only progresses while workers run
+type Coordinator struct {
+	Lease       time.Duration // time a worker has to report a claimed task
+	MaxAttempts int           // claims of a task before it fails
+	PollWait    time.Duration // time a claim waits for a task before returning none
+	Token       string        // bearer token workers must present, if not empty
+
+	mu      sync.Mutex
+	nextID  int
+	queue   []*pendingTask
+	claimed map[string]*pendingTask
+	wake    chan struct{} // closed and replaced when tasks are queued or the coordinator closes
+	closed  bool
+}
+
+// pendingTask is a task waiting to be claimed or reported
+type pendingTask struct {
+	id       string
+	task     analytics.SweepTask
+	attempts int
+	worker   string // name of the worker of the last claim
+	deadline time.Time
+	done     chan outcome
+}
+
+// outcome is the result of a task
+type outcome struct {
+	result types.SimulationResult
+	err    error
+}
+
+// NewCoordinator returns a coordinator with the default lease, attempts and poll wait
+func NewCoordinator() *Coordinator {
+	return &Coordinator{
+		Lease:       DefaultLease,
+		MaxAttempts: DefaultMaxAttempts,
+		PollWait:    DefaultPollWait,
+		claimed:     make(map[string]*pendingTask),
+		wake:        make(chan struct{}),
+	}
+}
+
+// Execute queues a task and waits for a worker to report its result
+func (c *Coordinator) Execute(task analytics.SweepTask) (types.SimulationResult, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return types.SimulationResult{}, ErrClosed
+	}
+	c.nextID++
+	pending := &pendingTask{id: strconv.Itoa(c.nextID), task: task, done: make(chan outcome, 1)}
+	c.queue = append(c.queue, pending)
+	c.wakeLocked()
+	c.mu.Unlock()
+
+	out := <-pending.done
+	return out.result, out.err
+}
+
+// Close fails the tasks not yet reported and tells polling workers that the sweep is over
+func (c *Coordinator) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	for _, pending := range c.queue {
+		pending.done <- outcome{err: ErrClosed}
+	}
+	for _, pending := range c.claimed {
+		pending.done <- outcome{err: ErrClosed}
+	}
+	c.queue, c.claimed = nil, nil
+	c.wakeLocked()
+}
+
+// wakeLocked wakes the claims waiting for a task
+func (c *Coordinator) wakeLocked() {
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
+
+// ServeHTTP serves the claims and reports of workers
+func (c *Coordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if c.Token != "" && r.Header.Get("Authorization") != "Bearer "+c.Token {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch {
+	case r.URL.Path == claimPath:
+		c.serveClaim(w, r)
+	case strings.HasPrefix(r.URL.Path, resultPath):
+		c.serveReport(w, r, strings.TrimPrefix(r.URL.Path, resultPath))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveClaim assigns the next task to a worker, waiting up to PollWait for one
+// Responds 204 No Content if none was queued in time and 410 Gone once the coordinator is closed
+func (c *Coordinator) serveClaim(w http.ResponseWriter, r *http.Request) {
+	var claim Claim
+	if err := json.NewDecoder(r.Body).Decode(&claim); err != nil {
+		http.Error(w, "invalid claim: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if claim.Version != types.Version {
+		http.Error(w, fmt.Sprintf("worker version %s does not match coordinator version %s", claim.Version, types.Version), http.StatusConflict)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), c.PollWait)
+	defer cancel()
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			http.Error(w, ErrClosed.Error(), http.StatusGone)
+			return
+		}
+		nextExpiry := c.expireLocked(time.Now())
+		if len(c.queue) > 0 {
+			pending := c.queue[0]
+			c.queue = c.queue[1:]
+			pending.attempts++
+			pending.worker = claim.Worker
+			pending.deadline = time.Now().Add(c.Lease)
+			c.claimed[pending.id] = pending
+			assignment := Assignment{ID: pending.id, Task: pending.task}
+			c.mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(assignment)
+			return
+		}
+		wake := c.wake
+		c.mu.Unlock()
+
+		// A lease running out while waiting makes its task available again
+		var expiry <-chan time.Time
+		var timer *time.Timer
+		if !nextExpiry.IsZero() {
+			timer = time.NewTimer(time.Until(nextExpiry))
+			expiry = timer.C
+		}
+		select {
+		case <-wake:
+		case <-expiry:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+}
+
+// expireLocked queues again the claimed tasks whose lease has run out, failing those claimed
+// MaxAttempts times already, and returns the earliest deadline of the others, zero if none
+func (c *Coordinator) expireLocked(now time.Time) time.Time {
+	var next time.Time
+	for id, pending := range c.claimed {
+		if now.Before(pending.deadline) {
+			if next.IsZero() || pending.deadline.Before(next) {
+				next = pending.deadline
+			}
+			continue
+		}
+		delete(c.claimed, id)
+		if pending.attempts >= c.MaxAttempts {
+			pending.done <- outcome{err: fmt.Errorf("no result within %s of each of %d claims, the last by worker %q", c.Lease, pending.attempts, pending.worker)}
+			continue
+		}
+		c.queue = append([]*pendingTask{pending}, c.queue...)
+	}
+	return next
+}
+
+// serveReport delivers the result of a task to its Execute call
+// A task reported after its lease ran out is still accepted if no other worker reported it
+// first; later reports of a task get 409 Conflict
+func (c *Coordinator) serveReport(w http.ResponseWriter, r *http.Request, id string) {
+	var report Report
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		http.Error(w, "invalid report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if report.Result == nil && report.Error == "" {
+		http.Error(w, "report has neither a result nor an error", http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	pending, ok := c.claimed[id]
+	if ok {
+		delete(c.claimed, id)
+	} else {
+		for i, queued := range c.queue {
+			if queued.id == id {
+				pending, ok = queued, true
+				c.queue = append(c.queue[:i], c.queue[i+1:]...)
+				break
+			}
+		}
+	}
+	c.mu.Unlock()
+	if !ok {
+		http.Error(w, "task "+id+" is not pending", http.StatusConflict)
+		return
+	}
+
+	if report.Error != "" {
+		pending.done <- outcome{err: errors.New(report.Error)}
+	} else {
+		pending.done <- outcome{result: *report.Result}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
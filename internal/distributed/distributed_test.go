@@ -0,0 +1,253 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func testSimulationConfig() types.SimulationConfig {
+	return types.SimulationConfig{
+		InitialHumans: 10,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     3000000.0,
+		RevenueScenario: types.FlatRevenue,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        20.0,
+			ForcedAcceleration: 1.0,
+		},
+		CatastrophicFailureRate: 0.05,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+// newTestCoordinator serves a coordinator with short waits, closing both when the test ends
+func newTestCoordinator(t *testing.T, token string) (*Coordinator, *httptest.Server) {
+	coordinator := NewCoordinator()
+	coordinator.PollWait = 50 * time.Millisecond
+	coordinator.Token = token
+	server := httptest.NewServer(coordinator)
+	t.Cleanup(func() {
+		coordinator.Close()
+		server.Close()
+	})
+	return coordinator, server
+}
+
+func TestSweepAcrossWorkersMatchesLocalRuns(t *testing.T) {
+	coordinator, server := newTestCoordinator(t, "secret")
+	config := testSimulationConfig()
+	ranges := analytics.ParameterRanges{
+		FixedBudget:             []float64{config.FixedBudget, config.FixedBudget * 1.5, config.FixedBudget * 2},
+		CatastrophicFailureRate: []float64{0.01, 0.1},
+	}
+
+	// Workers start before the sweep and wait for it
+	var wg sync.WaitGroup
+	completed := make([]int, 2)
+	errs := make([]error, 2)
+	for i := range completed {
+		worker := NewWorker(server.URL)
+		worker.Token = "secret"
+		worker.Parallelism = 2
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			completed[i], errs[i] = worker.Run(context.Background())
+		}(i)
+	}
+
+	engine := analytics.NewAnalyticsEngine()
+	local, err := engine.RunSensitivityAnalysis(config, ranges, 100, 7)
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysis failed: %v", err)
+	}
+	remote, err := engine.RunSensitivityAnalysisWithOptions(config, ranges, 100, 7, analytics.SensitivityOptions{Executor: coordinator})
+	if err != nil {
+		t.Fatalf("RunSensitivityAnalysisWithOptions across workers failed: %v", err)
+	}
+	coordinator.Close()
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Worker %d failed: %v", i, err)
+		}
+	}
+	if total := completed[0] + completed[1]; total != 5 {
+		t.Errorf("Expected the workers to complete 5 runs, got %d", total)
+	}
+	for name, results := range local {
+		for i := range results.Results {
+			if local, remote := results.Results[i], remote[name].Results[i]; local.TimeToEquilibrium != remote.TimeToEquilibrium ||
+				!reflect.DeepEqual(local.EquilibriumState.Workforce, remote.EquilibriumState.Workforce) || local.EquilibriumState.TotalCost != remote.EquilibriumState.TotalCost {
+				t.Errorf("%s run %d: expected the distributed run to match the local one", name, i)
+			}
+		}
+	}
+}
+
+// post sends a request to a test coordinator
+func post(t *testing.T, server *httptest.Server, path string, token string, body any) *http.Response {
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to encode request: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+// claimTask claims tasks until one is assigned
+func claimTask(t *testing.T, server *httptest.Server) Assignment {
+	for {
+		response := post(t, server, claimPath, "", Claim{Worker: "test", Version: types.Version})
+		if response.StatusCode == http.StatusOK {
+			var assignment Assignment
+			if err := json.NewDecoder(response.Body).Decode(&assignment); err != nil {
+				t.Fatalf("Failed to decode assignment: %v", err)
+			}
+			return assignment
+		}
+		if response.StatusCode != http.StatusNoContent {
+			t.Fatalf("Unexpected claim status %s", response.Status)
+		}
+	}
+}
+
+func TestExpiredLeaseIsClaimedAgain(t *testing.T) {
+	coordinator, server := newTestCoordinator(t, "")
+	coordinator.Lease = 20 * time.Millisecond
+	coordinator.MaxAttempts = 2
+
+	task := analytics.SweepTask{Key: store.RunKey{Parameter: "FixedBudget", Seed: 7}, Config: testSimulationConfig(), MaxTimeSteps: 20}
+	type executed struct {
+		result types.SimulationResult
+		err    error
+	}
+	done := make(chan executed, 1)
+	go func() {
+		result, err := coordinator.Execute(task)
+		done <- executed{result, err}
+	}()
+
+	first := claimTask(t, server)
+	// The first worker never reports, so the task is offered again once its lease runs out
+	second := claimTask(t, server)
+	if second.ID != first.ID {
+		t.Errorf("Expected task %s again, got %s", first.ID, second.ID)
+	}
+
+	result := types.SimulationResult{TimeToEquilibrium: 12}
+	if response := post(t, server, resultPath+second.ID, "", Report{Result: &result}); response.StatusCode != http.StatusNoContent {
+		t.Fatalf("Unexpected report status %s", response.Status)
+	}
+	if response := post(t, server, resultPath+first.ID, "", Report{Result: &result}); response.StatusCode != http.StatusConflict {
+		t.Errorf("Expected a late duplicate report to conflict, got %s", response.Status)
+	}
+	out := <-done
+	if out.err != nil || out.result.TimeToEquilibrium != 12 {
+		t.Errorf("Expected the reported result, got %+v, %v", out.result, out.err)
+	}
+}
+
+func TestTaskFailsAfterMaxAttempts(t *testing.T) {
+	coordinator, server := newTestCoordinator(t, "")
+	coordinator.Lease = time.Millisecond
+	coordinator.MaxAttempts = 1
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := coordinator.Execute(analytics.SweepTask{Config: testSimulationConfig(), MaxTimeSteps: 20})
+		done <- err
+	}()
+	claimTask(t, server)
+	time.Sleep(5 * time.Millisecond)
+	post(t, server, claimPath, "", Claim{Worker: "test", Version: types.Version})
+
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "no result") {
+		t.Errorf("Expected the task to fail once its only claim expired, got %v", err)
+	}
+}
+
+func TestCoordinatorRejectsWorkers(t *testing.T) {
+	_, server := newTestCoordinator(t, "secret")
+
+	if response := post(t, server, claimPath, "wrong", Claim{Version: types.Version}); response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected a wrong token to be rejected, got %s", response.Status)
+	}
+	if response := post(t, server, claimPath, "secret", Claim{Version: types.Version + "-other"}); response.StatusCode != http.StatusConflict {
+		t.Errorf("Expected another version to be rejected, got %s", response.Status)
+	}
+
+	worker := NewWorker(server.URL)
+	worker.Token = "wrong"
+	if _, err := worker.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Expected the worker to stop when rejected, got %v", err)
+	}
+}
+
+func TestWorkerGivesUpWithoutCoordinator(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	worker := NewWorker(url)
+	worker.Retries, worker.Backoff = 1, time.Millisecond
+	if _, err := worker.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to reach coordinator") {
+		t.Errorf("Expected the worker to give up, got %v", err)
+	}
+}
+
+func TestCloseFailsPendingTasks(t *testing.T) {
+	coordinator := NewCoordinator()
+	done := make(chan error, 1)
+	go func() {
+		_, err := coordinator.Execute(analytics.SweepTask{})
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	coordinator.Close()
+	if err := <-done; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := coordinator.Execute(analytics.SweepTask{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Defaults of Workers
+const (
+	DefaultRetries = 5
+	DefaultBackoff = time.Second
+)
+
+// Worker runs the tasks of a coordinator until the sweep is over
+// Requests failing with network or server errors are retried with exponential backoff, so
+// workers may be started before their coordinator and ride out brief outages
+type Worker struct {
+	Coordinator string // base URL of the coordinator, e.g. http://coordinator:7070
+	Name        string // sent with claims, for messages
+	Token       string // bearer token of the coordinator, if it requires one
+	Parallelism int    // tasks run at once; less than 1 runs one
+	// Options apply to the controller of every run, and must match those of the coordinator's
+	// sweep, e.g. by loading the same extensions, for results to match local runs
+	Options []controller.Option
+
+	Retries int           // retries of a failed request
+	Backoff time.Duration // wait before the first retry, doubled for each further one
+	Client  *http.Client  // nil uses http.DefaultClient
+}
+
+// NewWorker returns a worker of the coordinator at the base URL with the default retries and
+// backoff
+func NewWorker(coordinator string) *Worker {
+	return &Worker{Coordinator: coordinator, Retries: DefaultRetries, Backoff: DefaultBackoff}
+}
+
+// errSweepOver is returned by claim once the coordinator is closed
+var errSweepOver = errors.New("sweep over")
+
+// Run claims and runs tasks until the coordinator closes, returning the number of runs completed
+// Returns an error if the coordinator cannot be reached within the retries, rejects the worker
+// or ctx is cancelled
+func (w *Worker) Run(ctx context.Context) (int, error) {
+	parallelism := w.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	completed := 0
+	over := false
+	var first error
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				done, err := w.runOne(ctx)
+				mu.Lock()
+				if done {
+					completed++
+				}
+				if errors.Is(err, errSweepOver) {
+					over = true
+				} else if err != nil && first == nil && !over {
+					first = err
+				}
+				mu.Unlock()
+				if errors.Is(err, errSweepOver) {
+					return
+				}
+				if err != nil {
+					// The other loops stop too rather than run tasks that could not be reported;
+					// their errors are not reported if the coordinator is gone after the sweep
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return completed, first
+}
+
+// runOne claims a task, runs it and reports its outcome, reporting whether a task was run
+func (w *Worker) runOne(ctx context.Context) (bool, error) {
+	assignment, err := w.claim(ctx)
+	if err != nil || assignment == nil {
+		return false, err
+	}
+
+	var report Report
+	result, err := assignment.Task.Run(w.Options...)
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Result = &result
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode result of task %s: %w", assignment.ID, err)
+	}
+	status, _, err := w.post(ctx, resultPath+assignment.ID, body)
+	if err != nil {
+		return false, err
+	}
+	// A conflict means the task was reported by another worker after this one's lease ran out
+	return status != http.StatusConflict, nil
+}
+
+// claim asks the coordinator for a task, returning nil if none was available within its poll
+// wait and errSweepOver once it is closed
+func (w *Worker) claim(ctx context.Context) (*Assignment, error) {
+	body, err := json.Marshal(Claim{Worker: w.Name, Version: types.Version})
+	if err != nil {
+		return nil, err
+	}
+	status, response, err := w.post(ctx, claimPath, body)
+	switch {
+	case err != nil:
+		return nil, err
+	case status == http.StatusGone:
+		return nil, errSweepOver
+	case status == http.StatusNoContent:
+		return nil, nil
+	}
+	var assignment Assignment
+	if err := json.Unmarshal(response, &assignment); err != nil {
+		return nil, fmt.Errorf("invalid task from coordinator: %w", err)
+	}
+	return &assignment, nil
+}
+
+// post sends a request to the coordinator, retrying network and server errors, and returns
+// the status and body of a response that is a success, 409 Conflict or 410 Gone
+func (w *Worker) post(ctx context.Context, path string, body []byte) (int, []byte, error) {
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	url := strings.TrimSuffix(w.Coordinator, "/") + path
+	backoff := w.Backoff
+	var err error
+	for attempt := 0; attempt <= w.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return 0, nil, ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		var request *http.Request
+		request, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return 0, nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if w.Token != "" {
+			request.Header.Set("Authorization", "Bearer "+w.Token)
+		}
+		var response *http.Response
+		response, err = client.Do(request)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, nil, ctx.Err()
+			}
+			continue
+		}
+		data, readErr := io.ReadAll(response.Body)
+		response.Body.Close()
+		switch {
+		case readErr != nil:
+			err = readErr
+		case response.StatusCode/100 == 2 || response.StatusCode == http.StatusConflict && path != claimPath || response.StatusCode == http.StatusGone:
+			return response.StatusCode, data, nil
+		case response.StatusCode >= 500:
+			err = fmt.Errorf("%s: %s", response.Status, strings.TrimSpace(string(data)))
+		default:
+			return 0, nil, fmt.Errorf("coordinator rejected the worker: %s: %s", response.Status, strings.TrimSpace(string(data)))
+		}
+	}
+	return 0, nil, fmt.Errorf("failed to reach coordinator at %s: %w", w.Coordinator, err)
+}
@@ -0,0 +1,70 @@
+package economic
+
+import (
+	"math"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// PerStepRate converts an annual rate (e.g. 0.08 for 8%) to the equivalent compound rate per time step
+func PerStepRate(annualRate float64) float64 {
+	return math.Pow(1.0+annualRate, 1.0/types.TimeStepsPerYear) - 1.0
+}
+
+// AnnualRate converts a compound rate per time step to the equivalent annual rate
+func AnnualRate(perStepRate float64) float64 {
+	return math.Pow(1.0+perStepRate, types.TimeStepsPerYear) - 1.0
+}
+
+// NetPresentValue discounts a series of per-step cash flows at the given per-step rate
+// The first cash flow occurs at step 0 and is not discounted
+func NetPresentValue(perStepRate float64, cashFlows []float64) float64 {
+	npv := 0.0
+	discount := 1.0
+	for _, cashFlow := range cashFlows {
+		npv += cashFlow / discount
+		discount *= 1.0 + perStepRate
+	}
+	return npv
+}
+
+// InternalRateOfReturn finds the per-step rate at which the NPV of the cash flows is zero
+// Returns false if the cash flows never change sign or no root is bracketed, in which case
+// the IRR is undefined
+func InternalRateOfReturn(cashFlows []float64) (float64, bool) {
+	hasPositive, hasNegative := false, false
+	for _, cashFlow := range cashFlows {
+		if cashFlow > 0 {
+			hasPositive = true
+		} else if cashFlow < 0 {
+			hasNegative = true
+		}
+	}
+	if !hasPositive || !hasNegative {
+		return 0, false
+	}
+
+	// Bisection between a near-total loss and a very high return per step
+	low, high := -0.99, 10.0
+	npvLow := NetPresentValue(low, cashFlows)
+	npvHigh := NetPresentValue(high, cashFlows)
+	if npvLow*npvHigh > 0 {
+		return 0, false
+	}
+
+	const tolerance = 1e-10
+	const maxIterations = 200
+	for i := 0; i < maxIterations && high-low > tolerance; i++ {
+		mid := (low + high) / 2.0
+		npvMid := NetPresentValue(mid, cashFlows)
+		if npvMid == 0 {
+			return mid, true
+		}
+		if npvLow*npvMid < 0 {
+			high = mid
+		} else {
+			low, npvLow = mid, npvMid
+		}
+	}
+
+	return (low + high) / 2.0, true
+}
@@ -0,0 +1,44 @@
+package economic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNetPresentValue(t *testing.T) {
+	flows := []float64{-100.0, 60.0, 60.0}
+
+	if npv := NetPresentValue(0.0, flows); npv != 20.0 {
+		t.Errorf("Expected undiscounted NPV 20, got %f", npv)
+	}
+
+	expected := -100.0 + 60.0/1.1 + 60.0/(1.1*1.1)
+	if npv := NetPresentValue(0.1, flows); math.Abs(npv-expected) > 1e-9 {
+		t.Errorf("Expected NPV %f at 10%%, got %f", expected, npv)
+	}
+}
+
+func TestInternalRateOfReturn(t *testing.T) {
+	// -100 now and 110 one step later is a 10% return per step
+	rate, ok := InternalRateOfReturn([]float64{-100.0, 110.0})
+	if !ok {
+		t.Fatal("Expected IRR to be defined")
+	}
+	if math.Abs(rate-0.1) > 1e-6 {
+		t.Errorf("Expected IRR 0.1, got %f", rate)
+	}
+
+	if _, ok := InternalRateOfReturn([]float64{10.0, 20.0}); ok {
+		t.Error("Expected IRR to be undefined for all-positive cash flows")
+	}
+	if _, ok := InternalRateOfReturn([]float64{0.0, 0.0}); ok {
+		t.Error("Expected IRR to be undefined for zero cash flows")
+	}
+}
+
+func TestRateConversionRoundTrip(t *testing.T) {
+	annual := 0.08
+	if got := AnnualRate(PerStepRate(annual)); math.Abs(got-annual) > 1e-12 {
+		t.Errorf("Expected round trip to return %f, got %f", annual, got)
+	}
+}
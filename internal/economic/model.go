@@ -2,6 +2,7 @@ package economic
 
 import (
 	"math"
+	"workforce-ai-transition-simulator/internal/random"
 	"workforce-ai-transition-simulator/internal/types"
 )
 
@@ -9,16 +10,41 @@ import (
 type EconomicModel struct {
 	fixedBudget     float64
 	revenueScenario types.RevenueScenario
+	overhead        types.OverheadConfig
+	financing       types.FinancingConfig
+	debt            float64 // outstanding debt under the financing terms
+	market          types.MarketConditions
+	aiPricing       types.AIPricingConfig // platform fee and volume discounts on the AI fleet
+	volatility      float64 // sigma of the lognormal noise on revenue per time step
+	marketSize      float64 // most revenue the market can absorb, 0 for no limit
+	revenueRNG      random.RNG
 	revenueHistory  []float64
 }
 
 // NewEconomicModel creates a new EconomicModel instance
-func NewEconomicModel(fixedBudget float64, revenueScenario types.RevenueScenario) *EconomicModel {
-	return &EconomicModel{
+// Workforce costs are fully loaded with the given overhead. With a non-zero volatility,
+// revenue noise is drawn from its own stream so it never shifts other random sequences.
+// A non-zero marketSize caps revenue; see CalculateRevenue
+func NewEconomicModel(fixedBudget float64, revenueScenario types.RevenueScenario, overhead types.OverheadConfig, financing types.FinancingConfig, volatility float64, marketSize float64, streams *random.Streams) *EconomicModel {
+	em := &EconomicModel{
 		fixedBudget:     fixedBudget,
 		revenueScenario: revenueScenario,
+		overhead:        overhead,
+		financing:       financing,
+		market:          types.NormalMarket(),
+		volatility:      volatility,
+		marketSize:      marketSize,
 		revenueHistory:  make([]float64, 0),
 	}
+	if volatility > 0 {
+		em.revenueRNG = streams.Get(random.StreamRevenue)
+	}
+	return em
+}
+
+// SetAIPricing sets the platform fee and volume discounts pricing the AI fleet
+func (em *EconomicModel) SetAIPricing(pricing types.AIPricingConfig) {
+	em.aiPricing = pricing
 }
 
 // GetFixedBudget returns the fixed budget value
@@ -26,32 +52,119 @@ func (em *EconomicModel) GetFixedBudget() float64 {
 	return em.fixedBudget
 }
 
+// GetDebt returns the outstanding debt
+func (em *EconomicModel) GetDebt() float64 {
+	return em.debt
+}
+
+// RestoreDebt replaces the outstanding debt, e.g. when resuming from a checkpoint
+func (em *EconomicModel) RestoreDebt(debt float64) {
+	em.debt = debt
+}
+
+// spendingLimit returns the annual workforce spending the organization can fund
+// Without financing this is the fixed budget; with it, the unused credit line is added and
+// the annualized service of the outstanding debt is set aside
+func (em *EconomicModel) spendingLimit() float64 {
+	if !em.financing.Enabled() && em.debt == 0 {
+		return em.fixedBudget
+	}
+	headroom := math.Max(em.financing.CreditLine-em.debt, 0)
+	service := em.debt*PerStepRate(em.financing.InterestRate) + em.financing.Repayment(em.debt)
+	return em.fixedBudget + headroom - service*types.TimeStepsPerYear
+}
+
+// SettleFinancing pays interest and the scheduled repayment on the outstanding debt and borrows
+// whatever part of the step's spending the fixed budget cannot cover, up to the credit line
+// workforceCost is the annual workforce cost; returns the step's financing and its interest
+func (em *EconomicModel) SettleFinancing(workforceCost float64, revenue float64) (types.FinancingState, float64) {
+	if !em.financing.Enabled() && em.debt == 0 {
+		return types.FinancingState{}, 0
+	}
+	
+	interest := em.debt * PerStepRate(em.financing.InterestRate)
+	repaid := em.financing.Repayment(em.debt)
+	shortfall := (workforceCost-em.fixedBudget)/types.TimeStepsPerYear + interest + repaid
+	borrowed := math.Max(math.Min(shortfall, em.financing.CreditLine-(em.debt-repaid)), 0)
+	em.debt += borrowed - repaid
+	
+	state := types.FinancingState{Borrowed: borrowed, Repaid: repaid, Debt: em.debt}
+	if revenue > 0 {
+		state.Leverage = em.debt / revenue
+	}
+	return state, interest
+}
+
+// SetMarketConditions sets the market shock factors applied to revenue and costs from now on
+func (em *EconomicModel) SetMarketConditions(market types.MarketConditions) {
+	em.market = market
+}
+
 // GetRevenueHistory returns the revenue history
 func (em *EconomicModel) GetRevenueHistory() []float64 {
 	return em.revenueHistory
 }
 
-// CalculateWorkforceCost sums costs of all humans and AI agents
+// RestoreRevenueHistory replaces the revenue history, e.g. when resuming from a checkpoint
+func (em *EconomicModel) RestoreRevenueHistory(history []float64) {
+	em.revenueHistory = append(make([]float64, 0, len(history)), history...)
+}
+
+// CalculateWorkforceCost sums the fully loaded costs of all humans and AI agents under the
+// current market conditions
 func (em *EconomicModel) CalculateWorkforceCost(humans []*types.HumanWorker, agents []*types.AIAgent) float64 {
 	totalCost := 0.0
 	
 	// Sum human costs
 	for _, human := range humans {
-		totalCost += human.BaseCost
+		totalCost += em.overhead.HumanCost(human.BaseCost, human.ContractType) * em.market.Salary
 	}
 	
-	// Sum AI agent costs
+	// Sum AI agent costs, then price the fleet as a whole
+	aiCost := 0.0
 	for _, agent := range agents {
-		totalCost += agent.GetCost()
+		cost := em.overhead.AICost(agent.GetCost()) * em.market.AICost
+		totalCost += cost
+		aiCost += cost
+	}
+	if em.aiPricing.Enabled() {
+		totalCost += em.aiPricing.FleetCost(aiCost, len(agents), em.market.AICost) - aiCost
 	}
 	
 	return totalCost
 }
 
+// CalculateCostBreakdown itemizes human and AI agent costs by experience level
+// Penalties and severance are event-driven and left for the caller to fill in
+func (em *EconomicModel) CalculateCostBreakdown(humans []*types.HumanWorker, agents []*types.AIAgent) types.CostBreakdown {
+	breakdown := types.CostBreakdown{
+		HumanPayroll: make(map[types.ExperienceLevel]float64),
+		AICost:       make(map[types.ExperienceLevel]float64),
+	}
+	
+	for _, human := range humans {
+		breakdown.HumanPayroll[human.ExperienceLevel] += em.overhead.HumanCost(human.BaseCost, human.ContractType) * em.market.Salary
+	}
+	
+	discount := 1.0 - em.aiPricing.Discount(len(agents))
+	aiCost := 0.0
+	for _, agent := range agents {
+		cost := em.overhead.AICost(agent.GetCost()) * em.market.AICost * discount
+		breakdown.AICost[agent.ExperienceLevel] += cost
+		aiCost += cost
+	}
+	if em.aiPricing.Enabled() && len(agents) > 0 {
+		breakdown.AIPlatformFee = math.Max(em.aiPricing.PlatformFee*em.market.AICost-aiCost, 0)
+	}
+	
+	return breakdown
+}
+
 // GetAvailableBudget calculates remaining budget after current workforce costs
+// With financing, the budget includes the unused credit line less the annual debt service
 func (em *EconomicModel) GetAvailableBudget(humans []*types.HumanWorker, agents []*types.AIAgent) float64 {
 	currentCost := em.CalculateWorkforceCost(humans, agents)
-	return em.fixedBudget - currentCost
+	return em.spendingLimit() - currentCost
 }
 
 // CanAfford checks if a cost fits within available budget
@@ -61,7 +174,11 @@ func (em *EconomicModel) CanAfford(cost float64, humans []*types.HumanWorker, ag
 }
 
 // CalculateRevenue calculates revenue based on productivity and time step
-// Handles Flat_Revenue and Explosive_Growth scenarios
+// Handles Flat_Revenue and Explosive_Growth scenarios, scaled by the current market demand.
+// With a market size set, revenue saturates towards it (scaled by demand) instead of growing
+// linearly with productivity. With a non-zero volatility, revenue is multiplied by lognormal
+// noise with a mean of 1, so it varies between seeds without drifting from the deterministic
+// path on average
 func (em *EconomicModel) CalculateRevenue(productivity float64, timeStep int) float64 {
 	var revenue float64
 	
@@ -81,6 +198,10 @@ func (em *EconomicModel) CalculateRevenue(productivity float64, timeStep int) fl
 		// Default to flat revenue
 		revenue = productivity * 100000.0
 	}
+	revenue = saturate(revenue, em.marketSize) * em.market.Demand
+	if em.volatility > 0 {
+		revenue *= math.Exp(em.volatility*em.revenueRNG.NormFloat64() - em.volatility*em.volatility/2.0)
+	}
 	
 	// Record revenue in history
 	em.revenueHistory = append(em.revenueHistory, revenue)
@@ -88,6 +209,60 @@ func (em *EconomicModel) CalculateRevenue(productivity float64, timeStep int) fl
 	return revenue
 }
 
+// saturate limits revenue to what a market of the given size absorbs
+// Revenue well below the market size is almost unchanged, while each additional unit of
+// productivity earns less the closer revenue gets to the market size, which it never exceeds.
+// A market size of 0 is unlimited
+func saturate(revenue, marketSize float64) float64 {
+	if marketSize <= 0 {
+		return revenue
+	}
+	return marketSize * (1.0 - math.Exp(-revenue/marketSize))
+}
+
+// AttributeRevenue splits revenue between humans and AI agents by experience level
+// Each worker is credited in proportion to their effective productivity, including any
+// orchestration effectiveness bonus or penalty; when the market is saturated every unit of
+// productivity shares the diminished revenue equally
+func (em *EconomicModel) AttributeRevenue(revenue float64, humans []*types.HumanWorker, agents []*types.AIAgent, regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) types.RevenueAttribution {
+	attribution := types.RevenueAttribution{
+		HumanByExperience: make(map[types.ExperienceLevel]float64),
+		AIByExperience:    make(map[types.ExperienceLevel]float64),
+	}
+	
+	table := effectiveness.ProductivityTable()
+	totalProductivity := 0.0
+	orchestratorLevels := make(map[string]types.ExperienceLevel, len(humans))
+	for _, human := range humans {
+		productivity := human.GetEffectiveProductivity(regions)
+		attribution.HumanByExperience[human.ExperienceLevel] += productivity
+		totalProductivity += productivity
+		orchestratorLevels[human.ID] = human.ExperienceLevel
+	}
+	for _, agent := range agents {
+		productivity := agent.GetProductivity()
+		if level, exists := orchestratorLevels[agent.OrchestratorID]; exists {
+			productivity = table[level][agent.ExperienceLevel] * agent.ProductivityFactor()
+		}
+		attribution.AIByExperience[agent.ExperienceLevel] += productivity
+		totalProductivity += productivity
+	}
+	
+	if totalProductivity == 0.0 {
+		return attribution
+	}
+	
+	// Convert productivity sums into revenue shares
+	for level, productivity := range attribution.HumanByExperience {
+		attribution.HumanByExperience[level] = revenue * productivity / totalProductivity
+	}
+	for level, productivity := range attribution.AIByExperience {
+		attribution.AIByExperience[level] = revenue * productivity / totalProductivity
+	}
+	
+	return attribution
+}
+
 // GetCostPerProductivityUnit calculates cost-effectiveness metric for workers
 // Returns the cost per unit of productivity
 func (em *EconomicModel) GetCostPerProductivityUnit(cost float64, productivity float64) float64 {
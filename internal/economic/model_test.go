@@ -3,11 +3,12 @@ package economic
 import (
 	"math"
 	"testing"
+	"workforce-ai-transition-simulator/internal/random"
 	"workforce-ai-transition-simulator/internal/types"
 )
 
 func TestGetCostPerProductivityUnit(t *testing.T) {
-	em := NewEconomicModel(1000000.0, types.FlatRevenue)
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
 
 	tests := []struct {
 		name         string
@@ -60,3 +61,185 @@ func TestGetCostPerProductivityUnit(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateCostBreakdown(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+
+	humans := []*types.HumanWorker{
+		types.NewHumanWorker("h1", types.Senior, types.HighCostUS, true),
+		types.NewHumanWorker("h2", types.Senior, types.LowCostNonUS, false),
+	}
+	agents := []*types.AIAgent{
+		types.NewAIAgent("a1", "h1", 0),
+		types.NewAIAgent("a2", "h1", 0),
+	}
+
+	breakdown := em.CalculateCostBreakdown(humans, agents)
+
+	if breakdown.HumanPayroll[types.Senior] != 280000.0 {
+		t.Errorf("Expected senior payroll 280000, got %f", breakdown.HumanPayroll[types.Senior])
+	}
+	if breakdown.AICost[types.UniversityHire] != 40000.0 {
+		t.Errorf("Expected university AI cost 40000, got %f", breakdown.AICost[types.UniversityHire])
+	}
+	if total := breakdown.TotalHumanPayroll() + breakdown.TotalAICost(); total != em.CalculateWorkforceCost(humans, agents) {
+		t.Errorf("Expected breakdown to sum to workforce cost, got %f", total)
+	}
+}
+
+func TestAIPricingDiscountsAndPlatformFee(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+	em.SetAIPricing(types.AIPricingConfig{PlatformFee: 30000, Discounts: []types.VolumeDiscount{{MinAgents: 2, Discount: 0.1}, {MinAgents: 3, Discount: 0.25}}})
+
+	humans := []*types.HumanWorker{types.NewHumanWorker("h1", types.Senior, types.HighCostUS, true)}
+	one := []*types.AIAgent{types.NewAIAgent("a1", "h1", 0)}
+	three := []*types.AIAgent{types.NewAIAgent("a1", "h1", 0), types.NewAIAgent("a2", "h1", 0), types.NewAIAgent("a3", "h1", 0)}
+	payroll := em.CalculateWorkforceCost(humans, nil)
+
+	// One agent costs 20000, below the platform fee, so the fleet pays the fee
+	if cost := em.CalculateWorkforceCost(humans, one) - payroll; cost != 30000.0 {
+		t.Errorf("Expected a single agent to cost the platform fee of 30000, got %f", cost)
+	}
+	// Three agents cost 60000, less the 25% discount of the largest tier they qualify for
+	if cost := em.CalculateWorkforceCost(humans, three) - payroll; cost != 45000.0 {
+		t.Errorf("Expected three discounted agents to cost 45000, got %f", cost)
+	}
+	for _, agents := range [][]*types.AIAgent{one, three} {
+		breakdown := em.CalculateCostBreakdown(humans, agents)
+		if total := breakdown.TotalHumanPayroll() + breakdown.TotalAICost(); total != em.CalculateWorkforceCost(humans, agents) {
+			t.Errorf("Expected breakdown of %d agents to sum to workforce cost, got %f", len(agents), total)
+		}
+	}
+	if fee := em.CalculateCostBreakdown(humans, nil).AIPlatformFee; fee != 0 {
+		t.Errorf("Expected no platform fee without agents, got %f", fee)
+	}
+}
+
+func TestCalculateWorkforceCostWithOverhead(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{Employer: 1.25, AIInfrastructure: 1.5}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+
+	employee := types.NewHumanWorker("h1", types.Senior, types.HighCostUS, true)
+	contractor := types.NewHumanWorker("h2", types.Senior, types.HighCostUS, false)
+	contractor.ApplyEmployment(types.RegionTable(nil).Region(types.HighCostUS), types.Contractor, types.ContractConfig{})
+	agents := []*types.AIAgent{types.NewAIAgent("a1", "h1", 0)}
+
+	// The employer overhead loads the employee's salary but not the contractor's all-in rate
+	expected := employee.BaseCost*1.25 + contractor.BaseCost + types.AIAgentCosts[types.UniversityHire]*1.5
+	if got := em.CalculateWorkforceCost([]*types.HumanWorker{employee, contractor}, agents); math.Abs(got-expected) > 1e-6 {
+		t.Errorf("Expected loaded workforce cost %f, got %f", expected, got)
+	}
+
+	breakdown := em.CalculateCostBreakdown([]*types.HumanWorker{employee, contractor}, agents)
+	if math.Abs(breakdown.TotalHumanPayroll()+breakdown.TotalAICost()-expected) > 1e-6 {
+		t.Errorf("Expected breakdown to sum to loaded workforce cost %f, got %+v", expected, breakdown)
+	}
+}
+
+func TestAttributeRevenue(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+
+	// Human senior (3.5) and two university AI agents (0.8 each): 5.1 total productivity
+	humans := []*types.HumanWorker{types.NewHumanWorker("h1", types.Senior, types.HighCostUS, true)}
+	agents := []*types.AIAgent{types.NewAIAgent("a1", "h1", 0), types.NewAIAgent("a2", "h1", 0)}
+
+	attribution := em.AttributeRevenue(510000.0, humans, agents, types.DefaultRegions(types.CostCategoryDistribution{}, 0.1), types.OrchestrationEffectiveness{})
+
+	if math.Abs(attribution.HumanByExperience[types.Senior]-350000.0) > 1e-6 {
+		t.Errorf("Expected senior human revenue 350000, got %f", attribution.HumanByExperience[types.Senior])
+	}
+	if math.Abs(attribution.AIByExperience[types.UniversityHire]-160000.0) > 1e-6 {
+		t.Errorf("Expected university AI revenue 160000, got %f", attribution.AIByExperience[types.UniversityHire])
+	}
+	if math.Abs(attribution.AIShare()-160000.0/510000.0) > 1e-9 {
+		t.Errorf("Expected AI share %f, got %f", 160000.0/510000.0, attribution.AIShare())
+	}
+}
+
+func TestAttributeRevenueEmptyWorkforce(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+
+	attribution := em.AttributeRevenue(0.0, nil, nil, nil, types.OrchestrationEffectiveness{})
+	if attribution.AIShare() != 0 || attribution.TotalHuman() != 0 {
+		t.Errorf("Expected zero attribution for an empty workforce, got %+v", attribution)
+	}
+}
+
+func TestCalculateRevenueSaturatesAtMarketSize(t *testing.T) {
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 5000000.0, random.NewStreams(1))
+
+	// Small revenue is nearly linear, large revenue approaches but never exceeds the market size
+	small := em.CalculateRevenue(1, 0)
+	if small >= 100000.0 || small < 99000.0 {
+		t.Errorf("Expected revenue just under 100000 well below the market size, got %f", small)
+	}
+	previous, previousGain := small, small
+	for _, productivity := range []float64{10, 50, 100, 500} {
+		revenue := em.CalculateRevenue(productivity, 0)
+		if revenue > 5000000.0 || revenue <= previous {
+			t.Fatalf("Productivity %.0f: expected revenue to grow towards 5000000, got %f after %f", productivity, revenue, previous)
+		}
+		previous = revenue
+	}
+	if gain := em.CalculateRevenue(101, 0) - em.CalculateRevenue(100, 0); gain >= previousGain {
+		t.Errorf("Expected diminishing revenue per unit of productivity, got %f for the 101st unit versus %f for the first", gain, previousGain)
+	}
+}
+
+func TestCalculateRevenueWithVolatility(t *testing.T) {
+	deterministic := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0, 0, random.NewStreams(1))
+	if got := deterministic.CalculateRevenue(10, 3); got != 1000000.0 {
+		t.Errorf("Expected deterministic revenue 1000000, got %f", got)
+	}
+
+	em := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0.2, 0, random.NewStreams(7))
+	same := NewEconomicModel(1000000.0, types.FlatRevenue, types.OverheadConfig{}, types.FinancingConfig{}, 0.2, 0, random.NewStreams(7))
+	const draws = 20000
+	sum := 0.0
+	for i := 0; i < draws; i++ {
+		revenue := em.CalculateRevenue(10, 0)
+		if other := same.CalculateRevenue(10, 0); other != revenue {
+			t.Fatalf("Draw %d: expected the same seed to give the same revenue, got %f and %f", i, revenue, other)
+		}
+		sum += revenue
+	}
+
+	// The lognormal noise has a mean of 1, so the average stays on the deterministic path
+	if mean := sum / draws; math.Abs(mean-1000000.0) > 10000.0 {
+		t.Errorf("Expected mean revenue close to 1000000, got %f", mean)
+	}
+}
+
+func TestFinancingExtendsBudgetAndAmortizesDebt(t *testing.T) {
+	financing := types.FinancingConfig{CreditLine: 120000, InterestRate: 0.12, RepaymentSteps: 10}
+	em := NewEconomicModel(1200000.0, types.FlatRevenue, types.OverheadConfig{}, financing, 0, 0, random.NewStreams(1))
+
+	// The unused credit line is added to the budget
+	if got := em.GetAvailableBudget(nil, nil); got != 1320000.0 {
+		t.Errorf("Expected available budget 1320000 with no debt, got %f", got)
+	}
+
+	// Spending 1440000 a year exceeds the 1200000 budget by 20000 a step, which is borrowed
+	state, interest := em.SettleFinancing(1440000.0, 2400000.0)
+	if state.Borrowed != 20000.0 || state.Debt != 20000.0 || interest != 0 {
+		t.Errorf("Expected to borrow 20000 with no interest, got %+v and interest %f", state, interest)
+	}
+	if math.Abs(state.Leverage-20000.0/2400000.0) > 1e-12 {
+		t.Errorf("Expected leverage of debt to annual revenue, got %f", state.Leverage)
+	}
+
+	// Spending that leaves room in the budget for debt service repays a tenth of the debt and
+	// pays interest on it
+	state, interest = em.SettleFinancing(1100000.0, 2400000.0)
+	if state.Borrowed != 0 || state.Repaid != 2000.0 || state.Debt != 18000.0 {
+		t.Errorf("Expected to repay 2000 leaving 18000 of debt, got %+v", state)
+	}
+	if expected := 20000.0 * PerStepRate(0.12); math.Abs(interest-expected) > 1e-9 {
+		t.Errorf("Expected interest %f, got %f", expected, interest)
+	}
+
+	// Borrowing is capped at the credit line
+	state, _ = em.SettleFinancing(1e9, 2400000.0)
+	if state.Debt != 120000.0 {
+		t.Errorf("Expected debt capped at the 120000 credit line, got %f", state.Debt)
+	}
+}
@@ -0,0 +1,59 @@
+// Package enumtext converts the integer enum types of the simulator to and from text, so they
+// read as their names in JSON and YAML while numbers written by older versions still decode
+package enumtext
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Enum is an integer enum type whose values 0 to count-1 are named by String
+type Enum interface {
+	~int
+	String() string
+}
+
+// Text returns the text form of an enum value: its name, or its number when value is not one
+// of the count named values
+func Text[E Enum](value E, count int) []byte {
+	if value >= 0 && int(value) < count {
+		return []byte(value.String())
+	}
+	return []byte(strconv.Itoa(int(value)))
+}
+
+// Parse converts an enum name, matched case-insensitively, or a number to an enum value
+// Numbers are not checked against count, since some enums such as cost categories index
+// tables that can be larger than the named values
+func Parse[E Enum](text string, count int) (E, error) {
+	text = strings.TrimSpace(text)
+	names := make([]string, count)
+	for v := 0; v < count; v++ {
+		names[v] = E(v).String()
+		if strings.EqualFold(text, names[v]) {
+			return E(v), nil
+		}
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		return E(n), nil
+	}
+	var zero E
+	return zero, fmt.Errorf("unknown %T %q (expected one of %s or a number)", zero, text, strings.Join(names, ", "))
+}
+
+// UnmarshalJSON decodes an enum value from a JSON string holding its name or number, or from a
+// JSON number
+func UnmarshalJSON[E Enum](data []byte, count int) (E, error) {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return Parse[E](text, count)
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		var zero E
+		return zero, fmt.Errorf("%T must be a name or an integer, got %s", zero, data)
+	}
+	return E(n), nil
+}
@@ -0,0 +1,55 @@
+package enumtext
+
+import "testing"
+
+// color is a test enum with three named values
+type color int
+
+func (c color) String() string {
+	switch c {
+	case 0:
+		return "Red"
+	case 1:
+		return "Green"
+	case 2:
+		return "Blue"
+	default:
+		return "Unknown"
+	}
+}
+
+func TestTextAndParseRoundTrip(t *testing.T) {
+	for v := color(0); v < 3; v++ {
+		parsed, err := Parse[color](string(Text(v, 3)), 3)
+		if err != nil || parsed != v {
+			t.Errorf("Expected %s to round-trip, got %v (%v)", v, parsed, err)
+		}
+	}
+
+	if text := string(Text(color(7), 3)); text != "7" {
+		t.Errorf("Expected an unnamed value as its number, got %q", text)
+	}
+	if parsed, err := Parse[color](" green ", 3); err != nil || parsed != 1 {
+		t.Errorf("Expected names to match case-insensitively, got %v (%v)", parsed, err)
+	}
+	if parsed, err := Parse[color]("7", 3); err != nil || parsed != 7 {
+		t.Errorf("Expected a number to parse as is, got %v (%v)", parsed, err)
+	}
+	if _, err := Parse[color]("Purple", 3); err == nil {
+		t.Error("Expected an error for an unknown name")
+	}
+}
+
+func TestUnmarshalJSONAcceptsNamesAndNumbers(t *testing.T) {
+	for data, expected := range map[string]color{`"Blue"`: 2, `1`: 1, `"0"`: 0} {
+		value, err := UnmarshalJSON[color]([]byte(data), 3)
+		if err != nil || value != expected {
+			t.Errorf("Expected %s to decode as %s, got %v (%v)", data, expected, value, err)
+		}
+	}
+	for _, data := range []string{`true`, `1.5`, `"Purple"`} {
+		if _, err := UnmarshalJSON[color]([]byte(data), 3); err == nil {
+			t.Errorf("Expected an error decoding %s", data)
+		}
+	}
+}
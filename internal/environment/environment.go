@@ -0,0 +1,142 @@
+// Package environment exposes the simulation as a reinforcement learning environment: a policy
+// observes the organization, decides how many AI agents to hire and release in place of the
+// optimizer, and is rewarded for the outcome of every time step by an objective
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// DefaultMaxTimeSteps is the episode length when Options.MaxTimeSteps is not set
+const DefaultMaxTimeSteps = 120
+
+// Action is the workforce decision taken at a time step
+type Action = controller.WorkforceAction
+
+// Observation is what a policy sees of the organization after a time step
+type Observation struct {
+	TimeStep              int
+	Humans                int
+	AIAgents              int
+	AIAgentsByExperience  [types.NumExperienceLevels]int
+	OrchestrationCapacity int // AI agents that could still be hired within orchestration and oversight limits
+	AvailableBudget       float64
+	TotalCost             float64
+	RevenueOutput         float64
+	TotalProductivity     float64
+	CashBalance           float64 // 0 unless Cash is enabled
+}
+
+// Vector returns the observation as a flat slice of features in field order, with the AI agents
+// by experience level in level order, for policies that take numeric input
+func (o Observation) Vector() []float64 {
+	vector := []float64{float64(o.TimeStep), float64(o.Humans), float64(o.AIAgents)}
+	for _, count := range o.AIAgentsByExperience {
+		vector = append(vector, float64(count))
+	}
+	return append(vector, float64(o.OrchestrationCapacity), o.AvailableBudget, o.TotalCost, o.RevenueOutput, o.TotalProductivity, o.CashBalance)
+}
+
+// Options configures an Environment
+type Options struct {
+	Seed         int64               // seed of the first episode; episode n uses Seed+n
+	MaxTimeSteps int                 // time steps after which an episode ends; 0 uses DefaultMaxTimeSteps
+	Objective    objective.Objective // rewards each step; nil uses objective.Profit
+}
+
+// Environment runs episodes of a simulation driven by a policy's actions
+// An episode ends when the organization becomes insolvent or reaches a terminal state, or after
+// MaxTimeSteps. Reaching equilibrium does not end it, since the policy's own decisions are what
+// keeps the workforce where it is
+type Environment struct {
+	config     types.SimulationConfig
+	options    Options
+	episodes   int
+	controller *controller.SimulationController
+	action     Action
+	done       bool
+}
+
+// New creates an environment for the given configuration
+// Returns an error if the configuration is invalid
+func New(config types.SimulationConfig, options Options) (*Environment, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if options.MaxTimeSteps <= 0 {
+		options.MaxTimeSteps = DefaultMaxTimeSteps
+	}
+	if options.Objective == nil {
+		options.Objective = objective.Profit{}
+	}
+	return &Environment{config: config, options: options}, nil
+}
+
+// Reset starts a new episode with the next seed and returns the initial observation
+func (e *Environment) Reset() (Observation, error) {
+	policy := func(timeStep int) Action { return e.action }
+	sc := controller.NewSimulationController(e.config, e.options.Seed+int64(e.episodes), controller.WithWorkforcePolicy(policy))
+	if err := sc.Initialize(); err != nil {
+		return Observation{}, fmt.Errorf("initialization failed: %w", err)
+	}
+	e.controller = sc
+	e.episodes++
+	e.done = false
+	return e.observe(), nil
+}
+
+// Step applies an action for one time step and returns the resulting observation, the step's
+// reward and whether the episode has ended
+// Returns an error if no episode is under way, the episode has ended, or the action is negative
+func (e *Environment) Step(action Action) (Observation, float64, bool, error) {
+	if e.controller == nil {
+		return Observation{}, 0, false, errors.New("environment must be reset before stepping")
+	}
+	if e.done {
+		return Observation{}, 0, true, errors.New("episode has ended; reset the environment to start a new one")
+	}
+	if action.Hire < 0 || action.Release < 0 {
+		return Observation{}, 0, false, fmt.Errorf("action must not be negative, got %+v", action)
+	}
+
+	timeSeries := e.controller.GetTimeSeries()
+	previous := timeSeries[len(timeSeries)-1]
+	e.action = action
+	current := e.controller.Step()
+	e.action = Action{}
+
+	e.done = e.controller.IsInsolvent() || e.controller.IsTerminated() ||
+		e.controller.GetCurrentTimeStep() >= e.options.MaxTimeSteps
+	return e.observe(), e.options.Objective.Reward(previous, current), e.done, nil
+}
+
+// Controller returns the controller of the current episode, for inspecting its time series and
+// events; nil before the first Reset
+func (e *Environment) Controller() *controller.SimulationController {
+	return e.controller
+}
+
+// observe returns the observation of the current episode's latest state
+func (e *Environment) observe() Observation {
+	timeSeries := e.controller.GetTimeSeries()
+	state := timeSeries[len(timeSeries)-1]
+	observation := Observation{
+		TimeStep:              state.TimeStep,
+		Humans:                state.Workforce.Humans.Total,
+		AIAgents:              state.Workforce.AIAgents.Total,
+		OrchestrationCapacity: state.AvailableOrchestrationCapacity,
+		AvailableBudget:       state.AvailableBudget,
+		TotalCost:             state.TotalCost,
+		RevenueOutput:         state.RevenueOutput,
+		TotalProductivity:     state.TotalProductivity,
+		CashBalance:           state.CashBalance,
+	}
+	for level := range observation.AIAgentsByExperience {
+		observation.AIAgentsByExperience[level] = state.Workforce.AIAgents.ByExperience[types.ExperienceLevel(level)]
+	}
+	return observation
+}
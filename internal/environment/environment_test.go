@@ -0,0 +1,95 @@
+package environment
+
+import (
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/objective"
+	"workforce-ai-transition-simulator/internal/testutil"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestEnvironmentAppliesActions(t *testing.T) {
+	env, err := New(testutil.CanonicalConfig(), Options{Seed: 7, MaxTimeSteps: 5})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, _, _, err := env.Step(Action{}); err == nil {
+		t.Error("Expected stepping before Reset to fail")
+	}
+
+	initial, err := env.Reset()
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if initial.TimeStep != 0 || initial.Humans != 12 || initial.AIAgents != 0 || len(initial.Vector()) != 13 {
+		t.Fatalf("Expected the initial 12 humans and no AI agents, got %+v", initial)
+	}
+
+	observation, reward, done, err := env.Step(Action{Hire: 3})
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if observation.TimeStep != 1 || observation.AIAgents != 3 || observation.AIAgentsByExperience[types.UniversityHire] != 3 || done {
+		t.Errorf("Expected exactly the 3 hired AI agents after step 1, got %+v (done %t)", observation, done)
+	}
+	if state := env.Controller().GetTimeSeries()[1]; reward != state.NetCashFlow() {
+		t.Errorf("Expected the default reward to be the step's net cash flow %.0f, got %.0f", state.NetCashFlow(), reward)
+	}
+
+	observation, _, _, err = env.Step(Action{Hire: 1000, Release: 2})
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if observation.AIAgents <= 1 || observation.AIAgents > 3-2+initial.OrchestrationCapacity {
+		t.Errorf("Expected hires to be limited by capacity and budget after releasing 2, got %d AI agents", observation.AIAgents)
+	}
+	if _, _, _, err := env.Step(Action{Hire: -1}); err == nil {
+		t.Error("Expected a negative action to fail")
+	}
+
+	for !done {
+		if _, _, done, err = env.Step(Action{}); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+	if env.Controller().GetCurrentTimeStep() != 5 {
+		t.Errorf("Expected the episode to end after 5 steps, got %d", env.Controller().GetCurrentTimeStep())
+	}
+	if _, _, _, err := env.Step(Action{}); err == nil {
+		t.Error("Expected stepping an ended episode to fail")
+	}
+}
+
+func TestEnvironmentEpisodesAreReproducible(t *testing.T) {
+	run := func() [][]float64 {
+		env, err := New(testutil.CanonicalConfig(), Options{Seed: 7, MaxTimeSteps: 10, Objective: objective.CompositionTarget{TargetAIShare: 0.5}})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		var trace [][]float64
+		for episode := 0; episode < 2; episode++ {
+			if _, err := env.Reset(); err != nil {
+				t.Fatalf("Reset failed: %v", err)
+			}
+			for done := false; !done; {
+				var observation Observation
+				var reward float64
+				var err error
+				observation, reward, done, err = env.Step(Action{Hire: 2})
+				if err != nil {
+					t.Fatalf("Step failed: %v", err)
+				}
+				if reward > 0 || reward < -1 {
+					t.Fatalf("Expected a composition reward between -1 and 0, got %f", reward)
+				}
+				trace = append(trace, append(observation.Vector(), reward))
+			}
+		}
+		return trace
+	}
+
+	first, second := run(), run()
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected environments with the same seed to replay the same episodes")
+	}
+}
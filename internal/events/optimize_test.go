@@ -0,0 +1,175 @@
+package events_test
+
+import (
+	"fmt"
+	"testing"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/testfixtures"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// newOptimizer creates an EventProcessor with neutral settings and an assignment strategy
+func newOptimizer(assignment types.AssignmentStrategy) *events.EventProcessor {
+	return events.NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition, NaturalRate: 10.0, ForcedAcceleration: 1.0},
+		0.0,
+		types.DefaultFailureClasses(types.FailureRecoveryConfig{}).Table(),
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{},
+		types.DefaultRegions(types.CostCategoryDistribution{}, 0.1),
+		types.OverheadConfig{},
+		assignment,
+		random.NewStreams(12345),
+	)
+}
+
+// mixedHumans adds one human of each level to a builder, alternating regions
+func mixedHumans(builder *testfixtures.WorkforceBuilder) *testfixtures.WorkforceBuilder {
+	for i, level := range []types.ExperienceLevel{types.UniversityHire, types.MidLevel, types.Senior, types.Executive} {
+		builder.WithHumans(1, level, types.CostCategory(i%2))
+	}
+	return builder
+}
+
+func TestOptimizeWorkforceReleasesLeastCostEffectiveFirst(t *testing.T) {
+	// One agent per level; cost per productivity unit is highest for University_Hire,
+	// then Mid_Level, Senior and Executive
+	wm := testfixtures.NewWorkforceBuilder().
+		WithHumans(1, types.Senior, types.HighCostUS).
+		WithAgents(1, types.Executive).
+		WithAgents(1, types.Senior).
+		WithAgents(1, types.MidLevel).
+		WithAgents(1, types.UniversityHire).
+		MustManager(t)
+	owner := wm.GetAllHumans()[0]
+
+	// A 30000 deficit requires releasing the University_Hire (20000) and the Mid_Level (40000) agents
+	change := newOptimizer(types.FillFirst).OptimizeWorkforce(wm.GetAllHumans(), wm.GetAllAIAgents(), -30000.0, owner.GetOrchestrationCapacity())
+
+	expected := []string{"agent-4", "agent-3"}
+	if len(change.ReleaseAIAgents) != len(expected) {
+		t.Fatalf("Expected %d agents released, got %v", len(expected), change.ReleaseAIAgents)
+	}
+	for i, id := range expected {
+		if change.ReleaseAIAgents[i] != id {
+			t.Errorf("Expected release %d to be %s, got %s", i, id, change.ReleaseAIAgents[i])
+		}
+	}
+
+	if change.HireAIAgents != 0 {
+		t.Errorf("Expected no hires while over budget, got %d", change.HireAIAgents)
+	}
+}
+
+func TestOptimizeWorkforceReleaseWithoutCapacity(t *testing.T) {
+	wm := testfixtures.NewWorkforceBuilder().
+		WithHumans(2, types.MidLevel, types.HighCostUS).
+		WithAgents(2*types.OrchestrationLimit, types.MidLevel).
+		MustManager(t)
+
+	// Over budget with no orchestration capacity left must still release agents
+	change := newOptimizer(types.FillFirst).OptimizeWorkforce(wm.GetAllHumans(), wm.GetAllAIAgents(), -1.0, 0)
+	if len(change.ReleaseAIAgents) != 1 {
+		t.Errorf("Expected 1 agent released, got %d", len(change.ReleaseAIAgents))
+	}
+}
+
+func TestOptimizeWorkforcePricesHiresAtFleetCost(t *testing.T) {
+	wm := testfixtures.NewWorkforceBuilder().
+		WithHumans(1, types.UniversityHire, types.HighCostUS).
+		WithAgents(1, types.UniversityHire).
+		MustManager(t)
+	humans, agents := wm.GetAllHumans(), wm.GetAllAIAgents()
+	ep := newOptimizer(types.FillFirst)
+
+	// 10000 does not pay for a 20000 agent at its own price
+	if change := ep.OptimizeWorkforce(humans, agents, 10000, humans[0].GetOrchestrationCapacity()); change.HireAIAgents != 0 {
+		t.Errorf("Expected no hires without AI pricing, got %d", change.HireAIAgents)
+	}
+
+	// The fleet already pays a platform fee of 30000 for one agent, so a second only adds 10000
+	ep.SetAIPricing(types.AIPricingConfig{PlatformFee: 30000})
+	if change := ep.OptimizeWorkforce(humans, agents, 10000, humans[0].GetOrchestrationCapacity()); change.HireAIAgents != 1 {
+		t.Errorf("Expected one hire filling the platform fee, got %d", change.HireAIAgents)
+	}
+
+	// Three 20000 agents held at a fee of 70000 save nothing until the last is released
+	fleet := testfixtures.NewWorkforceBuilder().
+		WithHumans(1, types.UniversityHire, types.HighCostUS).
+		WithAgents(3, types.UniversityHire).
+		MustManager(t).GetAllAIAgents()
+	if change := newOptimizer(types.FillFirst).OptimizeWorkforce(humans, fleet, -15000, 0); len(change.ReleaseAIAgents) != 1 {
+		t.Errorf("Expected one release to cover the deficit without AI pricing, got %v", change.ReleaseAIAgents)
+	}
+	ep.SetAIPricing(types.AIPricingConfig{PlatformFee: 70000})
+	if change := ep.OptimizeWorkforce(humans, fleet, -15000, 0); len(change.ReleaseAIAgents) != 3 {
+		t.Errorf("Expected the whole fleet released to cover the deficit under the platform fee, got %v", change.ReleaseAIAgents)
+	}
+}
+
+func TestOptimizeWorkforcePicksVendorTiers(t *testing.T) {
+	wm := mixedHumans(testfixtures.NewWorkforceBuilder()).MustManager(t)
+	humans := wm.GetAllHumans()[:1]
+	tiers := types.VendorTable{
+		{Name: "Frontier", Share: 25, CostMultiplier: 2, ProductivityMultiplier: 1.5, FailureMultiplier: 0.5},
+		{Name: "Open_Source", Share: 75, CostMultiplier: 0.5, ProductivityMultiplier: 0.8, LearningMultiplier: 2},
+	}
+	ep := newOptimizer(types.FillFirst)
+
+	// The mix hires a quarter of the agents from the frontier tier
+	ep.SetVendors(types.VendorConfig{Tiers: tiers})
+	change := ep.OptimizeWorkforce(humans, nil, 1000000, humans[0].GetOrchestrationCapacity())
+	counts := make([]int, len(tiers))
+	for _, vendor := range change.Vendors {
+		counts[vendor]++
+	}
+	if change.HireAIAgents != 6 || len(change.Vendors) != 6 || counts[0] != 2 || counts[1] != 4 {
+		t.Errorf("Expected 6 hires split 2 and 4 across the tiers, got %d hires from %v", change.HireAIAgents, change.Vendors)
+	}
+
+	// 45000 pays for four open-source agents at 10000 each, but only one hire of the mix, whose
+	// second agent costs 40000
+	change = ep.OptimizeWorkforce(humans, nil, 45000, humans[0].GetOrchestrationCapacity())
+	if change.HireAIAgents != 1 || len(change.Vendors) != 1 {
+		t.Errorf("Expected the budget to pay for one hire of the mix, got %d from %v", change.HireAIAgents, change.Vendors)
+	}
+	ep.SetVendors(types.VendorConfig{Tiers: tiers, Policy: types.MostCostEffective})
+	change = ep.OptimizeWorkforce(humans, nil, 45000, humans[0].GetOrchestrationCapacity())
+	if change.HireAIAgents != 4 || len(change.Vendors) != 4 || change.Vendors[0] != 1 || change.Vendors[3] != 1 {
+		t.Errorf("Expected four open-source hires, got %d from %v", change.HireAIAgents, change.Vendors)
+	}
+}
+
+func TestOptimizeWorkforceRoundRobinContinuesAfterLastOrchestrator(t *testing.T) {
+	// The last agent belongs to human-2, so the turn passes to human-3
+	wm := mixedHumans(testfixtures.NewWorkforceBuilder()).
+		WithOrchestratedAgents(0, 2, types.UniversityHire).
+		WithOrchestratedAgents(2, 2, types.UniversityHire).
+		WithOrchestratedAgents(3, 2, types.UniversityHire).
+		WithOrchestratedAgents(1, 2, types.UniversityHire).
+		MustManager(t)
+
+	// Hires are capped at the 4 agents a single orchestrator could still take
+	change := newOptimizer(types.RoundRobin).OptimizeWorkforce(wm.GetAllHumans(), wm.GetAllAIAgents(), 5000000.0, 8)
+	want := []string{"human-3", "human-4", "human-1", "human-2"}
+	if fmt.Sprint(change.Assignments) != fmt.Sprint(want) || change.HireAIAgents != len(want) {
+		t.Errorf("Expected assignments %v, got %v (%d hires)", want, change.Assignments, change.HireAIAgents)
+	}
+}
+
+func TestOptimizeWorkforceLeastLoadedFillsEmptiestFirst(t *testing.T) {
+	wm := mixedHumans(testfixtures.NewWorkforceBuilder()).
+		WithOrchestratedAgents(0, 5, types.UniversityHire).
+		WithOrchestratedAgents(1, 1, types.UniversityHire).
+		WithOrchestratedAgents(2, 3, types.UniversityHire).
+		WithOrchestratedAgents(3, 5, types.UniversityHire).
+		MustManager(t)
+
+	// Ties go to the earliest human, so human-2 takes three agents before human-3 gets one
+	change := newOptimizer(types.LeastLoaded).OptimizeWorkforce(wm.GetAllHumans(), wm.GetAllAIAgents(), 5000000.0, 4)
+	want := []string{"human-2", "human-2", "human-2", "human-3"}
+	if fmt.Sprint(change.Assignments) != fmt.Sprint(want) {
+		t.Errorf("Expected assignments %v, got %v", want, change.Assignments)
+	}
+}
@@ -1,7 +1,9 @@
 package events
 
 import (
-	"math/rand"
+	"math"
+	"sort"
+	"workforce-ai-transition-simulator/internal/random"
 	"workforce-ai-transition-simulator/internal/types"
 )
 
@@ -9,28 +11,141 @@ import (
 type EventProcessor struct {
 	attritionConfig         types.AttritionConfig
 	catastrophicFailureRate float64
+	failureClasses          [types.NumFailureClasses]types.FailureClassConfig
 	aiLearningSpeed         types.AILearningSpeed
-	timeZoneInefficiency    float64
-	rng                     *rand.Rand
+	sharedLearning          types.SharedLearningConfig
+	regions                 types.RegionTable
+	overhead                types.OverheadConfig
+	assignment              types.AssignmentStrategy // how new agents are spread across orchestrators
+	market                  types.MarketConditions // current market shock factors on costs
+	burnoutMultiplier       float64 // factor applied to the natural attrition rate by workforce burnout
+	severityMultiplier      float64 // factor applied to failure severity by resilience spend
+	training                types.TrainingConfig // targeted training of agents, see SetTraining
+	trainingCapacity        int     // agents trained at a time; -1 for every eligible agent
+	dataExposure            types.DataExposureConfig // data agents learn from under their orchestrators
+	orchestrators           map[string]*types.HumanWorker // humans by ID as of this step, see SetOrchestrators
+	domains                 types.DomainTable // business functions capping the levels of their agents
+	vendors                 types.VendorConfig // vendor tiers agents are hired from and the policy choosing them
+	aiPricing               types.AIPricingConfig // platform fee and volume discounts pricing the agent fleet
+	rules                   *Rules  // scripted per-step rules; nil without a rules script
+	ruleMultiplier          float64 // factor applied to the natural attrition rate by the rules this step
+	
+	// Independent random streams per subsystem so that draws in one subsystem
+	// never shift the random sequence seen by another
+	attritionRNG    random.RNG
+	failureRNG      random.RNG
+	failureClassRNG random.RNG
+	optimizationRNG random.RNG
 }
 
 // NewEventProcessor creates a new EventProcessor instance
+// Attrition, failure and optimization randomness are drawn from separate streams
+// failureClasses sets the likelihood and handling of each class of failure, see
+// types.SimulationConfig.FailureClassTable
 func NewEventProcessor(
 	attritionConfig types.AttritionConfig,
 	catastrophicFailureRate float64,
+	failureClasses [types.NumFailureClasses]types.FailureClassConfig,
 	aiLearningSpeed types.AILearningSpeed,
-	timeZoneInefficiency float64,
-	rng *rand.Rand,
+	sharedLearning types.SharedLearningConfig,
+	regions types.RegionTable,
+	overhead types.OverheadConfig,
+	assignment types.AssignmentStrategy,
+	streams *random.Streams,
 ) *EventProcessor {
 	return &EventProcessor{
 		attritionConfig:         attritionConfig,
 		catastrophicFailureRate: catastrophicFailureRate,
+		failureClasses:          failureClasses,
 		aiLearningSpeed:         aiLearningSpeed,
-		timeZoneInefficiency:    timeZoneInefficiency,
-		rng:                     rng,
+		sharedLearning:          sharedLearning,
+		regions:                 regions,
+		overhead:                overhead,
+		assignment:              assignment,
+		market:                  types.NormalMarket(),
+		burnoutMultiplier:       1.0,
+		severityMultiplier:      1.0,
+		ruleMultiplier:          1.0,
+		attritionRNG:            streams.Get(random.StreamAttrition),
+		failureRNG:              streams.Get(random.StreamFailures),
+		failureClassRNG:         streams.Get(random.StreamFailureClass),
+		optimizationRNG:         streams.Get(random.StreamOptimization),
+	}
+}
+
+
+// SetBurnoutMultiplier sets the factor by which burnout raises the natural attrition rate
+func (ep *EventProcessor) SetBurnoutMultiplier(multiplier float64) {
+	ep.burnoutMultiplier = multiplier
+}
+
+// SetSeverityMultiplier sets the factor applied to the severity of every catastrophic failure
+func (ep *EventProcessor) SetSeverityMultiplier(multiplier float64) {
+	ep.severityMultiplier = multiplier
+}
+
+// SetTraining sets the targeted training of agents and how many it trains at a time, see
+// types.TrainingConfig.Capacity
+func (ep *EventProcessor) SetTraining(training types.TrainingConfig, capacity int) {
+	ep.training = training
+	ep.trainingCapacity = capacity
+}
+
+// SetDomains sets the domains agents work in, whose MaxAgentLevel stops their agents' level-ups
+func (ep *EventProcessor) SetDomains(domains types.DomainTable) {
+	ep.domains = domains
+}
+
+// SetVendors sets the vendor tiers agents are hired from, which scale their learning and the
+// severity of failures, and the policy OptimizeWorkforce picks the tier of each hire by
+func (ep *EventProcessor) SetVendors(vendors types.VendorConfig) {
+	ep.vendors = vendors
+}
+
+// SetAIPricing sets the platform fee and volume discounts that price the agent fleet as a whole,
+// which OptimizeWorkforce prices hires and releases at
+func (ep *EventProcessor) SetAIPricing(pricing types.AIPricingConfig) {
+	ep.aiPricing = pricing
+}
+
+// SetDataExposure sets how an agent's orchestrator scales the data it learns from
+func (ep *EventProcessor) SetDataExposure(exposure types.DataExposureConfig) {
+	ep.dataExposure = exposure
+}
+
+// SetOrchestrators sets the humans orchestrating agents this step, whose level and workload set
+// the data exposure of their agents
+func (ep *EventProcessor) SetOrchestrators(humans []*types.HumanWorker) {
+	ep.orchestrators = make(map[string]*types.HumanWorker, len(humans))
+	for _, human := range humans {
+		ep.orchestrators[human.ID] = human
 	}
 }
 
+// LoadRules compiles a rules script and evaluates it from then on, see Rules
+// An empty script removes the rules. Returns an error if the script is invalid
+func (ep *EventProcessor) LoadRules(script string) error {
+	rules, err := CompileRules(script)
+	if err != nil {
+		return err
+	}
+	ep.rules = rules
+	return nil
+}
+
+// EvaluateRules runs the rules on the state recorded at the end of the previous time step and
+// returns the actions they took for the next one, whose natural attrition rate they scale
+// Without rules no actions are taken
+func (ep *EventProcessor) EvaluateRules(state types.SimulationState) (RuleActions, error) {
+	actions, err := ep.rules.Evaluate(state)
+	ep.ruleMultiplier = actions.AttritionMultiplier
+	return actions, err
+}
+
+// SetMarketConditions sets the market shock factors applied to workforce costs
+func (ep *EventProcessor) SetMarketConditions(market types.MarketConditions) {
+	ep.market = market
+}
 
 // ProcessAttrition handles different types of human worker attrition
 // Returns a list of worker IDs to remove
@@ -44,33 +159,33 @@ func (ep *EventProcessor) ProcessAttrition(humans []*types.HumanWorker, timeStep
 		// Assuming each time step represents a month (12 time steps per year)
 		monthlyRate := ep.attritionConfig.NaturalRate / 12.0 / 100.0
 		
-		// Apply forced acceleration
-		effectiveRate := monthlyRate * ep.attritionConfig.ForcedAcceleration
+		// Apply forced acceleration and burnout
+		effectiveRate := monthlyRate * ep.attritionConfig.ForcedAcceleration * ep.burnoutMultiplier * ep.ruleMultiplier
 		
 		for _, human := range humans {
-			// Never remove business owner
-			if human.IsBusinessOwner {
+			// Never remove business owner unless owner attrition is enabled
+			if human.IsBusinessOwner && !ep.attritionConfig.OwnerAttrition {
 				continue
 			}
 			
-			// Probabilistically determine if this worker leaves
-			if ep.rng.Float64() < effectiveRate {
+			// Probabilistically determine if this worker leaves, at their region's rate
+			if ep.attritionRNG.Float64() < effectiveRate*ep.regions.Region(human.CostCategory).AttritionMultiplier() {
 				workersToRemove = append(workersToRemove, human.ID)
 			}
 		}
 		
 	case types.HiringFreeze:
 		// Hiring freeze: still allow natural attrition but prevent new hires
-		// This is handled by the simulation controller, but we still process natural attrition
+		// Hires are blocked by the simulation controller, but we still process natural attrition
 		monthlyRate := ep.attritionConfig.NaturalRate / 12.0 / 100.0
-		effectiveRate := monthlyRate * ep.attritionConfig.ForcedAcceleration
+		effectiveRate := monthlyRate * ep.attritionConfig.ForcedAcceleration * ep.burnoutMultiplier * ep.ruleMultiplier
 		
 		for _, human := range humans {
-			if human.IsBusinessOwner {
+			if human.IsBusinessOwner && !ep.attritionConfig.OwnerAttrition {
 				continue
 			}
 			
-			if ep.rng.Float64() < effectiveRate {
+			if ep.attritionRNG.Float64() < effectiveRate*ep.regions.Region(human.CostCategory).AttritionMultiplier() {
 				workersToRemove = append(workersToRemove, human.ID)
 			}
 		}
@@ -90,10 +205,15 @@ func (ep *EventProcessor) ProcessAttrition(humans []*types.HumanWorker, timeStep
 		
 		// Randomly select workers to remove
 		// Shuffle and take the first N workers
-		ep.rng.Shuffle(len(eligibleWorkers), func(i, j int) {
+		ep.attritionRNG.Shuffle(len(eligibleWorkers), func(i, j int) {
 			eligibleWorkers[i], eligibleWorkers[j] = eligibleWorkers[j], eligibleWorkers[i]
 		})
 		
+		// Contractors are released before employees
+		sort.SliceStable(eligibleWorkers, func(i, j int) bool {
+			return eligibleWorkers[i].ContractType == types.Contractor && eligibleWorkers[j].ContractType != types.Contractor
+		})
+		
 		// Take up to targetRemovalCount workers
 		removalCount := targetRemovalCount
 		if removalCount > len(eligibleWorkers) {
@@ -110,26 +230,90 @@ func (ep *EventProcessor) ProcessAttrition(humans []*types.HumanWorker, timeStep
 
 
 // ProcessLearning updates experience for all AI agents and triggers level-ups
-func (ep *EventProcessor) ProcessLearning(agents []*types.AIAgent, timeDelta int) {
-	// Data exposure is typically 1.0 (full exposure)
-	dataExposure := 1.0
+// Returns the agents that progressed to a higher experience level
+func (ep *EventProcessor) ProcessLearning(agents []*types.AIAgent, timeDelta int) []*types.AIAgent {
+	leveledUp := make([]*types.AIAgent, 0)
+	
+	// Shared learning from Senior and Executive agents speeds up junior agents
+	juniorSpeedup := 1.0
+	if ep.sharedLearning.Acceleration > 0 {
+		seniorAgents := 0
+		for _, agent := range agents {
+			if agent.ExperienceLevel >= types.Senior {
+				seniorAgents++
+			}
+		}
+		juniorSpeedup = ep.sharedLearning.Speedup(seniorAgents)
+	}
+	
+	// Targeted training lowers the thresholds of the agents it selects, while they remain at a
+	// level it targets
+	trained := ep.selectTrainees(agents)
+	thresholdMultiplier := func(agent *types.AIAgent) float64 {
+		if trained[agent] && ep.training.Targets(agent.ExperienceLevel) {
+			return 1.0 - ep.training.ThresholdReduction
+		}
+		return 1.0
+	}
 	
 	for _, agent := range agents {
-		// Accumulate experience based on time and data exposure
-		agent.AccumulateExperience(timeDelta, dataExposure)
+		// Accumulate experience based on time, the data exposure its orchestrator gives it and the
+		// learning of its vendor's model
+		dataExposure := ep.dataExposure.Exposure(ep.orchestrators[agent.OrchestratorID]) * ep.vendors.Tiers.Tier(agent.Vendor).Learning()
+		if agent.ExperienceLevel < types.Senior {
+			agent.AccumulateExperience(timeDelta, dataExposure*juniorSpeedup)
+		} else {
+			agent.AccumulateExperience(timeDelta, dataExposure)
+		}
 		
-		// Check and trigger level-ups
+		// Check and trigger level-ups up to the ceiling of the agent's domain
 		// An agent might level up multiple times if enough experience is accumulated
-		for agent.CheckLevelUp(ep.aiLearningSpeed) {
+		progressed := false
+		ceiling := ep.domains.MaxAgentLevel(agent.Domain)
+		for agent.ExperienceLevel < ceiling && agent.CheckTrainedLevelUp(ep.aiLearningSpeed, thresholdMultiplier(agent)) {
 			// Level up occurred, continue checking in case of multiple level-ups
+			progressed = true
+		}
+		if progressed {
+			leveledUp = append(leveledUp, agent)
+		}
+	}
+	
+	return leveledUp
+}
+
+// selectTrainees returns the agents targeted training covers this step: as many eligible agents
+// as it trains at a time, those with the least experience left to gain before their next level
+// first, and in workforce order among equals
+func (ep *EventProcessor) selectTrainees(agents []*types.AIAgent) map[*types.AIAgent]bool {
+	if ep.trainingCapacity == 0 {
+		return nil
+	}
+	eligible := make([]*types.AIAgent, 0, len(agents))
+	for _, agent := range agents {
+		if ep.training.Targets(agent.ExperienceLevel) && agent.ExperienceLevel < ep.domains.MaxAgentLevel(agent.Domain) {
+			eligible = append(eligible, agent)
+		}
+	}
+	if ep.trainingCapacity > 0 && ep.trainingCapacity < len(eligible) {
+		remaining := func(agent *types.AIAgent) float64 {
+			return ep.aiLearningSpeed.Threshold(agent.ExperienceLevel) - agent.ExperiencePoints
 		}
+		sort.SliceStable(eligible, func(i, j int) bool { return remaining(eligible[i]) < remaining(eligible[j]) })
+		eligible = eligible[:ep.trainingCapacity]
 	}
+	trained := make(map[*types.AIAgent]bool, len(eligible))
+	for _, agent := range eligible {
+		trained[agent] = true
+	}
+	return trained
 }
 
 
 // CatastrophicFailure represents a critical system failure event
 type CatastrophicFailure struct {
 	TimeStep int
+	Class    types.FailureClass
 	Severity float64 // 0-1, where 1 is most severe
 }
 
@@ -137,11 +321,13 @@ type CatastrophicFailure struct {
 // Returns a failure event or nil if no failure occurs
 func (ep *EventProcessor) GenerateCatastrophicFailure(timeStep int) *CatastrophicFailure {
 	// Check if a failure occurs based on the configured rate
-	if ep.rng.Float64() < ep.catastrophicFailureRate {
-		// Generate a failure with random severity
-		severity := ep.rng.Float64()
+	if ep.failureRNG.Float64() < ep.catastrophicFailureRate {
+		// Generate a failure with random severity; its class is drawn from a stream of its own so
+		// the class weights never shift the timing or severity of failures
+		severity := ep.failureRNG.Float64() * ep.severityMultiplier
 		return &CatastrophicFailure{
 			TimeStep: timeStep,
+			Class:    ep.drawFailureClass(),
 			Severity: severity,
 		}
 	}
@@ -149,16 +335,44 @@ func (ep *EventProcessor) GenerateCatastrophicFailure(timeStep int) *Catastrophi
 	return nil
 }
 
+// drawFailureClass picks the class of a failure in proportion to the class weights
+func (ep *EventProcessor) drawFailureClass() types.FailureClass {
+	total := 0.0
+	for _, class := range ep.failureClasses {
+		total += class.Weight
+	}
+	
+	draw := ep.failureClassRNG.Float64() * total
+	drawn := types.SecurityIncident
+	for class, config := range ep.failureClasses {
+		if config.Weight <= 0 {
+			continue
+		}
+		// Rounding can leave the draw just past the last weight, which then keeps the last weighted class
+		drawn = types.FailureClass(class)
+		if draw < config.Weight {
+			break
+		}
+		draw -= config.Weight
+	}
+	return drawn
+}
+
 
 // FailureOutcome represents the result of evaluating a catastrophic failure
 type FailureOutcome struct {
 	CanHandle            bool
 	ProductivityPenalty  float64 // 0-1, percentage reduction in productivity
 	RequiresHumanIntervention bool
+	SeniorHumans         int     // senior and executive humans available to respond
+	SeniorAgents         int     // senior and executive AI agents available to respond
+	HumanCapability      float64 // capability contributed by the senior humans
+	AgentCapability      float64 // capability contributed by the senior AI agents
+	RequiredCapability   float64 // capability needed at the failure's severity
 }
 
 // EvaluateFailureResponse assesses workforce capability to handle failures
-// Determines if productivity penalties should be applied
+// Determines if productivity penalties should be applied, by the requirements of the failure's class
 func (ep *EventProcessor) EvaluateFailureResponse(
 	failure *CatastrophicFailure,
 	humans []*types.HumanWorker,
@@ -180,46 +394,50 @@ func (ep *EventProcessor) EvaluateFailureResponse(
 		}
 	}
 	
+	// Agents of susceptible vendor tiers make the failure more severe, up to the most severe
+	severity := failure.Severity
+	if susceptibility := ep.vendors.Tiers.Susceptibility(agents); susceptibility != 1 {
+		severity = math.Min(severity*susceptibility, 1)
+	}
+	
 	// Calculate workforce capability score
 	// Senior humans are more valuable for handling failures
+	class := ep.failureClasses[failure.Class]
 	humanCapability := float64(seniorHumanCount) * 1.0
-	agentCapability := float64(seniorAgentCount) * 0.5 // AI agents are less capable
+	agentCapability := float64(seniorAgentCount) * class.AgentCapability
 	
 	totalCapability := humanCapability + agentCapability
+	requiredCapability := severity * class.Capability // Scale severity to required capability
+	outcome := FailureOutcome{
+		SeniorHumans:       seniorHumanCount,
+		SeniorAgents:       seniorAgentCount,
+		HumanCapability:    humanCapability,
+		AgentCapability:    agentCapability,
+		RequiredCapability: requiredCapability,
+	}
 	
 	// Determine if workforce can handle the failure
-	// Require at least one senior human for any failure
-	if seniorHumanCount == 0 {
-		// No senior humans - cannot handle failure
-		return FailureOutcome{
-			CanHandle:                 false,
-			ProductivityPenalty:       failure.Severity * 0.5, // 50% of severity as penalty
-			RequiresHumanIntervention: true,
-		}
+	// The failure's class sets how many senior humans it takes
+	if seniorHumanCount < class.MinSeniorHumans {
+		// Too few senior humans - cannot handle failure
+		outcome.ProductivityPenalty = severity * class.UnhandledPenalty
+		outcome.RequiresHumanIntervention = true
+		return outcome
 	}
 	
 	// Check if capability is sufficient for the failure severity
-	requiredCapability := failure.Severity * 3.0 // Scale severity to required capability
-	
 	if totalCapability >= requiredCapability {
 		// Workforce can handle the failure
-		return FailureOutcome{
-			CanHandle:                 true,
-			ProductivityPenalty:       0.0,
-			RequiresHumanIntervention: false,
-		}
+		outcome.CanHandle = true
+		return outcome
 	}
 	
 	// Workforce cannot fully handle the failure
 	// Apply productivity penalty proportional to the capability gap
 	capabilityGap := (requiredCapability - totalCapability) / requiredCapability
-	penalty := failure.Severity * capabilityGap * 0.3 // Up to 30% penalty
-	
-	return FailureOutcome{
-		CanHandle:                 false,
-		ProductivityPenalty:       penalty,
-		RequiresHumanIntervention: true,
-	}
+	outcome.ProductivityPenalty = severity * capabilityGap * class.GapPenalty
+	outcome.RequiresHumanIntervention = true
+	return outcome
 }
 
 
@@ -228,6 +446,8 @@ type WorkforceChange struct {
 	HireAIAgents     int      // Number of AI agents to hire
 	ReleaseAIAgents  []string // IDs of AI agents to release
 	OrchestratorID   string   // ID of human to assign new agents to
+	Assignments      []string // orchestrator ID of each agent to hire under a balancing strategy, overriding OrchestratorID
+	Vendors          []int    // vendor tier of each agent to hire, in hiring order; nil without vendor tiers
 }
 
 // OptimizeWorkforce evaluates hiring/release opportunities
@@ -243,6 +463,13 @@ func (ep *EventProcessor) OptimizeWorkforce(
 		ReleaseAIAgents: make([]string, 0),
 	}
 	
+	// If we're over budget (shouldn't normally occur) release agents until
+	// we're back under budget instead of hiring
+	if availableBudget < 0 {
+		change.ReleaseAIAgents = ep.selectAgentsToRelease(agents, -availableBudget)
+		return change
+	}
+	
 	// If no orchestration capacity, we can't hire agents
 	if availableOrchestrationCapacity <= 0 {
 		return change
@@ -250,24 +477,37 @@ func (ep *EventProcessor) OptimizeWorkforce(
 	
 	// Calculate cost-effectiveness of hiring a new AI agent
 	// Start with University_Hire level agent
-	newAgentCost := types.AIAgentCosts[types.UniversityHire]
+	// Costs are fully loaded with overhead on both sides of the comparison
+	newAgentCost := ep.overhead.AICost(types.AIAgentCosts[types.UniversityHire]) * ep.market.AICost
 	newAgentProductivity := types.AIAgentProductivity[types.UniversityHire]
+	newAgentCostPerProductivity := newAgentCost / newAgentProductivity
 	
-	// Check if we can afford at least one agent
-	if availableBudget < newAgentCost {
-		return change
+	// Check if we can afford at least one agent, and how many
+	vendors := ep.selectVendors(agents, availableOrchestrationCapacity)
+	maxAgentsByBudget := 0
+	if len(vendors) > 0 || ep.aiPricing.Enabled() {
+		// Vendor tiers and fleet pricing make the cost of each hire depend on the hires before
+		// it, so the hires are priced in order and judged together: a costly tier in a vendor
+		// mix does not stop the hiring of the others, and the hires reaching a volume discount
+		// are credited with its savings
+		maxAgentsByBudget, newAgentCostPerProductivity = ep.priceHires(agents, vendors, availableOrchestrationCapacity, availableBudget)
+		if maxAgentsByBudget == 0 {
+			return change
+		}
+	} else {
+		if availableBudget < newAgentCost {
+			return change
+		}
+		maxAgentsByBudget = int(availableBudget / newAgentCost)
 	}
 	
-	// Calculate cost per productivity unit for new agent
-	newAgentCostPerProductivity := newAgentCost / newAgentProductivity
-	
 	// Find the most cost-effective human to compare against
 	// (This helps decide if we should hire AI instead of humans)
 	bestHumanCostPerProductivity := 0.0
 	for _, human := range humans {
-		effectiveProductivity := human.GetEffectiveProductivity(ep.timeZoneInefficiency)
+		effectiveProductivity := human.GetEffectiveProductivity(ep.regions)
 		if effectiveProductivity > 0 {
-			costPerProductivity := human.BaseCost / effectiveProductivity
+			costPerProductivity := ep.overhead.HumanCost(human.BaseCost, human.ContractType) * ep.market.Salary / effectiveProductivity
 			if bestHumanCostPerProductivity == 0 || costPerProductivity < bestHumanCostPerProductivity {
 				bestHumanCostPerProductivity = costPerProductivity
 			}
@@ -278,12 +518,34 @@ func (ep *EventProcessor) OptimizeWorkforce(
 	// or if we have budget and capacity available
 	if newAgentCostPerProductivity < bestHumanCostPerProductivity || bestHumanCostPerProductivity == 0 {
 		// Calculate how many agents we can hire
-		maxAgentsByBudget := int(availableBudget / newAgentCost)
 		maxAgentsToHire := maxAgentsByBudget
 		if maxAgentsToHire > availableOrchestrationCapacity {
 			maxAgentsToHire = availableOrchestrationCapacity
 		}
 		
+		// Balancing strategies hire as many agents per step as filling a single orchestrator
+		// would, but spread them across every human with spare capacity
+		if ep.assignment != types.FillFirst {
+			maxCapacity := 0
+			for _, human := range humans {
+				if capacity := human.GetOrchestrationCapacity(); capacity > maxCapacity {
+					maxCapacity = capacity
+				}
+			}
+			if maxAgentsToHire > maxCapacity {
+				maxAgentsToHire = maxCapacity
+			}
+			change.Assignments = ep.assignOrchestrators(humans, agents, maxAgentsToHire)
+			change.HireAIAgents = len(change.Assignments)
+			if change.HireAIAgents > 0 {
+				change.OrchestratorID = change.Assignments[0]
+			}
+			if vendors != nil {
+				change.Vendors = vendors[:change.HireAIAgents]
+			}
+			return change
+		}
+		
 		// Find the best orchestrator (human with most available capacity)
 		var bestOrchestrator *types.HumanWorker
 		maxCapacity := 0
@@ -306,52 +568,197 @@ func (ep *EventProcessor) OptimizeWorkforce(
 			change.OrchestratorID = bestOrchestrator.ID
 		}
 	}
+	if vendors != nil {
+		change.Vendors = vendors[:change.HireAIAgents]
+	}
 	
-	// Check if we should release any agents due to budget constraints
-	// This would happen if we're over budget (shouldn't normally occur)
-	// or if agents are not cost-effective
-	if availableBudget < 0 {
-		// We're over budget - need to release agents
-		// Release the least productive agents first
-		type agentScore struct {
-			id           string
-			productivity float64
+	return change
+}
+
+// selectVendors picks the vendor tier of each of up to count new agents by the vendor policy, in
+// hiring order, or returns nil without vendor tiers
+func (ep *EventProcessor) selectVendors(agents []*types.AIAgent, count int) []int {
+	if len(ep.vendors.Tiers) == 0 || count <= 0 {
+		return nil
+	}
+	counts := make([]int, len(ep.vendors.Tiers))
+	for _, agent := range agents {
+		if agent.Vendor >= 0 && agent.Vendor < len(counts) {
+			counts[agent.Vendor]++
 		}
-		
-		agentScores := make([]agentScore, 0, len(agents))
-		for _, agent := range agents {
-			agentScores = append(agentScores, agentScore{
-				id:           agent.ID,
-				productivity: agent.GetProductivity(),
-			})
+	}
+	vendors := make([]int, count)
+	for i := range vendors {
+		vendors[i] = ep.vendors.Select(counts)
+		counts[vendors[i]]++
+	}
+	return vendors
+}
+
+// priceHires returns how many of up to count new agents, from the vendor tiers picked for them if
+// any, the budget pays for, hiring them in order at the price of the fleet, and the cost per
+// productivity unit they add together. Hires that lower the fleet's price, such as those
+// reaching a volume discount, add their savings
+func (ep *EventProcessor) priceHires(agents []*types.AIAgent, vendors []int, count int, availableBudget float64) (int, float64) {
+	listed := 0.0
+	for _, agent := range agents {
+		listed += ep.agentCost(agent)
+	}
+	fleetCost := ep.fleetCost(listed, len(agents))
+	startCost := fleetCost
+	
+	agent := types.NewAIAgent("", "", 0)
+	hires, productivity := 0, 0.0
+	for hires < count {
+		if hires < len(vendors) {
+			agent.ApplyVendor(vendors[hires], ep.vendors.Tiers.Tier(vendors[hires]))
 		}
-		
-		// Sort by productivity (ascending - least productive first)
-		for i := 0; i < len(agentScores)-1; i++ {
-			for j := i + 1; j < len(agentScores); j++ {
-				if agentScores[i].productivity > agentScores[j].productivity {
-					agentScores[i], agentScores[j] = agentScores[j], agentScores[i]
-				}
-			}
+		nextCost := ep.fleetCost(listed+ep.agentCost(agent), len(agents)+hires+1)
+		if nextCost-startCost > availableBudget {
+			break
 		}
-		
-		// Release agents until we're back under budget
-		budgetDeficit := -availableBudget
-		for _, score := range agentScores {
-			if budgetDeficit <= 0 {
+		listed += ep.agentCost(agent)
+		fleetCost = nextCost
+		productivity += agent.GetProductivity()
+		hires++
+	}
+	if hires == 0 {
+		return 0, 0
+	}
+	return hires, (fleetCost - startCost) / productivity
+}
+
+// agentCost returns the loaded annual cost of an agent at its own price, under the current market
+func (ep *EventProcessor) agentCost(agent *types.AIAgent) float64 {
+	return ep.overhead.AICost(agent.GetCost()) * ep.market.AICost
+}
+
+// fleetCost returns the annual cost of a fleet of agents whose own loaded costs sum to listed,
+// under the AI pricing
+func (ep *EventProcessor) fleetCost(listed float64, agents int) float64 {
+	return ep.aiPricing.FleetCost(listed, agents, ep.market.AICost)
+}
+
+// assignOrchestrators picks the orchestrator of each of up to count new agents under the
+// balancing strategy, in hiring order. The search starts with the human after the
+// orchestrator of the most recently hired agent, so successive steps keep taking turns:
+// Round_Robin takes the next human with spare capacity, Least_Loaded the first of those
+// orchestrating the fewest agents
+func (ep *EventProcessor) assignOrchestrators(humans []*types.HumanWorker, agents []*types.AIAgent, count int) []string {
+	load := make([]int, len(humans))
+	spare := make([]int, len(humans))
+	next := 0
+	for i, human := range humans {
+		load[i] = len(human.AssignedAgents)
+		spare[i] = human.GetOrchestrationCapacity()
+		if len(agents) > 0 && human.ID == agents[len(agents)-1].OrchestratorID {
+			next = i + 1
+		}
+	}
+	
+	assignments := make([]string, 0, count)
+	for len(assignments) < count {
+		chosen := -1
+		for offset := 0; offset < len(humans); offset++ {
+			i := (next + offset) % len(humans)
+			if spare[i] <= 0 {
+				continue
+			}
+			if ep.assignment == types.RoundRobin {
+				chosen = i
 				break
 			}
-			
-			// Find the agent and get its cost
-			for _, agent := range agents {
-				if agent.ID == score.id {
-					change.ReleaseAIAgents = append(change.ReleaseAIAgents, agent.ID)
-					budgetDeficit -= agent.GetCost()
-					break
-				}
+			if chosen < 0 || load[i] < load[chosen] {
+				chosen = i
 			}
 		}
+		if chosen < 0 {
+			break
+		}
+		load[chosen]++
+		spare[chosen]--
+		if ep.assignment == types.RoundRobin {
+			next = chosen + 1
+		}
+		assignments = append(assignments, humans[chosen].ID)
+	}
+	return assignments
+}
+
+// SelectExcessAgents picks count AI agents to release when the workforce must shrink by a
+// number of agents rather than by a budget, least cost-effective first
+func (ep *EventProcessor) SelectExcessAgents(agents []*types.AIAgent, count int) []string {
+	released := make([]string, 0, count)
+	for _, agent := range rankAgentsForRelease(agents) {
+		if len(released) >= count {
+			break
+		}
+		released = append(released, agent.ID)
 	}
+	return released
+}
+
+// selectAgentsToRelease picks the AI agents to release to cover a budget deficit
+func (ep *EventProcessor) selectAgentsToRelease(agents []*types.AIAgent, budgetDeficit float64) []string {
+	released := make([]string, 0)
 	
-	return change
+	// Under fleet pricing a release saves what it takes off the fleet's price, which may be less
+	// than the agent's own cost, e.g. nothing while the fleet is held at the platform fee
+	listed := 0.0
+	if ep.aiPricing.Enabled() {
+		for _, agent := range agents {
+			listed += ep.agentCost(agent)
+		}
+	}
+	remaining := len(agents)
+	for _, agent := range rankAgentsForRelease(agents) {
+		if budgetDeficit <= 0 {
+			break
+		}
+		released = append(released, agent.ID)
+		saving := ep.agentCost(agent)
+		if ep.aiPricing.Enabled() {
+			before := ep.fleetCost(listed, remaining)
+			listed -= saving
+			remaining--
+			saving = before - ep.fleetCost(listed, remaining)
+		}
+		budgetDeficit -= saving
+	}
+
+	return released
+}
+
+// rankAgentsForRelease orders AI agents for release by cost-effectiveness, least
+// cost-effective (highest cost per productivity unit) first, so the remaining workforce
+// delivers the most output per dollar
+func rankAgentsForRelease(agents []*types.AIAgent) []*types.AIAgent {
+	type agentScore struct {
+		agent               *types.AIAgent
+		costPerProductivity float64
+	}
+
+	scores := make([]agentScore, 0, len(agents))
+	for _, agent := range agents {
+		costPerProductivity := math.Inf(1)
+		if productivity := agent.GetProductivity(); productivity > 0 {
+			costPerProductivity = agent.GetCost() / productivity
+		}
+		scores = append(scores, agentScore{agent: agent, costPerProductivity: costPerProductivity})
+	}
+
+	// Sort by cost per productivity unit (descending - least cost-effective first)
+	// Ties are broken by ID so the release order is deterministic
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].costPerProductivity != scores[j].costPerProductivity {
+			return scores[i].costPerProductivity > scores[j].costPerProductivity
+		}
+		return scores[i].agent.ID < scores[j].agent.ID
+	})
+
+	ranked := make([]*types.AIAgent, len(scores))
+	for i, score := range scores {
+		ranked[i] = score.agent
+	}
+	return ranked
 }
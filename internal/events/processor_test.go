@@ -0,0 +1,306 @@
+package events
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// newTestProcessor creates an EventProcessor with neutral settings for tests
+func newTestProcessor(seed int64) *EventProcessor {
+	return NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition, NaturalRate: 10.0, ForcedAcceleration: 1.0},
+		0.0,
+		types.DefaultFailureClasses(types.FailureRecoveryConfig{}).Table(),
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{},
+		types.DefaultRegions(types.CostCategoryDistribution{}, 0.1),
+		types.OverheadConfig{},
+		types.FillFirst,
+		random.NewStreams(seed),
+	)
+}
+
+// newTestWorkforce builds humans with partially filled orchestration capacity and their agents
+func newTestWorkforce(humanCount int, agentsPerHuman int) ([]*types.HumanWorker, []*types.AIAgent) {
+	humans := make([]*types.HumanWorker, 0, humanCount)
+	agents := make([]*types.AIAgent, 0, humanCount*agentsPerHuman)
+
+	for i := 0; i < humanCount; i++ {
+		human := types.NewHumanWorker(fmt.Sprintf("human-%d", i+1), types.ExperienceLevel(i%4), types.CostCategory(i%2), i == 0)
+		for j := 0; j < agentsPerHuman; j++ {
+			agent := types.NewAIAgent(fmt.Sprintf("agent-%d", len(agents)+1), human.ID, 0)
+			agent.ExperienceLevel = types.ExperienceLevel(j % 4)
+			agent.Cost = types.AIAgentCosts[agent.ExperienceLevel]
+			human.AssignedAgents = append(human.AssignedAgents, agent.ID)
+			agents = append(agents, agent)
+		}
+		humans = append(humans, human)
+	}
+
+	return humans, agents
+}
+
+func BenchmarkOptimizeWorkforce(b *testing.B) {
+	ep := newTestProcessor(12345)
+	humans, agents := newTestWorkforce(200, 4)
+
+	b.Run("Hire", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ep.OptimizeWorkforce(humans, agents, 5000000.0, 400)
+		}
+	})
+
+	b.Run("Release", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ep.OptimizeWorkforce(humans, agents, -5000000.0, 400)
+		}
+	})
+}
+
+func TestProcessAttritionAppliesRegionModifiers(t *testing.T) {
+	regions := types.RegionTable{
+		{Name: "Stable", AttritionModifier: 0.0001},
+		{Name: "Volatile", AttritionModifier: 12},
+	}
+	ep := NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition, NaturalRate: 10.0, ForcedAcceleration: 1.0},
+		0.0,
+		types.DefaultFailureClasses(types.FailureRecoveryConfig{}).Table(),
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{},
+		regions,
+		types.OverheadConfig{},
+		types.FillFirst,
+		random.NewStreams(12345),
+	)
+	humans, _ := newTestWorkforce(200, 0)
+
+	// A 10% annual rate times 12 is a 10% monthly rate in the volatile region; humans are not
+	// removed between steps, so 100 volatile humans over 12 steps should leave about 120 times
+	departures := make(map[types.CostCategory]int)
+	for step := 1; step <= 12; step++ {
+		for _, id := range ep.ProcessAttrition(humans, step) {
+			for _, human := range humans {
+				if human.ID == id {
+					departures[human.CostCategory]++
+				}
+			}
+		}
+	}
+
+	if departures[0] != 0 {
+		t.Errorf("Expected no departures from the stable region, got %d", departures[0])
+	}
+	if departures[1] < 60 || departures[1] > 180 {
+		t.Errorf("Expected about 120 departures from the volatile region, got %d", departures[1])
+	}
+}
+
+func TestProcessLearningSharedAcceleration(t *testing.T) {
+	newAgents := func() []*types.AIAgent {
+		agents := []*types.AIAgent{types.NewAIAgent("junior", "human-1", 0)}
+		for i := 0; i < 4; i++ {
+			senior := types.NewAIAgent(fmt.Sprintf("senior-%d", i), "human-1", 0)
+			senior.ExperienceLevel = types.Senior
+			agents = append(agents, senior)
+		}
+		return agents
+	}
+
+	ep := NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition},
+		0.0,
+		types.DefaultFailureClasses(types.FailureRecoveryConfig{}).Table(),
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{Acceleration: 0.25},
+		nil,
+		types.OverheadConfig{},
+		types.FillFirst,
+		random.NewStreams(1),
+	)
+
+	// Four senior agents double the junior's learning speed, halving its 10 step threshold
+	agents := newAgents()
+	for step := 1; step <= 5; step++ {
+		ep.ProcessLearning(agents, 1)
+	}
+	if agents[0].ExperienceLevel != types.MidLevel {
+		t.Errorf("Expected the junior agent to reach Mid_Level in 5 steps, got %s", agents[0].ExperienceLevel)
+	}
+	if agents[1].ExperiencePoints != 5 {
+		t.Errorf("Expected senior agents to learn at the normal rate, got %v experience", agents[1].ExperiencePoints)
+	}
+
+	// Without shared learning the junior needs the full 10 steps
+	agents = newAgents()
+	baseline := newTestProcessor(1)
+	for step := 1; step <= 5; step++ {
+		baseline.ProcessLearning(agents, 1)
+	}
+	if agents[0].ExperienceLevel != types.UniversityHire {
+		t.Errorf("Expected the junior agent to stay University_Hire without shared learning, got %s", agents[0].ExperienceLevel)
+	}
+}
+
+func TestProcessLearningTrainsSelectedAgents(t *testing.T) {
+	agents := make([]*types.AIAgent, 4)
+	for i := range agents {
+		agents[i] = types.NewAIAgent(fmt.Sprintf("agent-%d", i), "human-1", 0)
+		agents[i].ExperiencePoints = 4
+	}
+	agents[2].ExperiencePoints = 6
+	agents[3].ExperienceLevel = types.Executive
+
+	// Training halves the 10 step threshold of the two agents it covers: the one closest to its
+	// next level, then the earliest hired of the others
+	ep := newTestProcessor(1)
+	ep.SetTraining(types.TrainingConfig{BudgetShare: 0.1, ThresholdReduction: 0.5}, 2)
+	ep.ProcessLearning(agents, 1)
+	for i, want := range []types.ExperienceLevel{types.MidLevel, types.UniversityHire, types.MidLevel, types.Executive} {
+		if agents[i].ExperienceLevel != want {
+			t.Errorf("Expected %s at %s, got %s", agents[i].ID, want, agents[i].ExperienceLevel)
+		}
+	}
+
+	// Training from Mid_Level on leaves University_Hire agents to learn organically
+	ep.SetTraining(types.TrainingConfig{BudgetShare: 0.1, ThresholdReduction: 0.5, MinLevel: types.MidLevel}, -1)
+	for step := 1; step <= 4; step++ {
+		ep.ProcessLearning(agents, 1)
+	}
+	if agents[1].ExperienceLevel != types.UniversityHire || agents[1].ExperiencePoints != 9 {
+		t.Errorf("Expected the University_Hire agent to learn untrained, got %s with %v experience", agents[1].ExperienceLevel, agents[1].ExperiencePoints)
+	}
+}
+
+func TestProcessLearningDataExposure(t *testing.T) {
+	// human-1 is a University_Hire and human-3 a Senior, each orchestrating 3 agents
+	humans, agents := newTestWorkforce(3, 3)
+	orphan := types.NewAIAgent("orphan", "departed", 0)
+	agents = append(agents, orphan)
+
+	ep := newTestProcessor(1)
+	ep.SetOrchestrators(humans)
+	ep.ProcessLearning(agents, 1)
+	for _, agent := range agents {
+		if agent.ExperiencePoints != 1 {
+			t.Fatalf("Expected full exposure without the model, got %v experience for %s", agent.ExperiencePoints, agent.ID)
+		}
+	}
+
+	// Each level adds half the exposure, and a half-used orchestration limit takes a quarter off
+	ep.SetDataExposure(types.DataExposureConfig{Availability: 1, LevelWeight: 0.5, WorkloadWeight: 0.5})
+	ep.ProcessLearning(agents, 1)
+	for _, tt := range []struct {
+		agent *types.AIAgent
+		want  float64
+	}{{agents[0], 1.75}, {agents[6], 2.5}, {orphan, 2}} {
+		if tt.agent.ExperiencePoints != tt.want {
+			t.Errorf("Expected %s under %s to reach %v experience, got %v", tt.agent.ID, tt.agent.OrchestratorID, tt.want, tt.agent.ExperiencePoints)
+		}
+	}
+}
+
+func TestVendorTiersScaleLearningAndFailures(t *testing.T) {
+	humans, _ := newTestWorkforce(1, 0)
+	tiers := types.VendorTable{
+		{Name: "Frontier", Share: 25, CostMultiplier: 2, ProductivityMultiplier: 1.5, FailureMultiplier: 0.5},
+		{Name: "Open_Source", Share: 75, CostMultiplier: 0.5, ProductivityMultiplier: 0.8, LearningMultiplier: 2},
+	}
+	ep := newTestProcessor(1)
+	ep.SetVendors(types.VendorConfig{Tiers: tiers})
+
+	// The open-source model learns twice as fast, and frontier agents halve the severity of failures
+	frontier, open := types.NewAIAgent("frontier", humans[0].ID, 0), types.NewAIAgent("open", humans[0].ID, 0)
+	frontier.ApplyVendor(0, tiers[0])
+	open.ApplyVendor(1, tiers[1])
+	ep.ProcessLearning([]*types.AIAgent{frontier, open}, 1)
+	if frontier.ExperiencePoints != 1 || open.ExperiencePoints != 2 {
+		t.Errorf("Expected 1 and 2 experience, got %v and %v", frontier.ExperiencePoints, open.ExperiencePoints)
+	}
+	failure := &CatastrophicFailure{Class: types.SecurityIncident, Severity: 0.8}
+	outcome := ep.EvaluateFailureResponse(failure, humans, []*types.AIAgent{frontier, frontier, frontier, open})
+	baseline := ep.EvaluateFailureResponse(failure, humans, nil)
+	if math.Abs(outcome.RequiredCapability-baseline.RequiredCapability*0.625) > 1e-9 {
+		t.Errorf("Expected frontier agents to cut the required capability to %.3f, got %.3f", baseline.RequiredCapability*0.625, outcome.RequiredCapability)
+	}
+}
+
+func TestSelectExcessAgentsReleasesLeastCostEffectiveFirst(t *testing.T) {
+	ep := newTestProcessor(12345)
+	_, agents := newTestWorkforce(1, 4)
+
+	// University_Hire agents have the highest cost per productivity unit
+	released := ep.SelectExcessAgents(agents, 2)
+	if len(released) != 2 || released[0] != "agent-1" || released[1] != "agent-2" {
+		t.Errorf("Expected agent-1 and agent-2 to be released, got %v", released)
+	}
+	if released := ep.SelectExcessAgents(agents, 10); len(released) != len(agents) {
+		t.Errorf("Expected every agent to be released when the excess exceeds the workforce, got %v", released)
+	}
+}
+
+func TestEvaluateFailureResponseUsesClassRequirements(t *testing.T) {
+	classes := types.DefaultFailureClasses(types.FailureRecoveryConfig{}).Table()
+	classes[types.ComplianceBreach] = types.FailureClassConfig{Weight: 1, MinSeniorHumans: 3, Capability: 1, UnhandledPenalty: 0.8}
+	classes[types.ModelOutage] = types.FailureClassConfig{Weight: 1, Capability: 10, AgentCapability: 0.5, GapPenalty: 0.4}
+	ep := NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition},
+		0.0,
+		classes,
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{},
+		nil,
+		types.OverheadConfig{},
+		types.FillFirst,
+		random.NewStreams(1),
+	)
+	// Two of the four humans are senior or executive, and eight of the sixteen agents
+	humans, agents := newTestWorkforce(4, 4)
+
+	breach := ep.EvaluateFailureResponse(&CatastrophicFailure{Class: types.ComplianceBreach, Severity: 0.5}, humans, agents)
+	if breach.CanHandle || breach.ProductivityPenalty != 0.4 {
+		t.Errorf("Expected a breach to need three senior humans, got %+v", breach)
+	}
+
+	// Capability is 2 humans + 8 agents x 0.5 = 6 against 10 x 0.8 = 8 required
+	outage := ep.EvaluateFailureResponse(&CatastrophicFailure{Class: types.ModelOutage, Severity: 0.8}, humans, agents)
+	if expected := 0.8 * 0.25 * 0.4; outage.CanHandle || math.Abs(outage.ProductivityPenalty-expected) > 1e-9 {
+		t.Errorf("Expected a penalty of %.3f for the capability gap, got %+v", expected, outage)
+	}
+	if minor := ep.EvaluateFailureResponse(&CatastrophicFailure{Class: types.ModelOutage, Severity: 0.5}, humans, agents); !minor.CanHandle {
+		t.Errorf("Expected an outage within the workforce's capability to be handled, got %+v", minor)
+	}
+}
+
+func TestGenerateCatastrophicFailureDrawsClassesByWeight(t *testing.T) {
+	var classes [types.NumFailureClasses]types.FailureClassConfig
+	classes[types.SecurityIncident].Weight = 3
+	classes[types.DataCorruption].Weight = 1
+	ep := NewEventProcessor(
+		types.AttritionConfig{Type: types.NaturalAttrition},
+		1.0,
+		classes,
+		types.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		types.SharedLearningConfig{},
+		nil,
+		types.OverheadConfig{},
+		types.FillFirst,
+		random.NewStreams(7),
+	)
+
+	counts := make(map[types.FailureClass]int)
+	for step := 0; step < 4000; step++ {
+		counts[ep.GenerateCatastrophicFailure(step).Class]++
+	}
+	if counts[types.ModelOutage] != 0 || counts[types.ComplianceBreach] != 0 {
+		t.Errorf("Expected unweighted classes never to occur, got %v", counts)
+	}
+	if share := float64(counts[types.SecurityIncident]) / 4000; share < 0.72 || share > 0.78 {
+		t.Errorf("Expected about 75%% security incidents, got %.3f", share)
+	}
+}
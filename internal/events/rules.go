@@ -0,0 +1,158 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// RulesFunction is the function a rules script defines, called at every time step
+const RulesFunction = "rules"
+
+// MaxRuleSteps bounds the computation of a single evaluation of the rules, so a runaway script
+// cannot stall the simulation
+const MaxRuleSteps = 100000
+
+// RuleActions are the decisions the rules took for a time step
+type RuleActions struct {
+	FreezeAIHiring      bool     // no AI agents are hired this step
+	FreezeHumanHiring   bool     // no humans are hired this step; due backfills wait
+	AttritionMultiplier float64  // factor on this step's attrition rates; 1 when no rule scaled them
+	ReleaseAgents       int      // least cost-effective AI agents to release this step
+	Messages            []string // printed by the rules
+}
+
+// Rules are compiled per-step rules written in Starlark
+// The script defines rules(state), which is called at the start of every time step with a
+// read-only view of the state recorded at the end of the previous step, and acts through the
+// builtins freeze_ai_hiring(), freeze_human_hiring(), scale_attrition(factor) and
+// release_agents(count). Output of print() is recorded in the event log
+type Rules struct {
+	function starlark.Callable
+}
+
+// actionsKey is the thread-local key of the actions being collected by an evaluation
+const actionsKey = "actions"
+
+// CompileRules compiles a rules script, returning nil for an empty script
+// Returns an error if the script does not parse, fails while loading or does not define
+// rules(state)
+func CompileRules(script string) (*Rules, error) {
+	if strings.TrimSpace(script) == "" {
+		return nil, nil
+	}
+	thread := &starlark.Thread{Name: "rules", Print: func(*starlark.Thread, string) {}}
+	thread.SetMaxExecutionSteps(MaxRuleSteps)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, "rules.star", script, ruleBuiltins)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	function, ok := globals[RulesFunction].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("invalid rules: the script must define %s(state)", RulesFunction)
+	}
+	return &Rules{function: function}, nil
+}
+
+// Evaluate runs the rules on a state and returns the actions they took
+// Returns an error if the rules fail, including when they exceed MaxRuleSteps
+func (r *Rules) Evaluate(state types.SimulationState) (RuleActions, error) {
+	actions := RuleActions{AttritionMultiplier: 1.0}
+	if r == nil {
+		return actions, nil
+	}
+	thread := &starlark.Thread{
+		Name: "rules",
+		Print: func(_ *starlark.Thread, message string) {
+			actions.Messages = append(actions.Messages, message)
+		},
+	}
+	thread.SetMaxExecutionSteps(MaxRuleSteps)
+	thread.SetLocal(actionsKey, &actions)
+	if _, err := starlark.Call(thread, r.function, starlark.Tuple{stateView(state)}, nil); err != nil {
+		return RuleActions{AttritionMultiplier: 1.0}, fmt.Errorf("rules failed at time step %d: %w", state.TimeStep+1, err)
+	}
+	return actions, nil
+}
+
+// ruleBuiltins are the actions available to rules scripts
+var ruleBuiltins = starlark.StringDict{
+	"freeze_ai_hiring": starlark.NewBuiltin("freeze_ai_hiring", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return withActions(thread, b, func(actions *RuleActions) { actions.FreezeAIHiring = true })
+	}),
+	"freeze_human_hiring": starlark.NewBuiltin("freeze_human_hiring", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return withActions(thread, b, func(actions *RuleActions) { actions.FreezeHumanHiring = true })
+	}),
+	"scale_attrition": starlark.NewBuiltin("scale_attrition", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var factor starlark.Value
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &factor); err != nil {
+			return nil, err
+		}
+		value, ok := starlark.AsFloat(factor)
+		if !ok || value < 0 {
+			return nil, fmt.Errorf("%s: factor must be a non-negative number, got %s", b.Name(), factor)
+		}
+		return withActions(thread, b, func(actions *RuleActions) { actions.AttritionMultiplier *= value })
+	}),
+	"release_agents": starlark.NewBuiltin("release_agents", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var count int
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &count); err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("%s: count must not be negative, got %d", b.Name(), count)
+		}
+		return withActions(thread, b, func(actions *RuleActions) { actions.ReleaseAgents += count })
+	}),
+}
+
+// withActions applies an action to the actions being collected on the thread
+// Actions are only available while rules(state) runs, not while the script loads
+func withActions(thread *starlark.Thread, b *starlark.Builtin, apply func(actions *RuleActions)) (starlark.Value, error) {
+	actions, ok := thread.Local(actionsKey).(*RuleActions)
+	if !ok {
+		return nil, errors.New(b.Name() + " can only be called from " + RulesFunction + "(state)")
+	}
+	apply(actions)
+	return starlark.None, nil
+}
+
+// stateView returns the read-only view of a state that rules see
+func stateView(state types.SimulationState) *starlarkstruct.Struct {
+	humans := state.Workforce.Humans.Total
+	agents := state.Workforce.AIAgents.Total
+	aiRatio := 0.0
+	if humans+agents > 0 {
+		aiRatio = 100.0 * float64(agents) / float64(humans+agents)
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"time_step":              starlark.MakeInt(state.TimeStep),
+		"humans":                 starlark.MakeInt(humans),
+		"ai_agents":              starlark.MakeInt(agents),
+		"ai_ratio":               starlark.Float(aiRatio),
+		"total_cost":             starlark.Float(state.TotalCost),
+		"available_budget":       starlark.Float(state.AvailableBudget),
+		"revenue":                starlark.Float(state.RevenueOutput),
+		"productivity":           starlark.Float(state.TotalProductivity),
+		"net_cash_flow":          starlark.Float(state.NetCashFlow()),
+		"cash_balance":           starlark.Float(state.CashBalance),
+		"burnout":                starlark.Float(state.Burnout),
+		"catastrophic_failures":  starlark.MakeInt(state.CatastrophicFailures),
+		"orchestration_capacity": starlark.MakeInt(state.AvailableOrchestrationCapacity),
+		"agents_hired":           starlark.MakeInt(state.AgentsHiredThisStep),
+		"agents_released":        starlark.MakeInt(state.AgentsReleasedThisStep),
+		"humans_lost":            starlark.MakeInt(state.HumansLostThisStep),
+		"is_equilibrium":         starlark.Bool(state.IsEquilibrium),
+	})
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// ruleState returns a state with the given workforce for evaluating rules
+func ruleState(humans int, agents int) types.SimulationState {
+	var state types.SimulationState
+	state.TimeStep = 3
+	state.Workforce.Humans.Total = humans
+	state.Workforce.AIAgents.Total = agents
+	return state
+}
+
+func TestCompileRules(t *testing.T) {
+	rules, err := CompileRules("  \n")
+	if err != nil || rules != nil {
+		t.Errorf("Expected an empty script to compile to no rules, got %v, %v", rules, err)
+	}
+
+	for name, script := range map[string]string{
+		"syntax error":       "def rules(state):\n  if\n",
+		"missing function":   "x = 1\n",
+		"not a function":     "rules = 1\n",
+		"action at load":     "freeze_ai_hiring()\ndef rules(state):\n  pass\n",
+		"undefined variable": "def rules(state):\n  freeze_everything()\n",
+	} {
+		if _, err := CompileRules(script); err == nil {
+			t.Errorf("%s: expected CompileRules to fail", name)
+		}
+	}
+}
+
+func TestRulesEvaluate(t *testing.T) {
+	rules, err := CompileRules(`
+def rules(state):
+    if state.ai_ratio > 70:
+        freeze_ai_hiring()
+        print("AI ratio at %d%%" % int(state.ai_ratio))
+    if state.humans < 10:
+        freeze_human_hiring()
+        scale_attrition(0.5)
+        scale_attrition(0.5)
+    if state.ai_agents > 50:
+        release_agents(state.ai_agents - 50)
+`)
+	if err != nil {
+		t.Fatalf("CompileRules failed: %v", err)
+	}
+
+	actions, err := rules.Evaluate(ruleState(20, 40))
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if actions.FreezeAIHiring || actions.FreezeHumanHiring || actions.ReleaseAgents != 0 || actions.AttritionMultiplier != 1.0 || len(actions.Messages) != 0 {
+		t.Errorf("Expected no actions below the thresholds, got %+v", actions)
+	}
+
+	actions, err = rules.Evaluate(ruleState(8, 60))
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !actions.FreezeAIHiring || !actions.FreezeHumanHiring {
+		t.Errorf("Expected both hiring freezes, got %+v", actions)
+	}
+	if actions.AttritionMultiplier != 0.25 {
+		t.Errorf("Expected attrition scales to compound to 0.25, got %f", actions.AttritionMultiplier)
+	}
+	if actions.ReleaseAgents != 10 {
+		t.Errorf("Expected 10 agents to be released, got %d", actions.ReleaseAgents)
+	}
+	if len(actions.Messages) != 1 || actions.Messages[0] != "AI ratio at 88%" {
+		t.Errorf("Expected the printed message to be collected, got %q", actions.Messages)
+	}
+
+	var none *Rules
+	if actions, err := none.Evaluate(ruleState(8, 60)); err != nil || actions.AttritionMultiplier != 1.0 || actions.FreezeAIHiring {
+		t.Errorf("Expected no rules to take no action, got %+v, %v", actions, err)
+	}
+}
+
+func TestRulesEvaluateErrors(t *testing.T) {
+	for name, script := range map[string]string{
+		"runaway loop":       "def rules(state):\n  for i in range(1000000000):\n    pass\n",
+		"negative factor":    "def rules(state):\n  scale_attrition(-1)\n",
+		"negative count":     "def rules(state):\n  release_agents(-1)\n",
+		"state is read-only": "def rules(state):\n  state.humans = 0\n",
+	} {
+		rules, err := CompileRules(script)
+		if err != nil {
+			t.Fatalf("%s: CompileRules failed: %v", name, err)
+		}
+		actions, err := rules.Evaluate(ruleState(10, 10))
+		if err == nil {
+			t.Errorf("%s: expected Evaluate to fail", name)
+		} else if !strings.Contains(err.Error(), "time step 4") {
+			t.Errorf("%s: expected the error to name the time step, got %v", name, err)
+		}
+		if actions.AttritionMultiplier != 1.0 || actions.ReleaseAgents != 0 {
+			t.Errorf("%s: expected failed rules to take no action, got %+v", name, actions)
+		}
+	}
+}
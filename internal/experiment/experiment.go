@@ -0,0 +1,193 @@
+// Package experiment groups related simulation runs, a base configuration and its variants,
+// into a registry that records every configuration and seed so any run can be reproduced exactly
+package experiment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// BaseVariant is the variant name of an experiment's base configuration
+const BaseVariant = "base"
+
+// Run is a single member run of an experiment
+type Run struct {
+	ID         string // "<variant>/<replicate>"
+	Variant    string
+	Replicate  int
+	Seed       int64 // derived from the experiment's master seed and the run ID
+	Config     types.SimulationConfig
+	ConfigHash string // SHA-256 of Config when the run was registered, see SimulationConfig.Hash
+}
+
+// Experiment is a registry of related runs sharing a master seed and step limit
+// Seeds are derived from the master seed and each run's ID, so a run keeps its seed however many
+// variants or replicates are added around it
+type Experiment struct {
+	Name         string
+	MasterSeed   int64
+	MaxTimeSteps int
+	Version      string    // simulator Version the experiment was created with
+	Created      time.Time // when the experiment was created, in UTC
+	Runs         []Run
+}
+
+// New creates an empty experiment
+func New(name string, masterSeed int64, maxTimeSteps int) *Experiment {
+	return &Experiment{
+		Name:         name,
+		MasterSeed:   masterSeed,
+		MaxTimeSteps: maxTimeSteps,
+		Version:      types.Version,
+		Created:      time.Now().UTC(),
+		Runs:         make([]Run, 0),
+	}
+}
+
+// AddBase registers replicates of the base configuration, see AddVariant
+func (e *Experiment) AddBase(config types.SimulationConfig, replicates int) ([]Run, error) {
+	return e.AddVariant(BaseVariant, config, replicates)
+}
+
+// AddVariant registers replicates of a named configuration and returns the new runs
+// Returns an error if the name is empty or already registered, replicates is not positive, or the
+// configuration is invalid
+func (e *Experiment) AddVariant(name string, config types.SimulationConfig, replicates int) ([]Run, error) {
+	if name == "" {
+		return nil, errors.New("variant name must not be empty")
+	}
+	if replicates <= 0 {
+		return nil, fmt.Errorf("variant %s needs at least 1 replicate, got %d", name, replicates)
+	}
+	for _, run := range e.Runs {
+		if run.Variant == name {
+			return nil, fmt.Errorf("variant %s is already registered", name)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("variant %s: %w", name, err)
+	}
+
+	// Stamp the schema version so the recorded hash matches the configuration as written
+	config.Version = types.ConfigVersion
+	hash := config.Hash()
+	runs := make([]Run, replicates)
+	for replicate := range runs {
+		id := fmt.Sprintf("%s/%d", name, replicate)
+		runs[replicate] = Run{
+			ID:         id,
+			Variant:    name,
+			Replicate:  replicate,
+			Seed:       random.DeriveSeed(e.MasterSeed, id),
+			Config:     config,
+			ConfigHash: hash,
+		}
+	}
+	e.Runs = append(e.Runs, runs...)
+	return runs, nil
+}
+
+// Run returns the run with the given ID
+func (e *Experiment) Run(id string) (Run, bool) {
+	for _, run := range e.Runs {
+		if run.ID == id {
+			return run, true
+		}
+	}
+	return Run{}, false
+}
+
+// Variant returns the runs of a variant in replicate order
+func (e *Experiment) Variant(name string) []Run {
+	runs := make([]Run, 0)
+	for _, run := range e.Runs {
+		if run.Variant == name {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// Execute simulates the run with the given ID with its recorded configuration and seed
+// Returns an error wrapping types.ErrNotFound if there is no such run, or an error if the recorded
+// configuration no longer matches its hash
+func (e *Experiment) Execute(id string) (types.SimulationResult, error) {
+	run, exists := e.Run(id)
+	if !exists {
+		return types.SimulationResult{}, fmt.Errorf("run %s: %w", id, types.ErrNotFound)
+	}
+	if hash := run.Config.Hash(); hash != run.ConfigHash {
+		return types.SimulationResult{}, fmt.Errorf("run %s: configuration hash %s does not match the recorded %s", id, hash, run.ConfigHash)
+	}
+
+	result, err := controller.NewSimulationController(run.Config, run.Seed).RunUntilEquilibrium(e.MaxTimeSteps)
+	if err != nil {
+		return types.SimulationResult{}, fmt.Errorf("run %s failed: %w", id, err)
+	}
+	return result, nil
+}
+
+// ExecuteAll simulates every run in registration order, passing each result to fn
+// Stops at the first error from a run or from fn
+func (e *Experiment) ExecuteAll(fn func(run Run, result types.SimulationResult) error) error {
+	for _, run := range e.Runs {
+		result, err := e.Execute(run.ID)
+		if err != nil {
+			return err
+		}
+		if err := fn(run, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write writes the experiment registry as JSON
+func (e *Experiment) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e); err != nil {
+		return fmt.Errorf("failed to encode experiment: %w", err)
+	}
+	return nil
+}
+
+// Save writes the experiment registry to a file
+func (e *Experiment) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create experiment file: %w", err)
+	}
+	if err := e.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read reads an experiment registry previously written by Write
+func Read(reader io.Reader) (*Experiment, error) {
+	var experiment Experiment
+	if err := json.NewDecoder(reader).Decode(&experiment); err != nil {
+		return nil, fmt.Errorf("failed to decode experiment: %w", err)
+	}
+	return &experiment, nil
+}
+
+// Load reads an experiment registry from a file
+func Load(path string) (*Experiment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open experiment file: %w", err)
+	}
+	defer file.Close()
+
+	return Read(file)
+}
@@ -0,0 +1,159 @@
+package experiment
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"workforce-ai-transition-simulator/internal/random"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// testConfig returns a small valid configuration
+func testConfig() types.SimulationConfig {
+	return types.SimulationConfig{
+		InitialHumans: 10,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     3000000.0,
+		RevenueScenario: types.FlatRevenue,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        20.0,
+			ForcedAcceleration: 1.0,
+		},
+		CatastrophicFailureRate: 0.05,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+func TestExperimentDerivesStableSeeds(t *testing.T) {
+	experiment := New("attrition", 42, 50)
+	base, err := experiment.AddBase(testConfig(), 2)
+	if err != nil {
+		t.Fatalf("AddBase failed: %v", err)
+	}
+	variant := testConfig()
+	variant.AttritionConfig.NaturalRate = 40.0
+	if _, err := experiment.AddVariant("high_attrition", variant, 3); err != nil {
+		t.Fatalf("AddVariant failed: %v", err)
+	}
+
+	if len(experiment.Runs) != 5 || len(experiment.Variant("high_attrition")) != 3 {
+		t.Fatalf("Expected 2 base and 3 variant runs, got %+v", experiment.Runs)
+	}
+	if base[1].ID != "base/1" || base[1].Seed != random.DeriveSeed(42, "base/1") {
+		t.Errorf("Expected seeds derived from the master seed and run ID, got %+v", base[1])
+	}
+	seeds := make(map[int64]bool)
+	for _, run := range experiment.Runs {
+		if seeds[run.Seed] {
+			t.Errorf("Seed %d is shared by more than one run", run.Seed)
+		}
+		seeds[run.Seed] = true
+	}
+
+	// A registry built in a different order assigns every run the same seed
+	reordered := New("attrition", 42, 50)
+	if _, err := reordered.AddVariant("high_attrition", variant, 3); err != nil {
+		t.Fatalf("AddVariant failed: %v", err)
+	}
+	if _, err := reordered.AddBase(testConfig(), 2); err != nil {
+		t.Fatalf("AddBase failed: %v", err)
+	}
+	for _, run := range experiment.Runs {
+		other, _ := reordered.Run(run.ID)
+		if other.Seed != run.Seed {
+			t.Errorf("Run %s has seed %d in one registry and %d in the other", run.ID, run.Seed, other.Seed)
+		}
+	}
+}
+
+func TestExperimentReproducesRunsFromRegistryFile(t *testing.T) {
+	experiment := New("reproduce", 7, 50)
+	if _, err := experiment.AddBase(testConfig(), 2); err != nil {
+		t.Fatalf("AddBase failed: %v", err)
+	}
+	original, err := experiment.Execute("base/1")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "experiment.json")
+	if err := experiment.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Runs, experiment.Runs) {
+		t.Errorf("Expected the registry to round-trip, got %+v", loaded.Runs)
+	}
+
+	reproduced, err := loaded.Execute("base/1")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !reflect.DeepEqual(reproduced.TimeSeries, original.TimeSeries) || reproduced.Metadata.Seed != original.Metadata.Seed {
+		t.Error("Expected the loaded registry to reproduce the run exactly")
+	}
+
+	executed := 0
+	if err := loaded.ExecuteAll(func(run Run, result types.SimulationResult) error {
+		executed++
+		return nil
+	}); err != nil || executed != 2 {
+		t.Errorf("Expected ExecuteAll to run both replicates, got %d runs and %v", executed, err)
+	}
+}
+
+func TestExperimentErrors(t *testing.T) {
+	experiment := New("errors", 1, 10)
+	if _, err := experiment.AddVariant("", testConfig(), 1); err == nil {
+		t.Error("Expected an error for an empty variant name")
+	}
+	if _, err := experiment.AddBase(testConfig(), 0); err == nil {
+		t.Error("Expected an error for no replicates")
+	}
+	if _, err := experiment.AddBase(types.SimulationConfig{}, 1); !errors.Is(err, types.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for an invalid configuration, got %v", err)
+	}
+	if _, err := experiment.AddBase(testConfig(), 1); err != nil {
+		t.Fatalf("AddBase failed: %v", err)
+	}
+	if _, err := experiment.AddBase(testConfig(), 1); err == nil {
+		t.Error("Expected an error for a duplicate variant")
+	}
+	if _, err := experiment.Execute("base/5"); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown run, got %v", err)
+	}
+
+	// Editing a recorded configuration breaks its hash
+	var buf bytes.Buffer
+	if err := experiment.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	tampered, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	tampered.Runs[0].Config.FixedBudget *= 2
+	if _, err := tampered.Execute("base/0"); err == nil {
+		t.Error("Expected an error for a configuration that no longer matches its hash")
+	}
+}
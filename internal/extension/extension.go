@@ -0,0 +1,53 @@
+// Package extension loads custom simulation behaviors from Go plugins at run time, so users can
+// supply their own attrition, revenue or optimization logic without rebuilding the simulator
+//
+// An extension is a main package built with "go build -buildmode=plugin" that exports a variable
+// named Behaviors of type simulator.Behaviors. It must be built with the same Go version and
+// the same version of this module as the simulator loading it
+package extension
+
+import (
+	"fmt"
+	"plugin"
+	"workforce-ai-transition-simulator/internal/controller"
+)
+
+// Symbol is the name of the variable an extension exports
+const Symbol = "Behaviors"
+
+// Load opens the extension plugin at path and returns the behaviors it exports
+// Returns an error if the plugin cannot be opened, as happens on platforms without plugin
+// support or when it was built against a different version of the simulator, or if it does
+// not export Behaviors
+func Load(path string) (controller.Behaviors, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return controller.Behaviors{}, fmt.Errorf("failed to open extension %s: %w", path, err)
+	}
+	symbol, err := p.Lookup(Symbol)
+	if err != nil {
+		return controller.Behaviors{}, fmt.Errorf("extension %s does not export %s: %w", path, Symbol, err)
+	}
+	behaviors, ok := symbol.(*controller.Behaviors)
+	if !ok {
+		return controller.Behaviors{}, fmt.Errorf("extension %s exports %s as %T, not simulator.Behaviors", path, Symbol, symbol)
+	}
+	if behaviors.Attrition == nil && behaviors.Revenue == nil && behaviors.Optimize == nil {
+		return controller.Behaviors{}, fmt.Errorf("extension %s sets none of the Attrition, Revenue and Optimize behaviors", path)
+	}
+	return *behaviors, nil
+}
+
+// Options loads the extensions at paths and returns a controller option for each, in order, so
+// behaviors of later extensions replace those of earlier ones
+func Options(paths []string) ([]controller.Option, error) {
+	options := make([]controller.Option, 0, len(paths))
+	for _, path := range paths {
+		behaviors, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, controller.WithBehaviors(behaviors))
+	}
+	return options, nil
+}
@@ -0,0 +1,55 @@
+// The tests are in an external package so the test binary links the same build of package
+// extension as the plugins it loads
+package extension_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/extension"
+	"workforce-ai-transition-simulator/internal/testutil"
+)
+
+// buildPlugin compiles the extension in testdata/name as a plugin and returns its path
+func buildPlugin(t *testing.T, name string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("building a plugin is slow")
+	}
+	path := filepath.Join(t.TempDir(), name+".so")
+	output, err := exec.Command("go", "build", "-buildmode=plugin", "-o", path, "./testdata/"+name).CombinedOutput()
+	if err != nil {
+		t.Skipf("plugins cannot be built here: %v\n%s", err, output)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := buildPlugin(t, "doubling")
+	options, err := extension.Options([]string{path})
+	if err != nil {
+		t.Fatalf("Options failed: %v", err)
+	}
+
+	config := testutil.CanonicalConfig()
+	baseline := controller.NewSimulationController(config, 42)
+	extended := controller.NewSimulationController(config, 42, options...)
+	for _, sc := range []*controller.SimulationController{baseline, extended} {
+		if err := sc.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+	}
+	if want, got := 2*baseline.Step().RevenueOutput, extended.Step().RevenueOutput; got != want {
+		t.Errorf("Expected the extension to double revenue to %f, got %f", want, got)
+	}
+}
+
+func TestLoadRejectsMissingPlugins(t *testing.T) {
+	if _, err := extension.Load(filepath.Join(t.TempDir(), "missing.so")); err == nil {
+		t.Error("Expected loading a missing plugin to fail")
+	}
+	if _, err := extension.Options([]string{filepath.Join(t.TempDir(), "missing.so")}); err == nil {
+		t.Error("Expected Options to fail on a missing plugin")
+	}
+}
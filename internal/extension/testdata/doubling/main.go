@@ -0,0 +1,13 @@
+// Command doubling is an example extension that doubles the revenue of every time step
+package main
+
+import "workforce-ai-transition-simulator/pkg/simulator"
+
+// Behaviors is the extension's custom simulation logic, looked up by the simulator
+var Behaviors = simulator.Behaviors{
+	Revenue: func(productivity float64, timeStep int, revenue float64) float64 {
+		return 2 * revenue
+	},
+}
+
+func main() {}
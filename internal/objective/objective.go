@@ -0,0 +1,175 @@
+// Package objective defines what counts as a good outcome of a simulation, so the optimizer, the
+// reinforcement learning environment and scenario comparisons can pursue or rank by the same goal
+package objective
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Objective scores simulation outcomes; higher is better
+type Objective interface {
+	// Name identifies the objective in reports
+	Name() string
+	// Reward scores a single time step from the state before it and the state after it
+	Reward(previous types.SimulationState, current types.SimulationState) float64
+	// Score scores a whole run
+	Score(result types.SimulationResult) float64
+}
+
+// Default parameters of the built-in objectives when parsed by name alone
+const (
+	DefaultMinMargin     = 0.1
+	DefaultTargetAIShare = 0.5
+)
+
+// Profit maximizes cumulative profit: the cash the organization gains over the run
+type Profit struct{}
+
+// Name returns "profit"
+func (Profit) Name() string { return "profit" }
+
+// Reward returns the step's net cash flow, see SimulationState.NetCashFlow
+func (Profit) Reward(previous types.SimulationState, current types.SimulationState) float64 {
+	return current.NetCashFlow()
+}
+
+// Score returns the cumulative net cash flow of the run
+func (p Profit) Score(result types.SimulationResult) float64 {
+	return cumulative(p, result.TimeSeries)
+}
+
+// JobPreservation minimizes the humans who lose their jobs while keeping the operating margin
+// (revenue less cost, as a fraction of revenue) at or above MinMargin
+// Every percentage point of margin below MinMargin costs as much as one lost job
+type JobPreservation struct {
+	MinMargin float64
+}
+
+// Name returns "job-preservation"
+func (JobPreservation) Name() string { return "job-preservation" }
+
+// Reward returns minus the humans lost in the step and the step's margin shortfall
+func (j JobPreservation) Reward(previous types.SimulationState, current types.SimulationState) float64 {
+	reward := -float64(current.HumansLostThisStep)
+	if shortfall := j.MinMargin - margin(current); shortfall > 0 {
+		reward -= shortfall * 100.0
+	}
+	return reward
+}
+
+// Score returns the cumulative reward of the run
+func (j JobPreservation) Score(result types.SimulationResult) float64 {
+	return cumulative(j, result.TimeSeries)
+}
+
+// Resilience maximizes the organization's ability to absorb catastrophic failures, measured by
+// the productivity it loses while recovering from them
+type Resilience struct{}
+
+// Name returns "resilience"
+func (Resilience) Name() string { return "resilience" }
+
+// Reward returns minus the fraction of productivity lost to failure recovery in the step
+func (Resilience) Reward(previous types.SimulationState, current types.SimulationState) float64 {
+	return -current.RecoveryLoss
+}
+
+// Score returns minus the productivity lost to failure recovery over the run, in steps of full
+// productivity
+func (r Resilience) Score(result types.SimulationResult) float64 {
+	return cumulative(r, result.TimeSeries)
+}
+
+// CompositionTarget steers the AI agents' share of the workforce towards TargetAIShare (0-1)
+type CompositionTarget struct {
+	TargetAIShare float64
+}
+
+// Name returns "composition"
+func (CompositionTarget) Name() string { return "composition" }
+
+// Reward returns minus the distance of the step's AI share from the target: 0 at the target,
+// down to -1 at the far extreme
+func (c CompositionTarget) Reward(previous types.SimulationState, current types.SimulationState) float64 {
+	total := current.Workforce.Humans.Total + current.Workforce.AIAgents.Total
+	if total == 0 {
+		return -1
+	}
+	share := float64(current.Workforce.AIAgents.Total) / float64(total)
+	return -math.Abs(share - c.TargetAIShare)
+}
+
+// Score returns the cumulative reward of the run
+func (c CompositionTarget) Score(result types.SimulationResult) float64 {
+	return cumulative(c, result.TimeSeries)
+}
+
+// builtins creates the built-in objectives by name from an optional parameter
+var builtins = map[string]func(parameter float64, set bool) Objective{
+	"profit": func(float64, bool) Objective { return Profit{} },
+	"job-preservation": func(parameter float64, set bool) Objective {
+		if !set {
+			parameter = DefaultMinMargin
+		}
+		return JobPreservation{MinMargin: parameter}
+	},
+	"resilience": func(float64, bool) Objective { return Resilience{} },
+	"composition": func(parameter float64, set bool) Objective {
+		if !set {
+			parameter = DefaultTargetAIShare
+		}
+		return CompositionTarget{TargetAIShare: parameter}
+	},
+}
+
+// Names returns the names of the built-in objectives
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse returns the built-in objective described by spec: a name, optionally followed by "=" and
+// the objective's parameter, the minimum margin of job-preservation or the target AI share of
+// composition (e.g. "composition=0.6")
+// Returns an error if the name is unknown or the parameter is not a number
+func Parse(spec string) (Objective, error) {
+	name, value, set := strings.Cut(strings.TrimSpace(spec), "=")
+	create, exists := builtins[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown objective %q (expected one of %s)", name, strings.Join(Names(), ", "))
+	}
+	parameter := 0.0
+	if set {
+		var err error
+		if parameter, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid parameter %q for objective %s", value, name)
+		}
+	}
+	return create(parameter, set), nil
+}
+
+// cumulative sums an objective's rewards over the steps of a time series
+func cumulative(objective Objective, timeSeries []types.SimulationState) float64 {
+	total := 0.0
+	for i := 1; i < len(timeSeries); i++ {
+		total += objective.Reward(timeSeries[i-1], timeSeries[i])
+	}
+	return total
+}
+
+// margin returns revenue less cost as a fraction of revenue, -1 without revenue
+func margin(state types.SimulationState) float64 {
+	if state.RevenueOutput <= 0 {
+		return -1
+	}
+	return (state.RevenueOutput - state.TotalCost) / state.RevenueOutput
+}
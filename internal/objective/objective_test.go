@@ -0,0 +1,93 @@
+package objective
+
+import (
+	"math"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// state returns a simulation state with the given workforce, revenue and cost
+func state(humans int, agents int, revenue float64, cost float64) types.SimulationState {
+	var s types.SimulationState
+	s.Workforce.Humans.Total = humans
+	s.Workforce.AIAgents.Total = agents
+	s.RevenueOutput = revenue
+	s.TotalCost = cost
+	return s
+}
+
+func TestProfit(t *testing.T) {
+	previous := state(10, 0, 0, 0)
+	current := state(10, 2, 240000, 120000)
+	if reward := (Profit{}).Reward(previous, current); reward != current.NetCashFlow() {
+		t.Errorf("Expected the step's net cash flow, got %f", reward)
+	}
+	result := types.SimulationResult{TimeSeries: []types.SimulationState{previous, current, current}}
+	if score := (Profit{}).Score(result); math.Abs(score-2*current.NetCashFlow()) > 1e-9 {
+		t.Errorf("Expected the cumulative net cash flow, got %f", score)
+	}
+}
+
+func TestJobPreservation(t *testing.T) {
+	objective := JobPreservation{MinMargin: 0.2}
+	current := state(8, 2, 100, 70)
+	current.HumansLostThisStep = 2
+	if reward := objective.Reward(current, current); reward != -2 {
+		t.Errorf("Expected a reward of -2 for 2 lost jobs above the minimum margin, got %f", reward)
+	}
+	current.TotalCost = 90
+	if reward := objective.Reward(current, current); math.Abs(reward+12) > 1e-9 {
+		t.Errorf("Expected 10 points of margin shortfall to cost 10 more, got %f", reward)
+	}
+}
+
+func TestResilience(t *testing.T) {
+	current := state(10, 5, 0, 0)
+	current.RecoveryLoss = 0.25
+	if reward := (Resilience{}).Reward(current, current); reward != -0.25 {
+		t.Errorf("Expected minus the recovery loss, got %f", reward)
+	}
+}
+
+func TestCompositionTarget(t *testing.T) {
+	current := state(6, 2, 0, 0)
+	if reward := (CompositionTarget{TargetAIShare: 0.25}).Reward(current, current); reward != 0 {
+		t.Errorf("Expected no penalty at the target share, got %f", reward)
+	}
+	if reward := (CompositionTarget{TargetAIShare: 0.75}).Reward(current, current); reward != -0.5 {
+		t.Errorf("Expected a penalty of the distance from the target share, got %f", reward)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Objective
+	}{
+		{"profit", Profit{}},
+		{"resilience", Resilience{}},
+		{"job-preservation", JobPreservation{MinMargin: DefaultMinMargin}},
+		{"job-preservation=0.15", JobPreservation{MinMargin: 0.15}},
+		{"composition", CompositionTarget{TargetAIShare: DefaultTargetAIShare}},
+		{" composition=0.6", CompositionTarget{TargetAIShare: 0.6}},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.spec, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Parse(%q) = %#v, want %#v", test.spec, got, test.want)
+		}
+	}
+
+	for _, spec := range []string{"revenue", "composition=high", ""} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", spec)
+		}
+	}
+	if names := Names(); len(names) != 4 {
+		t.Errorf("Expected 4 built-in objectives, got %v", names)
+	}
+}
@@ -0,0 +1,138 @@
+package publish
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+// DefaultTimeout bounds how long a message may take to be delivered, retries included, and how
+// long Close waits for the messages still buffered
+const DefaultTimeout = 10 * time.Second
+
+// SASL mechanisms of Kafka connections
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// KafkaOptions secure the connections of a Kafka producer
+type KafkaOptions struct {
+	TLS      *tls.Config // encrypts the connections unless nil
+	SASL     string      // SASLPlain, SASLScramSHA256 or SASLScramSHA512; none if empty
+	User     string
+	Password string
+	Timeout  time.Duration // DefaultTimeout if zero
+}
+
+// mechanism returns the SASL mechanism authenticating the connections, nil for none
+func (o KafkaOptions) mechanism() (sasl.Mechanism, error) {
+	switch strings.ToUpper(o.SASL) {
+	case "":
+		return nil, nil
+	case SASLPlain:
+		return plain.Auth{User: o.User, Pass: o.Password}.AsMechanism(), nil
+	case SASLScramSHA256:
+		return scram.Auth{User: o.User, Pass: o.Password}.AsSha256Mechanism(), nil
+	case SASLScramSHA512:
+		return scram.Auth{User: o.User, Pass: o.Password}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unknown Kafka SASL mechanism %q", o.SASL)
+	}
+}
+
+// Kafka is a producer publishing messages to a Kafka topic
+// Messages are produced asynchronously: Publish buffers them and the client sends them in
+// batches in the background, idempotently and acknowledged by every in-sync replica, retrying
+// failed requests after refreshing the partition leaders. Messages go to the partition the key
+// hashes to with the murmur2 hash of Kafka's default partitioner, in order. Delivery errors
+// surface on Close
+type Kafka struct {
+	Brokers []string // bootstrap brokers, host:port
+	Topic   string
+
+	client  *kgo.Client
+	timeout time.Duration
+
+	mu     sync.Mutex
+	err    error // first delivery error
+	failed int   // messages that could not be delivered
+}
+
+// DialKafka returns a producer publishing to topic through the bootstrap brokers
+// Brokers are only contacted once messages are published
+func DialKafka(brokers []string, topic string, options KafkaOptions) (*Kafka, error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.DefaultProduceTopic(topic),
+		kgo.ClientID("wfesim"),
+		kgo.AllowAutoTopicCreation(),
+		kgo.RecordDeliveryTimeout(timeout),
+	}
+	if options.TLS != nil {
+		opts = append(opts, kgo.DialTLSConfig(options.TLS))
+	}
+	mechanism, err := options.mechanism()
+	if err != nil {
+		return nil, err
+	}
+	if mechanism != nil {
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer for %s: %w", strings.Join(brokers, ","), err)
+	}
+	return &Kafka{Brokers: brokers, Topic: topic, client: client, timeout: timeout}, nil
+}
+
+// Publish buffers a message for the partition of key, blocking only while the buffer is full
+func (k *Kafka) Publish(key string, value []byte) error {
+	k.client.Produce(context.Background(), &kgo.Record{Key: []byte(key), Value: value}, k.delivered)
+	return nil
+}
+
+// delivered records the outcome of a message's delivery
+func (k *Kafka) delivered(_ *kgo.Record, err error) {
+	if err == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.err == nil {
+		k.err = err
+	}
+	k.failed++
+}
+
+// Close waits for the buffered messages to be delivered and closes the connections, returning
+// an error if any message was not delivered
+func (k *Kafka) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	flushErr := k.client.Flush(ctx)
+	k.client.Close()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.err != nil {
+		return fmt.Errorf("failed to produce %d messages to Kafka topic %s: %w", k.failed, k.Topic, k.err)
+	}
+	if flushErr != nil {
+		return fmt.Errorf("failed to flush messages to Kafka topic %s: %w", k.Topic, flushErr)
+	}
+	return nil
+}
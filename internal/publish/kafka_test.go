@@ -0,0 +1,125 @@
+package publish
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+// consume reads count records of topic from the cluster, failing the test if they do not arrive
+func consume(t *testing.T, cluster *kfake.Cluster, topic string, count int, opts ...kgo.Opt) []*kgo.Record {
+	t.Helper()
+	opts = append(opts, kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.ConsumeTopics(topic), kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()), kgo.FetchMaxWait(100*time.Millisecond))
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		t.Fatalf("Failed to create consumer: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var records []*kgo.Record
+	for len(records) < count {
+		fetches := client.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Expected %d records, got %d", count, len(records))
+		}
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
+
+func TestKafkaPublishRetriesAfterLeaderChange(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(2), kfake.SeedTopics(2, "simulation-runs"),
+		kfake.EnableSASL(), kfake.Superuser(SASLScramSHA256, "wfesim", "secret"))
+	if err != nil {
+		t.Fatalf("Failed to start Kafka cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	// The first produce request is answered with NOT_LEADER_OR_FOLLOWER for every partition
+	failed := false
+	cluster.ControlKey(int16(kmsg.Produce), func(request kmsg.Request) (kmsg.Response, error, bool) {
+		produce := request.(*kmsg.ProduceRequest)
+		response := produce.ResponseKind().(*kmsg.ProduceResponse)
+		for _, topic := range produce.Topics {
+			responseTopic := kmsg.NewProduceResponseTopic()
+			responseTopic.Topic = topic.Topic
+			for _, partition := range topic.Partitions {
+				responsePartition := kmsg.NewProduceResponseTopicPartition()
+				responsePartition.Partition = partition.Partition
+				responsePartition.ErrorCode = 6
+				responseTopic.Partitions = append(responseTopic.Partitions, responsePartition)
+			}
+			response.Topics = append(response.Topics, responseTopic)
+		}
+		failed = true
+		return response, nil, true
+	})
+
+	getenv := func(name string) string {
+		if name == "KAFKA_SASL_MECHANISM" {
+			return SASLScramSHA256
+		}
+		return ""
+	}
+	producer, err := open("kafka://wfesim:secret@"+cluster.ListenAddrs()[0]+"/simulation-runs", getenv)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	for i, key := range []string{"run-a", "run-a", "run-b"} {
+		if err := producer.Publish(key, []byte("message "+strconv.Itoa(i))); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if err := producer.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !failed {
+		t.Error("Expected the first produce request to fail")
+	}
+
+	records := consume(t, cluster, "simulation-runs", 3, kgo.SASL(scram.Auth{User: "wfesim", Pass: "secret"}.AsSha256Mechanism()))
+	var runA []*kgo.Record
+	for _, record := range records {
+		if string(record.Key) == "run-a" {
+			runA = append(runA, record)
+		}
+	}
+	if len(runA) != 2 || string(runA[0].Value) != "message 0" || string(runA[1].Value) != "message 1" {
+		t.Fatalf("Expected the messages of run-a in order, got %v", runA)
+	}
+	if runA[0].Partition != runA[1].Partition {
+		t.Errorf("Expected the messages of run-a on one partition, got %d and %d", runA[0].Partition, runA[1].Partition)
+	}
+}
+
+func TestKafkaPublishFailsWithoutBroker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	producer, err := DialKafka([]string{address}, "simulation-runs", KafkaOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("DialKafka failed: %v", err)
+	}
+	if err := producer.Publish("run", []byte("message")); err != nil {
+		t.Fatalf("Expected Publish to buffer the message, got %v", err)
+	}
+	if err := producer.Close(); err == nil {
+		t.Error("Expected an error without a reachable broker")
+	}
+	if _, err := DialKafka([]string{address}, "simulation-runs", KafkaOptions{SASL: "GSSAPI"}); err == nil {
+		t.Error("Expected an error for an unsupported SASL mechanism")
+	}
+}
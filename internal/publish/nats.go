@@ -0,0 +1,52 @@
+package publish
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// flushTimeout bounds how long Close waits for the server to acknowledge buffered messages
+const flushTimeout = 10 * time.Second
+
+// NATS publishes messages to a NATS subject
+// Messages are buffered by the client and sent in the background, reconnecting to the servers
+// if the connection drops; errors surface when the messages are flushed on Close
+type NATS struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// DialNATS connects to the comma-separated NATS server URLs to publish to subject, with the
+// credentials file creds unless it is empty
+func DialNATS(servers string, subject string, creds string) (*NATS, error) {
+	options := []nats.Option{nats.Name("wfesim")}
+	if creds != "" {
+		options = append(options, nats.UserCredentials(creds))
+	}
+	conn, err := nats.Connect(servers, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", servers, err)
+	}
+	return &NATS{conn: conn, subject: subject}, nil
+}
+
+// Publish publishes value to the subject; NATS keeps the messages of a connection in order, so
+// key is not needed
+func (n *NATS) Publish(key string, value []byte) error {
+	return n.conn.Publish(n.subject, value)
+}
+
+// Close flushes the buffered messages and closes the connection
+func (n *NATS) Close() error {
+	err := n.conn.FlushTimeout(flushTimeout)
+	if err == nil {
+		err = n.conn.LastError()
+	}
+	n.conn.Close()
+	if err != nil {
+		return fmt.Errorf("failed to flush messages to NATS subject %s: %w", n.subject, err)
+	}
+	return nil
+}
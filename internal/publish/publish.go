@@ -0,0 +1,164 @@
+// Package publish streams the states, events and alerts of a run to a Kafka topic or a NATS
+// subject as JSON, so data pipelines can consume simulation runs as they happen
+package publish
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// URI schemes of publish targets
+const (
+	SchemeKafka = "kafka"
+	SchemeNATS  = "nats"
+)
+
+// Publisher delivers messages to a topic or subject
+type Publisher interface {
+	// Publish delivers a message; messages with the same key are delivered in order
+	Publish(key string, value []byte) error
+	// Close delivers any buffered messages and releases the connection
+	Close() error
+}
+
+// Open returns the publisher of a target:
+//   - kafka://[user:password@]broker:9092[,broker:9092...]/topic, which connects over TLS if
+//     KAFKA_TLS is true and authenticates with the user and password by the SASL mechanism of
+//     KAFKA_SASL_MECHANISM, PLAIN by default
+//   - nats://[user:password@]host:4222[,host:4222...]/subject, which reads a credentials file
+//     from NATS_CREDS if it is set
+func Open(target string) (Publisher, error) {
+	return open(target, os.Getenv)
+}
+
+// open returns the publisher of a target, reading the environment with getenv
+func open(target string, getenv func(string) string) (Publisher, error) {
+	scheme, rest, ok := strings.Cut(target, "://")
+	if !ok {
+		return nil, fmt.Errorf("publish target %q is not a kafka:// or nats:// URI", target)
+	}
+	hosts, name, _ := strings.Cut(rest, "/")
+	if hosts == "" || name == "" {
+		return nil, fmt.Errorf("publish target %q needs hosts and a topic or subject", target)
+	}
+
+	userInfo, hostList, found := strings.Cut(hosts, "@")
+	if !found {
+		userInfo, hostList = "", hosts
+	}
+	switch scheme {
+	case SchemeKafka:
+		var options KafkaOptions
+		if useTLS, _ := strconv.ParseBool(getenv("KAFKA_TLS")); useTLS {
+			options.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if userInfo != "" {
+			options.User, options.Password, _ = strings.Cut(userInfo, ":")
+			options.SASL = getenv("KAFKA_SASL_MECHANISM")
+			if options.SASL == "" {
+				options.SASL = SASLPlain
+			}
+		}
+		return DialKafka(strings.Split(hostList, ","), name, options)
+	case SchemeNATS:
+		// Credentials in the user info apply to every server, as nats.Connect expects them
+		var servers []string
+		for _, host := range strings.Split(hostList, ",") {
+			if userInfo != "" {
+				host = userInfo + "@" + host
+			}
+			servers = append(servers, SchemeNATS+"://"+host)
+		}
+		return DialNATS(strings.Join(servers, ","), name, getenv("NATS_CREDS"))
+	default:
+		return nil, fmt.Errorf("publish target %q is not a kafka:// or nats:// URI", target)
+	}
+}
+
+// Message types
+const (
+	TypeStep  = "step"
+	TypeAlert = "alert"
+)
+
+// Message is what is published for every time step and alert of a run
+type Message struct {
+	Type     string // TypeStep or TypeAlert
+	RunID    string
+	TimeStep int
+	State    *types.SimulationState  `json:",omitempty"` // the state recorded at the time step
+	Events   []types.SimulationEvent `json:",omitempty"` // the events of the time step
+	Alert    *types.Alert            `json:",omitempty"`
+}
+
+// Stream publishes a run's states, events and alerts as JSON Messages keyed by its run ID, so
+// a partitioned topic keeps every run's messages in order
+// Add it as an observer of the run's controller (see controller.SimulationController.Observe).
+// A message that fails to publish does not stop the stream: later messages are still published,
+// and Err and Close report the failures
+type Stream struct {
+	publisher Publisher
+	runID     string
+	err       error // first failure
+	failed    int   // messages that failed to publish
+}
+
+// NewStream returns a stream publishing the run with the given ID to publisher
+func NewStream(publisher Publisher, runID string) *Stream {
+	return &Stream{publisher: publisher, runID: runID}
+}
+
+// OnStep publishes the state and events of a time step
+func (s *Stream) OnStep(state types.SimulationState, events []types.SimulationEvent) {
+	s.publish(Message{Type: TypeStep, RunID: s.runID, TimeStep: state.TimeStep, State: &state, Events: events})
+}
+
+// OnAlert publishes an alert
+func (s *Stream) OnAlert(alert types.Alert) {
+	s.publish(Message{Type: TypeAlert, RunID: s.runID, TimeStep: alert.TimeStep, Alert: &alert})
+}
+
+// publish publishes a message, recording a failure
+func (s *Stream) publish(message Message) {
+	value, err := json.Marshal(message)
+	if err != nil {
+		s.fail(fmt.Errorf("failed to encode %s message of time step %d: %w", message.Type, message.TimeStep, err))
+		return
+	}
+	if err := s.publisher.Publish(s.runID, value); err != nil {
+		s.fail(fmt.Errorf("failed to publish %s message of time step %d: %w", message.Type, message.TimeStep, err))
+	}
+}
+
+// fail records a message that failed to publish
+func (s *Stream) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+	s.failed++
+}
+
+// Err returns an error counting the messages that have failed to publish so far and wrapping
+// the first failure, or nil if none has
+// Like the observer methods, it must be called from the goroutine stepping the run
+func (s *Stream) Err() error {
+	if s.err == nil {
+		return nil
+	}
+	return fmt.Errorf("%d messages of run %s not published: %w", s.failed, s.runID, s.err)
+}
+
+// Close closes the publisher, returning Err if any message failed to publish, or else the
+// publisher's error, such as messages it could not deliver
+func (s *Stream) Close() error {
+	err := s.publisher.Close()
+	if s.err != nil {
+		return s.Err()
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package publish
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// recorder is a publisher keeping what it is given, failing failures messages from the
+// failAt-th if set
+type recorder struct {
+	keys     []string
+	messages []Message
+	failAt   int
+	failures int
+	closed   bool
+}
+
+func (r *recorder) Publish(key string, value []byte) error {
+	if r.failAt > 0 && len(r.messages)+1 >= r.failAt && r.failures > 0 {
+		r.failures--
+		return errors.New("broker unavailable")
+	}
+	var message Message
+	if err := json.Unmarshal(value, &message); err != nil {
+		return err
+	}
+	r.keys = append(r.keys, key)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *recorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestStreamPublishesStepsAndAlerts(t *testing.T) {
+	publisher := &recorder{}
+	stream := NewStream(publisher, "run-1")
+	stream.OnAlert(types.Alert{TimeStep: 3, Metric: "CashBalance", Message: "cash low"})
+	stream.OnStep(types.SimulationState{TimeStep: 3, RevenueOutput: 10}, []types.SimulationEvent{{TimeStep: 3, Description: "hired"}})
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if !publisher.closed {
+		t.Error("Expected Close to close the publisher")
+	}
+	if len(publisher.messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(publisher.messages))
+	}
+	alert, step := publisher.messages[0], publisher.messages[1]
+	if alert.Type != TypeAlert || alert.Alert == nil || alert.Alert.Message != "cash low" || alert.State != nil {
+		t.Errorf("Unexpected alert message %+v", alert)
+	}
+	if step.Type != TypeStep || step.RunID != "run-1" || step.TimeStep != 3 || step.State == nil || step.State.RevenueOutput != 10 {
+		t.Errorf("Unexpected step message %+v", step)
+	}
+	if len(step.Events) != 1 || step.Events[0].Description != "hired" {
+		t.Errorf("Expected the step's events, got %+v", step.Events)
+	}
+	for _, key := range publisher.keys {
+		if key != "run-1" {
+			t.Errorf("Expected messages keyed by run ID, got %q", key)
+		}
+	}
+}
+
+func TestStreamKeepsPublishingAfterAnError(t *testing.T) {
+	publisher := &recorder{failAt: 2, failures: 1}
+	stream := NewStream(publisher, "run-1")
+	for step := 0; step < 4; step++ {
+		stream.OnStep(types.SimulationState{TimeStep: step}, nil)
+	}
+	if err := stream.Err(); err == nil || !strings.Contains(err.Error(), "time step 1") {
+		t.Errorf("Expected the error of time step 1 before closing, got %v", err)
+	}
+	err := stream.Close()
+	if err == nil || !strings.Contains(err.Error(), "1 messages") || !strings.Contains(err.Error(), "time step 1") {
+		t.Errorf("Expected one failed message at time step 1, got %v", err)
+	}
+	if len(publisher.messages) != 3 || publisher.messages[2].TimeStep != 3 {
+		t.Errorf("Expected the later steps published after the error, got %d messages", len(publisher.messages))
+	}
+}
+
+func TestOpenRejectsInvalidTargets(t *testing.T) {
+	getenv := func(string) string { return "" }
+	for _, target := range []string{"localhost:9092/runs", "kafka://localhost:9092", "kafka:///runs", "amqp://localhost/runs"} {
+		if _, err := open(target, getenv); err == nil {
+			t.Errorf("Expected an error for %q", target)
+		}
+	}
+	publisher, err := open("kafka://a:9092,b:9092/runs", getenv)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer publisher.Close()
+	kafka, ok := publisher.(*Kafka)
+	if !ok || kafka.Topic != "runs" || len(kafka.Brokers) != 2 || kafka.Brokers[1] != "b:9092" {
+		t.Errorf("Unexpected Kafka producer %+v", publisher)
+	}
+}
+
+func TestNATSPublish(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	published := make(chan string, 4)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"version\":\"2.10.0\",\"proto\":1,\"max_payload\":1048576}\r\n")
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) == 0:
+			case fields[0] == "PING":
+				io.WriteString(conn, "PONG\r\n")
+			case fields[0] == "PUB" && len(fields) == 3:
+				size, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				published <- fields[1] + " " + string(payload[:size])
+			}
+		}
+	}()
+
+	publisher, err := open("nats://"+listener.Addr().String()+"/simulation.runs", func(string) string { return "" })
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if err := publisher.Publish("run-1", []byte(`{"Type":"step"}`)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if got := <-published; got != `simulation.runs {"Type":"step"}` {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+package random
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// RNG is the source of randomness used by simulation components
+// *rand.Rand satisfies this interface, so tests can substitute any standard generator
+type RNG interface {
+	Float64() float64
+	Intn(n int) int
+	NormFloat64() float64
+	Shuffle(n int, swap func(i, j int))
+}
+
+// Names of the independent random streams used by the simulation subsystems
+const (
+	StreamAttrition    = "attrition"
+	StreamFailures     = "failures"
+	StreamFailureClass = "failure_class"
+	StreamOptimization = "optimization"
+	StreamBackfill     = "backfill"
+	StreamRevenue      = "revenue"
+)
+
+// Source is a splitmix64 generator implementing rand.Source64
+// Unlike the standard library sources its complete state is a single uint64,
+// which makes it cheap to derive, copy and persist
+type Source struct {
+	state uint64
+}
+
+// NewSource creates a new Source seeded with the given value
+func NewSource(seed int64) *Source {
+	return &Source{state: uint64(seed)}
+}
+
+// Seed resets the source to the given seed
+func (s *Source) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// Uint64 returns the next pseudo-random 64-bit value
+func (s *Source) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	return mix64(s.state)
+}
+
+// Int63 returns the next pseudo-random non-negative 63-bit value
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// State returns the current internal state of the source
+func (s *Source) State() uint64 {
+	return s.state
+}
+
+// SetState restores a state previously returned by State
+func (s *Source) SetState(state uint64) {
+	s.state = state
+}
+
+// mix64 is the splitmix64 output finalizer
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// DeriveSeed derives the seed of a named stream from the master seed
+// The derivation is stable across runs and platforms, so a stream's sequence depends
+// only on the master seed and the stream name
+func DeriveSeed(masterSeed int64, name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(mix64(uint64(masterSeed) ^ mix64(h.Sum64())))
+}
+
+// Stream is a named, independently seeded random stream
+type Stream struct {
+	*rand.Rand
+	source *Source
+}
+
+// Source returns the underlying source of the stream
+func (s *Stream) Source() *Source {
+	return s.source
+}
+
+// Streams hands out independent deterministic random streams derived from a master seed
+// Each subsystem draws from its own stream, so adding or removing random draws in one
+// subsystem does not perturb the random sequence seen by any other subsystem
+type Streams struct {
+	masterSeed int64
+	streams    map[string]*Stream
+}
+
+// NewStreams creates a new set of streams derived from the master seed
+func NewStreams(masterSeed int64) *Streams {
+	return &Streams{
+		masterSeed: masterSeed,
+		streams:    make(map[string]*Stream),
+	}
+}
+
+// MasterSeed returns the seed all streams are derived from
+func (s *Streams) MasterSeed() int64 {
+	return s.masterSeed
+}
+
+// Get returns the stream with the given name, creating it on first use
+// Repeated calls with the same name return the same stream
+func (s *Streams) Get(name string) *Stream {
+	if stream, exists := s.streams[name]; exists {
+		return stream
+	}
+
+	source := NewSource(DeriveSeed(s.masterSeed, name))
+	stream := &Stream{
+		Rand:   rand.New(source),
+		source: source,
+	}
+	s.streams[name] = stream
+	return stream
+}
+
+// States returns the current state of every stream created so far, keyed by stream name
+func (s *Streams) States() map[string]uint64 {
+	states := make(map[string]uint64, len(s.streams))
+	for name, stream := range s.streams {
+		states[name] = stream.source.State()
+	}
+	return states
+}
+
+// Restore sets the state of each named stream, creating streams that do not exist yet
+// Streams not mentioned in states are left unchanged
+func (s *Streams) Restore(states map[string]uint64) {
+	for name, state := range states {
+		s.Get(name).source.SetState(state)
+	}
+}
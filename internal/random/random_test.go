@@ -0,0 +1,77 @@
+package random
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStreamsAreDeterministic(t *testing.T) {
+	a := NewStreams(42)
+	b := NewStreams(42)
+
+	for i := 0; i < 100; i++ {
+		if a.Get(StreamAttrition).Float64() != b.Get(StreamAttrition).Float64() {
+			t.Fatalf("Expected identical sequences for the same seed at draw %d", i)
+		}
+	}
+}
+
+func TestStreamsAreIndependent(t *testing.T) {
+	a := NewStreams(42)
+	b := NewStreams(42)
+
+	// Extra draws on one stream must not shift any other stream
+	for i := 0; i < 17; i++ {
+		a.Get(StreamOptimization).Float64()
+	}
+
+	for i := 0; i < 100; i++ {
+		if a.Get(StreamFailures).Float64() != b.Get(StreamFailures).Float64() {
+			t.Fatalf("Expected failure stream to be unaffected by optimization draws at draw %d", i)
+		}
+	}
+
+	if a.Get(StreamAttrition).Float64() == a.Get(StreamFailures).Float64() {
+		t.Error("Expected different streams to produce different values")
+	}
+}
+
+func TestGetReturnsSameStream(t *testing.T) {
+	s := NewStreams(7)
+	if s.Get(StreamAttrition) != s.Get(StreamAttrition) {
+		t.Error("Expected repeated Get calls to return the same stream")
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	if DeriveSeed(1, StreamAttrition) != DeriveSeed(1, StreamAttrition) {
+		t.Error("Expected derived seeds to be stable")
+	}
+	if DeriveSeed(1, StreamAttrition) == DeriveSeed(2, StreamAttrition) {
+		t.Error("Expected different master seeds to derive different stream seeds")
+	}
+	if DeriveSeed(1, StreamAttrition) == DeriveSeed(1, StreamFailures) {
+		t.Error("Expected different stream names to derive different seeds")
+	}
+}
+
+func TestSourceStateRoundTrip(t *testing.T) {
+	source := NewSource(99)
+	r := rand.New(source)
+	r.Float64()
+
+	saved := source.State()
+	expected := r.Float64()
+
+	source.SetState(saved)
+	if got := r.Float64(); got != expected {
+		t.Errorf("Expected restored source to replay %f, got %f", expected, got)
+	}
+}
+
+func TestRandSatisfiesRNG(t *testing.T) {
+	var rng RNG = rand.New(rand.NewSource(1))
+	if v := rng.Intn(10); v < 0 || v >= 10 {
+		t.Errorf("Expected value in [0, 10), got %d", v)
+	}
+}
@@ -0,0 +1,136 @@
+// Package scenarios provides a library of ready-made simulation configurations
+// The templates give new users a realistic starting point and give analyses a shared set of
+// named baselines to compare against
+package scenarios
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Scenario is a named, ready-made simulation configuration
+type Scenario struct {
+	Name        string
+	Description string
+	Config      types.SimulationConfig
+}
+
+// library holds the scenario templates by name
+var library = map[string]Scenario{
+	"aggressive-automation": {
+		Name:        "aggressive-automation",
+		Description: "Mid-sized team that freezes human hiring and invests in fast-learning AI agents",
+		Config: types.SimulationConfig{
+			Version:                  types.ConfigVersion,
+			InitialHumans:            50,
+			ExperienceDistribution:   types.ExperienceDistribution{UniversityHire: 35, MidLevel: 35, Senior: 25, Executive: 5},
+			CostCategoryDistribution: types.CostCategoryDistribution{HighCostUS: 75, LowCostNonUS: 25},
+			FixedBudget:              10000000,
+			RevenueScenario:          types.FlatRevenue,
+			AILearningSpeeds:         types.AILearningSpeed{UniversityToMid: 5, MidToSenior: 10, SeniorToExecutive: 15},
+			SharedLearning:           types.SharedLearningConfig{Acceleration: 0.1},
+			AttritionConfig: types.AttritionConfig{
+				Type:               types.NaturalAttrition,
+				NaturalRate:        12,
+				ForcedAcceleration: 1.5,
+				FreezeHumanHiring:  true,
+			},
+			CatastrophicFailureRate: 0.02,
+			TimeZoneInefficiency:    0.15,
+		},
+	},
+	"hiring-freeze": {
+		Name:        "hiring-freeze",
+		Description: "Established team under a full hiring freeze, shrinking through natural attrition alone",
+		Config: types.SimulationConfig{
+			Version:                  types.ConfigVersion,
+			InitialHumans:            100,
+			ExperienceDistribution:   types.ExperienceDistribution{UniversityHire: 30, MidLevel: 35, Senior: 28, Executive: 7},
+			CostCategoryDistribution: types.CostCategoryDistribution{HighCostUS: 80, LowCostNonUS: 20},
+			FixedBudget:              15000000,
+			RevenueScenario:          types.FlatRevenue,
+			AILearningSpeeds:         types.AILearningSpeed{UniversityToMid: 12, MidToSenior: 20, SeniorToExecutive: 30},
+			AttritionConfig: types.AttritionConfig{
+				Type:               types.HiringFreeze,
+				NaturalRate:        10,
+				ForcedAcceleration: 1,
+				FreezeAIHiring:     true,
+			},
+			CatastrophicFailureRate: 0.015,
+			TimeZoneInefficiency:    0.1,
+		},
+	},
+	"explosive-growth-startup": {
+		Name:        "explosive-growth-startup",
+		Description: "Small, junior-heavy startup with explosive revenue growth and a tight budget",
+		Config: types.SimulationConfig{
+			Version:                  types.ConfigVersion,
+			InitialHumans:            10,
+			ExperienceDistribution:   types.ExperienceDistribution{UniversityHire: 50, MidLevel: 25, Senior: 20, Executive: 5},
+			CostCategoryDistribution: types.CostCategoryDistribution{HighCostUS: 70, LowCostNonUS: 30},
+			FixedBudget:              1800000,
+			RevenueScenario:          types.ExplosiveGrowth,
+			AILearningSpeeds:         types.AILearningSpeed{UniversityToMid: 8, MidToSenior: 15, SeniorToExecutive: 25},
+			AttritionConfig: types.AttritionConfig{
+				Type:               types.NaturalAttrition,
+				NaturalRate:        8,
+				ForcedAcceleration: 1,
+			},
+			CatastrophicFailureRate: 0.02,
+			TimeZoneInefficiency:    0.1,
+		},
+	},
+	"regulated-enterprise": {
+		Name:        "regulated-enterprise",
+		Description: "Large enterprise with mandated human oversight of AI agents, full employer overhead and low attrition",
+		Config: types.SimulationConfig{
+			Version:                  types.ConfigVersion,
+			InitialHumans:            150,
+			ExperienceDistribution:   types.ExperienceDistribution{UniversityHire: 30, MidLevel: 35, Senior: 30, Executive: 5},
+			CostCategoryDistribution: types.CostCategoryDistribution{HighCostUS: 85, LowCostNonUS: 15},
+			Overhead:                 types.OverheadConfig{Employer: 1.3, AIInfrastructure: 1.2},
+			FixedBudget:              45000000,
+			RevenueScenario:          types.FlatRevenue,
+			AILearningSpeeds:         types.AILearningSpeed{UniversityToMid: 20, MidToSenior: 35, SeniorToExecutive: 50},
+			Oversight:                types.OversightConfig{MinHumans: 1, PerAgents: 3},
+			AttritionConfig: types.AttritionConfig{
+				Type:               types.NaturalAttrition,
+				NaturalRate:        5,
+				ForcedAcceleration: 1,
+			},
+			CatastrophicFailureRate: 0.01,
+			TimeZoneInefficiency:    0.1,
+		},
+	},
+}
+
+// Names returns the names of all scenario templates in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(library))
+	for name := range library {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns every scenario template, ordered by name
+func All() []Scenario {
+	all := make([]Scenario, 0, len(library))
+	for _, name := range Names() {
+		all = append(all, library[name])
+	}
+	return all
+}
+
+// Get returns the scenario template with the given name
+// Returns an error wrapping types.ErrNotFound that lists the available names if there is none
+func Get(name string) (Scenario, error) {
+	scenario, ok := library[name]
+	if !ok {
+		return Scenario{}, fmt.Errorf("scenario %q: %w (available: %s)", name, types.ErrNotFound, strings.Join(Names(), ", "))
+	}
+	return scenario, nil
+}
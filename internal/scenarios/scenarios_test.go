@@ -0,0 +1,37 @@
+package scenarios
+
+import (
+	"errors"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestScenariosAreValid(t *testing.T) {
+	all := All()
+	if len(all) != len(Names()) || len(all) == 0 {
+		t.Fatalf("Expected one scenario per name, got %d scenarios and %d names", len(all), len(Names()))
+	}
+
+	for _, scenario := range all {
+		if scenario.Description == "" {
+			t.Errorf("Scenario %s has no description", scenario.Name)
+		}
+		if err := scenario.Config.Validate(); err != nil {
+			t.Errorf("Scenario %s is invalid: %v", scenario.Name, err)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	scenario, err := Get("regulated-enterprise")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if scenario.Name != "regulated-enterprise" || !scenario.Config.Oversight.Enabled() {
+		t.Errorf("Expected the regulated enterprise to require human oversight, got %+v", scenario)
+	}
+
+	if _, err := Get("no-such-scenario"); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown scenario, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the rate limit of API keys that do not set one
+const DefaultRequestsPerMinute = 60
+
+// KeyHeader carries an API key, as an alternative to an Authorization: Bearer header
+const KeyHeader = "X-Api-Key"
+
+// APIKey is an entry of a keys file
+type APIKey struct {
+	Key               string // secret presented by clients
+	User              string // owner of the jobs submitted with the key
+	Admin             bool   `json:",omitempty"` // sees and cancels the jobs of every user
+	RequestsPerMinute int    `json:",omitempty"` // sustained rate; DefaultRequestsPerMinute if zero
+	Burst             int    `json:",omitempty"` // requests allowed at once; RequestsPerMinute if zero
+}
+
+// identity is the authenticated caller of a request
+type identity struct {
+	user  string
+	admin bool
+}
+
+// identityKey is the context key of a request's identity
+type identityKey struct{}
+
+// Keys authenticates requests by API key and limits the rate of each key's requests
+// Keys are looked up by their SHA-256 hash, so the time a lookup takes does not depend on how
+// much of a guessed key is right
+type Keys struct {
+	keys map[[sha256.Size]byte]*keyState
+}
+
+// keyState is an API key with the token bucket limiting its requests
+type keyState struct {
+	identity identity
+	rate     float64 // tokens added per second
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// LoadKeys reads a keys file: a JSON array of APIKey entries
+func LoadKeys(path string) (*Keys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys file: %w", err)
+	}
+	var entries []APIKey
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid keys file %s: %w", path, err)
+	}
+	return NewKeys(entries)
+}
+
+// NewKeys returns the keys of entries, which must each have a distinct key and a user
+func NewKeys(entries []APIKey) (*Keys, error) {
+	k := &Keys{keys: make(map[[sha256.Size]byte]*keyState, len(entries))}
+	for i, entry := range entries {
+		switch {
+		case entry.Key == "":
+			return nil, fmt.Errorf("key %d has no Key", i+1)
+		case entry.User == "":
+			return nil, fmt.Errorf("key %d has no User", i+1)
+		case entry.RequestsPerMinute < 0 || entry.Burst < 0:
+			return nil, fmt.Errorf("key %d of %s has a negative rate limit", i+1, entry.User)
+		}
+		hash := sha256.Sum256([]byte(entry.Key))
+		if _, ok := k.keys[hash]; ok {
+			return nil, fmt.Errorf("key %d of %s is listed twice", i+1, entry.User)
+		}
+		perMinute := entry.RequestsPerMinute
+		if perMinute == 0 {
+			perMinute = DefaultRequestsPerMinute
+		}
+		burst := entry.Burst
+		if burst == 0 {
+			burst = perMinute
+		}
+		k.keys[hash] = &keyState{
+			identity: identity{user: entry.User, admin: entry.Admin},
+			rate:     float64(perMinute) / 60,
+			burst:    float64(burst),
+			tokens:   float64(burst),
+		}
+	}
+	return k, nil
+}
+
+// Middleware serves requests carrying a known key within its rate limit with next, answering
+// others with 401 Unauthorized or 429 Too Many Requests
+func (k *Keys) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			key = bearer
+		}
+		state, ok := k.keys[sha256.Sum256([]byte(key))]
+		if key == "" || !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="wfesim"`)
+			http.Error(w, "missing or invalid API key", http.StatusUnauthorized)
+			return
+		}
+		if wait := state.take(time.Now()); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, state.identity)))
+	})
+}
+
+// take spends a token of the key's bucket, returning how long until one is available if none is
+func (s *keyState) take(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.last.IsZero() {
+		s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	}
+	s.last = now
+	if s.tokens < 1 {
+		return time.Duration((1 - s.tokens) / s.rate * float64(time.Second))
+	}
+	s.tokens--
+	return 0
+}
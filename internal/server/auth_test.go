@@ -0,0 +1,174 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// keyedRequest sends a request with an API key to a test server, decoding a JSON response into
+// out if set
+func keyedRequest(t *testing.T, server *httptest.Server, method, path, key string, body string, out any) *http.Response {
+	r, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if key != "" {
+		r.Header.Set("Authorization", "Bearer "+key)
+	}
+	response, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	if out != nil && response.StatusCode/100 == 2 {
+		if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return response
+}
+
+func newKeyedServer(t *testing.T, entries []APIKey) *httptest.Server {
+	keys, err := NewKeys(entries)
+	if err != nil {
+		t.Fatalf("NewKeys failed: %v", err)
+	}
+	scheduler := NewScheduler(1, 1)
+	api := New(scheduler)
+	api.SetKeys(keys)
+	server := httptest.NewServer(api)
+	t.Cleanup(func() {
+		server.Close()
+		scheduler.Close()
+	})
+	return server
+}
+
+func TestKeysRequireAValidKey(t *testing.T) {
+	server := newKeyedServer(t, []APIKey{{Key: "alice-key", User: "alice"}})
+
+	for _, key := range []string{"", "wrong"} {
+		if response := keyedRequest(t, server, http.MethodGet, "/v1/jobs", key, "", nil); response.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected key %q to be rejected, got %s", key, response.Status)
+		}
+	}
+	if response := keyedRequest(t, server, http.MethodGet, "/v1/jobs", "alice-key", "", nil); response.StatusCode != http.StatusOK {
+		t.Errorf("Expected a valid bearer key to be accepted, got %s", response.Status)
+	}
+
+	r, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/jobs", nil)
+	r.Header.Set(KeyHeader, "alice-key")
+	response, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Expected a valid %s to be accepted, got %s", KeyHeader, response.Status)
+	}
+}
+
+func TestKeysOwnJobs(t *testing.T) {
+	server := newKeyedServer(t, []APIKey{
+		{Key: "alice-key", User: "alice"},
+		{Key: "bob-key", User: "bob"},
+		{Key: "admin-key", User: "ops", Admin: true},
+	})
+
+	var job Job
+	// The user header cannot impersonate another user once keys are required
+	request := `{"Kind": "simulation", "Scenario": "hiring-freeze", "MaxTimeSteps": 20}`
+	if response := keyedRequest(t, server, http.MethodPost, "/v1/jobs", "alice-key", request, &job); response.StatusCode != http.StatusAccepted || job.User != "alice" {
+		t.Fatalf("Unexpected submission response %s %+v", response.Status, job)
+	}
+
+	for _, path := range []string{"/v1/jobs/" + job.ID, "/v1/jobs/" + job.ID + "/result"} {
+		if response := keyedRequest(t, server, http.MethodGet, path, "bob-key", "", nil); response.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected alice's job to be hidden from bob at %s, got %s", path, response.Status)
+		}
+	}
+	if response := keyedRequest(t, server, http.MethodDelete, "/v1/jobs/"+job.ID, "bob-key", "", nil); response.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected bob not to cancel alice's job, got %s", response.Status)
+	}
+	var jobs []Job
+	keyedRequest(t, server, http.MethodGet, "/v1/jobs", "bob-key", "", &jobs)
+	if len(jobs) != 0 {
+		t.Errorf("Expected bob to list no jobs, got %+v", jobs)
+	}
+	keyedRequest(t, server, http.MethodGet, "/v1/jobs", "admin-key", "", &jobs)
+	if len(jobs) != 1 {
+		t.Errorf("Expected the admin to list alice's job, got %+v", jobs)
+	}
+	if response := keyedRequest(t, server, http.MethodGet, "/v1/jobs/"+job.ID, "alice-key", "", nil); response.StatusCode != http.StatusOK {
+		t.Errorf("Expected alice to see her job, got %s", response.Status)
+	}
+}
+
+func TestKeysLimitRate(t *testing.T) {
+	server := newKeyedServer(t, []APIKey{
+		{Key: "limited", User: "alice", RequestsPerMinute: 1, Burst: 2},
+		{Key: "other", User: "bob", RequestsPerMinute: 1, Burst: 1},
+	})
+
+	for i := 0; i < 2; i++ {
+		if response := keyedRequest(t, server, http.MethodGet, "/v1/jobs", "limited", "", nil); response.StatusCode != http.StatusOK {
+			t.Fatalf("Expected request %d within the burst to pass, got %s", i+1, response.Status)
+		}
+	}
+	response := keyedRequest(t, server, http.MethodGet, "/v1/jobs", "limited", "", nil)
+	if response.StatusCode != http.StatusTooManyRequests || response.Header.Get("Retry-After") == "" {
+		t.Errorf("Expected the request beyond the burst to be limited with a Retry-After, got %s", response.Status)
+	}
+	// Limits are per key
+	if response := keyedRequest(t, server, http.MethodGet, "/v1/jobs", "other", "", nil); response.StatusCode != http.StatusOK {
+		t.Errorf("Expected another key to be unaffected, got %s", response.Status)
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	state := &keyState{rate: 1, burst: 2, tokens: 2}
+	start := time.Now()
+	if state.take(start) != 0 || state.take(start) != 0 {
+		t.Fatal("Expected the burst to be available")
+	}
+	if wait := state.take(start); wait != time.Second {
+		t.Errorf("Expected to wait a second for the next token, got %s", wait)
+	}
+	if wait := state.take(start.Add(time.Second)); wait != 0 {
+		t.Errorf("Expected a token after a second, got a wait of %s", wait)
+	}
+	if state.take(start.Add(time.Hour)); state.tokens != 1 {
+		t.Errorf("Expected the bucket to refill only up to its burst, got %g tokens after taking one", state.tokens)
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys.json")
+	for _, invalid := range []string{
+		`[{"Key": "k"}]`,
+		`[{"User": "alice"}]`,
+		`[{"Key": "k", "User": "alice"}, {"Key": "k", "User": "bob"}]`,
+		`[{"Key": "k", "User": "alice", "RequestsPerMinute": -1}]`,
+		`[{"Key": "k", "User": "alice", "Role": "admin"}]`,
+	} {
+		os.WriteFile(path, []byte(invalid), 0o600)
+		if _, err := LoadKeys(path); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+	os.WriteFile(path, []byte(`[{"Key": "k", "User": "alice", "Admin": true}]`), 0o600)
+	keys, err := LoadKeys(path)
+	if err != nil {
+		t.Fatalf("LoadKeys failed: %v", err)
+	}
+	if len(keys.keys) != 1 {
+		t.Errorf("Expected 1 key, got %d", len(keys.keys))
+	}
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults of Schedulers
+const (
+	DefaultUserLimit = 2
+	DefaultRetain    = 1000
+)
+
+// Errors of job operations
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobFinished = errors.New("job already finished")
+)
+
+// JobStatus is the stage of a job's life
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Finished reports whether a job in this status will not change any more
+func (s JobStatus) Finished() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// RunFunc does the work of a job, returning its result
+// It must return soon after ctx is cancelled
+type RunFunc func(ctx context.Context) (any, error)
+
+// Job describes a submitted job
+type Job struct {
+	ID          string
+	Kind        string
+	User        string
+	Priority    int
+	Status      JobStatus
+	Position    int    `json:",omitempty"` // place in the queue of a queued job, 1 being next
+	Error       string `json:",omitempty"`
+	SubmittedAt time.Time
+	StartedAt   *time.Time `json:",omitempty"`
+	FinishedAt  *time.Time `json:",omitempty"`
+}
+
+// job is a submitted job with its work and outcome
+type job struct {
+	Job
+	run       RunFunc
+	cancel    context.CancelFunc // cancels a running job
+	cancelled bool               // cancellation was requested while running
+	result    any
+}
+
+// Scheduler runs submitted jobs in the background, up to a number of workers at once and fewer
+// of any one user, so a user submitting a large batch does not hold up the others
+// Queued jobs start in order of decreasing priority, then of submission; a job that its user's
+// limit keeps waiting does not hold up the jobs of other users behind it
+type Scheduler struct {
+	workers   int
+	userLimit int
+	retain    int
+
+	mu       sync.Mutex
+	nextID   int
+	jobs     map[string]*job
+	order    []*job // every job kept, in order of submission
+	queue    []*job // queued jobs, in the order they start
+	running  map[string]int
+	active   int
+	closed   bool
+	done     sync.WaitGroup
+	finished []*job // finished jobs kept, oldest first
+}
+
+// NewScheduler returns a scheduler running up to workers jobs at once, and up to userLimit jobs
+// of the same user; it keeps the last DefaultRetain finished jobs
+func NewScheduler(workers, userLimit int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	if userLimit < 1 {
+		userLimit = workers
+	}
+	return &Scheduler{
+		workers:   workers,
+		userLimit: userLimit,
+		retain:    DefaultRetain,
+		jobs:      make(map[string]*job),
+		running:   make(map[string]int),
+	}
+}
+
+// Submit queues a job of user and starts it if a worker is free
+func (s *Scheduler) Submit(user, kind string, priority int, run RunFunc) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	j := &job{
+		Job: Job{
+			ID:          strconv.Itoa(s.nextID),
+			Kind:        kind,
+			User:        user,
+			Priority:    priority,
+			Status:      JobQueued,
+			SubmittedAt: time.Now().UTC(),
+		},
+		run: run,
+	}
+	s.jobs[j.ID] = j
+	s.order = append(s.order, j)
+	if s.closed {
+		s.finishLocked(j, JobCancelled, "scheduler closed")
+		return j.Job
+	}
+
+	// Behind every job of the same or higher priority
+	position := len(s.queue)
+	for position > 0 && s.queue[position-1].Priority < priority {
+		position--
+	}
+	s.queue = append(s.queue[:position], append([]*job{j}, s.queue[position:]...)...)
+	s.dispatchLocked()
+	return s.snapshotLocked(j)
+}
+
+// Get returns a job
+func (s *Scheduler) Get(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return s.snapshotLocked(j), true
+}
+
+// Result returns a job and, once it has succeeded, its result
+func (s *Scheduler) Result(id string) (Job, any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return Job{}, nil, false
+	}
+	return s.snapshotLocked(j), j.result, true
+}
+
+// List returns the jobs kept, in order of submission
+func (s *Scheduler) List() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]Job, len(s.order))
+	for i, j := range s.order {
+		jobs[i] = s.snapshotLocked(j)
+	}
+	return jobs
+}
+
+// Cancel removes a queued job from the queue, or stops a running one
+// A running job stays running until its work returns, which it does soon after
+func (s *Scheduler) Cancel(id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	switch j.Status {
+	case JobQueued:
+		s.removeQueuedLocked(j)
+		s.finishLocked(j, JobCancelled, "")
+	case JobRunning:
+		j.cancelled = true
+		j.cancel()
+	default:
+		return s.snapshotLocked(j), ErrJobFinished
+	}
+	return s.snapshotLocked(j), nil
+}
+
+// Close cancels every queued and running job and waits for the running ones to return
+// Jobs submitted afterwards are cancelled at once
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	for _, j := range s.queue {
+		s.finishLocked(j, JobCancelled, "scheduler closed")
+	}
+	s.queue = nil
+	for _, j := range s.jobs {
+		if j.Status == JobRunning {
+			j.cancelled = true
+			j.cancel()
+		}
+	}
+	s.mu.Unlock()
+	s.done.Wait()
+}
+
+// dispatchLocked starts queued jobs while workers are free
+func (s *Scheduler) dispatchLocked() {
+	for i := 0; i < len(s.queue) && s.active < s.workers; {
+		j := s.queue[i]
+		if s.running[j.User] >= s.userLimit {
+			i++
+			continue
+		}
+		s.queue = append(s.queue[:i], s.queue[i+1:]...)
+		s.startLocked(j)
+	}
+}
+
+// startLocked runs a job in the background
+func (s *Scheduler) startLocked(j *job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := time.Now().UTC()
+	j.Status, j.StartedAt, j.cancel = JobRunning, &started, cancel
+	s.active++
+	s.running[j.User]++
+	s.done.Add(1)
+
+	go func() {
+		defer s.done.Done()
+		result, err := j.run(ctx)
+		cancel()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.active--
+		if s.running[j.User]--; s.running[j.User] == 0 {
+			delete(s.running, j.User)
+		}
+		switch {
+		case j.cancelled:
+			s.finishLocked(j, JobCancelled, "")
+		case err != nil:
+			s.finishLocked(j, JobFailed, err.Error())
+		default:
+			j.result = result
+			s.finishLocked(j, JobSucceeded, "")
+		}
+		if !s.closed {
+			s.dispatchLocked()
+		}
+	}()
+}
+
+// finishLocked records the outcome of a job and drops the oldest finished jobs beyond retain
+func (s *Scheduler) finishLocked(j *job, status JobStatus, message string) {
+	finished := time.Now().UTC()
+	j.Status, j.Error, j.FinishedAt = status, message, &finished
+	j.run, j.cancel = nil, nil
+
+	s.finished = append(s.finished, j)
+	for len(s.finished) > s.retain {
+		dropped := s.finished[0]
+		s.finished = s.finished[1:]
+		delete(s.jobs, dropped.ID)
+		for i, kept := range s.order {
+			if kept == dropped {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// removeQueuedLocked takes a job off the queue
+func (s *Scheduler) removeQueuedLocked(j *job) {
+	for i, queued := range s.queue {
+		if queued == j {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// snapshotLocked returns a copy of a job's description with its place in the queue
+func (s *Scheduler) snapshotLocked(j *job) Job {
+	snapshot := j.Job
+	if j.Status == JobQueued {
+		for i, queued := range s.queue {
+			if queued == j {
+				snapshot.Position = i + 1
+				break
+			}
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gate is a job that runs until released or cancelled, recording the order jobs start in
+type gate struct {
+	mu      sync.Mutex
+	started []string
+	release chan struct{}
+}
+
+func newGate() *gate {
+	return &gate{release: make(chan struct{})}
+}
+
+func (g *gate) job(name string) RunFunc {
+	return func(ctx context.Context) (any, error) {
+		g.mu.Lock()
+		g.started = append(g.started, name)
+		g.mu.Unlock()
+		select {
+		case <-g.release:
+			return name, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (g *gate) order() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.started...)
+}
+
+// waitFor polls until a job reaches a status
+func waitFor(t *testing.T, s *Scheduler, id string, status JobStatus) Job {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, _ := s.Get(id)
+		if job.Status == status {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job %s is %s, expected %s", id, job.Status, status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerStartsByPriority(t *testing.T) {
+	s := NewScheduler(1, 1)
+	defer s.Close()
+	g := newGate()
+
+	first := s.Submit("alice", "test", 0, g.job("first"))
+	low := s.Submit("bob", "test", 0, g.job("low"))
+	high := s.Submit("carol", "test", 5, g.job("high"))
+	low, _ = s.Get(low.ID)
+	if high.Position != 1 || low.Position != 2 {
+		t.Errorf("Expected the high priority job to be next, got positions %d and %d", high.Position, low.Position)
+	}
+
+	close(g.release)
+	waitFor(t, s, first.ID, JobSucceeded)
+	waitFor(t, s, low.ID, JobSucceeded)
+	order := g.order()
+	if len(order) != 3 || order[1] != "high" || order[2] != "low" {
+		t.Errorf("Expected jobs to start by priority, got %v", order)
+	}
+	if _, result, _ := s.Result(high.ID); result != "high" {
+		t.Errorf("Expected the job's result, got %v", result)
+	}
+}
+
+func TestSchedulerLimitsJobsPerUser(t *testing.T) {
+	s := NewScheduler(3, 2)
+	defer s.Close()
+	g := newGate()
+
+	var alice []Job
+	for i := 0; i < 3; i++ {
+		alice = append(alice, s.Submit("alice", "test", 0, g.job("alice")))
+	}
+	bob := s.Submit("bob", "test", 0, g.job("bob"))
+
+	// The third job of alice waits for one of hers, without holding up bob's behind it
+	waitFor(t, s, bob.ID, JobRunning)
+	if job, _ := s.Get(alice[2].ID); job.Status != JobQueued {
+		t.Errorf("Expected alice's third job to wait for her limit, got %s", job.Status)
+	}
+	close(g.release)
+	waitFor(t, s, alice[2].ID, JobSucceeded)
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler(1, 1)
+	defer s.Close()
+	g := newGate()
+
+	running := s.Submit("alice", "test", 0, g.job("running"))
+	queued := s.Submit("bob", "test", 0, g.job("queued"))
+	waitFor(t, s, running.ID, JobRunning)
+
+	if job, err := s.Cancel(queued.ID); err != nil || job.Status != JobCancelled {
+		t.Errorf("Expected the queued job to be cancelled at once, got %s, %v", job.Status, err)
+	}
+	if _, err := s.Cancel(running.ID); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	job := waitFor(t, s, running.ID, JobCancelled)
+	if job.FinishedAt == nil {
+		t.Error("Expected a finish time")
+	}
+	if _, err := s.Cancel(running.ID); !errors.Is(err, ErrJobFinished) {
+		t.Errorf("Expected ErrJobFinished, got %v", err)
+	}
+	if _, err := s.Cancel("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+	if order := g.order(); len(order) != 1 {
+		t.Errorf("Expected the cancelled queued job never to start, got %v", order)
+	}
+}
+
+func TestSchedulerRecordsFailuresAndDropsOldJobs(t *testing.T) {
+	s := NewScheduler(1, 1)
+	defer s.Close()
+	s.retain = 2
+
+	failed := s.Submit("alice", "test", 0, func(context.Context) (any, error) { return nil, errors.New("boom") })
+	if job := waitFor(t, s, failed.ID, JobFailed); job.Error != "boom" {
+		t.Errorf("Expected the job's error, got %q", job.Error)
+	}
+	for i := 0; i < 2; i++ {
+		job := s.Submit("alice", "test", 0, func(context.Context) (any, error) { return i, nil })
+		waitFor(t, s, job.ID, JobSucceeded)
+	}
+	if _, ok := s.Get(failed.ID); ok {
+		t.Error("Expected the oldest finished job to be dropped")
+	}
+	if jobs := s.List(); len(jobs) != 2 {
+		t.Errorf("Expected 2 jobs kept, got %d", len(jobs))
+	}
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// OpenAPIPath is where a server serves the OpenAPI document of its API
+const OpenAPIPath = "/v1/openapi.json"
+
+// textMarshaler is implemented by the types encoded as JSON strings, such as enums and times
+var textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// OpenAPI returns the OpenAPI 3.1 document describing the endpoints of a server, with the API key
+// schemes a server with keys requires if authenticated is set
+// The schemas of request and response bodies are derived from the Go types the server encodes,
+// and that of configurations is the one of config.JSONSchema, so the document stays in step with
+// the server without being maintained by hand
+func OpenAPI(authenticated bool) ([]byte, error) {
+	g := &schemaGenerator{
+		components: map[string]interface{}{"SimulationConfig": config.Schema()},
+		names:      map[reflect.Type]string{reflect.TypeOf(types.SimulationConfig{}): "SimulationConfig"},
+	}
+
+	paths := make(map[string]interface{})
+	for _, r := range routes {
+		operations, ok := paths[r.path].(map[string]interface{})
+		if !ok {
+			operations = make(map[string]interface{})
+			paths[r.path] = operations
+		}
+		operations[strings.ToLower(r.method)] = g.operation(r, authenticated)
+	}
+	paths[OpenAPIPath] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "getOpenAPI",
+			"summary":     "This document",
+			"security":    []interface{}{},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OpenAPI document",
+					"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": map[string]interface{}{"type": "object"}}},
+				},
+			},
+		},
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":       "Workforce AI Transition Simulator API",
+			"version":     types.Version,
+			"description": "Runs simulations and sensitivity analyses as queued jobs",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": g.components},
+	}
+	if authenticated {
+		document["components"].(map[string]interface{})["securitySchemes"] = map[string]interface{}{
+			"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			"apiKey": map[string]interface{}{"type": "apiKey", "in": "header", "name": KeyHeader},
+		}
+		document["security"] = []interface{}{
+			map[string]interface{}{"bearer": []string{}},
+			map[string]interface{}{"apiKey": []string{}},
+		}
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// serveOpenAPI writes the OpenAPI document of the server
+func (s *Server) serveOpenAPI(w http.ResponseWriter) {
+	document, err := OpenAPI(s.authenticated)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(document)
+}
+
+// operation returns the OpenAPI operation of a route
+func (g *schemaGenerator) operation(r route, authenticated bool) map[string]interface{} {
+	var parameters []interface{}
+	for _, segment := range strings.Split(r.path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			parameters = append(parameters, map[string]interface{}{
+				"name": strings.TrimSuffix(name, "}"), "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	for _, q := range r.query {
+		schema := map[string]interface{}{"type": "string"}
+		if len(q.enum) > 0 {
+			schema["enum"] = q.enum
+		}
+		parameters = append(parameters, map[string]interface{}{"name": q.name, "in": "query", "description": q.description, "schema": schema})
+	}
+	if !authenticated {
+		// Without keys callers name themselves in a header
+		parameters = append(parameters, map[string]interface{}{
+			"name": UserHeader, "in": "header", "description": "User making the request; " + AnonymousUser + " if not set",
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+
+	var body map[string]interface{}
+	if len(r.responses) == 1 {
+		body = g.schema(r.responses[0])
+	} else {
+		alternatives := make([]interface{}, len(r.responses))
+		for i, t := range r.responses {
+			alternatives[i] = g.schema(t)
+		}
+		body = map[string]interface{}{"oneOf": alternatives}
+	}
+	responses := map[string]interface{}{
+		strconv.Itoa(r.status): map[string]interface{}{
+			"description": http.StatusText(r.status),
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": body}},
+		},
+	}
+	errors := r.errors
+	if authenticated {
+		errors = append(errors[:len(errors):len(errors)], http.StatusUnauthorized, http.StatusTooManyRequests)
+	}
+	for _, status := range errors {
+		responses[strconv.Itoa(status)] = map[string]interface{}{
+			"description": http.StatusText(status),
+			"content":     map[string]interface{}{"text/plain": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}},
+		}
+	}
+
+	operation := map[string]interface{}{
+		"operationId": operationID(r),
+		"summary":     r.summary,
+		"responses":   responses,
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	if r.request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": g.schema(r.request)}},
+		}
+	}
+	return operation
+}
+
+// operationID names the operation of a route after its handler's verb and resource, e.g.
+// listJobs or getJobResult, for generated clients to name their methods after
+func operationID(r route) string {
+	verbs := map[string]string{http.MethodGet: "get", http.MethodPost: "submit", http.MethodDelete: "cancel"}
+	verb := verbs[r.method]
+	var resource []string
+	for _, segment := range strings.Split(strings.TrimPrefix(r.path, "/v1/"), "/") {
+		if !strings.HasPrefix(segment, "{") {
+			resource = append(resource, strings.ToUpper(segment[:1])+segment[1:])
+		}
+	}
+	name := strings.Join(resource, "")
+	// Operations on a single job name it in the singular
+	if strings.Contains(r.path, "{id}") || r.method == http.MethodPost {
+		name = strings.Replace(name, "Jobs", "Job", 1)
+	} else if r.method == http.MethodGet {
+		verb = "list"
+	}
+	return verb + name
+}
+
+// schemaGenerator derives JSON Schemas from Go types as encoding/json encodes them, naming
+// every struct type as a component referenced wherever it is used
+type schemaGenerator struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// schema returns the schema of a type
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == reflect.TypeOf(json.RawMessage{}):
+		return map[string]interface{}{}
+	case t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return map[string]interface{}{"anyOf": []interface{}{g.schema(t.Elem()), map[string]interface{}{"type": "null"}}}
+	case reflect.Struct:
+		return map[string]interface{}{"$ref": "#/components/schemas/" + g.component(t)}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": g.schema(t.Elem())}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	default:
+		// Interfaces may hold any value
+		return map[string]interface{}{}
+	}
+}
+
+// component returns the name of the component of a struct type, adding it if it is new
+// Types of different packages with the same name are told apart by their package name
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.components[name]; taken || name == "" {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	g.names[t] = name
+	// Reserved before the fields are visited, so recursive types refer to themselves
+	g.components[name] = nil
+
+	properties := make(map[string]interface{})
+	g.addFields(t, properties)
+	g.components[name] = map[string]interface{}{"type": "object", "properties": properties}
+	return name
+}
+
+// addFields adds the schemas of the fields encoding/json encodes of a struct type to properties,
+// including those of embedded structs
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, properties)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.schema(field.Type)
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// refs collects the $ref values of a decoded JSON document
+func refs(value interface{}, found map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				found[ref] = true
+			}
+			refs(child, found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			refs(child, found)
+		}
+	}
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	data, err := OpenAPI(false)
+	if err != nil {
+		t.Fatalf("OpenAPI failed: %v", err)
+	}
+	var document struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components map[string]map[string]interface{}            `json:"components"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Invalid document: %v", err)
+	}
+	if document.OpenAPI != "3.1.0" {
+		t.Errorf("Unexpected OpenAPI version %q", document.OpenAPI)
+	}
+	for _, r := range routes {
+		if _, ok := document.Paths[r.path][strings.ToLower(r.method)]; !ok {
+			t.Errorf("Expected an operation for %s %s", r.method, r.path)
+		}
+	}
+	if _, ok := document.Components["securitySchemes"]; ok {
+		t.Error("Expected no security schemes without keys")
+	}
+
+	var decoded interface{}
+	json.Unmarshal(data, &decoded)
+	found := make(map[string]bool)
+	refs(decoded, found)
+	for ref := range found {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if !ok || document.Components["schemas"][name] == nil {
+			t.Errorf("Unresolved reference %s", ref)
+		}
+	}
+	for _, name := range []string{"JobRequest", "Job", "Report", "SensitivityReport", "SimulationConfig"} {
+		if !found["#/components/schemas/"+name] {
+			t.Errorf("Expected %s to be referenced", name)
+		}
+	}
+}
+
+func TestOpenAPIIsServedWithoutKey(t *testing.T) {
+	scheduler := NewScheduler(1, 1)
+	defer scheduler.Close()
+	s := New(scheduler)
+	keys, _ := NewKeys([]APIKey{{Key: "secret", User: "alice"}})
+	s.SetKeys(keys)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + OpenAPIPath)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer response.Body.Close()
+	var document struct {
+		Components struct{ SecuritySchemes map[string]interface{} } `json:"components"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&document); err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected response %d: %v", response.StatusCode, err)
+	}
+	if _, ok := document.Components.SecuritySchemes["apiKey"]; !ok {
+		t.Errorf("Expected the key scheme to be documented, got %+v", document.Components)
+	}
+	if status := request(t, server, http.MethodGet, "/v1/jobs", "", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("Expected other endpoints to still require a key, got %d", status)
+	}
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// pythonMethod is a method of the generated Python client, calling a route
+type pythonMethod struct {
+	Name       string
+	Summary    string
+	HTTPMethod string
+	Path       string   // Python expression building the path from the path parameters
+	Positional []string // path parameters
+	Query      []pythonArgument
+	Body       []pythonArgument // fields of the JSON body
+}
+
+// pythonArgument is a keyword argument of a method, sent as the named query parameter or field
+type pythonArgument struct {
+	Name string
+	Key  string
+}
+
+// PythonClient returns the source of a Python module calling the HTTP API, with a method per
+// endpoint and helpers that run a job to completion, so notebooks can drive a server with the
+// standard library alone
+// Like the OpenAPI document it is generated from the routes, so it cannot drift from the server
+func PythonClient() ([]byte, error) {
+	var methods []pythonMethod
+	for _, r := range routes {
+		m := pythonMethod{
+			Name:       snakeCase(operationID(r)),
+			Summary:    r.summary,
+			HTTPMethod: r.method,
+			Path:       fmt.Sprintf("%q", r.path),
+		}
+		var segments []string
+		for _, segment := range strings.Split(r.path, "/") {
+			if name, ok := strings.CutPrefix(segment, "{"); ok {
+				name = strings.TrimSuffix(name, "}")
+				m.Positional = append(m.Positional, snakeCase(name))
+				segments = append(segments, "{_quote("+snakeCase(name)+")}")
+				continue
+			}
+			segments = append(segments, segment)
+		}
+		if len(m.Positional) > 0 {
+			m.Path = fmt.Sprintf("f%q", strings.Join(segments, "/"))
+		}
+		for _, q := range r.query {
+			m.Query = append(m.Query, pythonArgument{Name: snakeCase(q.name), Key: q.name})
+		}
+		if r.request != nil {
+			for i := 0; i < r.request.NumField(); i++ {
+				field := r.request.Field(i)
+				m.Body = append(m.Body, pythonArgument{Name: snakeCase(field.Name), Key: field.Name})
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	var source bytes.Buffer
+	err := pythonTemplate.Execute(&source, map[string]interface{}{
+		"KeyHeader":     KeyHeader,
+		"UserHeader":    UserHeader,
+		"Methods":       methods,
+		"Simulation":    KindSimulation,
+		"Sensitivity":   KindSensitivity,
+		"Succeeded":     JobSucceeded,
+		"DefaultListen": "http://localhost:8080",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Python client: %w", err)
+	}
+	return source.Bytes(), nil
+}
+
+// snakeCase converts a Go or JSON name, such as MaxTimeSteps or getJobResult, to a Python one
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+var pythonTemplate = template.Must(template.New("python").Parse(`"""
+Workforce AI Transition Simulator - Python client
+
+Generated by ` + "`wfesim openapi -python`" + ` from the HTTP API of the server; do not edit.
+Requires only the Python standard library.
+
+Usage:
+    from wfesim_client import Client
+
+    client = Client("http://localhost:8080", api_key="...")
+    report = client.run_simulation(scenario="hiring-freeze", seed=42)
+    print(report["Summary"])
+"""
+
+import json
+import time
+import urllib.error
+import urllib.parse
+import urllib.request
+
+
+class WfesimError(Exception):
+    """An error response of the server."""
+
+    def __init__(self, status, message):
+        super().__init__(f"{status}: {message}")
+        self.status = status
+        self.message = message
+
+
+class JobFailed(Exception):
+    """A job that did not succeed."""
+
+    def __init__(self, job):
+        super().__init__(f"job {job['ID']} {job['Status']}: {job.get('Error', '')}")
+        self.job = job
+
+
+def _quote(value):
+    return urllib.parse.quote(str(value), safe="")
+
+
+class Client:
+    """Client of a wfesim server.
+
+    api_key authenticates requests to a server run with -keys; without keys, user names the
+    user jobs are submitted as.
+    """
+
+    def __init__(self, base_url="{{.DefaultListen}}", api_key=None, user=None, timeout=60):
+        self.base_url = base_url.rstrip("/")
+        self.api_key = api_key
+        self.user = user
+        self.timeout = timeout
+
+    def _request(self, method, path, query=None, body=None):
+        url = self.base_url + path
+        query = {k: v for k, v in (query or {}).items() if v is not None}
+        if query:
+            url += "?" + urllib.parse.urlencode(query)
+        headers = {"Accept": "application/json"}
+        data = None
+        if body is not None:
+            data = json.dumps({k: v for k, v in body.items() if v is not None}).encode()
+            headers["Content-Type"] = "application/json"
+        if self.api_key:
+            headers["{{.KeyHeader}}"] = self.api_key
+        if self.user:
+            headers["{{.UserHeader}}"] = self.user
+        request = urllib.request.Request(url, data=data, headers=headers, method=method)
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                return json.load(response)
+        except urllib.error.HTTPError as e:
+            raise WfesimError(e.code, e.read().decode(errors="replace").strip()) from None
+{{range .Methods}}
+    def {{.Name}}(self{{range .Positional}}, {{.}}{{end}}{{range .Query}}, {{.Name}}=None{{end}}{{range .Body}}, {{.Name}}=None{{end}}):
+        """{{.Summary}}."""
+        return self._request(
+            "{{.HTTPMethod}}",
+            {{.Path}},{{if .Query}}
+            query={ {{- range $i, $q := .Query}}{{if $i}}, {{end}}"{{$q.Key}}": {{$q.Name}}{{end -}} },{{end}}{{if .Body}}
+            body={
+{{- range .Body}}
+                "{{.Key}}": {{.Name}},
+{{- end}}
+            },{{end}}
+        )
+{{end}}
+    def wait(self, job_id, poll_interval=1.0, timeout=None):
+        """Poll a job until it finishes, returning it; raise JobFailed unless it succeeded."""
+        deadline = None if timeout is None else time.monotonic() + timeout
+        while True:
+            job = self.get_job(job_id)
+            if job["Status"] not in ("queued", "running"):
+                if job["Status"] != "{{.Succeeded}}":
+                    raise JobFailed(job)
+                return job
+            if deadline is not None and time.monotonic() > deadline:
+                raise TimeoutError(f"job {job_id} did not finish within {timeout}s")
+            time.sleep(poll_interval)
+
+    def run_simulation(self, scenario=None, config=None, seed=0, max_time_steps=None, priority=None, poll_interval=1.0, timeout=None):
+        """Run a simulation of a scenario or configuration dict, returning its report."""
+        job = self.submit_job(kind="{{.Simulation}}", scenario=scenario, config=config, seed=seed, max_time_steps=max_time_steps, priority=priority)
+        self.wait(job["ID"], poll_interval, timeout)
+        return self.get_job_result(job["ID"])
+
+    def run_sensitivity(self, parameter_ranges, scenario=None, config=None, seed=0, max_time_steps=None, priority=None, poll_interval=1.0, timeout=None):
+        """Run a sensitivity analysis varying parameter_ranges, returning its report."""
+        job = self.submit_job(kind="{{.Sensitivity}}", scenario=scenario, config=config, seed=seed, max_time_steps=max_time_steps, priority=priority, parameter_ranges=parameter_ranges)
+        self.wait(job["ID"], poll_interval, timeout)
+        return self.get_job_result(job["ID"])
+`))
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// pythonClientPath is the generated client kept in the repository
+const pythonClientPath = "../../clients/python/wfesim_client.py"
+
+func TestPythonClientIsUpToDate(t *testing.T) {
+	source, err := PythonClient()
+	if err != nil {
+		t.Fatalf("PythonClient failed: %v", err)
+	}
+	for _, method := range []string{"def submit_job(", "def list_jobs(", "def get_job_result(self, id)", "def cancel_job(", "max_time_steps=None"} {
+		if !strings.Contains(string(source), method) {
+			t.Errorf("Expected the client to contain %q", method)
+		}
+	}
+	committed, err := os.ReadFile(pythonClientPath)
+	if err != nil {
+		t.Fatalf("Failed to read the committed client: %v", err)
+	}
+	if !bytes.Equal(committed, source) {
+		t.Errorf("%s is out of date; regenerate it with `go run ./cmd/wfesim openapi -python -output clients/python/wfesim_client.py`", pythonClientPath)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{"MaxTimeSteps": "max_time_steps", "getJobResult": "get_job_result", "ID": "id", "AIAgents": "ai_agents"} {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
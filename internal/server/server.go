@@ -0,0 +1,354 @@
+// Package server serves simulations and sensitivity analyses over HTTP as jobs, which a Scheduler
+// queues by priority and runs within per-user concurrency limits, so a shared deployment is not
+// overwhelmed by one user's sweep
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/scenarios"
+	"workforce-ai-transition-simulator/internal/store"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Kinds of jobs
+const (
+	KindSimulation  = "simulation"
+	KindSensitivity = "sensitivity"
+)
+
+// DefaultMaxTimeSteps is the step limit of jobs that do not set one, as the CLI's -max-steps
+const DefaultMaxTimeSteps = 500
+
+// DefaultLimits are the limits wfesim serve applies to every run unless its operator sets
+// others, so a pathological configuration cannot hold a worker indefinitely
+var DefaultLimits = controller.Limits{MaxWallTime: 10 * time.Minute, MaxSteps: 10000}
+
+// UserHeader carries the name of the user submitting a request to a server without keys, whose
+// jobs count against the same concurrency limit; requests without it belong to AnonymousUser
+const (
+	UserHeader    = "X-Wfesim-User"
+	AnonymousUser = "anonymous"
+)
+
+// maxRequestBytes bounds the size of a job request
+const maxRequestBytes = 10 << 20
+
+// JobRequest is the body of a job submission
+// The configuration is either a Scenario template name or a Config document, as a JSON
+// configuration file would hold it
+type JobRequest struct {
+	Kind            string          // KindSimulation or KindSensitivity
+	Priority        int             `json:",omitempty"` // higher priorities start first
+	Scenario        string          `json:",omitempty"`
+	Config          json.RawMessage `json:",omitempty"`
+	Seed            int64
+	MaxTimeSteps    int                        `json:",omitempty"` // DefaultMaxTimeSteps if zero
+	ParameterRanges *analytics.ParameterRanges `json:",omitempty"` // values varied by a sensitivity job
+}
+
+// route is an endpoint of the API, with what its OpenAPI operation documents
+type route struct {
+	method    string
+	path      string
+	handle    func(*Server, http.ResponseWriter, *http.Request)
+	summary   string
+	query     []queryParameter
+	request   reflect.Type   // type of the JSON body, nil if none
+	status    int            // status of a successful response
+	responses []reflect.Type // types the JSON body of a successful response may have
+	errors    []int          // statuses of the plain text error responses
+}
+
+// queryParameter is an optional query parameter of a route
+type queryParameter struct {
+	name        string
+	description string
+	enum        []string
+}
+
+// routes are the endpoints of the API
+var routes = []route{
+	{
+		method: http.MethodPost, path: "/v1/jobs", handle: (*Server).submit,
+		summary: "Queue a simulation or sensitivity analysis",
+		request: reflect.TypeOf(JobRequest{}), status: http.StatusAccepted, responses: []reflect.Type{reflect.TypeOf(Job{})},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/v1/jobs", handle: (*Server).list,
+		summary: "List jobs",
+		query: []queryParameter{
+			{name: "user", description: "Only jobs of this user"},
+			{name: "status", description: "Only jobs in this status", enum: []string{string(JobQueued), string(JobRunning), string(JobSucceeded), string(JobFailed), string(JobCancelled)}},
+		},
+		status: http.StatusOK, responses: []reflect.Type{reflect.TypeOf([]Job{})},
+	},
+	{
+		method: http.MethodGet, path: "/v1/jobs/{id}", handle: (*Server).get,
+		summary: "Describe a job",
+		status:  http.StatusOK, responses: []reflect.Type{reflect.TypeOf(Job{})},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/v1/jobs/{id}/result", handle: (*Server).result,
+		summary: "Report of a succeeded job: a simulation report or a sensitivity report",
+		status:  http.StatusOK, responses: []reflect.Type{reflect.TypeOf(analytics.Report{}), reflect.TypeOf(analytics.SensitivityReport{})},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodDelete, path: "/v1/jobs/{id}", handle: (*Server).cancel,
+		summary: "Cancel a queued or running job",
+		status:  http.StatusOK, responses: []reflect.Type{reflect.TypeOf(Job{})},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+}
+
+// Server is the HTTP API of the simulator, whose endpoints are described by the OpenAPI
+// document it serves at OpenAPIPath, with a read-only results browser at UIPath
+// With keys set, every other request must carry an API key, and jobs belong to the user of the
+// key that submitted them: other users' jobs are not listed and are not found, except by admins
+type Server struct {
+	scheduler     *Scheduler
+	options       []controller.Option
+	mux           *http.ServeMux
+	handler       http.Handler
+	authenticated bool
+}
+
+// New returns a server running jobs with scheduler, applying options to the controller of every
+// simulation
+func New(scheduler *Scheduler, options ...controller.Option) *Server {
+	s := &Server{scheduler: scheduler, options: options, mux: http.NewServeMux()}
+	for _, r := range routes {
+		handle := r.handle
+		s.mux.HandleFunc(r.method+" "+r.path, func(w http.ResponseWriter, r *http.Request) { handle(s, w, r) })
+	}
+	s.handler = s.mux
+	return s
+}
+
+// SetKeys requires requests to carry one of keys, which also names their user
+func (s *Server) SetKeys(keys *Keys) {
+	s.handler = keys.Middleware(s.mux)
+	s.authenticated = true
+}
+
+// ServeHTTP serves the API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.servePublic(w, r) {
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+}
+
+// caller returns the identity of a request: that of its key, or without keys the user it
+// names, who may access every job
+func caller(r *http.Request) identity {
+	if id, ok := r.Context().Value(identityKey{}).(identity); ok {
+		return id
+	}
+	if name := r.Header.Get(UserHeader); name != "" {
+		return identity{user: name, admin: true}
+	}
+	return identity{user: AnonymousUser, admin: true}
+}
+
+// owns reports whether a caller may access a job
+func (id identity) owns(job Job) bool {
+	return id.admin || job.User == id.user
+}
+
+// lookup returns the job of a request's path if its caller may access it, answering 404 Not
+// Found otherwise
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (Job, bool) {
+	job, ok := s.scheduler.Get(r.PathValue("id"))
+	if !ok || !caller(r).owns(job) {
+		http.Error(w, ErrJobNotFound.Error(), http.StatusNotFound)
+		return Job{}, false
+	}
+	return job, true
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	var request JobRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		http.Error(w, "invalid job request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	run, err := s.prepare(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	job := s.scheduler.Submit(caller(r).user, request.Kind, request.Priority, run)
+	w.Header().Set("Location", "/v1/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	id := caller(r)
+	userFilter, statusFilter := r.URL.Query().Get("user"), JobStatus(r.URL.Query().Get("status"))
+	jobs := make([]Job, 0)
+	for _, job := range s.scheduler.List() {
+		if id.owns(job) && (userFilter == "" || job.User == userFilter) && (statusFilter == "" || job.Status == statusFilter) {
+			jobs = append(jobs, job)
+		}
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	if job, ok := s.lookup(w, r); ok {
+		writeJSON(w, http.StatusOK, job)
+	}
+}
+
+func (s *Server) result(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookup(w, r); !ok {
+		return
+	}
+	job, result, ok := s.scheduler.Result(r.PathValue("id"))
+	switch {
+	case !ok:
+		http.Error(w, ErrJobNotFound.Error(), http.StatusNotFound)
+	case job.Status != JobSucceeded:
+		http.Error(w, fmt.Sprintf("job %s is %s", job.ID, job.Status), http.StatusConflict)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func (s *Server) cancel(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookup(w, r); !ok {
+		return
+	}
+	job, err := s.scheduler.Cancel(r.PathValue("id"))
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrJobFinished):
+		http.Error(w, fmt.Sprintf("job %s is %s", job.ID, job.Status), http.StatusConflict)
+	default:
+		writeJSON(w, http.StatusOK, job)
+	}
+}
+
+// prepare checks a job request and returns the work of the job
+func (s *Server) prepare(request JobRequest) (RunFunc, error) {
+	simConfig, err := requestConfig(request)
+	if err != nil {
+		return nil, err
+	}
+	maxTimeSteps := request.MaxTimeSteps
+	if maxTimeSteps == 0 {
+		maxTimeSteps = DefaultMaxTimeSteps
+	}
+	if maxTimeSteps < 0 {
+		return nil, fmt.Errorf("MaxTimeSteps must be greater than 0, got %d", maxTimeSteps)
+	}
+
+	engine := analytics.NewAnalyticsEngine()
+	engine.SetControllerOptions(s.options...)
+	switch request.Kind {
+	case KindSimulation:
+		if request.ParameterRanges != nil {
+			return nil, errors.New("ParameterRanges only apply to sensitivity jobs")
+		}
+		return func(ctx context.Context) (any, error) {
+			task := analytics.SweepTask{Config: simConfig, Key: store.RunKey{Seed: request.Seed}, MaxTimeSteps: maxTimeSteps}
+			result, err := runTask(ctx, task, s.options)
+			if err != nil {
+				return nil, err
+			}
+			if result.Status == types.StatusAborted {
+				return nil, errors.New(result.EquilibriumReason.Message)
+			}
+			return engine.GenerateReport(result), nil
+		}, nil
+	case KindSensitivity:
+		if request.ParameterRanges == nil {
+			return nil, errors.New("sensitivity jobs require ParameterRanges")
+		}
+		return func(ctx context.Context) (any, error) {
+			opts := analytics.SensitivityOptions{Executor: &jobExecutor{ctx: ctx, options: s.options}}
+			results, err := engine.RunSensitivityAnalysisWithOptions(simConfig, *request.ParameterRanges, maxTimeSteps, request.Seed, opts)
+			if err != nil {
+				return nil, err
+			}
+			return engine.GenerateSensitivityReport(results), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown job kind %q (expected %s or %s)", request.Kind, KindSimulation, KindSensitivity)
+	}
+}
+
+// requestConfig returns the simulation configuration of a job request
+func requestConfig(request JobRequest) (types.SimulationConfig, error) {
+	switch {
+	case request.Scenario != "" && len(request.Config) > 0:
+		return types.SimulationConfig{}, errors.New("Scenario and Config are mutually exclusive")
+	case request.Scenario != "":
+		template, err := scenarios.Get(request.Scenario)
+		if err != nil {
+			return types.SimulationConfig{}, err
+		}
+		return template.Config, nil
+	case len(request.Config) > 0:
+		simConfig, _, err := config.Parse(request.Config, config.FormatJSON)
+		if err != nil {
+			return types.SimulationConfig{}, err
+		}
+		// Checked now rather than when the job starts, so the submission fails
+		return simConfig, simConfig.Validate()
+	default:
+		return types.SimulationConfig{}, errors.New("a Scenario or Config is required")
+	}
+}
+
+// jobExecutor runs the simulations of a sensitivity job one at a time, so the job occupies a
+// single worker, and stops once the job is cancelled
+type jobExecutor struct {
+	ctx     context.Context
+	options []controller.Option
+	mu      sync.Mutex
+}
+
+func (e *jobExecutor) Execute(task analytics.SweepTask) (types.SimulationResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return runTask(e.ctx, task, e.options)
+}
+
+// runTask simulates a task, returning ctx's error once ctx is done and the error of a violated
+// invariant, both of which fail the job
+func runTask(ctx context.Context, task analytics.SweepTask, options []controller.Option) (types.SimulationResult, error) {
+	if task.WarmStart == nil {
+		return controller.NewSimulationController(task.Config, task.Key.Seed, options...).RunUntilEquilibriumContext(ctx, task.MaxTimeSteps)
+	}
+	simController, err := controller.WarmStartSimulationController(*task.WarmStart, task.Config, options...)
+	if err != nil {
+		return types.SimulationResult{}, fmt.Errorf("failed to start simulation: %w", err)
+	}
+	return simController.RunUntilEquilibriumContext(ctx, task.MaxTimeSteps)
+}
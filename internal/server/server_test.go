@@ -0,0 +1,215 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/scenarios"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// request sends a request to a test server as user, decoding a JSON response into out if set
+func request(t *testing.T, server *httptest.Server, method, path, user string, body any, out any) int {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			t.Fatalf("Failed to encode request: %v", err)
+		}
+	}
+	r, err := http.NewRequest(method, server.URL+path, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if user != "" {
+		r.Header.Set(UserHeader, user)
+	}
+	response, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer response.Body.Close()
+	if out != nil && response.StatusCode/100 == 2 {
+		if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return response.StatusCode
+}
+
+func newTestServer(t *testing.T, workers int) *httptest.Server {
+	scheduler := NewScheduler(workers, 1)
+	server := httptest.NewServer(New(scheduler))
+	t.Cleanup(func() {
+		server.Close()
+		scheduler.Close()
+	})
+	return server
+}
+
+// awaitJob polls a job until it is finished
+func awaitJob(t *testing.T, server *httptest.Server, id string) Job {
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		var job Job
+		if status := request(t, server, http.MethodGet, "/v1/jobs/"+id, "", nil, &job); status != http.StatusOK {
+			t.Fatalf("Unexpected status %d for job %s", status, id)
+		}
+		if job.Status.Finished() {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job %s did not finish", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSimulationJobMatchesLocalRun(t *testing.T) {
+	server := newTestServer(t, 2)
+
+	var job Job
+	status := request(t, server, http.MethodPost, "/v1/jobs", "alice", JobRequest{Kind: KindSimulation, Scenario: "hiring-freeze", Seed: 7, MaxTimeSteps: 100}, &job)
+	if status != http.StatusAccepted || job.User != "alice" || job.Kind != KindSimulation {
+		t.Fatalf("Unexpected submission response %d %+v", status, job)
+	}
+	if job = awaitJob(t, server, job.ID); job.Status != JobSucceeded {
+		t.Fatalf("Expected the job to succeed, got %s: %s", job.Status, job.Error)
+	}
+
+	var report analytics.Report
+	if status := request(t, server, http.MethodGet, "/v1/jobs/"+job.ID+"/result", "", nil, &report); status != http.StatusOK {
+		t.Fatalf("Unexpected result status %d", status)
+	}
+	scenario, _ := scenarios.Get("hiring-freeze")
+	local, err := controller.NewSimulationController(scenario.Config, 7).RunUntilEquilibrium(100)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if len(report.TimeSeriesData) != len(local.TimeSeries) || !reflect.DeepEqual(report.TimeSeriesData[len(local.TimeSeries)-1].Workforce, local.EquilibriumState.Workforce) {
+		t.Error("Expected the job's report to match a local run")
+	}
+}
+
+func TestSensitivityJobAndListing(t *testing.T) {
+	server := newTestServer(t, 1)
+	ranges := analytics.ParameterRanges{CatastrophicFailureRate: []float64{0.01, 0.1}}
+
+	var job Job
+	if status := request(t, server, http.MethodPost, "/v1/jobs", "bob", JobRequest{Kind: KindSensitivity, Scenario: "hiring-freeze", Seed: 7, MaxTimeSteps: 50, ParameterRanges: &ranges}, &job); status != http.StatusAccepted {
+		t.Fatalf("Unexpected submission status %d", status)
+	}
+	if job = awaitJob(t, server, job.ID); job.Status != JobSucceeded {
+		t.Fatalf("Expected the job to succeed, got %s: %s", job.Status, job.Error)
+	}
+	var report analytics.SensitivityReport
+	request(t, server, http.MethodGet, "/v1/jobs/"+job.ID+"/result", "", nil, &report)
+	if len(report.DetailedResults["CatastrophicFailureRate"].Results) != 2 {
+		t.Errorf("Expected 2 runs in the report, got %+v", report.DetailedResults)
+	}
+
+	var jobs []Job
+	request(t, server, http.MethodGet, "/v1/jobs?user=bob", "", nil, &jobs)
+	if len(jobs) != 1 || jobs[0].ID != job.ID {
+		t.Errorf("Expected bob's job to be listed, got %+v", jobs)
+	}
+	request(t, server, http.MethodGet, "/v1/jobs?user=alice", "", nil, &jobs)
+	if len(jobs) != 0 {
+		t.Errorf("Expected no jobs of alice, got %+v", jobs)
+	}
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	scheduler := NewScheduler(1, 1)
+	server := httptest.NewServer(New(scheduler))
+	defer server.Close()
+	defer scheduler.Close()
+	// A job holding the only worker keeps the next one queued
+	g := newGate()
+	defer close(g.release)
+	scheduler.Submit("alice", "test", 0, g.job("busy"))
+
+	var job Job
+	request(t, server, http.MethodPost, "/v1/jobs", "", JobRequest{Kind: KindSimulation, Scenario: "hiring-freeze"}, &job)
+	if job.Status != JobQueued || job.Position != 1 {
+		t.Fatalf("Expected the job to be queued first, got %+v", job)
+	}
+	if status := request(t, server, http.MethodGet, "/v1/jobs/"+job.ID+"/result", "", nil, nil); status != http.StatusConflict {
+		t.Errorf("Expected no result before the job finishes, got %d", status)
+	}
+	if status := request(t, server, http.MethodDelete, "/v1/jobs/"+job.ID, "", nil, &job); status != http.StatusOK || job.Status != JobCancelled {
+		t.Fatalf("Unexpected cancel response %d %+v", status, job)
+	}
+	if status := request(t, server, http.MethodDelete, "/v1/jobs/"+job.ID, "", nil, nil); status != http.StatusConflict {
+		t.Errorf("Expected cancelling a finished job to conflict, got %d", status)
+	}
+}
+
+func TestAbortedSimulationFailsJob(t *testing.T) {
+	scheduler := NewScheduler(1, 1)
+	server := httptest.NewServer(New(scheduler, controller.WithLimits(controller.Limits{MaxSteps: 2})))
+	defer server.Close()
+	defer scheduler.Close()
+
+	var job Job
+	request(t, server, http.MethodPost, "/v1/jobs", "", JobRequest{Kind: KindSimulation, Scenario: "hiring-freeze", MaxTimeSteps: 100}, &job)
+	if job = awaitJob(t, server, job.ID); job.Status != JobFailed || !strings.HasPrefix(job.Error, "aborted: step limit of 2") {
+		t.Errorf("Expected the job to fail at the step limit, got %s: %s", job.Status, job.Error)
+	}
+}
+
+func TestInvariantViolationFailsJob(t *testing.T) {
+	checker := func(_ *controller.SimulationController, state types.SimulationState) error {
+		if state.TimeStep == 3 {
+			return errors.New("broken at step 3")
+		}
+		return nil
+	}
+	scheduler := NewScheduler(1, 1)
+	server := httptest.NewServer(New(scheduler, controller.WithInvariantChecker(checker)))
+	defer server.Close()
+	defer scheduler.Close()
+
+	var job Job
+	request(t, server, http.MethodPost, "/v1/jobs", "", JobRequest{Kind: KindSimulation, Scenario: "hiring-freeze", MaxTimeSteps: 100}, &job)
+	if job = awaitJob(t, server, job.ID); job.Status != JobFailed || job.Error != "broken at step 3" {
+		t.Errorf("Expected the job to fail with the invariant error, got %s: %s", job.Status, job.Error)
+	}
+}
+
+func TestRunTaskStopsWhenCancelled(t *testing.T) {
+	scenario, _ := scenarios.Get("hiring-freeze")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := runTask(ctx, analytics.SweepTask{Config: scenario.Config, MaxTimeSteps: 100}, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the run to stop with the context's error, got %v", err)
+	}
+}
+
+func TestSubmitRejectsInvalidRequests(t *testing.T) {
+	server := newTestServer(t, 1)
+	for _, invalid := range []any{
+		JobRequest{Kind: KindSimulation},
+		JobRequest{Kind: "optimization", Scenario: "hiring-freeze"},
+		JobRequest{Kind: KindSensitivity, Scenario: "hiring-freeze"},
+		JobRequest{Kind: KindSimulation, Scenario: "hiring-freeze", Config: json.RawMessage(`{"InitialHumans": 10}`)},
+		JobRequest{Kind: KindSimulation, Config: json.RawMessage(`{"InitialHumans": -1}`)},
+		map[string]any{"Kind": KindSimulation, "Scenario": "hiring-freeze", "Seeds": 3},
+	} {
+		if status := request(t, server, http.MethodPost, "/v1/jobs", "", invalid, nil); status != http.StatusBadRequest {
+			t.Errorf("Expected %+v to be rejected, got %d", invalid, status)
+		}
+	}
+	if status := request(t, server, http.MethodGet, "/v1/jobs/missing", "", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected an unknown job to be not found, got %d", status)
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// UIPath is where a server serves its results browser
+const UIPath = "/ui/"
+
+// uiFiles are the static files of the results browser, a page listing the runs of the API and
+// charting the report of the selected one
+//
+//go:embed ui
+var uiFiles embed.FS
+
+// uiHandler serves the results browser
+var uiHandler = func() http.Handler {
+	files, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix(UIPath, http.FileServer(http.FS(files)))
+}()
+
+// servePublic serves the requests that need no key: the OpenAPI document and the results
+// browser, whose pages hold no results but fetch them from the API with the key entered in them
+// It reports whether it served the request
+func (s *Server) servePublic(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	switch {
+	case r.URL.Path == OpenAPIPath:
+		s.serveOpenAPI(w)
+	case r.URL.Path == "/":
+		http.Redirect(w, r, UIPath, http.StatusFound)
+	case strings.HasPrefix(r.URL.Path, UIPath):
+		uiHandler.ServeHTTP(w, r)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,234 @@
+// Results browser of the wfesim server: lists the runs of the job API and renders the report of
+// the selected one. It only reads; jobs are submitted and cancelled through the API itself.
+'use strict';
+
+const KEY_STORAGE = 'wfesimKey';
+const REFRESH_MS = 5000;
+const COLORS = ['#3498db', '#e67e22', '#27ae60', '#9b59b6', '#e74c3c', '#16a085'];
+
+let selected = null;
+
+// api fetches a JSON document of the API, sending the API key entered in this browser tab
+async function api(path) {
+    const headers = {Accept: 'application/json'};
+    const key = sessionStorage.getItem(KEY_STORAGE);
+    if (key) {
+        headers['X-Api-Key'] = key;
+    }
+    const response = await fetch(path, {headers});
+    if (response.status === 401) {
+        document.getElementById('key-form').hidden = false;
+        throw new Error('This server requires an API key');
+    }
+    if (!response.ok) {
+        throw new Error(`${response.status}: ${(await response.text()).trim()}`);
+    }
+    return response.json();
+}
+
+function element(tag, attributes = {}, ...children) {
+    const e = document.createElement(tag);
+    for (const [name, value] of Object.entries(attributes)) {
+        e.setAttribute(name, value);
+    }
+    e.append(...children);
+    return e;
+}
+
+function svgElement(tag, attributes = {}) {
+    const e = document.createElementNS('http://www.w3.org/2000/svg', tag);
+    for (const [name, value] of Object.entries(attributes)) {
+        e.setAttribute(name, value);
+    }
+    return e;
+}
+
+function formatNumber(value) {
+    if (typeof value !== 'number') {
+        return String(value);
+    }
+    const magnitude = Math.abs(value);
+    if (magnitude >= 1e9) return (value / 1e9).toFixed(2) + 'B';
+    if (magnitude >= 1e6) return (value / 1e6).toFixed(2) + 'M';
+    if (magnitude >= 1e3) return (value / 1e3).toFixed(1) + 'k';
+    return Number.isInteger(value) ? String(value) : value.toFixed(3);
+}
+
+function showError(message) {
+    const error = document.getElementById('error');
+    error.textContent = message;
+    error.hidden = !message;
+}
+
+async function refreshJobs() {
+    const status = document.getElementById('status-filter').value;
+    let jobs;
+    try {
+        jobs = await api('/v1/jobs' + (status ? '?status=' + encodeURIComponent(status) : ''));
+    } catch (e) {
+        showError(e.message);
+        return;
+    }
+    const body = document.querySelector('#jobs tbody');
+    body.replaceChildren(...(jobs || []).slice().reverse().map(job => {
+        const row = element('tr', {},
+            element('td', {}, job.ID),
+            element('td', {}, job.Kind),
+            element('td', {}, job.User),
+            element('td', {class: 'status-' + job.Status}, job.Status),
+            element('td', {}, new Date(job.SubmittedAt).toLocaleString()));
+        if (job.ID === selected) {
+            row.classList.add('selected');
+        }
+        row.addEventListener('click', () => selectJob(job));
+        return row;
+    }));
+    document.getElementById('jobs-empty').hidden = (jobs || []).length > 0;
+}
+
+async function selectJob(job) {
+    selected = job.ID;
+    document.querySelectorAll('#jobs tbody tr').forEach(row => {
+        row.classList.toggle('selected', row.firstChild.textContent === job.ID);
+    });
+    document.getElementById('placeholder').hidden = true;
+    document.getElementById('result').hidden = true;
+    showError('');
+    if (job.Status !== 'succeeded') {
+        showError(`Run ${job.ID} is ${job.Status}` + (job.Error ? ': ' + job.Error : ''));
+        return;
+    }
+    let report;
+    try {
+        report = await api(`/v1/jobs/${encodeURIComponent(job.ID)}/result`);
+    } catch (e) {
+        showError(e.message);
+        return;
+    }
+    if (selected !== job.ID) {
+        return;
+    }
+    document.getElementById('result-title').textContent = `Run ${job.ID}: ${job.Kind}`;
+    if (report.ParameterRankings) {
+        renderSensitivity(report);
+    } else {
+        renderSimulation(report);
+    }
+    document.getElementById('result').hidden = false;
+}
+
+function renderCards(values) {
+    document.getElementById('cards').replaceChildren(...values.map(([label, value]) =>
+        element('div', {class: 'card'},
+            element('div', {class: 'value'}, formatNumber(value)),
+            element('div', {class: 'label'}, label))));
+}
+
+// lineChart draws series of [x, y] points sharing axes, with a legend naming them
+function lineChart(title, series, xLabel) {
+    const width = 640, height = 260, left = 60, right = 10, top = 10, bottom = 30;
+    const points = series.flatMap(s => s.points);
+    if (points.length === 0) {
+        return element('div');
+    }
+    const xs = points.map(p => p[0]), ys = points.map(p => p[1]);
+    const xMin = Math.min(...xs), xMax = Math.max(...xs);
+    const yMin = Math.min(0, ...ys), yMax = Math.max(...ys);
+    const x = v => left + (xMax === xMin ? 0.5 : (v - xMin) / (xMax - xMin)) * (width - left - right);
+    const y = v => top + (yMax === yMin ? 0.5 : 1 - (v - yMin) / (yMax - yMin)) * (height - top - bottom);
+
+    const svg = svgElement('svg', {viewBox: `0 0 ${width} ${height}`, role: 'img', 'aria-label': title});
+    svg.append(svgElement('line', {x1: left, y1: y(yMin), x2: width - right, y2: y(yMin), stroke: '#bdc3c7'}));
+    svg.append(svgElement('line', {x1: left, y1: top, x2: left, y2: height - bottom, stroke: '#bdc3c7'}));
+    for (const [v, anchor] of [[yMin, 'end'], [yMax, 'end']]) {
+        const label = svgElement('text', {x: left - 5, y: y(v) + 4, 'text-anchor': anchor, 'font-size': 11, fill: '#7f8c8d'});
+        label.textContent = formatNumber(v);
+        svg.append(label);
+    }
+    for (const v of [xMin, xMax]) {
+        const label = svgElement('text', {x: x(v), y: height - bottom + 15, 'text-anchor': 'middle', 'font-size': 11, fill: '#7f8c8d'});
+        label.textContent = formatNumber(v);
+        svg.append(label);
+    }
+    const axis = svgElement('text', {x: (left + width) / 2, y: height - 3, 'text-anchor': 'middle', 'font-size': 11, fill: '#7f8c8d'});
+    axis.textContent = xLabel;
+    svg.append(axis);
+    series.forEach((s, i) => {
+        const d = s.points.map((p, j) => `${j ? 'L' : 'M'}${x(p[0]).toFixed(1)},${y(p[1]).toFixed(1)}`).join('');
+        svg.append(svgElement('path', {d, fill: 'none', stroke: COLORS[i % COLORS.length], 'stroke-width': 2}));
+    });
+
+    const legend = element('div', {class: 'legend'}, ...series.map((s, i) =>
+        element('span', {}, element('i', {style: 'background:' + COLORS[i % COLORS.length]}), s.name)));
+    return element('div', {class: 'chart'}, element('h3', {}, title), svg, legend);
+}
+
+function renderSimulation(report) {
+    const summary = report.Summary || {};
+    renderCards([
+        ['Steps', report.TotalSimulationDuration],
+        ['Final humans', summary.FinalHumanCount],
+        ['Final AI agents', summary.FinalAIAgentCount],
+        ['Total revenue', summary.TotalRevenueGenerated],
+        ['Net present value', summary.NetPresentValue],
+        ['AI revenue share', summary.FinalAIRevenueShare],
+    ].filter(([, value]) => value !== undefined));
+
+    const states = report.TimeSeriesData || [];
+    const series = (name, value) => ({name, points: states.map(s => [s.TimeStep, value(s)])});
+    document.getElementById('charts').replaceChildren(
+        lineChart('Workforce', [
+            series('Humans', s => s.Workforce.Humans.Total),
+            series('AI agents', s => s.Workforce.AIAgents.Total),
+        ], 'time step'),
+        lineChart('Cost and revenue', [
+            series('Total cost', s => s.TotalCost),
+            series('Revenue', s => s.RevenueOutput),
+        ], 'time step'),
+        lineChart('Productivity', [series('Total productivity', s => s.TotalProductivity)], 'time step'));
+    document.getElementById('rankings').replaceChildren();
+}
+
+function renderSensitivity(report) {
+    const summary = report.Summary || {};
+    renderCards([
+        ['Most impactful', summary.MostImpactfulParameter],
+        ['Least impactful', summary.LeastImpactfulParameter],
+        ['Mean time to equilibrium', summary.AverageTimeToEquilibrium],
+        ['Censored runs', summary.CensoredRuns],
+    ].filter(([, value]) => value !== undefined && value !== ''));
+
+    const rankings = report.ParameterRankings || [];
+    const widest = Math.max(1e-12, ...rankings.map(r => r.TimeToEquilibriumImpact));
+    const widestComposition = Math.max(1e-12, ...rankings.map(r => r.CompositionImpact));
+    const bar = fraction => element('div', {class: 'bar', style: `width:${(100 * fraction).toFixed(1)}%`});
+    document.getElementById('rankings').replaceChildren(
+        element('h3', {}, 'Parameter rankings'),
+        element('table', {},
+            element('thead', {}, element('tr', {},
+                element('th', {}, '#'), element('th', {}, 'Parameter'),
+                element('th', {}, 'Time to equilibrium impact'), element('th', {}, 'Composition impact'))),
+            element('tbody', {}, ...rankings.map((r, i) => element('tr', {},
+                element('td', {}, String(i + 1)),
+                element('td', {}, r.ParameterName),
+                element('td', {}, formatNumber(r.TimeToEquilibriumImpact), bar(r.TimeToEquilibriumImpact / widest)),
+                element('td', {}, formatNumber(r.CompositionImpact), bar(r.CompositionImpact / widestComposition)))))));
+
+    const details = report.DetailedResults || {};
+    document.getElementById('charts').replaceChildren(...Object.keys(details).sort().map(name => {
+        const byValue = details[name].TimeToEquilibriumByValue || {};
+        const points = Object.entries(byValue).map(([v, steps]) => [Number(v), steps]).sort((a, b) => a[0] - b[0]);
+        return lineChart(name, [{name: 'Time to equilibrium', points}], name);
+    }));
+}
+
+document.getElementById('key-form').addEventListener('submit', event => {
+    event.preventDefault();
+    sessionStorage.setItem(KEY_STORAGE, document.getElementById('key').value);
+    document.getElementById('key-form').hidden = true;
+    showError('');
+    refreshJobs();
+});
+document.getElementById('status-filter').addEventListener('change', refreshJobs);
+refreshJobs();
+setInterval(refreshJobs, REFRESH_MS);
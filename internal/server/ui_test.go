@@ -0,0 +1,41 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUIIsServedWithoutKey(t *testing.T) {
+	scheduler := NewScheduler(1, 1)
+	defer scheduler.Close()
+	s := New(scheduler)
+	keys, _ := NewKeys([]APIKey{{Key: "secret", User: "alice"}})
+	s.SetKeys(keys)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	for path, want := range map[string]string{
+		"/":               "<title>Workforce AI Transition Simulator - Results</title>",
+		UIPath:            `<script src="app.js">`,
+		UIPath + "app.js": "/v1/jobs",
+	} {
+		response, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Request for %s failed: %v", path, err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK || !strings.Contains(string(body), want) {
+			t.Errorf("Expected %s to serve %q, got %s", path, want, response.Status)
+		}
+	}
+	if status := request(t, server, http.MethodGet, UIPath+"missing.js", "", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected an unknown file to be not found, got %d", status)
+	}
+	if status := request(t, server, http.MethodPost, UIPath, "", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("Expected other methods to require a key, got %d", status)
+	}
+}
@@ -0,0 +1,456 @@
+package sink
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URI schemes of object store targets
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+)
+
+// Defaults of ObjectStore uploads
+const (
+	DefaultRetries  = 3
+	DefaultBackoff  = 500 * time.Millisecond
+	DefaultPartSize = 16 << 20
+)
+
+// emptyPayloadHash is the hex-encoded SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// maxResponseSize bounds how much of a response body is read
+const maxResponseSize = 1 << 20
+
+// ObjectStore is a sink uploading every report as an object of an S3-compatible bucket,
+// which includes Google Cloud Storage through its XML API
+// Reports are spooled to a temporary file while they are written and uploaded when closed: with
+// a single PUT up to PartSize, and with a multipart upload of PartSize parts above it, which is
+// aborted if a part fails. Every request is retried on network errors and throttling or server
+// errors with exponential backoff
+type ObjectStore struct {
+	Scheme   string // URI scheme of locations, SchemeS3 or SchemeGCS
+	Bucket   string
+	Prefix   string // prepended to report names, as a directory
+	Endpoint string // base URL of the service, e.g. https://s3.us-east-1.amazonaws.com
+	// VirtualHosted addresses the bucket as a subdomain of the endpoint, as AWS prefers, rather
+	// than as the first element of the path, as S3-compatible services and GCS expect
+	VirtualHosted bool
+
+	// Requests are signed with AWS Signature Version 4 when AccessKeyID is set, otherwise sent
+	// with BearerToken, e.g. a Google OAuth access token
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	BearerToken     string
+
+	Retries  int           // retries after a failed request
+	Backoff  time.Duration // wait before the first retry, doubled for each further one
+	PartSize int64         // size of the parts of multipart uploads, DefaultPartSize if zero; S3 requires at least 5 MiB
+	Client   *http.Client  // nil uses http.DefaultClient
+}
+
+// S3FromEnv returns an S3 sink with the standard AWS environment variables:
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION (or
+// AWS_DEFAULT_REGION, otherwise us-east-1), and AWS_ENDPOINT_URL_S3 (or AWS_ENDPOINT_URL) to
+// use an S3-compatible service such as MinIO instead of AWS
+// Returns an error if no credentials are set
+func S3FromEnv(bucket string, prefix string, getenv func(string) string) (*ObjectStore, error) {
+	store := &ObjectStore{
+		Scheme:          SchemeS3,
+		Bucket:          bucket,
+		Prefix:          prefix,
+		Region:          firstNonEmpty(getenv("AWS_REGION"), getenv("AWS_DEFAULT_REGION"), "us-east-1"),
+		AccessKeyID:     getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    getenv("AWS_SESSION_TOKEN"),
+		Retries:         DefaultRetries,
+		Backoff:         DefaultBackoff,
+	}
+	store.Endpoint = firstNonEmpty(getenv("AWS_ENDPOINT_URL_S3"), getenv("AWS_ENDPOINT_URL"))
+	if store.Endpoint == "" {
+		store.Endpoint = "https://s3." + store.Region + ".amazonaws.com"
+		store.VirtualHosted = true
+	}
+	if store.AccessKeyID == "" || store.SecretAccessKey == "" {
+		return nil, errors.New("writing to s3:// requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return store, nil
+}
+
+// GCSFromEnv returns a Google Cloud Storage sink authenticated with GOOGLE_OAUTH_ACCESS_TOKEN
+// (e.g. from gcloud auth print-access-token), or with the HMAC key GCS_ACCESS_KEY_ID and
+// GCS_SECRET_ACCESS_KEY; STORAGE_EMULATOR_HOST overrides the endpoint
+// Returns an error if neither is set
+func GCSFromEnv(bucket string, prefix string, getenv func(string) string) (*ObjectStore, error) {
+	store := &ObjectStore{
+		Scheme:          SchemeGCS,
+		Bucket:          bucket,
+		Prefix:          prefix,
+		Endpoint:        firstNonEmpty(getenv("STORAGE_EMULATOR_HOST"), "https://storage.googleapis.com"),
+		Region:          "auto",
+		AccessKeyID:     getenv("GCS_ACCESS_KEY_ID"),
+		SecretAccessKey: getenv("GCS_SECRET_ACCESS_KEY"),
+		BearerToken:     getenv("GOOGLE_OAUTH_ACCESS_TOKEN"),
+		Retries:         DefaultRetries,
+		Backoff:         DefaultBackoff,
+	}
+	if !strings.Contains(store.Endpoint, "://") {
+		store.Endpoint = "http://" + store.Endpoint
+	}
+	if store.BearerToken == "" && (store.AccessKeyID == "" || store.SecretAccessKey == "") {
+		return nil, errors.New("writing to gs:// requires GOOGLE_OAUTH_ACCESS_TOKEN, or GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY")
+	}
+	return store, nil
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Create starts the named report, which is uploaded when it is closed
+func (s *ObjectStore) Create(name string) (io.WriteCloser, error) {
+	spool, err := os.CreateTemp("", "wfesim-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool %s: %w", s.Location(name), err)
+	}
+	return &object{store: s, key: s.key(name), location: s.Location(name), spool: spool, hash: sha256.New()}, nil
+}
+
+// Location returns the URI of the named report
+func (s *ObjectStore) Location(name string) string {
+	return s.Scheme + "://" + s.Bucket + "/" + s.key(name)
+}
+
+// key returns the object key of the named report
+func (s *ObjectStore) key(name string) string {
+	return strings.TrimPrefix(path.Join(s.Prefix, name), "/")
+}
+
+// partSize returns the size of the parts of multipart uploads
+func (s *ObjectStore) partSize() int64 {
+	if s.PartSize <= 0 {
+		return DefaultPartSize
+	}
+	return s.PartSize
+}
+
+// object is a report being written to an object store
+type object struct {
+	store    *ObjectStore
+	key      string
+	location string
+	spool    *os.File
+	hash     hash.Hash
+	size     int64
+	err      error // first error spooling the report, which is then not uploaded
+}
+
+// Write spools p
+func (o *object) Write(p []byte) (int, error) {
+	n, err := o.spool.Write(p)
+	o.hash.Write(p[:n])
+	o.size += int64(n)
+	if err != nil && o.err == nil {
+		o.err = err
+	}
+	return n, err
+}
+
+// Close uploads the spooled report and removes the spool
+func (o *object) Close() error {
+	defer os.Remove(o.spool.Name())
+	defer o.spool.Close()
+	if o.err != nil {
+		return fmt.Errorf("failed to spool %s: %w", o.location, o.err)
+	}
+
+	var err error
+	if o.size <= o.store.partSize() {
+		_, _, err = o.store.send(http.MethodPut, o.key, nil, o.spool, o.size, hex.EncodeToString(o.hash.Sum(nil)))
+	} else {
+		err = o.uploadParts()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", o.location, err)
+	}
+	return nil
+}
+
+// Abort removes the spool without uploading the report, for reports that failed to be written
+func (o *object) Abort() {
+	o.spool.Close()
+	os.Remove(o.spool.Name())
+}
+
+// completeMultipartUpload is the body of a request completing a multipart upload
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// completedPart is an uploaded part of a multipart upload
+type completedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// uploadParts uploads the spooled report with a multipart upload, aborting it if it fails so
+// the bucket does not keep the parts
+func (o *object) uploadParts() error {
+	data, _, err := o.store.send(http.MethodPost, o.key, url.Values{"uploads": {""}}, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(data, &initiated); err != nil || initiated.UploadID == "" {
+		return fmt.Errorf("failed to start multipart upload: no upload ID in %q", data)
+	}
+
+	if err := o.sendParts(initiated.UploadID); err != nil {
+		// An upload that cannot be aborted either is left to the bucket's lifecycle rules
+		o.store.send(http.MethodDelete, o.key, url.Values{"uploadId": {initiated.UploadID}}, nil, 0, emptyPayloadHash)
+		return err
+	}
+	return nil
+}
+
+// sendParts uploads the parts of the spooled report and completes the multipart upload
+func (o *object) sendParts(uploadID string) error {
+	partSize := o.store.partSize()
+	var complete completeMultipartUpload
+	for offset, number := int64(0), 1; offset < o.size; offset, number = offset+partSize, number+1 {
+		size := min(partSize, o.size-offset)
+		part := io.NewSectionReader(o.spool, offset, size)
+		hash := sha256.New()
+		if _, err := io.Copy(hash, part); err != nil {
+			return fmt.Errorf("failed to read part %d: %w", number, err)
+		}
+		query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+		_, header, err := o.store.send(http.MethodPut, o.key, query, part, size, hex.EncodeToString(hash.Sum(nil)))
+		if err != nil {
+			return fmt.Errorf("failed to upload part %d: %w", number, err)
+		}
+		complete.Parts = append(complete.Parts, completedPart{PartNumber: number, ETag: header.Get("ETag")})
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	if _, _, err := o.store.send(http.MethodPost, o.key, url.Values{"uploadId": {uploadID}}, bytes.NewReader(body), int64(len(body)), hex.EncodeToString(sum[:])); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// send sends a request for an object, retrying network errors and throttling or server errors
+// with exponential backoff, and returns the body and header of the response
+// The body of the request, nil for none, is read from its start on every attempt
+func (s *ObjectStore) send(method string, key string, query url.Values, body io.ReadSeeker, size int64, payloadHash string) ([]byte, http.Header, error) {
+	backoff := s.Backoff
+	var err error
+	for attempt := 0; attempt <= s.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		var data []byte
+		var header http.Header
+		var retry bool
+		data, header, retry, err = s.sendOnce(method, key, query, body, size, payloadHash)
+		if err == nil {
+			return data, header, nil
+		}
+		if !retry {
+			break
+		}
+	}
+	return nil, nil, err
+}
+
+// sendOnce sends a request for an object once, reporting whether a failure is worth retrying
+func (s *ObjectStore) sendOnce(method string, key string, query url.Values, body io.ReadSeeker, size int64, payloadHash string) ([]byte, http.Header, bool, error) {
+	var reader io.ReadCloser = http.NoBody
+	if body != nil {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, false, err
+		}
+		reader = io.NopCloser(body)
+	}
+	request, err := s.request(method, key, query, reader, size, payloadHash, time.Now().UTC())
+	if err != nil {
+		return nil, nil, false, err
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return nil, nil, true, err
+	}
+	// Completing a multipart upload can fail after the 200 status is sent, with an error body
+	if response.StatusCode/100 == 2 && !bytes.Contains(data, []byte("<Error>")) {
+		return data, response.Header, false, nil
+	}
+	retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 || response.StatusCode/100 == 2
+	message := strings.TrimSpace(string(data[:min(len(data), 512)]))
+	return nil, nil, retry, fmt.Errorf("%s: %s", response.Status, message)
+}
+
+// request returns the signed request of an object
+func (s *ObjectStore) request(method string, key string, query url.Values, body io.ReadCloser, size int64, payloadHash string, now time.Time) (*http.Request, error) {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", s.Endpoint, err)
+	}
+	host := endpoint.Host
+	objectPath := strings.TrimSuffix(endpoint.Path, "/") + "/" + escapePath(s.Bucket) + "/" + escapePath(key)
+	if s.VirtualHosted {
+		host = s.Bucket + "." + endpoint.Host
+		objectPath = strings.TrimSuffix(endpoint.Path, "/") + "/" + escapePath(key)
+	}
+	target, err := url.Parse(endpoint.Scheme + "://" + host + objectPath)
+	if err != nil {
+		return nil, err
+	}
+	target.RawQuery = canonicalQuery(query)
+
+	request, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	request.ContentLength = size
+	if s.AccessKeyID == "" {
+		request.Header.Set("Authorization", "Bearer "+s.BearerToken)
+		return request, nil
+	}
+
+	amzDate := now.Format("20060102T150405Z")
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+	request.Header.Set("Authorization", s.authorization(request.Method, host, objectPath, target.RawQuery, request.Header, payloadHash, now))
+	return request, nil
+}
+
+// authorization returns the AWS Signature Version 4 Authorization header of a request, which
+// signs the query, given as canonicalQuery returns it, and the host, Date and X-Amz-* headers
+func (s *ObjectStore) authorization(method string, host string, objectPath string, query string, header http.Header, payloadHash string, now time.Time) string {
+	headers := map[string]string{"host": host}
+	for name := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") || lower == "date" {
+			headers[lower] = strings.TrimSpace(header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{method, objectPath, query, canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+	date := now.Format("20060102")
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", now.Format("20060102T150405Z"), scope, sha256Hex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), date)
+	for _, part := range []string{s.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.AccessKeyID, scope, signedHeaders, signature)
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalQuery returns a query string as Signature Version 4 signs it, sorted by name and
+// then value, with both percent-encoded
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pairs []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, escape(name, false)+"="+escape(value, false))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escapePath percent-encodes an object key as Signature Version 4 expects, leaving only
+// unreserved characters and slashes as they are
+func escapePath(key string) string {
+	return escape(key, true)
+}
+
+// escape percent-encodes all but unreserved characters of s, and slashes if keepSlashes is false
+func escape(s string, keepSlashes bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c == '/' && keepSlashes) || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
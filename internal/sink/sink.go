@@ -0,0 +1,90 @@
+// Package sink writes reports to a local directory or directly to an S3 or Google Cloud Storage
+// bucket, so runs in containers without persistent disks can keep their output
+package sink
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sink is where reports are written
+type Sink interface {
+	// Create returns a writer of the named report; the report is only complete, and for object
+	// stores only uploaded, once Close returns without error
+	Create(name string) (io.WriteCloser, error)
+	// Location returns where the named report is written, for messages
+	Location(name string) string
+}
+
+// Open returns the sink of an output target: an s3://bucket/prefix or gs://bucket/prefix URI,
+// with credentials from the environment (see S3FromEnv and GCSFromEnv), or otherwise a local
+// directory, which is created if it does not exist
+func Open(target string) (Sink, error) {
+	return open(target, os.Getenv)
+}
+
+// open returns the sink of an output target, reading the environment with getenv
+func open(target string, getenv func(string) string) (Sink, error) {
+	for _, scheme := range []string{SchemeS3, SchemeGCS} {
+		if rest, ok := strings.CutPrefix(target, scheme+"://"); ok {
+			bucket, prefix, _ := strings.Cut(rest, "/")
+			if bucket == "" {
+				return nil, fmt.Errorf("output %q has no bucket", target)
+			}
+			if scheme == SchemeS3 {
+				return S3FromEnv(bucket, prefix, getenv)
+			}
+			return GCSFromEnv(bucket, prefix, getenv)
+		}
+	}
+
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return Dir(target), nil
+}
+
+// Abort releases a report that failed to be written without completing it: reports bound for
+// an object store are not uploaded, local files are closed as they are
+func Abort(w io.WriteCloser) {
+	if aborter, ok := w.(interface{ Abort() }); ok {
+		aborter.Abort()
+		return
+	}
+	w.Close()
+}
+
+// Dir is a local directory sink
+type Dir string
+
+// Create creates the named file in the directory
+func (d Dir) Create(name string) (io.WriteCloser, error) {
+	path := d.Location(name)
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return &file{File: f, path: path}, nil
+}
+
+// Location returns the path of the named file
+func (d Dir) Location(name string) string {
+	return filepath.Join(string(d), name)
+}
+
+// file is a report file whose Close errors name the file
+type file struct {
+	*os.File
+	path string
+}
+
+// Close closes the file
+func (f *file) Close() error {
+	if err := f.File.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", f.path, err)
+	}
+	return nil
+}
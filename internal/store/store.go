@@ -0,0 +1,211 @@
+// Package store persists simulation results so long-running parameter sweeps can be
+// resumed after a failure instead of starting over
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// RunKey identifies a single run of a parameter sweep
+type RunKey struct {
+	Parameter string
+	Value     float64
+	Seed      int64
+}
+
+// ResultStore records completed sweep runs
+// Implementations must be safe for concurrent use, since sweeps run parameters in parallel
+type ResultStore interface {
+	// Lookup returns the stored result for a run, if it has completed before
+	Lookup(key RunKey) (types.SimulationResult, bool)
+	// Save records the result of a completed run
+	Save(key RunKey, result types.SimulationResult) error
+}
+
+// ErrSweepMismatch is returned when a store file was written by a different sweep
+var ErrSweepMismatch = errors.New("result store belongs to a different sweep")
+
+// Header identifies the sweep a store file belongs to
+// Results are only reused by a sweep with the same header, since a run's result depends on the
+// base configuration and limits as much as on its key
+type Header struct {
+	ConfigHash   string // types.SimulationConfig.Hash of the base configuration
+	MaxTimeSteps int    // step limit of every run
+	WarmStart    bool   // runs continue from the baseline's equilibrium
+}
+
+// headerRecord is the first line of a JSONL store
+type headerRecord struct {
+	Header *Header
+}
+
+// record is a single line of a JSONL store
+type record struct {
+	Key    RunKey
+	Result types.SimulationResult
+}
+
+// JSONLStore is a ResultStore backed by an append-only file with one JSON record per line
+// Each result is written and synced as soon as its run completes, so at most the run in
+// progress is lost if the process dies. The first line is the header of the sweep the file
+// belongs to
+type JSONLStore struct {
+	mu      sync.Mutex
+	file    *os.File
+	results map[RunKey]types.SimulationResult
+}
+
+// OpenJSONLStore opens the store of the sweep identified by header at path, loading any results
+// recorded by earlier runs
+// The file is created with the header if it does not exist or is empty. A file with a different
+// header, or with results but no header, is refused with ErrSweepMismatch rather than resumed
+// with results of another sweep. A truncated final line, left by a process that died
+// mid-write, is discarded
+func OpenJSONLStore(path string, header Header) (*JSONLStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result store: %w", err)
+	}
+
+	stored, results, validSize, err := readRecords(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read result store %s: %w", path, err)
+	}
+	switch {
+	case stored == nil && len(results) > 0:
+		file.Close()
+		return nil, fmt.Errorf("%w: %s has no sweep header", ErrSweepMismatch, path)
+	case stored != nil && *stored != header:
+		file.Close()
+		return nil, fmt.Errorf("%w: %s holds runs of configuration %.12s with a limit of %d steps (warm start %t), not %.12s with %d steps (warm start %t)",
+			ErrSweepMismatch, path, stored.ConfigHash, stored.MaxTimeSteps, stored.WarmStart, header.ConfigHash, header.MaxTimeSteps, header.WarmStart)
+	case stored == nil:
+		// Start the file over with the header
+		validSize = 0
+	}
+
+	// Drop any partial trailing record and append after the last complete one
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to repair result store: %w", err)
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to repair result store: %w", err)
+	}
+
+	s := &JSONLStore{file: file, results: results}
+	if stored == nil {
+		data, err := json.Marshal(headerRecord{Header: &header})
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to encode result store header: %w", err)
+		}
+		if err := s.write(append(data, '\n')); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// readRecords decodes the header and all complete records and returns the size of the valid
+// prefix of the file
+// The header is nil if the first complete line is not one
+func readRecords(reader io.Reader) (*Header, map[RunKey]types.SimulationResult, int64, error) {
+	var header *Header
+	results := make(map[RunKey]types.SimulationResult)
+	buffered := bufio.NewReader(reader)
+	var validSize int64
+
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := buffered.ReadBytes('\n')
+		if err == io.EOF {
+			// A final line without a newline was never completely written
+			return header, results, validSize, nil
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if header == nil && len(results) == 0 {
+				var head headerRecord
+				if err := json.Unmarshal(trimmed, &head); err != nil {
+					return nil, nil, 0, fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+				if head.Header != nil {
+					header = head.Header
+					validSize += int64(len(line))
+					continue
+				}
+			}
+			var rec record
+			if err := json.Unmarshal(trimmed, &rec); err != nil {
+				return nil, nil, 0, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			results[rec.Key] = rec.Result
+		}
+		validSize += int64(len(line))
+	}
+}
+
+// Lookup returns the stored result for a run, if it has completed before
+func (s *JSONLStore) Lookup(key RunKey) (types.SimulationResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, exists := s.results[key]
+	return result, exists
+}
+
+// Save appends the result of a completed run and syncs it to disk
+func (s *JSONLStore) Save(key RunKey, result types.SimulationResult) error {
+	data, err := json.Marshal(record{Key: key, Result: result})
+	if err != nil {
+		return fmt.Errorf("failed to encode result for %s=%g: %w", key.Parameter, key.Value, err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.write(data); err != nil {
+		return err
+	}
+	s.results[key] = result
+	return nil
+}
+
+// write writes encoded lines and syncs them to disk
+func (s *JSONLStore) write(data []byte) error {
+	if _, err := s.file.Write(data); err != nil {
+		return fmt.Errorf("failed to write result store: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync result store: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of completed runs in the store
+func (s *JSONLStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.results)
+}
+
+// Close closes the underlying file
+func (s *JSONLStore) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// testHeader identifies the sweep of the test stores
+var testHeader = Header{ConfigHash: "4f1c9e", MaxTimeSteps: 200}
+
+func TestJSONLStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweep.jsonl")
+
+	s, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed: %v", err)
+	}
+	key := RunKey{Parameter: "FixedBudget", Value: 1500000, Seed: 42}
+	if _, exists := s.Lookup(key); exists {
+		t.Fatal("Expected an empty store")
+	}
+	result := types.SimulationResult{TimeToEquilibrium: 17, ReachedEquilibrium: true}
+	if err := s.Save(key, result); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed: %v", err)
+	}
+	defer reopened.Close()
+
+	got, exists := reopened.Lookup(key)
+	if !exists {
+		t.Fatal("Expected the saved run to be found after reopening")
+	}
+	if got.TimeToEquilibrium != 17 || !got.ReachedEquilibrium {
+		t.Errorf("Expected the saved result, got %+v", got)
+	}
+	if _, exists := reopened.Lookup(RunKey{Parameter: "FixedBudget", Value: 1500000, Seed: 43}); exists {
+		t.Error("Expected runs with a different seed not to match")
+	}
+}
+
+func TestJSONLStoreDiscardsTruncatedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweep.jsonl")
+
+	s, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed: %v", err)
+	}
+	if err := s.Save(RunKey{Parameter: "A", Value: 1, Seed: 1}, types.SimulationResult{TimeToEquilibrium: 5}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	s.Close()
+
+	// Simulate a process that died while writing the second record
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open store file: %v", err)
+	}
+	f.WriteString(`{"Key":{"Parameter":"A","Value":2`)
+	f.Close()
+
+	reopened, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed on a truncated file: %v", err)
+	}
+	if reopened.Len() != 1 {
+		t.Errorf("Expected 1 complete record, got %d", reopened.Len())
+	}
+	if err := reopened.Save(RunKey{Parameter: "A", Value: 2, Seed: 1}, types.SimulationResult{}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reopened.Close()
+
+	final, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("Expected the repaired store to be readable: %v", err)
+	}
+	defer final.Close()
+	if final.Len() != 2 {
+		t.Errorf("Expected 2 records after repair, got %d", final.Len())
+	}
+}
+
+func TestJSONLStoreRefusesAnotherSweep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweep.jsonl")
+
+	s, err := OpenJSONLStore(path, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed: %v", err)
+	}
+	if err := s.Save(RunKey{Parameter: "A", Value: 1, Seed: 1}, types.SimulationResult{TimeToEquilibrium: 5}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	s.Close()
+
+	for _, header := range []Header{
+		{ConfigHash: "7a20d3", MaxTimeSteps: 200},
+		{ConfigHash: testHeader.ConfigHash, MaxTimeSteps: 100},
+		{ConfigHash: testHeader.ConfigHash, MaxTimeSteps: 200, WarmStart: true},
+	} {
+		if _, err := OpenJSONLStore(path, header); !errors.Is(err, ErrSweepMismatch) {
+			t.Errorf("Expected %+v to be refused with ErrSweepMismatch, got %v", header, err)
+		}
+	}
+
+	// A file of results without a header may be from any sweep
+	legacy := filepath.Join(t.TempDir(), "legacy.jsonl")
+	if err := os.WriteFile(legacy, []byte(`{"Key":{"Parameter":"A","Value":1,"Seed":1},"Result":{}}`+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write store file: %v", err)
+	}
+	if _, err := OpenJSONLStore(legacy, testHeader); !errors.Is(err, ErrSweepMismatch) {
+		t.Errorf("Expected a store without a header to be refused, got %v", err)
+	}
+
+	// An empty file is started with the header
+	empty := filepath.Join(t.TempDir(), "empty.jsonl")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatalf("failed to write store file: %v", err)
+	}
+	s, err = OpenJSONLStore(empty, testHeader)
+	if err != nil {
+		t.Fatalf("OpenJSONLStore failed on an empty file: %v", err)
+	}
+	s.Close()
+	if _, err := OpenJSONLStore(empty, Header{ConfigHash: "7a20d3"}); !errors.Is(err, ErrSweepMismatch) {
+		t.Errorf("Expected the header written to an empty file to be checked, got %v", err)
+	}
+}
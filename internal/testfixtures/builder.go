@@ -0,0 +1,224 @@
+// Package testfixtures builds workforces, and controllers running them, in arbitrary
+// mid-simulation states, so tests of analytics and optimization can set up the state they need
+// without running full simulations to reach it
+package testfixtures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/testutil"
+	"workforce-ai-transition-simulator/internal/types"
+	"workforce-ai-transition-simulator/internal/workforce"
+)
+
+// WorkforceBuilder describes a workforce to build, e.g.
+//
+//	NewWorkforceBuilder().WithHumans(3, types.Senior, types.HighCostUS).WithAgents(10, types.MidLevel)
+//
+// The first human added is the business owner. Agents are assigned to the humans in turn, as a
+// rebalanced workforce would be, skipping humans whose orchestration capacity is used up, unless
+// added to a given human. They are hired as the controller hires them, into the domains and
+// vendor tiers of the configuration, and priced at their tier's cost of their level
+type WorkforceBuilder struct {
+	config   types.SimulationConfig
+	seed     int64
+	timeStep int
+	humans   []humanGroup
+	agents   []agentGroup
+}
+
+// humanGroup is a number of humans of the same level, region and contract
+type humanGroup struct {
+	count    int
+	level    types.ExperienceLevel
+	category types.CostCategory
+	contract types.ContractType
+}
+
+// agentGroup is a number of AI agents of the same level, orchestrated by the human at index
+// orchestrator, or by the humans in turn if it is negative
+type agentGroup struct {
+	count        int
+	level        types.ExperienceLevel
+	orchestrator int
+}
+
+// NewWorkforceBuilder returns a builder of an empty workforce at time step 0, simulated under
+// testutil.CanonicalConfig with testutil.CanonicalSeed
+func NewWorkforceBuilder() *WorkforceBuilder {
+	return &WorkforceBuilder{config: testutil.CanonicalConfig(), seed: testutil.CanonicalSeed}
+}
+
+// WithConfig sets the configuration the workforce is priced and simulated under
+// Its initial workforce parameters are ignored, as the builder sets the workforce
+func (b *WorkforceBuilder) WithConfig(config types.SimulationConfig) *WorkforceBuilder {
+	b.config = config
+	return b
+}
+
+// WithSeed sets the seed of the random streams of built controllers
+func (b *WorkforceBuilder) WithSeed(seed int64) *WorkforceBuilder {
+	b.seed = seed
+	return b
+}
+
+// AtTimeStep sets the time step the workforce is at, which built controllers continue from
+func (b *WorkforceBuilder) AtTimeStep(step int) *WorkforceBuilder {
+	b.timeStep = step
+	return b
+}
+
+// WithHumans adds full-time humans
+func (b *WorkforceBuilder) WithHumans(count int, level types.ExperienceLevel, category types.CostCategory) *WorkforceBuilder {
+	return b.WithContractHumans(count, level, category, types.FullTime)
+}
+
+// WithContractHumans adds humans employed under a contract type
+func (b *WorkforceBuilder) WithContractHumans(count int, level types.ExperienceLevel, category types.CostCategory, contract types.ContractType) *WorkforceBuilder {
+	b.humans = append(b.humans, humanGroup{count: count, level: level, category: category, contract: contract})
+	return b
+}
+
+// WithAgents adds AI agents that have learned up to level
+func (b *WorkforceBuilder) WithAgents(count int, level types.ExperienceLevel) *WorkforceBuilder {
+	return b.WithOrchestratedAgents(-1, count, level)
+}
+
+// WithOrchestratedAgents adds AI agents that have learned up to level, orchestrated by the
+// human at index human in the order humans are added; a negative index assigns them in turn
+func (b *WorkforceBuilder) WithOrchestratedAgents(human int, count int, level types.ExperienceLevel) *WorkforceBuilder {
+	b.agents = append(b.agents, agentGroup{count: count, level: level, orchestrator: human})
+	return b
+}
+
+// Manager builds the workforce in a new WorkforceManager
+// Returns an error if there are no humans or the agents exceed their orchestration capacity
+func (b *WorkforceBuilder) Manager() (*workforce.WorkforceManager, error) {
+	regions := b.config.RegionTable()
+	wm := workforce.NewWorkforceManager()
+	for _, group := range b.humans {
+		if int(group.category) >= len(regions) {
+			return nil, fmt.Errorf("cost category %d is not a region of the configuration", group.category)
+		}
+		for i := 0; i < group.count; i++ {
+			human, err := wm.AddHuman(group.level, group.category, len(wm.GetAllHumans()) == 0)
+			if err != nil {
+				return nil, err
+			}
+			human.ApplyEmployment(regions.Region(group.category), group.contract, b.config.Contracts)
+		}
+	}
+
+	humans := wm.GetAllHumans()
+	if len(humans) == 0 {
+		return nil, errors.New("a workforce needs at least one human")
+	}
+	next := 0
+	domains := make([]int, len(b.config.Domains))
+	vendors := make([]int, len(b.config.Vendors.Tiers))
+	for _, group := range b.agents {
+		if group.orchestrator >= len(humans) {
+			return nil, fmt.Errorf("AI agents are orchestrated by human %d of %d", group.orchestrator, len(humans))
+		}
+		for i := 0; i < group.count; i++ {
+			// The given human, or else the next human in turn with capacity left, orchestrates
+			// the agent
+			orchestrator := group.orchestrator
+			for tried := 0; tried < len(humans) && orchestrator < 0; tried++ {
+				if candidate := (next + tried) % len(humans); humans[candidate].CanOrchestrateMoreAgents() {
+					orchestrator = candidate
+				}
+			}
+			if orchestrator < 0 {
+				return nil, fmt.Errorf("%d humans cannot orchestrate %d AI agents", len(humans), b.agentCount())
+			}
+			if group.orchestrator < 0 {
+				next = orchestrator + 1
+			}
+			agent, err := wm.AddAIAgent(humans[orchestrator].ID, b.timeStep)
+			if err != nil {
+				return nil, err
+			}
+			if len(domains) > 0 {
+				agent.Domain = b.config.Domains.Assign(domains)
+				domains[agent.Domain]++
+			}
+			if len(vendors) > 0 {
+				vendor := b.config.Vendors.Select(vendors)
+				agent.ApplyVendor(vendor, b.config.Vendors.Tiers.Tier(vendor))
+				vendors[vendor]++
+			}
+			agent.SetLevel(group.level)
+		}
+	}
+	return wm, nil
+}
+
+// Snapshot builds the workforce as a snapshot
+func (b *WorkforceBuilder) Snapshot() (workforce.Snapshot, error) {
+	wm, err := b.Manager()
+	if err != nil {
+		return workforce.Snapshot{}, err
+	}
+	return wm.Snapshot(), nil
+}
+
+// Checkpoint builds a checkpoint of a run at the builder's time step with the workforce
+// Its time series is a placeholder for the state at the time step; Controller records the state
+// the workforce is actually in
+func (b *WorkforceBuilder) Checkpoint() (controller.Checkpoint, error) {
+	snapshot, err := b.Snapshot()
+	if err != nil {
+		return controller.Checkpoint{}, err
+	}
+	return controller.Checkpoint{
+		Config:          b.config,
+		Seed:            b.seed,
+		CurrentTimeStep: b.timeStep,
+		StartTimeStep:   b.timeStep,
+		TimeSeries:      []types.SimulationState{{TimeStep: b.timeStep}},
+		Workforce:       snapshot,
+	}, nil
+}
+
+// Controller builds a controller whose run starts at the builder's time step with the workforce,
+// as a warm start would, recording its state as the initial state of the time series
+// Stepping it continues the run from there
+func (b *WorkforceBuilder) Controller(opts ...controller.Option) (*controller.SimulationController, error) {
+	checkpoint, err := b.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	return controller.WarmStartSimulationController(checkpoint, b.config, opts...)
+}
+
+// MustManager is Manager failing the test on an error
+func (b *WorkforceBuilder) MustManager(t testing.TB) *workforce.WorkforceManager {
+	t.Helper()
+	wm, err := b.Manager()
+	if err != nil {
+		t.Fatalf("failed to build workforce: %v", err)
+	}
+	return wm
+}
+
+// MustController is Controller failing the test on an error
+func (b *WorkforceBuilder) MustController(t testing.TB, opts ...controller.Option) *controller.SimulationController {
+	t.Helper()
+	sc, err := b.Controller(opts...)
+	if err != nil {
+		t.Fatalf("failed to build controller: %v", err)
+	}
+	return sc
+}
+
+// agentCount returns the number of AI agents added
+func (b *WorkforceBuilder) agentCount() int {
+	count := 0
+	for _, group := range b.agents {
+		count += group.count
+	}
+	return count
+}
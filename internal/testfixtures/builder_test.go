@@ -0,0 +1,94 @@
+package testfixtures
+
+import (
+	"testing"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+func TestManagerBuildsWorkforce(t *testing.T) {
+	wm := NewWorkforceBuilder().
+		WithHumans(3, types.Senior, types.HighCostUS).
+		WithContractHumans(1, types.MidLevel, types.LowCostNonUS, types.Contractor).
+		WithAgents(6, types.MidLevel).
+		WithAgents(2, types.Executive).
+		MustManager(t)
+
+	composition := wm.GetWorkforceComposition()
+	if composition.Humans.Total != 4 || composition.Humans.ByExperience[types.Senior] != 3 || composition.Humans.ByContractType[types.Contractor] != 1 {
+		t.Errorf("Unexpected humans %+v", composition.Humans)
+	}
+	if composition.AIAgents.Total != 8 || composition.AIAgents.ByExperience[types.MidLevel] != 6 || composition.AIAgents.ByExperience[types.Executive] != 2 {
+		t.Errorf("Unexpected agents %+v", composition.AIAgents)
+	}
+	owner, err := wm.GetBusinessOwner()
+	if err != nil || owner.ID != wm.GetAllHumans()[0].ID {
+		t.Errorf("Expected the first human to own the business, got %v, %v", owner, err)
+	}
+	for _, human := range wm.GetAllHumans() {
+		if len(human.AssignedAgents) != 2 {
+			t.Errorf("Expected the agents to be spread evenly, %s has %d", human.ID, len(human.AssignedAgents))
+		}
+	}
+	for _, agent := range wm.GetAllAIAgents() {
+		if agent.Cost != types.AIAgentCosts[agent.ExperienceLevel] {
+			t.Errorf("Expected agent %s to cost as its level, got %v", agent.ID, agent.Cost)
+		}
+	}
+}
+
+func TestManagerHiresAgentsAsTheController(t *testing.T) {
+	config := NewWorkforceBuilder().config
+	config.Vendors = types.VendorConfig{Tiers: types.VendorTable{
+		{Name: "Frontier", Share: 50, CostMultiplier: 2, ProductivityMultiplier: 1.5},
+		{Name: "Open_Source", Share: 50, CostMultiplier: 0.5, ProductivityMultiplier: 0.8},
+	}}
+	wm := NewWorkforceBuilder().
+		WithConfig(config).
+		WithHumans(3, types.Senior, types.HighCostUS).
+		WithAgents(2, types.Senior).
+		WithOrchestratedAgents(2, 3, types.MidLevel).
+		MustManager(t)
+
+	agents := wm.GetAllAIAgents()
+	for i, multiplier := range []float64{2, 0.5, 2, 0.5, 2} {
+		if want := types.AIAgentCosts[agents[i].ExperienceLevel] * multiplier; agents[i].Vendor != i%2 || agents[i].Cost != want {
+			t.Errorf("Expected agent %s from tier %d at %v, got tier %d at %v", agents[i].ID, i%2, want, agents[i].Vendor, agents[i].Cost)
+		}
+	}
+	humans := wm.GetAllHumans()
+	if len(humans[0].AssignedAgents) != 1 || len(humans[1].AssignedAgents) != 1 || len(humans[2].AssignedAgents) != 3 {
+		t.Errorf("Expected the mid-level agents orchestrated by the third human, got %v, %v and %v",
+			humans[0].AssignedAgents, humans[1].AssignedAgents, humans[2].AssignedAgents)
+	}
+}
+
+func TestManagerRejectsImpossibleWorkforces(t *testing.T) {
+	if _, err := NewWorkforceBuilder().WithAgents(1, types.UniversityHire).Manager(); err == nil {
+		t.Error("Expected agents without humans to be rejected")
+	}
+	if _, err := NewWorkforceBuilder().WithHumans(1, types.Senior, types.HighCostUS).WithAgents(types.OrchestrationLimit+1, types.Senior).Manager(); err == nil {
+		t.Error("Expected agents beyond orchestration capacity to be rejected")
+	}
+	if _, err := NewWorkforceBuilder().WithHumans(1, types.Senior, types.HighCostUS).WithOrchestratedAgents(1, 1, types.Senior).Manager(); err == nil {
+		t.Error("Expected agents of a human not in the workforce to be rejected")
+	}
+}
+
+func TestControllerStartsMidSimulation(t *testing.T) {
+	sc := NewWorkforceBuilder().
+		WithHumans(4, types.MidLevel, types.HighCostUS).
+		WithAgents(12, types.Senior).
+		AtTimeStep(24).
+		MustController(t)
+
+	initial := sc.GetTimeSeries()[0]
+	if initial.TimeStep != 24 || initial.Workforce.Humans.Total != 4 || initial.Workforce.AIAgents.ByExperience[types.Senior] != 12 {
+		t.Errorf("Expected the built workforce as the state at step 24, got step %d with %+v", initial.TimeStep, initial.Workforce)
+	}
+	if initial.TotalCost <= 0 || initial.RevenueOutput <= 0 {
+		t.Errorf("Expected the state to be priced, got cost %v and revenue %v", initial.TotalCost, initial.RevenueOutput)
+	}
+	if state := sc.Step(); state.TimeStep != 25 {
+		t.Errorf("Expected the run to continue from step 24, got %d", state.TimeStep)
+	}
+}
@@ -0,0 +1,147 @@
+// Package testutil provides helpers shared by the simulator's tests
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// CanonicalSeed is the seed golden simulations run with
+const CanonicalSeed int64 = 20240101
+
+// CanonicalMaxTimeSteps bounds golden simulations so golden files stay small
+const CanonicalMaxTimeSteps = 60
+
+// CanonicalConfig returns the reference configuration for golden-file regression tests
+// It is a small team on explosive growth with every stochastic process active, so most
+// changes to the model show up in its results
+func CanonicalConfig() types.SimulationConfig {
+	return types.SimulationConfig{
+		InitialHumans: 12,
+		ExperienceDistribution: types.ExperienceDistribution{
+			UniversityHire: 40.0,
+			MidLevel:       30.0,
+			Senior:         20.0,
+			Executive:      10.0,
+		},
+		CostCategoryDistribution: types.CostCategoryDistribution{
+			HighCostUS:   60.0,
+			LowCostNonUS: 40.0,
+		},
+		FixedBudget:     3000000.0,
+		RevenueScenario: types.ExplosiveGrowth,
+		DiscountRate:    0.1,
+		AILearningSpeeds: types.AILearningSpeed{
+			UniversityToMid:   10,
+			MidToSenior:       15,
+			SeniorToExecutive: 20,
+		},
+		AttritionConfig: types.AttritionConfig{
+			Type:               types.NaturalAttrition,
+			NaturalRate:        15.0,
+			ForcedAcceleration: 1.0,
+		},
+		Backfill: types.BackfillConfig{
+			Fraction:       0.5,
+			LagSteps:       3,
+			RecruitingCost: 15000,
+		},
+		CatastrophicFailureRate: 0.05,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+// RunSimulation runs config with a fixed seed until equilibrium or maxTimeSteps, failing
+// the test if the simulation returns an error or violates an invariant of the model
+func RunSimulation(t testing.TB, config types.SimulationConfig, seed int64, maxTimeSteps int) types.SimulationResult {
+	t.Helper()
+
+	result, err := controller.NewSimulationController(config, seed, controller.WithInvariantChecks()).RunUntilEquilibrium(maxTimeSteps)
+	if err != nil {
+		t.Fatalf("simulation failed: %v", err)
+	}
+	return result
+}
+
+// GoldenPath returns the path of the named golden file in the calling package's testdata directory
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden.json")
+}
+
+// AssertGolden compares value, encoded as indented JSON, against the named golden file
+// With update the golden file is rewritten instead; packages with golden tests pass their own
+// -update flag, e.g. `go test ./internal/controller -run Golden -update` after an intentional
+// model change. On a mismatch the test fails with the first differing line, so the behavioral
+// change can be located and reviewed
+func AssertGolden(t testing.TB, name string, value interface{}, update bool) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode %s: %v", name, err)
+	}
+	got = append(got, '\n')
+
+	path := GoldenPath(name)
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to write golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with -update to create it): %v", path, err)
+	}
+	if bytes.Equal(want, got) {
+		return
+	}
+
+	line, wantLine, gotLine := firstDifference(string(want), string(got))
+	t.Errorf("%s differs from golden file %s at line %d:\n  want: %s\n  got:  %s\n"+
+		"If the change is intentional, rerun with -update and review the golden diff",
+		name, path, line, wantLine, gotLine)
+}
+
+// firstDifference returns the 1-based number and contents of the first line where want and
+// got differ; a missing line is reported as "<end of file>"
+func firstDifference(want, got string) (int, string, string) {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	lineAt := func(lines []string, i int) string {
+		if i < len(lines) {
+			return strings.TrimSpace(lines[i])
+		}
+		return "<end of file>"
+	}
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		if i >= len(wantLines) || i >= len(gotLines) || wantLines[i] != gotLines[i] {
+			return i + 1, lineAt(wantLines, i), lineAt(gotLines, i)
+		}
+	}
+	return 0, "", ""
+}
+
+// RunGolden runs config with seed for at most CanonicalMaxTimeSteps and compares the full
+// SimulationResult against the named golden file, rewriting it instead with update
+// The run ID and timestamp differ between runs and are cleared before the comparison
+func RunGolden(t testing.TB, name string, config types.SimulationConfig, seed int64, update bool) {
+	t.Helper()
+	result := RunSimulation(t, config, seed, CanonicalMaxTimeSteps)
+	result.Metadata.RunID = ""
+	result.Metadata.Timestamp = time.Time{}
+	AssertGolden(t, name, result, update)
+}
@@ -0,0 +1,35 @@
+package testutil
+
+import "testing"
+
+func TestFirstDifference(t *testing.T) {
+	cases := []struct {
+		name     string
+		want     string
+		got      string
+		line     int
+		wantLine string
+		gotLine  string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", 0, "", ""},
+		{"changed line", "a\n  b\nc\n", "a\n  x\nc\n", 2, "b", "x"},
+		{"extra line", "a\n", "a\nb\n", 2, "", "b"},
+		{"missing line", "a\nb", "a", 2, "b", "<end of file>"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, wantLine, gotLine := firstDifference(tc.want, tc.got)
+			if line != tc.line || wantLine != tc.wantLine || gotLine != tc.gotLine {
+				t.Errorf("Expected (%d, %q, %q), got (%d, %q, %q)",
+					tc.line, tc.wantLine, tc.gotLine, line, wantLine, gotLine)
+			}
+		})
+	}
+}
+
+func TestCanonicalConfigIsValid(t *testing.T) {
+	if err := CanonicalConfig().Validate(); err != nil {
+		t.Errorf("Expected canonical configuration to be valid, got %v", err)
+	}
+}
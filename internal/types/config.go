@@ -1,5 +1,12 @@
 package types
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
 // ExperienceDistribution defines the percentage distribution of workers across experience levels
 type ExperienceDistribution struct {
 	UniversityHire float64 // percentage (0-100)
@@ -14,6 +21,333 @@ type CostCategoryDistribution struct {
 	LowCostNonUS  float64 // percentage (0-100)
 }
 
+// Region describes a geographic location human workers are hired in, such as a country or a
+// group of countries with similar costs and working hours
+type Region struct {
+	Name                 string
+	Share                float64 // percentage of initial humans located in the region (0-100)
+	CostMultiplier       float64 // annual cost as a multiple of the High_Cost_US base cost (default 1)
+	TimeZoneInefficiency float64 // productivity penalty for workers in the region (0-1)
+	AttritionModifier    float64 // multiplier on the natural attrition rate (default 1)
+}
+
+// AnnualCost returns the annual full-time cost of a worker at the given level in the region
+func (r Region) AnnualCost(level ExperienceLevel) float64 {
+	multiplier := r.CostMultiplier
+	if multiplier == 0 {
+		multiplier = 1.0
+	}
+	return BaseCosts[level][HighCostUS] * multiplier
+}
+
+// AttritionMultiplier returns the factor applied to the natural attrition rate in the region
+func (r Region) AttritionMultiplier() float64 {
+	if r.AttritionModifier == 0 {
+		return 1.0
+	}
+	return r.AttritionModifier
+}
+
+// RegionTable lists the regions of a simulation; a worker's CostCategory indexes the table
+type RegionTable []Region
+
+// lowCostNonUSCostMultiplier is the cost of Low_Cost_Non_US workers relative to High_Cost_US
+const lowCostNonUSCostMultiplier = 0.4
+
+// DefaultRegions returns the two-region table of the High_Cost_US and Low_Cost_Non_US cost
+// categories, used when a configuration defines no regions
+func DefaultRegions(distribution CostCategoryDistribution, timeZoneInefficiency float64) RegionTable {
+	return RegionTable{
+		HighCostUS: {
+			Name:           HighCostUS.String(),
+			Share:          distribution.HighCostUS,
+			CostMultiplier: 1.0,
+		},
+		LowCostNonUS: {
+			Name:                 LowCostNonUS.String(),
+			Share:                distribution.LowCostNonUS,
+			CostMultiplier:       lowCostNonUSCostMultiplier,
+			TimeZoneInefficiency: timeZoneInefficiency,
+		},
+	}
+}
+
+// Region returns the region of a cost category
+// Categories outside the table fall back to the default regions without a time zone penalty
+func (t RegionTable) Region(category CostCategory) Region {
+	if category >= 0 && int(category) < len(t) {
+		return t[category]
+	}
+	if category == LowCostNonUS {
+		return Region{Name: category.String(), CostMultiplier: lowCostNonUSCostMultiplier}
+	}
+	return Region{Name: category.String()}
+}
+
+// Name returns the name of the region of a cost category
+func (t RegionTable) Name(category CostCategory) string {
+	return t.Region(category).Name
+}
+
+// ContractConfig defines the contract mix of the initial workforce and the terms of non-FTE
+// contracts. With zero shares every human is a full-time employee; zero terms use the defaults
+type ContractConfig struct {
+	ContractorShare          float64 // percentage of initial humans hired as contractors (0-100)
+	PartTimeShare            float64 // percentage of initial humans hired part-time (0-100)
+	ContractorRateMultiplier float64 // contractor hourly rate as a multiple of the equivalent FTE hourly cost (default 1)
+	ContractorHoursPerYear   float64 // hours billed by each contractor per year (default StandardHoursPerYear)
+	PartTimeFraction         float64 // part-time hours as a fraction of full time (0-1, default 0.5)
+}
+
+// HourlyRate returns the contractor hourly rate for a worker whose full-time annual cost is
+// fullTimeCost
+func (c ContractConfig) HourlyRate(fullTimeCost float64) float64 {
+	multiplier := c.ContractorRateMultiplier
+	if multiplier == 0 {
+		multiplier = 1.0
+	}
+	return fullTimeCost / StandardHoursPerYear * multiplier
+}
+
+// HoursPerYear returns the hours worked per year under a contract type
+func (c ContractConfig) HoursPerYear(contract ContractType) float64 {
+	switch contract {
+	case Contractor:
+		if c.ContractorHoursPerYear > 0 {
+			return c.ContractorHoursPerYear
+		}
+	case PartTime:
+		if c.PartTimeFraction > 0 {
+			return StandardHoursPerYear * c.PartTimeFraction
+		}
+		return StandardHoursPerYear * 0.5
+	}
+	return StandardHoursPerYear
+}
+
+// AnnualCost returns the annual cost under a contract type of a worker whose full-time annual
+// cost is fullTimeCost
+// Employees are salaried pro rata to their hours; contractors bill their hourly rate
+func (c ContractConfig) AnnualCost(fullTimeCost float64, contract ContractType) float64 {
+	switch contract {
+	case Contractor:
+		return c.HourlyRate(fullTimeCost) * c.HoursPerYear(Contractor)
+	case PartTime:
+		return fullTimeCost * c.HoursPerYear(PartTime) / StandardHoursPerYear
+	default:
+		return fullTimeCost
+	}
+}
+
+// OverheadConfig loads salaries and agent subscriptions with the costs that come on top of them,
+// so human and AI costs compare like for like. A zero multiplier is treated as 1.0 (no overhead)
+type OverheadConfig struct {
+	Employer         float64 // multiplier on employee pay for payroll tax, benefits and office space
+	AIInfrastructure float64 // multiplier on AI agent cost for compute, tooling and integration
+}
+
+// HumanCost returns the fully loaded annual cost of a human paid baseCost under a contract type
+// Contractors bill an all-in hourly rate, so the employer overhead does not apply to them
+func (o OverheadConfig) HumanCost(baseCost float64, contract ContractType) float64 {
+	if contract == Contractor || o.Employer == 0 {
+		return baseCost
+	}
+	return baseCost * o.Employer
+}
+
+// AICost returns the fully loaded annual cost of an AI agent costing cost
+func (o OverheadConfig) AICost(cost float64) float64 {
+	if o.AIInfrastructure == 0 {
+		return cost
+	}
+	return cost * o.AIInfrastructure
+}
+
+// MarketShock is an exogenous change in market conditions at a given time step, such as a
+// demand drop, a fall in AI costs or a salary inflation spike
+// Shocks are permanent: each one changes conditions from its time step on, compounding with
+// earlier shocks of the same type
+type MarketShock struct {
+	TimeStep int
+	Type     ShockType
+	Change   float64 // percentage change, e.g. -30 for a 30% demand drop or -50 for AI costs halving
+}
+
+// String describes the shock, e.g. "Demand_Shock of -30%"
+func (s MarketShock) String() string {
+	return fmt.Sprintf("%s of %+g%%", s.Type, s.Change)
+}
+
+// MarketShocks is the schedule of market shocks of a simulation
+type MarketShocks []MarketShock
+
+// MarketConditions are the factors market shocks apply to revenue and costs
+type MarketConditions struct {
+	Demand float64 // multiplier on revenue
+	AICost float64 // multiplier on AI agent costs
+	Salary float64 // multiplier on human costs
+}
+
+// NormalMarket returns the market conditions before any shock
+func NormalMarket() MarketConditions {
+	return MarketConditions{Demand: 1.0, AICost: 1.0, Salary: 1.0}
+}
+
+// Conditions returns the market conditions at a time step, after every shock up to and
+// including that step
+func (m MarketShocks) Conditions(timeStep int) MarketConditions {
+	conditions := NormalMarket()
+	for _, shock := range m {
+		if shock.TimeStep > timeStep {
+			continue
+		}
+		factor := 1.0 + shock.Change/100.0
+		switch shock.Type {
+		case DemandShock:
+			conditions.Demand *= factor
+		case AICostShock:
+			conditions.AICost *= factor
+		case SalaryInflation:
+			conditions.Salary *= factor
+		}
+	}
+	return conditions
+}
+
+// At returns the shocks that occur at a time step, in schedule order
+func (m MarketShocks) At(timeStep int) []MarketShock {
+	var shocks []MarketShock
+	for _, shock := range m {
+		if shock.TimeStep == timeStep {
+			shocks = append(shocks, shock)
+		}
+	}
+	return shocks
+}
+
+// Pending reports whether any shock occurs after a time step
+func (m MarketShocks) Pending(timeStep int) bool {
+	for _, shock := range m {
+		if shock.TimeStep > timeStep {
+			return true
+		}
+	}
+	return false
+}
+
+// AICostCurve models AI prices changing steadily over time, as model prices have fallen year
+// on year, compounding an annual change from time step 0. It applies on top of AI cost shocks.
+// Off by default: a zero AnnualChange keeps AI prices static
+type AICostCurve struct {
+	AnnualChange float64 // percentage change in AI agent costs per year, e.g. -20 (more than -100)
+	Floor        float64 // lowest multiple of the starting price a falling curve reaches (0-1, 0 = no floor)
+}
+
+// Factor returns the multiplier the curve applies to AI agent costs at a time step
+func (a AICostCurve) Factor(timeStep int) float64 {
+	if a.AnnualChange == 0 {
+		return 1.0
+	}
+	factor := math.Pow(1.0+a.AnnualChange/100.0, float64(timeStep)/TimeStepsPerYear)
+	if a.AnnualChange < 0 && factor < a.Floor {
+		return a.Floor
+	}
+	return factor
+}
+
+// VolumeDiscount is a tier of AI pricing: a discount on every agent once the fleet is large enough
+type VolumeDiscount struct {
+	MinAgents int     // fleet size from which the discount applies
+	Discount  float64 // fraction off the cost of every agent in the fleet (0-1)
+}
+
+// AIPricingConfig models AI licensing that prices the fleet rather than each agent alone: a
+// platform fee is the least the fleet costs, as under seat minimums, and volume discounts lower
+// the cost of every agent as the fleet grows. Off by default: with no fee and no discounts every
+// agent costs its own price
+type AIPricingConfig struct {
+	PlatformFee float64          // minimum annual AI spend while any agent is deployed, before market factors
+	Discounts   []VolumeDiscount // volume discount tiers; the largest discount the fleet qualifies for applies
+}
+
+// Enabled returns whether the pricing changes the cost of a fleet
+func (p AIPricingConfig) Enabled() bool {
+	return p.PlatformFee > 0 || len(p.Discounts) > 0
+}
+
+// Discount returns the fraction off the cost of every agent in a fleet of a size
+func (p AIPricingConfig) Discount(agents int) float64 {
+	discount := 0.0
+	for _, tier := range p.Discounts {
+		if agents >= tier.MinAgents && tier.Discount > discount {
+			discount = tier.Discount
+		}
+	}
+	return discount
+}
+
+// FleetCost returns the annual cost of a fleet of agents whose own costs sum to cost, given the
+// market factor on AI costs that scales the platform fee too. An empty fleet costs nothing
+func (p AIPricingConfig) FleetCost(cost float64, agents int, aiCostFactor float64) float64 {
+	if agents <= 0 || !p.Enabled() {
+		return cost
+	}
+	return math.Max(cost*(1.0-p.Discount(agents)), p.PlatformFee*aiCostFactor)
+}
+
+// CashConfig tracks the organization's cash balance, so a run can end by running out of money
+// Every time step adds its revenue and subtracts its costs; the run ends as insolvent when the
+// balance falls below the negative of the credit limit. Off by default, leaving only the
+// FixedBudget constraint on spending
+type CashConfig struct {
+	Enabled        bool
+	InitialBalance float64 // cash on hand at the start of the run
+	CreditLimit    float64 // how far the balance may fall below zero before insolvency
+}
+
+// Insolvent reports whether a cash balance is beyond the credit limit
+// Always false when cash tracking is disabled
+func (c CashConfig) Insolvent(balance float64) bool {
+	return c.Enabled && balance < -c.CreditLimit
+}
+
+// DefaultRepaymentSteps is the number of time steps over which debt is repaid when
+// FinancingConfig.RepaymentSteps is not set
+const DefaultRepaymentSteps = 24
+
+// FinancingConfig lets the organization borrow to fund a workforce beyond its fixed budget
+// The optimizer may spend up to the fixed budget plus the unused credit line per year, less
+// the annualized debt service. Each step, spending the fixed budget cannot cover is borrowed,
+// and outstanding debt accrues interest and is repaid in equal shares over RepaymentSteps.
+// Off by default
+type FinancingConfig struct {
+	CreditLine     float64 // maximum outstanding debt (0 = no financing)
+	InterestRate   float64 // annual interest rate on outstanding debt (0-1)
+	RepaymentSteps int     // time steps over which debt is repaid (default 24)
+}
+
+// Enabled reports whether the organization may borrow
+func (f FinancingConfig) Enabled() bool {
+	return f.CreditLine > 0
+}
+
+// Repayment returns the principal repaid in a time step on the given outstanding debt
+func (f FinancingConfig) Repayment(debt float64) float64 {
+	steps := f.RepaymentSteps
+	if steps <= 0 {
+		steps = DefaultRepaymentSteps
+	}
+	return debt / float64(steps)
+}
+
+// FinancingState records the organization's borrowing in a time step
+type FinancingState struct {
+	Borrowed float64 // principal borrowed this step
+	Repaid   float64 // principal repaid this step
+	Debt     float64 // outstanding debt after this step
+	Leverage float64 // outstanding debt relative to annual revenue
+}
+
 // AILearningSpeed defines the time steps required for AI agents to progress through experience levels
 type AILearningSpeed struct {
 	UniversityToMid int // time steps required
@@ -21,54 +355,923 @@ type AILearningSpeed struct {
 	SeniorToExecutive int // time steps required
 }
 
+// Threshold returns the experience an agent at a level needs to reach the next one, or +Inf for
+// Executive agents, which are at the top level
+func (s AILearningSpeed) Threshold(level ExperienceLevel) float64 {
+	switch level {
+	case UniversityHire:
+		return float64(s.UniversityToMid)
+	case MidLevel:
+		return float64(s.MidToSenior)
+	case Senior:
+		return float64(s.SeniorToExecutive)
+	default:
+		return math.Inf(1)
+	}
+}
+
+// SharedLearningConfig models a network effect between AI agents: every Senior or Executive
+// agent feeds shared fine-tuning and knowledge bases that speed up the learning of
+// University_Hire and Mid_Level agents. Off by default: a zero Acceleration has no effect
+type SharedLearningConfig struct {
+	Acceleration float64 // learning speedup of junior agents per Senior or Executive agent (e.g. 0.05 = 5%)
+	MaxSpeedup   float64 // cap on the learning speed multiplier of junior agents (default 3)
+}
+
+// Speedup returns the learning speed multiplier of junior agents given the number of Senior
+// and Executive agents; it divides the junior level-up thresholds
+func (s SharedLearningConfig) Speedup(seniorAgents int) float64 {
+	maxSpeedup := s.MaxSpeedup
+	if maxSpeedup == 0 {
+		maxSpeedup = 3.0
+	}
+	return math.Min(1.0+s.Acceleration*float64(seniorAgents), maxSpeedup)
+}
+
+// DataExposureConfig models how much data an AI agent learns from, scaling the experience it
+// gains each time step: agents under more experienced orchestrators see richer work, while
+// orchestrators stretched across many agents give each of them less. Off by default: with a zero
+// Availability every agent has full exposure
+type DataExposureConfig struct {
+	Availability   float64 // data available to agents, scaling every agent's exposure (e.g. 1 = as much as today)
+	LevelWeight    float64 // exposure gained per level of the orchestrator above University_Hire (e.g. 0.25 = 25%)
+	WorkloadWeight float64 // exposure lost by an orchestrator at the orchestration limit, in proportion to its agents (0-1)
+}
+
+// Exposure returns the data exposure of an agent under an orchestrator, or full exposure when the
+// model is off
+// A nil orchestrator, such as one that left the workforce this step, contributes neither level
+// nor workload
+func (d DataExposureConfig) Exposure(orchestrator *HumanWorker) float64 {
+	if d.Availability <= 0 {
+		return 1.0
+	}
+	if orchestrator == nil {
+		return d.Availability
+	}
+	level := 1.0 + d.LevelWeight*float64(orchestrator.ExperienceLevel)
+	load := math.Min(float64(len(orchestrator.AssignedAgents))/float64(OrchestrationLimit), 1.0)
+	return d.Availability * level * (1.0 - d.WorkloadWeight*load)
+}
+
+// Domain is a business function AI agents work in, such as engineering or sales
+// Some functions rest on human insight no agent learns, so their agents stop at a lower level
+// than Executive and the work above it stays with humans
+type Domain struct {
+	Name          string
+	Share         float64         // percentage of AI agents working in the domain (0-100)
+	MaxAgentLevel ExperienceLevel // highest level the domain's agents reach; University_Hire if unset
+}
+
+// DomainTable lists the domains of a simulation; an agent's Domain indexes the table
+type DomainTable []Domain
+
+// MaxAgentLevel returns the highest level agents in a domain reach
+// Domains outside the table, as every agent's is without domains, reach Executive
+func (t DomainTable) MaxAgentLevel(domain int) ExperienceLevel {
+	if domain >= 0 && domain < len(t) {
+		return t[domain].MaxAgentLevel
+	}
+	return Executive
+}
+
+// Assign returns the domain of the next agent hired, given the number of agents in each domain:
+// the one furthest below its share of the agents once the new agent is counted, the first among
+// equals. Returns 0 without domains
+func (t DomainTable) Assign(counts []int) int {
+	return assignByShare(t, func(d Domain) float64 { return d.Share }, counts)
+}
+
+// assignByShare returns the index of the entry of a table of percentage shares furthest below its
+// share once one more item is counted, given the items counted for each entry, the first among
+// equals. Returns 0 for an empty table
+func assignByShare[T any](table []T, share func(T) float64, counts []int) int {
+	total := 1
+	for _, count := range counts {
+		total += count
+	}
+	assigned, largest := 0, math.Inf(-1)
+	for i, entry := range table {
+		deficit := share(entry) / 100 * float64(total)
+		if i < len(counts) {
+			deficit -= float64(counts[i])
+		}
+		if deficit > largest {
+			assigned, largest = i, deficit
+		}
+	}
+	return assigned
+}
+
+// VendorTier is an AI model vendor agents can be hired from, such as a frontier model or an
+// open-source model run in-house, with its own cost, productivity, learning and failure profile
+// Zero multipliers default to 1
+type VendorTier struct {
+	Name                   string
+	Share                  float64 // percentage of agents hired from the tier under the Vendor_Mix policy (0-100)
+	CostMultiplier         float64 // agent cost as a multiple of the AIAgentCosts of its level (default 1)
+	ProductivityMultiplier float64 // agent productivity as a multiple of the AIAgentProductivity of its level (default 1)
+	LearningMultiplier     float64 // multiplier on the experience the tier's agents gain (default 1)
+	FailureMultiplier      float64 // multiplier on the severity of failures, averaged over the agents (default 1)
+}
+
+// Learning returns the multiplier on the experience the tier's agents gain
+func (v VendorTier) Learning() float64 {
+	if v.LearningMultiplier == 0 {
+		return 1.0
+	}
+	return v.LearningMultiplier
+}
+
+// Susceptibility returns the multiplier on the severity of failures the tier's agents meet
+func (v VendorTier) Susceptibility() float64 {
+	if v.FailureMultiplier == 0 {
+		return 1.0
+	}
+	return v.FailureMultiplier
+}
+
+// costPerProductivity returns the cost of the tier's agents per productivity unit, relative to
+// the default
+func (v VendorTier) costPerProductivity() float64 {
+	agent := AIAgent{CostMultiplier: v.CostMultiplier, ProductivityMultiplier: v.ProductivityMultiplier}
+	return agent.levelCost(UniversityHire) / AIAgentCosts[UniversityHire] / agent.ProductivityFactor()
+}
+
+// VendorTable lists the vendor tiers of a simulation; an agent's Vendor indexes the table
+type VendorTable []VendorTier
+
+// Tier returns a vendor tier, or the default profile for indexes outside the table
+func (t VendorTable) Tier(vendor int) VendorTier {
+	if vendor >= 0 && vendor < len(t) {
+		return t[vendor]
+	}
+	return VendorTier{}
+}
+
+// Susceptibility returns the mean failure multiplier of the tiers of agents, or 1 without agents
+func (t VendorTable) Susceptibility(agents []*AIAgent) float64 {
+	if len(t) == 0 || len(agents) == 0 {
+		return 1.0
+	}
+	total := 0.0
+	for _, agent := range agents {
+		total += t.Tier(agent.Vendor).Susceptibility()
+	}
+	return total / float64(len(agents))
+}
+
+// VendorConfig sets the vendor tiers AI agents are hired from and the policy choosing the tier of
+// each hire. Off by default: without tiers every agent has the default profile
+type VendorConfig struct {
+	Tiers  VendorTable
+	Policy VendorPolicy // how the optimizer picks the tier of each hire (default Vendor_Mix)
+}
+
+// Select returns the tier of the next agent hired, given the number of agents from each tier
+// Returns 0 without tiers
+func (v VendorConfig) Select(counts []int) int {
+	if v.Policy == MostCostEffective {
+		best := 0
+		for i, tier := range v.Tiers {
+			if tier.costPerProductivity() < v.Tiers[best].costPerProductivity() {
+				best = i
+			}
+		}
+		return best
+	}
+	return assignByShare(v.Tiers, func(tier VendorTier) float64 { return tier.Share }, counts)
+}
+
+// TrainingConfig models deliberate investment in AI capability, as opposed to organic learning:
+// part of the budget pays for targeted training of selected agents, which lowers the experience
+// they need to reach the next level
+// The spend is taken from the fixed budget before the workforce is paid, like resilience, so
+// training competes with headcount. It trains as many agents at a time as it pays for at
+// CostPerAgent, choosing those at MinLevel or above that are closest to their next level. Off by
+// default: with no BudgetShare agents only learn organically
+type TrainingConfig struct {
+	BudgetShare        float64         // fraction of the fixed budget spent on agent training each year (0-1)
+	CostPerAgent       float64         // annual cost of training one agent; 0 trains every eligible agent
+	ThresholdReduction float64         // fraction by which training lowers a trained agent's level-up threshold (0-1)
+	MinLevel           ExperienceLevel // lowest level of the agents trained; Executive agents have nothing left to learn
+}
+
+// Spend returns the annual training spend out of the given budget
+func (t TrainingConfig) Spend(budget float64) float64 {
+	return budget * t.BudgetShare
+}
+
+// Capacity returns how many agents the spend out of the given budget trains at a time, or -1 for
+// every eligible agent
+func (t TrainingConfig) Capacity(budget float64) int {
+	if t.BudgetShare <= 0 || t.ThresholdReduction <= 0 {
+		return 0
+	}
+	if t.CostPerAgent <= 0 {
+		return -1
+	}
+	return int(t.Spend(budget) / t.CostPerAgent)
+}
+
+// Targets returns whether agents at a level are eligible for training
+func (t TrainingConfig) Targets(level ExperienceLevel) bool {
+	return level >= t.MinLevel && level < Executive
+}
+
+// BackfillConfig controls replacement hiring for humans lost to attrition
+// Off by default: with a zero Fraction no departures are backfilled
+type BackfillConfig struct {
+	Fraction       float64 // share of attrition departures that are backfilled (0-1)
+	LagSteps       int     // recruiting lag in time steps between a departure and its replacement
+	RecruitingCost float64 // one-off cost charged for each backfill hire
+}
+
+// BackfillRequest is a pending replacement hire for a departed human
+type BackfillRequest struct {
+	DueTimeStep     int // time step at which the replacement joins
+	ExperienceLevel ExperienceLevel
+	CostCategory    CostCategory
+	ContractType    ContractType
+}
+
+// WorkloadConfig sets the output the business must deliver regardless of headcount
+// When the workforce falls short, the remaining humans work unpaid overtime to close the gap,
+// and sustained overtime builds burnout that raises natural attrition. Off by default: with a
+// zero Required there is no workload pressure
+type WorkloadConfig struct {
+	Required         float64 // productivity units the workforce must deliver each time step
+	MaxOvertime      float64 // largest overtime boost to human productivity, as a fraction (0-1, default 0.2)
+	BurnoutAttrition float64 // increase in the attrition rate multiplier per unit of burnout (default 1)
+	RecoveryRate     float64 // fraction of burnout shed each time step without overtime (0-1)
+}
+
+// Overtime returns the overtime humans work to meet the required workload, as a fraction of
+// their productivity, given the humans' and the whole workforce's productivity without overtime
+func (w WorkloadConfig) Overtime(humanProductivity, totalProductivity float64) float64 {
+	if w.Required <= 0 || totalProductivity >= w.Required || humanProductivity <= 0 {
+		return 0
+	}
+	maxOvertime := w.MaxOvertime
+	if maxOvertime == 0 {
+		maxOvertime = 0.2
+	}
+	return math.Min((w.Required-totalProductivity)/humanProductivity, maxOvertime)
+}
+
+// Burnout returns the burnout after a time step with the given overtime
+// Every step of overtime adds the overtime fraction; steps without overtime recover
+func (w WorkloadConfig) Burnout(burnout, overtime float64) float64 {
+	if overtime > 0 {
+		return burnout + overtime
+	}
+	return burnout * (1.0 - w.RecoveryRate)
+}
+
+// AttritionMultiplier returns the factor burnout applies to the natural attrition rate
+func (w WorkloadConfig) AttritionMultiplier(burnout float64) float64 {
+	factor := w.BurnoutAttrition
+	if factor == 0 {
+		factor = 1.0
+	}
+	return 1.0 + factor*burnout
+}
+
+// FailureRecoveryConfig sets what it takes to recover from a catastrophic failure
+// Every failure is remediated at a one-off cost scaled by its severity. For RecoverySteps time
+// steps, starting with the failure's own, productivity stays depressed by the penalty of a
+// failure the workforce could not handle, and senior and executive humans give SeniorOccupancy
+// of their capacity to the recovery. Off by default: failures then only cost a productivity penalty
+// RemediationCost and RecoverySteps apply to every class of the default failure class table;
+// configured FailureClasses set their own
+type FailureRecoveryConfig struct {
+	RemediationCost float64 // one-off cost of remediating a failure of full severity, scaled by severity
+	RecoverySteps   int     // time steps a recovery lasts, including the failure's own
+	SeniorOccupancy float64 // fraction of senior and executive humans' capacity taken up by recovery (0-1)
+}
+
+// FailureClassConfig sets how often a class of catastrophic failure occurs and what it takes to
+// handle and recover from it
+// Senior and executive humans each contribute 1 to the workforce's capability, and senior and
+// executive AI agents AgentCapability each. A failure is handled when at least MinSeniorHumans
+// senior humans are available and the capability reaches Capability scaled by the failure's
+// severity. Penalties, costs and requirements are given at full severity and scale with it
+type FailureClassConfig struct {
+	Weight           float64 // relative likelihood that a failure is of this class; 0 means it never occurs
+	MinSeniorHumans  int     // senior or executive humans needed to handle a failure of this class
+	Capability       float64 // workforce capability needed to handle a failure of full severity
+	AgentCapability  float64 // capability each senior or executive AI agent contributes
+	UnhandledPenalty float64 // productivity penalty when fewer than MinSeniorHumans are available (0-1)
+	GapPenalty       float64 // productivity penalty with no capability at all, scaled by the capability gap (0-1)
+	RemediationCost  float64 // one-off cost of remediating a failure of full severity
+	RecoverySteps    int     // time steps a recovery lasts, including the failure's own
+}
+
+// Remediation returns the one-off cost of remediating a failure of the given severity (0-1)
+func (f FailureClassConfig) Remediation(severity float64) float64 {
+	return f.RemediationCost * severity
+}
+
+// FailureClasses configures each class of catastrophic failure
+// Off by default: with no class given a weight, DefaultFailureClasses applies
+type FailureClasses struct {
+	SecurityIncident FailureClassConfig
+	ModelOutage      FailureClassConfig
+	DataCorruption   FailureClassConfig
+	ComplianceBreach FailureClassConfig
+}
+
+// Enabled reports whether any failure class is given a weight
+func (f FailureClasses) Enabled() bool {
+	for _, class := range f.Table() {
+		if class.Weight > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Table returns the class configurations indexed by FailureClass
+func (f FailureClasses) Table() [NumFailureClasses]FailureClassConfig {
+	return [NumFailureClasses]FailureClassConfig{f.SecurityIncident, f.ModelOutage, f.DataCorruption, f.ComplianceBreach}
+}
+
+// DefaultFailureClasses returns the failure classes used when none are configured: every class
+// is equally likely and needs one senior human and a capability of 3 at full severity, with the
+// remediation cost and recovery time of the Recovery configuration
+func DefaultFailureClasses(recovery FailureRecoveryConfig) FailureClasses {
+	class := FailureClassConfig{
+		Weight:           1,
+		MinSeniorHumans:  1,
+		Capability:       3,
+		AgentCapability:  0.5,
+		UnhandledPenalty: 0.5,
+		GapPenalty:       0.3,
+		RemediationCost:  recovery.RemediationCost,
+		RecoverySteps:    recovery.RecoverySteps,
+	}
+	return FailureClasses{SecurityIncident: class, ModelOutage: class, DataCorruption: class, ComplianceBreach: class}
+}
+
+// ResilienceConfig sets the share of the budget spent on resilience, such as monitoring and
+// redundancy, and how much that spend lowers the likelihood and severity of catastrophic failures
+// The spend is taken from the fixed budget before the workforce is paid, so resilience competes
+// with headcount. Returns diminish: a BudgetShare equal to HalfEffectShare achieves half of the
+// maximum reductions. Off by default: with no BudgetShare the whole budget goes to the workforce
+type ResilienceConfig struct {
+	BudgetShare          float64 // fraction of the fixed budget spent on resilience each year (0-1)
+	MaxRateReduction     float64 // largest fraction by which resilience lowers the failure rate (0-1)
+	MaxSeverityReduction float64 // largest fraction by which resilience lowers failure severity (0-1)
+	HalfEffectShare      float64 // budget share achieving half of the maximum reductions; 0 achieves them in full at any spend
+}
+
+// Spend returns the annual resilience spend out of the given budget
+func (r ResilienceConfig) Spend(budget float64) float64 {
+	return budget * r.BudgetShare
+}
+
+// Effect returns the fraction of the maximum reductions the configured spend achieves (0-1)
+func (r ResilienceConfig) Effect() float64 {
+	if r.BudgetShare <= 0 {
+		return 0
+	}
+	return r.BudgetShare / (r.BudgetShare + r.HalfEffectShare)
+}
+
+// RateMultiplier returns the factor resilience applies to the catastrophic failure rate
+func (r ResilienceConfig) RateMultiplier() float64 {
+	return 1.0 - r.MaxRateReduction*r.Effect()
+}
+
+// SeverityMultiplier returns the factor resilience applies to the severity of catastrophic failures
+func (r ResilienceConfig) SeverityMultiplier() float64 {
+	return 1.0 - r.MaxSeverityReduction*r.Effect()
+}
+
+// FailureRecovery is the recovery from a catastrophic failure that is still under way
+type FailureRecovery struct {
+	EndTimeStep      int     // last time step of the recovery
+	ProductivityLoss float64 // fraction of productivity lost while recovering (0-1)
+}
+
+// FailureRecord describes a catastrophic failure and the workforce's response to it
+type FailureRecord struct {
+	TimeStep            int
+	Class               FailureClass
+	Severity            float64 // 0-1, where 1 is most severe
+	Handled             bool
+	SeniorHumans        int     // senior and executive humans available to respond
+	SeniorAgents        int     // senior and executive AI agents available to respond
+	HumanCapability     float64 // capability contributed by the senior humans
+	AgentCapability     float64 // capability contributed by the senior AI agents
+	RequiredCapability  float64 // capability needed at the failure's severity
+	ProductivityPenalty float64 // productivity lost to the failure when not handled (0-1)
+	Penalty             float64 // spend lost to the failure, charged as a Penalties cost
+	Remediation         float64 // one-off cost of remediating the failure
+}
+
+// TaskDemand is the work available each time step, in productivity units by the experience
+// level its tasks require. Humans and AI agents can take tasks at or below their own level, and
+// capacity beyond the demand goes unused. Off by default: with no demand every worker
+// contributes their full productivity
+type TaskDemand struct {
+	UniversityHire float64 // productivity units of University_Hire tasks per time step
+	MidLevel       float64 // productivity units of Mid_Level tasks per time step
+	Senior         float64 // productivity units of Senior tasks per time step
+	Executive      float64 // productivity units of Executive tasks per time step
+}
+
+// Enabled reports whether any task demand is configured
+func (d TaskDemand) Enabled() bool {
+	return d.UniversityHire > 0 || d.MidLevel > 0 || d.Senior > 0 || d.Executive > 0
+}
+
+// Match assigns workers to tasks given the workforce's productive capacity at each experience
+// level and reports the resulting utilization
+// Tasks are filled from the most demanding level down, each by the least experienced workers
+// able to take it, which keeps experienced workers free for the tasks only they can do and
+// delivers as much work as possible
+func (d TaskDemand) Match(capacity [NumExperienceLevels]float64) TaskUtilization {
+	demand := [NumExperienceLevels]float64{d.UniversityHire, d.MidLevel, d.Senior, d.Executive}
+	var used [NumExperienceLevels]float64
+	for task := NumExperienceLevels - 1; task >= 0; task-- {
+		remaining := demand[task]
+		for worker := task; worker < NumExperienceLevels && remaining > 0; worker++ {
+			taken := math.Min(remaining, capacity[worker]-used[worker])
+			used[worker] += taken
+			remaining -= taken
+		}
+	}
+	
+	utilization := TaskUtilization{ByExperience: make(map[ExperienceLevel]float64, NumExperienceLevels)}
+	totalCapacity := 0.0
+	for level := range capacity {
+		if capacity[level] > 0 {
+			utilization.ByExperience[ExperienceLevel(level)] = used[level] / capacity[level] * 100.0
+		}
+		utilization.Delivered += used[level]
+		totalCapacity += capacity[level]
+	}
+	if totalCapacity > 0 {
+		utilization.Overall = utilization.Delivered / totalCapacity * 100.0
+	}
+	return utilization
+}
+
+// OrchestrationEffectiveness scales the productivity of AI agents by the experience level of
+// the human orchestrating them, so agents supervised by seniors can earn a bonus and agents
+// supervised by juniors a penalty. A zero multiplier is treated as 1.0 (no effect)
+type OrchestrationEffectiveness struct {
+	UniversityHire float64 // productivity multiplier
+	MidLevel       float64 // productivity multiplier
+	Senior         float64 // productivity multiplier
+	Executive      float64 // productivity multiplier
+}
+
+// Multiplier returns the productivity multiplier for agents orchestrated by a human at the given level
+func (oe OrchestrationEffectiveness) Multiplier(level ExperienceLevel) float64 {
+	var multiplier float64
+	switch level {
+	case UniversityHire:
+		multiplier = oe.UniversityHire
+	case MidLevel:
+		multiplier = oe.MidLevel
+	case Senior:
+		multiplier = oe.Senior
+	case Executive:
+		multiplier = oe.Executive
+	}
+	
+	if multiplier == 0 {
+		return 1.0
+	}
+	return multiplier
+}
+
+// AgentProductivityTable holds AI agent productivity indexed by orchestrator level and then
+// agent level, with the orchestration multiplier already applied
+type AgentProductivityTable [NumExperienceLevels][NumExperienceLevels]float64
+
+// ProductivityTable precomputes the productivity of agents at every level under every
+// orchestrator level, so per-agent lookups in hot loops are plain array indexing
+func (oe OrchestrationEffectiveness) ProductivityTable() AgentProductivityTable {
+	var table AgentProductivityTable
+	for orchestratorLevel := range table {
+		multiplier := oe.Multiplier(ExperienceLevel(orchestratorLevel))
+		for agentLevel := range table[orchestratorLevel] {
+			table[orchestratorLevel][agentLevel] = AIAgentProductivity[agentLevel] * multiplier
+		}
+	}
+	return table
+}
+
+// AssignmentConfig controls how AI agents are spread across the humans orchestrating them
+// Spreading agents out limits how many are lost when a single orchestrator leaves, since a
+// departing human's agents are released with them
+type AssignmentConfig struct {
+	Strategy  AssignmentStrategy
+	Rebalance bool // after attrition, move agents from the most to the least loaded humans until loads differ by at most one
+}
+
+// OversightConfig is a regulatory requirement of at least MinHumans humans for every PerAgents
+// AI agents, as in regulated industries that mandate human review of automated work. Off by
+// default: with either value zero, AI agents are limited only by orchestration capacity
+type OversightConfig struct {
+	MinHumans int // humans required for every PerAgents AI agents
+	PerAgents int
+}
+
+// Enabled reports whether a minimum human oversight ratio is required
+func (o OversightConfig) Enabled() bool {
+	return o.MinHumans > 0 && o.PerAgents > 0
+}
+
+// MaxAgents returns the most AI agents the given number of humans may oversee
+// Only meaningful when the requirement is Enabled
+func (o OversightConfig) MaxAgents(humans int) int {
+	return humans * o.PerAgents / o.MinHumans
+}
+
+// RulesConfig holds per-step rules scripted in Starlark, for conditional policies the
+// configuration cannot express, e.g. freezing AI hiring once AI agents make up most of the
+// workforce. The script defines rules(state); see events.Rules for what it can see and do
+type RulesConfig struct {
+	Script string // Starlark source; empty for no rules
+}
+
+// Enabled reports whether rules are scripted
+func (r RulesConfig) Enabled() bool {
+	return strings.TrimSpace(r.Script) != ""
+}
+
+// AlertRule raises an alert when a metric of the recorded state crosses a threshold, e.g. when the
+// human count drops below 30% of its initial value
+// An alert fires at the first time step its condition holds and again each time it holds after
+// having stopped holding, rather than at every step it holds
+type AlertRule struct {
+	Metric            string // one of AlertMetrics, e.g. "human_count"
+	Comparator        AlertComparator
+	Threshold         float64
+	RelativeToInitial bool   // Threshold is a percentage of the metric's value at the start of the run
+	Message           string // reported when the alert fires; describes the condition when empty
+}
+
+// alertMetrics are the metrics alert rules can watch, computed from a recorded state
+var alertMetrics = map[string]func(state SimulationState) float64{
+	"human_count":    func(state SimulationState) float64 { return float64(state.Workforce.Humans.Total) },
+	"ai_agent_count": func(state SimulationState) float64 { return float64(state.Workforce.AIAgents.Total) },
+	"total_workforce": func(state SimulationState) float64 {
+		return float64(state.Workforce.Humans.Total + state.Workforce.AIAgents.Total)
+	},
+	"ai_ratio": func(state SimulationState) float64 {
+		total := state.Workforce.Humans.Total + state.Workforce.AIAgents.Total
+		if total == 0 {
+			return 0
+		}
+		return 100.0 * float64(state.Workforce.AIAgents.Total) / float64(total)
+	},
+	"total_cost":                func(state SimulationState) float64 { return state.TotalCost },
+	"available_budget":          func(state SimulationState) float64 { return state.AvailableBudget },
+	"total_productivity":        func(state SimulationState) float64 { return state.TotalProductivity },
+	"revenue_output":            func(state SimulationState) float64 { return state.RevenueOutput },
+	"net_cash_flow":             func(state SimulationState) float64 { return state.NetCashFlow() },
+	"cash_balance":              func(state SimulationState) float64 { return state.CashBalance },
+	"burnout":                   func(state SimulationState) float64 { return state.Burnout },
+	"catastrophic_failures":     func(state SimulationState) float64 { return float64(state.CatastrophicFailures) },
+	"orchestration_utilization": func(state SimulationState) float64 { return state.Workforce.OrchestrationUtilization },
+}
+
+// AlertMetrics returns the names of the metrics alert rules can watch, in name order
+func AlertMetrics() []string {
+	names := make([]string, 0, len(alertMetrics))
+	for name := range alertMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AbsoluteThreshold returns the threshold of the rule for a run starting from the given state
+func (r AlertRule) AbsoluteThreshold(initial SimulationState) float64 {
+	if !r.RelativeToInitial {
+		return r.Threshold
+	}
+	return r.Value(initial) * r.Threshold / 100.0
+}
+
+// Value returns the rule's metric in a state; NaN if the metric is unknown
+func (r AlertRule) Value(state SimulationState) float64 {
+	metric, exists := alertMetrics[r.Metric]
+	if !exists {
+		return math.NaN()
+	}
+	return metric(state)
+}
+
+// Holds reports whether the rule's condition holds in a state against the given absolute threshold
+func (r AlertRule) Holds(state SimulationState, threshold float64) bool {
+	return r.Comparator.Holds(r.Value(state), threshold)
+}
+
+// Describe returns the rule's message, or a description of its condition when it has none,
+// e.g. "human_count < 30% of initial"
+func (r AlertRule) Describe() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	if r.RelativeToInitial {
+		return fmt.Sprintf("%s %s %g%% of initial", r.Metric, r.Comparator, r.Threshold)
+	}
+	return fmt.Sprintf("%s %s %g", r.Metric, r.Comparator, r.Threshold)
+}
+
 // AttritionConfig defines the attrition behavior for human workers
 type AttritionConfig struct {
 	Type                AttritionType
 	NaturalRate         float64 // annual percentage (0-100)
 	ForcedAcceleration  float64 // multiplier for attrition rate
+	SeveranceMonths     float64 // months of salary paid to each human leaving under Reduction_In_Force
+	OwnerAttrition      bool    // the business owner is subject to natural attrition and is succeeded when they leave
+	
+	// Hiring freeze scope. Hiring_Freeze freezes human hiring only, as it always has; AI
+	// hiring is frozen only when FreezeAIHiring opts in. FreezeHumanHiring freezes human hiring
+	// under the other attrition types
+	FreezeHumanHiring   bool
+	FreezeAIHiring      bool
+}
+
+// HumanHiringFrozen reports whether new human workers may not be hired
+func (ac AttritionConfig) HumanHiringFrozen() bool {
+	return ac.FreezeHumanHiring || ac.Type == HiringFreeze
+}
+
+// AIHiringFrozen reports whether new AI agents may not be hired
+func (ac AttritionConfig) AIHiringFrozen() bool {
+	return ac.FreezeAIHiring
+}
+
+// DefaultStabilityWindow is the number of time steps the workforce composition must stay stable
+// for equilibrium when EquilibriumConfig sets no Window
+const DefaultStabilityWindow = 5
+
+// EquilibriumConfig sets what counts as a settled workforce when detecting equilibrium
+// The composition is stable when neither headcount has moved by more than MaxCompositionChange
+// percent of its value at the start of the last Window time steps, and the budget counts as
+// exhausted once no more than BudgetSlack of it is left. The zero value keeps the defaults: a
+// DefaultStabilityWindow window, an unchanged composition and no budget left
+// With ConfirmSteps set a run keeps going after equilibrium is first detected, stopping early at
+// a catastrophic failure or market shock, and reports whether the equilibrium held
+type EquilibriumConfig struct {
+	Window               int     // time steps the composition must stay stable; 0 uses DefaultStabilityWindow
+	MaxCompositionChange float64 // largest change in human or AI agent headcount over the window still counted as stable (percentage 0-100)
+	BudgetSlack          float64 // available budget at or below which no further expansion is affordable
+	ConfirmSteps         int     // time steps to keep simulating after equilibrium is first detected, to check it persists; 0 stops at detection
+}
+
+// StabilityWindow returns the number of time steps the composition must stay stable
+func (e EquilibriumConfig) StabilityWindow() int {
+	if e.Window > 0 {
+		return e.Window
+	}
+	return DefaultStabilityWindow
+}
+
+// Settled reports whether the workforce composition stayed within MaxCompositionChange of the
+// first of the given states throughout them
+func (e EquilibriumConfig) Settled(states []SimulationState) bool {
+	if len(states) == 0 {
+		return false
+	}
+	first := states[0].Workforce
+	for _, state := range states[1:] {
+		if !e.withinChange(first.Humans.Total, state.Workforce.Humans.Total) ||
+			!e.withinChange(first.AIAgents.Total, state.Workforce.AIAgents.Total) {
+			return false
+		}
+	}
+	return true
+}
+
+// withinChange reports whether a headcount moved by no more than MaxCompositionChange percent
+func (e EquilibriumConfig) withinChange(from int, to int) bool {
+	change := math.Abs(float64(to - from))
+	return change <= float64(from)*e.MaxCompositionChange/100.0
+}
+
+// BudgetExhausted reports whether the available budget leaves no room for further expansion
+func (e EquilibriumConfig) BudgetExhausted(availableBudget float64) bool {
+	return availableBudget <= e.BudgetSlack
 }
 
+// ConfigVersion is the current schema version of SimulationConfig
+// Bump it, and register a migration in the config package, whenever a change means an older
+// configuration would no longer load or would behave differently
+const ConfigVersion = 1
+
 // SimulationConfig contains all configuration parameters for a simulation run
 type SimulationConfig struct {
+	Version int // schema version the configuration was written for; 0 if it predates versioning
+	
 	// Initial workforce configuration
 	InitialHumans            int
 	ExperienceDistribution   ExperienceDistribution
 	CostCategoryDistribution CostCategoryDistribution
+	Regions                  RegionTable // replaces CostCategoryDistribution and TimeZoneInefficiency when set
+	Contracts                ContractConfig
+	Overhead                 OverheadConfig // employer and AI infrastructure overhead on top of base costs
 	
 	// Economic configuration
 	FixedBudget      float64
 	RevenueScenario  RevenueScenario
+	RevenueVolatility float64 // sigma of lognormal noise on revenue per time step (0 = deterministic)
+	MarketSize       float64 // maximum addressable annual revenue; revenue saturates towards it (0 = unlimited)
+	DiscountRate     float64 // annual discount rate for NPV metrics (0-1)
+	Shocks           MarketShocks // exogenous market shocks, applied from their time step on
+	AICostCurve      AICostCurve  // steady change in AI prices over time (default off: static prices)
+	AIPricing        AIPricingConfig // platform fee and volume discounts pricing the AI fleet (default off)
+	Cash             CashConfig   // cash balance and insolvency (default off)
+	Financing        FinancingConfig // borrowing beyond the fixed budget (default off)
 	
 	// AI learning configuration
 	AILearningSpeeds AILearningSpeed
+	SharedLearning   SharedLearningConfig
+	Training         TrainingConfig // budget spent on targeted AI agent training (default off)
+	DataExposure     DataExposureConfig // data agents learn from under their orchestrators (default off: full exposure)
+	Domains          DomainTable        // business functions agents are hired into, capping their levels (default none)
+	Vendors          VendorConfig       // AI vendor tiers agents are hired from (default none)
+	
+	// AI agent productivity multipliers by orchestrator experience level
+	OrchestrationEffectiveness OrchestrationEffectiveness
+	
+	// Regulatory minimum ratio of humans to AI agents
+	Oversight OversightConfig
+	
+	// Assignment of AI agents to orchestrating humans
+	Assignment AssignmentConfig
 	
 	// Attrition configuration
 	AttritionConfig AttritionConfig
+	Backfill        BackfillConfig
+	Workload        WorkloadConfig
+	Tasks           TaskDemand // work available by skill level, limiting the productivity that earns revenue
 	
 	// Failure and inefficiency configuration
 	CatastrophicFailureRate float64 // probability per time step (0-1)
-	TimeZoneInefficiency    float64 // productivity penalty for Low_Cost_Non_US (0-1)
+	Recovery                FailureRecoveryConfig
+	FailureClasses          FailureClasses // likelihood and handling of each class of failure
+	Resilience              ResilienceConfig // budget spent lowering the likelihood and severity of failures
+	TimeZoneInefficiency    float64 // productivity penalty for Low_Cost_Non_US (0-1) when no Regions are set
+	
+	// Equilibrium detection
+	Equilibrium EquilibriumConfig
+	
+	// Scripted per-step rules
+	Rules RulesConfig
+	
+	// Thresholds on recorded metrics that raise alerts during a run
+	Alerts []AlertRule
+}
+
+// WorkforceBudget returns the part of the fixed budget left for the workforce after resilience
+// and training spend
+func (c SimulationConfig) WorkforceBudget() float64 {
+	return c.FixedBudget - c.Resilience.Spend(c.FixedBudget) - c.Training.Spend(c.FixedBudget)
+}
+
+// FailureClassTable returns the configured failure classes, or DefaultFailureClasses when none
+// is given a weight
+func (c SimulationConfig) FailureClassTable() [NumFailureClasses]FailureClassConfig {
+	if c.FailureClasses.Enabled() {
+		return c.FailureClasses.Table()
+	}
+	return DefaultFailureClasses(c.Recovery).Table()
+}
+
+// MarketConditions returns the market conditions at a time step: those of the shocks up to and
+// including the step, with AI costs following the AI cost curve
+func (c SimulationConfig) MarketConditions(timeStep int) MarketConditions {
+	conditions := c.Shocks.Conditions(timeStep)
+	conditions.AICost *= c.AICostCurve.Factor(timeStep)
+	return conditions
+}
+
+// RegionTable returns the configured regions, or the default High_Cost_US and Low_Cost_Non_US
+// regions built from CostCategoryDistribution and TimeZoneInefficiency when none are set
+func (c SimulationConfig) RegionTable() RegionTable {
+	if len(c.Regions) > 0 {
+		return c.Regions
+	}
+	return DefaultRegions(c.CostCategoryDistribution, c.TimeZoneInefficiency)
+}
+
+// HumanComposition counts the humans of a workforce
+type HumanComposition struct {
+	Total          int                     `json:"Total"`
+	ByExperience   map[ExperienceLevel]int `json:"ByExperience"`
+	ByCostCategory map[CostCategory]int    `json:"ByCostCategory"` // keyed by index into the region table
+	ByContractType map[ContractType]int    `json:"ByContractType"`
 }
 
-// Validate checks if the configuration is valid
-func (c *SimulationConfig) Validate() error {
-	// Validation will be implemented as part of the simulation controller
-	// This method is a placeholder for now
-	return nil
+// AgentComposition counts the AI agents of a workforce
+type AgentComposition struct {
+	Total        int                     `json:"Total"`
+	ByExperience map[ExperienceLevel]int `json:"ByExperience"`
 }
 
 // WorkforceComposition represents detailed workforce statistics
 type WorkforceComposition struct {
-	Humans struct {
-		Total          int
-		ByExperience   map[ExperienceLevel]int
-		ByCostCategory map[CostCategory]int
+	Humans                   HumanComposition `json:"Humans"`
+	AIAgents                 AgentComposition `json:"AIAgents"`
+	HumanSubtotals           GroupSubtotals   `json:"HumanSubtotals"` // productivity and cost of the humans
+	AISubtotals              GroupSubtotals   `json:"AISubtotals"`    // productivity and cost of the AI agents, as orchestrated
+	OrchestrationUtilization float64          `json:"OrchestrationUtilization"` // percentage of capacity used (0-100)
+}
+
+// GroupSubtotals are the productivity and cost of a group of workers, in total and by experience
+// level, showing the value each group contributes alongside its headcount
+// Productivity is nominal: before overtime, failure recovery and task matching
+type GroupSubtotals struct {
+	Productivity             float64
+	ProductivityByExperience map[ExperienceLevel]float64
+	Cost                     float64 // annual cost
+	CostByExperience         map[ExperienceLevel]float64
+}
+
+// CostBreakdown itemizes the cost of the workforce at a time step
+// Payroll and AI costs, including any AI platform fee top-up, are annual run-rates that sum to
+// TotalCost; resilience and training spend are annual run-rates outside TotalCost; penalties,
+// severance and recruiting are one-off amounts incurred during the time step
+type CostBreakdown struct {
+	HumanPayroll map[ExperienceLevel]float64 // annual human payroll by experience level
+	AICost       map[ExperienceLevel]float64 // annual AI agent cost by experience level, after volume discounts
+	AIPlatformFee float64                    // annual top-up of AI costs to the platform fee, included in TotalCost
+	Resilience   float64                     // annual resilience spend, not included in TotalCost
+	Training     float64                     // annual AI agent training spend, not included in TotalCost
+	Penalties    float64                     // spend lost to unhandled catastrophic failures this step
+	Severance    float64                     // severance paid to departing humans this step
+	Recruiting   float64                     // recruiting cost of backfill hires this step
+	Remediation  float64                     // cost of remediating catastrophic failures this step
+	Interest     float64                     // interest paid on debt this step
+}
+
+// TotalHumanPayroll returns the annual human payroll across all experience levels
+func (c CostBreakdown) TotalHumanPayroll() float64 {
+	total := 0.0
+	for _, cost := range c.HumanPayroll {
+		total += cost
+	}
+	return total
+}
+
+// TotalAICost returns the annual AI agent cost across all experience levels, with the platform
+// fee top-up
+func (c CostBreakdown) TotalAICost() float64 {
+	total := c.AIPlatformFee
+	for _, cost := range c.AICost {
+		total += cost
+	}
+	return total
+}
+
+// RevenueAttribution splits a time step's revenue between humans and AI agents
+// Revenue is attributed in proportion to each worker's effective productivity
+type RevenueAttribution struct {
+	HumanByExperience map[ExperienceLevel]float64 // revenue generated by humans per experience level
+	AIByExperience    map[ExperienceLevel]float64 // revenue generated by AI agents per experience level
+}
+
+// TotalHuman returns the revenue generated by humans across all experience levels
+func (r RevenueAttribution) TotalHuman() float64 {
+	total := 0.0
+	for _, revenue := range r.HumanByExperience {
+		total += revenue
 	}
-	AIAgents struct {
-		Total        int
-		ByExperience map[ExperienceLevel]int
+	return total
+}
+
+// TotalAI returns the revenue generated by AI agents across all experience levels
+func (r RevenueAttribution) TotalAI() float64 {
+	total := 0.0
+	for _, revenue := range r.AIByExperience {
+		total += revenue
+	}
+	return total
+}
+
+// AIShare returns the fraction of revenue generated by AI agents (0-1)
+func (r RevenueAttribution) AIShare() float64 {
+	human, ai := r.TotalHuman(), r.TotalAI()
+	if human+ai == 0 {
+		return 0
 	}
-	OrchestrationUtilization float64 // percentage of capacity used (0-100)
+	return ai / (human + ai)
+}
+
+// TaskUtilization reports how much of the workforce's capacity was matched to task demand
+type TaskUtilization struct {
+	ByExperience map[ExperienceLevel]float64 // percentage of capacity used, by worker experience level (0-100)
+	Overall      float64                     // percentage of the whole workforce's capacity used (0-100)
+	Delivered    float64                     // productivity matched to tasks
 }
 
 // SimulationState represents the state of the simulation at a specific time step
@@ -76,11 +1279,81 @@ type SimulationState struct {
 	TimeStep                  int
 	Workforce                 WorkforceComposition
 	TotalCost                 float64
+	CostBreakdown             CostBreakdown
 	AvailableBudget          float64
 	TotalProductivity        float64
 	RevenueOutput            float64
+	RevenueAttribution       RevenueAttribution
+	Overtime                 float64 // overtime worked by humans this step, as a fraction of their productivity
+	Burnout                  float64 // burnout accumulated from overtime up to this step
+	RecoveryLoss             float64 // fraction of productivity lost to failure recovery this step (0-1)
+	Utilization              TaskUtilization // matching of capacity to task demand; zero unless Tasks is configured
+	MarketShocks             []MarketShock // market shocks that took effect this step
+	CashBalance              float64 // cash on hand after this step; 0 unless Cash is enabled
+	Financing                FinancingState
 	IsEquilibrium            bool
+	EquilibriumReason        EquilibriumReason
 	CatastrophicFailures     int
+	
+	// Workforce dynamics of this step, so they are visible without diffing consecutive states
+	AvailableOrchestrationCapacity int // AI agents that could still be hired after this step, within orchestration and oversight limits
+	AgentsHiredThisStep            int // AI agents hired by workforce optimization this step
+	AgentsReleasedThisStep         int // AI agents released this step, by optimization, oversight or their orchestrator leaving
+	HumansLostThisStep             int // humans who left this step through attrition or a reduction in force
+}
+
+// NetCashFlow returns the cash the organization gained (or lost) in this step
+// Revenue, workforce cost, resilience and training spend are annual run-rates, so the step earns and spends
+// one TimeStepsPerYear-th of them; penalties, severance, recruiting, remediation and interest are
+// one-off step amounts. Borrowing and repaying principal are not included
+func (s SimulationState) NetCashFlow() float64 {
+	return (s.RevenueOutput-s.TotalCost-s.CostBreakdown.Resilience-s.CostBreakdown.Training)/TimeStepsPerYear -
+		s.CostBreakdown.Penalties - s.CostBreakdown.Severance - s.CostBreakdown.Recruiting -
+		s.CostBreakdown.Remediation - s.CostBreakdown.Interest
+}
+
+// EquilibriumReason records why a simulation reached equilibrium, or why it stopped without it
+type EquilibriumReason struct {
+	Code    EquilibriumReasonCode
+	Message string
+}
+
+// EquilibriumConfirmation reports whether an equilibrium held over the time steps simulated after
+// it was first detected
+type EquilibriumConfirmation struct {
+	Steps     int  // time steps simulated after equilibrium was first detected
+	Persisted bool // equilibrium held at every one of those steps
+	LostAt    int  // first time step at which equilibrium no longer held; 0 if it persisted
+	Disrupted bool // confirmation stopped early at a catastrophic failure or market shock
+}
+
+// OptimizerDecision records a workforce change proposed by the optimizer, how a reviewer resolved
+// it, and what was executed
+type OptimizerDecision struct {
+	TimeStep         int
+	ProposedHires    int
+	ProposedReleases []string // IDs of the AI agents the optimizer proposed to release
+	Verdict          DecisionVerdict
+	Reason           string   // the reviewer's explanation, if given
+	Hires            int      // AI agents hired when the decision was executed
+	Releases         []string // IDs of the AI agents released when the decision was executed
+}
+
+// SimulationEvent is a notable occurrence recorded during a simulation time step
+type SimulationEvent struct {
+	TimeStep    int
+	Type        EventType
+	Description string
+}
+
+// Alert records an alert rule firing at a time step
+type Alert struct {
+	TimeStep  int
+	Rule      int // index of the rule in SimulationConfig.Alerts
+	Metric    string
+	Value     float64 // the metric's value at the time step
+	Threshold float64 // the absolute threshold it crossed
+	Message   string
 }
 
 // SimulationResult represents the complete result of a simulation run
@@ -88,6 +1361,16 @@ type SimulationResult struct {
 	Config                    SimulationConfig
 	TimeSeries               []SimulationState
 	EquilibriumState         SimulationState
-	TimeToEquilibrium        int
+	TimeToEquilibrium        int // time step at which the run stopped; a lower bound when ReachedEquilibrium is false
+	ReachedEquilibrium       bool
+	Insolvent                bool // the run ended because the cash balance fell beyond the credit limit
+	EquilibriumReason        EquilibriumReason
+	Status                   RunStatus // how the run ended: equilibrium, step limit or a terminal state
 	TotalCatastrophicFailures int
+	Failures                 []FailureRecord // every catastrophic failure of the run, in order
+	Confirmation             *EquilibriumConfirmation // nil unless Equilibrium.ConfirmSteps is set and equilibrium was reached
+	Decisions                []OptimizerDecision // reviewed optimizer decisions, in order; nil unless a decision approver is set
+	Alerts                   []Alert // alerts raised by the configured alert rules, in order
+	Events                   []SimulationEvent
+	Metadata                 RunMetadata // provenance of the run
 }
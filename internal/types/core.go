@@ -10,6 +10,9 @@ const (
 	Executive
 )
 
+// NumExperienceLevels is the number of experience levels; tables indexed by ExperienceLevel have this length
+const NumExperienceLevels = 4
+
 // String returns the string representation of ExperienceLevel
 func (e ExperienceLevel) String() string {
 	switch e {
@@ -26,7 +29,8 @@ func (e ExperienceLevel) String() string {
 	}
 }
 
-// CostCategory represents the cost classification of human workers
+// CostCategory represents the cost classification of human workers: an index into the
+// simulation's region table. The named categories are the regions of the default table
 type CostCategory int
 
 const (
@@ -34,6 +38,9 @@ const (
 	LowCostNonUS
 )
 
+// NumCostCategories is the number of cost categories; tables indexed by CostCategory have this length
+const NumCostCategories = 2
+
 // String returns the string representation of CostCategory
 func (c CostCategory) String() string {
 	switch c {
@@ -46,6 +53,36 @@ func (c CostCategory) String() string {
 	}
 }
 
+// ContractType represents the employment arrangement of a human worker
+type ContractType int
+
+const (
+	FullTime ContractType = iota
+	Contractor
+	PartTime
+)
+
+// NumContractTypes is the number of contract types; tables indexed by ContractType have this length
+const NumContractTypes = 3
+
+// String returns the string representation of ContractType
+func (c ContractType) String() string {
+	switch c {
+	case FullTime:
+		return "FTE"
+	case Contractor:
+		return "Contractor"
+	case PartTime:
+		return "Part_Time"
+	default:
+		return "Unknown"
+	}
+}
+
+// StandardHoursPerYear is the number of hours a full-time worker works in a year, used to
+// convert between annual and hourly costs
+const StandardHoursPerYear = 2080
+
 // RevenueScenario represents the revenue growth pattern
 type RevenueScenario int
 
@@ -89,13 +126,338 @@ func (a AttritionType) String() string {
 	}
 }
 
+// ShockType identifies what an exogenous market shock changes
+type ShockType int
+
+const (
+	DemandShock     ShockType = iota // revenue earned per unit of productivity
+	AICostShock                      // cost of AI agents
+	SalaryInflation                  // cost of human workers
+)
+
+// String returns the string representation of ShockType
+func (s ShockType) String() string {
+	switch s {
+	case DemandShock:
+		return "Demand_Shock"
+	case AICostShock:
+		return "AI_Cost_Shock"
+	case SalaryInflation:
+		return "Salary_Inflation"
+	default:
+		return "Unknown"
+	}
+}
+
+// AssignmentStrategy controls how newly hired AI agents are assigned to orchestrating humans
+type AssignmentStrategy int
+
+const (
+	FillFirst   AssignmentStrategy = iota // fill the human with the most spare capacity before using another
+	RoundRobin                            // one agent per human in turn, continuing after the last assignment
+	LeastLoaded                           // each agent to the human orchestrating the fewest agents
+)
+
+// String returns the string representation of AssignmentStrategy
+func (a AssignmentStrategy) String() string {
+	switch a {
+	case FillFirst:
+		return "Fill_First"
+	case RoundRobin:
+		return "Round_Robin"
+	case LeastLoaded:
+		return "Least_Loaded"
+	default:
+		return "Unknown"
+	}
+}
+
+// VendorPolicy controls which vendor tier the optimizer hires each AI agent from
+type VendorPolicy int
+
+const (
+	VendorMix         VendorPolicy = iota // the tier furthest below its share of the agents
+	MostCostEffective                     // the tier with the lowest cost per productivity unit
+)
+
+// String returns the string representation of VendorPolicy
+func (v VendorPolicy) String() string {
+	switch v {
+	case VendorMix:
+		return "Vendor_Mix"
+	case MostCostEffective:
+		return "Most_Cost_Effective"
+	default:
+		return "Unknown"
+	}
+}
+
+// EventType classifies entries in the simulation event log
+type EventType int
+
+const (
+	AttritionEvent EventType = iota
+	AgentHiredEvent
+	AgentReleasedEvent
+	AgentLevelUpEvent
+	CatastrophicFailureEvent
+	EquilibriumEvent
+	HumanHiredEvent
+	OwnerSuccessionEvent
+	OversightEnforcedEvent
+	MarketShockEvent
+	InsolvencyEvent
+	AgentsRebalancedEvent
+	TerminalStateEvent
+	RuleEvent
+	AlertEvent
+)
+
+// String returns the string representation of EventType
+func (e EventType) String() string {
+	switch e {
+	case AttritionEvent:
+		return "Attrition"
+	case AgentHiredEvent:
+		return "Agent_Hired"
+	case AgentReleasedEvent:
+		return "Agent_Released"
+	case AgentLevelUpEvent:
+		return "Agent_Level_Up"
+	case CatastrophicFailureEvent:
+		return "Catastrophic_Failure"
+	case EquilibriumEvent:
+		return "Equilibrium"
+	case HumanHiredEvent:
+		return "Human_Hired"
+	case OwnerSuccessionEvent:
+		return "Owner_Succession"
+	case OversightEnforcedEvent:
+		return "Oversight_Enforced"
+	case MarketShockEvent:
+		return "Market_Shock"
+	case InsolvencyEvent:
+		return "Insolvency"
+	case AgentsRebalancedEvent:
+		return "Agents_Rebalanced"
+	case TerminalStateEvent:
+		return "Terminal_State"
+	case RuleEvent:
+		return "Rule"
+	case AlertEvent:
+		return "Alert"
+	default:
+		return "Unknown"
+	}
+}
+
+// FailureClass classifies a catastrophic failure by its cause, which sets what it takes to handle
+type FailureClass int
+
+const (
+	SecurityIncident FailureClass = iota
+	ModelOutage
+	DataCorruption
+	ComplianceBreach
+)
+
+// NumFailureClasses is the number of failure classes; tables indexed by FailureClass have this length
+const NumFailureClasses = 4
+
+// String returns the string representation of FailureClass
+func (f FailureClass) String() string {
+	switch f {
+	case SecurityIncident:
+		return "Security_Incident"
+	case ModelOutage:
+		return "Model_Outage"
+	case DataCorruption:
+		return "Data_Corruption"
+	case ComplianceBreach:
+		return "Compliance_Breach"
+	default:
+		return "Unknown"
+	}
+}
+
+// EquilibriumReasonCode classifies why a simulation reached equilibrium or stopped without it
+type EquilibriumReasonCode int
+
+const (
+	EquilibriumNotReached EquilibriumReasonCode = iota
+	OrchestrationCapacityReached
+	BudgetExhausted
+	CostEffectivenessEquilibrium
+	CompositionStable
+	MaxStepsReached
+	Insolvent
+	OrganizationCollapsed
+	OnlyOwnerRemains
+	LimitExceeded
+)
+
+// String returns the string representation of EquilibriumReasonCode
+func (e EquilibriumReasonCode) String() string {
+	switch e {
+	case EquilibriumNotReached:
+		return "Not_Reached"
+	case OrchestrationCapacityReached:
+		return "Orchestration_Capacity_Reached"
+	case BudgetExhausted:
+		return "Budget_Exhausted"
+	case CostEffectivenessEquilibrium:
+		return "Cost_Effectiveness_Equilibrium"
+	case CompositionStable:
+		return "Composition_Stable"
+	case MaxStepsReached:
+		return "Max_Steps_Reached"
+	case Insolvent:
+		return "Insolvent"
+	case OrganizationCollapsed:
+		return "Organization_Collapsed"
+	case OnlyOwnerRemains:
+		return "Only_Owner_Remains"
+	case LimitExceeded:
+		return "Limit_Exceeded"
+	default:
+		return "Unknown"
+	}
+}
+
+// RunStatus classifies how a simulation run ended
+type RunStatus int
+
+const (
+	StatusUnknown RunStatus = iota
+	StatusEquilibrium
+	StatusMaxStepsReached
+	StatusInsolvent
+	StatusCollapsed
+	StatusOnlyOwnerRemains
+	StatusAborted // a resource limit of the run was exceeded, see controller.Limits
+)
+
+// String returns the string representation of RunStatus
+func (s RunStatus) String() string {
+	switch s {
+	case StatusEquilibrium:
+		return "Equilibrium"
+	case StatusMaxStepsReached:
+		return "Max_Steps_Reached"
+	case StatusInsolvent:
+		return "Insolvent"
+	case StatusCollapsed:
+		return "Collapsed"
+	case StatusOnlyOwnerRemains:
+		return "Only_Owner_Remains"
+	case StatusAborted:
+		return "Aborted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Terminal reports whether the run ended because the organization could no longer operate or
+// change, rather than by settling into equilibrium or running out of steps
+func (s RunStatus) Terminal() bool {
+	return s == StatusInsolvent || s == StatusCollapsed || s == StatusOnlyOwnerRemains
+}
+
+// DecisionVerdict is how a reviewer resolved a workforce change proposed by the optimizer
+type DecisionVerdict int
+
+const (
+	DecisionApproved DecisionVerdict = iota
+	DecisionModified
+	DecisionRejected
+)
+
+// String returns the string representation of DecisionVerdict
+func (v DecisionVerdict) String() string {
+	switch v {
+	case DecisionApproved:
+		return "Approved"
+	case DecisionModified:
+		return "Modified"
+	case DecisionRejected:
+		return "Rejected"
+	default:
+		return "Unknown"
+	}
+}
+
+// AlertComparator is how an alert rule compares its metric with its threshold
+type AlertComparator int
+
+const (
+	Below     AlertComparator = iota // the metric is less than the threshold
+	AtOrBelow                        // the metric is less than or equal to the threshold
+	Above                            // the metric is greater than the threshold
+	AtOrAbove                        // the metric is greater than or equal to the threshold
+)
+
+// String returns the string representation of AlertComparator
+func (a AlertComparator) String() string {
+	switch a {
+	case Below:
+		return "<"
+	case AtOrBelow:
+		return "<="
+	case Above:
+		return ">"
+	case AtOrAbove:
+		return ">="
+	default:
+		return "Unknown"
+	}
+}
+
+// Holds reports whether value compares with threshold as the comparator requires
+func (a AlertComparator) Holds(value float64, threshold float64) bool {
+	switch a {
+	case Below:
+		return value < threshold
+	case AtOrBelow:
+		return value <= threshold
+	case Above:
+		return value > threshold
+	case AtOrAbove:
+		return value >= threshold
+	default:
+		return false
+	}
+}
+
+// RunStatusOf returns the status of a run that stopped for the given reason
+func RunStatusOf(reason EquilibriumReasonCode) RunStatus {
+	switch reason {
+	case EquilibriumNotReached:
+		return StatusUnknown
+	case MaxStepsReached:
+		return StatusMaxStepsReached
+	case Insolvent:
+		return StatusInsolvent
+	case OrganizationCollapsed:
+		return StatusCollapsed
+	case OnlyOwnerRemains:
+		return StatusOnlyOwnerRemains
+	case LimitExceeded:
+		return StatusAborted
+	default:
+		return StatusEquilibrium
+	}
+}
+
 // OrchestrationLimit is the maximum number of AI agents a single human can manage
 const OrchestrationLimit = 6
 
+// TimeStepsPerYear is the number of simulation time steps in a year (each step is a month)
+const TimeStepsPerYear = 12
+
 // Cost and productivity values for human workers based on experience level and cost category
 var (
-	// BaseCosts maps experience level and cost category to annual cost
-	BaseCosts = map[ExperienceLevel]map[CostCategory]float64{
+	// BaseCosts holds the annual cost indexed by experience level and cost category
+	BaseCosts = [NumExperienceLevels][NumCostCategories]float64{
 		UniversityHire: {
 			HighCostUS:   100000,
 			LowCostNonUS: 40000,
@@ -114,8 +476,8 @@ var (
 		},
 	}
 
-	// BaseProductivity maps experience level to productivity value
-	BaseProductivity = map[ExperienceLevel]float64{
+	// BaseProductivity holds the productivity value indexed by experience level
+	BaseProductivity = [NumExperienceLevels]float64{
 		UniversityHire: 1.0,
 		MidLevel:       2.0,
 		Senior:         3.5,
@@ -128,7 +490,9 @@ type HumanWorker struct {
 	ID               string
 	ExperienceLevel  ExperienceLevel
 	CostCategory     CostCategory
-	BaseCost         float64
+	ContractType     ContractType
+	BaseCost         float64 // annual cost; for contractors the hourly rate times billed hours
+	HourlyRate       float64 // contractor hourly rate, zero for salaried workers
 	BaseProductivity float64
 	AssignedAgents   []string // IDs of assigned AI agents
 	IsBusinessOwner  bool
@@ -136,7 +500,9 @@ type HumanWorker struct {
 
 // NewHumanWorker creates a new HumanWorker with attributes assigned based on experience level and cost category
 func NewHumanWorker(id string, experienceLevel ExperienceLevel, costCategory CostCategory, isBusinessOwner bool) *HumanWorker {
-	baseCost := BaseCosts[experienceLevel][costCategory]
+	// Categories beyond the default regions start at the High_Cost_US cost until the worker
+	// is placed in their region with ApplyEmployment
+	baseCost := RegionTable(nil).Region(costCategory).AnnualCost(experienceLevel)
 	baseProductivity := BaseProductivity[experienceLevel]
 
 	return &HumanWorker{
@@ -151,12 +517,22 @@ func NewHumanWorker(id string, experienceLevel ExperienceLevel, costCategory Cos
 }
 
 // GetEffectiveProductivity calculates the effective productivity of the human worker
-// applying time zone inefficiency penalty for Low_Cost_Non_US workers
-func (h *HumanWorker) GetEffectiveProductivity(timeZoneInefficiency float64) float64 {
-	if h.CostCategory == LowCostNonUS {
-		return h.BaseProductivity * (1.0 - timeZoneInefficiency)
+// applying the time zone inefficiency penalty of the worker's region
+func (h *HumanWorker) GetEffectiveProductivity(regions RegionTable) float64 {
+	return h.BaseProductivity * (1.0 - regions.Region(h.CostCategory).TimeZoneInefficiency)
+}
+
+// ApplyEmployment places the worker in a region on a contract type, recalculating their cost
+// and their productivity, which scales with the hours they work
+func (h *HumanWorker) ApplyEmployment(region Region, contract ContractType, terms ContractConfig) {
+	fullTimeCost := region.AnnualCost(h.ExperienceLevel)
+	h.ContractType = contract
+	h.BaseCost = terms.AnnualCost(fullTimeCost, contract)
+	h.HourlyRate = 0
+	if contract == Contractor {
+		h.HourlyRate = terms.HourlyRate(fullTimeCost)
 	}
-	return h.BaseProductivity
+	h.BaseProductivity = BaseProductivity[h.ExperienceLevel] * terms.HoursPerYear(contract) / StandardHoursPerYear
 }
 
 // CanOrchestrateMoreAgents checks if the human worker can orchestrate additional AI agents
@@ -171,16 +547,16 @@ func (h *HumanWorker) GetOrchestrationCapacity() int {
 
 // AI Agent cost and productivity values based on experience level
 var (
-	// AIAgentCosts maps experience level to annual cost for AI agents
-	AIAgentCosts = map[ExperienceLevel]float64{
+	// AIAgentCosts holds the annual cost of AI agents indexed by experience level
+	AIAgentCosts = [NumExperienceLevels]float64{
 		UniversityHire: 20000,
 		MidLevel:       40000,
 		Senior:         70000,
 		Executive:      100000,
 	}
 
-	// AIAgentProductivity maps experience level to productivity value for AI agents
-	AIAgentProductivity = map[ExperienceLevel]float64{
+	// AIAgentProductivity holds the productivity value of AI agents indexed by experience level
+	AIAgentProductivity = [NumExperienceLevels]float64{
 		UniversityHire: 0.8,
 		MidLevel:       1.8,
 		Senior:         3.2,
@@ -196,6 +572,10 @@ type AIAgent struct {
 	Cost            float64
 	OrchestratorID  string
 	CreationTime    int // time step when the agent was created
+	Domain          int // index into the domain table; 0 without domains
+	Vendor          int // index into the vendor tiers; 0 without vendor tiers
+	CostMultiplier         float64 // agent cost as a multiple of AIAgentCosts, set by its vendor tier; 0 for 1
+	ProductivityMultiplier float64 // agent productivity as a multiple of AIAgentProductivity, set by its vendor tier; 0 for 1
 }
 
 // NewAIAgent creates a new AIAgent initialized at University_Hire level
@@ -210,6 +590,36 @@ func NewAIAgent(id string, orchestratorID string, creationTime int) *AIAgent {
 	}
 }
 
+// ApplyVendor places the agent on a vendor tier, recalculating its cost
+func (a *AIAgent) ApplyVendor(vendor int, tier VendorTier) {
+	a.Vendor = vendor
+	a.CostMultiplier = tier.CostMultiplier
+	a.ProductivityMultiplier = tier.ProductivityMultiplier
+	a.Cost = a.levelCost(a.ExperienceLevel)
+}
+
+// SetLevel places the agent at a level, priced at its vendor tier's cost of the level
+func (a *AIAgent) SetLevel(level ExperienceLevel) {
+	a.ExperienceLevel = level
+	a.Cost = a.levelCost(level)
+}
+
+// levelCost returns the annual cost of the agent at a level from its vendor tier
+func (a *AIAgent) levelCost(level ExperienceLevel) float64 {
+	if a.CostMultiplier == 0 {
+		return AIAgentCosts[level]
+	}
+	return AIAgentCosts[level] * a.CostMultiplier
+}
+
+// ProductivityFactor returns the multiple of its level's productivity the agent delivers
+func (a *AIAgent) ProductivityFactor() float64 {
+	if a.ProductivityMultiplier == 0 {
+		return 1.0
+	}
+	return a.ProductivityMultiplier
+}
+
 // AccumulateExperience calculates and adds experience points based on time and data exposure
 // timeDelta is the number of time steps elapsed
 // dataExposure is a multiplier representing the amount of data the agent has been exposed to (typically 1.0)
@@ -223,32 +633,23 @@ func (a *AIAgent) AccumulateExperience(timeDelta int, dataExposure float64) {
 // Returns true if a level up occurred
 // learningSpeed contains the thresholds for each level progression
 func (a *AIAgent) CheckLevelUp(learningSpeed AILearningSpeed) bool {
-	var threshold float64
-	var nextLevel ExperienceLevel
-	
-	switch a.ExperienceLevel {
-	case UniversityHire:
-		threshold = float64(learningSpeed.UniversityToMid)
-		nextLevel = MidLevel
-	case MidLevel:
-		threshold = float64(learningSpeed.MidToSenior)
-		nextLevel = Senior
-	case Senior:
-		threshold = float64(learningSpeed.SeniorToExecutive)
-		nextLevel = Executive
-	case Executive:
-		// Already at max level
-		return false
-	default:
+	return a.CheckTrainedLevelUp(learningSpeed, 1.0)
+}
+
+// CheckTrainedLevelUp is CheckLevelUp with the threshold of the agent's level scaled by
+// thresholdMultiplier, e.g. lowered by targeted training
+func (a *AIAgent) CheckTrainedLevelUp(learningSpeed AILearningSpeed, thresholdMultiplier float64) bool {
+	// Executive agents are already at the max level
+	if a.ExperienceLevel < UniversityHire || a.ExperienceLevel >= Executive {
 		return false
 	}
+	threshold := learningSpeed.Threshold(a.ExperienceLevel) * thresholdMultiplier
+	nextLevel := a.ExperienceLevel + 1
 	
 	// Check if experience points exceed the threshold
 	if a.ExperiencePoints >= threshold {
-		a.ExperienceLevel = nextLevel
+		a.SetLevel(nextLevel)
 		a.ExperiencePoints = 0.0 // Reset experience points for the new level
-		// Update cost based on new experience level
-		a.Cost = AIAgentCosts[nextLevel]
 		return true
 	}
 	
@@ -257,7 +658,7 @@ func (a *AIAgent) CheckLevelUp(learningSpeed AILearningSpeed) bool {
 
 // GetProductivity returns the productivity value based on the agent's current experience level
 func (a *AIAgent) GetProductivity() float64 {
-	return AIAgentProductivity[a.ExperienceLevel]
+	return AIAgentProductivity[a.ExperienceLevel] * a.ProductivityFactor()
 }
 
 // GetCost returns the cost of the agent based on their current experience level
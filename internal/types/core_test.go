@@ -1,6 +1,9 @@
 package types
 
 import (
+	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -79,6 +82,55 @@ func TestAttritionTypeString(t *testing.T) {
 	}
 }
 
+func TestEquilibriumReasonCodeString(t *testing.T) {
+	tests := []struct {
+		code     EquilibriumReasonCode
+		expected string
+	}{
+		{EquilibriumNotReached, "Not_Reached"},
+		{OrchestrationCapacityReached, "Orchestration_Capacity_Reached"},
+		{BudgetExhausted, "Budget_Exhausted"},
+		{CostEffectivenessEquilibrium, "Cost_Effectiveness_Equilibrium"},
+		{CompositionStable, "Composition_Stable"},
+		{MaxStepsReached, "Max_Steps_Reached"},
+		{OrganizationCollapsed, "Organization_Collapsed"},
+		{OnlyOwnerRemains, "Only_Owner_Remains"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.code.String(); got != tt.expected {
+				t.Errorf("EquilibriumReasonCode.String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunStatusOf(t *testing.T) {
+	tests := []struct {
+		reason   EquilibriumReasonCode
+		status   RunStatus
+		terminal bool
+	}{
+		{CompositionStable, StatusEquilibrium, false},
+		{BudgetExhausted, StatusEquilibrium, false},
+		{MaxStepsReached, StatusMaxStepsReached, false},
+		{Insolvent, StatusInsolvent, true},
+		{OrganizationCollapsed, StatusCollapsed, true},
+		{OnlyOwnerRemains, StatusOnlyOwnerRemains, true},
+		{LimitExceeded, StatusAborted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason.String(), func(t *testing.T) {
+			status := RunStatusOf(tt.reason)
+			if status != tt.status || status.Terminal() != tt.terminal {
+				t.Errorf("RunStatusOf(%s) = %s (terminal %t), want %s (terminal %t)", tt.reason, status, status.Terminal(), tt.status, tt.terminal)
+			}
+		})
+	}
+}
+
 func TestOrchestrationLimit(t *testing.T) {
 	if OrchestrationLimit != 6 {
 		t.Errorf("OrchestrationLimit = %v, want 6", OrchestrationLimit)
@@ -195,7 +247,7 @@ func TestGetEffectiveProductivity(t *testing.T) {
 				BaseProductivity: tt.baseProductivity,
 			}
 			
-			got := worker.GetEffectiveProductivity(tt.timeZoneInefficiency)
+			got := worker.GetEffectiveProductivity(DefaultRegions(CostCategoryDistribution{}, tt.timeZoneInefficiency))
 			// Use a small tolerance for floating point comparison
 			const tolerance = 1e-9
 			if diff := got - tt.expected; diff < -tolerance || diff > tolerance {
@@ -396,3 +448,336 @@ func TestGetCost(t *testing.T) {
 		})
 	}
 }
+
+func TestAttritionConfigHiringFreezeScope(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      AttritionConfig
+		humanFrozen bool
+		aiFrozen    bool
+	}{
+		{"natural attrition", AttritionConfig{Type: NaturalAttrition}, false, false},
+		// Configurations written before the flags existed keep freezing human hiring only
+		{"hiring freeze", AttritionConfig{Type: HiringFreeze}, true, false},
+		{"hiring freeze humans only", AttritionConfig{Type: HiringFreeze, FreezeHumanHiring: true}, true, false},
+		{"hiring freeze with AI", AttritionConfig{Type: HiringFreeze, FreezeAIHiring: true}, true, true},
+		{"natural attrition AI freeze", AttritionConfig{Type: NaturalAttrition, FreezeAIHiring: true}, false, true},
+		{"reduction in force human freeze", AttritionConfig{Type: ReductionInForce, FreezeHumanHiring: true}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.HumanHiringFrozen(); got != tt.humanFrozen {
+				t.Errorf("HumanHiringFrozen() = %v, want %v", got, tt.humanFrozen)
+			}
+			if got := tt.config.AIHiringFrozen(); got != tt.aiFrozen {
+				t.Errorf("AIHiringFrozen() = %v, want %v", got, tt.aiFrozen)
+			}
+		})
+	}
+}
+
+func TestOrchestrationEffectivenessMultiplier(t *testing.T) {
+	effectiveness := OrchestrationEffectiveness{UniversityHire: 0.8, Senior: 1.1, Executive: 1.2}
+
+	tests := []struct {
+		level    ExperienceLevel
+		expected float64
+	}{
+		{UniversityHire, 0.8},
+		{MidLevel, 1.0}, // unset multipliers have no effect
+		{Senior, 1.1},
+		{Executive, 1.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := effectiveness.Multiplier(tt.level); got != tt.expected {
+				t.Errorf("Multiplier(%s) = %v, want %v", tt.level, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrchestrationEffectivenessProductivityTable(t *testing.T) {
+	effectiveness := OrchestrationEffectiveness{UniversityHire: 0.5, Executive: 1.5}
+	table := effectiveness.ProductivityTable()
+
+	for orchestrator := UniversityHire; orchestrator <= Executive; orchestrator++ {
+		for agent := UniversityHire; agent <= Executive; agent++ {
+			expected := AIAgentProductivity[agent] * effectiveness.Multiplier(orchestrator)
+			if got := table[orchestrator][agent]; got != expected {
+				t.Errorf("table[%s][%s] = %v, want %v", orchestrator, agent, got, expected)
+			}
+		}
+	}
+}
+
+func TestApplyEmployment(t *testing.T) {
+	terms := ContractConfig{ContractorRateMultiplier: 1.5, ContractorHoursPerYear: 1040, PartTimeFraction: 0.6}
+	regions := DefaultRegions(CostCategoryDistribution{}, 0)
+
+	contractor := NewHumanWorker("h1", Senior, HighCostUS, false)
+	contractor.ApplyEmployment(regions.Region(HighCostUS), Contractor, terms)
+	expectedRate := 200000.0 / StandardHoursPerYear * 1.5
+	if contractor.HourlyRate != expectedRate {
+		t.Errorf("HourlyRate = %v, want %v", contractor.HourlyRate, expectedRate)
+	}
+	if contractor.BaseCost != expectedRate*1040 {
+		t.Errorf("Contractor BaseCost = %v, want %v", contractor.BaseCost, expectedRate*1040)
+	}
+	if contractor.BaseProductivity != 3.5*0.5 {
+		t.Errorf("Contractor BaseProductivity = %v, want %v", contractor.BaseProductivity, 3.5*0.5)
+	}
+
+	partTime := NewHumanWorker("h2", MidLevel, LowCostNonUS, false)
+	partTime.ApplyEmployment(regions.Region(LowCostNonUS), PartTime, terms)
+	if partTime.BaseCost != 60000*0.6 || partTime.HourlyRate != 0 {
+		t.Errorf("Part-time BaseCost = %v, HourlyRate = %v, want %v and 0", partTime.BaseCost, partTime.HourlyRate, 60000*0.6)
+	}
+	if partTime.BaseProductivity != 2.0*0.6 {
+		t.Errorf("Part-time BaseProductivity = %v, want %v", partTime.BaseProductivity, 2.0*0.6)
+	}
+
+	fullTime := NewHumanWorker("h3", Executive, HighCostUS, false)
+	fullTime.ApplyEmployment(Region{Name: "EU", CostMultiplier: 0.8}, FullTime, ContractConfig{})
+	if fullTime.BaseCost != 240000 || fullTime.BaseProductivity != 5.0 {
+		t.Errorf("FTE BaseCost = %v, BaseProductivity = %v, want 240000 and 5", fullTime.BaseCost, fullTime.BaseProductivity)
+	}
+}
+
+func TestContractConfigDefaults(t *testing.T) {
+	var terms ContractConfig
+
+	if got := terms.AnnualCost(150000, Contractor); got != 150000 {
+		t.Errorf("Default contractor cost = %v, want the FTE-equivalent 150000", got)
+	}
+	if got := terms.AnnualCost(150000, PartTime); got != 75000 {
+		t.Errorf("Default part-time cost = %v, want half of 150000", got)
+	}
+}
+
+func TestDefaultRegionsMatchCostCategories(t *testing.T) {
+	regions := DefaultRegions(CostCategoryDistribution{HighCostUS: 70, LowCostNonUS: 30}, 0.15)
+
+	for level := UniversityHire; level <= Executive; level++ {
+		for category := HighCostUS; category <= LowCostNonUS; category++ {
+			if got := regions.Region(category).AnnualCost(level); got != BaseCosts[level][category] {
+				t.Errorf("%s %s cost = %v, want %v", category, level, got, BaseCosts[level][category])
+			}
+		}
+	}
+	if regions.Name(LowCostNonUS) != "Low_Cost_Non_US" || regions[LowCostNonUS].Share != 30 {
+		t.Errorf("Unexpected Low_Cost_Non_US region %+v", regions[LowCostNonUS])
+	}
+	if regions[HighCostUS].TimeZoneInefficiency != 0 || regions[LowCostNonUS].TimeZoneInefficiency != 0.15 {
+		t.Errorf("Expected the time zone penalty on Low_Cost_Non_US only, got %+v", regions)
+	}
+	if regions.Region(LowCostNonUS).AttritionMultiplier() != 1.0 {
+		t.Errorf("Expected a neutral attrition multiplier, got %v", regions.Region(LowCostNonUS).AttritionMultiplier())
+	}
+}
+
+func TestRegionTableFallback(t *testing.T) {
+	var regions RegionTable
+
+	worker := NewHumanWorker("h1", MidLevel, LowCostNonUS, false)
+	if got := worker.GetEffectiveProductivity(regions); got != 2.0 {
+		t.Errorf("GetEffectiveProductivity() with no regions = %v, want 2", got)
+	}
+	if got := regions.Region(LowCostNonUS).AnnualCost(MidLevel); got != 60000 {
+		t.Errorf("Fallback Low_Cost_Non_US cost = %v, want 60000", got)
+	}
+}
+
+func TestWorkloadConfig(t *testing.T) {
+	workload := WorkloadConfig{Required: 100, RecoveryRate: 0.5}
+
+	if got := workload.Overtime(50, 100); got != 0 {
+		t.Errorf("Overtime() with the workload met = %v, want 0", got)
+	}
+	if got := workload.Overtime(50, 95); got != 0.1 {
+		t.Errorf("Overtime() for a 5 unit shortfall = %v, want 0.1", got)
+	}
+	if got := workload.Overtime(50, 60); got != 0.2 {
+		t.Errorf("Overtime() beyond the default cap = %v, want 0.2", got)
+	}
+	if got := (WorkloadConfig{}).Overtime(50, 0); got != 0 {
+		t.Errorf("Overtime() without a required workload = %v, want 0", got)
+	}
+
+	if got := workload.Burnout(0.4, 0.2); math.Abs(got-0.6) > 1e-12 {
+		t.Errorf("Burnout() after overtime = %v, want 0.6", got)
+	}
+	if got := workload.Burnout(0.4, 0); got != 0.2 {
+		t.Errorf("Burnout() after recovery = %v, want 0.2", got)
+	}
+	if got := workload.AttritionMultiplier(0.5); got != 1.5 {
+		t.Errorf("AttritionMultiplier() = %v, want 1.5", got)
+	}
+}
+
+func TestSharedLearningSpeedup(t *testing.T) {
+	shared := SharedLearningConfig{Acceleration: 0.1}
+
+	if got := shared.Speedup(0); got != 1.0 {
+		t.Errorf("Speedup(0) = %v, want 1", got)
+	}
+	if got := shared.Speedup(5); got != 1.5 {
+		t.Errorf("Speedup(5) = %v, want 1.5", got)
+	}
+	if got := shared.Speedup(100); got != 3.0 {
+		t.Errorf("Speedup(100) = %v, want the default cap of 3", got)
+	}
+	if got := (SharedLearningConfig{Acceleration: 0.1, MaxSpeedup: 1.2}).Speedup(5); got != 1.2 {
+		t.Errorf("Speedup(5) with a 1.2 cap = %v, want 1.2", got)
+	}
+}
+
+func TestOversightConfig(t *testing.T) {
+	if (OversightConfig{}).Enabled() || (OversightConfig{MinHumans: 1}).Enabled() {
+		t.Error("Expected oversight to require both MinHumans and PerAgents")
+	}
+
+	oversight := OversightConfig{MinHumans: 2, PerAgents: 5}
+	if got := oversight.MaxAgents(7); got != 17 {
+		t.Errorf("MaxAgents(7) = %d, want 17", got)
+	}
+}
+
+func TestOverheadConfig(t *testing.T) {
+	if got := (OverheadConfig{}).HumanCost(100000, FullTime); got != 100000 {
+		t.Errorf("HumanCost with no overhead = %v, want 100000", got)
+	}
+
+	overhead := OverheadConfig{Employer: 1.3, AIInfrastructure: 1.2}
+	if got := overhead.HumanCost(100000, PartTime); got != 130000 {
+		t.Errorf("HumanCost for a part-time employee = %v, want 130000", got)
+	}
+	if got := overhead.HumanCost(100000, Contractor); got != 100000 {
+		t.Errorf("HumanCost for a contractor = %v, want the unloaded 100000", got)
+	}
+	if got := overhead.AICost(20000); got != 24000 {
+		t.Errorf("AICost = %v, want 24000", got)
+	}
+}
+
+func TestMarketShocksConditions(t *testing.T) {
+	shocks := MarketShocks{
+		{TimeStep: 5, Type: DemandShock, Change: -30},
+		{TimeStep: 10, Type: AICostShock, Change: -50},
+		{TimeStep: 10, Type: AICostShock, Change: -50},
+		{TimeStep: 12, Type: SalaryInflation, Change: 8},
+	}
+
+	if got := shocks.Conditions(4); got != NormalMarket() {
+		t.Errorf("Conditions(4) = %+v, want the normal market", got)
+	}
+	got := shocks.Conditions(12)
+	if math.Abs(got.Demand-0.7) > 1e-9 || math.Abs(got.AICost-0.25) > 1e-9 || math.Abs(got.Salary-1.08) > 1e-9 {
+		t.Errorf("Conditions(12) = %+v, want demand 0.7, AI cost 0.25 and salary 1.08", got)
+	}
+
+	if at := shocks.At(10); len(at) != 2 {
+		t.Errorf("At(10) returned %d shocks, want 2", len(at))
+	}
+	if !shocks.Pending(11) || shocks.Pending(12) {
+		t.Error("Expected a shock pending after step 11 and none after step 12")
+	}
+	if s := shocks[0].String(); s != "Demand_Shock of -30%" {
+		t.Errorf("String() = %q, want %q", s, "Demand_Shock of -30%")
+	}
+}
+
+func TestCashConfigInsolvent(t *testing.T) {
+	if (CashConfig{}).Insolvent(-1e9) {
+		t.Error("Expected disabled cash tracking never to be insolvent")
+	}
+	cash := CashConfig{Enabled: true, CreditLimit: 1000}
+	if cash.Insolvent(-1000) {
+		t.Error("Expected a balance at the credit limit to be solvent")
+	}
+	if !cash.Insolvent(-1000.01) {
+		t.Error("Expected a balance beyond the credit limit to be insolvent")
+	}
+}
+
+func TestSimulationStateNetCashFlow(t *testing.T) {
+	state := SimulationState{RevenueOutput: 1200000, TotalCost: 600000}
+	state.CostBreakdown.Severance = 20000
+	state.CostBreakdown.Recruiting = 5000
+	if got := state.NetCashFlow(); got != 25000 {
+		t.Errorf("NetCashFlow() = %v, want 25000", got)
+	}
+}
+
+func TestTaskDemandMatch(t *testing.T) {
+	if (TaskDemand{}).Enabled() {
+		t.Error("Expected no task demand by default")
+	}
+
+	// Senior tasks take all three seniors and two executives; University_Hire tasks take the
+	// juniors and mid-levels and then four more executives, leaving executives 60% utilized
+	demand := TaskDemand{UniversityHire: 10, Senior: 5}
+	utilization := demand.Match([NumExperienceLevels]float64{4, 2, 3, 10})
+	if utilization.Delivered != 15 {
+		t.Errorf("Delivered = %v, want 15", utilization.Delivered)
+	}
+	want := map[ExperienceLevel]float64{UniversityHire: 100, MidLevel: 100, Senior: 100, Executive: 60}
+	for level, percent := range want {
+		if math.Abs(utilization.ByExperience[level]-percent) > 1e-9 {
+			t.Errorf("ByExperience[%s] = %v, want %v", level, utilization.ByExperience[level], percent)
+		}
+	}
+	if math.Abs(utilization.Overall-1500.0/19.0) > 1e-9 {
+		t.Errorf("Overall = %v, want %v", utilization.Overall, 1500.0/19.0)
+	}
+}
+
+func TestEnumsRoundTripAsNames(t *testing.T) {
+	var composition WorkforceComposition
+	composition.Humans.ByExperience = map[ExperienceLevel]int{Senior: 3, Executive: 1}
+	composition.Humans.ByCostCategory = map[CostCategory]int{LowCostNonUS: 2, CostCategory(4): 1}
+	reason := EquilibriumReason{Code: OnlyOwnerRemains}
+	
+	data, err := json.Marshal(struct {
+		Composition WorkforceComposition
+		Reason      EquilibriumReason
+		Status      RunStatus
+		Attrition   AttritionType
+	}{composition, reason, StatusCollapsed, HiringFreeze})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, name := range []string{`"Senior":3`, `"Low_Cost_Non_US":2`, `"4":1`, `"Only_Owner_Remains"`, `"Collapsed"`, `"Hiring_Freeze"`} {
+		if !strings.Contains(string(data), name) {
+			t.Errorf("Expected %s in %s", name, data)
+		}
+	}
+	
+	var decoded struct {
+		Composition WorkforceComposition
+		Reason      EquilibriumReason
+		Status      RunStatus
+		Attrition   AttritionType
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Composition.Humans.ByExperience[Senior] != 3 || decoded.Composition.Humans.ByCostCategory[CostCategory(4)] != 1 ||
+		decoded.Reason.Code != OnlyOwnerRemains || decoded.Status != StatusCollapsed || decoded.Attrition != HiringFreeze {
+		t.Errorf("Expected the values to round-trip, got %+v", decoded)
+	}
+	
+	// Numbers written before enums were named still decode, as values and as map keys
+	var legacy struct {
+		Levels map[ExperienceLevel]int
+		Type   ShockType
+	}
+	if err := json.Unmarshal([]byte(`{"Levels": {"2": 5}, "Type": 1}`), &legacy); err != nil {
+		t.Fatalf("Unmarshal of numbers failed: %v", err)
+	}
+	if legacy.Levels[Senior] != 5 || legacy.Type != AICostShock {
+		t.Errorf("Expected numbers to decode, got %+v", legacy)
+	}
+}
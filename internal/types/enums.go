@@ -0,0 +1,370 @@
+package types
+
+import "workforce-ai-transition-simulator/internal/enumtext"
+
+// The enum types marshal as their names, in JSON values and map keys alike, and unmarshal from
+// names or from the numbers earlier versions wrote
+
+// Number of named values of the enum types without an exported count
+const (
+	numRevenueScenarios       = int(ExplosiveGrowth) + 1
+	numAttritionTypes         = int(ReductionInForce) + 1
+	numShockTypes             = int(SalaryInflation) + 1
+	numAssignmentStrategies   = int(LeastLoaded) + 1
+	numVendorPolicies         = int(MostCostEffective) + 1
+	numEventTypes             = int(RuleEvent) + 1
+	numEquilibriumReasonCodes = int(LimitExceeded) + 1
+	numRunStatuses            = int(StatusAborted) + 1
+	numDecisionVerdicts       = int(DecisionRejected) + 1
+	numAlertComparators       = int(AtOrAbove) + 1
+)
+
+// MarshalText encodes ExperienceLevel as its name
+func (e ExperienceLevel) MarshalText() ([]byte, error) {
+	return enumtext.Text(e, NumExperienceLevels), nil
+}
+
+// UnmarshalText decodes ExperienceLevel from its name or number
+func (e *ExperienceLevel) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[ExperienceLevel](string(text), NumExperienceLevels)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// UnmarshalJSON decodes ExperienceLevel from its name or number, as a JSON string or number
+func (e *ExperienceLevel) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[ExperienceLevel](data, NumExperienceLevels)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalText encodes CostCategory as its name
+func (c CostCategory) MarshalText() ([]byte, error) {
+	return enumtext.Text(c, NumCostCategories), nil
+}
+
+// UnmarshalText decodes CostCategory from its name or number
+func (c *CostCategory) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[CostCategory](string(text), NumCostCategories)
+	if err != nil {
+		return err
+	}
+	*c = value
+	return nil
+}
+
+// UnmarshalJSON decodes CostCategory from its name or number, as a JSON string or number
+func (c *CostCategory) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[CostCategory](data, NumCostCategories)
+	if err != nil {
+		return err
+	}
+	*c = value
+	return nil
+}
+
+// MarshalText encodes ContractType as its name
+func (c ContractType) MarshalText() ([]byte, error) {
+	return enumtext.Text(c, NumContractTypes), nil
+}
+
+// UnmarshalText decodes ContractType from its name or number
+func (c *ContractType) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[ContractType](string(text), NumContractTypes)
+	if err != nil {
+		return err
+	}
+	*c = value
+	return nil
+}
+
+// UnmarshalJSON decodes ContractType from its name or number, as a JSON string or number
+func (c *ContractType) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[ContractType](data, NumContractTypes)
+	if err != nil {
+		return err
+	}
+	*c = value
+	return nil
+}
+
+// MarshalText encodes RevenueScenario as its name
+func (r RevenueScenario) MarshalText() ([]byte, error) {
+	return enumtext.Text(r, numRevenueScenarios), nil
+}
+
+// UnmarshalText decodes RevenueScenario from its name or number
+func (r *RevenueScenario) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[RevenueScenario](string(text), numRevenueScenarios)
+	if err != nil {
+		return err
+	}
+	*r = value
+	return nil
+}
+
+// UnmarshalJSON decodes RevenueScenario from its name or number, as a JSON string or number
+func (r *RevenueScenario) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[RevenueScenario](data, numRevenueScenarios)
+	if err != nil {
+		return err
+	}
+	*r = value
+	return nil
+}
+
+// MarshalText encodes AttritionType as its name
+func (a AttritionType) MarshalText() ([]byte, error) {
+	return enumtext.Text(a, numAttritionTypes), nil
+}
+
+// UnmarshalText decodes AttritionType from its name or number
+func (a *AttritionType) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[AttritionType](string(text), numAttritionTypes)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// UnmarshalJSON decodes AttritionType from its name or number, as a JSON string or number
+func (a *AttritionType) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[AttritionType](data, numAttritionTypes)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// MarshalText encodes ShockType as its name
+func (s ShockType) MarshalText() ([]byte, error) {
+	return enumtext.Text(s, numShockTypes), nil
+}
+
+// UnmarshalText decodes ShockType from its name or number
+func (s *ShockType) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[ShockType](string(text), numShockTypes)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// UnmarshalJSON decodes ShockType from its name or number, as a JSON string or number
+func (s *ShockType) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[ShockType](data, numShockTypes)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// MarshalText encodes AssignmentStrategy as its name
+func (a AssignmentStrategy) MarshalText() ([]byte, error) {
+	return enumtext.Text(a, numAssignmentStrategies), nil
+}
+
+// UnmarshalText decodes AssignmentStrategy from its name or number
+func (a *AssignmentStrategy) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[AssignmentStrategy](string(text), numAssignmentStrategies)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// UnmarshalJSON decodes AssignmentStrategy from its name or number, as a JSON string or number
+func (a *AssignmentStrategy) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[AssignmentStrategy](data, numAssignmentStrategies)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// MarshalText encodes VendorPolicy as its name
+func (v VendorPolicy) MarshalText() ([]byte, error) {
+	return enumtext.Text(v, numVendorPolicies), nil
+}
+
+// UnmarshalText decodes VendorPolicy from its name or number
+func (v *VendorPolicy) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[VendorPolicy](string(text), numVendorPolicies)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON decodes VendorPolicy from its name or number, as a JSON string or number
+func (v *VendorPolicy) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[VendorPolicy](data, numVendorPolicies)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// MarshalText encodes EventType as its name
+func (e EventType) MarshalText() ([]byte, error) {
+	return enumtext.Text(e, numEventTypes), nil
+}
+
+// UnmarshalText decodes EventType from its name or number
+func (e *EventType) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[EventType](string(text), numEventTypes)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// UnmarshalJSON decodes EventType from its name or number, as a JSON string or number
+func (e *EventType) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[EventType](data, numEventTypes)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalText encodes FailureClass as its name
+func (f FailureClass) MarshalText() ([]byte, error) {
+	return enumtext.Text(f, NumFailureClasses), nil
+}
+
+// UnmarshalText decodes FailureClass from its name or number
+func (f *FailureClass) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[FailureClass](string(text), NumFailureClasses)
+	if err != nil {
+		return err
+	}
+	*f = value
+	return nil
+}
+
+// UnmarshalJSON decodes FailureClass from its name or number, as a JSON string or number
+func (f *FailureClass) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[FailureClass](data, NumFailureClasses)
+	if err != nil {
+		return err
+	}
+	*f = value
+	return nil
+}
+
+// MarshalText encodes EquilibriumReasonCode as its name
+func (e EquilibriumReasonCode) MarshalText() ([]byte, error) {
+	return enumtext.Text(e, numEquilibriumReasonCodes), nil
+}
+
+// UnmarshalText decodes EquilibriumReasonCode from its name or number
+func (e *EquilibriumReasonCode) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[EquilibriumReasonCode](string(text), numEquilibriumReasonCodes)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// UnmarshalJSON decodes EquilibriumReasonCode from its name or number, as a JSON string or number
+func (e *EquilibriumReasonCode) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[EquilibriumReasonCode](data, numEquilibriumReasonCodes)
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalText encodes RunStatus as its name
+func (s RunStatus) MarshalText() ([]byte, error) {
+	return enumtext.Text(s, numRunStatuses), nil
+}
+
+// UnmarshalText decodes RunStatus from its name or number
+func (s *RunStatus) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[RunStatus](string(text), numRunStatuses)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// UnmarshalJSON decodes RunStatus from its name or number, as a JSON string or number
+func (s *RunStatus) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[RunStatus](data, numRunStatuses)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// MarshalText encodes DecisionVerdict as its name
+func (v DecisionVerdict) MarshalText() ([]byte, error) {
+	return enumtext.Text(v, numDecisionVerdicts), nil
+}
+
+// UnmarshalText decodes DecisionVerdict from its name or number
+func (v *DecisionVerdict) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[DecisionVerdict](string(text), numDecisionVerdicts)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON decodes DecisionVerdict from its name or number, as a JSON string or number
+func (v *DecisionVerdict) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[DecisionVerdict](data, numDecisionVerdicts)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// MarshalText encodes AlertComparator as its symbol
+func (a AlertComparator) MarshalText() ([]byte, error) {
+	return enumtext.Text(a, numAlertComparators), nil
+}
+
+// UnmarshalText decodes AlertComparator from its symbol or number
+func (a *AlertComparator) UnmarshalText(text []byte) error {
+	value, err := enumtext.Parse[AlertComparator](string(text), numAlertComparators)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// UnmarshalJSON decodes AlertComparator from its symbol or number, as a JSON string or number
+func (a *AlertComparator) UnmarshalJSON(data []byte) error {
+	value, err := enumtext.UnmarshalJSON[AlertComparator](data, numAlertComparators)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds shared across packages
+// Errors returned by the simulator wrap one of these, so callers can branch on the kind of
+// failure with errors.Is regardless of the message
+var (
+	ErrInvalidConfig           = errors.New("invalid configuration")
+	ErrBudgetExceeded          = errors.New("budget exceeded")
+	ErrNoOrchestrationCapacity = errors.New("no orchestration capacity")
+	ErrBusinessOwnerRemoval    = errors.New("cannot remove business owner")
+	ErrBusinessOwnerExists     = errors.New("business owner already exists")
+	ErrNotFound                = errors.New("not found")
+	ErrHiringFrozen            = errors.New("hiring is frozen")
+)
+
+// ConfigError reports a configuration field that violates a constraint
+// It matches ErrInvalidConfig with errors.Is; use errors.As to inspect the field
+type ConfigError struct {
+	Field      string      // path of the field, e.g. "AttritionConfig.NaturalRate"
+	Constraint string      // the violated constraint, e.g. "must be between 0 and 100"
+	Value      interface{} // the offending value
+}
+
+// Error returns a description of the violated constraint
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s %s, got %v", e.Field, e.Constraint, e.Value)
+}
+
+// Is reports whether target is ErrInvalidConfig
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Version is the simulator version recorded in the metadata of every run
+// Release builds set it with -ldflags "-X workforce-ai-transition-simulator/internal/types.Version=v1.2.0"
+var Version = "dev"
+
+// RunMetadata records how a simulation result was produced, so results stored over time can
+// be traced back to the exact configuration, seed and simulator version behind them
+type RunMetadata struct {
+	RunID      string    // random UUID identifying the run
+	Timestamp  time.Time // when the run started, in UTC
+	Seed       int64
+	Version    string      // simulator Version that produced the run
+	ConfigHash string      // SHA-256 of the configuration, see SimulationConfig.Hash
+	Lineage    *RunLineage `json:",omitempty"` // run this one was branched from; nil unless it is a what-if branch
+}
+
+// RunLineage records the run a what-if branch was started from and where
+type RunLineage struct {
+	ParentRunID      string
+	ParentConfigHash string // configuration of the parent run, which the branch may have changed
+	BranchStep       int    // time step of the parent run the branch continues from
+}
+
+// NewRunID returns a fresh random identifier for a run
+func NewRunID() string {
+	return newUUID()
+}
+
+// NewRunMetadata returns the metadata of a run of config with seed started at the given time,
+// under a freshly generated RunID
+func NewRunMetadata(config SimulationConfig, seed int64, startedAt time.Time) RunMetadata {
+	return RunMetadata{
+		RunID:      newUUID(),
+		Timestamp:  startedAt.UTC(),
+		Seed:       seed,
+		Version:    Version,
+		ConfigHash: config.Hash(),
+	}
+}
+
+// Hash returns the hex-encoded SHA-256 of the configuration's JSON encoding
+// Equal configurations hash equally, so runs of the same configuration can be grouped
+func (c SimulationConfig) Hash() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// SimulationConfig holds only plain data, so encoding cannot fail
+		panic(fmt.Sprintf("failed to encode configuration: %v", err))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newUUID returns a random (version 4) UUID in its canonical textual form
+func newUUID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		// crypto/rand only fails if the operating system's entropy source is unavailable
+		panic(fmt.Sprintf("failed to generate run ID: %v", err))
+	}
+	id[6] = id[6]&0x0f | 0x40 // version 4
+	id[8] = id[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewRunMetadata(t *testing.T) {
+	config := validConfig()
+	startedAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	metadata := NewRunMetadata(config, 7, startedAt)
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !uuid.MatchString(metadata.RunID) {
+		t.Errorf("Expected a version 4 UUID, got %q", metadata.RunID)
+	}
+	if !metadata.Timestamp.Equal(startedAt) || metadata.Timestamp.Location() != time.UTC {
+		t.Errorf("Expected the start time in UTC, got %v", metadata.Timestamp)
+	}
+	if metadata.Seed != 7 || metadata.Version != Version || metadata.ConfigHash != config.Hash() {
+		t.Errorf("Unexpected metadata %+v", metadata)
+	}
+
+	if again := NewRunMetadata(config, 7, startedAt); again.RunID == metadata.RunID {
+		t.Error("Expected every run to get a new run ID")
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	config := validConfig()
+	if len(config.Hash()) != 64 || config.Hash() != validConfig().Hash() {
+		t.Errorf("Expected equal configurations to share a SHA-256 hash, got %q", config.Hash())
+	}
+
+	config.FixedBudget++
+	if config.Hash() == validConfig().Hash() {
+		t.Error("Expected a changed configuration to hash differently")
+	}
+}
@@ -0,0 +1,515 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FieldBounds describes the numeric range a configuration field must fall in
+// Bounds are inclusive unless ExclusiveMin is set; unbounded sides are ±Inf
+type FieldBounds struct {
+	Field        string
+	Min          float64
+	Max          float64
+	ExclusiveMin bool
+	Integer      bool // the field holds an integer
+}
+
+// Constraint describes the bounds in words, e.g. "must be between 0 and 1"
+func (b FieldBounds) Constraint() string {
+	switch {
+	case b.ExclusiveMin && math.IsInf(b.Max, 1):
+		return fmt.Sprintf("must be greater than %g", b.Min)
+	case b.Min == 0 && math.IsInf(b.Max, 1):
+		return "must be non-negative"
+	default:
+		return fmt.Sprintf("must be between %g and %g", b.Min, b.Max)
+	}
+}
+
+// contains reports whether value satisfies the bounds
+func (b FieldBounds) contains(value float64) bool {
+	if math.IsNaN(value) || value > b.Max {
+		return false
+	}
+	if b.ExclusiveMin {
+		return value > b.Min
+	}
+	return value >= b.Min
+}
+
+// boundedField pairs a field's bounds with an accessor for its value
+type boundedField struct {
+	FieldBounds
+	value func(c SimulationConfig) float64
+}
+
+// positive, nonNegative, between and enum build the bounds of a field
+func positive(field string, integer bool, value func(c SimulationConfig) float64) boundedField {
+	return boundedField{FieldBounds{Field: field, Min: 0, Max: math.Inf(1), ExclusiveMin: true, Integer: integer}, value}
+}
+
+func nonNegative(field string, value func(c SimulationConfig) float64) boundedField {
+	return boundedField{FieldBounds{Field: field, Min: 0, Max: math.Inf(1)}, value}
+}
+
+func between(field string, min, max float64, value func(c SimulationConfig) float64) boundedField {
+	return boundedField{FieldBounds{Field: field, Min: min, Max: max}, value}
+}
+
+func enum(field string, max int, value func(c SimulationConfig) float64) boundedField {
+	return boundedField{FieldBounds{Field: field, Min: 0, Max: float64(max), Integer: true}, value}
+}
+
+// boundedFields lists the range constraints of every numeric configuration field, in the
+// order they are checked
+var boundedFields = []boundedField{
+	enum("Version", ConfigVersion, func(c SimulationConfig) float64 { return float64(c.Version) }),
+	positive("InitialHumans", true, func(c SimulationConfig) float64 { return float64(c.InitialHumans) }),
+	between("ExperienceDistribution.UniversityHire", 0, 100, func(c SimulationConfig) float64 { return c.ExperienceDistribution.UniversityHire }),
+	between("ExperienceDistribution.MidLevel", 0, 100, func(c SimulationConfig) float64 { return c.ExperienceDistribution.MidLevel }),
+	between("ExperienceDistribution.Senior", 0, 100, func(c SimulationConfig) float64 { return c.ExperienceDistribution.Senior }),
+	between("ExperienceDistribution.Executive", 0, 100, func(c SimulationConfig) float64 { return c.ExperienceDistribution.Executive }),
+	between("CostCategoryDistribution.HighCostUS", 0, 100, func(c SimulationConfig) float64 { return c.CostCategoryDistribution.HighCostUS }),
+	between("CostCategoryDistribution.LowCostNonUS", 0, 100, func(c SimulationConfig) float64 { return c.CostCategoryDistribution.LowCostNonUS }),
+	between("Contracts.ContractorShare", 0, 100, func(c SimulationConfig) float64 { return c.Contracts.ContractorShare }),
+	between("Contracts.PartTimeShare", 0, 100, func(c SimulationConfig) float64 { return c.Contracts.PartTimeShare }),
+	nonNegative("Contracts.ContractorRateMultiplier", func(c SimulationConfig) float64 { return c.Contracts.ContractorRateMultiplier }),
+	nonNegative("Contracts.ContractorHoursPerYear", func(c SimulationConfig) float64 { return c.Contracts.ContractorHoursPerYear }),
+	between("Contracts.PartTimeFraction", 0, 1, func(c SimulationConfig) float64 { return c.Contracts.PartTimeFraction }),
+	nonNegative("Overhead.Employer", func(c SimulationConfig) float64 { return c.Overhead.Employer }),
+	nonNegative("Overhead.AIInfrastructure", func(c SimulationConfig) float64 { return c.Overhead.AIInfrastructure }),
+	positive("FixedBudget", false, func(c SimulationConfig) float64 { return c.FixedBudget }),
+	enum("RevenueScenario", int(ExplosiveGrowth), func(c SimulationConfig) float64 { return float64(c.RevenueScenario) }),
+	nonNegative("RevenueVolatility", func(c SimulationConfig) float64 { return c.RevenueVolatility }),
+	nonNegative("MarketSize", func(c SimulationConfig) float64 { return c.MarketSize }),
+	between("DiscountRate", 0, 1, func(c SimulationConfig) float64 { return c.DiscountRate }),
+	boundedField{FieldBounds{Field: "AICostCurve.AnnualChange", Min: -100, Max: math.Inf(1), ExclusiveMin: true}, func(c SimulationConfig) float64 { return c.AICostCurve.AnnualChange }},
+	between("AICostCurve.Floor", 0, 1, func(c SimulationConfig) float64 { return c.AICostCurve.Floor }),
+	nonNegative("AIPricing.PlatformFee", func(c SimulationConfig) float64 { return c.AIPricing.PlatformFee }),
+	nonNegative("Cash.CreditLimit", func(c SimulationConfig) float64 { return c.Cash.CreditLimit }),
+	nonNegative("Financing.CreditLine", func(c SimulationConfig) float64 { return c.Financing.CreditLine }),
+	between("Financing.InterestRate", 0, 1, func(c SimulationConfig) float64 { return c.Financing.InterestRate }),
+	boundedField{FieldBounds{Field: "Financing.RepaymentSteps", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Financing.RepaymentSteps) }},
+	positive("AILearningSpeeds.UniversityToMid", true, func(c SimulationConfig) float64 { return float64(c.AILearningSpeeds.UniversityToMid) }),
+	positive("AILearningSpeeds.MidToSenior", true, func(c SimulationConfig) float64 { return float64(c.AILearningSpeeds.MidToSenior) }),
+	positive("AILearningSpeeds.SeniorToExecutive", true, func(c SimulationConfig) float64 { return float64(c.AILearningSpeeds.SeniorToExecutive) }),
+	nonNegative("SharedLearning.Acceleration", func(c SimulationConfig) float64 { return c.SharedLearning.Acceleration }),
+	nonNegative("SharedLearning.MaxSpeedup", func(c SimulationConfig) float64 { return c.SharedLearning.MaxSpeedup }),
+	nonNegative("DataExposure.Availability", func(c SimulationConfig) float64 { return c.DataExposure.Availability }),
+	nonNegative("DataExposure.LevelWeight", func(c SimulationConfig) float64 { return c.DataExposure.LevelWeight }),
+	between("DataExposure.WorkloadWeight", 0, 1, func(c SimulationConfig) float64 { return c.DataExposure.WorkloadWeight }),
+	between("Training.BudgetShare", 0, 1, func(c SimulationConfig) float64 { return c.Training.BudgetShare }),
+	nonNegative("Training.CostPerAgent", func(c SimulationConfig) float64 { return c.Training.CostPerAgent }),
+	between("Training.ThresholdReduction", 0, 1, func(c SimulationConfig) float64 { return c.Training.ThresholdReduction }),
+	enum("Training.MinLevel", int(Senior), func(c SimulationConfig) float64 { return float64(c.Training.MinLevel) }),
+	nonNegative("OrchestrationEffectiveness.UniversityHire", func(c SimulationConfig) float64 { return c.OrchestrationEffectiveness.UniversityHire }),
+	nonNegative("OrchestrationEffectiveness.MidLevel", func(c SimulationConfig) float64 { return c.OrchestrationEffectiveness.MidLevel }),
+	nonNegative("OrchestrationEffectiveness.Senior", func(c SimulationConfig) float64 { return c.OrchestrationEffectiveness.Senior }),
+	nonNegative("OrchestrationEffectiveness.Executive", func(c SimulationConfig) float64 { return c.OrchestrationEffectiveness.Executive }),
+	boundedField{FieldBounds{Field: "Oversight.MinHumans", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Oversight.MinHumans) }},
+	boundedField{FieldBounds{Field: "Oversight.PerAgents", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Oversight.PerAgents) }},
+	enum("Assignment.Strategy", int(LeastLoaded), func(c SimulationConfig) float64 { return float64(c.Assignment.Strategy) }),
+	enum("Vendors.Policy", int(MostCostEffective), func(c SimulationConfig) float64 { return float64(c.Vendors.Policy) }),
+	enum("AttritionConfig.Type", int(ReductionInForce), func(c SimulationConfig) float64 { return float64(c.AttritionConfig.Type) }),
+	between("AttritionConfig.NaturalRate", 0, 100, func(c SimulationConfig) float64 { return c.AttritionConfig.NaturalRate }),
+	nonNegative("AttritionConfig.ForcedAcceleration", func(c SimulationConfig) float64 { return c.AttritionConfig.ForcedAcceleration }),
+	nonNegative("AttritionConfig.SeveranceMonths", func(c SimulationConfig) float64 { return c.AttritionConfig.SeveranceMonths }),
+	between("Backfill.Fraction", 0, 1, func(c SimulationConfig) float64 { return c.Backfill.Fraction }),
+	boundedField{FieldBounds{Field: "Backfill.LagSteps", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Backfill.LagSteps) }},
+	nonNegative("Backfill.RecruitingCost", func(c SimulationConfig) float64 { return c.Backfill.RecruitingCost }),
+	nonNegative("Workload.Required", func(c SimulationConfig) float64 { return c.Workload.Required }),
+	between("Workload.MaxOvertime", 0, 1, func(c SimulationConfig) float64 { return c.Workload.MaxOvertime }),
+	nonNegative("Workload.BurnoutAttrition", func(c SimulationConfig) float64 { return c.Workload.BurnoutAttrition }),
+	between("Workload.RecoveryRate", 0, 1, func(c SimulationConfig) float64 { return c.Workload.RecoveryRate }),
+	nonNegative("Tasks.UniversityHire", func(c SimulationConfig) float64 { return c.Tasks.UniversityHire }),
+	nonNegative("Tasks.MidLevel", func(c SimulationConfig) float64 { return c.Tasks.MidLevel }),
+	nonNegative("Tasks.Senior", func(c SimulationConfig) float64 { return c.Tasks.Senior }),
+	nonNegative("Tasks.Executive", func(c SimulationConfig) float64 { return c.Tasks.Executive }),
+	between("CatastrophicFailureRate", 0, 1, func(c SimulationConfig) float64 { return c.CatastrophicFailureRate }),
+	nonNegative("Recovery.RemediationCost", func(c SimulationConfig) float64 { return c.Recovery.RemediationCost }),
+	boundedField{FieldBounds{Field: "Recovery.RecoverySteps", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Recovery.RecoverySteps) }},
+	between("Recovery.SeniorOccupancy", 0, 1, func(c SimulationConfig) float64 { return c.Recovery.SeniorOccupancy }),
+	between("Resilience.BudgetShare", 0, 1, func(c SimulationConfig) float64 { return c.Resilience.BudgetShare }),
+	between("Resilience.MaxRateReduction", 0, 1, func(c SimulationConfig) float64 { return c.Resilience.MaxRateReduction }),
+	between("Resilience.MaxSeverityReduction", 0, 1, func(c SimulationConfig) float64 { return c.Resilience.MaxSeverityReduction }),
+	nonNegative("Resilience.HalfEffectShare", func(c SimulationConfig) float64 { return c.Resilience.HalfEffectShare }),
+	between("TimeZoneInefficiency", 0, 1, func(c SimulationConfig) float64 { return c.TimeZoneInefficiency }),
+	boundedField{FieldBounds{Field: "Equilibrium.Window", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Equilibrium.Window) }},
+	between("Equilibrium.MaxCompositionChange", 0, 100, func(c SimulationConfig) float64 { return c.Equilibrium.MaxCompositionChange }),
+	nonNegative("Equilibrium.BudgetSlack", func(c SimulationConfig) float64 { return c.Equilibrium.BudgetSlack }),
+	boundedField{FieldBounds{Field: "Equilibrium.ConfirmSteps", Min: 0, Max: math.Inf(1), Integer: true}, func(c SimulationConfig) float64 { return float64(c.Equilibrium.ConfirmSteps) }},
+}
+
+// regionFields lists the range constraints of the numeric fields of each configured region
+// Their Field is relative to the region, e.g. "Share"
+var regionFields = []struct {
+	FieldBounds
+	value func(r Region) float64
+}{
+	{FieldBounds{Field: "Share", Min: 0, Max: 100}, func(r Region) float64 { return r.Share }},
+	{FieldBounds{Field: "CostMultiplier", Min: 0, Max: math.Inf(1)}, func(r Region) float64 { return r.CostMultiplier }},
+	{FieldBounds{Field: "TimeZoneInefficiency", Min: 0, Max: 1}, func(r Region) float64 { return r.TimeZoneInefficiency }},
+	{FieldBounds{Field: "AttritionModifier", Min: 0, Max: math.Inf(1)}, func(r Region) float64 { return r.AttritionModifier }},
+}
+
+// shockFields lists the range constraints of the numeric fields of each scheduled market shock
+// Their Field is relative to the shock, e.g. "Change"; a change of -100% or less would wipe
+// out revenue or costs entirely
+var shockFields = []struct {
+	FieldBounds
+	value func(s MarketShock) float64
+}{
+	{FieldBounds{Field: "TimeStep", Min: 0, Max: math.Inf(1), Integer: true}, func(s MarketShock) float64 { return float64(s.TimeStep) }},
+	{FieldBounds{Field: "Type", Min: 0, Max: float64(SalaryInflation), Integer: true}, func(s MarketShock) float64 { return float64(s.Type) }},
+	{FieldBounds{Field: "Change", Min: -100, Max: math.Inf(1), ExclusiveMin: true}, func(s MarketShock) float64 { return s.Change }},
+}
+
+// domainFields lists the range constraints of the numeric fields of each configured domain
+// Their Field is relative to the domain, e.g. "Share"
+var domainFields = []struct {
+	FieldBounds
+	value func(d Domain) float64
+}{
+	{FieldBounds{Field: "Share", Min: 0, Max: 100}, func(d Domain) float64 { return d.Share }},
+	{FieldBounds{Field: "MaxAgentLevel", Min: 0, Max: float64(Executive), Integer: true}, func(d Domain) float64 { return float64(d.MaxAgentLevel) }},
+}
+
+// vendorFields lists the range constraints of the numeric fields of each vendor tier
+// Their Field is relative to the tier, e.g. "CostMultiplier"
+var vendorFields = []struct {
+	FieldBounds
+	value func(v VendorTier) float64
+}{
+	{FieldBounds{Field: "Share", Min: 0, Max: 100}, func(v VendorTier) float64 { return v.Share }},
+	{FieldBounds{Field: "CostMultiplier", Min: 0, Max: math.Inf(1)}, func(v VendorTier) float64 { return v.CostMultiplier }},
+	{FieldBounds{Field: "ProductivityMultiplier", Min: 0, Max: math.Inf(1)}, func(v VendorTier) float64 { return v.ProductivityMultiplier }},
+	{FieldBounds{Field: "LearningMultiplier", Min: 0, Max: math.Inf(1)}, func(v VendorTier) float64 { return v.LearningMultiplier }},
+	{FieldBounds{Field: "FailureMultiplier", Min: 0, Max: math.Inf(1)}, func(v VendorTier) float64 { return v.FailureMultiplier }},
+}
+
+// discountFields lists the range constraints of the numeric fields of each AI volume discount
+// Their Field is relative to the discount, e.g. "Discount"
+var discountFields = []struct {
+	FieldBounds
+	value func(d VolumeDiscount) float64
+}{
+	{FieldBounds{Field: "MinAgents", Min: 0, Max: math.Inf(1), Integer: true}, func(d VolumeDiscount) float64 { return float64(d.MinAgents) }},
+	{FieldBounds{Field: "Discount", Min: 0, Max: 1}, func(d VolumeDiscount) float64 { return d.Discount }},
+}
+
+// alertFields lists the range constraints of the numeric fields of each alert rule
+// Their Field is relative to the rule, e.g. "Comparator"
+var alertFields = []struct {
+	FieldBounds
+	value func(r AlertRule) float64
+}{
+	{FieldBounds{Field: "Comparator", Min: 0, Max: float64(AtOrAbove), Integer: true}, func(r AlertRule) float64 { return float64(r.Comparator) }},
+}
+
+// failureClassFields lists the range constraints of the numeric fields of each failure class
+// Their Field is relative to the class, e.g. "Weight"
+var failureClassFields = []struct {
+	FieldBounds
+	value func(f FailureClassConfig) float64
+}{
+	{FieldBounds{Field: "Weight", Min: 0, Max: math.Inf(1)}, func(f FailureClassConfig) float64 { return f.Weight }},
+	{FieldBounds{Field: "MinSeniorHumans", Min: 0, Max: math.Inf(1), Integer: true}, func(f FailureClassConfig) float64 { return float64(f.MinSeniorHumans) }},
+	{FieldBounds{Field: "Capability", Min: 0, Max: math.Inf(1)}, func(f FailureClassConfig) float64 { return f.Capability }},
+	{FieldBounds{Field: "AgentCapability", Min: 0, Max: math.Inf(1)}, func(f FailureClassConfig) float64 { return f.AgentCapability }},
+	{FieldBounds{Field: "UnhandledPenalty", Min: 0, Max: 1}, func(f FailureClassConfig) float64 { return f.UnhandledPenalty }},
+	{FieldBounds{Field: "GapPenalty", Min: 0, Max: 1}, func(f FailureClassConfig) float64 { return f.GapPenalty }},
+	{FieldBounds{Field: "RemediationCost", Min: 0, Max: math.Inf(1)}, func(f FailureClassConfig) float64 { return f.RemediationCost }},
+	{FieldBounds{Field: "RecoverySteps", Min: 0, Max: math.Inf(1), Integer: true}, func(f FailureClassConfig) float64 { return float64(f.RecoverySteps) }},
+}
+
+// failureClassFieldNames are the names of the FailureClasses fields, indexed by FailureClass
+var failureClassFieldNames = [NumFailureClasses]string{"SecurityIncident", "ModelOutage", "DataCorruption", "ComplianceBreach"}
+
+// ConfigFieldBounds returns the range constraints of every numeric configuration field
+// Fields of list elements are named with an empty index, e.g. "Regions[].Share"
+func ConfigFieldBounds() []FieldBounds {
+	bounds := make([]FieldBounds, 0, len(boundedFields)+len(regionFields)+len(domainFields)+len(vendorFields)+len(discountFields)+len(shockFields)+len(alertFields)+NumFailureClasses*len(failureClassFields))
+	for _, field := range boundedFields {
+		bounds = append(bounds, field.FieldBounds)
+	}
+	for _, field := range regionFields {
+		b := field.FieldBounds
+		b.Field = "Regions[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, field := range domainFields {
+		b := field.FieldBounds
+		b.Field = "Domains[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, field := range vendorFields {
+		b := field.FieldBounds
+		b.Field = "Vendors.Tiers[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, field := range discountFields {
+		b := field.FieldBounds
+		b.Field = "AIPricing.Discounts[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, field := range shockFields {
+		b := field.FieldBounds
+		b.Field = "Shocks[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, field := range alertFields {
+		b := field.FieldBounds
+		b.Field = "Alerts[]." + b.Field
+		bounds = append(bounds, b)
+	}
+	for _, name := range failureClassFieldNames {
+		for _, field := range failureClassFields {
+			b := field.FieldBounds
+			b.Field = "FailureClasses." + name + "." + b.Field
+			bounds = append(bounds, b)
+		}
+	}
+	return bounds
+}
+
+// Diagnose checks every configuration constraint and returns all violations
+// Returns an empty slice if the configuration is valid
+func (c SimulationConfig) Diagnose() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+
+	for _, field := range boundedFields {
+		value := field.value(c)
+		if field.contains(value) {
+			continue
+		}
+		var reported interface{} = value
+		if field.Integer {
+			reported = int(value)
+		}
+		diagnostics = append(diagnostics, ConfigError{Field: field.Field, Constraint: field.Constraint(), Value: reported})
+	}
+
+	// Percentage distributions must sum to 100%, allowing small floating point errors
+	expSum := c.ExperienceDistribution.UniversityHire +
+		c.ExperienceDistribution.MidLevel +
+		c.ExperienceDistribution.Senior +
+		c.ExperienceDistribution.Executive
+	if expSum < 99.9 || expSum > 100.1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "ExperienceDistribution", Constraint: "must sum to 100", Value: expSum})
+	}
+	if len(c.Regions) > 0 {
+		diagnostics = append(diagnostics, c.diagnoseRegions()...)
+	} else {
+		costSum := c.CostCategoryDistribution.HighCostUS +
+			c.CostCategoryDistribution.LowCostNonUS
+		if costSum < 99.9 || costSum > 100.1 {
+			diagnostics = append(diagnostics, ConfigError{Field: "CostCategoryDistribution", Constraint: "must sum to 100", Value: costSum})
+		}
+	}
+	if share := c.Resilience.BudgetShare + c.Training.BudgetShare; share > 1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "Training.BudgetShare", Constraint: "must not exceed 1 together with Resilience.BudgetShare", Value: c.Training.BudgetShare})
+	}
+	if maxSpeedup := c.SharedLearning.MaxSpeedup; maxSpeedup > 0 && maxSpeedup < 1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "SharedLearning.MaxSpeedup", Constraint: "must be 0 (the default) or at least 1", Value: maxSpeedup})
+	}
+	for _, overhead := range []struct {
+		field string
+		value float64
+	}{{"Overhead.Employer", c.Overhead.Employer}, {"Overhead.AIInfrastructure", c.Overhead.AIInfrastructure}} {
+		if overhead.value > 0 && overhead.value < 1 {
+			diagnostics = append(diagnostics, ConfigError{Field: overhead.field, Constraint: "must be 0 (the default) or at least 1", Value: overhead.value})
+		}
+	}
+	if (c.Oversight.MinHumans > 0) != (c.Oversight.PerAgents > 0) {
+		diagnostics = append(diagnostics, ConfigError{Field: "Oversight", Constraint: "MinHumans and PerAgents must be set together", Value: fmt.Sprintf("%d per %d", c.Oversight.MinHumans, c.Oversight.PerAgents)})
+	}
+	if contractSum := c.Contracts.ContractorShare + c.Contracts.PartTimeShare; contractSum > 100.1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "Contracts", Constraint: "shares must not exceed 100 in total", Value: contractSum})
+	}
+	if len(c.Domains) > 0 {
+		diagnostics = append(diagnostics, c.diagnoseDomains()...)
+	}
+	if len(c.Vendors.Tiers) > 0 {
+		diagnostics = append(diagnostics, c.diagnoseVendors()...)
+	}
+	for i, discount := range c.AIPricing.Discounts {
+		for _, field := range discountFields {
+			if value := field.value(discount); !field.contains(value) {
+				var reported interface{} = value
+				if field.Integer {
+					reported = int(value)
+				}
+				diagnostics = append(diagnostics, ConfigError{Field: fmt.Sprintf("AIPricing.Discounts[%d].%s", i, field.Field), Constraint: field.Constraint(), Value: reported})
+			}
+		}
+	}
+	diagnostics = append(diagnostics, c.diagnoseShocks()...)
+	diagnostics = append(diagnostics, c.diagnoseAlerts()...)
+	diagnostics = append(diagnostics, c.diagnoseFailureClasses()...)
+
+	return diagnostics
+}
+
+// diagnoseFailureClasses checks the configured failure classes
+func (c SimulationConfig) diagnoseFailureClasses() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+	for i, class := range c.FailureClasses.Table() {
+		prefix := "FailureClasses." + failureClassFieldNames[i] + "."
+		for _, field := range failureClassFields {
+			value := field.value(class)
+			if field.contains(value) {
+				continue
+			}
+			var reported interface{} = value
+			if field.Integer {
+				reported = int(value)
+			}
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: reported})
+		}
+	}
+	return diagnostics
+}
+
+// diagnoseShocks checks the scheduled market shocks
+func (c SimulationConfig) diagnoseShocks() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+	for i, shock := range c.Shocks {
+		prefix := fmt.Sprintf("Shocks[%d].", i)
+		for _, field := range shockFields {
+			value := field.value(shock)
+			if field.contains(value) {
+				continue
+			}
+			var reported interface{} = value
+			if field.Integer {
+				reported = int(value)
+			}
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: reported})
+		}
+	}
+	return diagnostics
+}
+
+// diagnoseAlerts checks the configured alert rules
+func (c SimulationConfig) diagnoseAlerts() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+	for i, rule := range c.Alerts {
+		prefix := fmt.Sprintf("Alerts[%d].", i)
+		if _, exists := alertMetrics[rule.Metric]; !exists {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Metric", Constraint: "must be one of " + strings.Join(AlertMetrics(), ", "), Value: rule.Metric})
+		}
+		for _, field := range alertFields {
+			if value := field.value(rule); !field.contains(value) {
+				diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: int(value)})
+			}
+		}
+		if math.IsNaN(rule.Threshold) || math.IsInf(rule.Threshold, 0) {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Threshold", Constraint: "must be a finite number", Value: rule.Threshold})
+		}
+	}
+	return diagnostics
+}
+
+// diagnoseRegions checks the configured region table
+func (c SimulationConfig) diagnoseRegions() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+
+	names := make(map[string]bool, len(c.Regions))
+	shareSum := 0.0
+	for i, region := range c.Regions {
+		prefix := fmt.Sprintf("Regions[%d].", i)
+		if region.Name == "" {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must not be empty", Value: region.Name})
+		} else if names[region.Name] {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must be unique", Value: region.Name})
+		}
+		names[region.Name] = true
+
+		for _, field := range regionFields {
+			if value := field.value(region); !field.contains(value) {
+				diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: value})
+			}
+		}
+		shareSum += region.Share
+	}
+	if shareSum < 99.9 || shareSum > 100.1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "Regions", Constraint: "shares must sum to 100", Value: shareSum})
+	}
+
+	return diagnostics
+}
+
+// diagnoseDomains checks the configured domain table
+func (c SimulationConfig) diagnoseDomains() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+
+	names := make(map[string]bool, len(c.Domains))
+	shareSum := 0.0
+	for i, domain := range c.Domains {
+		prefix := fmt.Sprintf("Domains[%d].", i)
+		if domain.Name == "" {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must not be empty", Value: domain.Name})
+		} else if names[domain.Name] {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must be unique", Value: domain.Name})
+		}
+		names[domain.Name] = true
+
+		for _, field := range domainFields {
+			if value := field.value(domain); !field.contains(value) {
+				var reported interface{} = value
+				if field.Integer {
+					reported = int(value)
+				}
+				diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: reported})
+			}
+		}
+		shareSum += domain.Share
+	}
+	if shareSum < 99.9 || shareSum > 100.1 {
+		diagnostics = append(diagnostics, ConfigError{Field: "Domains", Constraint: "shares must sum to 100", Value: shareSum})
+	}
+
+	return diagnostics
+}
+
+// diagnoseVendors checks the configured vendor tiers; their shares only matter to the Vendor_Mix
+// policy
+func (c SimulationConfig) diagnoseVendors() []ConfigError {
+	diagnostics := make([]ConfigError, 0)
+
+	names := make(map[string]bool, len(c.Vendors.Tiers))
+	shareSum := 0.0
+	for i, tier := range c.Vendors.Tiers {
+		prefix := fmt.Sprintf("Vendors.Tiers[%d].", i)
+		if tier.Name == "" {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must not be empty", Value: tier.Name})
+		} else if names[tier.Name] {
+			diagnostics = append(diagnostics, ConfigError{Field: prefix + "Name", Constraint: "must be unique", Value: tier.Name})
+		}
+		names[tier.Name] = true
+
+		for _, field := range vendorFields {
+			if value := field.value(tier); !field.contains(value) {
+				diagnostics = append(diagnostics, ConfigError{Field: prefix + field.Field, Constraint: field.Constraint(), Value: value})
+			}
+		}
+		shareSum += tier.Share
+	}
+	if c.Vendors.Policy == VendorMix && (shareSum < 99.9 || shareSum > 100.1) {
+		diagnostics = append(diagnostics, ConfigError{Field: "Vendors.Tiers", Constraint: "shares must sum to 100 under the Vendor_Mix policy", Value: shareSum})
+	}
+
+	return diagnostics
+}
+
+// Validate returns the first constraint violation as a *ConfigError, or nil if the
+// configuration is valid
+func (c SimulationConfig) Validate() error {
+	diagnostics := c.Diagnose()
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	return &diagnostics[0]
+}
@@ -0,0 +1,327 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// validConfig returns a configuration that satisfies every constraint
+func validConfig() SimulationConfig {
+	return SimulationConfig{
+		InitialHumans:            10,
+		ExperienceDistribution:   ExperienceDistribution{UniversityHire: 40, MidLevel: 30, Senior: 20, Executive: 10},
+		CostCategoryDistribution: CostCategoryDistribution{HighCostUS: 60, LowCostNonUS: 40},
+		FixedBudget:              1000000,
+		AILearningSpeeds:         AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		AttritionConfig:          AttritionConfig{Type: NaturalAttrition, NaturalRate: 10, ForcedAcceleration: 1},
+		CatastrophicFailureRate:  0.01,
+		TimeZoneInefficiency:     0.1,
+	}
+}
+
+func TestDiagnoseValidConfig(t *testing.T) {
+	config := validConfig()
+	if diagnostics := config.Diagnose(); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected a valid configuration, got %v", err)
+	}
+}
+
+func TestDiagnoseReportsEveryViolation(t *testing.T) {
+	config := validConfig()
+	config.FixedBudget = -1
+	config.AttritionConfig.NaturalRate = 150
+	config.AttritionConfig.Type = AttritionType(7)
+	config.ExperienceDistribution.Executive = 30 // sums to 120
+
+	diagnostics := config.Diagnose()
+	expected := []ConfigError{
+		{Field: "FixedBudget", Constraint: "must be greater than 0", Value: -1.0},
+		{Field: "AttritionConfig.Type", Constraint: "must be between 0 and 2", Value: 7},
+		{Field: "AttritionConfig.NaturalRate", Constraint: "must be between 0 and 100", Value: 150.0},
+		{Field: "ExperienceDistribution", Constraint: "must sum to 100", Value: 120.0},
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(expected), len(diagnostics), diagnostics)
+	}
+	for i := range expected {
+		if diagnostics[i] != expected[i] {
+			t.Errorf("Diagnostic %d = %+v, want %+v", i, diagnostics[i], expected[i])
+		}
+	}
+
+	err := config.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected Validate to return ErrInvalidConfig, got %v", err)
+	}
+	if err.Error() != "FixedBudget must be greater than 0, got -1" {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+}
+
+func TestDiagnoseRegions(t *testing.T) {
+	config := validConfig()
+	config.CostCategoryDistribution = CostCategoryDistribution{} // ignored when regions are set
+	config.Regions = RegionTable{
+		{Name: "US", Share: 60, CostMultiplier: 1},
+		{Name: "US", Share: 30, TimeZoneInefficiency: 1.5},
+		{Share: 20, AttritionModifier: -1},
+	}
+
+	diagnostics := config.Diagnose()
+	expected := []ConfigError{
+		{Field: "Regions[1].Name", Constraint: "must be unique", Value: "US"},
+		{Field: "Regions[1].TimeZoneInefficiency", Constraint: "must be between 0 and 1", Value: 1.5},
+		{Field: "Regions[2].Name", Constraint: "must not be empty", Value: ""},
+		{Field: "Regions[2].AttritionModifier", Constraint: "must be non-negative", Value: -1.0},
+		{Field: "Regions", Constraint: "shares must sum to 100", Value: 110.0},
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(expected), len(diagnostics), diagnostics)
+	}
+	for i := range expected {
+		if diagnostics[i] != expected[i] {
+			t.Errorf("Diagnostic %d = %+v, want %+v", i, diagnostics[i], expected[i])
+		}
+	}
+}
+
+func TestDiagnoseSharedLearningMaxSpeedup(t *testing.T) {
+	config := validConfig()
+	config.SharedLearning = SharedLearningConfig{Acceleration: 0.05, MaxSpeedup: 0.5}
+
+	diagnostics := config.Diagnose()
+	expected := ConfigError{Field: "SharedLearning.MaxSpeedup", Constraint: "must be 0 (the default) or at least 1", Value: 0.5}
+	if len(diagnostics) != 1 || diagnostics[0] != expected {
+		t.Errorf("Expected %+v, got %v", expected, diagnostics)
+	}
+}
+
+func TestDiagnoseOverheadBelowOne(t *testing.T) {
+	config := validConfig()
+	config.Overhead = OverheadConfig{Employer: 1.3, AIInfrastructure: 0.8}
+
+	diagnostics := config.Diagnose()
+	expected := ConfigError{Field: "Overhead.AIInfrastructure", Constraint: "must be 0 (the default) or at least 1", Value: 0.8}
+	if len(diagnostics) != 1 || diagnostics[0] != expected {
+		t.Errorf("Expected %+v, got %v", expected, diagnostics)
+	}
+}
+
+func TestDiagnoseMarketShocks(t *testing.T) {
+	config := validConfig()
+	config.Shocks = MarketShocks{
+		{TimeStep: 12, Type: AICostShock, Change: -50},
+		{TimeStep: -1, Type: DemandShock, Change: -100},
+	}
+
+	diagnostics := config.Diagnose()
+	expected := []ConfigError{
+		{Field: "Shocks[1].TimeStep", Constraint: "must be non-negative", Value: -1},
+		{Field: "Shocks[1].Change", Constraint: "must be greater than -100", Value: -100.0},
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, got %v", len(expected), diagnostics)
+	}
+	for i := range expected {
+		if diagnostics[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], diagnostics[i])
+		}
+	}
+}
+
+func TestDiagnoseFailureClasses(t *testing.T) {
+	config := validConfig()
+	config.FailureClasses.ModelOutage = FailureClassConfig{Weight: 1, Capability: 2}
+	config.FailureClasses.ComplianceBreach = FailureClassConfig{Weight: -1, GapPenalty: 1.5}
+
+	diagnostics := config.Diagnose()
+	expected := []ConfigError{
+		{Field: "FailureClasses.ComplianceBreach.Weight", Constraint: "must be non-negative", Value: -1.0},
+		{Field: "FailureClasses.ComplianceBreach.GapPenalty", Constraint: "must be between 0 and 1", Value: 1.5},
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, got %v", len(expected), diagnostics)
+	}
+	for i := range expected {
+		if diagnostics[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], diagnostics[i])
+		}
+	}
+}
+
+func TestFailureClassTableDefaultsUntilAClassIsWeighted(t *testing.T) {
+	config := validConfig()
+	config.Recovery = FailureRecoveryConfig{RemediationCost: 1000, RecoverySteps: 2}
+	for class, failure := range config.FailureClassTable() {
+		if failure.Weight != 1 || failure.RemediationCost != 1000 || failure.RecoverySteps != 2 {
+			t.Errorf("Expected the default %s class to take the Recovery settings, got %+v", FailureClass(class), failure)
+		}
+	}
+
+	config.FailureClasses.DataCorruption = FailureClassConfig{Weight: 2, RecoverySteps: 6}
+	table := config.FailureClassTable()
+	if table[DataCorruption].RecoverySteps != 6 || table[SecurityIncident].Weight != 0 {
+		t.Errorf("Expected the configured classes once one is weighted, got %+v", table)
+	}
+}
+
+func TestResilienceReturnsDiminish(t *testing.T) {
+	resilience := ResilienceConfig{BudgetShare: 0.05, MaxRateReduction: 0.8, MaxSeverityReduction: 0.4, HalfEffectShare: 0.05}
+	if rate := resilience.RateMultiplier(); math.Abs(rate-0.6) > 1e-9 {
+		t.Errorf("Expected half of the maximum rate reduction at the half-effect share, got multiplier %.4f", rate)
+	}
+	if severity := resilience.SeverityMultiplier(); math.Abs(severity-0.8) > 1e-9 {
+		t.Errorf("Expected half of the maximum severity reduction at the half-effect share, got multiplier %.4f", severity)
+	}
+
+	doubled := resilience
+	doubled.BudgetShare = 0.1
+	if gain := resilience.RateMultiplier() - doubled.RateMultiplier(); gain <= 0 || gain >= 1-resilience.RateMultiplier() {
+		t.Errorf("Expected doubling the spend to help less than the first share did, got %.4f", gain)
+	}
+
+	config := validConfig()
+	config.Resilience = ResilienceConfig{BudgetShare: 0.25}
+	if budget := config.WorkforceBudget(); budget != config.FixedBudget*0.75 {
+		t.Errorf("Expected resilience spend to come out of the workforce budget, got %.2f of %.2f", budget, config.FixedBudget)
+	}
+	if (ResilienceConfig{}).RateMultiplier() != 1 || (ResilienceConfig{}).SeverityMultiplier() != 1 {
+		t.Error("Expected no effect without resilience spend")
+	}
+}
+
+func TestTrainingCapacityAndBudget(t *testing.T) {
+	training := TrainingConfig{BudgetShare: 0.1, CostPerAgent: 30000, ThresholdReduction: 0.4, MinLevel: MidLevel}
+	if capacity := training.Capacity(1000000); capacity != 3 {
+		t.Errorf("Expected a 100000 spend to train 3 agents at 30000 each, got %d", capacity)
+	}
+	if !training.Targets(MidLevel) || !training.Targets(Senior) || training.Targets(UniversityHire) || training.Targets(Executive) {
+		t.Error("Expected Mid_Level and Senior agents to be targeted, and neither University_Hire nor Executive")
+	}
+	if capacity := (TrainingConfig{BudgetShare: 0.1, ThresholdReduction: 0.4}).Capacity(1000000); capacity != -1 {
+		t.Errorf("Expected training without a cost per agent to cover every eligible agent, got %d", capacity)
+	}
+	if capacity := (TrainingConfig{CostPerAgent: 30000, ThresholdReduction: 0.4}).Capacity(1000000); capacity != 0 {
+		t.Errorf("Expected no training without spend, got %d", capacity)
+	}
+
+	config := validConfig()
+	config.Resilience = ResilienceConfig{BudgetShare: 0.25}
+	config.Training = training
+	if budget := config.WorkforceBudget(); math.Abs(budget-config.FixedBudget*0.65) > 1e-6 {
+		t.Errorf("Expected resilience and training spend to come out of the workforce budget, got %.2f of %.2f", budget, config.FixedBudget)
+	}
+	config.Training.BudgetShare = 0.8
+	diagnostics := config.Diagnose()
+	expected := ConfigError{Field: "Training.BudgetShare", Constraint: "must not exceed 1 together with Resilience.BudgetShare", Value: 0.8}
+	if len(diagnostics) != 1 || diagnostics[0] != expected {
+		t.Errorf("Expected %+v, got %v", expected, diagnostics)
+	}
+}
+
+func TestCheckTrainedLevelUp(t *testing.T) {
+	speed := AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20}
+	agent := NewAIAgent("agent-1", "human-1", 0)
+	agent.ExperiencePoints = 6
+	if agent.CheckLevelUp(speed) {
+		t.Fatal("Expected an untrained agent to need 10 experience")
+	}
+	if !agent.CheckTrainedLevelUp(speed, 0.6) || agent.ExperienceLevel != MidLevel || agent.Cost != AIAgentCosts[MidLevel] {
+		t.Errorf("Expected a threshold lowered to 6 to promote the agent, got %s", agent.ExperienceLevel)
+	}
+	agent.ExperienceLevel = Executive
+	agent.ExperiencePoints = 1000
+	if agent.CheckTrainedLevelUp(speed, 0.1) || speed.Threshold(Executive) != math.Inf(1) {
+		t.Error("Expected Executive agents to have no level left to reach")
+	}
+}
+
+func TestDomainAssignmentAndCeilings(t *testing.T) {
+	domains := DomainTable{{Name: "Support", Share: 25, MaxAgentLevel: MidLevel}, {Name: "Engineering", Share: 75, MaxAgentLevel: Executive}}
+	counts := make([]int, len(domains))
+	for i := 0; i < 8; i++ {
+		counts[domains.Assign(counts)]++
+	}
+	if counts[0] != 2 || counts[1] != 6 {
+		t.Errorf("Expected 8 agents split 2 and 6 by share, got %v", counts)
+	}
+	if domains.MaxAgentLevel(0) != MidLevel || domains.MaxAgentLevel(5) != Executive || (DomainTable{}).MaxAgentLevel(0) != Executive {
+		t.Error("Expected agents outside the domain table to reach Executive")
+	}
+
+	config := validConfig()
+	config.Domains = DomainTable{{Name: "Support", Share: 40, MaxAgentLevel: 7}, {Name: "Support", Share: 40}}
+	expected := []ConfigError{
+		{Field: "Domains[0].MaxAgentLevel", Constraint: "must be between 0 and 3", Value: 7},
+		{Field: "Domains[1].Name", Constraint: "must be unique", Value: "Support"},
+		{Field: "Domains", Constraint: "shares must sum to 100", Value: 80.0},
+	}
+	diagnostics := config.Diagnose()
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, got %v", len(expected), diagnostics)
+	}
+	for i := range expected {
+		if diagnostics[i] != expected[i] {
+			t.Errorf("Diagnostic %d: expected %+v, got %+v", i, expected[i], diagnostics[i])
+		}
+	}
+}
+
+func TestEquilibriumSettledWithinTolerance(t *testing.T) {
+	states := make([]SimulationState, 3)
+	for i, humans := range []int{100, 102, 99} {
+		states[i].Workforce.Humans.Total = humans
+		states[i].Workforce.AIAgents.Total = 20
+	}
+
+	if (EquilibriumConfig{}).Settled(states) {
+		t.Error("Expected any headcount change to be unstable by default")
+	}
+	if !(EquilibriumConfig{MaxCompositionChange: 2}).Settled(states) {
+		t.Error("Expected a 2% tolerance to accept headcounts within 2 of 100")
+	}
+	if (EquilibriumConfig{MaxCompositionChange: 1}).Settled(states) {
+		t.Error("Expected a 1% tolerance to reject a change of 2 humans")
+	}
+	if window := (EquilibriumConfig{}).StabilityWindow(); window != DefaultStabilityWindow {
+		t.Errorf("Expected the default window of %d steps, got %d", DefaultStabilityWindow, window)
+	}
+	if !(EquilibriumConfig{BudgetSlack: 1000}).BudgetExhausted(999) || (EquilibriumConfig{}).BudgetExhausted(1) {
+		t.Error("Expected the budget to be exhausted at or below the slack only")
+	}
+}
+
+func TestDiagnoseAlerts(t *testing.T) {
+	config := validConfig()
+	config.Alerts = []AlertRule{
+		{Metric: "human_count", Comparator: Below, Threshold: 30, RelativeToInitial: true},
+		{Metric: "headcount", Comparator: AlertComparator(7), Threshold: math.Inf(1)},
+	}
+
+	diagnostics := config.Diagnose()
+	if len(diagnostics) != 3 || diagnostics[0].Field != "Alerts[1].Metric" ||
+		diagnostics[1].Field != "Alerts[1].Comparator" || diagnostics[2].Field != "Alerts[1].Threshold" {
+		t.Errorf("Expected the second rule's metric, comparator and threshold to be reported, got %v", diagnostics)
+	}
+}
+
+func TestAlertComparatorText(t *testing.T) {
+	var rule AlertRule
+	if err := json.Unmarshal([]byte(`{"Metric": "ai_ratio", "Comparator": ">=", "Threshold": 70}`), &rule); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	var state SimulationState
+	state.Workforce.Humans.Total = 3
+	state.Workforce.AIAgents.Total = 7
+	if rule.Comparator != AtOrAbove || !rule.Holds(state, rule.AbsoluteThreshold(SimulationState{})) {
+		t.Errorf("Expected the rule to hold at a 70%% AI ratio, got %+v", rule)
+	}
+	if text, _ := Below.MarshalText(); string(text) != "<" {
+		t.Errorf("Expected Below to marshal as <, got %s", text)
+	}
+}
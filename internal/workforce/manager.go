@@ -1,7 +1,6 @@
 package workforce
 
 import (
-	"errors"
 	"fmt"
 	"workforce-ai-transition-simulator/internal/types"
 )
@@ -10,9 +9,21 @@ import (
 type WorkforceManager struct {
 	humans         map[string]*types.HumanWorker
 	aiAgents       map[string]*types.AIAgent
+	
+	// IDs in creation order so iteration (and therefore every random draw made
+	// while iterating) is deterministic for a given seed
+	humanOrder []string
+	agentOrder []string
+	
 	businessOwnerID string
 	nextHumanID    int
 	nextAgentID    int
+	
+	// Agent productivity precomputed for the orchestration effectiveness it was built with;
+	// rebuilt whenever CalculateTotalProductivity is called with different settings
+	productivityTable      types.AgentProductivityTable
+	productivityTableFor   types.OrchestrationEffectiveness
+	productivityTableBuilt bool
 }
 
 // NewWorkforceManager creates a new WorkforceManager instance
@@ -20,6 +31,8 @@ func NewWorkforceManager() *WorkforceManager {
 	return &WorkforceManager{
 		humans:      make(map[string]*types.HumanWorker),
 		aiAgents:    make(map[string]*types.AIAgent),
+		humanOrder:  make([]string, 0),
+		agentOrder:  make([]string, 0),
 		nextHumanID: 1,
 		nextAgentID: 1,
 	}
@@ -37,20 +50,20 @@ func (wm *WorkforceManager) GetAIAgent(id string) (*types.AIAgent, bool) {
 	return agent, exists
 }
 
-// GetAllHumans returns all human workers
+// GetAllHumans returns all human workers in creation order
 func (wm *WorkforceManager) GetAllHumans() []*types.HumanWorker {
-	humans := make([]*types.HumanWorker, 0, len(wm.humans))
-	for _, human := range wm.humans {
-		humans = append(humans, human)
+	humans := make([]*types.HumanWorker, 0, len(wm.humanOrder))
+	for _, id := range wm.humanOrder {
+		humans = append(humans, wm.humans[id])
 	}
 	return humans
 }
 
-// GetAllAIAgents returns all AI agents
+// GetAllAIAgents returns all AI agents in creation order
 func (wm *WorkforceManager) GetAllAIAgents() []*types.AIAgent {
-	agents := make([]*types.AIAgent, 0, len(wm.aiAgents))
-	for _, agent := range wm.aiAgents {
-		agents = append(agents, agent)
+	agents := make([]*types.AIAgent, 0, len(wm.agentOrder))
+	for _, id := range wm.agentOrder {
+		agents = append(agents, wm.aiAgents[id])
 	}
 	return agents
 }
@@ -58,11 +71,11 @@ func (wm *WorkforceManager) GetAllAIAgents() []*types.AIAgent {
 // GetBusinessOwner returns the business owner human worker
 func (wm *WorkforceManager) GetBusinessOwner() (*types.HumanWorker, error) {
 	if wm.businessOwnerID == "" {
-		return nil, errors.New("no business owner exists")
+		return nil, fmt.Errorf("business owner %w", types.ErrNotFound)
 	}
 	human, exists := wm.humans[wm.businessOwnerID]
 	if !exists {
-		return nil, errors.New("business owner not found")
+		return nil, fmt.Errorf("business owner %s %w", wm.businessOwnerID, types.ErrNotFound)
 	}
 	return human, nil
 }
@@ -77,7 +90,7 @@ func (wm *WorkforceManager) AddHuman(experienceLevel types.ExperienceLevel, cost
 	
 	// If this is marked as business owner, ensure we don't already have one
 	if isBusinessOwner && wm.businessOwnerID != "" {
-		return nil, errors.New("business owner already exists")
+		return nil, types.ErrBusinessOwnerExists
 	}
 	
 	// If no business owner exists yet, make this the business owner
@@ -90,6 +103,7 @@ func (wm *WorkforceManager) AddHuman(experienceLevel types.ExperienceLevel, cost
 	
 	// Add to collection
 	wm.humans[id] = human
+	wm.humanOrder = append(wm.humanOrder, id)
 	
 	// Track business owner
 	if isBusinessOwner {
@@ -106,12 +120,12 @@ func (wm *WorkforceManager) RemoveHuman(workerID string) error {
 	// Check if worker exists
 	human, exists := wm.humans[workerID]
 	if !exists {
-		return fmt.Errorf("human worker %s not found", workerID)
+		return fmt.Errorf("human worker %s %w", workerID, types.ErrNotFound)
 	}
 	
 	// Prevent removal of business owner
 	if human.IsBusinessOwner {
-		return errors.New("cannot remove business owner")
+		return types.ErrBusinessOwnerRemoval
 	}
 	
 	// Release all assigned AI agents
@@ -119,25 +133,107 @@ func (wm *WorkforceManager) RemoveHuman(workerID string) error {
 		// Remove the agent from the collection
 		delete(wm.aiAgents, agentID)
 	}
+	if len(human.AssignedAgents) > 0 {
+		wm.agentOrder = retainExisting(wm.agentOrder, func(id string) bool {
+			_, exists := wm.aiAgents[id]
+			return exists
+		})
+	}
 	
 	// Remove the human worker
 	delete(wm.humans, workerID)
+	wm.humanOrder = removeID(wm.humanOrder, workerID)
 	
 	return nil
 }
 
+// Succession describes a change of business owner
+type Succession struct {
+	PreviousOwner    *types.HumanWorker
+	Successor        *types.HumanWorker
+	ReassignedAgents int // agents of the previous owner now orchestrated by another human
+	ReleasedAgents   int // agents of the previous owner no one had capacity to orchestrate
+}
+
+// RemoveBusinessOwner removes the business owner and promotes the most senior remaining human
+// to owner, preferring the longest-serving human among equals
+// The departing owner's AI agents are reassigned to the successor first and then to other
+// humans with spare capacity in creation order; agents no one can orchestrate are released
+// Returns an error wrapping ErrBusinessOwnerRemoval if the owner is the only human
+func (wm *WorkforceManager) RemoveBusinessOwner() (Succession, error) {
+	owner, err := wm.GetBusinessOwner()
+	if err != nil {
+		return Succession{}, err
+	}
+	
+	// Choose the successor: the highest experience level, earliest hired on ties
+	var successor *types.HumanWorker
+	for _, id := range wm.humanOrder {
+		human := wm.humans[id]
+		if human == owner {
+			continue
+		}
+		if successor == nil || human.ExperienceLevel > successor.ExperienceLevel {
+			successor = human
+		}
+	}
+	if successor == nil {
+		return Succession{}, fmt.Errorf("%w: no other human to succeed %s", types.ErrBusinessOwnerRemoval, owner.ID)
+	}
+	
+	owner.IsBusinessOwner = false
+	successor.IsBusinessOwner = true
+	wm.businessOwnerID = successor.ID
+	
+	succession := Succession{PreviousOwner: owner, Successor: successor}
+	
+	// Hand the owner's agents to the successor, then to anyone else with capacity
+	candidates := make([]*types.HumanWorker, 0, len(wm.humanOrder))
+	candidates = append(candidates, successor)
+	for _, id := range wm.humanOrder {
+		if human := wm.humans[id]; human != owner && human != successor {
+			candidates = append(candidates, human)
+		}
+	}
+	next := 0
+	for _, agentID := range owner.AssignedAgents {
+		for next < len(candidates) && !candidates[next].CanOrchestrateMoreAgents() {
+			next++
+		}
+		if next == len(candidates) {
+			delete(wm.aiAgents, agentID)
+			succession.ReleasedAgents++
+			continue
+		}
+		wm.aiAgents[agentID].OrchestratorID = candidates[next].ID
+		candidates[next].AssignedAgents = append(candidates[next].AssignedAgents, agentID)
+		succession.ReassignedAgents++
+	}
+	if succession.ReleasedAgents > 0 {
+		wm.agentOrder = retainExisting(wm.agentOrder, func(id string) bool {
+			_, exists := wm.aiAgents[id]
+			return exists
+		})
+	}
+	
+	delete(wm.humans, owner.ID)
+	wm.humanOrder = removeID(wm.humanOrder, owner.ID)
+	
+	return succession, nil
+}
+
 // AddAIAgent creates and assigns an AI agent to a human with available capacity
 // Returns the created AI agent or an error if no capacity is available
 func (wm *WorkforceManager) AddAIAgent(orchestratorID string, creationTime int) (*types.AIAgent, error) {
 	// Check if orchestrator exists
 	human, exists := wm.humans[orchestratorID]
 	if !exists {
-		return nil, fmt.Errorf("orchestrator %s not found", orchestratorID)
+		return nil, fmt.Errorf("orchestrator %s %w", orchestratorID, types.ErrNotFound)
 	}
 	
 	// Check if orchestrator has capacity
 	if !human.CanOrchestrateMoreAgents() {
-		return nil, fmt.Errorf("orchestrator %s has reached orchestration limit", orchestratorID)
+		return nil, fmt.Errorf("orchestrator %s: %w", orchestratorID, types.ErrNoOrchestrationCapacity)
 	}
 	
 	// Generate unique ID
@@ -149,6 +245,7 @@ func (wm *WorkforceManager) AddAIAgent(orchestratorID string, creationTime int)
 	
 	// Add to collection
 	wm.aiAgents[id] = agent
+	wm.agentOrder = append(wm.agentOrder, id)
 	
 	// Assign to orchestrator
 	human.AssignedAgents = append(human.AssignedAgents, id)
@@ -162,7 +259,7 @@ func (wm *WorkforceManager) ReleaseAIAgent(agentID string) error {
 	// Check if agent exists
 	agent, exists := wm.aiAgents[agentID]
 	if !exists {
-		return fmt.Errorf("AI agent %s not found", agentID)
+		return fmt.Errorf("AI agent %s %w", agentID, types.ErrNotFound)
 	}
 	
 	// Remove agent from orchestrator's assigned list
@@ -181,10 +278,62 @@ func (wm *WorkforceManager) ReleaseAIAgent(agentID string) error {
 	
 	// Remove the agent from the collection
 	delete(wm.aiAgents, agentID)
+	wm.agentOrder = removeID(wm.agentOrder, agentID)
 	
 	return nil
 }
 
+// RebalanceAgents evens out orchestration load by moving AI agents from the human orchestrating
+// the most agents to the one orchestrating the fewest, until no two humans differ by more than
+// one agent. Humans are considered in hiring order, and each move takes the most recently
+// assigned agent. Returns the number of agents moved
+func (wm *WorkforceManager) RebalanceAgents() int {
+	moved := 0
+	for {
+		var most, least *types.HumanWorker
+		for _, id := range wm.humanOrder {
+			human := wm.humans[id]
+			if most == nil || len(human.AssignedAgents) > len(most.AssignedAgents) {
+				most = human
+			}
+			if least == nil || len(human.AssignedAgents) < len(least.AssignedAgents) {
+				least = human
+			}
+		}
+		if most == nil || len(most.AssignedAgents)-len(least.AssignedAgents) <= 1 {
+			return moved
+		}
+		
+		last := len(most.AssignedAgents) - 1
+		agentID := most.AssignedAgents[last]
+		most.AssignedAgents = most.AssignedAgents[:last]
+		least.AssignedAgents = append(least.AssignedAgents, agentID)
+		wm.aiAgents[agentID].OrchestratorID = least.ID
+		moved++
+	}
+}
+
+// removeID removes a single ID from an ordered ID list, preserving the order of the rest
+func removeID(ids []string, id string) []string {
+	for i, existing := range ids {
+		if existing == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// retainExisting filters an ordered ID list in place, keeping IDs for which keep returns true
+func retainExisting(ids []string, keep func(id string) bool) []string {
+	kept := ids[:0]
+	for _, id := range ids {
+		if keep(id) {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
 // GetAvailableOrchestrationCapacity calculates the total available capacity across all humans
 // Returns the sum of available capacity from all human workers
 func (wm *WorkforceManager) GetAvailableOrchestrationCapacity() int {
@@ -195,24 +344,72 @@ func (wm *WorkforceManager) GetAvailableOrchestrationCapacity() int {
 	return totalCapacity
 }
 
-// CalculateTotalProductivity sums productivity from all humans and AI agents
-// timeZoneInefficiency is the productivity penalty for Low_Cost_Non_US workers (0-1)
-func (wm *WorkforceManager) CalculateTotalProductivity(timeZoneInefficiency float64) float64 {
-	totalProductivity := 0.0
+// CalculateHumanProductivity sums the effective productivity of all humans
+// regions supplies the time zone inefficiency penalty of each human's region
+func (wm *WorkforceManager) CalculateHumanProductivity(regions types.RegionTable) float64 {
+	productivity := 0.0
 	
-	// Sum human productivity
-	for _, human := range wm.humans {
-		totalProductivity += human.GetEffectiveProductivity(timeZoneInefficiency)
+	// Sum in creation order so the floating point sum is reproducible
+	for _, id := range wm.humanOrder {
+		productivity += wm.humans[id].GetEffectiveProductivity(regions)
 	}
 	
-	// Sum AI agent productivity
-	for _, agent := range wm.aiAgents {
-		totalProductivity += agent.GetProductivity()
+	return productivity
+}
+
+// CalculateTotalProductivity sums productivity from all humans and AI agents
+// regions supplies the time zone inefficiency penalty of each human's region
+// effectiveness scales each agent's productivity by the experience level of its orchestrator
+func (wm *WorkforceManager) CalculateTotalProductivity(regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) float64 {
+	totalProductivity := wm.CalculateHumanProductivity(regions)
+	
+	// Sum AI agent productivity, scaled by each agent's vendor tier; agents whose orchestrator no
+	// longer exists are left unscaled by orchestration effectiveness
+	table := wm.agentProductivityTable(effectiveness)
+	for _, id := range wm.agentOrder {
+		agent := wm.aiAgents[id]
+		if orchestrator, exists := wm.humans[agent.OrchestratorID]; exists {
+			totalProductivity += table[orchestrator.ExperienceLevel][agent.ExperienceLevel] * agent.ProductivityFactor()
+		} else {
+			totalProductivity += agent.GetProductivity()
+		}
 	}
 	
 	return totalProductivity
 }
 
+// CalculateProductivityByExperience splits the productivity of CalculateTotalProductivity by
+// experience level, separately for humans and AI agents
+func (wm *WorkforceManager) CalculateProductivityByExperience(regions types.RegionTable, effectiveness types.OrchestrationEffectiveness) (human, ai [types.NumExperienceLevels]float64) {
+	for _, id := range wm.humanOrder {
+		h := wm.humans[id]
+		human[h.ExperienceLevel] += h.GetEffectiveProductivity(regions)
+	}
+	
+	table := wm.agentProductivityTable(effectiveness)
+	for _, id := range wm.agentOrder {
+		agent := wm.aiAgents[id]
+		if orchestrator, exists := wm.humans[agent.OrchestratorID]; exists {
+			ai[agent.ExperienceLevel] += table[orchestrator.ExperienceLevel][agent.ExperienceLevel] * agent.ProductivityFactor()
+		} else {
+			ai[agent.ExperienceLevel] += agent.GetProductivity()
+		}
+	}
+	
+	return human, ai
+}
+
+// agentProductivityTable returns the agent productivity table for effectiveness, reusing the
+// cached table while the settings are unchanged
+func (wm *WorkforceManager) agentProductivityTable(effectiveness types.OrchestrationEffectiveness) *types.AgentProductivityTable {
+	if !wm.productivityTableBuilt || wm.productivityTableFor != effectiveness {
+		wm.productivityTable = effectiveness.ProductivityTable()
+		wm.productivityTableFor = effectiveness
+		wm.productivityTableBuilt = true
+	}
+	return &wm.productivityTable
+}
+
 // GetWorkforceComposition returns detailed workforce statistics
 func (wm *WorkforceManager) GetWorkforceComposition() types.WorkforceComposition {
 	composition := types.WorkforceComposition{}
@@ -220,6 +417,7 @@ func (wm *WorkforceManager) GetWorkforceComposition() types.WorkforceComposition
 	// Initialize maps
 	composition.Humans.ByExperience = make(map[types.ExperienceLevel]int)
 	composition.Humans.ByCostCategory = make(map[types.CostCategory]int)
+	composition.Humans.ByContractType = make(map[types.ContractType]int)
 	composition.AIAgents.ByExperience = make(map[types.ExperienceLevel]int)
 	
 	// Count humans
@@ -227,6 +425,7 @@ func (wm *WorkforceManager) GetWorkforceComposition() types.WorkforceComposition
 	for _, human := range wm.humans {
 		composition.Humans.ByExperience[human.ExperienceLevel]++
 		composition.Humans.ByCostCategory[human.CostCategory]++
+		composition.Humans.ByContractType[human.ContractType]++
 	}
 	
 	// Count AI agents
@@ -1,6 +1,7 @@
 package workforce
 
 import (
+	"errors"
 	"testing"
 	"workforce-ai-transition-simulator/internal/types"
 )
@@ -50,8 +51,8 @@ func TestAddHuman(t *testing.T) {
 	
 	// Try to add another business owner (should fail)
 	_, err = wm.AddHuman(types.Executive, types.HighCostUS, true)
-	if err == nil {
-		t.Error("Expected error when adding second business owner")
+	if !errors.Is(err, types.ErrBusinessOwnerExists) {
+		t.Errorf("Expected ErrBusinessOwnerExists when adding second business owner, got %v", err)
 	}
 }
 
@@ -68,8 +69,8 @@ func TestRemoveHuman(t *testing.T) {
 	
 	// Try to remove business owner (should fail)
 	err := wm.RemoveHuman(human1.ID)
-	if err == nil {
-		t.Error("Expected error when removing business owner")
+	if !errors.Is(err, types.ErrBusinessOwnerRemoval) {
+		t.Errorf("Expected ErrBusinessOwnerRemoval when removing business owner, got %v", err)
 	}
 	
 	// Remove human2 (should also remove their agents)
@@ -96,6 +97,87 @@ func TestRemoveHuman(t *testing.T) {
 	}
 }
 
+func TestRemoveBusinessOwnerPromotesMostSeniorHuman(t *testing.T) {
+	wm := NewWorkforceManager()
+	
+	owner, _ := wm.AddHuman(types.MidLevel, types.HighCostUS, true)
+	wm.AddHuman(types.UniversityHire, types.HighCostUS, false)
+	senior, _ := wm.AddHuman(types.Senior, types.HighCostUS, false)
+	wm.AddHuman(types.Senior, types.LowCostNonUS, false) // hired later, so not preferred
+	
+	// The successor is already near capacity, so the remaining agents go to the next human
+	for i := 0; i < types.OrchestrationLimit-1; i++ {
+		wm.AddAIAgent(senior.ID, 0)
+	}
+	ownerAgent1, _ := wm.AddAIAgent(owner.ID, 0)
+	ownerAgent2, _ := wm.AddAIAgent(owner.ID, 0)
+	
+	succession, err := wm.RemoveBusinessOwner()
+	if err != nil {
+		t.Fatalf("RemoveBusinessOwner() error = %v", err)
+	}
+	if succession.PreviousOwner.ID != owner.ID || succession.Successor.ID != senior.ID {
+		t.Errorf("Expected %s to succeed %s, got %s succeeding %s",
+			senior.ID, owner.ID, succession.Successor.ID, succession.PreviousOwner.ID)
+	}
+	
+	newOwner, err := wm.GetBusinessOwner()
+	if err != nil || newOwner.ID != senior.ID || !newOwner.IsBusinessOwner {
+		t.Errorf("Expected %s to be the business owner, got %v (%v)", senior.ID, newOwner, err)
+	}
+	if _, exists := wm.GetHuman(owner.ID); exists {
+		t.Error("Previous owner should be removed")
+	}
+	
+	if succession.ReassignedAgents != 2 || succession.ReleasedAgents != 0 {
+		t.Errorf("Expected 2 reassigned and 0 released agents, got %d and %d",
+			succession.ReassignedAgents, succession.ReleasedAgents)
+	}
+	if ownerAgent1.OrchestratorID != senior.ID {
+		t.Errorf("Expected first agent to move to the successor, got %s", ownerAgent1.OrchestratorID)
+	}
+	if ownerAgent2.OrchestratorID == senior.ID || ownerAgent2.OrchestratorID == owner.ID {
+		t.Errorf("Expected second agent to move to another human, got %s", ownerAgent2.OrchestratorID)
+	}
+	if len(wm.GetAllAIAgents()) != types.OrchestrationLimit+1 {
+		t.Errorf("Expected no agents to be released, got %d agents", len(wm.GetAllAIAgents()))
+	}
+}
+
+func TestRemoveBusinessOwnerReleasesAgentsWithoutCapacity(t *testing.T) {
+	wm := NewWorkforceManager()
+	
+	owner, _ := wm.AddHuman(types.Executive, types.HighCostUS, true)
+	successor, _ := wm.AddHuman(types.MidLevel, types.HighCostUS, false)
+	for i := 0; i < types.OrchestrationLimit; i++ {
+		wm.AddAIAgent(owner.ID, 0)
+		wm.AddAIAgent(successor.ID, 0)
+	}
+	
+	succession, err := wm.RemoveBusinessOwner()
+	if err != nil {
+		t.Fatalf("RemoveBusinessOwner() error = %v", err)
+	}
+	if succession.ReleasedAgents != types.OrchestrationLimit {
+		t.Errorf("Expected %d released agents, got %d", types.OrchestrationLimit, succession.ReleasedAgents)
+	}
+	if len(wm.GetAllAIAgents()) != types.OrchestrationLimit {
+		t.Errorf("Expected only the successor's agents to remain, got %d", len(wm.GetAllAIAgents()))
+	}
+}
+
+func TestRemoveBusinessOwnerRequiresSuccessor(t *testing.T) {
+	wm := NewWorkforceManager()
+	owner, _ := wm.AddHuman(types.Executive, types.HighCostUS, true)
+	
+	if _, err := wm.RemoveBusinessOwner(); !errors.Is(err, types.ErrBusinessOwnerRemoval) {
+		t.Errorf("Expected ErrBusinessOwnerRemoval without a successor, got %v", err)
+	}
+	if current, err := wm.GetBusinessOwner(); err != nil || current.ID != owner.ID {
+		t.Errorf("Expected %s to remain the business owner", owner.ID)
+	}
+}
+
 func TestAddAIAgent(t *testing.T) {
 	wm := NewWorkforceManager()
 	
@@ -129,8 +211,8 @@ func TestAddAIAgent(t *testing.T) {
 	
 	// Try to add agent to non-existent orchestrator
 	_, err = wm.AddAIAgent("non-existent", 0)
-	if err == nil {
-		t.Error("Expected error when adding agent to non-existent orchestrator")
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when adding agent to non-existent orchestrator, got %v", err)
 	}
 }
 
@@ -150,8 +232,8 @@ func TestAddAIAgentCapacityLimit(t *testing.T) {
 	
 	// Try to add 7th agent (should fail)
 	_, err := wm.AddAIAgent(human.ID, 6)
-	if err == nil {
-		t.Error("Expected error when exceeding orchestration limit")
+	if !errors.Is(err, types.ErrNoOrchestrationCapacity) {
+		t.Errorf("Expected ErrNoOrchestrationCapacity when exceeding orchestration limit, got %v", err)
 	}
 }
 
@@ -233,7 +315,7 @@ func TestCalculateTotalProductivity(t *testing.T) {
 	wm.AddAIAgent(human1.ID, 0) // University hire: 0.8
 	
 	// Calculate with 20% time zone inefficiency
-	productivity := wm.CalculateTotalProductivity(0.2)
+	productivity := wm.CalculateTotalProductivity(types.DefaultRegions(types.CostCategoryDistribution{}, 0.2), types.OrchestrationEffectiveness{})
 	expected := 2.0 + 2.8 + 0.8 + 0.8 // 6.4
 	
 	const tolerance = 1e-9
@@ -242,6 +324,42 @@ func TestCalculateTotalProductivity(t *testing.T) {
 	}
 }
 
+func TestCalculateTotalProductivityOrchestrationEffectiveness(t *testing.T) {
+	wm := NewWorkforceManager()
+	
+	junior, _ := wm.AddHuman(types.UniversityHire, types.HighCostUS, false) // productivity: 1.0
+	senior, _ := wm.AddHuman(types.Senior, types.HighCostUS, false)         // productivity: 3.5
+	wm.AddAIAgent(junior.ID, 0)                                           // University hire: 0.8
+	wm.AddAIAgent(senior.ID, 0)                                           // University hire: 0.8
+	
+	effectiveness := types.OrchestrationEffectiveness{UniversityHire: 0.5, Senior: 1.5}
+	productivity := wm.CalculateTotalProductivity(nil, effectiveness)
+	expected := 1.0 + 3.5 + 0.8*0.5 + 0.8*1.5 // 6.1
+	
+	const tolerance = 1e-9
+	if diff := productivity - expected; diff < -tolerance || diff > tolerance {
+		t.Errorf("Expected productivity %v, got %v", expected, productivity)
+	}
+}
+
+func TestCalculateTotalProductivityRebuildsForNewEffectiveness(t *testing.T) {
+	wm := NewWorkforceManager()
+	
+	senior, _ := wm.AddHuman(types.Senior, types.HighCostUS, false) // productivity: 3.5
+	wm.AddAIAgent(senior.ID, 0)                                     // University hire: 0.8
+	
+	first := wm.CalculateTotalProductivity(nil, types.OrchestrationEffectiveness{Senior: 2.0})
+	second := wm.CalculateTotalProductivity(nil, types.OrchestrationEffectiveness{Senior: 0.5})
+	
+	const tolerance = 1e-9
+	if diff := first - (3.5 + 0.8*2.0); diff < -tolerance || diff > tolerance {
+		t.Errorf("Expected productivity %v, got %v", 3.5+0.8*2.0, first)
+	}
+	if diff := second - (3.5 + 0.8*0.5); diff < -tolerance || diff > tolerance {
+		t.Errorf("Expected productivity %v after changing effectiveness, got %v", 3.5+0.8*0.5, second)
+	}
+}
+
 func TestGetWorkforceComposition(t *testing.T) {
 	wm := NewWorkforceManager()
 	
@@ -300,3 +418,89 @@ func TestGetWorkforceComposition(t *testing.T) {
 		t.Errorf("Expected utilization %v%%, got %v%%", expectedUtilization, composition.OrchestrationUtilization)
 	}
 }
+
+func BenchmarkCalculateTotalProductivity(b *testing.B) {
+	wm := NewWorkforceManager()
+	levels := []types.ExperienceLevel{types.UniversityHire, types.MidLevel, types.Senior, types.Executive}
+	for i := 0; i < 200; i++ {
+		human, err := wm.AddHuman(levels[i%len(levels)], types.CostCategory(i%2), false)
+		if err != nil {
+			b.Fatalf("AddHuman() error = %v", err)
+		}
+		for j := 0; j < types.OrchestrationLimit; j++ {
+			if _, err := wm.AddAIAgent(human.ID, 0); err != nil {
+				b.Fatalf("AddAIAgent() error = %v", err)
+			}
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wm.CalculateTotalProductivity(types.DefaultRegions(types.CostCategoryDistribution{}, 0.1), types.OrchestrationEffectiveness{})
+	}
+}
+
+func TestGetAllReturnsCreationOrder(t *testing.T) {
+	wm := NewWorkforceManager()
+
+	ids := make([]string, 0)
+	for i := 0; i < 12; i++ {
+		human, _ := wm.AddHuman(types.Senior, types.HighCostUS, false)
+		ids = append(ids, human.ID)
+	}
+	wm.AddAIAgent(ids[3], 0)
+	wm.AddAIAgent(ids[3], 0)
+	agent, _ := wm.AddAIAgent(ids[5], 0)
+
+	// Remove one human (and with it two agents) from the middle
+	if err := wm.RemoveHuman(ids[3]); err != nil {
+		t.Fatalf("RemoveHuman() error = %v", err)
+	}
+	ids = append(ids[:3], ids[4:]...)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		humans := wm.GetAllHumans()
+		if len(humans) != len(ids) {
+			t.Fatalf("Expected %d humans, got %d", len(ids), len(humans))
+		}
+		for i, human := range humans {
+			if human.ID != ids[i] {
+				t.Fatalf("Expected human %d to be %s, got %s", i, ids[i], human.ID)
+			}
+		}
+	}
+
+	agents := wm.GetAllAIAgents()
+	if len(agents) != 1 || agents[0].ID != agent.ID {
+		t.Errorf("Expected only %s to remain, got %d agents", agent.ID, len(agents))
+	}
+}
+
+func TestRebalanceAgentsEvensOutLoads(t *testing.T) {
+	wm := NewWorkforceManager()
+	owner, _ := wm.AddHuman(types.Senior, types.HighCostUS, true)
+	second, _ := wm.AddHuman(types.MidLevel, types.HighCostUS, false)
+	third, _ := wm.AddHuman(types.MidLevel, types.LowCostNonUS, false)
+	for i := 0; i < 6; i++ {
+		wm.AddAIAgent(owner.ID, i)
+	}
+	wm.AddAIAgent(second.ID, 6)
+
+	if moved := wm.RebalanceAgents(); moved != 3 {
+		t.Errorf("Expected 3 agents to move, got %d", moved)
+	}
+	for _, human := range []*types.HumanWorker{owner, second, third} {
+		if len(human.AssignedAgents) < 2 || len(human.AssignedAgents) > 3 {
+			t.Errorf("Expected %s to orchestrate 2 or 3 agents, got %d", human.ID, len(human.AssignedAgents))
+		}
+		for _, agentID := range human.AssignedAgents {
+			if agent, _ := wm.GetAIAgent(agentID); agent.OrchestratorID != human.ID {
+				t.Errorf("Expected %s to be orchestrated by %s, got %s", agentID, human.ID, agent.OrchestratorID)
+			}
+		}
+	}
+	if moved := wm.RebalanceAgents(); moved != 0 {
+		t.Errorf("Expected a balanced workforce to stay as it is, got %d moves", moved)
+	}
+}
@@ -0,0 +1,80 @@
+package workforce
+
+import (
+	"errors"
+	"fmt"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Snapshot is a serializable copy of a WorkforceManager's complete state
+// Humans and agents are listed in creation order so a restored manager iterates
+// (and draws random numbers) in exactly the same order as the original
+type Snapshot struct {
+	Humans          []types.HumanWorker
+	AIAgents        []types.AIAgent
+	BusinessOwnerID string
+	NextHumanID     int
+	NextAgentID     int
+}
+
+// Snapshot returns a deep copy of the manager's state
+func (wm *WorkforceManager) Snapshot() Snapshot {
+	snapshot := Snapshot{
+		Humans:          make([]types.HumanWorker, 0, len(wm.humanOrder)),
+		AIAgents:        make([]types.AIAgent, 0, len(wm.agentOrder)),
+		BusinessOwnerID: wm.businessOwnerID,
+		NextHumanID:     wm.nextHumanID,
+		NextAgentID:     wm.nextAgentID,
+	}
+
+	for _, human := range wm.GetAllHumans() {
+		copied := *human
+		copied.AssignedAgents = append([]string(nil), human.AssignedAgents...)
+		snapshot.Humans = append(snapshot.Humans, copied)
+	}
+	for _, agent := range wm.GetAllAIAgents() {
+		snapshot.AIAgents = append(snapshot.AIAgents, *agent)
+	}
+
+	return snapshot
+}
+
+// RestoreWorkforceManager creates a WorkforceManager from a snapshot
+// The snapshot is copied, so it can be restored any number of times
+// Returns an error if the snapshot is internally inconsistent
+func RestoreWorkforceManager(snapshot Snapshot) (*WorkforceManager, error) {
+	wm := NewWorkforceManager()
+	wm.nextHumanID = snapshot.NextHumanID
+	wm.nextAgentID = snapshot.NextAgentID
+
+	for _, human := range snapshot.Humans {
+		if _, exists := wm.humans[human.ID]; exists {
+			return nil, fmt.Errorf("duplicate human worker %s in snapshot", human.ID)
+		}
+		copied := human
+		copied.AssignedAgents = append([]string(nil), human.AssignedAgents...)
+		wm.humans[copied.ID] = &copied
+		wm.humanOrder = append(wm.humanOrder, copied.ID)
+		if copied.IsBusinessOwner {
+			wm.businessOwnerID = copied.ID
+		}
+	}
+
+	for _, agent := range snapshot.AIAgents {
+		if _, exists := wm.aiAgents[agent.ID]; exists {
+			return nil, fmt.Errorf("duplicate AI agent %s in snapshot", agent.ID)
+		}
+		if _, exists := wm.humans[agent.OrchestratorID]; !exists {
+			return nil, fmt.Errorf("AI agent %s references unknown orchestrator %s", agent.ID, agent.OrchestratorID)
+		}
+		copied := agent
+		wm.aiAgents[copied.ID] = &copied
+		wm.agentOrder = append(wm.agentOrder, copied.ID)
+	}
+
+	if wm.businessOwnerID != snapshot.BusinessOwnerID {
+		return nil, errors.New("snapshot business owner does not match its workers")
+	}
+
+	return wm, nil
+}
@@ -0,0 +1,300 @@
+package simulator
+
+import (
+	"io"
+
+	"workforce-ai-transition-simulator/internal/analytics"
+	"workforce-ai-transition-simulator/internal/compress"
+	"workforce-ai-transition-simulator/internal/distributed"
+	"workforce-ai-transition-simulator/internal/sink"
+	"workforce-ai-transition-simulator/internal/store"
+)
+
+// Analytics records simulation results and produces reports, sensitivity analyses,
+// counterfactual comparisons and Monte Carlo summaries
+type Analytics = analytics.AnalyticsEngine
+
+// Report and analysis types produced by Analytics
+type (
+	Report                   = analytics.Report
+	ReportSummary            = analytics.ReportSummary
+	MetricSummary            = analytics.MetricSummary
+	MetricInfo               = analytics.MetricInfo
+	RollingKPI               = analytics.RollingKPI
+	Anomaly                  = analytics.Anomaly
+	Phase                    = analytics.Phase
+	Forecast                 = analytics.Forecast
+	SeriesForecast           = analytics.SeriesForecast
+	ForecastPoint            = analytics.ForecastPoint
+	Incident                 = analytics.Incident
+	CorrelationMatrix        = analytics.CorrelationMatrix
+	ObservedSeries           = analytics.ObservedSeries
+	ObservedPoint            = analytics.ObservedPoint
+	GoodnessOfFit            = analytics.GoodnessOfFit
+	CalibrationParameter     = analytics.CalibrationParameter
+	CalibrationResult        = analytics.CalibrationResult
+	ParameterRanges          = analytics.ParameterRanges
+	SensitivityOptions       = analytics.SensitivityOptions
+	SensitivityResults       = analytics.SensitivityResults
+	SensitivityReport        = analytics.SensitivityReport
+	SensitivitySummary       = analytics.SensitivitySummary
+	ParameterImpact          = analytics.ParameterImpact
+	PairwiseResults          = analytics.PairwiseResults
+	Heatmap                  = analytics.Heatmap
+	CounterfactualComparison = analytics.CounterfactualComparison
+	CounterfactualStep       = analytics.CounterfactualStep
+	MonteCarloResult         = analytics.MonteCarloResult
+	MonteCarloStep           = analytics.MonteCarloStep
+	Percentiles              = analytics.Percentiles
+	MonteCarloConfidence     = analytics.MonteCarloConfidence
+	ConfidenceInterval       = analytics.ConfidenceInterval
+	Attractor                = analytics.Attractor
+	PerturbationResilience   = analytics.PerturbationResilience
+	PerturbationResult       = analytics.PerturbationResult
+	PhaseSpacePoint          = analytics.PhaseSpacePoint
+	ScenarioScore            = analytics.ScenarioScore
+	ScenarioRanking          = analytics.ScenarioRanking
+	RunSummary               = analytics.RunSummary
+	ReportCSVStream          = analytics.ReportCSVStream
+)
+
+// CensoredRunPolicy controls how analyses treat runs that stop without reaching equilibrium
+type CensoredRunPolicy = analytics.CensoredRunPolicy
+
+const (
+	IncludeCensored  = analytics.IncludeCensored
+	ExcludeCensored  = analytics.ExcludeCensored
+	PenalizeCensored = analytics.PenalizeCensored
+)
+
+// DefaultCensoredPenaltyFactor is the multiplier applied to censored run times under PenalizeCensored
+const DefaultCensoredPenaltyFactor = analytics.DefaultCensoredPenaltyFactor
+
+// DefaultRollingWindow is the number of trailing time steps aggregated by rolling KPIs
+const DefaultRollingWindow = analytics.DefaultRollingWindow
+
+// DefaultAnomalyThreshold is the number of standard deviations from the rolling mean beyond which
+// a value is flagged as an anomaly
+const DefaultAnomalyThreshold = analytics.DefaultAnomalyThreshold
+
+// Aggregation is the rule for combining a metric's per-step values into one value for a run
+type Aggregation = analytics.Aggregation
+
+const (
+	AggregateSum  = analytics.AggregateSum
+	AggregateMean = analytics.AggregateMean
+	AggregateLast = analytics.AggregateLast
+)
+
+// TransitionPhase classifies a stretch of a run by how much of the workforce is AI
+type TransitionPhase = analytics.TransitionPhase
+
+const (
+	HumanDominant  = analytics.HumanDominant
+	MixedWorkforce = analytics.MixedWorkforce
+	AIDominant     = analytics.AIDominant
+)
+
+// ForecastMethod selects the trend fitted to the end of a run when forecasting
+type ForecastMethod = analytics.ForecastMethod
+
+const (
+	LinearForecast      = analytics.LinearForecast
+	ExponentialForecast = analytics.ExponentialForecast
+)
+
+// DefaultForecastHorizon is the number of steps forecast beyond the end of a run
+const DefaultForecastHorizon = analytics.DefaultForecastHorizon
+
+// ResultStore records completed sensitivity runs so an interrupted sweep can be resumed
+type ResultStore = store.ResultStore
+
+// RunKey identifies a single run of a sensitivity sweep in a ResultStore
+type RunKey = store.RunKey
+
+// JSONLStore is a ResultStore backed by a file with one JSON record per line
+type JSONLStore = store.JSONLStore
+
+// StoreHeader identifies the sweep a JSONLStore belongs to
+type StoreHeader = store.Header
+
+// ErrSweepMismatch is returned when a JSONLStore was written by a different sweep
+var ErrSweepMismatch = store.ErrSweepMismatch
+
+// NewAnalytics creates an empty Analytics engine
+func NewAnalytics() *Analytics {
+	return analytics.NewAnalyticsEngine()
+}
+
+// ParseCensoredRunPolicy parses a censored run policy by name (include, exclude or penalize)
+func ParseCensoredRunPolicy(name string) (CensoredRunPolicy, error) {
+	return analytics.ParseCensoredRunPolicy(name)
+}
+
+// ParseForecastMethod parses a forecast method by name (linear or exponential)
+func ParseForecastMethod(name string) (ForecastMethod, error) {
+	return analytics.ParseForecastMethod(name)
+}
+
+// SensitivityParameterNames returns the parameters a sensitivity analysis can vary
+func SensitivityParameterNames() []string {
+	return analytics.SensitivityParameterNames()
+}
+
+// HeatmapMetrics returns the outcome metrics a pairwise heatmap can show
+func HeatmapMetrics() []string {
+	return analytics.HeatmapMetrics()
+}
+
+// BaselineCheckpoint runs config to equilibrium and checkpoints the result, for use as the
+// warm start of a sensitivity analysis
+func BaselineCheckpoint(config Config, maxTimeSteps int, seed int64, opts ...Option) (*Checkpoint, error) {
+	return analytics.BaselineCheckpoint(config, maxTimeSteps, seed, opts...)
+}
+
+// DetectChangepoints returns the time steps where the cost and headcount series of a run shift to a new level
+func DetectChangepoints(timeSeries []State) []int {
+	return analytics.DetectChangepoints(timeSeries)
+}
+
+// DetectPhases splits a run at its changepoints into human-dominant, mixed and AI-dominant phases
+func DetectPhases(timeSeries []State) []Phase {
+	return analytics.DetectPhases(timeSeries)
+}
+
+// WriteCorrelationCSV writes a correlation matrix as CSV, one row per pair of metrics
+func WriteCorrelationCSV(matrix CorrelationMatrix, writer io.Writer) error {
+	return analytics.WriteCorrelationCSV(matrix, writer)
+}
+
+// WriteCorrelationJSON writes a correlation matrix as JSON; undefined correlations are null
+func WriteCorrelationJSON(matrix CorrelationMatrix, writer io.Writer) error {
+	return analytics.WriteCorrelationJSON(matrix, writer)
+}
+
+// PhaseSpace returns the trajectory of a run through (human count, AI agent count) space, annotated with the events that moved it
+func PhaseSpace(timeSeries []State) []PhaseSpacePoint {
+	return analytics.PhaseSpace(timeSeries)
+}
+
+// WritePhaseSpaceCSV writes a phase space trajectory as CSV, one row per step
+func WritePhaseSpaceCSV(points []PhaseSpacePoint, writer io.Writer) error {
+	return analytics.WritePhaseSpaceCSV(points, writer)
+}
+
+// WritePhaseSpaceJSON writes a phase space trajectory as JSON
+func WritePhaseSpaceJSON(points []PhaseSpacePoint, writer io.Writer) error {
+	return analytics.WritePhaseSpaceJSON(points, writer)
+}
+
+// RankResults ranks named simulation results by their score under obj, best first
+func RankResults(results map[string]Result, obj Objective) ScenarioRanking {
+	return analytics.RankResults(results, obj)
+}
+
+// SummarizeRun reduces a run of a batch to its key parameters and outcomes, see RunSummary
+func SummarizeRun(batch string, varied string, result Result) RunSummary {
+	return analytics.SummarizeRun(batch, varied, result)
+}
+
+// SensitivityRunSummaries returns a summary of every run of a one-at-a-time sensitivity analysis
+func SensitivityRunSummaries(results map[string]SensitivityResults) []RunSummary {
+	return analytics.SensitivityRunSummaries(results)
+}
+
+// PairwiseRunSummaries returns a summary of every run of a pairwise sensitivity grid
+func PairwiseRunSummaries(results PairwiseResults) []RunSummary {
+	return analytics.PairwiseRunSummaries(results)
+}
+
+// WriteRunSummariesCSV writes run summaries as CSV with one row per run, for regression analysis
+func WriteRunSummariesCSV(summaries []RunSummary, writer io.Writer) error {
+	return analytics.WriteRunSummariesCSV(summaries, writer)
+}
+
+// WriteRunSummariesArrow writes run summaries as an Apache Arrow IPC file with one row per run
+func WriteRunSummariesArrow(summaries []RunSummary, writer io.Writer) error {
+	return analytics.WriteRunSummariesArrow(summaries, writer)
+}
+
+// WriteDecisionsCSV writes a decision trail of reviewed optimizer decisions as CSV
+func WriteDecisionsCSV(decisions []OptimizerDecision, writer io.Writer) error {
+	return analytics.WriteDecisionsCSV(decisions, writer)
+}
+
+// ReadObservedCSV reads observed history from CSV with a TimeStep column and one column per metric
+func ReadObservedCSV(reader io.Reader) ([]ObservedSeries, error) {
+	return analytics.ReadObservedCSV(reader)
+}
+
+// LoadObservedCSV reads observed history from a CSV file, see ReadObservedCSV
+func LoadObservedCSV(path string) ([]ObservedSeries, error) {
+	return analytics.LoadObservedCSV(path)
+}
+
+// ObservableMetrics returns the built-in metrics that can be compared against observed history
+func ObservableMetrics() []string {
+	return analytics.ObservableMetrics()
+}
+
+// OpenJSONLStore opens the JSONL result store of the sweep identified by header at path,
+// creating it if it does not exist, and refuses one written by a different sweep
+func OpenJSONLStore(path string, header StoreHeader) (*JSONLStore, error) {
+	return store.OpenJSONLStore(path, header)
+}
+
+// Compression is a compression format of report output, see NewCompressedWriter
+type Compression = compress.Format
+
+const (
+	NoCompression = compress.None
+	Gzip          = compress.Gzip
+	Zstd          = compress.Zstd
+)
+
+// NewCompressedWriter returns a writer compressing what the report writers write to it into w
+// Close must be called to finish the compressed stream; it does not close w
+func NewCompressedWriter(w io.Writer, format Compression) (io.WriteCloser, error) {
+	return compress.NewWriter(w, format)
+}
+
+// NewDecompressingReader returns a reader of a report written compressed with gzip or zstd, or
+// of an uncompressed one as is
+func NewDecompressingReader(r io.Reader) (io.ReadCloser, error) {
+	return compress.NewReader(r)
+}
+
+// Sink is where reports are written: a local directory or an S3 or Google Cloud Storage bucket
+type Sink = sink.Sink
+
+// OpenSink returns the sink of an output target, as the CLI's -output flag takes it: an
+// s3://bucket/prefix or gs://bucket/prefix URI, with credentials from the environment, or
+// otherwise a local directory, which is created if it does not exist
+func OpenSink(target string) (Sink, error) {
+	return sink.Open(target)
+}
+
+// SweepTask is a single run of a sensitivity analysis, handed to the SweepExecutor of
+// SensitivityOptions
+type SweepTask = analytics.SweepTask
+
+// SweepExecutor runs the simulations of a sensitivity analysis in place of the local process
+type SweepExecutor = analytics.SweepExecutor
+
+// Coordinator is a SweepExecutor handing sensitivity runs to remote Workers, and the
+// http.Handler they poll
+type Coordinator = distributed.Coordinator
+
+// Worker runs the sensitivity runs of a Coordinator
+type Worker = distributed.Worker
+
+// NewCoordinator returns a coordinator with default leases, to be served over HTTP and set as
+// the Executor of SensitivityOptions
+func NewCoordinator() *Coordinator {
+	return distributed.NewCoordinator()
+}
+
+// NewWorker returns a worker of the coordinator served at the base URL
+func NewWorker(coordinator string) *Worker {
+	return distributed.NewWorker(coordinator)
+}
@@ -0,0 +1,180 @@
+package simulator
+
+import (
+	"workforce-ai-transition-simulator/internal/config"
+	"workforce-ai-transition-simulator/internal/scenarios"
+	"workforce-ai-transition-simulator/internal/types"
+)
+
+// Config describes a simulation; the zero value of every optional section leaves it off
+type Config = types.SimulationConfig
+
+// Sections of a Config
+type (
+	ExperienceDistribution     = types.ExperienceDistribution
+	CostCategoryDistribution   = types.CostCategoryDistribution
+	Region                     = types.Region
+	RegionTable                = types.RegionTable
+	ContractConfig             = types.ContractConfig
+	OverheadConfig             = types.OverheadConfig
+	MarketShock                = types.MarketShock
+	MarketShocks               = types.MarketShocks
+	AICostCurve                = types.AICostCurve
+	AIPricingConfig            = types.AIPricingConfig
+	VolumeDiscount             = types.VolumeDiscount
+	CashConfig                 = types.CashConfig
+	FinancingConfig            = types.FinancingConfig
+	AILearningSpeed            = types.AILearningSpeed
+	SharedLearningConfig       = types.SharedLearningConfig
+	TrainingConfig             = types.TrainingConfig
+	DataExposureConfig         = types.DataExposureConfig
+	Domain                     = types.Domain
+	DomainTable                = types.DomainTable
+	VendorTier                 = types.VendorTier
+	VendorTable                = types.VendorTable
+	VendorConfig               = types.VendorConfig
+	BackfillConfig             = types.BackfillConfig
+	WorkloadConfig             = types.WorkloadConfig
+	TaskDemand                 = types.TaskDemand
+	FailureRecoveryConfig      = types.FailureRecoveryConfig
+	FailureClassConfig         = types.FailureClassConfig
+	FailureClasses             = types.FailureClasses
+	ResilienceConfig           = types.ResilienceConfig
+	OrchestrationEffectiveness = types.OrchestrationEffectiveness
+	AgentProductivityTable     = types.AgentProductivityTable
+	OversightConfig            = types.OversightConfig
+	AssignmentConfig           = types.AssignmentConfig
+	AttritionConfig            = types.AttritionConfig
+	EquilibriumConfig          = types.EquilibriumConfig
+	RulesConfig                = types.RulesConfig
+	AlertRule                  = types.AlertRule
+)
+
+// Enumerations used in a Config
+type (
+	ExperienceLevel    = types.ExperienceLevel
+	CostCategory       = types.CostCategory
+	ContractType       = types.ContractType
+	RevenueScenario    = types.RevenueScenario
+	AttritionType      = types.AttritionType
+	ShockType          = types.ShockType
+	AssignmentStrategy = types.AssignmentStrategy
+	VendorPolicy       = types.VendorPolicy
+	FailureClass       = types.FailureClass
+	AlertComparator    = types.AlertComparator
+)
+
+const (
+	UniversityHire = types.UniversityHire
+	MidLevel       = types.MidLevel
+	Senior         = types.Senior
+	Executive      = types.Executive
+
+	HighCostUS   = types.HighCostUS
+	LowCostNonUS = types.LowCostNonUS
+
+	FullTime   = types.FullTime
+	Contractor = types.Contractor
+	PartTime   = types.PartTime
+
+	FlatRevenue     = types.FlatRevenue
+	ExplosiveGrowth = types.ExplosiveGrowth
+
+	NaturalAttrition = types.NaturalAttrition
+	HiringFreeze     = types.HiringFreeze
+	ReductionInForce = types.ReductionInForce
+
+	DemandShock     = types.DemandShock
+	AICostShock     = types.AICostShock
+	SalaryInflation = types.SalaryInflation
+
+	FillFirst   = types.FillFirst
+	RoundRobin  = types.RoundRobin
+	LeastLoaded = types.LeastLoaded
+
+	VendorMix         = types.VendorMix
+	MostCostEffective = types.MostCostEffective
+
+	SecurityIncident = types.SecurityIncident
+	ModelOutage      = types.ModelOutage
+	DataCorruption   = types.DataCorruption
+	ComplianceBreach = types.ComplianceBreach
+
+	Below     = types.Below
+	AtOrBelow = types.AtOrBelow
+	Above     = types.Above
+	AtOrAbove = types.AtOrAbove
+)
+
+// AlertMetrics returns the names of the metrics alert rules can watch
+func AlertMetrics() []string {
+	return types.AlertMetrics()
+}
+
+// ConfigVersion is the configuration schema version written by this simulator
+const ConfigVersion = types.ConfigVersion
+
+// ConfigError describes a configuration value that violates a constraint
+type ConfigError = types.ConfigError
+
+// FieldBounds describes the allowed range of a numeric configuration field
+type FieldBounds = types.FieldBounds
+
+// Errors wrapped by the simulator, for use with errors.Is
+var (
+	ErrInvalidConfig           = types.ErrInvalidConfig
+	ErrBudgetExceeded          = types.ErrBudgetExceeded
+	ErrNoOrchestrationCapacity = types.ErrNoOrchestrationCapacity
+	ErrBusinessOwnerRemoval    = types.ErrBusinessOwnerRemoval
+	ErrBusinessOwnerExists     = types.ErrBusinessOwnerExists
+	ErrNotFound                = types.ErrNotFound
+	ErrHiringFrozen            = types.ErrHiringFrozen
+)
+
+// DefaultFailureClasses returns the failure classes used when a Config gives none a weight
+func DefaultFailureClasses(recovery FailureRecoveryConfig) FailureClasses {
+	return types.DefaultFailureClasses(recovery)
+}
+
+// ConfigFieldBounds returns the bounds of every numeric configuration field
+func ConfigFieldBounds() []FieldBounds {
+	return types.ConfigFieldBounds()
+}
+
+// ConfigFormat is the encoding of a configuration file
+type ConfigFormat = config.Format
+
+const (
+	FormatJSON = config.FormatJSON
+	FormatYAML = config.FormatYAML
+)
+
+// LoadConfig reads a configuration from a JSON or YAML file, chosen by its extension
+// Older configurations are upgraded to ConfigVersion; the warnings describe the changes
+func LoadConfig(path string) (Config, []string, error) {
+	return config.Load(path)
+}
+
+// ParseConfig decodes a configuration from JSON or YAML data, upgrading it like LoadConfig
+func ParseConfig(data []byte, format ConfigFormat) (Config, []string, error) {
+	return config.Parse(data, format)
+}
+
+// ConfigSchema returns the JSON Schema describing configuration files
+func ConfigSchema() ([]byte, error) {
+	return config.JSONSchema()
+}
+
+// Scenario is a named, ready-made configuration
+type Scenario = scenarios.Scenario
+
+// Scenarios returns every scenario template, ordered by name
+func Scenarios() []Scenario {
+	return scenarios.All()
+}
+
+// GetScenario returns the scenario template with the given name
+// Returns an error wrapping ErrNotFound if there is none
+func GetScenario(name string) (Scenario, error) {
+	return scenarios.Get(name)
+}
@@ -0,0 +1,22 @@
+package simulator
+
+import "workforce-ai-transition-simulator/internal/environment"
+
+// Environment exposes the simulator as a reinforcement learning environment: Reset starts an
+// episode and Step applies a policy's hire and release action for one time step
+type Environment = environment.Environment
+
+// Types of the environment's episodes
+type (
+	EnvironmentOptions = environment.Options
+	Observation        = environment.Observation
+	Action             = environment.Action
+)
+
+// DefaultEpisodeLength is the episode length when EnvironmentOptions.MaxTimeSteps is not set
+const DefaultEpisodeLength = environment.DefaultMaxTimeSteps
+
+// NewEnvironment creates an environment for config
+func NewEnvironment(config Config, options EnvironmentOptions) (*Environment, error) {
+	return environment.New(config, options)
+}
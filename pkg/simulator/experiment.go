@@ -0,0 +1,31 @@
+package simulator
+
+import (
+	"io"
+
+	"workforce-ai-transition-simulator/internal/experiment"
+)
+
+// Experiment groups a base configuration and its variants into a registry of reproducible runs
+type Experiment = experiment.Experiment
+
+// ExperimentRun is a single member run of an Experiment
+type ExperimentRun = experiment.Run
+
+// BaseVariant is the variant name of an experiment's base configuration
+const BaseVariant = experiment.BaseVariant
+
+// NewExperiment creates an empty experiment whose run seeds are derived from masterSeed
+func NewExperiment(name string, masterSeed int64, maxTimeSteps int) *Experiment {
+	return experiment.New(name, masterSeed, maxTimeSteps)
+}
+
+// ReadExperiment reads an experiment registry previously written by Experiment.Write
+func ReadExperiment(reader io.Reader) (*Experiment, error) {
+	return experiment.Read(reader)
+}
+
+// LoadExperiment reads an experiment registry from a file
+func LoadExperiment(path string) (*Experiment, error) {
+	return experiment.Load(path)
+}
@@ -0,0 +1,21 @@
+package simulator
+
+import (
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/extension"
+)
+
+// Behaviors replaces the attrition, revenue or optimization logic of a simulation with custom
+// functions; nil functions keep the built-in logic. An extension plugin exports one as a
+// variable named Behaviors
+type Behaviors = controller.Behaviors
+
+// WithBehaviors replaces the simulation logic behaviors sets
+func WithBehaviors(behaviors Behaviors) Option {
+	return controller.WithBehaviors(behaviors)
+}
+
+// LoadExtension opens the Go plugin at path and returns the behaviors it exports
+func LoadExtension(path string) (Behaviors, error) {
+	return extension.Load(path)
+}
@@ -0,0 +1,34 @@
+package simulator
+
+import (
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/objective"
+)
+
+// Objective scores simulation outcomes, per time step and per run, for the optimizer, the
+// reinforcement learning environment and scenario rankings; higher is better
+type Objective = objective.Objective
+
+// Built-in objectives
+type (
+	ProfitObjective          = objective.Profit
+	JobPreservationObjective = objective.JobPreservation
+	ResilienceObjective      = objective.Resilience
+	CompositionObjective     = objective.CompositionTarget
+)
+
+// ParseObjective returns the built-in objective named by spec, optionally with a parameter
+// after "=", e.g. "job-preservation=0.15"
+func ParseObjective(spec string) (Objective, error) {
+	return objective.Parse(spec)
+}
+
+// ObjectiveNames returns the names of the built-in objectives
+func ObjectiveNames() []string {
+	return objective.Names()
+}
+
+// WithObjective has the optimizer hire only as many of its proposed AI agents as serve obj best
+func WithObjective(obj Objective) Option {
+	return controller.WithObjective(obj)
+}
@@ -0,0 +1,91 @@
+package simulator
+
+import "workforce-ai-transition-simulator/internal/types"
+
+// Result is the outcome of a simulation run, including its full time series
+type Result = types.SimulationResult
+
+// State is the organization at one time step of a run
+type State = types.SimulationState
+
+// Parts of a State or Result
+type (
+	WorkforceComposition    = types.WorkforceComposition
+	HumanComposition        = types.HumanComposition
+	AgentComposition        = types.AgentComposition
+	GroupSubtotals          = types.GroupSubtotals
+	CostBreakdown           = types.CostBreakdown
+	RevenueAttribution      = types.RevenueAttribution
+	FinancingState          = types.FinancingState
+	MarketConditions        = types.MarketConditions
+	TaskUtilization         = types.TaskUtilization
+	EquilibriumReason       = types.EquilibriumReason
+	EquilibriumConfirmation = types.EquilibriumConfirmation
+	FailureRecord           = types.FailureRecord
+	OptimizerDecision       = types.OptimizerDecision
+	Alert                   = types.Alert
+	Event                   = types.SimulationEvent
+	RunMetadata             = types.RunMetadata
+	RunLineage              = types.RunLineage
+	HumanWorker             = types.HumanWorker
+	AIAgent                 = types.AIAgent
+)
+
+// EquilibriumReasonCode classifies why a run reached equilibrium or stopped without it
+type EquilibriumReasonCode = types.EquilibriumReasonCode
+
+const (
+	EquilibriumNotReached        = types.EquilibriumNotReached
+	OrchestrationCapacityReached = types.OrchestrationCapacityReached
+	BudgetExhausted              = types.BudgetExhausted
+	CostEffectivenessEquilibrium = types.CostEffectivenessEquilibrium
+	CompositionStable            = types.CompositionStable
+	MaxStepsReached              = types.MaxStepsReached
+	Insolvent                    = types.Insolvent
+	OrganizationCollapsed        = types.OrganizationCollapsed
+	OnlyOwnerRemains             = types.OnlyOwnerRemains
+	LimitExceeded                = types.LimitExceeded
+)
+
+// RunStatus classifies how a run ended: equilibrium, the step limit, a terminal state or an abort
+type RunStatus = types.RunStatus
+
+const (
+	StatusUnknown          = types.StatusUnknown
+	StatusEquilibrium      = types.StatusEquilibrium
+	StatusMaxStepsReached  = types.StatusMaxStepsReached
+	StatusInsolvent        = types.StatusInsolvent
+	StatusCollapsed        = types.StatusCollapsed
+	StatusOnlyOwnerRemains = types.StatusOnlyOwnerRemains
+	StatusAborted          = types.StatusAborted
+)
+
+// DecisionVerdict is how a reviewer resolved a workforce change proposed by the optimizer
+type DecisionVerdict = types.DecisionVerdict
+
+const (
+	DecisionApproved = types.DecisionApproved
+	DecisionModified = types.DecisionModified
+	DecisionRejected = types.DecisionRejected
+)
+
+// EventType classifies entries in the event log of a run
+type EventType = types.EventType
+
+const (
+	AttritionEvent           = types.AttritionEvent
+	AgentHiredEvent          = types.AgentHiredEvent
+	AgentReleasedEvent       = types.AgentReleasedEvent
+	AgentLevelUpEvent        = types.AgentLevelUpEvent
+	CatastrophicFailureEvent = types.CatastrophicFailureEvent
+	EquilibriumEvent         = types.EquilibriumEvent
+	HumanHiredEvent          = types.HumanHiredEvent
+	OwnerSuccessionEvent     = types.OwnerSuccessionEvent
+	OversightEnforcedEvent   = types.OversightEnforcedEvent
+	MarketShockEvent         = types.MarketShockEvent
+	InsolvencyEvent          = types.InsolvencyEvent
+	AgentsRebalancedEvent    = types.AgentsRebalancedEvent
+	TerminalStateEvent       = types.TerminalStateEvent
+	RuleEvent                = types.RuleEvent
+	AlertEvent               = types.AlertEvent
+)
@@ -0,0 +1,171 @@
+// Package simulator is the public API of the workforce AI transition simulator
+// The implementation lives in internal packages; this package re-exports the configuration
+// types, the simulation controller and the analytics entry points under stable names so other
+// modules can use the simulator as a library. Types are aliases, so values pass freely between
+// this package and the command line tools
+package simulator
+
+import (
+	"io"
+	"time"
+	"workforce-ai-transition-simulator/internal/controller"
+	"workforce-ai-transition-simulator/internal/events"
+	"workforce-ai-transition-simulator/internal/publish"
+	"workforce-ai-transition-simulator/internal/workforce"
+)
+
+// Controller runs a simulation one time step at a time or until equilibrium
+type Controller = controller.SimulationController
+
+// Option customizes a Controller built by New
+type Option = controller.Option
+
+// Snapshot is the state of a run as of its latest completed time step, which other goroutines may
+// read while the run goes on, see Controller.Snapshot
+type Snapshot = controller.Snapshot
+
+// EventProcessor, EconomicModel and WorkforceManager are the components of a Controller that
+// options can replace with custom implementations
+type (
+	EventProcessor   = controller.EventProcessor
+	EconomicModel    = controller.EconomicModel
+	WorkforceManager = controller.WorkforceManager
+)
+
+// Types in the signatures of the component interfaces
+type (
+	CatastrophicFailure = events.CatastrophicFailure
+	FailureOutcome      = events.FailureOutcome
+	WorkforceChange     = events.WorkforceChange
+	Succession          = workforce.Succession
+	WorkforceSnapshot   = workforce.Snapshot
+)
+
+// DecisionApprover reviews the workforce changes the optimizer proposes, see WithDecisionApprover
+type (
+	DecisionApprover = controller.DecisionApprover
+	DecisionReview   = controller.DecisionReview
+)
+
+// WorkforcePolicy decides the AI agents hired and released at each time step in place of the
+// optimizer, see WithWorkforcePolicy
+type (
+	WorkforcePolicy = controller.WorkforcePolicy
+	WorkforceAction = controller.WorkforceAction
+)
+
+// Observer is notified of every time step and alert of a run, see WithObserver
+type Observer = controller.Observer
+
+// Limits abort runs that take too long or hold too much state, see WithLimits
+type Limits = controller.Limits
+
+// InvariantViolation is an invariant of the model found violated by WithInvariantChecks
+type InvariantViolation = controller.InvariantViolation
+
+// ErrInvariantViolated is wrapped by every InvariantViolation
+var ErrInvariantViolated = controller.ErrInvariantViolated
+
+// Publisher delivers messages to a Kafka topic or NATS subject, see OpenPublisher
+type Publisher = publish.Publisher
+
+// PublishStream is an Observer publishing a run's states, events and alerts as JSON
+// PublishedMessages, see NewPublishStream
+type (
+	PublishStream    = publish.Stream
+	PublishedMessage = publish.Message
+)
+
+// Checkpoint captures a simulation mid-run so it can be resumed or used as a warm start
+type Checkpoint = controller.Checkpoint
+
+// New creates a Controller for config whose random streams are derived from seed
+func New(config Config, seed int64, opts ...Option) *Controller {
+	return controller.NewSimulationController(config, seed, opts...)
+}
+
+// Run simulates config with seed until equilibrium or maxTimeSteps, whichever comes first
+func Run(config Config, seed int64, maxTimeSteps int) (Result, error) {
+	return New(config, seed).RunUntilEquilibrium(maxTimeSteps)
+}
+
+// WithEventProcessor substitutes a custom event processor for the default one
+func WithEventProcessor(processor EventProcessor) Option {
+	return controller.WithEventProcessor(processor)
+}
+
+// WithEconomicModel substitutes a custom economic model for the default one
+func WithEconomicModel(model EconomicModel) Option {
+	return controller.WithEconomicModel(model)
+}
+
+// WithWorkforceManager substitutes a custom workforce manager for the default one
+func WithWorkforceManager(manager WorkforceManager) Option {
+	return controller.WithWorkforceManager(manager)
+}
+
+// WithDecisionApprover holds every workforce change the optimizer proposes for review by
+// approver and records the decision trail in the result
+func WithDecisionApprover(approver DecisionApprover) Option {
+	return controller.WithDecisionApprover(approver)
+}
+
+// WithObserver notifies observer of every time step and alert of the run
+func WithObserver(observer Observer) Option {
+	return controller.WithObserver(observer)
+}
+
+// WithLimits aborts the run with StatusAborted once it exceeds limits
+func WithLimits(limits Limits) Option {
+	return controller.WithLimits(limits)
+}
+
+// WithInvariantChecks checks the invariants of the model after every time step, failing the run
+// with an InvariantViolation on the first one violated
+func WithInvariantChecks() Option {
+	return controller.WithInvariantChecks()
+}
+
+// WithPacing spaces the time steps of the run at least interval apart, e.g. for live demos
+func WithPacing(interval time.Duration) Option {
+	return controller.WithPacing(interval)
+}
+
+// OpenPublisher connects to a kafka://broker:9092/topic or nats://host:4222/subject target
+func OpenPublisher(target string) (Publisher, error) {
+	return publish.Open(target)
+}
+
+// NewPublishStream returns an Observer publishing the run with the given ID to publisher
+// Close must be called after the run; it closes publisher and returns the first error met
+func NewPublishStream(publisher Publisher, runID string) *PublishStream {
+	return publish.NewStream(publisher, runID)
+}
+
+// WithWorkforcePolicy lets policy decide the AI agents hired and released at every time step
+// instead of the optimizer
+func WithWorkforcePolicy(policy WorkforcePolicy) Option {
+	return controller.WithWorkforcePolicy(policy)
+}
+
+// Restore recreates the Controller a checkpoint was taken from, which continues exactly as
+// the original would have, given the options it was created with
+func Restore(checkpoint Checkpoint, config Config, opts ...Option) (*Controller, error) {
+	return controller.RestoreSimulationController(checkpoint, config, opts...)
+}
+
+// WarmStart starts a new run of config from the workforce captured in a checkpoint
+func WarmStart(checkpoint Checkpoint, config Config, opts ...Option) (*Controller, error) {
+	return controller.WarmStartSimulationController(checkpoint, config, opts...)
+}
+
+// WriteCheckpoint writes a checkpoint as JSON
+func WriteCheckpoint(checkpoint Checkpoint, writer io.Writer) error {
+	return controller.WriteCheckpoint(checkpoint, writer)
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint, migrating older ones
+// Returns the checkpoint and any migration warnings
+func ReadCheckpoint(reader io.Reader) (Checkpoint, []string, error) {
+	return controller.ReadCheckpoint(reader)
+}
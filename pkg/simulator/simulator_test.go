@@ -0,0 +1,70 @@
+package simulator_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"workforce-ai-transition-simulator/pkg/simulator"
+)
+
+func exampleConfig() simulator.Config {
+	return simulator.Config{
+		Version:                  simulator.ConfigVersion,
+		InitialHumans:            20,
+		ExperienceDistribution:   simulator.ExperienceDistribution{UniversityHire: 40, MidLevel: 30, Senior: 20, Executive: 10},
+		CostCategoryDistribution: simulator.CostCategoryDistribution{HighCostUS: 60, LowCostNonUS: 40},
+		FixedBudget:              8000000,
+		RevenueScenario:          simulator.FlatRevenue,
+		AILearningSpeeds:         simulator.AILearningSpeed{UniversityToMid: 10, MidToSenior: 15, SeniorToExecutive: 20},
+		AttritionConfig: simulator.AttritionConfig{
+			Type:               simulator.NaturalAttrition,
+			NaturalRate:        10,
+			ForcedAcceleration: 1,
+		},
+		CatastrophicFailureRate: 0.02,
+		TimeZoneInefficiency:    0.1,
+	}
+}
+
+func TestRunMatchesController(t *testing.T) {
+	config := exampleConfig()
+	result, err := simulator.Run(config, 42, 50)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	again, err := simulator.New(config, 42).RunUntilEquilibrium(50)
+	if err != nil {
+		t.Fatalf("RunUntilEquilibrium failed: %v", err)
+	}
+	if len(result.TimeSeries) == 0 || len(result.TimeSeries) != len(again.TimeSeries) {
+		t.Fatalf("Expected identical runs, got %d and %d steps", len(result.TimeSeries), len(again.TimeSeries))
+	}
+	if result.EquilibriumState.TotalCost != again.EquilibriumState.TotalCost {
+		t.Errorf("Expected identical final cost, got %.2f and %.2f", result.EquilibriumState.TotalCost, again.EquilibriumState.TotalCost)
+	}
+
+	var csv bytes.Buffer
+	if err := simulator.NewAnalytics().WriteReportCSV(result, &csv); err != nil {
+		t.Fatalf("WriteReportCSV failed: %v", err)
+	}
+	if csv.Len() == 0 {
+		t.Error("Expected a CSV report")
+	}
+}
+
+func TestParseConfigAndScenarios(t *testing.T) {
+	config, _, err := simulator.ParseConfig([]byte(`{"Version": 1, "InitialHumans": 12, "FixedBudget": 3000000}`), simulator.FormatJSON)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if config.InitialHumans != 12 {
+		t.Errorf("Expected 12 initial humans, got %d", config.InitialHumans)
+	}
+
+	if len(simulator.Scenarios()) == 0 {
+		t.Fatal("Expected scenario templates")
+	}
+	if _, err := simulator.GetScenario("no-such-scenario"); !errors.Is(err, simulator.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown scenario, got %v", err)
+	}
+}
@@ -25,7 +25,7 @@ go test ./...
 if %ERRORLEVEL% EQU 0 (
     echo Setup complete! All tests passed.
     echo.
-    echo To build the simulator, run: go build -o simulator.exe ./cmd/simulator
+    echo To build the simulator, run: go build -o wfesim.exe ./cmd/wfesim
     echo To run tests, run: go test ./...
 ) else (
     echo Setup complete, but some tests failed. Please review the output above.
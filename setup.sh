@@ -26,7 +26,7 @@ go test ./...
 if [ $? -eq 0 ]; then
     echo "Setup complete! All tests passed."
     echo ""
-    echo "To build the simulator, run: go build -o simulator ./cmd/simulator"
+    echo "To build the simulator, run: go build -o wfesim ./cmd/wfesim"
     echo "To run tests, run: go test ./..."
 else
     echo "Setup complete, but some tests failed. Please review the output above."